@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // garante os fusos horários mesmo em imagens sem zoneinfo
 
 	"github.com/joho/godotenv"
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/config"
@@ -45,13 +46,19 @@ func main() {
 	}
 
 	// Setup do Router
-	servidor := server.NewServer(db)
+	servidor := server.NewServer(db, cfg)
 
 	// Graceful Shutdown
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.ServerPort),
 		Handler: servidor.Router,
 	}
+	srv.RegisterOnShutdown(servidor.EncerrarStreams)
+
+	// Rotinas em segundo plano (tarefas recorrentes, atrasos e lembretes)
+	if cfg.AgendadorAtivo {
+		servidor.Agendador.Iniciar(context.Background())
+	}
 
 	go func() {
 		logrus.Infof("Servidor iniciado na porta %s", cfg.ServerPort)
@@ -67,9 +74,14 @@ func main() {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	// mesmo se o HTTP não terminar a tempo, o agendador e o MQTT ainda precisam ser encerrados
 	if err := srv.Shutdown(ctx); err != nil {
-		logrus.Fatal("Erro no desligamento do servidor:", err)
+		logrus.Warn("Erro no desligamento do servidor:", err)
+	}
+	if err := servidor.Agendador.Parar(ctx); err != nil {
+		logrus.Warn("Erro no desligamento do agendador:", err)
 	}
+	servidor.Encerrar()
 
 	logrus.Println("Servidor desligado com sucesso.")
 }
@@ -0,0 +1,56 @@
+// Comando seed-geneticas carrega o catálogo inicial de genéticas no banco. Genéticas que já
+// existem (mesmo nome e breeder, com tolerância a grafias diferentes) são ignoradas, então o
+// comando pode ser executado mais de uma vez.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/config"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/database"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/database/seeds"
+	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	caminho := flag.String("arquivo", "", "catálogo JSON a carregar no lugar do catálogo inicial embutido")
+	flag.Parse()
+
+	// Carrega as variáveis de ambiente do arquivo .env
+	if err := godotenv.Load(); err != nil {
+		log.Println("Arquivo .env não encontrado, usando variáveis de ambiente do sistema")
+	}
+
+	cfg := config.LoadConfig()
+
+	db, err := database.NewDatabase(cfg)
+	if err != nil {
+		logrus.Fatalf("Falha ao conectar ao banco de dados: %v", err)
+	}
+
+	var catalogo io.Reader = bytes.NewReader(seeds.GeneticasIniciais)
+	if *caminho != "" {
+		arquivo, err := os.Open(*caminho)
+		if err != nil {
+			logrus.Fatalf("Falha ao abrir o catálogo %s: %v", *caminho, err)
+		}
+		defer arquivo.Close()
+		catalogo = arquivo
+	}
+
+	geneticaService := service.NewGeneticaService(database.NewGeneticaRepositorio(db.DB))
+	resultado, err := geneticaService.ImportarJSON(catalogo)
+	if err != nil {
+		logrus.Fatalf("Falha ao carregar o catálogo de genéticas: %v", err)
+	}
+	for _, ignorada := range resultado.Ignoradas {
+		logrus.Infof("Ignorada %s (%s): %s", ignorada.Nome, ignorada.Origem, ignorada.Motivo)
+	}
+	logrus.Infof("Catálogo de genéticas carregado: %d criadas, %d ignoradas", len(resultado.Criadas), len(resultado.Ignoradas))
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 
@@ -18,6 +19,43 @@ type Config struct {
 	DBPassword string
 	DBName     string
 	ServerPort string
+
+	// Fuso horário usado para avaliar horários locais (janelas de alerta, agendas)
+	FusoHorario string
+
+	// Servidor SMTP para o canal de notificação por e-mail (desabilitado se SMTPHost estiver vazio)
+	SMTPHost      string
+	SMTPPort      string
+	SMTPUsuario   string
+	SMTPSenha     string
+	SMTPRemetente string
+
+	// Bot do Telegram para o canal de notificação (desabilitado se TelegramToken estiver vazio)
+	TelegramURL   string
+	TelegramToken string
+
+	// Ponte MQTT para sensores e atuadores (desabilitada se MQTTBroker estiver vazio)
+	MQTTBroker   string // ex.: tcp://localhost:1883
+	MQTTClientID string
+	MQTTUsuario  string
+	MQTTSenha    string
+	// MQTTTopicos mapeia tópicos para ambientes: "filtro|ambiente|formato[|metrica]" separados por ";"
+	MQTTTopicos string
+	// MQTTPrefixosComando lista, separados por vírgula, os prefixos de tópico em que comandos podem ser publicados
+	MQTTPrefixosComando string
+
+	// Provedor de clima dos ambientes externos: ClimaArquivo (JSON local) tem precedência sobre ClimaURL (Open-Meteo)
+	ClimaURL     string
+	ClimaArquivo string
+
+	// Agendador de rotinas em segundo plano (séries recorrentes, atrasos e lembretes de tarefas).
+	// Com várias réplicas, advisory locks do Postgres evitam execuções simultâneas.
+	AgendadorAtivo     bool
+	AgendadorIntervalo string // duração Go, ex.: 1m
+	// TarefasHorizonteDias é até quantos dias à frente as ocorrências recorrentes são criadas
+	TarefasHorizonteDias string
+	// TarefasAntecedenciaLembrete é quanto antes da data agendada o lembrete é enviado (duração Go)
+	TarefasAntecedenciaLembrete string
 }
 
 func LoadConfig() *Config {
@@ -52,10 +90,47 @@ func LoadConfig() *Config {
 		}
 	}
 
+	config.FusoHorario = getEnv("FUSO_HORARIO", "America/Sao_Paulo")
+	config.SMTPHost = getEnv("SMTP_HOST", "")
+	config.SMTPPort = getEnv("SMTP_PORT", "587")
+	config.SMTPUsuario = getEnv("SMTP_USUARIO", "")
+	config.SMTPSenha = getEnv("SMTP_SENHA", "")
+	config.SMTPRemetente = getEnv("SMTP_REMETENTE", "")
+	config.TelegramURL = getEnv("TELEGRAM_URL", "https://api.telegram.org")
+	config.TelegramToken = getEnv("TELEGRAM_BOT_TOKEN", "")
+	config.MQTTBroker = getEnv("MQTT_BROKER", "")
+	config.MQTTClientID = getEnv("MQTT_CLIENT_ID", "cultivo-api")
+	config.MQTTUsuario = getEnv("MQTT_USUARIO", "")
+	config.MQTTSenha = getEnv("MQTT_SENHA", "")
+	config.MQTTTopicos = getEnv("MQTT_TOPICOS", "cultivo/ambientes/+/microclima|+|json")
+	config.MQTTPrefixosComando = getEnv("MQTT_PREFIXOS_COMANDO", "cmnd/,cultivo/comandos/")
+	config.ClimaURL = getEnv("CLIMA_URL", "https://api.open-meteo.com/v1/forecast")
+	config.ClimaArquivo = getEnv("CLIMA_ARQUIVO", "")
+	config.AgendadorAtivo = getEnv("AGENDADOR_ATIVO", "true") != "false"
+	config.AgendadorIntervalo = getEnv("AGENDADOR_INTERVALO", "1m")
+	config.TarefasHorizonteDias = getEnv("TAREFAS_HORIZONTE_DIAS", "14")
+	config.TarefasAntecedenciaLembrete = getEnv("TAREFAS_ANTECEDENCIA_LEMBRETE", "1h")
+
 	log.Println(config)
 	return config
 }
 
+// segredoOculto substitui senhas e tokens quando a configuração é impressa
+const segredoOculto = "***"
+
+// String imprime a configuração sem os segredos, para o log da inicialização
+func (c Config) String() string {
+	// o tipo local não herda String, evitando a recursão no Sprintf
+	type configSemSegredos Config
+	copia := configSemSegredos(c)
+	for _, segredo := range []*string{&copia.DBPassword, &copia.SMTPSenha, &copia.TelegramToken, &copia.MQTTSenha} {
+		if *segredo != "" {
+			*segredo = segredoOculto
+		}
+	}
+	return fmt.Sprintf("%+v", copia)
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_String(t *testing.T) {
+	t.Run("Success - Oculta os Segredos", func(t *testing.T) {
+		cfg := &Config{
+			DBHost:        "localhost",
+			DBPassword:    "senha-do-banco",
+			SMTPHost:      "smtp.exemplo.com",
+			SMTPSenha:     "senha-smtp",
+			TelegramToken: "123456:bot-token",
+			MQTTBroker:    "tcp://broker:1883",
+			MQTTSenha:     "senha-mqtt",
+		}
+
+		impresso := cfg.String()
+
+		assert.Contains(t, impresso, "smtp.exemplo.com")
+		assert.NotContains(t, impresso, "senha-do-banco")
+		assert.NotContains(t, impresso, "senha-smtp")
+		assert.Contains(t, impresso, "tcp://broker:1883")
+		assert.NotContains(t, impresso, "senha-mqtt")
+		assert.NotContains(t, impresso, "bot-token")
+		assert.Contains(t, impresso, "DBPassword:***")
+	})
+
+	t.Run("Success - Segredo Vazio Continua Vazio", func(t *testing.T) {
+		assert.Contains(t, Config{}.String(), "SMTPSenha: ")
+	})
+}
@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type AlertaController struct {
+	servico service.AlertaService
+}
+
+func NewAlertaController(servico service.AlertaService) *AlertaController {
+	return &AlertaController{servico}
+}
+
+// CriarRegra godoc
+// @Summary      Cria uma regra de alerta para o ambiente
+// @Description  Define métrica, comparação, limite, duração mínima, janela de horário, estágios e canais de notificação
+// @Tags         alertas
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                 true  "ID do Ambiente"
+// @Param        regra  body      dto.RegraAlertaDTO  true  "Regra de alerta"
+// @Success      201    {object}  entity.RegraAlerta
+// @Failure      400    {object}  map[string]string
+// @Failure      401    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/regras-alerta [post]
+func (c *AlertaController) CriarRegra(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var regraDto dto.RegraAlertaDTO
+	if err := ctx.ShouldBindJSON(&regraDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar regra de alerta")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	regra, err := c.servico.CriarRegra(ambienteID, usuarioID, &regraDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao criar regra de alerta")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, regra)
+}
+
+// ListarRegras godoc
+// @Summary      Lista as regras de alerta do ambiente
+// @Description  Apenas as regras do usuário autenticado
+// @Tags         alertas
+// @Produce      json
+// @Param        id   path      int  true  "ID do Ambiente"
+// @Success      200  {array}   entity.RegraAlerta
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/regras-alerta [get]
+func (c *AlertaController) ListarRegras(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	regras, err := c.servico.ListarRegras(ambienteID, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao listar regras de alerta")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, regras)
+}
+
+// BuscarRegra godoc
+// @Summary      Busca uma regra de alerta por ID
+// @Tags         alertas
+// @Produce      json
+// @Param        id   path      int  true  "ID da Regra de Alerta"
+// @Success      200  {object}  entity.RegraAlerta
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/regras-alerta/{id} [get]
+func (c *AlertaController) BuscarRegra(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	regra, err := c.servico.BuscarRegra(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Regra de alerta não encontrada", "Erro interno ao buscar regra de alerta")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, regra)
+}
+
+// AtualizarRegra godoc
+// @Summary      Atualiza uma regra de alerta
+// @Description  Substitui a configuração da regra; a contagem de duração da condição é reiniciada
+// @Tags         alertas
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                 true  "ID da Regra de Alerta"
+// @Param        regra  body      dto.RegraAlertaDTO  true  "Regra de alerta"
+// @Success      200    {object}  entity.RegraAlerta
+// @Failure      400    {object}  map[string]string
+// @Failure      401    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /api/v1/regras-alerta/{id} [put]
+func (c *AlertaController) AtualizarRegra(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var regraDto dto.RegraAlertaDTO
+	if err := ctx.ShouldBindJSON(&regraDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar regra de alerta")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	regra, err := c.servico.AtualizarRegra(id, usuarioID, &regraDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Regra de alerta não encontrada", "Erro interno ao atualizar regra de alerta")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, regra)
+}
+
+// DeletarRegra godoc
+// @Summary      Remove uma regra de alerta
+// @Tags         alertas
+// @Param        id   path      int  true  "ID da Regra de Alerta"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/regras-alerta/{id} [delete]
+func (c *AlertaController) DeletarRegra(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.DeletarRegra(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Regra de alerta não encontrada", "Erro interno ao deletar regra de alerta")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListarAlertas godoc
+// @Summary      Lista os alertas do usuário
+// @Description  Retorna os alertas do usuário autenticado, dos mais recentes para os mais antigos
+// @Tags         alertas
+// @Produce      json
+// @Param        status       query     string  false  "Filtra por status: aberto, reconhecido ou resolvido"
+// @Param        ambiente_id  query     int     false  "Filtra por ambiente"
+// @Param        page         query     int     false  "Número da página (padrão: 1)"
+// @Param        limit        query     int     false  "Limite de itens por página (padrão: 10)"
+// @Success      200          {object}  dto.PaginatedResponse{data=[]entity.Alerta}
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/alertas [get]
+func (c *AlertaController) ListarAlertas(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaAlertasDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar alertas")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	alertas, total, err := c.servico.ListarAlertas(usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Alerta não encontrado", "Erro interno ao listar alertas")
+		return
+	}
+
+	dataBytes, err := json.Marshal(alertas)
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao serializar alertas para resposta paginada")
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Erro interno ao listar alertas", err.Error())
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, &dto.PaginatedResponse{
+		Data:  dataBytes,
+		Total: total,
+		Page:  consulta.Page,
+		Limit: consulta.Limit,
+	})
+}
+
+// Reconhecer godoc
+// @Summary      Reconhece um alerta aberto
+// @Description  Marca o alerta como visto; ele continua ativo até a condição deixar de valer ou ser resolvido
+// @Tags         alertas
+// @Produce      json
+// @Param        id   path      int  true  "ID do Alerta"
+// @Success      200  {object}  entity.Alerta
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/alertas/{id}/reconhecer [post]
+func (c *AlertaController) Reconhecer(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	alerta, err := c.servico.Reconhecer(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Alerta não encontrado", "Erro interno ao reconhecer alerta")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, alerta)
+}
+
+// Resolver godoc
+// @Summary      Resolve um alerta manualmente
+// @Tags         alertas
+// @Produce      json
+// @Param        id   path      int  true  "ID do Alerta"
+// @Success      200  {object}  entity.Alerta
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/alertas/{id}/resolver [post]
+func (c *AlertaController) Resolver(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	alerta, err := c.servico.Resolver(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Alerta não encontrado", "Erro interno ao resolver alerta")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, alerta)
+}
+
+func (c *AlertaController) responderErro(ctx *gin.Context, err error, naoEncontrado, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, naoEncontrado, err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
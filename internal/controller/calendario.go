@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// tamanhoMaximoCalendario limita o corpo das importações de arquivos .ics
+const tamanhoMaximoCalendario = 1 << 20
+
+type CalendarioController struct {
+	servico service.CalendarioService
+}
+
+func NewCalendarioController(servico service.CalendarioService) *CalendarioController {
+	return &CalendarioController{servico}
+}
+
+// GerarToken godoc
+// @Summary      Gera o endereço secreto do feed iCalendar
+// @Description  O token é exibido só nesta resposta; gerar um novo invalida o endereço anterior
+// @Tags         calendario
+// @Produce      json
+// @Success      201  {object}  dto.TokenCalendarioDTO
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/calendario/token [post]
+func (c *CalendarioController) GerarToken(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	token, err := c.servico.GerarToken(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao gerar token do calendário")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, dto.TokenCalendarioDTO{
+		Token: token,
+		URL:   urlFeedCalendario(ctx, token),
+	})
+}
+
+// RevogarToken godoc
+// @Summary      Desativa o feed iCalendar
+// @Tags         calendario
+// @Success      204
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/calendario/token [delete]
+func (c *CalendarioController) RevogarToken(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.servico.RevogarToken(usuarioID); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao revogar token do calendário")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// Feed godoc
+// @Summary      Feed iCalendar (RFC 5545) do usuário
+// @Description  Tarefas abertas (as recorrentes com RRULE), lembretes, início dos estágios e janela de colheita prevista das plantas ativas. Não exige autenticação: o token secreto identifica o usuário
+// @Tags         calendario
+// @Produce      text/calendar
+// @Param        token  path      string  true  "Token do feed, com ou sem .ics"
+// @Success      200    {string}  string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /api/v1/calendario/feed/{token} [get]
+func (c *CalendarioController) Feed(ctx *gin.Context) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	calendario, err := c.servico.Feed(token)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao gerar calendário")
+		return
+	}
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", calendario)
+}
+
+// Importar godoc
+// @Summary      Importa um arquivo .ics como tarefas
+// @Description  Aceita o arquivo no corpo (text/calendar) ou em multipart no campo "arquivo", até 1 MB. O tipo da tarefa vem de X-CULTIVO-TIPO ou da primeira categoria; recorrências diárias e semanais viram tarefas recorrentes
+// @Tags         calendario
+// @Accept       plain
+// @Accept       mpfd
+// @Produce      json
+// @Param        arquivo  formData  file  false  "Arquivo .ics (multipart)"
+// @Success      201      {object}  dto.ImportacaoCalendarioDTO
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      413      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/calendario/importar [post]
+func (c *CalendarioController) Importar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, tamanhoMaximoCalendario)
+	var corpo io.Reader = ctx.Request.Body
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		arquivo, err := ctx.FormFile("arquivo")
+		if err != nil {
+			c.responderErro(ctx, err, "Erro ao ler o arquivo .ics enviado")
+			return
+		}
+		aberto, err := arquivo.Open()
+		if err != nil {
+			c.responderErro(ctx, err, "Erro ao ler o arquivo .ics enviado")
+			return
+		}
+		defer aberto.Close()
+		corpo = aberto
+	}
+
+	resultado, err := c.servico.Importar(usuarioID, corpo)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao importar calendário")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, resultado)
+}
+
+// urlFeedCalendario monta o endereço do feed a partir da rota do token e do host da requisição
+func urlFeedCalendario(ctx *gin.Context, token string) string {
+	esquema := "http"
+	if ctx.Request.TLS != nil || ctx.GetHeader("X-Forwarded-Proto") == "https" {
+		esquema = "https"
+	}
+	base := strings.TrimSuffix(ctx.FullPath(), "/token")
+	return esquema + "://" + ctx.Request.Host + base + "/feed/" + token + ".ics"
+}
+
+func (c *CalendarioController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	var errTamanho *http.MaxBytesError
+	switch {
+	case errors.As(err, &errTamanho):
+		utils.RespondWithError(ctx, http.StatusRequestEntityTooLarge, "Arquivo maior que o limite de importação", err.Error())
+	case errors.Is(err, http.ErrMissingFile):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Campo \"arquivo\" ausente", err.Error())
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Calendário não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockCalendarioService é um mock para o service.CalendarioService
+type MockCalendarioService struct {
+	mock.Mock
+}
+
+func (m *MockCalendarioService) GerarToken(usuarioID uint) (string, error) {
+	args := m.Called(usuarioID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCalendarioService) RevogarToken(usuarioID uint) error {
+	return m.Called(usuarioID).Error(0)
+}
+
+func (m *MockCalendarioService) Feed(token string) ([]byte, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// Importar lê o arquivo como o serviço faria, para que o mock receba o conteúdo
+// e os erros de leitura (como o limite de tamanho) cheguem ao controller
+func (m *MockCalendarioService) Importar(usuarioID uint, arquivo io.Reader) (*dto.ImportacaoCalendarioDTO, error) {
+	conteudo, err := io.ReadAll(arquivo)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(usuarioID, string(conteudo))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ImportacaoCalendarioDTO), args.Error(1)
+}
+
+func routerCalendario(mockService *MockCalendarioService) *gin.Engine {
+	controlador := NewCalendarioController(mockService)
+	router := novoRouterTeste()
+	router.GET("/api/v1/calendario/feed/:token", controlador.Feed)
+	router.POST("/api/v1/calendario/token", controlador.GerarToken)
+	router.DELETE("/api/v1/calendario/token", controlador.RevogarToken)
+	router.POST("/api/v1/calendario/importar", controlador.Importar)
+	return router
+}
+
+const eventoICS = "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Regar\r\nDTSTART:20260601T090000Z\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func TestCalendarioController_GerarToken(t *testing.T) {
+	t.Run("Success - Monta a URL do Feed", func(t *testing.T) {
+		mockService := new(MockCalendarioService)
+		mockService.On("GerarToken", uint(7)).Return("abc123", nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/calendario/token", nil)
+		req.Host = "cultivo.local"
+		req.Header.Set("X-Forwarded-Proto", "https")
+		routerCalendario(mockService).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resposta dto.TokenCalendarioDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resposta))
+		assert.Equal(t, "abc123", resposta.Token)
+		assert.Equal(t, "https://cultivo.local/api/v1/calendario/feed/abc123.ics", resposta.URL)
+	})
+}
+
+func TestCalendarioController_RevogarToken(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockCalendarioService)
+		mockService.On("RevogarToken", uint(7)).Return(nil).Once()
+
+		w := requisitar(routerCalendario(mockService), http.MethodDelete, "/api/v1/calendario/token", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestCalendarioController_Feed(t *testing.T) {
+	t.Run("Success - Aceita o Token com a Extensão .ics", func(t *testing.T) {
+		mockService := new(MockCalendarioService)
+		mockService.On("Feed", "abc123").Return([]byte(eventoICS), nil).Once()
+
+		w := requisitar(routerCalendario(mockService), http.MethodGet, "/api/v1/calendario/feed/abc123.ics", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, eventoICS, w.Body.String())
+	})
+
+	t.Run("Error - Token Desconhecido", func(t *testing.T) {
+		mockService := new(MockCalendarioService)
+		mockService.On("Feed", "revogado").Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerCalendario(mockService), http.MethodGet, "/api/v1/calendario/feed/revogado.ics", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestCalendarioController_Importar(t *testing.T) {
+	t.Run("Success - Corpo da Requisição", func(t *testing.T) {
+		mockService := new(MockCalendarioService)
+		mockService.On("Importar", uint(7), eventoICS).Return(&dto.ImportacaoCalendarioDTO{Ignorados: 0}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/calendario/importar", strings.NewReader(eventoICS))
+		req.Header.Set("Content-Type", "text/calendar")
+		routerCalendario(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Success - Arquivo Multipart", func(t *testing.T) {
+		mockService := new(MockCalendarioService)
+		mockService.On("Importar", uint(7), eventoICS).Return(&dto.ImportacaoCalendarioDTO{}, nil).Once()
+		var corpo bytes.Buffer
+		formulario := multipart.NewWriter(&corpo)
+		parte, _ := formulario.CreateFormFile("arquivo", "tarefas.ics")
+		parte.Write([]byte(eventoICS))
+		formulario.Close()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/calendario/importar", &corpo)
+		req.Header.Set("Content-Type", formulario.FormDataContentType())
+		routerCalendario(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Multipart Sem o Campo Arquivo", func(t *testing.T) {
+		mockService := new(MockCalendarioService)
+		var corpo bytes.Buffer
+		formulario := multipart.NewWriter(&corpo)
+		formulario.WriteField("outro", "valor")
+		formulario.Close()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/calendario/importar", &corpo)
+		req.Header.Set("Content-Type", formulario.FormDataContentType())
+		routerCalendario(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Importar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Arquivo Maior que o Limite", func(t *testing.T) {
+		mockService := new(MockCalendarioService)
+
+		w := requisitar(routerCalendario(mockService), http.MethodPost, "/api/v1/calendario/importar",
+			strings.Repeat("X", tamanhoMaximoCalendario+1))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		mockService.AssertNotCalled(t, "Importar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Arquivo Inválido", func(t *testing.T) {
+		mockService := new(MockCalendarioService)
+		mockService.On("Importar", uint(7), "não é um calendário").Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerCalendario(mockService), http.MethodPost, "/api/v1/calendario/importar", "não é um calendário")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
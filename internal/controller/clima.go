@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type ClimaController struct {
+	servico service.ClimaService
+}
+
+func NewClimaController(servico service.ClimaService) *ClimaController {
+	return &ClimaController{servico}
+}
+
+// DefinirLocalizacao godoc
+// @Summary      Define a localização de um ambiente externo
+// @Description  As coordenadas são arredondadas para duas casas decimais (cerca de 1 km) antes de serem gravadas
+// @Tags         clima
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int                 true  "ID do Ambiente"
+// @Param        localizacao  body      dto.LocalizacaoDTO  true  "Coordenadas em graus decimais"
+// @Success      200          {object}  entity.Ambiente
+// @Failure      400          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/localizacao [put]
+func (c *ClimaController) DefinirLocalizacao(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var localizacaoDto dto.LocalizacaoDTO
+	if err := ctx.ShouldBindJSON(&localizacaoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para definir localização")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	ambiente, err := c.servico.DefinirLocalizacao(ambienteID, &localizacaoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao definir localização")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, ambiente)
+}
+
+// RemoverLocalizacao godoc
+// @Summary      Remove a localização de um ambiente
+// @Tags         clima
+// @Param        id   path  int  true  "ID do Ambiente"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/localizacao [delete]
+func (c *ClimaController) RemoverLocalizacao(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.RemoverLocalizacao(ambienteID); err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao remover localização")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// Sol godoc
+// @Summary      Calcula nascer, pôr do sol e duração do dia no local do ambiente
+// @Tags         clima
+// @Produce      json
+// @Param        id      path      int     true   "ID do Ambiente"
+// @Param        inicio  query     string  false  "Primeiro dia (AAAA-MM-DD, padrão: hoje)"
+// @Param        fim     query     string  false  "Último dia (AAAA-MM-DD, padrão: inicio + 6 dias)"
+// @Success      200     {array}   dto.SolDiaDTO
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/sol [get]
+func (c *ClimaController) Sol(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaClimaDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para calcular o sol")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	dias, err := c.servico.Sol(ambienteID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao calcular nascer e pôr do sol")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, dias)
+}
+
+// Sincronizar godoc
+// @Summary      Busca o tempo no local do ambiente e grava os registros de clima
+// @Description  Um registro por dia; dias já sincronizados são atualizados (previsões viram observações)
+// @Tags         clima
+// @Produce      json
+// @Param        id      path      int     true   "ID do Ambiente"
+// @Param        inicio  query     string  false  "Primeiro dia (AAAA-MM-DD, padrão: hoje - 6 dias)"
+// @Param        fim     query     string  false  "Último dia (AAAA-MM-DD, padrão: inicio + 6 dias)"
+// @Success      200     {array}   entity.ClimaRegistro
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/clima/sincronizar [post]
+func (c *ClimaController) Sincronizar(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaClimaDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para sincronizar clima")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	registros, err := c.servico.Sincronizar(ctx.Request.Context(), ambienteID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao sincronizar clima")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, registros)
+}
+
+// ListarClima godoc
+// @Summary      Lista os registros de clima do ambiente
+// @Tags         clima
+// @Produce      json
+// @Param        id      path      int     true   "ID do Ambiente"
+// @Param        inicio  query     string  false  "Primeiro dia (AAAA-MM-DD, padrão: hoje - 6 dias)"
+// @Param        fim     query     string  false  "Último dia (AAAA-MM-DD, padrão: inicio + 6 dias)"
+// @Success      200     {array}   entity.ClimaRegistro
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/clima [get]
+func (c *ClimaController) ListarClima(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaClimaDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar clima")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	registros, err := c.servico.ListarClima(ambienteID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao listar clima")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, registros)
+}
+
+func (c *ClimaController) responderErro(ctx *gin.Context, err error, naoEncontrado, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, naoEncontrado, err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockClimaService é um mock para o service.ClimaService
+type MockClimaService struct {
+	mock.Mock
+}
+
+func (m *MockClimaService) DefinirLocalizacao(ambienteID uint, localizacaoDto *dto.LocalizacaoDTO) (*entity.Ambiente, error) {
+	args := m.Called(ambienteID, localizacaoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Ambiente), args.Error(1)
+}
+
+func (m *MockClimaService) RemoverLocalizacao(ambienteID uint) error {
+	return m.Called(ambienteID).Error(0)
+}
+
+func (m *MockClimaService) Sol(ambienteID uint, consulta *dto.ConsultaClimaDTO) ([]dto.SolDiaDTO, error) {
+	args := m.Called(ambienteID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.SolDiaDTO), args.Error(1)
+}
+
+func (m *MockClimaService) Sincronizar(ctx context.Context, ambienteID uint, consulta *dto.ConsultaClimaDTO) ([]entity.ClimaRegistro, error) {
+	args := m.Called(ambienteID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ClimaRegistro), args.Error(1)
+}
+
+func (m *MockClimaService) ListarClima(ambienteID uint, consulta *dto.ConsultaClimaDTO) ([]entity.ClimaRegistro, error) {
+	args := m.Called(ambienteID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ClimaRegistro), args.Error(1)
+}
+
+func routerClima(mockService *MockClimaService) *gin.Engine {
+	controlador := NewClimaController(mockService)
+	router := novoRouterTeste()
+	router.PUT("/ambientes/:id/localizacao", controlador.DefinirLocalizacao)
+	router.DELETE("/ambientes/:id/localizacao", controlador.RemoverLocalizacao)
+	router.GET("/ambientes/:id/sol", controlador.Sol)
+	router.POST("/ambientes/:id/clima/sincronizar", controlador.Sincronizar)
+	router.GET("/ambientes/:id/clima", controlador.ListarClima)
+	return router
+}
+
+func TestClimaController_DefinirLocalizacao(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockClimaService)
+		ambiente := &entity.Ambiente{Nome: "Varanda"}
+		ambiente.ID = 3
+		mockService.On("DefinirLocalizacao", uint(3), mock.MatchedBy(func(l *dto.LocalizacaoDTO) bool {
+			return *l.Latitude == -23.55 && *l.Longitude == -46.63
+		})).Return(ambiente, nil).Once()
+
+		w := requisitar(routerClima(mockService), http.MethodPut, "/ambientes/3/localizacao", `{"latitude":-23.55,"longitude":-46.63}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Varanda")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Latitude Fora do Intervalo", func(t *testing.T) {
+		mockService := new(MockClimaService)
+
+		w := requisitar(routerClima(mockService), http.MethodPut, "/ambientes/3/localizacao", `{"latitude":-91,"longitude":-46.63}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Latitude")
+		mockService.AssertNotCalled(t, "DefinirLocalizacao", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockClimaService)
+
+		w := requisitar(routerClima(mockService), http.MethodPut, "/ambientes/abc/localizacao", `{"latitude":0,"longitude":0}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "DefinirLocalizacao", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Ambiente de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockClimaService)
+		mockService.On("DefinirLocalizacao", uint(3), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerClima(mockService), http.MethodPut, "/ambientes/3/localizacao", `{"latitude":0,"longitude":0}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestClimaController_RemoverLocalizacao(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockClimaService)
+		mockService.On("RemoverLocalizacao", uint(3)).Return(nil).Once()
+
+		w := requisitar(routerClima(mockService), http.MethodDelete, "/ambientes/3/localizacao", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - ID Zero", func(t *testing.T) {
+		mockService := new(MockClimaService)
+
+		w := requisitar(routerClima(mockService), http.MethodDelete, "/ambientes/0/localizacao", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RemoverLocalizacao", mock.Anything)
+	})
+}
+
+func TestClimaController_Sol(t *testing.T) {
+	t.Run("Success - Repassa o Período", func(t *testing.T) {
+		mockService := new(MockClimaService)
+		mockService.On("Sol", uint(3), &dto.ConsultaClimaDTO{Inicio: "2026-06-01", Fim: "2026-06-03"}).
+			Return([]dto.SolDiaDTO{}, nil).Once()
+
+		w := requisitar(routerClima(mockService), http.MethodGet, "/ambientes/3/sol?inicio=2026-06-01&fim=2026-06-03", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Data em Formato Inválido", func(t *testing.T) {
+		mockService := new(MockClimaService)
+
+		w := requisitar(routerClima(mockService), http.MethodGet, "/ambientes/3/sol?inicio=01/06/2026", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Sol", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Ambiente Sem Localização", func(t *testing.T) {
+		mockService := new(MockClimaService)
+		mockService.On("Sol", uint(3), mock.Anything).
+			Return(nil, fmt.Errorf("%w: ambiente sem localização", utils.ErrInvalidInput)).Once()
+
+		w := requisitar(routerClima(mockService), http.MethodGet, "/ambientes/3/sol", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestClimaController_Sincronizar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockClimaService)
+		mockService.On("Sincronizar", uint(3), &dto.ConsultaClimaDTO{}).Return([]entity.ClimaRegistro{{AmbienteID: 3}}, nil).Once()
+
+		w := requisitar(routerClima(mockService), http.MethodPost, "/ambientes/3/clima/sincronizar", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Falha do Provedor", func(t *testing.T) {
+		mockService := new(MockClimaService)
+		mockService.On("Sincronizar", uint(3), mock.Anything).Return(nil, errors.New("provedor indisponível")).Once()
+
+		w := requisitar(routerClima(mockService), http.MethodPost, "/ambientes/3/clima/sincronizar", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestClimaController_ListarClima(t *testing.T) {
+	t.Run("Error - Ambiente Não Encontrado", func(t *testing.T) {
+		mockService := new(MockClimaService)
+		mockService.On("ListarClima", uint(9), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerClima(mockService), http.MethodGet, "/ambientes/9/clima", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
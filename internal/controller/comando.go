@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type ComandoController struct {
+	publicador service.PublicadorComandos
+}
+
+// NewComandoController cria o controller de comandos. publicador pode ser nil quando
+// nenhuma integração com atuadores estiver configurada.
+func NewComandoController(publicador service.PublicadorComandos) *ComandoController {
+	return &ComandoController{publicador}
+}
+
+// Publicar godoc
+// @Summary      Publica um comando para um atuador
+// @Description  Publica o payload no tópico MQTT informado (ex.: cmnd/exaustor/POWER com payload ON).
+// @Description  Apenas tópicos sob os prefixos de comando configurados são aceitos.
+// @Tags         atuadores
+// @Accept       json
+// @Produce      json
+// @Param        comando  body      dto.ComandoAtuadorDTO  true  "Comando"
+// @Success      202      {object}  dto.ComandoAtuadorDTO
+// @Failure      400      {object}  map[string]string
+// @Failure      502      {object}  map[string]string
+// @Failure      503      {object}  map[string]string
+// @Router       /api/v1/atuadores/comandos [post]
+func (c *ComandoController) Publicar(ctx *gin.Context) {
+	if c.publicador == nil {
+		utils.RespondWithError(ctx, http.StatusServiceUnavailable, "Integração com atuadores não configurada", nil)
+		return
+	}
+
+	var comandoDto dto.ComandoAtuadorDTO
+	if err := ctx.ShouldBindJSON(&comandoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para publicar comando")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	if err := c.publicador.Publicar(comandoDto.Topico, []byte(comandoDto.Payload), comandoDto.Retain); err != nil {
+		if errors.Is(err, utils.ErrInvalidInput) {
+			utils.RespondWithError(ctx, http.StatusBadRequest, "Tópico não permitido", err.Error())
+			return
+		}
+		logrus.WithError(err).Error("Erro ao publicar comando")
+		utils.RespondWithError(ctx, http.StatusBadGateway, "Erro ao publicar comando", err.Error())
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusAccepted, comandoDto)
+}
@@ -0,0 +1,280 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type CronogramaCultivoController struct {
+	servico service.CronogramaCultivoService
+}
+
+func NewCronogramaCultivoController(servico service.CronogramaCultivoService) *CronogramaCultivoController {
+	return &CronogramaCultivoController{servico}
+}
+
+// Criar godoc
+// @Summary      Cria um cronograma de cultivo
+// @Description  Cada item é agendado a partir do início de um estágio: dia_inicio (0 = primeiro dia), repetindo a cada frequencia_dias até dia_fim ou, sem dia_fim, enquanto durar o estágio
+// @Tags         cronogramas
+// @Accept       json
+// @Produce      json
+// @Param        cronograma  body      dto.CronogramaCultivoDTO  true  "Cronograma"
+// @Success      201         {object}  entity.CronogramaCultivo
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /api/v1/cronogramas [post]
+func (c *CronogramaCultivoController) Criar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var cronogramaDto dto.CronogramaCultivoDTO
+	if err := ctx.ShouldBindJSON(&cronogramaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar cronograma")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	cronograma, err := c.servico.Criar(usuarioID, &cronogramaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Cronograma não encontrado", "Erro interno ao criar cronograma")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, cronograma)
+}
+
+// Listar godoc
+// @Summary      Lista os cronogramas de cultivo do usuário
+// @Tags         cronogramas
+// @Produce      json
+// @Success      200  {array}   entity.CronogramaCultivo
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/cronogramas [get]
+func (c *CronogramaCultivoController) Listar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	cronogramas, err := c.servico.Listar(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Cronograma não encontrado", "Erro interno ao listar cronogramas")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, cronogramas)
+}
+
+// BuscarPorID godoc
+// @Summary      Busca um cronograma de cultivo com os itens
+// @Tags         cronogramas
+// @Produce      json
+// @Param        id   path      int  true  "ID do Cronograma"
+// @Success      200  {object}  entity.CronogramaCultivo
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/cronogramas/{id} [get]
+func (c *CronogramaCultivoController) BuscarPorID(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	cronograma, err := c.servico.BuscarPorID(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Cronograma não encontrado", "Erro interno ao buscar cronograma")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, cronograma)
+}
+
+// Atualizar godoc
+// @Summary      Atualiza um cronograma de cultivo
+// @Description  Os itens substituem os anteriores e as plantas em que o cronograma está aplicado são replanejadas
+// @Tags         cronogramas
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                       true  "ID do Cronograma"
+// @Param        cronograma  body      dto.CronogramaCultivoDTO  true  "Cronograma"
+// @Success      200         {object}  entity.CronogramaCultivo
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /api/v1/cronogramas/{id} [put]
+func (c *CronogramaCultivoController) Atualizar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var cronogramaDto dto.CronogramaCultivoDTO
+	if err := ctx.ShouldBindJSON(&cronogramaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar cronograma")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	cronograma, err := c.servico.Atualizar(id, usuarioID, &cronogramaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Cronograma não encontrado", "Erro interno ao atualizar cronograma")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, cronograma)
+}
+
+// Deletar godoc
+// @Summary      Remove um cronograma de cultivo
+// @Description  Remove também as aplicações e as tarefas ainda não concluídas geradas por ele
+// @Tags         cronogramas
+// @Param        id   path  int  true  "ID do Cronograma"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/cronogramas/{id} [delete]
+func (c *CronogramaCultivoController) Deletar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Cronograma não encontrado", "Erro interno ao deletar cronograma")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// Aplicar godoc
+// @Summary      Aplica o cronograma a plantas
+// @Description  Aceita uma planta, um lote (planta_ids) ou as plantas de um diário. Gera as tarefas do estágio atual de cada planta, que são replanejadas quando o estágio muda
+// @Tags         cronogramas
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                       true  "ID do Cronograma"
+// @Param        aplicar   body      dto.AplicarCronogramaDTO  true  "Plantas"
+// @Success      200       {array}   dto.PlanejamentoCronogramaDTO
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /api/v1/cronogramas/{id}/aplicar [post]
+func (c *CronogramaCultivoController) Aplicar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var aplicarDto dto.AplicarCronogramaDTO
+	if err := ctx.ShouldBindJSON(&aplicarDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para aplicar cronograma")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	planejamentos, err := c.servico.Aplicar(id, usuarioID, &aplicarDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Cronograma não encontrado", "Erro interno ao aplicar cronograma")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, planejamentos)
+}
+
+// ListarAplicacoes godoc
+// @Summary      Lista as plantas em que o cronograma está aplicado
+// @Tags         cronogramas
+// @Produce      json
+// @Param        id   path      int  true  "ID do Cronograma"
+// @Success      200  {array}   entity.AplicacaoCronograma
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/cronogramas/{id}/aplicacoes [get]
+func (c *CronogramaCultivoController) ListarAplicacoes(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	aplicacoes, err := c.servico.ListarAplicacoes(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Cronograma não encontrado", "Erro interno ao listar aplicações do cronograma")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, aplicacoes)
+}
+
+// RemoverAplicacao godoc
+// @Summary      Desliga um cronograma de uma planta
+// @Description  Remove as tarefas ainda não concluídas geradas pela aplicação; as concluídas são mantidas
+// @Tags         cronogramas
+// @Param        id   path  int  true  "ID da Aplicação"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/aplicacoes-cronograma/{id} [delete]
+func (c *CronogramaCultivoController) RemoverAplicacao(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.RemoverAplicacao(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Aplicação de cronograma não encontrada", "Erro interno ao remover aplicação de cronograma")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CronogramaCultivoController) responderErro(ctx *gin.Context, err error, naoEncontrado, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, naoEncontrado, err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCronogramaCultivoService é um mock para o service.CronogramaCultivoService
+type MockCronogramaCultivoService struct {
+	mock.Mock
+}
+
+func (m *MockCronogramaCultivoService) EstagioIniciado(planta *entity.Planta, estagio *entity.EstagioCrescimento) error {
+	return m.Called(planta, estagio).Error(0)
+}
+
+func (m *MockCronogramaCultivoService) Criar(usuarioID uint, cronogramaDto *dto.CronogramaCultivoDTO) (*entity.CronogramaCultivo, error) {
+	args := m.Called(usuarioID, cronogramaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CronogramaCultivo), args.Error(1)
+}
+
+func (m *MockCronogramaCultivoService) Listar(usuarioID uint) ([]entity.CronogramaCultivo, error) {
+	args := m.Called(usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.CronogramaCultivo), args.Error(1)
+}
+
+func (m *MockCronogramaCultivoService) BuscarPorID(id, usuarioID uint) (*entity.CronogramaCultivo, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CronogramaCultivo), args.Error(1)
+}
+
+func (m *MockCronogramaCultivoService) Atualizar(id, usuarioID uint, cronogramaDto *dto.CronogramaCultivoDTO) (*entity.CronogramaCultivo, error) {
+	args := m.Called(id, usuarioID, cronogramaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CronogramaCultivo), args.Error(1)
+}
+
+func (m *MockCronogramaCultivoService) Deletar(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+func (m *MockCronogramaCultivoService) Aplicar(id, usuarioID uint, aplicarDto *dto.AplicarCronogramaDTO) ([]dto.PlanejamentoCronogramaDTO, error) {
+	args := m.Called(id, usuarioID, aplicarDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.PlanejamentoCronogramaDTO), args.Error(1)
+}
+
+func (m *MockCronogramaCultivoService) ListarAplicacoes(id, usuarioID uint) ([]entity.AplicacaoCronograma, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AplicacaoCronograma), args.Error(1)
+}
+
+func (m *MockCronogramaCultivoService) RemoverAplicacao(aplicacaoID, usuarioID uint) error {
+	return m.Called(aplicacaoID, usuarioID).Error(0)
+}
+
+func routerCronogramas(mockService *MockCronogramaCultivoService) *gin.Engine {
+	controlador := NewCronogramaCultivoController(mockService)
+	router := novoRouterTeste()
+	router.POST("/cronogramas", controlador.Criar)
+	router.GET("/cronogramas", controlador.Listar)
+	router.GET("/cronogramas/:id", controlador.BuscarPorID)
+	router.PUT("/cronogramas/:id", controlador.Atualizar)
+	router.DELETE("/cronogramas/:id", controlador.Deletar)
+	router.POST("/cronogramas/:id/aplicar", controlador.Aplicar)
+	router.GET("/cronogramas/:id/aplicacoes", controlador.ListarAplicacoes)
+	router.DELETE("/aplicacoes-cronograma/:id", controlador.RemoverAplicacao)
+	return router
+}
+
+const cronogramaValido = `{"nome":"Vega padrão","itens":[{"nome":"Regar","tipo":"regar","estagio":"vegetativo","frequencia_dias":2}]}`
+
+func TestCronogramaCultivoController_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+		mockService.On("Criar", uint(7), mock.MatchedBy(func(d *dto.CronogramaCultivoDTO) bool {
+			return d.Nome == "Vega padrão" && len(d.Itens) == 1 && d.Itens[0].Estagio == entity.EstagioVegetativo
+		})).Return(&entity.CronogramaCultivo{Nome: "Vega padrão"}, nil).Once()
+
+		w := requisitar(routerCronogramas(mockService), http.MethodPost, "/cronogramas", cronogramaValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Sem Itens", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+
+		w := requisitar(routerCronogramas(mockService), http.MethodPost, "/cronogramas", `{"nome":"Vazio","itens":[]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Itens")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Estágio Desconhecido", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+		mockService.On("Criar", uint(7), mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerCronogramas(mockService), http.MethodPost, "/cronogramas", cronogramaValido)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCronogramaCultivoController_Atualizar(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+
+		w := requisitar(routerCronogramas(mockService), http.MethodPut, "/cronogramas/0", cronogramaValido)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Atualizar", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Cronograma de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+		mockService.On("Atualizar", uint(5), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerCronogramas(mockService), http.MethodPut, "/cronogramas/5", cronogramaValido)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Cronograma não encontrado")
+	})
+}
+
+func TestCronogramaCultivoController_Aplicar(t *testing.T) {
+	t.Run("Success - Lote de Plantas", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+		mockService.On("Aplicar", uint(5), uint(7), &dto.AplicarCronogramaDTO{PlantaIDs: []uint{10, 11}}).
+			Return([]dto.PlanejamentoCronogramaDTO{}, nil).Once()
+
+		w := requisitar(routerCronogramas(mockService), http.MethodPost, "/cronogramas/5/aplicar", `{"planta_ids":[10,11]}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - ID de Planta Zero", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+
+		w := requisitar(routerCronogramas(mockService), http.MethodPost, "/cronogramas/5/aplicar", `{"planta_ids":[0]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Aplicar", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+		mockService.On("Aplicar", uint(5), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerCronogramas(mockService), http.MethodPost, "/cronogramas/5/aplicar", `{"planta_ids":[10]}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestCronogramaCultivoController_RemoverAplicacao(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+		mockService.On("RemoverAplicacao", uint(12), uint(7)).Return(nil).Once()
+
+		w := requisitar(routerCronogramas(mockService), http.MethodDelete, "/aplicacoes-cronograma/12", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Error - Aplicação Não Encontrada", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+		mockService.On("RemoverAplicacao", uint(12), uint(7)).Return(utils.ErrNotFound).Once()
+
+		w := requisitar(routerCronogramas(mockService), http.MethodDelete, "/aplicacoes-cronograma/12", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Aplicação de cronograma não encontrada")
+	})
+}
+
+func TestCronogramaCultivoController_Listar(t *testing.T) {
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockCronogramaCultivoService)
+		mockService.On("Listar", uint(7)).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerCronogramas(mockService), http.MethodGet, "/cronogramas", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,285 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type CruzamentoController struct {
+	servico service.CruzamentoService
+}
+
+func NewCruzamentoController(servico service.CruzamentoService) *CruzamentoController {
+	return &CruzamentoController{servico}
+}
+
+// Criar godoc
+// @Summary      Registra um cruzamento
+// @Description  Cria a genética resultante no catálogo com mãe, pai e geração. F1 cruza genéticas diferentes, Fn cruza genéticas F(n-1), BXn cruza uma genética F1 (ou BX(n-1)) com uma das genéticas de origem dela e Sn autocruza a genética mãe (o pai pode ser omitido)
+// @Tags         cruzamentos
+// @Accept       json
+// @Produce      json
+// @Param        cruzamento  body      dto.CruzamentoDTO  true  "Cruzamento"
+// @Success      201         {object}  entity.Cruzamento
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /api/v1/cruzamentos [post]
+func (c *CruzamentoController) Criar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var cruzamentoDto dto.CruzamentoDTO
+	if err := ctx.ShouldBindJSON(&cruzamentoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar cruzamento")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	cruzamento, err := c.servico.Criar(usuarioID, &cruzamentoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao criar cruzamento")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, cruzamento)
+}
+
+// Listar godoc
+// @Summary      Lista os cruzamentos do usuário
+// @Description  Dos mais recentes para os mais antigos, com as genéticas mãe, pai e resultante
+// @Tags         cruzamentos
+// @Produce      json
+// @Success      200  {array}   entity.Cruzamento
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/cruzamentos [get]
+func (c *CruzamentoController) Listar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	cruzamentos, err := c.servico.Listar(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar cruzamentos")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, cruzamentos)
+}
+
+// BuscarPorID godoc
+// @Summary      Busca um cruzamento
+// @Description  Inclui as genéticas e as polinizações do cruzamento
+// @Tags         cruzamentos
+// @Produce      json
+// @Param        id   path      int  true  "ID do Cruzamento"
+// @Success      200  {object}  entity.Cruzamento
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/cruzamentos/{id} [get]
+func (c *CruzamentoController) BuscarPorID(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	cruzamento, err := c.servico.BuscarPorID(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar cruzamento")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, cruzamento)
+}
+
+// Deletar godoc
+// @Summary      Remove um cruzamento
+// @Description  Remove também as polinizações; os lotes de sementes ficam no banco de sementes e a genética resultante continua no catálogo
+// @Tags         cruzamentos
+// @Param        id   path      int  true  "ID do Cruzamento"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/cruzamentos/{id} [delete]
+func (c *CruzamentoController) Deletar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar cruzamento")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RegistrarPolinizacao godoc
+// @Summary      Registra uma polinização do cruzamento
+// @Description  A planta mãe deve ser da genética mãe do cruzamento e a doadora, quando informada, da genética pai. A planta mãe só poliniza a si mesma em autocruzamentos
+// @Tags         cruzamentos
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int                 true  "ID do Cruzamento"
+// @Param        polinizacao  body      dto.PolinizacaoDTO  true  "Polinização"
+// @Success      201          {object}  entity.Polinizacao
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/cruzamentos/{id}/polinizacoes [post]
+func (c *CruzamentoController) RegistrarPolinizacao(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var polinizacaoDto dto.PolinizacaoDTO
+	if err := ctx.ShouldBindJSON(&polinizacaoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar polinização")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	polinizacao, err := c.servico.RegistrarPolinizacao(id, usuarioID, &polinizacaoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar polinização")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, polinizacao)
+}
+
+// ListarPolinizacoes godoc
+// @Summary      Lista as polinizações do cruzamento
+// @Description  Ordenadas pela data, com as plantas mãe e doadora
+// @Tags         cruzamentos
+// @Produce      json
+// @Param        id   path      int  true  "ID do Cruzamento"
+// @Success      200  {array}   entity.Polinizacao
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/cruzamentos/{id}/polinizacoes [get]
+func (c *CruzamentoController) ListarPolinizacoes(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	polinizacoes, err := c.servico.ListarPolinizacoes(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar polinizações")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, polinizacoes)
+}
+
+// RegistrarLote godoc
+// @Summary      Registra um lote de sementes colhido do cruzamento
+// @Description  O lote entra no banco de sementes como um pacote da genética resultante, com o breeder da genética e a data de colheita como data de aquisição
+// @Tags         cruzamentos
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                  true  "ID do Cruzamento"
+// @Param        lote  body      dto.LoteSementesDTO  true  "Lote de sementes"
+// @Success      201   {object}  entity.PacoteSemente
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/v1/cruzamentos/{id}/lotes [post]
+func (c *CruzamentoController) RegistrarLote(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var loteDto dto.LoteSementesDTO
+	if err := ctx.ShouldBindJSON(&loteDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar lote de sementes")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	pacote, err := c.servico.RegistrarLote(id, usuarioID, &loteDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar lote de sementes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, pacote)
+}
+
+// ListarLotes godoc
+// @Summary      Lista os lotes de sementes do cruzamento
+// @Description  Pacotes do banco de sementes colhidos do cruzamento, com o saldo atual
+// @Tags         cruzamentos
+// @Produce      json
+// @Param        id   path      int  true  "ID do Cruzamento"
+// @Success      200  {array}   entity.PacoteSemente
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/cruzamentos/{id}/lotes [get]
+func (c *CruzamentoController) ListarLotes(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	lotes, err := c.servico.ListarLotes(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar lotes de sementes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, lotes)
+}
+
+func (c *CruzamentoController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Cruzamento não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
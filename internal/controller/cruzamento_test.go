@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCruzamentoService é um mock para o service.CruzamentoService
+type MockCruzamentoService struct {
+	mock.Mock
+}
+
+func (m *MockCruzamentoService) Criar(usuarioID uint, cruzamentoDto *dto.CruzamentoDTO) (*entity.Cruzamento, error) {
+	args := m.Called(usuarioID, cruzamentoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Cruzamento), args.Error(1)
+}
+
+func (m *MockCruzamentoService) Listar(usuarioID uint) ([]entity.Cruzamento, error) {
+	args := m.Called(usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Cruzamento), args.Error(1)
+}
+
+func (m *MockCruzamentoService) BuscarPorID(id, usuarioID uint) (*entity.Cruzamento, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Cruzamento), args.Error(1)
+}
+
+func (m *MockCruzamentoService) Deletar(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+func (m *MockCruzamentoService) RegistrarPolinizacao(id, usuarioID uint, polinizacaoDto *dto.PolinizacaoDTO) (*entity.Polinizacao, error) {
+	args := m.Called(id, usuarioID, polinizacaoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Polinizacao), args.Error(1)
+}
+
+func (m *MockCruzamentoService) ListarPolinizacoes(id, usuarioID uint) ([]entity.Polinizacao, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Polinizacao), args.Error(1)
+}
+
+func (m *MockCruzamentoService) RegistrarLote(id, usuarioID uint, loteDto *dto.LoteSementesDTO) (*entity.PacoteSemente, error) {
+	args := m.Called(id, usuarioID, loteDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PacoteSemente), args.Error(1)
+}
+
+func (m *MockCruzamentoService) ListarLotes(id, usuarioID uint) ([]entity.PacoteSemente, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.PacoteSemente), args.Error(1)
+}
+
+func routerCruzamentos(mockService *MockCruzamentoService) *gin.Engine {
+	controlador := NewCruzamentoController(mockService)
+	router := novoRouterTeste()
+	router.POST("/cruzamentos", controlador.Criar)
+	router.GET("/cruzamentos", controlador.Listar)
+	router.GET("/cruzamentos/:id", controlador.BuscarPorID)
+	router.DELETE("/cruzamentos/:id", controlador.Deletar)
+	router.POST("/cruzamentos/:id/polinizacoes", controlador.RegistrarPolinizacao)
+	router.GET("/cruzamentos/:id/polinizacoes", controlador.ListarPolinizacoes)
+	router.POST("/cruzamentos/:id/lotes", controlador.RegistrarLote)
+	router.GET("/cruzamentos/:id/lotes", controlador.ListarLotes)
+	return router
+}
+
+const cruzamentoValido = `{"nome":"Skunk x Haze","mae_genetica_id":5,"pai_genetica_id":6,"geracao":"F1","tipo_genetica":"hibrido","tempo_floracao":63}`
+
+func TestCruzamentoController_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("Criar", uint(7), mock.MatchedBy(func(d *dto.CruzamentoDTO) bool {
+			return d.MaeGeneticaID == 5 && d.PaiGeneticaID == 6 && d.Geracao == "F1" &&
+				d.TipoGenetica == "hibrido" && d.TempoFloracao == 63
+		})).Return(&entity.Cruzamento{Nome: "Skunk x Haze"}, nil).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos", cruzamentoValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Mãe em camelCase", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos",
+			`{"nome":"Skunk x Haze","maeGeneticaId":5,"geracao":"F1"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "MaeGeneticaID")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Tipo de Genética Desconhecido", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos",
+			`{"nome":"Skunk x Haze","mae_genetica_id":5,"geracao":"F1","tipo_genetica":"autoflorescente"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "TipoGenetica")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Geração Incompatível", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("Criar", uint(7), mock.Anything).
+			Return(nil, fmt.Errorf("%w: F2 cruza genéticas F1", utils.ErrInvalidInput)).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos", cruzamentoValido)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "F2 cruza genéticas F1")
+	})
+
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("Criar", uint(7), mock.Anything).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos", cruzamentoValido)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestCruzamentoController_BuscarPorID(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("BuscarPorID", uint(2), uint(7)).Return(&entity.Cruzamento{Nome: "Skunk x Haze", MaeGeneticaID: 5}, nil).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodGet, "/cruzamentos/2", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"mae_genetica_id":5`)
+	})
+
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodGet, "/cruzamentos/abc", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "BuscarPorID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Cruzamento de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("BuscarPorID", uint(2), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodGet, "/cruzamentos/2", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestCruzamentoController_Deletar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("Deletar", uint(2), uint(7)).Return(nil).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodDelete, "/cruzamentos/2", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestCruzamentoController_RegistrarPolinizacao(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("RegistrarPolinizacao", uint(2), uint(7), mock.MatchedBy(func(d *dto.PolinizacaoDTO) bool {
+			return d.PlantaMaeID == 10 && *d.PlantaDoadoraID == 11 && d.Metodo == "pincel"
+		})).Return(&entity.Polinizacao{CruzamentoID: 2}, nil).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos/2/polinizacoes",
+			`{"planta_mae_id":10,"planta_doadora_id":11,"metodo":"pincel"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Método Desconhecido", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos/2/polinizacoes",
+			`{"planta_mae_id":10,"metodo":"vento"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Metodo")
+		mockService.AssertNotCalled(t, "RegistrarPolinizacao", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("RegistrarPolinizacao", uint(2), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos/2/polinizacoes", `{"planta_mae_id":10}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestCruzamentoController_RegistrarLote(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("RegistrarLote", uint(2), uint(7), mock.MatchedBy(func(d *dto.LoteSementesDTO) bool {
+			return d.Quantidade == 40 && d.LocalArmazenamento == "geladeira"
+		})).Return(&entity.PacoteSemente{QuantidadeInicial: 40}, nil).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos/2/lotes",
+			`{"quantidade":40,"local_armazenamento":"geladeira"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Quantidade Obrigatória", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodPost, "/cruzamentos/2/lotes", `{"local_armazenamento":"geladeira"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Quantidade")
+		mockService.AssertNotCalled(t, "RegistrarLote", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCruzamentoController_ListarLotes(t *testing.T) {
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockCruzamentoService)
+		mockService.On("ListarLotes", uint(2), uint(7)).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerCruzamentos(mockService), http.MethodGet, "/cruzamentos/2/lotes", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,356 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type EnergiaController struct {
+	servico service.EnergiaService
+}
+
+func NewEnergiaController(servico service.EnergiaService) *EnergiaController {
+	return &EnergiaController{servico}
+}
+
+// CriarEquipamento godoc
+// @Summary      Cadastra um equipamento no ambiente
+// @Description  Potência por unidade, quantidade e modo de funcionamento (contínuo, fotoperíodo, horas por dia ou ciclo)
+// @Tags         energia
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int                 true  "ID do Ambiente"
+// @Param        equipamento  body      dto.EquipamentoDTO  true  "Equipamento"
+// @Success      201          {object}  entity.Equipamento
+// @Failure      400          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/equipamentos [post]
+func (c *EnergiaController) CriarEquipamento(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var equipamentoDto dto.EquipamentoDTO
+	if err := ctx.ShouldBindJSON(&equipamentoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar equipamento")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	equipamento, err := c.servico.CriarEquipamento(ambienteID, &equipamentoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao criar equipamento")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, equipamento)
+}
+
+// ListarEquipamentos godoc
+// @Summary      Lista os equipamentos do ambiente
+// @Tags         energia
+// @Produce      json
+// @Param        id   path      int  true  "ID do Ambiente"
+// @Success      200  {array}   entity.Equipamento
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/equipamentos [get]
+func (c *EnergiaController) ListarEquipamentos(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	equipamentos, err := c.servico.ListarEquipamentos(ambienteID)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao listar equipamentos")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, equipamentos)
+}
+
+// AtualizarEquipamento godoc
+// @Summary      Atualiza um equipamento
+// @Tags         energia
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int                 true  "ID do Equipamento"
+// @Param        equipamento  body      dto.EquipamentoDTO  true  "Equipamento"
+// @Success      200          {object}  entity.Equipamento
+// @Failure      400          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/equipamentos/{id} [put]
+func (c *EnergiaController) AtualizarEquipamento(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var equipamentoDto dto.EquipamentoDTO
+	if err := ctx.ShouldBindJSON(&equipamentoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar equipamento")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	equipamento, err := c.servico.AtualizarEquipamento(id, &equipamentoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Equipamento não encontrado", "Erro interno ao atualizar equipamento")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, equipamento)
+}
+
+// DeletarEquipamento godoc
+// @Summary      Remove um equipamento
+// @Description  Para manter o histórico de consumo, prefira informar removido_em
+// @Tags         energia
+// @Param        id   path  int  true  "ID do Equipamento"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/equipamentos/{id} [delete]
+func (c *EnergiaController) DeletarEquipamento(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.DeletarEquipamento(id); err != nil {
+		c.responderErro(ctx, err, "Equipamento não encontrado", "Erro interno ao deletar equipamento")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// CriarTarifa godoc
+// @Summary      Cadastra uma tarifa de energia do usuário
+// @Description  Valor do kWh com tributos e adicionais das bandeiras (padrão: valores vigentes da ANEEL).
+// @Description  Tarifas anteriores continuam valendo para os dias antes de vigente_desde.
+// @Tags         energia
+// @Accept       json
+// @Produce      json
+// @Param        tarifa  body      dto.TarifaEnergiaDTO  true  "Tarifa de energia"
+// @Success      201     {object}  entity.TarifaEnergia
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/energia/tarifas [post]
+func (c *EnergiaController) CriarTarifa(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var tarifaDto dto.TarifaEnergiaDTO
+	if err := ctx.ShouldBindJSON(&tarifaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar tarifa de energia")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	tarifa, err := c.servico.CriarTarifa(usuarioID, &tarifaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Tarifa não encontrada", "Erro interno ao criar tarifa de energia")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, tarifa)
+}
+
+// ListarTarifas godoc
+// @Summary      Lista as tarifas de energia do usuário
+// @Tags         energia
+// @Produce      json
+// @Success      200  {array}   entity.TarifaEnergia
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/energia/tarifas [get]
+func (c *EnergiaController) ListarTarifas(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	tarifas, err := c.servico.ListarTarifas(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Tarifa não encontrada", "Erro interno ao listar tarifas de energia")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, tarifas)
+}
+
+// DeletarTarifa godoc
+// @Summary      Remove uma tarifa de energia
+// @Tags         energia
+// @Param        id   path  int  true  "ID da Tarifa"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/energia/tarifas/{id} [delete]
+func (c *EnergiaController) DeletarTarifa(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.DeletarTarifa(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Tarifa não encontrada", "Erro interno ao deletar tarifa de energia")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// DefinirBandeira godoc
+// @Summary      Define a bandeira tarifária de um mês
+// @Description  Meses sem bandeira definida são calculados com bandeira verde
+// @Tags         energia
+// @Accept       json
+// @Produce      json
+// @Param        competencia  path      string                    true  "Mês (AAAA-MM)"
+// @Param        bandeira     body      dto.BandeiraTarifariaDTO  true  "Bandeira"
+// @Success      200          {object}  entity.BandeiraMensal
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/energia/bandeiras/{competencia} [put]
+func (c *EnergiaController) DefinirBandeira(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var bandeiraDto dto.BandeiraTarifariaDTO
+	if err := ctx.ShouldBindJSON(&bandeiraDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para definir bandeira tarifária")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	bandeira, err := c.servico.DefinirBandeira(usuarioID, ctx.Param("competencia"), &bandeiraDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Bandeira não encontrada", "Erro interno ao definir bandeira tarifária")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, bandeira)
+}
+
+// ListarBandeiras godoc
+// @Summary      Lista as bandeiras tarifárias registradas pelo usuário
+// @Tags         energia
+// @Produce      json
+// @Success      200  {array}   entity.BandeiraMensal
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/energia/bandeiras [get]
+func (c *EnergiaController) ListarBandeiras(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	bandeiras, err := c.servico.ListarBandeiras(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Bandeira não encontrada", "Erro interno ao listar bandeiras tarifárias")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, bandeiras)
+}
+
+// ConsumoAmbiente godoc
+// @Summary      Estima o consumo e o custo de energia do ambiente
+// @Description  kWh por dia e por equipamento, com as horas de luz programadas e as tarifas e bandeiras do usuário
+// @Tags         energia
+// @Produce      json
+// @Param        id      path      int     true   "ID do Ambiente"
+// @Param        inicio  query     string  false  "Primeiro dia (AAAA-MM-DD, padrão: fim - 29 dias)"
+// @Param        fim     query     string  false  "Último dia (AAAA-MM-DD, padrão: hoje)"
+// @Success      200     {object}  dto.ConsumoEnergiaDTO
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/energia [get]
+func (c *EnergiaController) ConsumoAmbiente(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaEnergiaDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para consultar consumo de energia")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	consumo, err := c.servico.ConsumoAmbiente(ambienteID, usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao estimar consumo de energia")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, consumo)
+}
+
+// ConsumoDiario godoc
+// @Summary      Estima o consumo e o custo de energia de um diário de cultivo
+// @Description  Soma os ambientes do diário e das suas plantas, do início ao fim do cultivo (ou até hoje),
+// @Description  e calcula o custo por grama com o peso seco registrado nas plantas
+// @Tags         energia
+// @Produce      json
+// @Param        id   path      int  true  "ID do Diário de Cultivo"
+// @Success      200  {object}  dto.ConsumoDiarioCultivoDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/diarios-cultivo/{id}/energia [get]
+func (c *EnergiaController) ConsumoDiario(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	diarioID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	consumo, err := c.servico.ConsumoDiario(diarioID, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Diário de cultivo não encontrado", "Erro interno ao estimar consumo de energia do diário")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, consumo)
+}
+
+func (c *EnergiaController) responderErro(ctx *gin.Context, err error, naoEncontrado, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, naoEncontrado, err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type EstagioCrescimentoController struct {
+	servico service.EstagioCrescimentoService
+}
+
+func NewEstagioCrescimentoController(servico service.EstagioCrescimentoService) *EstagioCrescimentoController {
+	return &EstagioCrescimentoController{servico}
+}
+
+// MudarEstagio godoc
+// @Summary      Muda o estágio de crescimento de uma planta
+// @Description  Encerra o estágio atual da planta e inicia o novo estágio informado
+// @Tags         planta
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                  true  "ID da Planta"
+// @Param        estagio  body      dto.MudarEstagioDTO  true  "Novo estágio"
+// @Success      201      {object}  entity.EstagioCrescimento
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/plantas/{id}/estagios [post]
+func (c *EstagioCrescimentoController) MudarEstagio(ctx *gin.Context) {
+	plantaID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var estagioDto dto.MudarEstagioDTO
+	if err := ctx.ShouldBindJSON(&estagioDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para mudar estágio da planta")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	estagio, err := c.servico.MudarEstagio(plantaID, &estagioDto)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			utils.RespondWithError(ctx, http.StatusNotFound, "Planta não encontrada", err.Error())
+			return
+		}
+		logrus.WithError(err).Error("Erro ao mudar estágio da planta")
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Erro interno ao mudar estágio", err.Error())
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, estagio)
+}
+
+// ListarHistorico godoc
+// @Summary      Lista o histórico de estágios de uma planta
+// @Description  Retorna os estágios de crescimento da planta em ordem cronológica
+// @Tags         planta
+// @Produce      json
+// @Param        id   path      int  true  "ID da Planta"
+// @Success      200  {array}   entity.EstagioCrescimento
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/plantas/{id}/estagios [get]
+func (c *EstagioCrescimentoController) ListarHistorico(ctx *gin.Context) {
+	plantaID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	estagios, err := c.servico.ListarHistorico(plantaID)
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao listar estágios da planta")
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Erro interno ao listar estágios", err.Error())
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, estagios)
+}
@@ -0,0 +1,372 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type EstoqueController struct {
+	servico service.EstoqueService
+}
+
+func NewEstoqueController(servico service.EstoqueService) *EstoqueController {
+	return &EstoqueController{servico}
+}
+
+// CriarItem godoc
+// @Summary      Cadastra um item no estoque
+// @Description  Nutriente, substrato, vaso, defensivo, semente ou outro insumo. A quantidade inicial entra como ajuste valorizado pelo custo unitário informado
+// @Tags         estoque
+// @Accept       json
+// @Produce      json
+// @Param        item  body      dto.ItemEstoqueDTO  true  "Item"
+// @Success      201   {object}  entity.ItemEstoque
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/v1/estoque/itens [post]
+func (c *EstoqueController) CriarItem(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var itemDto dto.ItemEstoqueDTO
+	if err := ctx.ShouldBindJSON(&itemDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar item de estoque")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	item, err := c.servico.CriarItem(usuarioID, &itemDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao criar item de estoque")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, item)
+}
+
+// ListarItens godoc
+// @Summary      Lista o estoque do usuário
+// @Description  Ordenado por categoria e nome
+// @Tags         estoque
+// @Produce      json
+// @Param        categoria         query     string  false  "nutriente, substrato, vaso, defensivo, semente ou outro"
+// @Param        abaixo_minimo     query     bool    false  "Só os itens no estoque mínimo ou abaixo dele"
+// @Param        vencendo_em_dias  query     int     false  "Só os itens que vencem nos próximos dias, incluindo os vencidos"
+// @Success      200               {array}   entity.ItemEstoque
+// @Failure      400               {object}  map[string]string
+// @Failure      401               {object}  map[string]string
+// @Failure      500               {object}  map[string]string
+// @Router       /api/v1/estoque/itens [get]
+func (c *EstoqueController) ListarItens(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaItensEstoqueDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar estoque")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	itens, err := c.servico.ListarItens(usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar estoque")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, itens)
+}
+
+// BuscarItem godoc
+// @Summary      Busca um item do estoque por ID
+// @Tags         estoque
+// @Produce      json
+// @Param        id   path      int  true  "ID do Item"
+// @Success      200  {object}  entity.ItemEstoque
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/estoque/itens/{id} [get]
+func (c *EstoqueController) BuscarItem(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	item, err := c.servico.BuscarItem(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar item de estoque")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, item)
+}
+
+// AtualizarItem godoc
+// @Summary      Atualiza um item do estoque
+// @Description  Altera os dados cadastrais; o saldo muda só pelas compras, consumos e ajustes. A unidade só pode mudar com o estoque zerado
+// @Tags         estoque
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                 true  "ID do Item"
+// @Param        item  body      dto.ItemEstoqueDTO  true  "Item"
+// @Success      200   {object}  entity.ItemEstoque
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/v1/estoque/itens/{id} [put]
+func (c *EstoqueController) AtualizarItem(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var itemDto dto.ItemEstoqueDTO
+	if err := ctx.ShouldBindJSON(&itemDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar item de estoque")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	item, err := c.servico.AtualizarItem(id, usuarioID, &itemDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar item de estoque")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, item)
+}
+
+// DeletarItem godoc
+// @Summary      Remove um item do estoque
+// @Description  Os consumos do item continuam no custo dos ciclos em que foram registrados
+// @Tags         estoque
+// @Param        id   path      int  true  "ID do Item"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/estoque/itens/{id} [delete]
+func (c *EstoqueController) DeletarItem(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.DeletarItem(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar item de estoque")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RegistrarCompra godoc
+// @Summary      Registra a compra de um item
+// @Description  Soma a quantidade ao saldo e recalcula o custo médio pela média ponderada; a validade informada passa a ser a do item
+// @Tags         estoque
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                   true  "ID do Item"
+// @Param        compra  body      dto.CompraEstoqueDTO  true  "Compra"
+// @Success      201     {object}  entity.MovimentacaoEstoque
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/estoque/itens/{id}/compras [post]
+func (c *EstoqueController) RegistrarCompra(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var compraDto dto.CompraEstoqueDTO
+	if err := ctx.ShouldBindJSON(&compraDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar compra")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	compra, err := c.servico.RegistrarCompra(id, usuarioID, &compraDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar compra")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, compra)
+}
+
+// RegistrarConsumo godoc
+// @Summary      Registra o consumo de um item
+// @Description  Saída manual, como a aplicação de um defensivo; regas e tarefas concluídas dão baixa automaticamente. Pode ser ligada a uma planta ou diário para o custo do ciclo
+// @Tags         estoque
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                    true  "ID do Item"
+// @Param        consumo  body      dto.ConsumoEstoqueDTO  true  "Consumo"
+// @Success      201      {object}  entity.MovimentacaoEstoque
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/estoque/itens/{id}/consumos [post]
+func (c *EstoqueController) RegistrarConsumo(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consumoDto dto.ConsumoEstoqueDTO
+	if err := ctx.ShouldBindJSON(&consumoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar consumo")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	consumo, err := c.servico.RegistrarConsumo(id, usuarioID, &consumoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar consumo")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, consumo)
+}
+
+// Ajustar godoc
+// @Summary      Ajusta o saldo pela contagem do inventário
+// @Tags         estoque
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                   true  "ID do Item"
+// @Param        ajuste  body      dto.AjusteEstoqueDTO  true  "Ajuste"
+// @Success      201     {object}  entity.MovimentacaoEstoque
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/estoque/itens/{id}/ajustes [post]
+func (c *EstoqueController) Ajustar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var ajusteDto dto.AjusteEstoqueDTO
+	if err := ctx.ShouldBindJSON(&ajusteDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para ajustar estoque")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	ajuste, err := c.servico.Ajustar(id, usuarioID, &ajusteDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao ajustar estoque")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, ajuste)
+}
+
+// ListarMovimentacoes godoc
+// @Summary      Lista as movimentações de um item
+// @Description  Compras, consumos e ajustes, das mais recentes para as mais antigas
+// @Tags         estoque
+// @Produce      json
+// @Param        id   path      int  true  "ID do Item"
+// @Success      200  {array}   entity.MovimentacaoEstoque
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/estoque/itens/{id}/movimentacoes [get]
+func (c *EstoqueController) ListarMovimentacoes(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	movimentacoes, err := c.servico.ListarMovimentacoes(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar movimentações de estoque")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, movimentacoes)
+}
+
+// CustoCiclo godoc
+// @Summary      Custo dos insumos do ciclo de um diário de cultivo
+// @Description  Consumos lançados no diário e nas plantas dele durante o ciclo, valorizados pelo custo médio das compras, por item, por categoria e por planta
+// @Tags         estoque
+// @Produce      json
+// @Param        id   path      int  true  "ID do Diário de Cultivo"
+// @Success      200  {object}  dto.CustoCicloDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/diarios-cultivo/{id}/custos [get]
+func (c *EstoqueController) CustoCiclo(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	custo, err := c.servico.CustoCiclo(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao calcular custo do ciclo")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, custo)
+}
+
+func (c *EstoqueController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Registro não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEstoqueService é um mock para o service.EstoqueService
+type MockEstoqueService struct {
+	mock.Mock
+}
+
+func (m *MockEstoqueService) RegaRegistrada(rega *entity.Rega) error {
+	return m.Called(rega).Error(0)
+}
+
+func (m *MockEstoqueService) PrepararBaixa(usuarioID uint, baixa service.BaixaEstoque, consumos []dto.ItemConsumidoDTO) (*repository.MovimentacoesEstoque, error) {
+	args := m.Called(usuarioID, baixa, consumos)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.MovimentacoesEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueService) CriarItem(usuarioID uint, itemDto *dto.ItemEstoqueDTO) (*entity.ItemEstoque, error) {
+	args := m.Called(usuarioID, itemDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueService) BuscarItem(id, usuarioID uint) (*entity.ItemEstoque, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueService) ListarItens(usuarioID uint, consulta *dto.ConsultaItensEstoqueDTO) ([]entity.ItemEstoque, error) {
+	args := m.Called(usuarioID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ItemEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueService) AtualizarItem(id, usuarioID uint, itemDto *dto.ItemEstoqueDTO) (*entity.ItemEstoque, error) {
+	args := m.Called(id, usuarioID, itemDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueService) DeletarItem(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+func (m *MockEstoqueService) RegistrarCompra(id, usuarioID uint, compraDto *dto.CompraEstoqueDTO) (*entity.MovimentacaoEstoque, error) {
+	args := m.Called(id, usuarioID, compraDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.MovimentacaoEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueService) RegistrarConsumo(id, usuarioID uint, consumoDto *dto.ConsumoEstoqueDTO) (*entity.MovimentacaoEstoque, error) {
+	args := m.Called(id, usuarioID, consumoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.MovimentacaoEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueService) Ajustar(id, usuarioID uint, ajusteDto *dto.AjusteEstoqueDTO) (*entity.MovimentacaoEstoque, error) {
+	args := m.Called(id, usuarioID, ajusteDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.MovimentacaoEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueService) ListarMovimentacoes(id, usuarioID uint) ([]entity.MovimentacaoEstoque, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.MovimentacaoEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueService) CustoCiclo(diarioID, usuarioID uint) (*dto.CustoCicloDTO, error) {
+	args := m.Called(diarioID, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CustoCicloDTO), args.Error(1)
+}
+
+func routerEstoque(mockService *MockEstoqueService) *gin.Engine {
+	controlador := NewEstoqueController(mockService)
+	router := novoRouterTeste()
+	router.POST("/estoque/itens", controlador.CriarItem)
+	router.GET("/estoque/itens", controlador.ListarItens)
+	router.GET("/estoque/itens/:id", controlador.BuscarItem)
+	router.PUT("/estoque/itens/:id", controlador.AtualizarItem)
+	router.DELETE("/estoque/itens/:id", controlador.DeletarItem)
+	router.POST("/estoque/itens/:id/compras", controlador.RegistrarCompra)
+	router.POST("/estoque/itens/:id/consumos", controlador.RegistrarConsumo)
+	router.POST("/estoque/itens/:id/ajustes", controlador.Ajustar)
+	router.GET("/estoque/itens/:id/movimentacoes", controlador.ListarMovimentacoes)
+	router.GET("/diarios-cultivo/:id/custos", controlador.CustoCiclo)
+	return router
+}
+
+const itemEstoqueValido = `{"nome":"Grow","categoria":"nutriente","unidade":"ml","quantidade_inicial":1000,"custo_unitario":0.08}`
+
+func TestEstoqueController_CriarItem(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+		mockService.On("CriarItem", uint(7), mock.MatchedBy(func(d *dto.ItemEstoqueDTO) bool {
+			return d.Categoria == "nutriente" && d.Unidade == "ml" && d.QuantidadeInicial == 1000
+		})).Return(&entity.ItemEstoque{Nome: "Grow"}, nil).Once()
+
+		w := requisitar(routerEstoque(mockService), http.MethodPost, "/estoque/itens", itemEstoqueValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Unidade Desconhecida", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+
+		w := requisitar(routerEstoque(mockService), http.MethodPost, "/estoque/itens", `{"nome":"Grow","categoria":"nutriente","unidade":"litro"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Unidade")
+		mockService.AssertNotCalled(t, "CriarItem", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEstoqueController_ListarItens(t *testing.T) {
+	t.Run("Success - Repassa os Filtros", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+		mockService.On("ListarItens", uint(7), mock.MatchedBy(func(c *dto.ConsultaItensEstoqueDTO) bool {
+			return c.Categoria == "vaso" && c.AbaixoMinimo && c.VencendoEmDias == nil
+		})).Return([]entity.ItemEstoque{}, nil).Once()
+
+		w := requisitar(routerEstoque(mockService), http.MethodGet, "/estoque/itens?categoria=vaso&abaixo_minimo=true", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Categoria Desconhecida", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+
+		w := requisitar(routerEstoque(mockService), http.MethodGet, "/estoque/itens?categoria=ferramenta", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListarItens", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEstoqueController_BuscarItem(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+
+		w := requisitar(routerEstoque(mockService), http.MethodGet, "/estoque/itens/abc", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "BuscarItem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Item de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+		mockService.On("BuscarItem", uint(3), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerEstoque(mockService), http.MethodGet, "/estoque/itens/3", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestEstoqueController_RegistrarCompra(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+		mockService.On("RegistrarCompra", uint(3), uint(7), mock.MatchedBy(func(d *dto.CompraEstoqueDTO) bool {
+			return d.Quantidade == 500 && d.CustoTotal == 45
+		})).Return(&entity.MovimentacaoEstoque{}, nil).Once()
+
+		w := requisitar(routerEstoque(mockService), http.MethodPost, "/estoque/itens/3/compras", `{"quantidade":500,"custo_total":45}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Quantidade Obrigatória", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+
+		w := requisitar(routerEstoque(mockService), http.MethodPost, "/estoque/itens/3/compras", `{"custo_total":45}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RegistrarCompra", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEstoqueController_RegistrarConsumo(t *testing.T) {
+	t.Run("Error - Origem Desconhecida", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+
+		w := requisitar(routerEstoque(mockService), http.MethodPost, "/estoque/itens/3/consumos", `{"quantidade":10,"origem":"rega"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RegistrarConsumo", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Saldo Insuficiente", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+		mockService.On("RegistrarConsumo", uint(3), uint(7), mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerEstoque(mockService), http.MethodPost, "/estoque/itens/3/consumos", `{"quantidade":10}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+		mockService.On("RegistrarConsumo", uint(3), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerEstoque(mockService), http.MethodPost, "/estoque/itens/3/consumos", `{"quantidade":10,"planta_id":10}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestEstoqueController_Ajustar(t *testing.T) {
+	t.Run("Success - Contagem Zerada", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+		mockService.On("Ajustar", uint(3), uint(7), &dto.AjusteEstoqueDTO{QuantidadeContada: 0}).
+			Return(&entity.MovimentacaoEstoque{}, nil).Once()
+
+		w := requisitar(routerEstoque(mockService), http.MethodPost, "/estoque/itens/3/ajustes", `{"quantidade_contada":0}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Contagem Negativa", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+
+		w := requisitar(routerEstoque(mockService), http.MethodPost, "/estoque/itens/3/ajustes", `{"quantidade_contada":-1}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Ajustar", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEstoqueController_CustoCiclo(t *testing.T) {
+	t.Run("Error - Diário de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+		mockService.On("CustoCiclo", uint(5), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerEstoque(mockService), http.MethodGet, "/diarios-cultivo/5/custos", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockEstoqueService)
+		mockService.On("CustoCiclo", uint(5), uint(7)).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerEstoque(mockService), http.MethodGet, "/diarios-cultivo/5/custos", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
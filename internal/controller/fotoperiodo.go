@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type FotoperiodoController struct {
+	servico service.FotoperiodoService
+}
+
+func NewFotoperiodoController(servico service.FotoperiodoService) *FotoperiodoController {
+	return &FotoperiodoController{servico}
+}
+
+// Criar godoc
+// @Summary      Cria uma programação de luz para o ambiente
+// @Description  Horários de ligar/desligar (horário local) e rampa de nascer/pôr do sol. Programações anteriores
+// @Description  ficam no histórico; uma vigente_desde futura agenda a troca.
+// @Tags         fotoperiodo
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int                 true  "ID do Ambiente"
+// @Param        fotoperiodo  body      dto.FotoperiodoDTO  true  "Programação de luz"
+// @Success      201          {object}  entity.Fotoperiodo
+// @Failure      400          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/fotoperiodos [post]
+func (c *FotoperiodoController) Criar(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var fotoperiodoDto dto.FotoperiodoDTO
+	if err := ctx.ShouldBindJSON(&fotoperiodoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar fotoperíodo")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	fotoperiodo, err := c.servico.Criar(ambienteID, &fotoperiodoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao criar fotoperíodo")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, fotoperiodo)
+}
+
+// Virar godoc
+// @Summary      Agenda a virada de fotoperíodo
+// @Description  Cria uma programação com as horas de luz informadas (ex.: 12 para 12/12) a partir da data,
+// @Description  mantendo o horário de ligar e a rampa da programação vigente
+// @Tags         fotoperiodo
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                       true  "ID do Ambiente"
+// @Param        virada  body      dto.ViradaFotoperiodoDTO  true  "Data e horas de luz"
+// @Success      201     {object}  entity.Fotoperiodo
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/fotoperiodos/virada [post]
+func (c *FotoperiodoController) Virar(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var viradaDto dto.ViradaFotoperiodoDTO
+	if err := ctx.ShouldBindJSON(&viradaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para virada de fotoperíodo")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	fotoperiodo, err := c.servico.Virar(ambienteID, &viradaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao agendar virada de fotoperíodo")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, fotoperiodo)
+}
+
+// ListarHistorico godoc
+// @Summary      Lista o histórico de programações de luz do ambiente
+// @Tags         fotoperiodo
+// @Produce      json
+// @Param        id   path      int  true  "ID do Ambiente"
+// @Success      200  {array}   entity.Fotoperiodo
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/fotoperiodos [get]
+func (c *FotoperiodoController) ListarHistorico(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	historico, err := c.servico.ListarHistorico(ambienteID)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao listar fotoperíodos")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, historico)
+}
+
+// BuscarVigente godoc
+// @Summary      Busca a programação de luz vigente do ambiente
+// @Tags         fotoperiodo
+// @Produce      json
+// @Param        id   path      int  true  "ID do Ambiente"
+// @Success      200  {object}  entity.Fotoperiodo
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/fotoperiodos/vigente [get]
+func (c *FotoperiodoController) BuscarVigente(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	fotoperiodo, err := c.servico.BuscarVigente(ambienteID)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente sem fotoperíodo vigente", "Erro interno ao buscar fotoperíodo vigente")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, fotoperiodo)
+}
+
+// HorasLuz godoc
+// @Summary      Calcula as horas de luz do ambiente dia a dia
+// @Description  Considera as trocas agendadas; sem programação, usa o tempo de exposição do ambiente
+// @Tags         fotoperiodo
+// @Produce      json
+// @Param        id      path      int     true   "ID do Ambiente"
+// @Param        inicio  query     string  false  "Primeiro dia (AAAA-MM-DD, padrão: hoje)"
+// @Param        fim     query     string  false  "Último dia (AAAA-MM-DD, padrão: inicio + 6 dias)"
+// @Success      200     {array}   dto.HorasLuzDiaDTO
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/fotoperiodos/horas-luz [get]
+func (c *FotoperiodoController) HorasLuz(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaHorasLuzDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para consultar horas de luz")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	dias, err := c.servico.HorasLuz(ambienteID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao calcular horas de luz")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, dias)
+}
+
+// Sugestao godoc
+// @Summary      Sugere a troca de fotoperíodo conforme o estágio das plantas
+// @Description  Compara as horas de luz de hoje com o estágio predominante; quando a troca é sugerida,
+// @Description  o campo virada pode ser enviado a POST /ambientes/{id}/fotoperiodos/virada
+// @Tags         fotoperiodo
+// @Produce      json
+// @Param        id   path      int  true  "ID do Ambiente"
+// @Success      200  {object}  dto.SugestaoFotoperiodoDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/fotoperiodos/sugestao [get]
+func (c *FotoperiodoController) Sugestao(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	sugestao, err := c.servico.Sugestao(ambienteID)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao sugerir fotoperíodo")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, sugestao)
+}
+
+// Deletar godoc
+// @Summary      Remove uma programação de luz
+// @Tags         fotoperiodo
+// @Param        id   path  int  true  "ID do Fotoperíodo"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/fotoperiodos/{id} [delete]
+func (c *FotoperiodoController) Deletar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id); err != nil {
+		c.responderErro(ctx, err, "Fotoperíodo não encontrado", "Erro interno ao deletar fotoperíodo")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *FotoperiodoController) responderErro(ctx *gin.Context, err error, naoEncontrado, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, naoEncontrado, err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -2,8 +2,10 @@ package controller
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
@@ -15,6 +17,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// tamanhoMaximoCatalogoGeneticas limita o corpo das importações do catálogo de genéticas
+const tamanhoMaximoCatalogoGeneticas = 2 << 20
+
 type GeneticaController struct {
 	servico service.GeneticaService
 }
@@ -52,6 +57,10 @@ func (ctrl *GeneticaController) Criar(c *gin.Context) {
 	}
 
 	geneticaCriada, err := ctrl.servico.Criar(&dto)
+	if errors.Is(err, utils.ErrInvalidInput) {
+		utils.RespondWithError(c, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+		return
+	}
 	if err != nil {
 		logrus.WithError(err).Error("Erro ao criar genética")
 		utils.RespondWithError(c, http.StatusInternalServerError, "Erro interno ao criar genética", err.Error())
@@ -175,6 +184,10 @@ func (c *GeneticaController) Atualizar(ctx *gin.Context) {
 		utils.RespondWithError(ctx, http.StatusNotFound, "Genética não encontrada", utils.ErrNotFound.Error())
 		return
 	}
+	if errors.Is(err, utils.ErrInvalidInput) {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+		return
+	}
 	if err != nil {
 		logrus.WithError(err).Error("Erro ao atualizar genética")
 		utils.RespondWithError(ctx, http.StatusInternalServerError, "Erro interno ao atualizar genética", err.Error())
@@ -216,3 +229,232 @@ func (c *GeneticaController) Deletar(ctx *gin.Context) {
 	}
 	utils.RespondWithJSON(ctx, http.StatusOK, gin.H{"message": "Genética deletada com sucesso"})
 }
+
+// ImportarJSON godoc
+// @Summary      Importa genéticas em JSON
+// @Description  Aceita uma lista de genéticas no corpo ou no campo "arquivo" de um multipart/form-data, até 2 MB, no mesmo formato da criação ou da exportação. Genéticas com nome e breeder (origem) parecidos com outra do catálogo ou do próprio arquivo são ignoradas e listadas no resultado; um registro inválido rejeita o arquivo
+// @Tags         genetica
+// @Accept       json
+// @Accept       mpfd
+// @Produce      json
+// @Param        arquivo  formData  file  false  "Arquivo JSON (multipart)"
+// @Success      201      {object}  dto.ResultadoImportacaoGeneticasDTO
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      413      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/geneticas/importar/json [post]
+func (c *GeneticaController) ImportarJSON(ctx *gin.Context) {
+	corpo, fechar, err := c.arquivoImportado(ctx)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro ao ler o arquivo JSON enviado")
+		return
+	}
+	defer fechar()
+
+	resultado, err := c.servico.ImportarJSON(corpo)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao importar genéticas")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, resultado)
+}
+
+// ImportarCSV godoc
+// @Summary      Importa genéticas em CSV
+// @Description  Aceita o CSV no corpo (text/csv) ou no campo "arquivo" de um multipart/form-data, até 2 MB. Uma linha por genética, com as colunas nome, origem, tipo_genetica, tipo_especie e tempo_floracao e, opcionalmente, descricao e caracteristicas. Duplicadas são ignoradas como na importação JSON
+// @Tags         genetica
+// @Accept       plain
+// @Accept       mpfd
+// @Produce      json
+// @Param        arquivo      formData  file    false  "Arquivo CSV (multipart)"
+// @Param        delimitador  query     string  false  "virgula, ponto_virgula ou tab (padrão: virgula)"
+// @Success      201          {object}  dto.ResultadoImportacaoGeneticasDTO
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      413          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/geneticas/importar/csv [post]
+func (c *GeneticaController) ImportarCSV(ctx *gin.Context) {
+	var opcoes dto.ImportacaoGeneticasCSVDTO
+	if err := ctx.ShouldBindQuery(&opcoes); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para importar genéticas")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	corpo, fechar, err := c.arquivoImportado(ctx)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro ao ler o arquivo CSV enviado")
+		return
+	}
+	defer fechar()
+
+	resultado, err := c.servico.ImportarCSV(corpo, &opcoes)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao importar genéticas")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, resultado)
+}
+
+// Exportar godoc
+// @Summary      Exporta o catálogo de genéticas
+// @Description  Todas as genéticas, ordenadas por nome, em JSON (mesmo formato aceito na importação) ou CSV
+// @Tags         genetica
+// @Produce      json
+// @Produce      text/csv
+// @Param        formato  query     string  false  "json ou csv (padrão: json)"
+// @Success      200      {array}   dto.GeneticaResponseDTO
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/geneticas/exportar [get]
+func (c *GeneticaController) Exportar(ctx *gin.Context) {
+	var exportacao dto.ExportacaoGeneticasDTO
+	if err := ctx.ShouldBindQuery(&exportacao); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para exportar genéticas")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	if exportacao.Formato == "csv" {
+		conteudo, err := c.servico.ExportarCSV()
+		if err != nil {
+			c.responderErro(ctx, err, "Erro interno ao exportar genéticas")
+			return
+		}
+		ctx.Header("Content-Disposition", `attachment; filename="geneticas.csv"`)
+		ctx.Data(http.StatusOK, "text/csv; charset=utf-8", conteudo)
+		return
+	}
+	conteudo, err := c.servico.ExportarJSON()
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao exportar genéticas")
+		return
+	}
+	ctx.Header("Content-Disposition", `attachment; filename="geneticas.json"`)
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", conteudo)
+}
+
+// Duplicadas godoc
+// @Summary      Lista genéticas possivelmente duplicadas
+// @Description  Agrupa as genéticas do catálogo com nome e breeder (origem) parecidos, ignorando caixa, acentos, pontuação e palavras como "seeds". Em cada grupo a mais antiga vem primeiro
+// @Tags         genetica
+// @Produce      json
+// @Success      200  {array}   dto.GrupoGeneticasDuplicadasDTO
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/geneticas/duplicadas [get]
+func (c *GeneticaController) Duplicadas(ctx *gin.Context) {
+	grupos, err := c.servico.BuscarDuplicadas()
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar genéticas duplicadas")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, grupos)
+}
+
+// Mesclar godoc
+// @Summary      Mescla genéticas duplicadas
+// @Description  Mantém a genética do caminho, passa para ela as plantas e os pacotes de sementes das duplicadas e remove as duplicadas. Descrição, características e floração vazias são preenchidas com as das duplicadas
+// @Tags         genetica
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int                        true  "ID da Genética mantida"
+// @Param        mesclagem  body      dto.MesclagemGeneticasDTO  true  "Genéticas duplicadas"
+// @Success      200        {object}  dto.ResultadoMesclagemGeneticasDTO
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      404        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /api/v1/geneticas/{id}/mesclar [post]
+func (c *GeneticaController) Mesclar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var mesclagemDto dto.MesclagemGeneticasDTO
+	if err := ctx.ShouldBindJSON(&mesclagemDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para mesclar genéticas")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	resultado, err := c.servico.Mesclar(id, &mesclagemDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao mesclar genéticas")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, resultado)
+}
+
+// Pedigree godoc
+// @Summary      Árvore de ancestrais da genética
+// @Description  Mãe e pai de cada genética, recursivamente, até a profundidade pedida. Em autocruzamentos (S1...) mãe e pai são a mesma genética
+// @Tags         genetica
+// @Produce      json
+// @Param        id            path      int  true   "ID da Genética"
+// @Param        profundidade  query     int  false  "Gerações de ancestrais, de 1 a 10 (padrão: 5)"
+// @Success      200           {object}  dto.PedigreeGeneticaDTO
+// @Failure      400           {object}  map[string]string
+// @Failure      401           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /api/v1/geneticas/{id}/pedigree [get]
+func (c *GeneticaController) Pedigree(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaPedigreeDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para o pedigree da genética")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	pedigree, err := c.servico.Pedigree(id, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao montar pedigree da genética")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, pedigree)
+}
+
+// arquivoImportado retorna o corpo da requisição ou o campo "arquivo" do multipart, limitado
+// a tamanhoMaximoCatalogoGeneticas
+func (c *GeneticaController) arquivoImportado(ctx *gin.Context) (io.Reader, func(), error) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, tamanhoMaximoCatalogoGeneticas)
+	if !strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		return ctx.Request.Body, func() {}, nil
+	}
+	arquivo, err := ctx.FormFile("arquivo")
+	if err != nil {
+		return nil, nil, err
+	}
+	aberto, err := arquivo.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	return aberto, func() { aberto.Close() }, nil
+}
+
+func (c *GeneticaController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	var errTamanho *http.MaxBytesError
+	switch {
+	case errors.As(err, &errTamanho):
+		utils.RespondWithError(ctx, http.StatusRequestEntityTooLarge, "Arquivo maior que o limite de importação", err.Error())
+	case errors.Is(err, http.ErrMissingFile):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Campo \"arquivo\" ausente", err.Error())
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Genética não encontrada", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
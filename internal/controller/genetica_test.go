@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -47,6 +48,41 @@ func (m *MockGeneticaService) Deletar(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockGeneticaService) ImportarJSON(arquivo io.Reader) (*dto.ResultadoImportacaoGeneticasDTO, error) {
+	args := m.Called(arquivo)
+	return args.Get(0).(*dto.ResultadoImportacaoGeneticasDTO), args.Error(1)
+}
+
+func (m *MockGeneticaService) ImportarCSV(arquivo io.Reader, opcoes *dto.ImportacaoGeneticasCSVDTO) (*dto.ResultadoImportacaoGeneticasDTO, error) {
+	args := m.Called(arquivo, opcoes)
+	return args.Get(0).(*dto.ResultadoImportacaoGeneticasDTO), args.Error(1)
+}
+
+func (m *MockGeneticaService) ExportarJSON() ([]byte, error) {
+	args := m.Called()
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockGeneticaService) ExportarCSV() ([]byte, error) {
+	args := m.Called()
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockGeneticaService) BuscarDuplicadas() ([]dto.GrupoGeneticasDuplicadasDTO, error) {
+	args := m.Called()
+	return args.Get(0).([]dto.GrupoGeneticasDuplicadasDTO), args.Error(1)
+}
+
+func (m *MockGeneticaService) Mesclar(id uint, mesclagemDto *dto.MesclagemGeneticasDTO) (*dto.ResultadoMesclagemGeneticasDTO, error) {
+	args := m.Called(id, mesclagemDto)
+	return args.Get(0).(*dto.ResultadoMesclagemGeneticasDTO), args.Error(1)
+}
+
+func (m *MockGeneticaService) Pedigree(id uint, consulta *dto.ConsultaPedigreeDTO) (*dto.PedigreeGeneticaDTO, error) {
+	args := m.Called(id, consulta)
+	return args.Get(0).(*dto.PedigreeGeneticaDTO), args.Error(1)
+}
+
 func TestGeneticaController_Listar(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
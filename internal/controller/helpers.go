@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// responderErroBinding responde 400 com as mensagens de validação por campo, quando houver.
+func responderErroBinding(ctx *gin.Context, err error) {
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		errMsgs := make(map[string]string)
+		for _, fe := range ve {
+			errMsgs[fe.Field()] = utils.GetErrorMsg(fe)
+		}
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Erro de validação", errMsgs)
+		return
+	}
+	utils.RespondWithError(ctx, http.StatusBadRequest, "Requisição inválida", err.Error())
+}
+
+// parametroID lê um parâmetro de rota numérico e positivo. Responde 400 e retorna false se inválido.
+func parametroID(ctx *gin.Context, nome string) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param(nome), 10, 32)
+	if err != nil || id == 0 {
+		utils.RespondWithError(ctx, http.StatusBadRequest, "ID inválido", utils.ErrInvalidInput.Error())
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// usuarioAutenticado lê o ID do usuário definido pelo AuthMiddleware. Responde 401 e retorna false se ausente.
+func usuarioAutenticado(ctx *gin.Context) (uint, bool) {
+	valor, _ := ctx.Get("userID")
+	texto, _ := valor.(string)
+	id, err := strconv.ParseUint(texto, 10, 32)
+	if err != nil || id == 0 {
+		utils.RespondWithError(ctx, http.StatusUnauthorized, "Usuário não autenticado", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
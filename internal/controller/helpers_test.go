@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// novoRouterTeste cria um router de teste com o usuário 7 já autenticado
+func novoRouterTeste() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("userID", "7")
+		ctx.Next()
+	})
+	return router
+}
+
+// requisitar executa uma requisição no router; o corpo, quando informado, é enviado como JSON
+func requisitar(router *gin.Engine, metodo, caminho, corpo string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(metodo, caminho, strings.NewReader(corpo))
+	if corpo != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type LayoutController struct {
+	servico service.LayoutService
+}
+
+func NewLayoutController(servico service.LayoutService) *LayoutController {
+	return &LayoutController{servico}
+}
+
+// Posicionar godoc
+// @Summary      Posiciona uma planta ou vaso no ambiente
+// @Description  Coordenadas do centro do item em cm a partir do canto do ambiente (X no comprimento, Y na largura).
+// @Description  Sem largura_cm, usa o diâmetro do vaso. O item precisa caber no ambiente e não sobrepor os demais.
+// @Tags         layout
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                   true  "ID do Ambiente"
+// @Param        posicao  body      dto.PosicaoLayoutDTO  true  "Posição"
+// @Success      201      {object}  entity.PosicaoLayout
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/layout [post]
+func (c *LayoutController) Posicionar(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var posicaoDto dto.PosicaoLayoutDTO
+	if err := ctx.ShouldBindJSON(&posicaoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para posicionar item no layout")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	posicao, err := c.servico.Posicionar(ambienteID, &posicaoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao posicionar item no layout")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, posicao)
+}
+
+// Ocupacao godoc
+// @Summary      Lista o layout do ambiente com a ocupação da área de cultivo
+// @Tags         layout
+// @Produce      json
+// @Param        id   path      int  true  "ID do Ambiente"
+// @Success      200  {object}  dto.OcupacaoLayoutDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/layout [get]
+func (c *LayoutController) Ocupacao(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	ocupacao, err := c.servico.Ocupacao(ambienteID)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao calcular ocupação do layout")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, ocupacao)
+}
+
+// PlantaBaixa godoc
+// @Summary      Desenha a planta baixa do ambiente em SVG
+// @Tags         layout
+// @Produce      image/svg+xml
+// @Param        id     path      int     true   "ID do Ambiente"
+// @Param        grade  query     number  false  "Espaçamento da grade em cm (padrão: 30)"
+// @Success      200    {string}  string  "SVG"
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/layout/svg [get]
+func (c *LayoutController) PlantaBaixa(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaPlantaBaixaDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para desenhar a planta baixa")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	svg, err := c.servico.PlantaBaixaSVG(ambienteID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao desenhar a planta baixa")
+		return
+	}
+	ctx.Data(http.StatusOK, "image/svg+xml", svg)
+}
+
+// Atualizar godoc
+// @Summary      Move ou redimensiona um item do layout
+// @Tags         layout
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                   true  "ID da Posição"
+// @Param        posicao  body      dto.PosicaoLayoutDTO  true  "Posição"
+// @Success      200      {object}  entity.PosicaoLayout
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/layout/{id} [put]
+func (c *LayoutController) Atualizar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var posicaoDto dto.PosicaoLayoutDTO
+	if err := ctx.ShouldBindJSON(&posicaoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar item do layout")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	posicao, err := c.servico.Atualizar(id, &posicaoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Posição não encontrada", "Erro interno ao atualizar item do layout")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, posicao)
+}
+
+// Remover godoc
+// @Summary      Remove um item do layout
+// @Tags         layout
+// @Param        id   path  int  true  "ID da Posição"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/layout/{id} [delete]
+func (c *LayoutController) Remover(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Remover(id); err != nil {
+		c.responderErro(ctx, err, "Posição não encontrada", "Erro interno ao remover item do layout")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *LayoutController) responderErro(ctx *gin.Context, err error, naoEncontrado, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, naoEncontrado, err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
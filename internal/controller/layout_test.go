@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLayoutService é um mock para o service.LayoutService
+type MockLayoutService struct {
+	mock.Mock
+}
+
+func (m *MockLayoutService) Posicionar(ambienteID uint, posicaoDto *dto.PosicaoLayoutDTO) (*entity.PosicaoLayout, error) {
+	args := m.Called(ambienteID, posicaoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PosicaoLayout), args.Error(1)
+}
+
+func (m *MockLayoutService) Atualizar(id uint, posicaoDto *dto.PosicaoLayoutDTO) (*entity.PosicaoLayout, error) {
+	args := m.Called(id, posicaoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PosicaoLayout), args.Error(1)
+}
+
+func (m *MockLayoutService) Remover(id uint) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockLayoutService) Ocupacao(ambienteID uint) (*dto.OcupacaoLayoutDTO, error) {
+	args := m.Called(ambienteID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OcupacaoLayoutDTO), args.Error(1)
+}
+
+func (m *MockLayoutService) PlantaBaixaSVG(ambienteID uint, consulta *dto.ConsultaPlantaBaixaDTO) ([]byte, error) {
+	args := m.Called(ambienteID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func routerLayout(mockService *MockLayoutService) *gin.Engine {
+	controlador := NewLayoutController(mockService)
+	router := novoRouterTeste()
+	router.POST("/ambientes/:id/layout", controlador.Posicionar)
+	router.GET("/ambientes/:id/layout", controlador.Ocupacao)
+	router.GET("/ambientes/:id/layout/svg", controlador.PlantaBaixa)
+	router.PUT("/layout/:id", controlador.Atualizar)
+	router.DELETE("/layout/:id", controlador.Remover)
+	return router
+}
+
+func TestLayoutController_Posicionar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+		mockService.On("Posicionar", uint(3), mock.MatchedBy(func(p *dto.PosicaoLayoutDTO) bool {
+			return *p.VasoID == 2 && p.X == 30 && p.Y == 45
+		})).Return(&entity.PosicaoLayout{AmbienteID: 3}, nil).Once()
+
+		w := requisitar(routerLayout(mockService), http.MethodPost, "/ambientes/3/layout", `{"vaso_id":2,"x":30,"y":45}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Formato Desconhecido", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+
+		w := requisitar(routerLayout(mockService), http.MethodPost, "/ambientes/3/layout", `{"x":30,"y":45,"formato":"triangular"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Formato")
+		mockService.AssertNotCalled(t, "Posicionar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Item Sobreposto", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+		mockService.On("Posicionar", uint(3), mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerLayout(mockService), http.MethodPost, "/ambientes/3/layout", `{"x":30,"y":45}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error - Ambiente de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+		mockService.On("Posicionar", uint(3), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerLayout(mockService), http.MethodPost, "/ambientes/3/layout", `{"x":30,"y":45}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLayoutController_PlantaBaixa(t *testing.T) {
+	t.Run("Success - Responde SVG", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+		mockService.On("PlantaBaixaSVG", uint(3), &dto.ConsultaPlantaBaixaDTO{GradeCm: 50}).Return([]byte("<svg/>"), nil).Once()
+
+		w := requisitar(routerLayout(mockService), http.MethodGet, "/ambientes/3/layout/svg?grade=50", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+		assert.Equal(t, "<svg/>", w.Body.String())
+	})
+
+	t.Run("Error - Grade Fora do Intervalo", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+
+		w := requisitar(routerLayout(mockService), http.MethodGet, "/ambientes/3/layout/svg?grade=1", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "PlantaBaixaSVG", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLayoutController_Ocupacao(t *testing.T) {
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+		mockService.On("Ocupacao", uint(3)).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerLayout(mockService), http.MethodGet, "/ambientes/3/layout", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestLayoutController_Atualizar(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+
+		w := requisitar(routerLayout(mockService), http.MethodPut, "/layout/-1", `{"x":30,"y":45}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Atualizar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Posição Não Encontrada", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+		mockService.On("Atualizar", uint(4), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerLayout(mockService), http.MethodPut, "/layout/4", `{"x":30,"y":45}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Posição não encontrada")
+	})
+}
+
+func TestLayoutController_Remover(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockLayoutService)
+		mockService.On("Remover", uint(4)).Return(nil).Once()
+
+		w := requisitar(routerLayout(mockService), http.MethodDelete, "/layout/4", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,332 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type LembreteController struct {
+	servico service.LembreteService
+}
+
+func NewLembreteController(servico service.LembreteService) *LembreteController {
+	return &LembreteController{servico}
+}
+
+// Criar godoc
+// @Summary      Cria um lembrete
+// @Description  Lembrete único ou recorrente (diária, semanal ou mensal), entregue no app e nos canais escolhidos
+// @Tags         lembretes
+// @Accept       json
+// @Produce      json
+// @Param        lembrete  body      dto.LembreteDTO  true  "Lembrete"
+// @Success      201       {object}  entity.Lembrete
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /api/v1/lembretes [post]
+func (c *LembreteController) Criar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var lembreteDto dto.LembreteDTO
+	if err := ctx.ShouldBindJSON(&lembreteDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar lembrete")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	lembrete, err := c.servico.Criar(usuarioID, &lembreteDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao criar lembrete")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, lembrete)
+}
+
+// Listar godoc
+// @Summary      Lista os lembretes do usuário
+// @Description  Retorna os lembretes do usuário autenticado, dos mais recentes para os mais antigos
+// @Tags         lembretes
+// @Produce      json
+// @Param        status  query     string  false  "Filtra por status: agendado, nao_lido ou lido"
+// @Param        page    query     int     false  "Número da página (padrão: 1)"
+// @Param        limit   query     int     false  "Limite de itens por página (padrão: 10)"
+// @Success      200     {object}  dto.PaginatedResponse{data=[]entity.Lembrete}
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/lembretes [get]
+func (c *LembreteController) Listar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaLembretesDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar lembretes")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	lembretes, total, err := c.servico.Listar(usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar lembretes")
+		return
+	}
+
+	dataBytes, err := json.Marshal(lembretes)
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao serializar lembretes para resposta paginada")
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Erro interno ao listar lembretes", err.Error())
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, &dto.PaginatedResponse{
+		Data:  dataBytes,
+		Total: total,
+		Page:  consulta.Page,
+		Limit: consulta.Limit,
+	})
+}
+
+// BuscarPorID godoc
+// @Summary      Busca um lembrete por ID
+// @Tags         lembretes
+// @Produce      json
+// @Param        id   path      int  true  "ID do Lembrete"
+// @Success      200  {object}  entity.Lembrete
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/lembretes/{id} [get]
+func (c *LembreteController) BuscarPorID(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	lembrete, err := c.servico.BuscarPorID(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar lembrete")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, lembrete)
+}
+
+// Atualizar godoc
+// @Summary      Atualiza um lembrete
+// @Description  Substitui mensagem, data, recorrência e canais; o lembrete volta a ficar agendado para a nova data
+// @Tags         lembretes
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int              true  "ID do Lembrete"
+// @Param        lembrete  body      dto.LembreteDTO  true  "Lembrete"
+// @Success      200       {object}  entity.Lembrete
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /api/v1/lembretes/{id} [put]
+func (c *LembreteController) Atualizar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var lembreteDto dto.LembreteDTO
+	if err := ctx.ShouldBindJSON(&lembreteDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar lembrete")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	lembrete, err := c.servico.Atualizar(id, usuarioID, &lembreteDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar lembrete")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, lembrete)
+}
+
+// Deletar godoc
+// @Summary      Remove um lembrete
+// @Tags         lembretes
+// @Param        id   path      int  true  "ID do Lembrete"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/lembretes/{id} [delete]
+func (c *LembreteController) Deletar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar lembrete")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// Adiar godoc
+// @Summary      Adia a próxima entrega de um lembrete
+// @Description  Soneca: o lembrete é entregue de novo depois dos minutos informados
+// @Tags         lembretes
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                   true  "ID do Lembrete"
+// @Param        adiar  body      dto.AdiarLembreteDTO  true  "Minutos de adiamento"
+// @Success      200    {object}  entity.Lembrete
+// @Failure      400    {object}  map[string]string
+// @Failure      401    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /api/v1/lembretes/{id}/adiar [post]
+func (c *LembreteController) Adiar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var adiarDto dto.AdiarLembreteDTO
+	if err := ctx.ShouldBindJSON(&adiarDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para adiar lembrete")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	lembrete, err := c.servico.Adiar(id, usuarioID, &adiarDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao adiar lembrete")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, lembrete)
+}
+
+// MarcarLido godoc
+// @Summary      Marca um lembrete como lido
+// @Description  Lembretes únicos deixam de ser entregues; os recorrentes seguem para a próxima ocorrência
+// @Tags         lembretes
+// @Produce      json
+// @Param        id   path      int  true  "ID do Lembrete"
+// @Success      200  {object}  entity.Lembrete
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/lembretes/{id}/lido [post]
+func (c *LembreteController) MarcarLido(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	lembrete, err := c.servico.MarcarLido(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao marcar lembrete como lido")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, lembrete)
+}
+
+// BuscarPreferencias godoc
+// @Summary      Busca as preferências de notificação do usuário
+// @Tags         lembretes
+// @Produce      json
+// @Success      200  {object}  entity.PreferenciaNotificacao
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/preferencias-notificacao [get]
+func (c *LembreteController) BuscarPreferencias(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	preferencia, err := c.servico.BuscarPreferencias(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar preferências de notificação")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, preferencia)
+}
+
+// AtualizarPreferencias godoc
+// @Summary      Atualiza as preferências de notificação do usuário
+// @Description  Destinos de e-mail, webhook e Telegram e o horário de silêncio, durante o qual as entregas ficam para o fim da janela
+// @Tags         lembretes
+// @Accept       json
+// @Produce      json
+// @Param        preferencias  body      dto.PreferenciaNotificacaoDTO  true  "Preferências de notificação"
+// @Success      200           {object}  entity.PreferenciaNotificacao
+// @Failure      400           {object}  map[string]string
+// @Failure      401           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /api/v1/preferencias-notificacao [put]
+func (c *LembreteController) AtualizarPreferencias(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var preferenciaDto dto.PreferenciaNotificacaoDTO
+	if err := ctx.ShouldBindJSON(&preferenciaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar preferências de notificação")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	preferencia, err := c.servico.AtualizarPreferencias(usuarioID, &preferenciaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar preferências de notificação")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, preferencia)
+}
+
+func (c *LembreteController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Lembrete não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
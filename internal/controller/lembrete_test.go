@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLembreteService é um mock para o service.LembreteService
+type MockLembreteService struct {
+	mock.Mock
+}
+
+func (m *MockLembreteService) Criar(usuarioID uint, lembreteDto *dto.LembreteDTO) (*entity.Lembrete, error) {
+	args := m.Called(usuarioID, lembreteDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Lembrete), args.Error(1)
+}
+
+func (m *MockLembreteService) Listar(usuarioID uint, consulta *dto.ConsultaLembretesDTO) ([]entity.Lembrete, int64, error) {
+	args := m.Called(usuarioID, consulta)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entity.Lembrete), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockLembreteService) BuscarPorID(id, usuarioID uint) (*entity.Lembrete, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Lembrete), args.Error(1)
+}
+
+func (m *MockLembreteService) Atualizar(id, usuarioID uint, lembreteDto *dto.LembreteDTO) (*entity.Lembrete, error) {
+	args := m.Called(id, usuarioID, lembreteDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Lembrete), args.Error(1)
+}
+
+func (m *MockLembreteService) Deletar(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+func (m *MockLembreteService) Adiar(id, usuarioID uint, adiarDto *dto.AdiarLembreteDTO) (*entity.Lembrete, error) {
+	args := m.Called(id, usuarioID, adiarDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Lembrete), args.Error(1)
+}
+
+func (m *MockLembreteService) MarcarLido(id, usuarioID uint) (*entity.Lembrete, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Lembrete), args.Error(1)
+}
+
+func (m *MockLembreteService) BuscarPreferencias(usuarioID uint) (*entity.PreferenciaNotificacao, error) {
+	args := m.Called(usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PreferenciaNotificacao), args.Error(1)
+}
+
+func (m *MockLembreteService) AtualizarPreferencias(usuarioID uint, preferenciaDto *dto.PreferenciaNotificacaoDTO) (*entity.PreferenciaNotificacao, error) {
+	args := m.Called(usuarioID, preferenciaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PreferenciaNotificacao), args.Error(1)
+}
+
+func (m *MockLembreteService) DispararPendentes() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func routerLembretes(mockService *MockLembreteService) *gin.Engine {
+	controlador := NewLembreteController(mockService)
+	router := novoRouterTeste()
+	router.POST("/lembretes", controlador.Criar)
+	router.GET("/lembretes", controlador.Listar)
+	router.GET("/lembretes/:id", controlador.BuscarPorID)
+	router.PUT("/lembretes/:id", controlador.Atualizar)
+	router.DELETE("/lembretes/:id", controlador.Deletar)
+	router.POST("/lembretes/:id/adiar", controlador.Adiar)
+	router.POST("/lembretes/:id/lido", controlador.MarcarLido)
+	router.GET("/preferencias-notificacao", controlador.BuscarPreferencias)
+	router.PUT("/preferencias-notificacao", controlador.AtualizarPreferencias)
+	return router
+}
+
+const lembreteValido = `{"mensagem":"Trocar a água do reservatório","data_hora":"2026-06-01T08:00:00Z","repetir":true,"frequencia":"semanal"}`
+
+func TestLembreteController_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+		mockService.On("Criar", uint(7), mock.MatchedBy(func(d *dto.LembreteDTO) bool {
+			return d.Repetir && d.Frequencia == "semanal" && d.DataHora.Equal(time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC))
+		})).Return(&entity.Lembrete{Mensagem: "Trocar a água do reservatório"}, nil).Once()
+
+		w := requisitar(routerLembretes(mockService), http.MethodPost, "/lembretes", lembreteValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Repetido Sem Frequência", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+
+		w := requisitar(routerLembretes(mockService), http.MethodPost, "/lembretes",
+			`{"mensagem":"Regar","data_hora":"2026-06-01T08:00:00Z","repetir":true}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Frequencia")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Canal Desconhecido", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+
+		w := requisitar(routerLembretes(mockService), http.MethodPost, "/lembretes",
+			`{"mensagem":"Regar","data_hora":"2026-06-01T08:00:00Z","canais":["sms"]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLembreteController_Listar(t *testing.T) {
+	t.Run("Error - Limite Acima do Máximo", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+
+		w := requisitar(routerLembretes(mockService), http.MethodGet, "/lembretes?limit=500", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Listar", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLembreteController_Atualizar(t *testing.T) {
+	t.Run("Error - Lembrete de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+		mockService.On("Atualizar", uint(4), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerLembretes(mockService), http.MethodPut, "/lembretes/4", lembreteValido)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Lembrete não encontrado")
+	})
+}
+
+func TestLembreteController_Adiar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+		mockService.On("Adiar", uint(4), uint(7), &dto.AdiarLembreteDTO{Minutos: 30}).Return(&entity.Lembrete{}, nil).Once()
+
+		w := requisitar(routerLembretes(mockService), http.MethodPost, "/lembretes/4/adiar", `{"minutos":30}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Mais de Sete Dias", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+
+		w := requisitar(routerLembretes(mockService), http.MethodPost, "/lembretes/4/adiar", `{"minutos":10081}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Adiar", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+
+		w := requisitar(routerLembretes(mockService), http.MethodPost, "/lembretes/abc/adiar", `{"minutos":30}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Adiar", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLembreteController_MarcarLido(t *testing.T) {
+	t.Run("Error - Lembrete Ainda Não Entregue", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+		mockService.On("MarcarLido", uint(4), uint(7)).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerLembretes(mockService), http.MethodPost, "/lembretes/4/lido", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestLembreteController_Deletar(t *testing.T) {
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+		mockService.On("Deletar", uint(4), uint(7)).Return(errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerLembretes(mockService), http.MethodDelete, "/lembretes/4", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestLembreteController_AtualizarPreferencias(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+		mockService.On("AtualizarPreferencias", uint(7), mock.MatchedBy(func(d *dto.PreferenciaNotificacaoDTO) bool {
+			return d.SilencioInicio == "22:00" && d.SilencioFim == "07:00"
+		})).Return(&entity.PreferenciaNotificacao{}, nil).Once()
+
+		w := requisitar(routerLembretes(mockService), http.MethodPut, "/preferencias-notificacao",
+			`{"silencio_inicio":"22:00","silencio_fim":"07:00"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Silêncio Sem Fim", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+
+		w := requisitar(routerLembretes(mockService), http.MethodPut, "/preferencias-notificacao", `{"silencio_inicio":"22:00"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "AtualizarPreferencias", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Webhook Sem URL Válida", func(t *testing.T) {
+		mockService := new(MockLembreteService)
+
+		w := requisitar(routerLembretes(mockService), http.MethodPut, "/preferencias-notificacao", `{"webhook_url":"servidor-local"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "AtualizarPreferencias", mock.Anything, mock.Anything)
+	})
+}
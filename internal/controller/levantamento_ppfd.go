@@ -0,0 +1,211 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type LevantamentoPPFDController struct {
+	servico service.LevantamentoPPFDService
+}
+
+func NewLevantamentoPPFDController(servico service.LevantamentoPPFDService) *LevantamentoPPFDController {
+	return &LevantamentoPPFDController{servico}
+}
+
+// Registrar godoc
+// @Summary      Registra um levantamento de PPFD do ambiente
+// @Description  Medições em pontos da área de cultivo (cm a partir do canto do ambiente) com a luminária
+// @Description  na altura informada. Retorna média, mínimo, máximo e uniformidade.
+// @Tags         levantamento-ppfd
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int                      true  "ID do Ambiente"
+// @Param        levantamento  body      dto.LevantamentoPPFDDTO  true  "Levantamento"
+// @Success      201           {object}  dto.ResumoLevantamentoPPFDDTO
+// @Failure      400           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/levantamentos-ppfd [post]
+func (c *LevantamentoPPFDController) Registrar(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var levantamentoDto dto.LevantamentoPPFDDTO
+	if err := ctx.ShouldBindJSON(&levantamentoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar levantamento de PPFD")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	levantamento, err := c.servico.Registrar(ambienteID, &levantamentoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao registrar levantamento de PPFD")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, levantamento)
+}
+
+// Listar godoc
+// @Summary      Lista os levantamentos de PPFD do ambiente
+// @Description  Do mais recente ao mais antigo, com as estatísticas de cada um, para acompanhar os ajustes de luz
+// @Tags         levantamento-ppfd
+// @Produce      json
+// @Param        id   path      int  true  "ID do Ambiente"
+// @Success      200  {array}   dto.ResumoLevantamentoPPFDDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/levantamentos-ppfd [get]
+func (c *LevantamentoPPFDController) Listar(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	levantamentos, err := c.servico.Listar(ambienteID)
+	if err != nil {
+		c.responderErro(ctx, err, "Ambiente não encontrado", "Erro interno ao listar levantamentos de PPFD")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, levantamentos)
+}
+
+// Buscar godoc
+// @Summary      Busca um levantamento de PPFD com os pontos
+// @Tags         levantamento-ppfd
+// @Produce      json
+// @Param        id   path      int  true  "ID do Levantamento"
+// @Success      200  {object}  dto.ResumoLevantamentoPPFDDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/levantamentos-ppfd/{id} [get]
+func (c *LevantamentoPPFDController) Buscar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	levantamento, err := c.servico.Buscar(id)
+	if err != nil {
+		c.responderErro(ctx, err, "Levantamento não encontrado", "Erro interno ao buscar levantamento de PPFD")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, levantamento)
+}
+
+// Comparar godoc
+// @Summary      Compara dois levantamentos de PPFD do mesmo ambiente
+// @Description  Diferença de média e uniformidade e, ponto a ponto, o valor do levantamento comparado nos
+// @Description  pontos do base (interpolado quando não foi medido no mesmo lugar)
+// @Tags         levantamento-ppfd
+// @Produce      json
+// @Param        id   path      int  true  "ID do Levantamento base"
+// @Param        com  query     int  true  "ID do Levantamento comparado"
+// @Success      200  {object}  dto.ComparacaoPPFDDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/levantamentos-ppfd/{id}/comparar [get]
+func (c *LevantamentoPPFDController) Comparar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaComparacaoPPFDDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para comparar levantamentos de PPFD")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	comparacao, err := c.servico.Comparar(id, consulta.Com)
+	if err != nil {
+		c.responderErro(ctx, err, "Levantamento não encontrado", "Erro interno ao comparar levantamentos de PPFD")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, comparacao)
+}
+
+// Mapa godoc
+// @Summary      Desenha o mapa de calor do levantamento de PPFD
+// @Description  Interpola as medições sobre a planta baixa do ambiente. Informe escala_min e escala_max para
+// @Description  usar a mesma escala de cores em mapas que serão comparados.
+// @Tags         levantamento-ppfd
+// @Produce      image/svg+xml
+// @Produce      image/png
+// @Param        id          path      int     true   "ID do Levantamento"
+// @Param        formato     query     string  false  "svg (padrão) ou png"
+// @Param        resolucao   query     number  false  "Lado das células em cm (padrão: 5)"
+// @Param        escala_min  query     number  false  "PPFD do início da escala de cores"
+// @Param        escala_max  query     number  false  "PPFD do fim da escala de cores"
+// @Success      200         {file}    file
+// @Failure      400         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /api/v1/levantamentos-ppfd/{id}/mapa [get]
+func (c *LevantamentoPPFDController) Mapa(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaMapaPPFDDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para desenhar mapa de PPFD")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	imagem, contentType, err := c.servico.Mapa(id, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Levantamento não encontrado", "Erro interno ao desenhar mapa de PPFD")
+		return
+	}
+	ctx.Data(http.StatusOK, contentType, imagem)
+}
+
+// Deletar godoc
+// @Summary      Remove um levantamento de PPFD
+// @Tags         levantamento-ppfd
+// @Param        id   path  int  true  "ID do Levantamento"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/levantamentos-ppfd/{id} [delete]
+func (c *LevantamentoPPFDController) Deletar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id); err != nil {
+		c.responderErro(ctx, err, "Levantamento não encontrado", "Erro interno ao deletar levantamento de PPFD")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *LevantamentoPPFDController) responderErro(ctx *gin.Context, err error, naoEncontrado, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, naoEncontrado, err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLevantamentoPPFDService é um mock para o service.LevantamentoPPFDService
+type MockLevantamentoPPFDService struct {
+	mock.Mock
+}
+
+func (m *MockLevantamentoPPFDService) Registrar(ambienteID uint, levantamentoDto *dto.LevantamentoPPFDDTO) (*dto.ResumoLevantamentoPPFDDTO, error) {
+	args := m.Called(ambienteID, levantamentoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ResumoLevantamentoPPFDDTO), args.Error(1)
+}
+
+func (m *MockLevantamentoPPFDService) Listar(ambienteID uint) ([]dto.ResumoLevantamentoPPFDDTO, error) {
+	args := m.Called(ambienteID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.ResumoLevantamentoPPFDDTO), args.Error(1)
+}
+
+func (m *MockLevantamentoPPFDService) Buscar(id uint) (*dto.ResumoLevantamentoPPFDDTO, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ResumoLevantamentoPPFDDTO), args.Error(1)
+}
+
+func (m *MockLevantamentoPPFDService) Comparar(baseID, comparadoID uint) (*dto.ComparacaoPPFDDTO, error) {
+	args := m.Called(baseID, comparadoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ComparacaoPPFDDTO), args.Error(1)
+}
+
+func (m *MockLevantamentoPPFDService) Mapa(id uint, consulta *dto.ConsultaMapaPPFDDTO) ([]byte, string, error) {
+	args := m.Called(id, consulta)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).([]byte), args.String(1), args.Error(2)
+}
+
+func (m *MockLevantamentoPPFDService) Deletar(id uint) error {
+	return m.Called(id).Error(0)
+}
+
+func routerLevantamentoPPFD(mockService *MockLevantamentoPPFDService) *gin.Engine {
+	controlador := NewLevantamentoPPFDController(mockService)
+	router := novoRouterTeste()
+	router.POST("/ambientes/:id/levantamentos-ppfd", controlador.Registrar)
+	router.GET("/ambientes/:id/levantamentos-ppfd", controlador.Listar)
+	router.GET("/levantamentos-ppfd/:id", controlador.Buscar)
+	router.GET("/levantamentos-ppfd/:id/comparar", controlador.Comparar)
+	router.GET("/levantamentos-ppfd/:id/mapa", controlador.Mapa)
+	router.DELETE("/levantamentos-ppfd/:id", controlador.Deletar)
+	return router
+}
+
+const levantamentoPPFDValido = `{"altura_luminaria_cm":45,"pontos":[{"x":10,"y":10,"ppfd":800},{"x":50,"y":10,"ppfd":900},{"x":30,"y":40,"ppfd":850}]}`
+
+func TestLevantamentoPPFDController_Registrar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+		mockService.On("Registrar", uint(3), mock.MatchedBy(func(l *dto.LevantamentoPPFDDTO) bool {
+			return l.AlturaLuminariaCm == 45 && len(l.Pontos) == 3
+		})).Return(&dto.ResumoLevantamentoPPFDDTO{}, nil).Once()
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodPost, "/ambientes/3/levantamentos-ppfd", levantamentoPPFDValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Poucos Pontos", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodPost, "/ambientes/3/levantamentos-ppfd",
+			`{"altura_luminaria_cm":45,"pontos":[{"x":10,"y":10,"ppfd":800}]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Pontos")
+		mockService.AssertNotCalled(t, "Registrar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Ponto Fora do Ambiente", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+		mockService.On("Registrar", uint(3), mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodPost, "/ambientes/3/levantamentos-ppfd", levantamentoPPFDValido)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error - Ambiente de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+		mockService.On("Registrar", uint(3), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodPost, "/ambientes/3/levantamentos-ppfd", levantamentoPPFDValido)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLevantamentoPPFDController_Buscar(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodGet, "/levantamentos-ppfd/abc", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Buscar", mock.Anything)
+	})
+
+	t.Run("Error - Levantamento Não Encontrado", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+		mockService.On("Buscar", uint(8)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodGet, "/levantamentos-ppfd/8", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLevantamentoPPFDController_Comparar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+		mockService.On("Comparar", uint(8), uint(9)).Return(&dto.ComparacaoPPFDDTO{}, nil).Once()
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodGet, "/levantamentos-ppfd/8/comparar?com=9", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Levantamento Comparado Obrigatório", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodGet, "/levantamentos-ppfd/8/comparar", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Comparar", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLevantamentoPPFDController_Mapa(t *testing.T) {
+	t.Run("Success - Responde com o Content Type do Serviço", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+		mockService.On("Mapa", uint(8), &dto.ConsultaMapaPPFDDTO{Formato: "png"}).Return([]byte("png"), "image/png", nil).Once()
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodGet, "/levantamentos-ppfd/8/mapa?formato=png", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("Error - Formato Desconhecido", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodGet, "/levantamentos-ppfd/8/mapa?formato=gif", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Mapa", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLevantamentoPPFDController_Deletar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+		mockService.On("Deletar", uint(8)).Return(nil).Once()
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodDelete, "/levantamentos-ppfd/8", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockLevantamentoPPFDService)
+		mockService.On("Deletar", uint(8)).Return(errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerLevantamentoPPFD(mockService), http.MethodDelete, "/levantamentos-ppfd/8", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,285 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// tamanhoMaximoImportacao limita o corpo das importações de histórico (~ meses de leituras por minuto)
+const tamanhoMaximoImportacao = 64 << 20
+
+type MicroclimaController struct {
+	servico service.MicroclimaService
+}
+
+func NewMicroclimaController(servico service.MicroclimaService) *MicroclimaController {
+	return &MicroclimaController{servico}
+}
+
+// RegistrarLeitura godoc
+// @Summary      Registra uma leitura de sensores
+// @Description  Registra uma leitura de temperatura, umidade, luminosidade, CO2 e umidade do solo para o ambiente
+// @Tags         microclima
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                       true  "ID do Ambiente"
+// @Param        leitura  body      dto.LeituraMicroclimaDTO  true  "Leitura dos sensores"
+// @Success      201      {object}  entity.Microclima
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/microclima [post]
+func (c *MicroclimaController) RegistrarLeitura(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var leituraDto dto.LeituraMicroclimaDTO
+	if err := ctx.ShouldBindJSON(&leituraDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar leitura de microclima")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	leitura, err := c.servico.RegistrarLeitura(ambienteID, &leituraDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar leitura")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, leitura)
+}
+
+// RegistrarLote godoc
+// @Summary      Registra leituras de sensores em lote
+// @Description  Registra várias leituras de uma vez para o ambiente (até 5000 por requisição)
+// @Tags         microclima
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                            true  "ID do Ambiente"
+// @Param        lote  body      dto.LoteLeiturasMicroclimaDTO  true  "Leituras dos sensores"
+// @Success      201   {object}  dto.IngestaoMicroclimaResponseDTO
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/microclima/lote [post]
+func (c *MicroclimaController) RegistrarLote(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var loteDto dto.LoteLeiturasMicroclimaDTO
+	if err := ctx.ShouldBindJSON(&loteDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar lote de microclima")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	resultado, err := c.servico.RegistrarLote(ambienteID, &loteDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar lote de leituras")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, resultado)
+}
+
+// ListarLeituras godoc
+// @Summary      Lista a série bruta de leituras
+// @Description  Retorna as leituras do ambiente no intervalo informado (padrão: últimas 24 horas)
+// @Tags         microclima
+// @Produce      json
+// @Param        id      path      int     true   "ID do Ambiente"
+// @Param        inicio  query     string  false  "Início do intervalo (RFC3339)"
+// @Param        fim     query     string  false  "Fim do intervalo (RFC3339)"
+// @Param        limit   query     int     false  "Máximo de leituras (padrão: 1000, máximo: 10000)"
+// @Success      200     {object}  dto.SerieMicroclimaResponseDTO
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/microclima [get]
+func (c *MicroclimaController) ListarLeituras(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaMicroclimaDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar leituras de microclima")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	serie, err := c.servico.ListarLeituras(ambienteID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar leituras")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, serie)
+}
+
+// Agregar godoc
+// @Summary      Retorna leituras agregadas por janela de tempo
+// @Description  Retorna mínimo, máximo e média de cada métrica em janelas de 5 minutos, 1 hora ou 1 dia,
+// @Description  com VPD, ponto de orvalho e DLI derivados e sinalização das janelas fora das faixas do estágio
+// @Tags         microclima
+// @Produce      json
+// @Param        id            path      int     true   "ID do Ambiente"
+// @Param        inicio        query     string  false  "Início do intervalo (RFC3339)"
+// @Param        fim           query     string  false  "Fim do intervalo (RFC3339)"
+// @Param        intervalo     query     string  false  "Tamanho da janela: 5m, 1h ou 1d (padrão: 1h)"
+// @Param        offset_folha  query     number  false  "Diferença entre folha e ar em °C (padrão: -2)"
+// @Param        fator_lux     query     number  false  "Fator de conversão lux → PPFD (padrão: 0.0185)"
+// @Param        estagio       query     string  false  "Estágio usado nas faixas alvo (padrão: predominante no ambiente)"
+// @Success      200           {object}  dto.AgregadoMicroclimaResponseDTO
+// @Failure      400           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/microclima/agregado [get]
+func (c *MicroclimaController) Agregar(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaMicroclimaDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para agregar leituras de microclima")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	agregado, err := c.servico.Agregar(ambienteID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao agregar leituras")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, agregado)
+}
+
+// ImportarLineProtocol godoc
+// @Summary      Importa histórico no formato line protocol do InfluxDB
+// @Description  Aceita o corpo de uma escrita do Influx (measurement[,tags] campos [timestamp]). Campos com nomes
+// @Description  conhecidos (temperature/temperatura, humidity/umidade, lux, co2, soil_moisture, ppfd) viram leituras;
+// @Description  linhas com o mesmo timestamp são combinadas. Linhas inválidas são rejeitadas sem abortar a importação.
+// @Tags         microclima
+// @Accept       plain
+// @Produce      json
+// @Param        id           path      int     true   "ID do Ambiente"
+// @Param        precision    query     string  false  "Unidade do timestamp: ns, us, ms ou s (padrão: ns)"
+// @Param        measurement  query     string  false  "Importa apenas essa measurement"
+// @Param        linhas       body      string  true   "Linhas no formato line protocol"
+// @Success      201          {object}  dto.ImportacaoMicroclimaResponseDTO
+// @Failure      400          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      413          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/microclima/importar/line-protocol [post]
+func (c *MicroclimaController) ImportarLineProtocol(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var opcoes dto.ImportacaoLineProtocolDTO
+	if err := ctx.ShouldBindQuery(&opcoes); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para importar line protocol")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	corpo := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, tamanhoMaximoImportacao)
+	resultado, err := c.servico.ImportarLineProtocol(ambienteID, corpo, &opcoes)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao importar line protocol")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, resultado)
+}
+
+// ImportarCSV godoc
+// @Summary      Importa histórico a partir de CSV
+// @Description  Aceita o CSV no corpo (text/csv) ou no campo "arquivo" de um multipart/form-data. A primeira linha
+// @Description  deve ser o cabeçalho; as colunas são reconhecidas pelo nome ou pelo mapeamento informado.
+// @Tags         microclima
+// @Accept       plain
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id            path      int     true   "ID do Ambiente"
+// @Param        arquivo       formData  file    false  "Arquivo CSV"
+// @Param        delimitador   query     string  false  "virgula, ponto_virgula ou tab (padrão: virgula)"
+// @Param        decimal       query     string  false  "Separador decimal: ponto ou virgula (padrão: ponto)"
+// @Param        coluna_data   query     string  false  "Nome da coluna de data (padrão: detectada pelo nome)"
+// @Param        formato_data  query     string  false  "Layout Go (ex.: 02/01/2006 15:04), unix ou unix_ms"
+// @Param        fuso          query     string  false  "Fuso IANA das datas sem deslocamento (padrão: UTC)"
+// @Param        colunas       query     string  false  "Mapeamento métrica=coluna separado por vírgula"
+// @Success      201           {object}  dto.ImportacaoMicroclimaResponseDTO
+// @Failure      400           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Failure      413           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/microclima/importar/csv [post]
+func (c *MicroclimaController) ImportarCSV(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var opcoes dto.ImportacaoCSVDTO
+	if err := ctx.ShouldBindQuery(&opcoes); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para importar CSV")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, tamanhoMaximoImportacao)
+	var corpo io.Reader = ctx.Request.Body
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		arquivo, err := ctx.FormFile("arquivo")
+		if err != nil {
+			c.responderErro(ctx, err, "Erro ao ler o arquivo CSV enviado")
+			return
+		}
+		aberto, err := arquivo.Open()
+		if err != nil {
+			c.responderErro(ctx, err, "Erro ao ler o arquivo CSV enviado")
+			return
+		}
+		defer aberto.Close()
+		corpo = aberto
+	}
+
+	resultado, err := c.servico.ImportarCSV(ambienteID, corpo, &opcoes)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao importar CSV")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, resultado)
+}
+
+func (c *MicroclimaController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	var errTamanho *http.MaxBytesError
+	switch {
+	case errors.As(err, &errTamanho):
+		utils.RespondWithError(ctx, http.StatusRequestEntityTooLarge, "Arquivo maior que o limite de importação", err.Error())
+	case errors.Is(err, http.ErrMissingFile):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Campo \"arquivo\" ausente", err.Error())
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Ambiente não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,319 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// intervaloStreamNotificacoes é a cada quanto o stream consulta o banco sem aviso local (notificações
+// criadas por outra réplica) e envia um comentário para manter a conexão aberta
+const intervaloStreamNotificacoes = 15 * time.Second
+
+// EmissorTicketStream emite os tickets de uso único aceitos na abertura do stream
+type EmissorTicketStream interface {
+	Emitir(usuarioID uint) (string, time.Time, error)
+}
+
+type NotificacaoController struct {
+	servico service.NotificacaoService
+	tickets EmissorTicketStream
+	// encerrando é fechado no desligamento do servidor para encerrar os streams abertos
+	encerrando chan struct{}
+	encerrar   sync.Once
+}
+
+func NewNotificacaoController(servico service.NotificacaoService, tickets EmissorTicketStream) *NotificacaoController {
+	return &NotificacaoController{servico: servico, tickets: tickets, encerrando: make(chan struct{})}
+}
+
+// EncerrarStreams encerra os streams abertos e recusa os novos. O Shutdown do http.Server não
+// cancela requisições ativas, então um stream aberto impediria o desligamento.
+func (c *NotificacaoController) EncerrarStreams() {
+	c.encerrar.Do(func() { close(c.encerrando) })
+}
+
+// Listar godoc
+// @Summary      Lista a central de notificações do usuário
+// @Description  Alertas, lembretes, tarefas e avisos de cultivo, dos mais recentes para os mais antigos
+// @Tags         notificacoes
+// @Produce      json
+// @Param        categoria  query     string  false  "Filtra por categoria: alerta, lembrete, tarefa, cultivo, estoque, convite ou sistema"
+// @Param        nao_lidas  query     bool    false  "Só as não lidas"
+// @Param        page       query     int     false  "Número da página (padrão: 1)"
+// @Param        limit      query     int     false  "Limite de itens por página (padrão: 10)"
+// @Success      200        {object}  dto.PaginatedResponse{data=[]entity.Notificacao}
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /api/v1/notificacoes [get]
+func (c *NotificacaoController) Listar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaNotificacoesDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar notificações")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	notificacoes, total, err := c.servico.Listar(usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar notificações")
+		return
+	}
+
+	dataBytes, err := json.Marshal(notificacoes)
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao serializar notificações para resposta paginada")
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Erro interno ao listar notificações", err.Error())
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, &dto.PaginatedResponse{
+		Data:  dataBytes,
+		Total: total,
+		Page:  consulta.Page,
+		Limit: consulta.Limit,
+	})
+}
+
+// ContarNaoLidas godoc
+// @Summary      Conta as notificações não lidas
+// @Tags         notificacoes
+// @Produce      json
+// @Success      200  {object}  dto.ContagemNotificacoesDTO
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/notificacoes/nao-lidas [get]
+func (c *NotificacaoController) ContarNaoLidas(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	total, err := c.servico.ContarNaoLidas(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao contar notificações")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, dto.ContagemNotificacoesDTO{NaoLidas: total})
+}
+
+// MarcarLida godoc
+// @Summary      Marca uma notificação como lida
+// @Tags         notificacoes
+// @Produce      json
+// @Param        id   path      int  true  "ID da Notificação"
+// @Success      200  {object}  entity.Notificacao
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/notificacoes/{id}/lida [post]
+func (c *NotificacaoController) MarcarLida(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	notificacao, err := c.servico.MarcarLida(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao marcar notificação como lida")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, notificacao)
+}
+
+// MarcarLidas godoc
+// @Summary      Marca notificações como lidas em lote
+// @Description  Com ids, marca só essas; sem ids, marca todas as não lidas da categoria informada (ou todas)
+// @Tags         notificacoes
+// @Accept       json
+// @Produce      json
+// @Param        notificacoes  body      dto.MarcarNotificacoesLidasDTO  true  "Notificações a marcar"
+// @Success      200           {object}  dto.NotificacoesLidasDTO
+// @Failure      400           {object}  map[string]string
+// @Failure      401           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /api/v1/notificacoes/lidas [post]
+func (c *NotificacaoController) MarcarLidas(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var marcarDto dto.MarcarNotificacoesLidasDTO
+	if err := ctx.ShouldBindJSON(&marcarDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para marcar notificações como lidas")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	resultado, err := c.servico.MarcarLidas(usuarioID, &marcarDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao marcar notificações como lidas")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, resultado)
+}
+
+// EmitirTicketStream godoc
+// @Summary      Emite um ticket para abrir o stream de notificações
+// @Description  O ticket vale por poucos segundos e para uma única conexão. Serve aos clientes que não enviam o cabeçalho Authorization, como o EventSource do navegador, sem colocar o token JWT na URL
+// @Tags         notificacoes
+// @Produce      json
+// @Success      201  {object}  dto.TicketStreamDTO
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/notificacoes/stream/ticket [post]
+func (c *NotificacaoController) EmitirTicketStream(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	ticket, expiraEm, err := c.tickets.Emitir(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao emitir ticket do stream")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, dto.TicketStreamDTO{Ticket: ticket, ExpiraEm: expiraEm})
+}
+
+// Stream godoc
+// @Summary      Stream em tempo real da central de notificações
+// @Description  Server-Sent Events: "notificacao" para cada notificação nova (id = ID da notificação) e "nao_lidas" com a contagem atual. Envie Last-Event-ID para receber o que foi criado enquanto a conexão estava fechada. Como o EventSource do navegador não envia cabeçalhos, em vez do cabeçalho Authorization pode ir no parâmetro ticket um ticket emitido por POST /notificacoes/stream/ticket
+// @Tags         notificacoes
+// @Produce      text/event-stream
+// @Param        Last-Event-ID  header    int     false  "ID da última notificação recebida"
+// @Param        ticket         query     string  false  "Ticket de uso único, para clientes que não enviam o cabeçalho Authorization"
+// @Success      200            {string}  string
+// @Failure      401            {object}  map[string]string
+// @Failure      500            {object}  map[string]string
+// @Failure      503            {object}  map[string]string
+// @Router       /api/v1/notificacoes/stream [get]
+func (c *NotificacaoController) Stream(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	select {
+	case <-c.encerrando:
+		utils.RespondWithError(ctx, http.StatusServiceUnavailable, "Servidor em desligamento", nil)
+		return
+	default:
+	}
+
+	// assina antes de ler a posição inicial para não perder o que for criado entre as duas
+	avisos, cancelar := c.servico.Assinar(usuarioID)
+	defer cancelar()
+
+	ultimoID, err := c.posicaoInicialStream(ctx, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao abrir o stream de notificações")
+		return
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	ticker := time.NewTicker(intervaloStreamNotificacoes)
+	defer ticker.Stop()
+
+	// o primeiro passo envia o que ficou pendente desde Last-Event-ID e a contagem atual
+	enviarContagem := true
+	ctx.Stream(func(w io.Writer) bool {
+		if !enviarContagem {
+			select {
+			case <-ctx.Request.Context().Done():
+				return false
+			case <-c.encerrando:
+				return false
+			case <-avisos:
+				enviarContagem = true
+			case <-ticker.C:
+			}
+		}
+
+		novas, err := c.servico.ListarDesde(usuarioID, ultimoID)
+		if err != nil {
+			logrus.WithError(err).Error("Erro ao buscar notificações novas para o stream")
+			return false
+		}
+		for _, notificacao := range novas {
+			if err := escreverEventoSSE(w, strconv.FormatUint(uint64(notificacao.ID), 10), "notificacao", notificacao); err != nil {
+				return false
+			}
+			ultimoID = notificacao.ID
+		}
+
+		if !enviarContagem && len(novas) == 0 {
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+		enviarContagem = false
+		total, err := c.servico.ContarNaoLidas(usuarioID)
+		if err != nil {
+			logrus.WithError(err).Error("Erro ao contar notificações para o stream")
+			return false
+		}
+		return escreverEventoSSE(w, "", "nao_lidas", dto.ContagemNotificacoesDTO{NaoLidas: total}) == nil
+	})
+}
+
+// posicaoInicialStream retoma do Last-Event-ID enviado pelo cliente ou começa da notificação mais recente
+func (c *NotificacaoController) posicaoInicialStream(ctx *gin.Context, usuarioID uint) (uint, error) {
+	if ultimo, err := strconv.ParseUint(ctx.GetHeader("Last-Event-ID"), 10, 64); err == nil {
+		return uint(ultimo), nil
+	}
+	return c.servico.UltimoID(usuarioID)
+}
+
+// escreverEventoSSE escreve um evento no formato Server-Sent Events com os dados em JSON
+func escreverEventoSSE(w io.Writer, id, evento string, dados any) error {
+	conteudo, err := json.Marshal(dados)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", evento, conteudo)
+	return err
+}
+
+func (c *NotificacaoController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Notificação não encontrada", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,298 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/middleware"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockNotificacaoService é um mock para o service.NotificacaoService
+type MockNotificacaoService struct {
+	mock.Mock
+}
+
+func (m *MockNotificacaoService) Publicar(notificacao service.Notificacao) (*entity.Notificacao, error) {
+	args := m.Called(notificacao)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Notificacao), args.Error(1)
+}
+
+func (m *MockNotificacaoService) Listar(usuarioID uint, consulta *dto.ConsultaNotificacoesDTO) ([]entity.Notificacao, int64, error) {
+	args := m.Called(usuarioID, consulta)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entity.Notificacao), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificacaoService) ContarNaoLidas(usuarioID uint) (int64, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificacaoService) MarcarLida(id, usuarioID uint) (*entity.Notificacao, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Notificacao), args.Error(1)
+}
+
+func (m *MockNotificacaoService) MarcarLidas(usuarioID uint, marcarDto *dto.MarcarNotificacoesLidasDTO) (*dto.NotificacoesLidasDTO, error) {
+	args := m.Called(usuarioID, marcarDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.NotificacoesLidasDTO), args.Error(1)
+}
+
+func (m *MockNotificacaoService) ListarDesde(usuarioID, aposID uint) ([]entity.Notificacao, error) {
+	args := m.Called(usuarioID, aposID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Notificacao), args.Error(1)
+}
+
+func (m *MockNotificacaoService) UltimoID(usuarioID uint) (uint, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockNotificacaoService) Assinar(usuarioID uint) (<-chan struct{}, func()) {
+	args := m.Called(usuarioID)
+	return args.Get(0).(chan struct{}), func() {}
+}
+
+// routerNotificacoes registra o controller com o usuário 7 já autenticado
+func routerNotificacoes(controlador *NotificacaoController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("userID", "7")
+		ctx.Next()
+	})
+	router.GET("/notificacoes/stream", controlador.Stream)
+	router.GET("/notificacoes", controlador.Listar)
+	router.GET("/notificacoes/nao-lidas", controlador.ContarNaoLidas)
+	router.POST("/notificacoes/lidas", controlador.MarcarLidas)
+	router.POST("/notificacoes/:id/lida", controlador.MarcarLida)
+	return router
+}
+
+func TestNotificacaoController_Stream(t *testing.T) {
+	t.Run("Success - Desligamento Encerra o Stream Aberto", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+		mockService.On("Assinar", uint(7)).Return(make(chan struct{}))
+		mockService.On("UltimoID", uint(7)).Return(uint(40), nil)
+		mockService.On("ListarDesde", uint(7), uint(40)).Return([]entity.Notificacao{}, nil)
+		mockService.On("ContarNaoLidas", uint(7)).Return(int64(3), nil)
+		controlador := NewNotificacaoController(mockService, nil)
+		servidor := httptest.NewServer(routerNotificacoes(controlador))
+		defer servidor.Close()
+
+		resposta, err := http.Get(servidor.URL + "/notificacoes/stream")
+		require.NoError(t, err)
+		defer resposta.Body.Close()
+		assert.Equal(t, http.StatusOK, resposta.StatusCode)
+		leitor := bufio.NewReader(resposta.Body)
+		linha, err := leitor.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "event: nao_lidas\n", linha)
+
+		controlador.EncerrarStreams()
+
+		terminou := make(chan error, 1)
+		go func() {
+			_, err := io.ReadAll(leitor)
+			terminou <- err
+		}()
+		select {
+		case err := <-terminou:
+			assert.NoError(t, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("o stream continuou aberto depois do desligamento")
+		}
+	})
+
+	t.Run("Error - Recusa Streams Durante o Desligamento", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+		controlador := NewNotificacaoController(mockService, nil)
+		controlador.EncerrarStreams()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/notificacoes/stream", nil)
+		routerNotificacoes(controlador).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.True(t, strings.Contains(w.Body.String(), "desligamento"))
+		mockService.AssertNotCalled(t, "Assinar", mock.Anything)
+	})
+}
+
+func TestNotificacaoController_EmitirTicketStream(t *testing.T) {
+	// abrirStream usa o AuthStreamMiddleware com um handler que só devolve o usuário autenticado
+	abrirStream := func(tickets *middleware.TicketsStream, ticket string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.GET("/notificacoes/stream", middleware.AuthStreamMiddleware(tickets), func(ctx *gin.Context) {
+			ctx.String(http.StatusOK, ctx.GetString("userID"))
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/notificacoes/stream?ticket="+ticket, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success - Ticket Abre o Stream Uma Única Vez", func(t *testing.T) {
+		tickets := middleware.NewTicketsStream(time.Minute)
+		router := novoRouterTeste()
+		router.POST("/notificacoes/stream/ticket", NewNotificacaoController(new(MockNotificacaoService), tickets).EmitirTicketStream)
+
+		w := requisitar(router, http.MethodPost, "/notificacoes/stream/ticket", "")
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resposta dto.TicketStreamDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resposta))
+		require.NotEmpty(t, resposta.Ticket)
+
+		primeira := abrirStream(tickets, resposta.Ticket)
+		assert.Equal(t, http.StatusOK, primeira.Code)
+		assert.Equal(t, "7", primeira.Body.String())
+		assert.Equal(t, http.StatusUnauthorized, abrirStream(tickets, resposta.Ticket).Code)
+	})
+
+	t.Run("Error - Ticket Expirado", func(t *testing.T) {
+		tickets := middleware.NewTicketsStream(0)
+		ticket, _, err := tickets.Emitir(7)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, abrirStream(tickets, ticket).Code)
+	})
+
+	t.Run("Error - Token JWT na URL Não É Aceito", func(t *testing.T) {
+		token, err := utils.GenerateToken(7)
+		require.NoError(t, err)
+
+		w := abrirStream(middleware.NewTicketsStream(time.Minute), "&access_token="+token)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestNotificacaoController_Listar(t *testing.T) {
+	t.Run("Success - Filtra por Categoria e Não Lidas", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+		mockService.On("Listar", uint(7), mock.MatchedBy(func(c *dto.ConsultaNotificacoesDTO) bool {
+			return c.Categoria == "estoque" && c.NaoLidas && c.Page == 1
+		})).Return([]entity.Notificacao{{Titulo: "Estoque baixo"}}, int64(1), nil).Once()
+
+		w := requisitar(routerNotificacoes(NewNotificacaoController(mockService, nil)), http.MethodGet, "/notificacoes?categoria=estoque&nao_lidas=true", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resposta dto.PaginatedResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resposta))
+		assert.Equal(t, int64(1), resposta.Total)
+		assert.Contains(t, string(resposta.Data), "Estoque baixo")
+	})
+
+	t.Run("Error - Categoria Desconhecida", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+
+		w := requisitar(routerNotificacoes(NewNotificacaoController(mockService, nil)), http.MethodGet, "/notificacoes?categoria=promocao", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Listar", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNotificacaoController_ContarNaoLidas(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+		mockService.On("ContarNaoLidas", uint(7)).Return(int64(3), nil).Once()
+
+		w := requisitar(routerNotificacoes(NewNotificacaoController(mockService, nil)), http.MethodGet, "/notificacoes/nao-lidas", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"nao_lidas":3}`, w.Body.String())
+	})
+}
+
+func TestNotificacaoController_MarcarLida(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+		mockService.On("MarcarLida", uint(40), uint(7)).Return(&entity.Notificacao{Titulo: "Estoque baixo"}, nil).Once()
+
+		w := requisitar(routerNotificacoes(NewNotificacaoController(mockService, nil)), http.MethodPost, "/notificacoes/40/lida", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+
+		w := requisitar(routerNotificacoes(NewNotificacaoController(mockService, nil)), http.MethodPost, "/notificacoes/abc/lida", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "MarcarLida", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Notificação de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+		mockService.On("MarcarLida", uint(40), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerNotificacoes(NewNotificacaoController(mockService, nil)), http.MethodPost, "/notificacoes/40/lida", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Notificação não encontrada")
+	})
+}
+
+func TestNotificacaoController_MarcarLidas(t *testing.T) {
+	t.Run("Success - Todas da Categoria", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+		mockService.On("MarcarLidas", uint(7), &dto.MarcarNotificacoesLidasDTO{Categoria: "alerta"}).
+			Return(&dto.NotificacoesLidasDTO{Marcadas: 2, NaoLidas: 1}, nil).Once()
+
+		w := requisitar(routerNotificacoes(NewNotificacaoController(mockService, nil)), http.MethodPost, "/notificacoes/lidas", `{"categoria":"alerta"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"marcadas":2,"nao_lidas":1}`, w.Body.String())
+	})
+
+	t.Run("Error - Categoria Desconhecida", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+
+		w := requisitar(routerNotificacoes(NewNotificacaoController(mockService, nil)), http.MethodPost, "/notificacoes/lidas", `{"categoria":"promocao"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "MarcarLidas", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockNotificacaoService)
+		mockService.On("MarcarLidas", uint(7), mock.Anything).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerNotificacoes(NewNotificacaoController(mockService, nil)), http.MethodPost, "/notificacoes/lidas", `{}`)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type PlanejamentoSafraController struct {
+	servico service.PlanejamentoSafraService
+}
+
+func NewPlanejamentoSafraController(servico service.PlanejamentoSafraService) *PlanejamentoSafraController {
+	return &PlanejamentoSafraController{servico}
+}
+
+// Planejar godoc
+// @Summary      Planeja a temporada de uma genética em um ambiente externo
+// @Description  Simula uma semeadura por semana nos próximos 12 meses com a duração do dia na latitude do ambiente:
+// @Description  prevê o início da floração natural (ou das autoflorescentes) e a colheita, recomenda janelas de
+// @Description  semeadura e aponta meses de risco. Os riscos climáticos usam os registros de clima do último ano.
+// @Tags         clima
+// @Produce      json
+// @Param        id           path      int     true   "ID do Ambiente"
+// @Param        genetica_id  query     int     true   "ID da Genética"
+// @Param        inicio       query     string  false  "Primeira semeadura (AAAA-MM-DD, padrão: hoje)"
+// @Success      200          {object}  dto.PlanejamentoSafraDTO
+// @Failure      400          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/ambientes/{id}/planejamento-safra [get]
+func (c *PlanejamentoSafraController) Planejar(ctx *gin.Context) {
+	ambienteID, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaPlanejamentoSafraDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para planejar safra")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	planejamento, err := c.servico.Planejar(ambienteID, &consulta)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			utils.RespondWithError(ctx, http.StatusNotFound, "Ambiente ou genética não encontrado", err.Error())
+		case errors.Is(err, utils.ErrInvalidInput):
+			utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+		default:
+			logrus.WithError(err).Error("Erro interno ao planejar safra")
+			utils.RespondWithError(ctx, http.StatusInternalServerError, "Erro interno ao planejar safra", err.Error())
+		}
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, planejamento)
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPlanejamentoSafraService é um mock para o service.PlanejamentoSafraService
+type MockPlanejamentoSafraService struct {
+	mock.Mock
+}
+
+func (m *MockPlanejamentoSafraService) Planejar(ambienteID uint, consulta *dto.ConsultaPlanejamentoSafraDTO) (*dto.PlanejamentoSafraDTO, error) {
+	args := m.Called(ambienteID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PlanejamentoSafraDTO), args.Error(1)
+}
+
+func routerPlanejamentoSafra(mockService *MockPlanejamentoSafraService) *gin.Engine {
+	router := novoRouterTeste()
+	router.GET("/ambientes/:id/planejamento-safra", NewPlanejamentoSafraController(mockService).Planejar)
+	return router
+}
+
+func TestPlanejamentoSafraController_Planejar(t *testing.T) {
+	t.Run("Success - Repassa Genética e Início", func(t *testing.T) {
+		mockService := new(MockPlanejamentoSafraService)
+		mockService.On("Planejar", uint(3), &dto.ConsultaPlanejamentoSafraDTO{GeneticaID: 5, Inicio: "2026-08-01"}).
+			Return(&dto.PlanejamentoSafraDTO{}, nil).Once()
+
+		w := requisitar(routerPlanejamentoSafra(mockService), http.MethodGet, "/ambientes/3/planejamento-safra?genetica_id=5&inicio=2026-08-01", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Genética Obrigatória", func(t *testing.T) {
+		mockService := new(MockPlanejamentoSafraService)
+
+		w := requisitar(routerPlanejamentoSafra(mockService), http.MethodGet, "/ambientes/3/planejamento-safra", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "GeneticaID")
+		mockService.AssertNotCalled(t, "Planejar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockPlanejamentoSafraService)
+
+		w := requisitar(routerPlanejamentoSafra(mockService), http.MethodGet, "/ambientes/x/planejamento-safra?genetica_id=5", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Planejar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Ambiente de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockPlanejamentoSafraService)
+		mockService.On("Planejar", uint(3), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerPlanejamentoSafra(mockService), http.MethodGet, "/ambientes/3/planejamento-safra?genetica_id=5", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Error - Ambiente Sem Localização", func(t *testing.T) {
+		mockService := new(MockPlanejamentoSafraService)
+		mockService.On("Planejar", uint(3), mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerPlanejamentoSafra(mockService), http.MethodGet, "/ambientes/3/planejamento-safra?genetica_id=5", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockPlanejamentoSafraService)
+		mockService.On("Planejar", uint(3), mock.Anything).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerPlanejamentoSafra(mockService), http.MethodGet, "/ambientes/3/planejamento-safra?genetica_id=5", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,430 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type RegaController struct {
+	servico service.RegaService
+}
+
+func NewRegaController(servico service.RegaService) *RegaController {
+	return &RegaController{servico}
+}
+
+// Criar godoc
+// @Summary      Registra uma rega
+// @Description  Rega ou fertirrigação de uma planta ou do lote de um diário de cultivo. EC e PPM são convertidos um no outro pela escala (padrão 500); com receita e sem nutrientes, as doses da receita são copiadas
+// @Tags         regas
+// @Accept       json
+// @Produce      json
+// @Param        rega  body      dto.RegaDTO  true  "Rega"
+// @Success      201   {object}  entity.Rega
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/v1/regas [post]
+func (c *RegaController) Criar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var regaDto dto.RegaDTO
+	if err := ctx.ShouldBindJSON(&regaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar rega")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	rega, err := c.servico.Criar(usuarioID, &regaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar rega")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, rega)
+}
+
+// Listar godoc
+// @Summary      Lista as regas do usuário
+// @Description  Regas das plantas e dos lotes, das mais recentes para as mais antigas
+// @Tags         regas
+// @Produce      json
+// @Param        planta_id          query     int     false  "Filtra pelas regas individuais da planta"
+// @Param        diario_cultivo_id  query     int     false  "Filtra pelas regas do lote do diário"
+// @Param        de                 query     string  false  "Data inicial (YYYY-MM-DD)"
+// @Param        ate                query     string  false  "Data final, inclusive (YYYY-MM-DD)"
+// @Param        page               query     int     false  "Número da página (padrão: 1)"
+// @Param        limit              query     int     false  "Limite de itens por página (padrão: 10)"
+// @Success      200                {object}  dto.PaginatedResponse{data=[]entity.Rega}
+// @Failure      400                {object}  map[string]string
+// @Failure      401                {object}  map[string]string
+// @Failure      500                {object}  map[string]string
+// @Router       /api/v1/regas [get]
+func (c *RegaController) Listar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaRegasDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar regas")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	regas, total, err := c.servico.Listar(usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar regas")
+		return
+	}
+
+	dataBytes, err := json.Marshal(regas)
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao serializar regas para resposta paginada")
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Erro interno ao listar regas", err.Error())
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, &dto.PaginatedResponse{
+		Data:  dataBytes,
+		Total: total,
+		Page:  consulta.Page,
+		Limit: consulta.Limit,
+	})
+}
+
+// BuscarPorID godoc
+// @Summary      Busca uma rega por ID
+// @Tags         regas
+// @Produce      json
+// @Param        id   path      int  true  "ID da Rega"
+// @Success      200  {object}  entity.Rega
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/regas/{id} [get]
+func (c *RegaController) BuscarPorID(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	rega, err := c.servico.BuscarPorID(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar rega")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, rega)
+}
+
+// Atualizar godoc
+// @Summary      Atualiza uma rega
+// @Description  Substitui todos os dados da rega, inclusive os nutrientes
+// @Tags         regas
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int          true  "ID da Rega"
+// @Param        rega  body      dto.RegaDTO  true  "Rega"
+// @Success      200   {object}  entity.Rega
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/v1/regas/{id} [put]
+func (c *RegaController) Atualizar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var regaDto dto.RegaDTO
+	if err := ctx.ShouldBindJSON(&regaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar rega")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	rega, err := c.servico.Atualizar(id, usuarioID, &regaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar rega")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, rega)
+}
+
+// Deletar godoc
+// @Summary      Remove uma rega
+// @Tags         regas
+// @Param        id   path      int  true  "ID da Rega"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/regas/{id} [delete]
+func (c *RegaController) Deletar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar rega")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GraficoPlanta godoc
+// @Summary      Gráfico de EC e pH das regas de uma planta
+// @Description  Série cronológica de EC/pH de entrada e drenagem da planta, incluindo as regas do lote dos diários dela (com o volume dividido entre as plantas do diário)
+// @Tags         regas
+// @Produce      json
+// @Param        id   path      int     true   "ID da Planta"
+// @Param        de   query     string  false  "Data inicial (YYYY-MM-DD)"
+// @Param        ate  query     string  false  "Data final, inclusive (YYYY-MM-DD)"
+// @Success      200  {object}  dto.GraficoRegaPlantaDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/plantas/{id}/regas/grafico [get]
+func (c *RegaController) GraficoPlanta(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaGraficoRegaDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para o gráfico de regas")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	grafico, err := c.servico.GraficoPlanta(id, usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao montar o gráfico de regas")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, grafico)
+}
+
+// ResumoCiclo godoc
+// @Summary      Resumo das regas do ciclo de um diário de cultivo
+// @Description  Total de água por ciclo e por planta, médias de EC/pH de entrada e quantidade de cada nutriente aplicada
+// @Tags         regas
+// @Produce      json
+// @Param        id   path      int  true  "ID do Diário de Cultivo"
+// @Success      200  {object}  dto.ResumoRegaCicloDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/diarios-cultivo/{id}/regas/resumo [get]
+func (c *RegaController) ResumoCiclo(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	resumo, err := c.servico.ResumoCiclo(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao resumir as regas do ciclo")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, resumo)
+}
+
+// CriarReceita godoc
+// @Summary      Cria uma receita de nutrientes
+// @Description  Doses por litro (ml/L ou g/L) reaproveitáveis nas regas
+// @Tags         regas
+// @Accept       json
+// @Produce      json
+// @Param        receita  body      dto.ReceitaNutrienteDTO  true  "Receita"
+// @Success      201      {object}  entity.ReceitaNutriente
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/receitas-nutriente [post]
+func (c *RegaController) CriarReceita(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var receitaDto dto.ReceitaNutrienteDTO
+	if err := ctx.ShouldBindJSON(&receitaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar receita de nutrientes")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	receita, err := c.servico.CriarReceita(usuarioID, &receitaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao criar receita de nutrientes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, receita)
+}
+
+// ListarReceitas godoc
+// @Summary      Lista as receitas de nutrientes do usuário
+// @Tags         regas
+// @Produce      json
+// @Success      200  {array}   entity.ReceitaNutriente
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/receitas-nutriente [get]
+func (c *RegaController) ListarReceitas(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	receitas, err := c.servico.ListarReceitas(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar receitas de nutrientes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, receitas)
+}
+
+// BuscarReceita godoc
+// @Summary      Busca uma receita de nutrientes por ID
+// @Tags         regas
+// @Produce      json
+// @Param        id   path      int  true  "ID da Receita"
+// @Success      200  {object}  entity.ReceitaNutriente
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/receitas-nutriente/{id} [get]
+func (c *RegaController) BuscarReceita(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	receita, err := c.servico.BuscarReceita(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar receita de nutrientes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, receita)
+}
+
+// AtualizarReceita godoc
+// @Summary      Atualiza uma receita de nutrientes
+// @Description  Os itens informados substituem os anteriores; regas já registradas mantêm as doses copiadas
+// @Tags         regas
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                      true  "ID da Receita"
+// @Param        receita  body      dto.ReceitaNutrienteDTO  true  "Receita"
+// @Success      200      {object}  entity.ReceitaNutriente
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/receitas-nutriente/{id} [put]
+func (c *RegaController) AtualizarReceita(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var receitaDto dto.ReceitaNutrienteDTO
+	if err := ctx.ShouldBindJSON(&receitaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar receita de nutrientes")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	receita, err := c.servico.AtualizarReceita(id, usuarioID, &receitaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar receita de nutrientes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, receita)
+}
+
+// DeletarReceita godoc
+// @Summary      Remove uma receita de nutrientes
+// @Tags         regas
+// @Param        id   path      int  true  "ID da Receita"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/receitas-nutriente/{id} [delete]
+func (c *RegaController) DeletarReceita(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.DeletarReceita(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar receita de nutrientes")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *RegaController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Registro não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,277 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRegaService é um mock para o service.RegaService
+type MockRegaService struct {
+	mock.Mock
+}
+
+func (m *MockRegaService) Criar(usuarioID uint, regaDto *dto.RegaDTO) (*entity.Rega, error) {
+	args := m.Called(usuarioID, regaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Rega), args.Error(1)
+}
+
+func (m *MockRegaService) BuscarPorID(id, usuarioID uint) (*entity.Rega, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Rega), args.Error(1)
+}
+
+func (m *MockRegaService) Listar(usuarioID uint, consulta *dto.ConsultaRegasDTO) ([]entity.Rega, int64, error) {
+	args := m.Called(usuarioID, consulta)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entity.Rega), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRegaService) Atualizar(id, usuarioID uint, regaDto *dto.RegaDTO) (*entity.Rega, error) {
+	args := m.Called(id, usuarioID, regaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Rega), args.Error(1)
+}
+
+func (m *MockRegaService) Deletar(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+func (m *MockRegaService) GraficoPlanta(plantaID, usuarioID uint, consulta *dto.ConsultaGraficoRegaDTO) (*dto.GraficoRegaPlantaDTO, error) {
+	args := m.Called(plantaID, usuarioID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.GraficoRegaPlantaDTO), args.Error(1)
+}
+
+func (m *MockRegaService) ResumoCiclo(diarioID, usuarioID uint) (*dto.ResumoRegaCicloDTO, error) {
+	args := m.Called(diarioID, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ResumoRegaCicloDTO), args.Error(1)
+}
+
+func (m *MockRegaService) CriarReceita(usuarioID uint, receitaDto *dto.ReceitaNutrienteDTO) (*entity.ReceitaNutriente, error) {
+	args := m.Called(usuarioID, receitaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ReceitaNutriente), args.Error(1)
+}
+
+func (m *MockRegaService) ListarReceitas(usuarioID uint) ([]entity.ReceitaNutriente, error) {
+	args := m.Called(usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ReceitaNutriente), args.Error(1)
+}
+
+func (m *MockRegaService) BuscarReceita(id, usuarioID uint) (*entity.ReceitaNutriente, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ReceitaNutriente), args.Error(1)
+}
+
+func (m *MockRegaService) AtualizarReceita(id, usuarioID uint, receitaDto *dto.ReceitaNutrienteDTO) (*entity.ReceitaNutriente, error) {
+	args := m.Called(id, usuarioID, receitaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ReceitaNutriente), args.Error(1)
+}
+
+func (m *MockRegaService) DeletarReceita(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+func routerRegas(mockService *MockRegaService) *gin.Engine {
+	controlador := NewRegaController(mockService)
+	router := novoRouterTeste()
+	router.POST("/regas", controlador.Criar)
+	router.GET("/regas", controlador.Listar)
+	router.GET("/regas/:id", controlador.BuscarPorID)
+	router.PUT("/regas/:id", controlador.Atualizar)
+	router.DELETE("/regas/:id", controlador.Deletar)
+	router.GET("/plantas/:id/regas/grafico", controlador.GraficoPlanta)
+	router.GET("/diarios-cultivo/:id/regas/resumo", controlador.ResumoCiclo)
+	router.POST("/receitas-nutriente", controlador.CriarReceita)
+	router.GET("/receitas-nutriente", controlador.ListarReceitas)
+	router.GET("/receitas-nutriente/:id", controlador.BuscarReceita)
+	router.PUT("/receitas-nutriente/:id", controlador.AtualizarReceita)
+	router.DELETE("/receitas-nutriente/:id", controlador.DeletarReceita)
+	return router
+}
+
+const receitaValida = `{"nome":"Vega","itens":[{"nutriente":"Grow","dose":2,"unidade":"ml_l"}]}`
+
+func TestRegaController_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("Criar", uint(7), mock.MatchedBy(func(d *dto.RegaDTO) bool {
+			return *d.PlantaID == 10 && d.VolumeLitros == 1.5 && *d.ECEntrada == 1.2
+		})).Return(&entity.Rega{VolumeLitros: 1.5}, nil).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodPost, "/regas", `{"planta_id":10,"volume_litros":1.5,"ec_entrada":1.2}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Escala de PPM Desconhecida", func(t *testing.T) {
+		mockService := new(MockRegaService)
+
+		w := requisitar(routerRegas(mockService), http.MethodPost, "/regas", `{"planta_id":10,"volume_litros":1.5,"escala_ppm":600}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "EscalaPPM")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Sem Planta e Sem Diário", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("Criar", uint(7), mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodPost, "/regas", `{"volume_litros":1.5}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("Criar", uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodPost, "/regas", `{"planta_id":10,"volume_litros":1.5}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestRegaController_BuscarPorID(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockRegaService)
+
+		w := requisitar(routerRegas(mockService), http.MethodGet, "/regas/abc", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "BuscarPorID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Rega de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("BuscarPorID", uint(4), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodGet, "/regas/4", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestRegaController_GraficoPlanta(t *testing.T) {
+	t.Run("Success - Repassa o Período", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("GraficoPlanta", uint(10), uint(7), &dto.ConsultaGraficoRegaDTO{De: "2026-05-01", Ate: "2026-05-31"}).
+			Return(&dto.GraficoRegaPlantaDTO{PlantaID: 10}, nil).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodGet, "/plantas/10/regas/grafico?de=2026-05-01&ate=2026-05-31", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Data em Formato Inválido", func(t *testing.T) {
+		mockService := new(MockRegaService)
+
+		w := requisitar(routerRegas(mockService), http.MethodGet, "/plantas/10/regas/grafico?de=ontem", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GraficoPlanta", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRegaController_ResumoCiclo(t *testing.T) {
+	t.Run("Error - Diário de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("ResumoCiclo", uint(3), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodGet, "/diarios-cultivo/3/regas/resumo", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestRegaController_CriarReceita(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("CriarReceita", uint(7), mock.MatchedBy(func(d *dto.ReceitaNutrienteDTO) bool {
+			return d.Nome == "Vega" && len(d.Itens) == 1 && d.Itens[0].Unidade == "ml_l"
+		})).Return(&entity.ReceitaNutriente{Nome: "Vega"}, nil).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodPost, "/receitas-nutriente", receitaValida)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Unidade Desconhecida", func(t *testing.T) {
+		mockService := new(MockRegaService)
+
+		w := requisitar(routerRegas(mockService), http.MethodPost, "/receitas-nutriente",
+			`{"nome":"Vega","itens":[{"nutriente":"Grow","dose":2,"unidade":"gotas"}]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CriarReceita", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRegaController_AtualizarReceita(t *testing.T) {
+	t.Run("Error - Receita de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("AtualizarReceita", uint(2), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodPut, "/receitas-nutriente/2", receitaValida)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestRegaController_DeletarReceita(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("DeletarReceita", uint(2), uint(7)).Return(nil).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodDelete, "/receitas-nutriente/2", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockRegaService)
+		mockService.On("DeletarReceita", uint(2), uint(7)).Return(errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerRegas(mockService), http.MethodDelete, "/receitas-nutriente/2", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
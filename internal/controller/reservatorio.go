@@ -0,0 +1,361 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type ReservatorioController struct {
+	servico service.ReservatorioService
+}
+
+func NewReservatorioController(servico service.ReservatorioService) *ReservatorioController {
+	return &ReservatorioController{servico}
+}
+
+// Criar godoc
+// @Summary      Cria um reservatório
+// @Description  Reservatório de solução nutritiva de um sistema hidropônico ou aeropônico. Com intervalo de troca, o agendador cria a tarefa de troca a partir da última troca
+// @Tags         reservatorios
+// @Accept       json
+// @Produce      json
+// @Param        reservatorio  body      dto.ReservatorioDTO  true  "Reservatório"
+// @Success      201           {object}  entity.Reservatorio
+// @Failure      400           {object}  map[string]string
+// @Failure      401           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /api/v1/reservatorios [post]
+func (c *ReservatorioController) Criar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var reservatorioDto dto.ReservatorioDTO
+	if err := ctx.ShouldBindJSON(&reservatorioDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar reservatório")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	reservatorio, err := c.servico.Criar(usuarioID, &reservatorioDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao criar reservatório")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, reservatorio)
+}
+
+// Listar godoc
+// @Summary      Lista os reservatórios do usuário
+// @Tags         reservatorios
+// @Produce      json
+// @Success      200  {array}   entity.Reservatorio
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/reservatorios [get]
+func (c *ReservatorioController) Listar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	reservatorios, err := c.servico.Listar(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar reservatórios")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, reservatorios)
+}
+
+// BuscarPorID godoc
+// @Summary      Busca um reservatório por ID
+// @Tags         reservatorios
+// @Produce      json
+// @Param        id   path      int  true  "ID do Reservatório"
+// @Success      200  {object}  entity.Reservatorio
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/reservatorios/{id} [get]
+func (c *ReservatorioController) BuscarPorID(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	reservatorio, err := c.servico.BuscarPorID(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar reservatório")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, reservatorio)
+}
+
+// Atualizar godoc
+// @Summary      Atualiza um reservatório
+// @Description  Substitui os dados e as plantas do reservatório. Mudar o intervalo de troca reagenda a tarefa de troca aberta
+// @Tags         reservatorios
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int                  true  "ID do Reservatório"
+// @Param        reservatorio  body      dto.ReservatorioDTO  true  "Reservatório"
+// @Success      200           {object}  entity.Reservatorio
+// @Failure      400           {object}  map[string]string
+// @Failure      401           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /api/v1/reservatorios/{id} [put]
+func (c *ReservatorioController) Atualizar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var reservatorioDto dto.ReservatorioDTO
+	if err := ctx.ShouldBindJSON(&reservatorioDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar reservatório")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	reservatorio, err := c.servico.Atualizar(id, usuarioID, &reservatorioDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar reservatório")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, reservatorio)
+}
+
+// Deletar godoc
+// @Summary      Remove um reservatório
+// @Description  Remove também as manutenções, as leituras e as tarefas de troca abertas
+// @Tags         reservatorios
+// @Param        id   path      int  true  "ID do Reservatório"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/reservatorios/{id} [delete]
+func (c *ReservatorioController) Deletar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar reservatório")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RegistrarManutencao godoc
+// @Summary      Registra uma troca ou reposição da solução
+// @Description  A troca marca o início de um novo ciclo da solução e conclui as tarefas de troca abertas; completar registra a água ou solução reposta
+// @Tags         reservatorios
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                            true  "ID do Reservatório"
+// @Param        manutencao  body      dto.ManutencaoReservatorioDTO  true  "Manutenção"
+// @Success      201         {object}  entity.ManutencaoReservatorio
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /api/v1/reservatorios/{id}/manutencoes [post]
+func (c *ReservatorioController) RegistrarManutencao(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var manutencaoDto dto.ManutencaoReservatorioDTO
+	if err := ctx.ShouldBindJSON(&manutencaoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar manutenção de reservatório")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	manutencao, err := c.servico.RegistrarManutencao(id, usuarioID, &manutencaoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar manutenção de reservatório")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, manutencao)
+}
+
+// ListarManutencoes godoc
+// @Summary      Lista as trocas e reposições do reservatório
+// @Description  Das mais recentes para as mais antigas
+// @Tags         reservatorios
+// @Produce      json
+// @Param        id   path      int  true  "ID do Reservatório"
+// @Success      200  {array}   entity.ManutencaoReservatorio
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/reservatorios/{id}/manutencoes [get]
+func (c *ReservatorioController) ListarManutencoes(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	manutencoes, err := c.servico.ListarManutencoes(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar manutenções de reservatório")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, manutencoes)
+}
+
+// RegistrarLeitura godoc
+// @Summary      Registra uma leitura da solução
+// @Description  EC, pH, temperatura da água, oxigênio dissolvido e nível; PPM é convertido em EC pela escala (padrão 500) quando a EC não é informada
+// @Tags         reservatorios
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                         true  "ID do Reservatório"
+// @Param        leitura  body      dto.LeituraReservatorioDTO  true  "Leitura"
+// @Success      201      {object}  entity.LeituraReservatorio
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/reservatorios/{id}/leituras [post]
+func (c *ReservatorioController) RegistrarLeitura(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var leituraDto dto.LeituraReservatorioDTO
+	if err := ctx.ShouldBindJSON(&leituraDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar leitura de reservatório")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	leitura, err := c.servico.RegistrarLeitura(id, usuarioID, &leituraDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar leitura de reservatório")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, leitura)
+}
+
+// ListarLeituras godoc
+// @Summary      Lista as leituras do reservatório
+// @Description  Em ordem cronológica
+// @Tags         reservatorios
+// @Produce      json
+// @Param        id   path      int     true   "ID do Reservatório"
+// @Param        de   query     string  false  "Data inicial (YYYY-MM-DD)"
+// @Param        ate  query     string  false  "Data final, inclusive (YYYY-MM-DD)"
+// @Success      200  {array}   entity.LeituraReservatorio
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/reservatorios/{id}/leituras [get]
+func (c *ReservatorioController) ListarLeituras(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaLeiturasReservatorioDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar leituras de reservatório")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	leituras, err := c.servico.ListarLeituras(id, usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar leituras de reservatório")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, leituras)
+}
+
+// AnalisarDeriva godoc
+// @Summary      Analisa a deriva da solução desde a última troca
+// @Description  Variação diária de EC e pH, consumo de água pelas reposições, dias desde a troca e diagnósticos (EC subindo ou caindo, água quente, pouco oxigênio, troca atrasada)
+// @Tags         reservatorios
+// @Produce      json
+// @Param        id   path      int  true  "ID do Reservatório"
+// @Success      200  {object}  dto.DerivaReservatorioDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/reservatorios/{id}/deriva [get]
+func (c *ReservatorioController) AnalisarDeriva(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	deriva, err := c.servico.AnalisarDeriva(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao analisar deriva do reservatório")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, deriva)
+}
+
+func (c *ReservatorioController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Reservatório não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
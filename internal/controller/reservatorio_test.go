@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReservatorioService é um mock para o service.ReservatorioService
+type MockReservatorioService struct {
+	mock.Mock
+}
+
+func (m *MockReservatorioService) Criar(usuarioID uint, reservatorioDto *dto.ReservatorioDTO) (*entity.Reservatorio, error) {
+	args := m.Called(usuarioID, reservatorioDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioService) BuscarPorID(id, usuarioID uint) (*entity.Reservatorio, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioService) Listar(usuarioID uint) ([]entity.Reservatorio, error) {
+	args := m.Called(usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Reservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioService) Atualizar(id, usuarioID uint, reservatorioDto *dto.ReservatorioDTO) (*entity.Reservatorio, error) {
+	args := m.Called(id, usuarioID, reservatorioDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioService) Deletar(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+func (m *MockReservatorioService) RegistrarManutencao(id, usuarioID uint, manutencaoDto *dto.ManutencaoReservatorioDTO) (*entity.ManutencaoReservatorio, error) {
+	args := m.Called(id, usuarioID, manutencaoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ManutencaoReservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioService) ListarManutencoes(id, usuarioID uint) ([]entity.ManutencaoReservatorio, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ManutencaoReservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioService) RegistrarLeitura(id, usuarioID uint, leituraDto *dto.LeituraReservatorioDTO) (*entity.LeituraReservatorio, error) {
+	args := m.Called(id, usuarioID, leituraDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LeituraReservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioService) ListarLeituras(id, usuarioID uint, consulta *dto.ConsultaLeiturasReservatorioDTO) ([]entity.LeituraReservatorio, error) {
+	args := m.Called(id, usuarioID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.LeituraReservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioService) AnalisarDeriva(id, usuarioID uint) (*dto.DerivaReservatorioDTO, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.DerivaReservatorioDTO), args.Error(1)
+}
+
+func (m *MockReservatorioService) GerarTarefasTroca() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func routerReservatorios(mockService *MockReservatorioService) *gin.Engine {
+	controlador := NewReservatorioController(mockService)
+	router := novoRouterTeste()
+	router.POST("/reservatorios", controlador.Criar)
+	router.GET("/reservatorios", controlador.Listar)
+	router.GET("/reservatorios/:id", controlador.BuscarPorID)
+	router.PUT("/reservatorios/:id", controlador.Atualizar)
+	router.DELETE("/reservatorios/:id", controlador.Deletar)
+	router.POST("/reservatorios/:id/manutencoes", controlador.RegistrarManutencao)
+	router.GET("/reservatorios/:id/manutencoes", controlador.ListarManutencoes)
+	router.POST("/reservatorios/:id/leituras", controlador.RegistrarLeitura)
+	router.GET("/reservatorios/:id/leituras", controlador.ListarLeituras)
+	router.GET("/reservatorios/:id/deriva", controlador.AnalisarDeriva)
+	return router
+}
+
+const reservatorioValido = `{"nome":"Tanque 1","volume_litros":60,"planta_ids":[10,11],"intervalo_troca_dias":7}`
+
+func TestReservatorioController_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+		mockService.On("Criar", uint(7), mock.MatchedBy(func(d *dto.ReservatorioDTO) bool {
+			return d.Nome == "Tanque 1" && d.VolumeLitros == 60 && len(d.PlantaIDs) == 2 && d.IntervaloTrocaDias == 7
+		})).Return(&entity.Reservatorio{Nome: "Tanque 1"}, nil).Once()
+
+		w := requisitar(routerReservatorios(mockService), http.MethodPost, "/reservatorios", reservatorioValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Intervalo de Troca Acima do Máximo", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+
+		w := requisitar(routerReservatorios(mockService), http.MethodPost, "/reservatorios",
+			`{"nome":"Tanque 1","volume_litros":60,"intervalo_troca_dias":120}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "IntervaloTrocaDias")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+		mockService.On("Criar", uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerReservatorios(mockService), http.MethodPost, "/reservatorios", reservatorioValido)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestReservatorioController_BuscarPorID(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+
+		w := requisitar(routerReservatorios(mockService), http.MethodGet, "/reservatorios/0", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "BuscarPorID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Reservatório de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+		mockService.On("BuscarPorID", uint(3), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerReservatorios(mockService), http.MethodGet, "/reservatorios/3", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Reservatório não encontrado")
+	})
+}
+
+func TestReservatorioController_RegistrarManutencao(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+		mockService.On("RegistrarManutencao", uint(3), uint(7), mock.MatchedBy(func(d *dto.ManutencaoReservatorioDTO) bool {
+			return d.Tipo == "troca" && d.VolumeLitros == 60
+		})).Return(&entity.ManutencaoReservatorio{}, nil).Once()
+
+		w := requisitar(routerReservatorios(mockService), http.MethodPost, "/reservatorios/3/manutencoes", `{"tipo":"troca","volume_litros":60}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Tipo Desconhecido", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+
+		w := requisitar(routerReservatorios(mockService), http.MethodPost, "/reservatorios/3/manutencoes", `{"tipo":"limpeza","volume_litros":60}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RegistrarManutencao", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReservatorioController_RegistrarLeitura(t *testing.T) {
+	t.Run("Error - pH Fora do Intervalo", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+
+		w := requisitar(routerReservatorios(mockService), http.MethodPost, "/reservatorios/3/leituras", `{"ph":15}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RegistrarLeitura", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Leitura Sem Grandezas", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+		mockService.On("RegistrarLeitura", uint(3), uint(7), mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerReservatorios(mockService), http.MethodPost, "/reservatorios/3/leituras", `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestReservatorioController_ListarLeituras(t *testing.T) {
+	t.Run("Success - Repassa o Período", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+		mockService.On("ListarLeituras", uint(3), uint(7), &dto.ConsultaLeiturasReservatorioDTO{De: "2026-05-01"}).
+			Return([]entity.LeituraReservatorio{}, nil).Once()
+
+		w := requisitar(routerReservatorios(mockService), http.MethodGet, "/reservatorios/3/leituras?de=2026-05-01", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Data em Formato Inválido", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+
+		w := requisitar(routerReservatorios(mockService), http.MethodGet, "/reservatorios/3/leituras?ate=31-05-2026", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListarLeituras", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReservatorioController_AnalisarDeriva(t *testing.T) {
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockReservatorioService)
+		mockService.On("AnalisarDeriva", uint(3), uint(7)).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerReservatorios(mockService), http.MethodGet, "/reservatorios/3/deriva", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,336 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type SementeController struct {
+	servico service.SementeService
+}
+
+func NewSementeController(servico service.SementeService) *SementeController {
+	return &SementeController{servico}
+}
+
+// CriarPacote godoc
+// @Summary      Cadastra um pacote no banco de sementes
+// @Description  O pacote pertence a uma genética cadastrada; a quantidade informada é o total de sementes do pacote
+// @Tags         sementes
+// @Accept       json
+// @Produce      json
+// @Param        pacote  body      dto.PacoteSementeDTO  true  "Pacote"
+// @Success      201     {object}  entity.PacoteSemente
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/sementes/pacotes [post]
+func (c *SementeController) CriarPacote(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var pacoteDto dto.PacoteSementeDTO
+	if err := ctx.ShouldBindJSON(&pacoteDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar pacote de sementes")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	pacote, err := c.servico.CriarPacote(usuarioID, &pacoteDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao criar pacote de sementes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, pacote)
+}
+
+// ListarPacotes godoc
+// @Summary      Lista o banco de sementes do usuário
+// @Description  Ordenado por breeder e data de aquisição
+// @Tags         sementes
+// @Produce      json
+// @Param        genetica_id   query     int     false  "Só os pacotes da genética"
+// @Param        breeder       query     string  false  "Só os pacotes do breeder"
+// @Param        com_sementes  query     bool    false  "Só os pacotes com sementes restantes"
+// @Success      200           {array}   entity.PacoteSemente
+// @Failure      400           {object}  map[string]string
+// @Failure      401           {object}  map[string]string
+// @Failure      500           {object}  map[string]string
+// @Router       /api/v1/sementes/pacotes [get]
+func (c *SementeController) ListarPacotes(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaPacotesSementeDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar pacotes de sementes")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	pacotes, err := c.servico.ListarPacotes(usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar pacotes de sementes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, pacotes)
+}
+
+// BuscarPacote godoc
+// @Summary      Busca um pacote de sementes por ID
+// @Tags         sementes
+// @Produce      json
+// @Param        id   path      int  true  "ID do Pacote"
+// @Success      200  {object}  entity.PacoteSemente
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/sementes/pacotes/{id} [get]
+func (c *SementeController) BuscarPacote(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	pacote, err := c.servico.BuscarPacote(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar pacote de sementes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, pacote)
+}
+
+// AtualizarPacote godoc
+// @Summary      Atualiza um pacote de sementes
+// @Description  A quantidade informada passa a ser o saldo restante do pacote, como numa contagem
+// @Tags         sementes
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                   true  "ID do Pacote"
+// @Param        pacote  body      dto.PacoteSementeDTO  true  "Pacote"
+// @Success      200     {object}  entity.PacoteSemente
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/sementes/pacotes/{id} [put]
+func (c *SementeController) AtualizarPacote(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var pacoteDto dto.PacoteSementeDTO
+	if err := ctx.ShouldBindJSON(&pacoteDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar pacote de sementes")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	pacote, err := c.servico.AtualizarPacote(id, usuarioID, &pacoteDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar pacote de sementes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, pacote)
+}
+
+// DeletarPacote godoc
+// @Summary      Remove um pacote de sementes
+// @Description  As germinações do pacote continuam nas taxas de germinação e as plantas criadas a partir dele são mantidas
+// @Tags         sementes
+// @Param        id   path      int  true  "ID do Pacote"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/sementes/pacotes/{id} [delete]
+func (c *SementeController) DeletarPacote(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.DeletarPacote(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar pacote de sementes")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// Plantar godoc
+// @Summary      Cria plantas a partir do pacote
+// @Description  Cada planta consome uma semente do pacote. Sem nome, as plantas recebem o nome da genética, numerado quando há mais de uma
+// @Tags         sementes
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                   true  "ID do Pacote"
+// @Param        plantio  body      dto.PlantioPacoteDTO  true  "Plantio"
+// @Success      201      {array}   entity.Planta
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/sementes/pacotes/{id}/plantas [post]
+func (c *SementeController) Plantar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var plantioDto dto.PlantioPacoteDTO
+	if err := ctx.ShouldBindJSON(&plantioDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para plantar sementes do pacote")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	plantas, err := c.servico.Plantar(id, usuarioID, &plantioDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao plantar sementes do pacote")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, plantas)
+}
+
+// RegistrarGerminacao godoc
+// @Summary      Registra o resultado de uma germinação do pacote
+// @Description  As sementes que não germinaram saem do saldo do pacote; as germinadas saem quando viram plantas
+// @Tags         sementes
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                       true  "ID do Pacote"
+// @Param        germinacao  body      dto.GerminacaoSementeDTO  true  "Germinação"
+// @Success      201         {object}  entity.GerminacaoSemente
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /api/v1/sementes/pacotes/{id}/germinacoes [post]
+func (c *SementeController) RegistrarGerminacao(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var germinacaoDto dto.GerminacaoSementeDTO
+	if err := ctx.ShouldBindJSON(&germinacaoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar germinação")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	germinacao, err := c.servico.RegistrarGerminacao(id, usuarioID, &germinacaoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar germinação")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, germinacao)
+}
+
+// ListarGerminacoes godoc
+// @Summary      Lista as germinações do pacote
+// @Description  Das mais recentes para as mais antigas
+// @Tags         sementes
+// @Produce      json
+// @Param        id   path      int  true  "ID do Pacote"
+// @Success      200  {array}   entity.GerminacaoSemente
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/sementes/pacotes/{id}/germinacoes [get]
+func (c *SementeController) ListarGerminacoes(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	germinacoes, err := c.servico.ListarGerminacoes(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar germinações")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, germinacoes)
+}
+
+// TaxasGerminacao godoc
+// @Summary      Taxas de germinação por breeder ou genética
+// @Description  Soma as germinações registradas em todos os pacotes do usuário, da maior taxa para a menor
+// @Tags         sementes
+// @Produce      json
+// @Param        agrupar  query     string  false  "breeder ou genetica (padrão)"
+// @Success      200      {array}   dto.TaxaGerminacaoDTO
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/sementes/taxas-germinacao [get]
+func (c *SementeController) TaxasGerminacao(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaTaxasGerminacaoDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para taxas de germinação")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	taxas, err := c.servico.TaxasGerminacao(usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao calcular taxas de germinação")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, taxas)
+}
+
+func (c *SementeController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Registro não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
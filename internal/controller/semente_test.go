@@ -0,0 +1,263 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSementeService é um mock para o service.SementeService
+type MockSementeService struct {
+	mock.Mock
+}
+
+func (m *MockSementeService) CriarPacote(usuarioID uint, pacoteDto *dto.PacoteSementeDTO) (*entity.PacoteSemente, error) {
+	args := m.Called(usuarioID, pacoteDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PacoteSemente), args.Error(1)
+}
+
+func (m *MockSementeService) BuscarPacote(id, usuarioID uint) (*entity.PacoteSemente, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PacoteSemente), args.Error(1)
+}
+
+func (m *MockSementeService) ListarPacotes(usuarioID uint, consulta *dto.ConsultaPacotesSementeDTO) ([]entity.PacoteSemente, error) {
+	args := m.Called(usuarioID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.PacoteSemente), args.Error(1)
+}
+
+func (m *MockSementeService) AtualizarPacote(id, usuarioID uint, pacoteDto *dto.PacoteSementeDTO) (*entity.PacoteSemente, error) {
+	args := m.Called(id, usuarioID, pacoteDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PacoteSemente), args.Error(1)
+}
+
+func (m *MockSementeService) DeletarPacote(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+func (m *MockSementeService) Plantar(id, usuarioID uint, plantioDto *dto.PlantioPacoteDTO) ([]entity.Planta, error) {
+	args := m.Called(id, usuarioID, plantioDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Planta), args.Error(1)
+}
+
+func (m *MockSementeService) RegistrarGerminacao(id, usuarioID uint, germinacaoDto *dto.GerminacaoSementeDTO) (*entity.GerminacaoSemente, error) {
+	args := m.Called(id, usuarioID, germinacaoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.GerminacaoSemente), args.Error(1)
+}
+
+func (m *MockSementeService) ListarGerminacoes(id, usuarioID uint) ([]entity.GerminacaoSemente, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.GerminacaoSemente), args.Error(1)
+}
+
+func (m *MockSementeService) TaxasGerminacao(usuarioID uint, consulta *dto.ConsultaTaxasGerminacaoDTO) ([]dto.TaxaGerminacaoDTO, error) {
+	args := m.Called(usuarioID, consulta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaxaGerminacaoDTO), args.Error(1)
+}
+
+func routerSementes(mockService *MockSementeService) *gin.Engine {
+	controlador := NewSementeController(mockService)
+	router := novoRouterTeste()
+	router.POST("/sementes/pacotes", controlador.CriarPacote)
+	router.GET("/sementes/pacotes", controlador.ListarPacotes)
+	router.GET("/sementes/pacotes/:id", controlador.BuscarPacote)
+	router.PUT("/sementes/pacotes/:id", controlador.AtualizarPacote)
+	router.DELETE("/sementes/pacotes/:id", controlador.DeletarPacote)
+	router.POST("/sementes/pacotes/:id/plantas", controlador.Plantar)
+	router.POST("/sementes/pacotes/:id/germinacoes", controlador.RegistrarGerminacao)
+	router.GET("/sementes/pacotes/:id/germinacoes", controlador.ListarGerminacoes)
+	router.GET("/sementes/taxas-germinacao", controlador.TaxasGerminacao)
+	return router
+}
+
+const pacoteSementeValido = `{"genetica_id":5,"breeder":"Dutch Passion","quantidade":10}`
+
+const plantioValido = `{"quantidade":2,"ambiente_id":1,"meio_cultivo_id":1}`
+
+func TestSementeController_CriarPacote(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockSementeService)
+		mockService.On("CriarPacote", uint(7), mock.MatchedBy(func(d *dto.PacoteSementeDTO) bool {
+			return d.GeneticaID == 5 && d.Breeder == "Dutch Passion" && d.Quantidade == 10
+		})).Return(&entity.PacoteSemente{Breeder: "Dutch Passion"}, nil).Once()
+
+		w := requisitar(routerSementes(mockService), http.MethodPost, "/sementes/pacotes", pacoteSementeValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Breeder Obrigatório", func(t *testing.T) {
+		mockService := new(MockSementeService)
+
+		w := requisitar(routerSementes(mockService), http.MethodPost, "/sementes/pacotes", `{"genetica_id":5,"quantidade":10}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Breeder")
+		mockService.AssertNotCalled(t, "CriarPacote", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Genética Inexistente", func(t *testing.T) {
+		mockService := new(MockSementeService)
+		mockService.On("CriarPacote", uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerSementes(mockService), http.MethodPost, "/sementes/pacotes", pacoteSementeValido)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSementeController_BuscarPacote(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockSementeService)
+
+		w := requisitar(routerSementes(mockService), http.MethodGet, "/sementes/pacotes/abc", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "BuscarPacote", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Pacote de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockSementeService)
+		mockService.On("BuscarPacote", uint(3), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerSementes(mockService), http.MethodGet, "/sementes/pacotes/3", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSementeController_Plantar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockSementeService)
+		mockService.On("Plantar", uint(3), uint(7), mock.MatchedBy(func(d *dto.PlantioPacoteDTO) bool {
+			return d.Quantidade == 2 && d.AmbienteID == 1 && d.MeioCultivoID == 1
+		})).Return([]entity.Planta{{Nome: "Skunk #1"}, {Nome: "Skunk #2"}}, nil).Once()
+
+		w := requisitar(routerSementes(mockService), http.MethodPost, "/sementes/pacotes/3/plantas", plantioValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), "Skunk #2")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Mais de Cem Plantas", func(t *testing.T) {
+		mockService := new(MockSementeService)
+
+		w := requisitar(routerSementes(mockService), http.MethodPost, "/sementes/pacotes/3/plantas",
+			`{"quantidade":101,"ambiente_id":1,"meio_cultivo_id":1}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Plantar", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Sementes Insuficientes", func(t *testing.T) {
+		mockService := new(MockSementeService)
+		mockService.On("Plantar", uint(3), uint(7), mock.Anything).
+			Return(nil, fmt.Errorf("%w: o pacote tem 1 semente", utils.ErrInvalidInput)).Once()
+
+		w := requisitar(routerSementes(mockService), http.MethodPost, "/sementes/pacotes/3/plantas", plantioValido)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "o pacote tem 1 semente")
+	})
+
+	t.Run("Error - Ambiente de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockSementeService)
+		mockService.On("Plantar", uint(3), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerSementes(mockService), http.MethodPost, "/sementes/pacotes/3/plantas", plantioValido)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSementeController_RegistrarGerminacao(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockSementeService)
+		mockService.On("RegistrarGerminacao", uint(3), uint(7), mock.MatchedBy(func(d *dto.GerminacaoSementeDTO) bool {
+			return d.Semeadas == 5 && d.Germinadas == 4 && d.Metodo == "papel_toalha"
+		})).Return(&entity.GerminacaoSemente{}, nil).Once()
+
+		w := requisitar(routerSementes(mockService), http.MethodPost, "/sementes/pacotes/3/germinacoes",
+			`{"semeadas":5,"germinadas":4,"metodo":"papel_toalha"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Mais Germinadas que Semeadas", func(t *testing.T) {
+		mockService := new(MockSementeService)
+
+		w := requisitar(routerSementes(mockService), http.MethodPost, "/sementes/pacotes/3/germinacoes", `{"semeadas":5,"germinadas":6}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Germinadas")
+		mockService.AssertNotCalled(t, "RegistrarGerminacao", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSementeController_TaxasGerminacao(t *testing.T) {
+	t.Run("Success - Agrupa por Breeder", func(t *testing.T) {
+		mockService := new(MockSementeService)
+		mockService.On("TaxasGerminacao", uint(7), &dto.ConsultaTaxasGerminacaoDTO{Agrupar: "breeder"}).
+			Return([]dto.TaxaGerminacaoDTO{{Breeder: "Dutch Passion", Taxa: 80}}, nil).Once()
+
+		w := requisitar(routerSementes(mockService), http.MethodGet, "/sementes/taxas-germinacao?agrupar=breeder", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Agrupamento Desconhecido", func(t *testing.T) {
+		mockService := new(MockSementeService)
+
+		w := requisitar(routerSementes(mockService), http.MethodGet, "/sementes/taxas-germinacao?agrupar=ambiente", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "TaxasGerminacao", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSementeController_DeletarPacote(t *testing.T) {
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockSementeService)
+		mockService.On("DeletarPacote", uint(3), uint(7)).Return(errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerSementes(mockService), http.MethodDelete, "/sementes/pacotes/3", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type SubstratoController struct {
+	servico service.SubstratoService
+}
+
+func NewSubstratoController(servico service.SubstratoService) *SubstratoController {
+	return &SubstratoController{servico}
+}
+
+// Criar godoc
+// @Summary      Cadastra uma mistura de substrato
+// @Description  pH e retenção de água (fração do volume retida); a retenção orienta a sugestão de rega
+// @Tags         substratos
+// @Accept       json
+// @Produce      json
+// @Param        substrato  body      dto.SubstratoDTO  true  "Substrato"
+// @Success      201        {object}  entity.Substrato
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /api/v1/substratos [post]
+func (c *SubstratoController) Criar(ctx *gin.Context) {
+	var substratoDto dto.SubstratoDTO
+	if err := ctx.ShouldBindJSON(&substratoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar substrato")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	substrato, err := c.servico.Criar(&substratoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao criar substrato")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, substrato)
+}
+
+// Listar godoc
+// @Summary      Lista os substratos
+// @Description  Ordenados por nome
+// @Tags         substratos
+// @Produce      json
+// @Success      200  {array}   entity.Substrato
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/substratos [get]
+func (c *SubstratoController) Listar(ctx *gin.Context) {
+	substratos, err := c.servico.Listar()
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar substratos")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, substratos)
+}
+
+// BuscarPorID godoc
+// @Summary      Busca um substrato por ID
+// @Tags         substratos
+// @Produce      json
+// @Param        id   path      int  true  "ID do Substrato"
+// @Success      200  {object}  entity.Substrato
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/substratos/{id} [get]
+func (c *SubstratoController) BuscarPorID(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	substrato, err := c.servico.BuscarPorID(id)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar substrato")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, substrato)
+}
+
+// Atualizar godoc
+// @Summary      Atualiza um substrato
+// @Description  Altera o cadastro do substrato; as plantas que o usam passam a ver os novos dados
+// @Tags         substratos
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int               true  "ID do Substrato"
+// @Param        substrato  body      dto.SubstratoDTO  true  "Substrato"
+// @Success      200        {object}  entity.Substrato
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      404        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /api/v1/substratos/{id} [put]
+func (c *SubstratoController) Atualizar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var substratoDto dto.SubstratoDTO
+	if err := ctx.ShouldBindJSON(&substratoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar substrato")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	substrato, err := c.servico.Atualizar(id, &substratoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar substrato")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, substrato)
+}
+
+// Deletar godoc
+// @Summary      Remove um substrato
+// @Description  As plantas e os transplantes que usavam o substrato são mantidos
+// @Tags         substratos
+// @Param        id   path      int  true  "ID do Substrato"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/substratos/{id} [delete]
+func (c *SubstratoController) Deletar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar substrato")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *SubstratoController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Registro não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSubstratoService é um mock para o service.SubstratoService
+type MockSubstratoService struct {
+	mock.Mock
+}
+
+func (m *MockSubstratoService) Criar(substratoDto *dto.SubstratoDTO) (*entity.Substrato, error) {
+	args := m.Called(substratoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Substrato), args.Error(1)
+}
+
+func (m *MockSubstratoService) BuscarPorID(id uint) (*entity.Substrato, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Substrato), args.Error(1)
+}
+
+func (m *MockSubstratoService) Listar() ([]entity.Substrato, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Substrato), args.Error(1)
+}
+
+func (m *MockSubstratoService) Atualizar(id uint, substratoDto *dto.SubstratoDTO) (*entity.Substrato, error) {
+	args := m.Called(id, substratoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Substrato), args.Error(1)
+}
+
+func (m *MockSubstratoService) Deletar(id uint) error {
+	return m.Called(id).Error(0)
+}
+
+func routerSubstratos(mockService *MockSubstratoService) *gin.Engine {
+	controlador := NewSubstratoController(mockService)
+	router := novoRouterTeste()
+	router.POST("/substratos", controlador.Criar)
+	router.GET("/substratos", controlador.Listar)
+	router.GET("/substratos/:id", controlador.BuscarPorID)
+	router.PUT("/substratos/:id", controlador.Atualizar)
+	router.DELETE("/substratos/:id", controlador.Deletar)
+	return router
+}
+
+const substratoValido = `{"nome":"Light mix","composicao":"turfa, perlita","ph":6.2,"retencao_agua":0.3}`
+
+func TestSubstratoController_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockSubstratoService)
+		mockService.On("Criar", mock.MatchedBy(func(d *dto.SubstratoDTO) bool {
+			return d.Nome == "Light mix" && d.PH == 6.2 && d.RetencaoAgua == 0.3
+		})).Return(&entity.Substrato{Nome: "Light mix"}, nil).Once()
+
+		w := requisitar(routerSubstratos(mockService), http.MethodPost, "/substratos", substratoValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Retenção Acima de Um", func(t *testing.T) {
+		mockService := new(MockSubstratoService)
+
+		w := requisitar(routerSubstratos(mockService), http.MethodPost, "/substratos", `{"nome":"Coco","retencao_agua":1.5}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "RetencaoAgua")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - pH Fora da Escala", func(t *testing.T) {
+		mockService := new(MockSubstratoService)
+
+		w := requisitar(routerSubstratos(mockService), http.MethodPost, "/substratos", `{"nome":"Coco","ph":15}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "PH")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+}
+
+func TestSubstratoController_Listar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockSubstratoService)
+		mockService.On("Listar").Return([]entity.Substrato{{Nome: "Light mix"}, {Nome: "Coco"}}, nil).Once()
+
+		w := requisitar(routerSubstratos(mockService), http.MethodGet, "/substratos", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Coco")
+	})
+
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockSubstratoService)
+		mockService.On("Listar").Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerSubstratos(mockService), http.MethodGet, "/substratos", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestSubstratoController_Atualizar(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockSubstratoService)
+
+		w := requisitar(routerSubstratos(mockService), http.MethodPut, "/substratos/0", substratoValido)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Atualizar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Substrato Inexistente", func(t *testing.T) {
+		mockService := new(MockSubstratoService)
+		mockService.On("Atualizar", uint(4), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerSubstratos(mockService), http.MethodPut, "/substratos/4", substratoValido)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSubstratoController_Deletar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockSubstratoService)
+		mockService.On("Deletar", uint(4)).Return(nil).Once()
+
+		w := requisitar(routerSubstratos(mockService), http.MethodDelete, "/substratos/4", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,331 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// tamanhoMaximoTabelaNutricao limita o corpo das importações de tabelas de nutrição
+const tamanhoMaximoTabelaNutricao = 1 << 20
+
+type TabelaNutricaoController struct {
+	servico service.TabelaNutricaoService
+}
+
+func NewTabelaNutricaoController(servico service.TabelaNutricaoService) *TabelaNutricaoController {
+	return &TabelaNutricaoController{servico}
+}
+
+// Criar godoc
+// @Summary      Cria uma tabela de nutrição
+// @Description  Tabela de alimentação de uma linha de nutrientes: dose de cada produto por estágio e semana do estágio, com a EC e o pH da semana
+// @Tags         tabelas-nutricao
+// @Accept       json
+// @Produce      json
+// @Param        tabela  body      dto.TabelaNutricaoDTO  true  "Tabela de nutrição"
+// @Success      201     {object}  entity.TabelaNutricao
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/tabelas-nutricao [post]
+func (c *TabelaNutricaoController) Criar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var tabelaDto dto.TabelaNutricaoDTO
+	if err := ctx.ShouldBindJSON(&tabelaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar tabela de nutrição")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	tabela, err := c.servico.Criar(usuarioID, &tabelaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao criar tabela de nutrição")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, tabela)
+}
+
+// Listar godoc
+// @Summary      Lista as tabelas de nutrição do usuário
+// @Tags         tabelas-nutricao
+// @Produce      json
+// @Success      200  {array}   entity.TabelaNutricao
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/tabelas-nutricao [get]
+func (c *TabelaNutricaoController) Listar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	tabelas, err := c.servico.Listar(usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar tabelas de nutrição")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, tabelas)
+}
+
+// BuscarPorID godoc
+// @Summary      Busca uma tabela de nutrição por ID
+// @Tags         tabelas-nutricao
+// @Produce      json
+// @Param        id   path      int  true  "ID da Tabela"
+// @Success      200  {object}  entity.TabelaNutricao
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/tabelas-nutricao/{id} [get]
+func (c *TabelaNutricaoController) BuscarPorID(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	tabela, err := c.servico.BuscarPorID(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar tabela de nutrição")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, tabela)
+}
+
+// Atualizar godoc
+// @Summary      Atualiza uma tabela de nutrição
+// @Description  Os itens informados substituem os anteriores
+// @Tags         tabelas-nutricao
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                    true  "ID da Tabela"
+// @Param        tabela  body      dto.TabelaNutricaoDTO  true  "Tabela de nutrição"
+// @Success      200     {object}  entity.TabelaNutricao
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/tabelas-nutricao/{id} [put]
+func (c *TabelaNutricaoController) Atualizar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var tabelaDto dto.TabelaNutricaoDTO
+	if err := ctx.ShouldBindJSON(&tabelaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar tabela de nutrição")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	tabela, err := c.servico.Atualizar(id, usuarioID, &tabelaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar tabela de nutrição")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, tabela)
+}
+
+// Deletar godoc
+// @Summary      Remove uma tabela de nutrição
+// @Tags         tabelas-nutricao
+// @Param        id   path      int  true  "ID da Tabela"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/tabelas-nutricao/{id} [delete]
+func (c *TabelaNutricaoController) Deletar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar tabela de nutrição")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ImportarJSON godoc
+// @Summary      Importa uma tabela de nutrição em JSON
+// @Description  Aceita o documento no corpo ou no campo "arquivo" de um multipart/form-data, até 1 MB, no mesmo formato da criação. Estágios aceitam os nomes usuais dos fabricantes (veg, bloom, flush...)
+// @Tags         tabelas-nutricao
+// @Accept       json
+// @Accept       mpfd
+// @Produce      json
+// @Param        arquivo  formData  file  false  "Arquivo JSON (multipart)"
+// @Success      201      {object}  entity.TabelaNutricao
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      413      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/tabelas-nutricao/importar/json [post]
+func (c *TabelaNutricaoController) ImportarJSON(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	corpo, fechar, err := c.arquivoImportado(ctx)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro ao ler o arquivo JSON enviado")
+		return
+	}
+	defer fechar()
+
+	tabela, err := c.servico.ImportarJSON(usuarioID, corpo)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao importar tabela de nutrição")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, tabela)
+}
+
+// ImportarCSV godoc
+// @Summary      Importa uma tabela de nutrição em CSV
+// @Description  Aceita o CSV no corpo (text/csv) ou no campo "arquivo" de um multipart/form-data, até 1 MB. Uma linha por produto e semana, com as colunas estagio, semana, produto, dose e unidade (ml_l ou g_l) e, opcionalmente, ec_alvo e ph_alvo
+// @Tags         tabelas-nutricao
+// @Accept       plain
+// @Accept       mpfd
+// @Produce      json
+// @Param        arquivo      formData  file    false  "Arquivo CSV (multipart)"
+// @Param        nome         query     string  true   "Nome da tabela"
+// @Param        fabricante   query     string  false  "Fabricante da linha de nutrientes"
+// @Param        delimitador  query     string  false  "virgula, ponto_virgula ou tab (padrão: virgula)"
+// @Param        decimal      query     string  false  "Separador decimal: ponto ou virgula (padrão: ponto)"
+// @Success      201          {object}  entity.TabelaNutricao
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      413          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/tabelas-nutricao/importar/csv [post]
+func (c *TabelaNutricaoController) ImportarCSV(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var opcoes dto.ImportacaoTabelaNutricaoDTO
+	if err := ctx.ShouldBindQuery(&opcoes); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para importar tabela de nutrição")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	corpo, fechar, err := c.arquivoImportado(ctx)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro ao ler o arquivo CSV enviado")
+		return
+	}
+	defer fechar()
+
+	tabela, err := c.servico.ImportarCSV(usuarioID, corpo, &opcoes)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao importar tabela de nutrição")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, tabela)
+}
+
+// CalcularMistura godoc
+// @Summary      Calcula a mistura do reservatório
+// @Description  Quantidade de cada produto da semana da tabela para o volume informado. A semana vem do estágio atual da planta ou de estagio e semana; com ec_alvo, as doses são ajustadas descontando a EC da água base (ec_agua_base ou ppm_agua_base). Para produtos com solução estoque, retorna também os ml do estoque a adicionar
+// @Tags         tabelas-nutricao
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                    true  "ID da Tabela"
+// @Param        calculo  body      dto.CalculoMisturaDTO  true  "Reservatório e alvo"
+// @Success      200      {object}  dto.ResultadoMisturaDTO
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /api/v1/tabelas-nutricao/{id}/mistura [post]
+func (c *TabelaNutricaoController) CalcularMistura(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var calculoDto dto.CalculoMisturaDTO
+	if err := ctx.ShouldBindJSON(&calculoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para calcular mistura")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	resultado, err := c.servico.CalcularMistura(id, usuarioID, &calculoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao calcular mistura")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, resultado)
+}
+
+// arquivoImportado retorna o corpo da requisição ou o campo "arquivo" do multipart, limitado
+// a tamanhoMaximoTabelaNutricao
+func (c *TabelaNutricaoController) arquivoImportado(ctx *gin.Context) (io.Reader, func(), error) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, tamanhoMaximoTabelaNutricao)
+	if !strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		return ctx.Request.Body, func() {}, nil
+	}
+	arquivo, err := ctx.FormFile("arquivo")
+	if err != nil {
+		return nil, nil, err
+	}
+	aberto, err := arquivo.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	return aberto, func() { aberto.Close() }, nil
+}
+
+func (c *TabelaNutricaoController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	var errTamanho *http.MaxBytesError
+	switch {
+	case errors.As(err, &errTamanho):
+		utils.RespondWithError(ctx, http.StatusRequestEntityTooLarge, "Arquivo maior que o limite de importação", err.Error())
+	case errors.Is(err, http.ErrMissingFile):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Campo \"arquivo\" ausente", err.Error())
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Tabela de nutrição não encontrada", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,280 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTabelaNutricaoService é um mock para o service.TabelaNutricaoService
+type MockTabelaNutricaoService struct {
+	mock.Mock
+}
+
+func (m *MockTabelaNutricaoService) Criar(usuarioID uint, tabelaDto *dto.TabelaNutricaoDTO) (*entity.TabelaNutricao, error) {
+	args := m.Called(usuarioID, tabelaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TabelaNutricao), args.Error(1)
+}
+
+func (m *MockTabelaNutricaoService) Listar(usuarioID uint) ([]entity.TabelaNutricao, error) {
+	args := m.Called(usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.TabelaNutricao), args.Error(1)
+}
+
+func (m *MockTabelaNutricaoService) BuscarPorID(id, usuarioID uint) (*entity.TabelaNutricao, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TabelaNutricao), args.Error(1)
+}
+
+func (m *MockTabelaNutricaoService) Atualizar(id, usuarioID uint, tabelaDto *dto.TabelaNutricaoDTO) (*entity.TabelaNutricao, error) {
+	args := m.Called(id, usuarioID, tabelaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TabelaNutricao), args.Error(1)
+}
+
+func (m *MockTabelaNutricaoService) Deletar(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+// ImportarJSON lê o arquivo como o serviço faria, para que o mock receba o conteúdo
+// e os erros de leitura (como o limite de tamanho) cheguem ao controller
+func (m *MockTabelaNutricaoService) ImportarJSON(usuarioID uint, arquivo io.Reader) (*entity.TabelaNutricao, error) {
+	conteudo, err := io.ReadAll(arquivo)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(usuarioID, string(conteudo))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TabelaNutricao), args.Error(1)
+}
+
+func (m *MockTabelaNutricaoService) ImportarCSV(usuarioID uint, arquivo io.Reader, opcoes *dto.ImportacaoTabelaNutricaoDTO) (*entity.TabelaNutricao, error) {
+	conteudo, err := io.ReadAll(arquivo)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(usuarioID, string(conteudo), opcoes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TabelaNutricao), args.Error(1)
+}
+
+func (m *MockTabelaNutricaoService) CalcularMistura(id, usuarioID uint, calculoDto *dto.CalculoMisturaDTO) (*dto.ResultadoMisturaDTO, error) {
+	args := m.Called(id, usuarioID, calculoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ResultadoMisturaDTO), args.Error(1)
+}
+
+func routerTabelasNutricao(mockService *MockTabelaNutricaoService) *gin.Engine {
+	controlador := NewTabelaNutricaoController(mockService)
+	router := novoRouterTeste()
+	router.POST("/tabelas-nutricao", controlador.Criar)
+	router.GET("/tabelas-nutricao", controlador.Listar)
+	router.POST("/tabelas-nutricao/importar/json", controlador.ImportarJSON)
+	router.POST("/tabelas-nutricao/importar/csv", controlador.ImportarCSV)
+	router.GET("/tabelas-nutricao/:id", controlador.BuscarPorID)
+	router.PUT("/tabelas-nutricao/:id", controlador.Atualizar)
+	router.DELETE("/tabelas-nutricao/:id", controlador.Deletar)
+	router.POST("/tabelas-nutricao/:id/mistura", controlador.CalcularMistura)
+	return router
+}
+
+const tabelaNutricaoValida = `{"nome":"Linha Bio","itens":[{"estagio":"vegetativo","semana":1,"produto":"Grow","dose":1,"unidade":"ml_l"}]}`
+
+func TestTabelaNutricaoController_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+		mockService.On("Criar", uint(7), mock.MatchedBy(func(d *dto.TabelaNutricaoDTO) bool {
+			return d.Nome == "Linha Bio" && len(d.Itens) == 1 && d.Itens[0].Estagio == "vegetativo"
+		})).Return(&entity.TabelaNutricao{Nome: "Linha Bio"}, nil).Once()
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPost, "/tabelas-nutricao", tabelaNutricaoValida)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Estágio Desconhecido", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPost, "/tabelas-nutricao",
+			`{"nome":"Linha Bio","itens":[{"estagio":"colheita","semana":1,"produto":"Grow","dose":1,"unidade":"ml_l"}]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Estagio")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Semana Repetida", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+		mockService.On("Criar", uint(7), mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPost, "/tabelas-nutricao", tabelaNutricaoValida)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTabelaNutricaoController_Atualizar(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPut, "/tabelas-nutricao/abc", tabelaNutricaoValida)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Atualizar", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Tabela de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+		mockService.On("Atualizar", uint(2), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPut, "/tabelas-nutricao/2", tabelaNutricaoValida)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Tabela de nutrição não encontrada")
+	})
+}
+
+func TestTabelaNutricaoController_ImportarJSON(t *testing.T) {
+	t.Run("Success - Corpo da Requisição", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+		mockService.On("ImportarJSON", uint(7), tabelaNutricaoValida).Return(&entity.TabelaNutricao{Nome: "Linha Bio"}, nil).Once()
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPost, "/tabelas-nutricao/importar/json", tabelaNutricaoValida)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Arquivo Maior que o Limite", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPost, "/tabelas-nutricao/importar/json",
+			strings.Repeat(" ", tamanhoMaximoTabelaNutricao+1))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		mockService.AssertNotCalled(t, "ImportarJSON", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTabelaNutricaoController_ImportarCSV(t *testing.T) {
+	const csv = "estagio,semana,produto,dose,unidade\nvegetativo,1,Grow,1,ml_l\n"
+
+	t.Run("Success - Arquivo Multipart com Opções", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+		mockService.On("ImportarCSV", uint(7), csv, &dto.ImportacaoTabelaNutricaoDTO{Nome: "Linha Bio", Decimal: "virgula"}).
+			Return(&entity.TabelaNutricao{Nome: "Linha Bio"}, nil).Once()
+		var corpo bytes.Buffer
+		formulario := multipart.NewWriter(&corpo)
+		parte, _ := formulario.CreateFormFile("arquivo", "tabela.csv")
+		parte.Write([]byte(csv))
+		formulario.Close()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/tabelas-nutricao/importar/csv?nome=Linha+Bio&decimal=virgula", &corpo)
+		req.Header.Set("Content-Type", formulario.FormDataContentType())
+		routerTabelasNutricao(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Nome Obrigatório", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPost, "/tabelas-nutricao/importar/csv", csv)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ImportarCSV", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Multipart Sem o Campo Arquivo", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+		var corpo bytes.Buffer
+		formulario := multipart.NewWriter(&corpo)
+		formulario.WriteField("outro", "valor")
+		formulario.Close()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/tabelas-nutricao/importar/csv?nome=Linha+Bio", &corpo)
+		req.Header.Set("Content-Type", formulario.FormDataContentType())
+		routerTabelasNutricao(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "arquivo")
+		mockService.AssertNotCalled(t, "ImportarCSV", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTabelaNutricaoController_CalcularMistura(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+		mockService.On("CalcularMistura", uint(2), uint(7), mock.MatchedBy(func(d *dto.CalculoMisturaDTO) bool {
+			return d.VolumeLitros == 20 && d.Semana == 3 && d.Estagio == "floracao"
+		})).Return(&dto.ResultadoMisturaDTO{}, nil).Once()
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPost, "/tabelas-nutricao/2/mistura",
+			`{"volume_litros":20,"estagio":"floracao","semana":3}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Volume Obrigatório", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPost, "/tabelas-nutricao/2/mistura", `{"semana":3}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CalcularMistura", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+		mockService.On("CalcularMistura", uint(2), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodPost, "/tabelas-nutricao/2/mistura", `{"volume_litros":20,"planta_id":10}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestTabelaNutricaoController_Listar(t *testing.T) {
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockTabelaNutricaoService)
+		mockService.On("Listar", uint(7)).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerTabelasNutricao(mockService), http.MethodGet, "/tabelas-nutricao", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type TarefaController struct {
+	servico service.TarefaService
+}
+
+func NewTarefaController(servico service.TarefaService) *TarefaController {
+	return &TarefaController{servico}
+}
+
+// Criar godoc
+// @Summary      Cria uma tarefa de cuidado
+// @Description  Tarefas podem ser ligadas a uma planta, a um ambiente e a um diário; recorrentes precisam de frequencia_dias
+// @Tags         tarefas
+// @Accept       json
+// @Produce      json
+// @Param        tarefa  body      dto.TarefaDTO  true  "Tarefa"
+// @Success      201     {object}  entity.Tarefa
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/tarefas [post]
+func (c *TarefaController) Criar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var tarefaDto dto.TarefaDTO
+	if err := ctx.ShouldBindJSON(&tarefaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar tarefa")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	tarefa, err := c.servico.Criar(usuarioID, &tarefaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Tarefa não encontrada", "Erro interno ao criar tarefa")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, tarefa)
+}
+
+// Listar godoc
+// @Summary      Lista as tarefas do usuário
+// @Description  Ordenadas pela data agendada. Pendentes vencidas aparecem como atrasadas.
+// @Tags         tarefas
+// @Produce      json
+// @Param        status             query     string  false  "pendente, concluida ou atrasada"
+// @Param        planta_id          query     int     false  "Filtra por planta"
+// @Param        ambiente_id        query     int     false  "Filtra por ambiente"
+// @Param        diario_cultivo_id  query     int     false  "Filtra por diário de cultivo"
+// @Param        de                 query     string  false  "Agendadas a partir do dia (AAAA-MM-DD)"
+// @Param        ate                query     string  false  "Agendadas até o dia (AAAA-MM-DD)"
+// @Param        page               query     int     false  "Número da página (padrão: 1)"
+// @Param        limit              query     int     false  "Limite de itens por página (padrão: 10)"
+// @Success      200                {object}  dto.PaginatedResponse{data=[]entity.Tarefa}
+// @Failure      400                {object}  map[string]string
+// @Failure      401                {object}  map[string]string
+// @Failure      500                {object}  map[string]string
+// @Router       /api/v1/tarefas [get]
+func (c *TarefaController) Listar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+
+	var consulta dto.ConsultaTarefasDTO
+	if err := ctx.ShouldBindQuery(&consulta); err != nil {
+		logrus.WithError(err).Error("Parâmetros inválidos para listar tarefas")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	tarefas, total, err := c.servico.Listar(usuarioID, &consulta)
+	if err != nil {
+		c.responderErro(ctx, err, "Tarefa não encontrada", "Erro interno ao listar tarefas")
+		return
+	}
+
+	dataBytes, err := json.Marshal(tarefas)
+	if err != nil {
+		logrus.WithError(err).Error("Erro ao serializar tarefas para resposta paginada")
+		utils.RespondWithError(ctx, http.StatusInternalServerError, "Erro interno ao listar tarefas", err.Error())
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, &dto.PaginatedResponse{
+		Data:  dataBytes,
+		Total: total,
+		Page:  consulta.Page,
+		Limit: consulta.Limit,
+	})
+}
+
+// BuscarPorID godoc
+// @Summary      Busca uma tarefa com as fotos
+// @Tags         tarefas
+// @Produce      json
+// @Param        id   path      int  true  "ID da Tarefa"
+// @Success      200  {object}  entity.Tarefa
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/tarefas/{id} [get]
+func (c *TarefaController) BuscarPorID(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	tarefa, err := c.servico.BuscarPorID(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Tarefa não encontrada", "Erro interno ao buscar tarefa")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, tarefa)
+}
+
+// Atualizar godoc
+// @Summary      Atualiza ou reagenda uma tarefa
+// @Tags         tarefas
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int            true  "ID da Tarefa"
+// @Param        tarefa  body      dto.TarefaDTO  true  "Tarefa"
+// @Success      200     {object}  entity.Tarefa
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /api/v1/tarefas/{id} [put]
+func (c *TarefaController) Atualizar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var tarefaDto dto.TarefaDTO
+	if err := ctx.ShouldBindJSON(&tarefaDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar tarefa")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	tarefa, err := c.servico.Atualizar(id, usuarioID, &tarefaDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Tarefa não encontrada", "Erro interno ao atualizar tarefa")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, tarefa)
+}
+
+// Concluir godoc
+// @Summary      Conclui uma tarefa
+// @Description  Registra notas e fotos da execução e dá baixa no estoque dos insumos informados; nas tarefas recorrentes, agenda a próxima ocorrência
+// @Tags         tarefas
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int                     true  "ID da Tarefa"
+// @Param        conclusao  body      dto.ConclusaoTarefaDTO  true  "Conclusão"
+// @Success      200        {object}  dto.TarefaConcluidaDTO
+// @Failure      400        {object}  map[string]string
+// @Failure      401        {object}  map[string]string
+// @Failure      404        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /api/v1/tarefas/{id}/concluir [post]
+func (c *TarefaController) Concluir(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var conclusaoDto dto.ConclusaoTarefaDTO
+	if err := ctx.ShouldBindJSON(&conclusaoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para concluir tarefa")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	resultado, err := c.servico.Concluir(id, usuarioID, &conclusaoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Tarefa não encontrada", "Erro interno ao concluir tarefa")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, resultado)
+}
+
+// Deletar godoc
+// @Summary      Remove uma tarefa
+// @Tags         tarefas
+// @Param        id   path  int  true  "ID da Tarefa"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/tarefas/{id} [delete]
+func (c *TarefaController) Deletar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id, usuarioID); err != nil {
+		c.responderErro(ctx, err, "Tarefa não encontrada", "Erro interno ao deletar tarefa")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *TarefaController) responderErro(ctx *gin.Context, err error, naoEncontrado, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, naoEncontrado, err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
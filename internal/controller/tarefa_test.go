@@ -0,0 +1,262 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTarefaService é um mock para o service.TarefaService
+type MockTarefaService struct {
+	mock.Mock
+}
+
+func (m *MockTarefaService) Criar(usuarioID uint, tarefaDto *dto.TarefaDTO) (*entity.Tarefa, error) {
+	args := m.Called(usuarioID, tarefaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tarefa), args.Error(1)
+}
+
+func (m *MockTarefaService) BuscarPorID(id, usuarioID uint) (*entity.Tarefa, error) {
+	args := m.Called(id, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tarefa), args.Error(1)
+}
+
+func (m *MockTarefaService) Listar(usuarioID uint, consulta *dto.ConsultaTarefasDTO) ([]entity.Tarefa, int64, error) {
+	args := m.Called(usuarioID, consulta)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entity.Tarefa), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTarefaService) Atualizar(id, usuarioID uint, tarefaDto *dto.TarefaDTO) (*entity.Tarefa, error) {
+	args := m.Called(id, usuarioID, tarefaDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tarefa), args.Error(1)
+}
+
+func (m *MockTarefaService) Concluir(id, usuarioID uint, conclusaoDto *dto.ConclusaoTarefaDTO) (*dto.TarefaConcluidaDTO, error) {
+	args := m.Called(id, usuarioID, conclusaoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TarefaConcluidaDTO), args.Error(1)
+}
+
+func (m *MockTarefaService) Deletar(id, usuarioID uint) error {
+	return m.Called(id, usuarioID).Error(0)
+}
+
+func (m *MockTarefaService) AtualizarAtrasadas() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTarefaService) MaterializarRecorrentes(horizonte time.Duration) (int, error) {
+	args := m.Called(horizonte)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTarefaService) DispararLembretes(antecedencia time.Duration) (int, error) {
+	args := m.Called(antecedencia)
+	return args.Int(0), args.Error(1)
+}
+
+func registrarRotasTarefa(router *gin.Engine, mockService *MockTarefaService) *gin.Engine {
+	controlador := NewTarefaController(mockService)
+	router.POST("/tarefas", controlador.Criar)
+	router.GET("/tarefas", controlador.Listar)
+	router.GET("/tarefas/:id", controlador.BuscarPorID)
+	router.PUT("/tarefas/:id", controlador.Atualizar)
+	router.POST("/tarefas/:id/concluir", controlador.Concluir)
+	router.DELETE("/tarefas/:id", controlador.Deletar)
+	return router
+}
+
+func routerTarefas(mockService *MockTarefaService) *gin.Engine {
+	return registrarRotasTarefa(novoRouterTeste(), mockService)
+}
+
+const tarefaValida = `{"tipo":"regar","data_agendada":"2026-06-01T09:00:00Z"}`
+
+func TestTarefaController_Criar(t *testing.T) {
+	t.Run("Success - Cria para o Usuário Autenticado", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		mockService.On("Criar", uint(7), mock.MatchedBy(func(d *dto.TarefaDTO) bool {
+			return d.Tipo == "regar" && d.DataAgendada.Equal(time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC))
+		})).Return(&entity.Tarefa{Tipo: "regar", UsuarioID: 7}, nil).Once()
+
+		w := requisitar(routerTarefas(mockService), http.MethodPost, "/tarefas", tarefaValida)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Recorrente Sem Frequência", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+
+		w := requisitar(routerTarefas(mockService), http.MethodPost, "/tarefas",
+			`{"tipo":"regar","data_agendada":"2026-06-01T09:00:00Z","recorrente":true}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "FrequenciaDias")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		mockService.On("Criar", uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerTarefas(mockService), http.MethodPost, "/tarefas", tarefaValida)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Error - Usuário Não Autenticado", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		gin.SetMode(gin.TestMode)
+
+		w := requisitar(registrarRotasTarefa(gin.New(), mockService), http.MethodPost, "/tarefas", tarefaValida)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockService.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTarefaController_Listar(t *testing.T) {
+	t.Run("Success - Resposta Paginada", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		mockService.On("Listar", uint(7), mock.MatchedBy(func(c *dto.ConsultaTarefasDTO) bool {
+			return c.Status == "atrasada" && c.Page == 2 && c.Limit == 10
+		})).Return([]entity.Tarefa{{Tipo: "regar"}}, int64(11), nil).Once()
+
+		w := requisitar(routerTarefas(mockService), http.MethodGet, "/tarefas?status=atrasada&page=2", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resposta dto.PaginatedResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resposta))
+		assert.Equal(t, int64(11), resposta.Total)
+		assert.Equal(t, 2, resposta.Page)
+	})
+
+	t.Run("Error - Status Desconhecido", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+
+		w := requisitar(routerTarefas(mockService), http.MethodGet, "/tarefas?status=cancelada", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Listar", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTarefaController_BuscarPorID(t *testing.T) {
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+
+		w := requisitar(routerTarefas(mockService), http.MethodGet, "/tarefas/abc", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "BuscarPorID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Tarefa de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		mockService.On("BuscarPorID", uint(4), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerTarefas(mockService), http.MethodGet, "/tarefas/4", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Tarefa não encontrada")
+	})
+}
+
+func TestTarefaController_Concluir(t *testing.T) {
+	t.Run("Success - Retorna a Próxima Ocorrência", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		mockService.On("Concluir", uint(4), uint(7), mock.MatchedBy(func(c *dto.ConclusaoTarefaDTO) bool {
+			return c.Notas == "ok"
+		})).Return(&dto.TarefaConcluidaDTO{Proxima: &entity.Tarefa{Tipo: "regar"}}, nil).Once()
+
+		w := requisitar(routerTarefas(mockService), http.MethodPost, "/tarefas/4/concluir", `{"notas":"ok"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"proxima"`)
+	})
+
+	t.Run("Error - Foto Sem URL Válida", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+
+		w := requisitar(routerTarefas(mockService), http.MethodPost, "/tarefas/4/concluir", `{"fotos":[{"url":"foto.jpg"}]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Concluir", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Repassa os Insumos Consumidos", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		mockService.On("Concluir", uint(4), uint(7), mock.MatchedBy(func(c *dto.ConclusaoTarefaDTO) bool {
+			return len(c.Consumos) == 1 && c.Consumos[0].ItemID == 3 && c.Consumos[0].Quantidade == 2
+		})).Return(&dto.TarefaConcluidaDTO{}, nil).Once()
+
+		w := requisitar(routerTarefas(mockService), http.MethodPost, "/tarefas/4/concluir", `{"consumos":[{"item_id":3,"quantidade":2}]}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Consumo Sem Quantidade", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+
+		w := requisitar(routerTarefas(mockService), http.MethodPost, "/tarefas/4/concluir", `{"consumos":[{"item_id":3}]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Concluir", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Tarefa Já Concluída", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		mockService.On("Concluir", uint(4), uint(7), mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		w := requisitar(routerTarefas(mockService), http.MethodPost, "/tarefas/4/concluir", `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTarefaController_Deletar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		mockService.On("Deletar", uint(4), uint(7)).Return(nil).Once()
+
+		w := requisitar(routerTarefas(mockService), http.MethodDelete, "/tarefas/4", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockTarefaService)
+		mockService.On("Deletar", uint(4), uint(7)).Return(errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerTarefas(mockService), http.MethodDelete, "/tarefas/4", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
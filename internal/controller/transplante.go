@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type TransplanteController struct {
+	servico service.TransplanteService
+}
+
+func NewTransplanteController(servico service.TransplanteService) *TransplanteController {
+	return &TransplanteController{servico}
+}
+
+// BuscarRecipiente godoc
+// @Summary      Vaso e substrato atuais da planta
+// @Tags         transplantes
+// @Produce      json
+// @Param        id   path      int  true  "ID da Planta"
+// @Success      200  {object}  dto.RecipientePlantaDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/plantas/{id}/recipiente [get]
+func (c *TransplanteController) BuscarRecipiente(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	recipiente, err := c.servico.BuscarRecipiente(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar recipiente da planta")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, recipiente)
+}
+
+// DefinirRecipiente godoc
+// @Summary      Define o vaso e o substrato da planta
+// @Description  Corrige o recipiente atual sem registrar transplante; campos omitidos ficam vazios
+// @Tags         transplantes
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                true  "ID da Planta"
+// @Param        recipiente  body      dto.RecipienteDTO  true  "Recipiente"
+// @Success      200         {object}  dto.RecipientePlantaDTO
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /api/v1/plantas/{id}/recipiente [put]
+func (c *TransplanteController) DefinirRecipiente(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var recipienteDto dto.RecipienteDTO
+	if err := ctx.ShouldBindJSON(&recipienteDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para definir recipiente da planta")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	recipiente, err := c.servico.DefinirRecipiente(id, usuarioID, &recipienteDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao definir recipiente da planta")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, recipiente)
+}
+
+// Transplantar godoc
+// @Summary      Registra um transplante da planta
+// @Description  Guarda o vaso e o substrato anteriores e passa a planta para os novos; o que não for informado continua igual. Os consumos dão baixa no estoque com origem transplante
+// @Tags         transplantes
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int                 true  "ID da Planta"
+// @Param        transplante  body      dto.TransplanteDTO  true  "Transplante"
+// @Success      201          {object}  entity.Transplante
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /api/v1/plantas/{id}/transplantes [post]
+func (c *TransplanteController) Transplantar(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var transplanteDto dto.TransplanteDTO
+	if err := ctx.ShouldBindJSON(&transplanteDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para registrar transplante")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	transplante, err := c.servico.Transplantar(id, usuarioID, &transplanteDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao registrar transplante")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, transplante)
+}
+
+// ListarTransplantes godoc
+// @Summary      Histórico de transplantes da planta
+// @Description  Em ordem cronológica, com os vasos e substratos anteriores e novos
+// @Tags         transplantes
+// @Produce      json
+// @Param        id   path      int  true  "ID da Planta"
+// @Success      200  {array}   entity.Transplante
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/plantas/{id}/transplantes [get]
+func (c *TransplanteController) ListarTransplantes(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	transplantes, err := c.servico.ListarTransplantes(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar transplantes")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, transplantes)
+}
+
+// SugerirRega godoc
+// @Summary      Sugestão de rega pelo vaso da planta
+// @Description  Calcula o volume pela capacidade do vaso e a retenção do substrato e compara com as regas individuais desde que a planta está no vaso atual
+// @Tags         transplantes
+// @Produce      json
+// @Param        id   path      int  true  "ID da Planta"
+// @Success      200  {object}  dto.SugestaoRegaDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/plantas/{id}/sugestao-rega [get]
+func (c *TransplanteController) SugerirRega(ctx *gin.Context) {
+	usuarioID, ok := usuarioAutenticado(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	sugestao, err := c.servico.SugerirRega(id, usuarioID)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao sugerir rega")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, sugestao)
+}
+
+func (c *TransplanteController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Registro não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransplanteService é um mock para o service.TransplanteService
+type MockTransplanteService struct {
+	mock.Mock
+}
+
+func (m *MockTransplanteService) BuscarRecipiente(plantaID, usuarioID uint) (*dto.RecipientePlantaDTO, error) {
+	args := m.Called(plantaID, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RecipientePlantaDTO), args.Error(1)
+}
+
+func (m *MockTransplanteService) DefinirRecipiente(plantaID, usuarioID uint, recipienteDto *dto.RecipienteDTO) (*dto.RecipientePlantaDTO, error) {
+	args := m.Called(plantaID, usuarioID, recipienteDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RecipientePlantaDTO), args.Error(1)
+}
+
+func (m *MockTransplanteService) Transplantar(plantaID, usuarioID uint, transplanteDto *dto.TransplanteDTO) (*entity.Transplante, error) {
+	args := m.Called(plantaID, usuarioID, transplanteDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Transplante), args.Error(1)
+}
+
+func (m *MockTransplanteService) ListarTransplantes(plantaID, usuarioID uint) ([]entity.Transplante, error) {
+	args := m.Called(plantaID, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Transplante), args.Error(1)
+}
+
+func (m *MockTransplanteService) SugerirRega(plantaID, usuarioID uint) (*dto.SugestaoRegaDTO, error) {
+	args := m.Called(plantaID, usuarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SugestaoRegaDTO), args.Error(1)
+}
+
+func routerTransplantes(mockService *MockTransplanteService) *gin.Engine {
+	controlador := NewTransplanteController(mockService)
+	router := novoRouterTeste()
+	router.GET("/plantas/:id/recipiente", controlador.BuscarRecipiente)
+	router.PUT("/plantas/:id/recipiente", controlador.DefinirRecipiente)
+	router.POST("/plantas/:id/transplantes", controlador.Transplantar)
+	router.GET("/plantas/:id/transplantes", controlador.ListarTransplantes)
+	router.GET("/plantas/:id/sugestao-rega", controlador.SugerirRega)
+	return router
+}
+
+func TestTransplanteController_BuscarRecipiente(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+		mockService.On("BuscarRecipiente", uint(1), uint(7)).
+			Return(&dto.RecipientePlantaDTO{PlantaID: 1, Vaso: &entity.Vaso{Nome: "Vaso de tecido 11L"}}, nil).Once()
+
+		w := requisitar(routerTransplantes(mockService), http.MethodGet, "/plantas/1/recipiente", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Vaso de tecido 11L")
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+		mockService.On("BuscarRecipiente", uint(1), uint(7)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerTransplantes(mockService), http.MethodGet, "/plantas/1/recipiente", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestTransplanteController_DefinirRecipiente(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+		mockService.On("DefinirRecipiente", uint(1), uint(7), mock.MatchedBy(func(d *dto.RecipienteDTO) bool {
+			return d.VasoID != nil && *d.VasoID == 3 && d.SubstratoID == nil
+		})).Return(&dto.RecipientePlantaDTO{PlantaID: 1}, nil).Once()
+
+		w := requisitar(routerTransplantes(mockService), http.MethodPut, "/plantas/1/recipiente", `{"vaso_id":3}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Vaso Zero", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+
+		w := requisitar(routerTransplantes(mockService), http.MethodPut, "/plantas/1/recipiente", `{"vaso_id":0}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "VasoID")
+		mockService.AssertNotCalled(t, "DefinirRecipiente", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTransplanteController_Transplantar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+		mockService.On("Transplantar", uint(1), uint(7), mock.MatchedBy(func(d *dto.TransplanteDTO) bool {
+			return *d.VasoID == 3 && *d.SubstratoID == 4 && len(d.Consumos) == 1 &&
+				d.Consumos[0].ItemID == 9 && d.Consumos[0].Quantidade == 11
+		})).Return(&entity.Transplante{PlantaID: 1}, nil).Once()
+
+		w := requisitar(routerTransplantes(mockService), http.MethodPost, "/plantas/1/transplantes",
+			`{"vaso_id":3,"substrato_id":4,"consumos":[{"item_id":9,"quantidade":11}]}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+
+		w := requisitar(routerTransplantes(mockService), http.MethodPost, "/plantas/abc/transplantes", `{"vaso_id":3}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Transplantar", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Consumo sem Quantidade", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+
+		w := requisitar(routerTransplantes(mockService), http.MethodPost, "/plantas/1/transplantes",
+			`{"vaso_id":3,"consumos":[{"item_id":9}]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Transplantar", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Mesmo Recipiente", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+		mockService.On("Transplantar", uint(1), uint(7), mock.Anything).
+			Return(nil, fmt.Errorf("%w: a planta já está nesse vaso e substrato", utils.ErrInvalidInput)).Once()
+
+		w := requisitar(routerTransplantes(mockService), http.MethodPost, "/plantas/1/transplantes", `{"vaso_id":3}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "a planta já está nesse vaso e substrato")
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+		mockService.On("Transplantar", uint(1), uint(7), mock.Anything).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerTransplantes(mockService), http.MethodPost, "/plantas/1/transplantes", `{"vaso_id":3}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestTransplanteController_ListarTransplantes(t *testing.T) {
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+		mockService.On("ListarTransplantes", uint(1), uint(7)).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerTransplantes(mockService), http.MethodGet, "/plantas/1/transplantes", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestTransplanteController_SugerirRega(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+		mockService.On("SugerirRega", uint(1), uint(7)).
+			Return(&dto.SugestaoRegaDTO{PlantaID: 1, VolumeVasoLitros: 11, VolumeSugeridoLitros: 3.5}, nil).Once()
+
+		w := requisitar(routerTransplantes(mockService), http.MethodGet, "/plantas/1/sugestao-rega", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"volume_sugerido_litros":3.5`)
+	})
+
+	t.Run("Error - Planta sem Vaso", func(t *testing.T) {
+		mockService := new(MockTransplanteService)
+		mockService.On("SugerirRega", uint(1), uint(7)).
+			Return(nil, fmt.Errorf("%w: a planta não tem vaso definido", utils.ErrInvalidInput)).Once()
+
+		w := requisitar(routerTransplantes(mockService), http.MethodGet, "/plantas/1/sugestao-rega", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type VasoController struct {
+	servico service.VasoService
+}
+
+func NewVasoController(servico service.VasoService) *VasoController {
+	return &VasoController{servico}
+}
+
+// Criar godoc
+// @Summary      Cadastra um vaso
+// @Description  Volume em litros e diâmetro em cm; o volume orienta a sugestão de rega das plantas no vaso
+// @Tags         vasos
+// @Accept       json
+// @Produce      json
+// @Param        vaso  body      dto.VasoDTO  true  "Vaso"
+// @Success      201   {object}  entity.Vaso
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/v1/vasos [post]
+func (c *VasoController) Criar(ctx *gin.Context) {
+	var vasoDto dto.VasoDTO
+	if err := ctx.ShouldBindJSON(&vasoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para criar vaso")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	vaso, err := c.servico.Criar(&vasoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao criar vaso")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusCreated, vaso)
+}
+
+// Listar godoc
+// @Summary      Lista os vasos
+// @Description  Ordenados por volume e nome
+// @Tags         vasos
+// @Produce      json
+// @Success      200  {array}   entity.Vaso
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/vasos [get]
+func (c *VasoController) Listar(ctx *gin.Context) {
+	vasos, err := c.servico.Listar()
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao listar vasos")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, vasos)
+}
+
+// BuscarPorID godoc
+// @Summary      Busca um vaso por ID
+// @Tags         vasos
+// @Produce      json
+// @Param        id   path      int  true  "ID do Vaso"
+// @Success      200  {object}  entity.Vaso
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/vasos/{id} [get]
+func (c *VasoController) BuscarPorID(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	vaso, err := c.servico.BuscarPorID(id)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao buscar vaso")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, vaso)
+}
+
+// Atualizar godoc
+// @Summary      Atualiza um vaso
+// @Description  Altera o cadastro do vaso; as plantas que o usam passam a ver os novos dados
+// @Tags         vasos
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int          true  "ID do Vaso"
+// @Param        vaso  body      dto.VasoDTO  true  "Vaso"
+// @Success      200   {object}  entity.Vaso
+// @Failure      400   {object}  map[string]string
+// @Failure      401   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /api/v1/vasos/{id} [put]
+func (c *VasoController) Atualizar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	var vasoDto dto.VasoDTO
+	if err := ctx.ShouldBindJSON(&vasoDto); err != nil {
+		logrus.WithError(err).Error("Payload inválido para atualizar vaso")
+		responderErroBinding(ctx, err)
+		return
+	}
+
+	vaso, err := c.servico.Atualizar(id, &vasoDto)
+	if err != nil {
+		c.responderErro(ctx, err, "Erro interno ao atualizar vaso")
+		return
+	}
+	utils.RespondWithJSON(ctx, http.StatusOK, vaso)
+}
+
+// Deletar godoc
+// @Summary      Remove um vaso
+// @Description  As plantas e os transplantes que usavam o vaso são mantidos
+// @Tags         vasos
+// @Param        id   path      int  true  "ID do Vaso"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /api/v1/vasos/{id} [delete]
+func (c *VasoController) Deletar(ctx *gin.Context) {
+	id, ok := parametroID(ctx, "id")
+	if !ok {
+		return
+	}
+
+	if err := c.servico.Deletar(id); err != nil {
+		c.responderErro(ctx, err, "Erro interno ao deletar vaso")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *VasoController) responderErro(ctx *gin.Context, err error, mensagem string) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.RespondWithError(ctx, http.StatusNotFound, "Registro não encontrado", err.Error())
+	case errors.Is(err, utils.ErrInvalidInput):
+		utils.RespondWithError(ctx, http.StatusBadRequest, "Parâmetros inválidos", err.Error())
+	default:
+		logrus.WithError(err).Error(mensagem)
+		utils.RespondWithError(ctx, http.StatusInternalServerError, mensagem, err.Error())
+	}
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockVasoService é um mock para o service.VasoService
+type MockVasoService struct {
+	mock.Mock
+}
+
+func (m *MockVasoService) Criar(vasoDto *dto.VasoDTO) (*entity.Vaso, error) {
+	args := m.Called(vasoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Vaso), args.Error(1)
+}
+
+func (m *MockVasoService) BuscarPorID(id uint) (*entity.Vaso, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Vaso), args.Error(1)
+}
+
+func (m *MockVasoService) Listar() ([]entity.Vaso, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Vaso), args.Error(1)
+}
+
+func (m *MockVasoService) Atualizar(id uint, vasoDto *dto.VasoDTO) (*entity.Vaso, error) {
+	args := m.Called(id, vasoDto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Vaso), args.Error(1)
+}
+
+func (m *MockVasoService) Deletar(id uint) error {
+	return m.Called(id).Error(0)
+}
+
+func routerVasos(mockService *MockVasoService) *gin.Engine {
+	controlador := NewVasoController(mockService)
+	router := novoRouterTeste()
+	router.POST("/vasos", controlador.Criar)
+	router.GET("/vasos", controlador.Listar)
+	router.GET("/vasos/:id", controlador.BuscarPorID)
+	router.PUT("/vasos/:id", controlador.Atualizar)
+	router.DELETE("/vasos/:id", controlador.Deletar)
+	return router
+}
+
+const vasoValido = `{"nome":"Vaso de tecido 11L","material":"tecido","volume":11,"diametro":28}`
+
+func TestVasoController_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockVasoService)
+		mockService.On("Criar", mock.MatchedBy(func(d *dto.VasoDTO) bool {
+			return d.Nome == "Vaso de tecido 11L" && d.Volume == 11 && d.Diametro == 28
+		})).Return(&entity.Vaso{Nome: "Vaso de tecido 11L", Volume: 11}, nil).Once()
+
+		w := requisitar(routerVasos(mockService), http.MethodPost, "/vasos", vasoValido)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Volume Obrigatório", func(t *testing.T) {
+		mockService := new(MockVasoService)
+
+		w := requisitar(routerVasos(mockService), http.MethodPost, "/vasos", `{"nome":"Vaso sem volume"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Volume")
+		mockService.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Falha Interna", func(t *testing.T) {
+		mockService := new(MockVasoService)
+		mockService.On("Criar", mock.Anything).Return(nil, errors.New("banco indisponível")).Once()
+
+		w := requisitar(routerVasos(mockService), http.MethodPost, "/vasos", vasoValido)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestVasoController_BuscarPorID(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockVasoService)
+		mockService.On("BuscarPorID", uint(3)).Return(&entity.Vaso{Nome: "Vaso de tecido 11L"}, nil).Once()
+
+		w := requisitar(routerVasos(mockService), http.MethodGet, "/vasos/3", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Vaso de tecido 11L")
+	})
+
+	t.Run("Error - ID Inválido", func(t *testing.T) {
+		mockService := new(MockVasoService)
+
+		w := requisitar(routerVasos(mockService), http.MethodGet, "/vasos/abc", "")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "BuscarPorID", mock.Anything)
+	})
+
+	t.Run("Error - Vaso Inexistente", func(t *testing.T) {
+		mockService := new(MockVasoService)
+		mockService.On("BuscarPorID", uint(3)).Return(nil, utils.ErrNotFound).Once()
+
+		w := requisitar(routerVasos(mockService), http.MethodGet, "/vasos/3", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestVasoController_Atualizar(t *testing.T) {
+	t.Run("Error - Diâmetro Negativo", func(t *testing.T) {
+		mockService := new(MockVasoService)
+
+		w := requisitar(routerVasos(mockService), http.MethodPut, "/vasos/3", `{"nome":"Vaso","volume":11,"diametro":-1}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Diametro")
+		mockService.AssertNotCalled(t, "Atualizar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Dados Inválidos", func(t *testing.T) {
+		mockService := new(MockVasoService)
+		mockService.On("Atualizar", uint(3), mock.Anything).
+			Return(nil, fmt.Errorf("%w: nome já cadastrado", utils.ErrInvalidInput)).Once()
+
+		w := requisitar(routerVasos(mockService), http.MethodPut, "/vasos/3", vasoValido)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "nome já cadastrado")
+	})
+}
+
+func TestVasoController_Deletar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockVasoService)
+		mockService.On("Deletar", uint(3)).Return(nil).Once()
+
+		w := requisitar(routerVasos(mockService), http.MethodDelete, "/vasos/3", "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Error - Vaso Inexistente", func(t *testing.T) {
+		mockService := new(MockVasoService)
+		mockService.On("Deletar", uint(3)).Return(utils.ErrNotFound).Once()
+
+		w := requisitar(routerVasos(mockService), http.MethodDelete, "/vasos/3", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,67 @@
+// Package calculo reúne as fórmulas agronômicas usadas para derivar métricas
+// de cultivo (VPD, ponto de orvalho, DLI) a partir das leituras dos sensores.
+package calculo
+
+import "math"
+
+// FatorLuxPPFDSol converte lux em PPFD (µmol/m²/s) para luz solar plena.
+// Para LEDs brancos o fator costuma ficar entre 0,014 e 0,02.
+const FatorLuxPPFDSol = 0.0185
+
+// OffsetFolhaPadrao é a diferença típica entre a temperatura da folha e a do ar (°C).
+const OffsetFolhaPadrao = -2.0
+
+// PressaoVaporSaturacao retorna a pressão de vapor de saturação em kPa para
+// uma temperatura em °C (equação de Tetens).
+func PressaoVaporSaturacao(temperatura float64) float64 {
+	return 0.61078 * math.Exp(17.27*temperatura/(temperatura+237.3))
+}
+
+// VPDAr retorna o déficit de pressão de vapor do ar em kPa.
+func VPDAr(temperatura, umidade float64) float64 {
+	svp := PressaoVaporSaturacao(temperatura)
+	return Arredondar(svp*(1-umidade/100), 3)
+}
+
+// VPDFolha retorna o déficit de pressão de vapor entre a folha e o ar em kPa.
+// offsetFolha é somado à temperatura do ar para estimar a temperatura da folha.
+func VPDFolha(temperatura, umidade, offsetFolha float64) float64 {
+	svpFolha := PressaoVaporSaturacao(temperatura + offsetFolha)
+	pressaoVaporAr := PressaoVaporSaturacao(temperatura) * umidade / 100
+	return Arredondar(math.Max(svpFolha-pressaoVaporAr, 0), 3)
+}
+
+// PontoOrvalho retorna a temperatura de ponto de orvalho em °C (aproximação de Magnus).
+// Umidades abaixo de 1% são tratadas como 1% para manter o resultado finito.
+func PontoOrvalho(temperatura, umidade float64) float64 {
+	umidade = math.Max(umidade, 1)
+	const a, b = 17.27, 237.3
+	gamma := math.Log(umidade/100) + a*temperatura/(b+temperatura)
+	return Arredondar(b*gamma/(a-gamma), 2)
+}
+
+// LuxParaPPFD converte uma leitura em lux para PPFD usando o fator informado.
+func LuxParaPPFD(lux, fator float64) float64 {
+	if fator <= 0 {
+		fator = FatorLuxPPFDSol
+	}
+	return lux * fator
+}
+
+// IntegralLuz retorna os mols de fótons por m² recebidos com um PPFD médio
+// constante durante o número de segundos informado.
+func IntegralLuz(ppfd, segundos float64) float64 {
+	return ppfd * segundos / 1e6
+}
+
+// DLI retorna a integral diária de luz (mol/m²/dia) para um PPFD médio
+// mantido durante as horas de luz do dia.
+func DLI(ppfd, horasLuz float64) float64 {
+	return Arredondar(IntegralLuz(ppfd, horasLuz*3600), 2)
+}
+
+// Arredondar arredonda o valor para o número de casas decimais informado.
+func Arredondar(valor float64, casas int) float64 {
+	p := math.Pow(10, float64(casas))
+	return math.Round(valor*p) / p
+}
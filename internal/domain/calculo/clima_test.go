@@ -0,0 +1,46 @@
+package calculo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVPD(t *testing.T) {
+	// 25 °C e 60% UR: SVP ≈ 3,17 kPa
+	assert.InDelta(t, 3.168, PressaoVaporSaturacao(25), 0.01)
+	assert.InDelta(t, 1.267, VPDAr(25, 60), 0.01)
+	// folha 2 °C mais fria reduz o VPD
+	assert.InDelta(t, 0.905, VPDFolha(25, 60, -2), 0.01)
+	assert.Equal(t, 0.0, VPDFolha(20, 100, -2))
+}
+
+func TestPontoOrvalho(t *testing.T) {
+	assert.InDelta(t, 16.7, PontoOrvalho(25, 60), 0.1)
+	assert.InDelta(t, 20.0, PontoOrvalho(20, 100), 0.01)
+	// umidade zerada é tratada como 1%
+	assert.Equal(t, -34.71, PontoOrvalho(25, 0))
+	assert.Equal(t, PontoOrvalho(25, 1), PontoOrvalho(25, 0))
+}
+
+func TestArredondar(t *testing.T) {
+	assert.Equal(t, 0.1545, Arredondar(170.0/1100, 4))
+	assert.Equal(t, 2.5, Arredondar(2.45, 1))
+	assert.Equal(t, -1.27, Arredondar(-1.2678, 2))
+}
+
+func TestDLI(t *testing.T) {
+	assert.Equal(t, 43.2, DLI(1000, 12))
+	assert.Equal(t, 38.88, DLI(600, 18))
+	assert.InDelta(t, 925.0, LuxParaPPFD(50000, FatorLuxPPFDSol), 0.001)
+	assert.InDelta(t, 750.0, LuxParaPPFD(50000, 0.015), 0.001)
+}
+
+func TestInterpolarIDW(t *testing.T) {
+	pontos := []PontoMedido{{X: 0, Y: 0, Valor: 400}, {X: 100, Y: 0, Valor: 800}}
+	assert.Equal(t, 400.0, InterpolarIDW(pontos, 0, 0))
+	assert.InDelta(t, 600.0, InterpolarIDW(pontos, 50, 0), 1e-9)
+	// mais perto do primeiro ponto, mais perto do valor dele
+	assert.Less(t, InterpolarIDW(pontos, 25, 0), 600.0)
+	assert.Equal(t, 0.0, InterpolarIDW(nil, 10, 10))
+}
@@ -0,0 +1,53 @@
+package calculo
+
+import "gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+
+// Faixa representa um intervalo alvo fechado [Min, Max].
+type Faixa struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Contem indica se o valor está dentro da faixa.
+func (f Faixa) Contem(valor float64) bool {
+	return valor >= f.Min && valor <= f.Max
+}
+
+// FaixasAlvo agrupa as faixas recomendadas de cada métrica para um estágio.
+type FaixasAlvo struct {
+	Temperatura Faixa `json:"temperatura"` // °C
+	Umidade     Faixa `json:"umidade"`     // %
+	VPDFolha    Faixa `json:"vpd_folha"`   // kPa
+	DLI         Faixa `json:"dli"`         // mol/m²/dia
+}
+
+// MargemOrvalhoMinima é a diferença mínima (°C) entre a temperatura da folha e
+// o ponto de orvalho abaixo da qual há risco de condensação.
+const MargemOrvalhoMinima = 2.0
+
+var faixasPorEstagio = map[entity.EstagioPlanta]FaixasAlvo{
+	entity.EstagioGerminacao: {
+		Temperatura: Faixa{22, 28}, Umidade: Faixa{70, 90}, VPDFolha: Faixa{0.4, 0.8}, DLI: Faixa{6, 15},
+	},
+	entity.EstagioPlantula: {
+		Temperatura: Faixa{20, 26}, Umidade: Faixa{65, 80}, VPDFolha: Faixa{0.4, 0.8}, DLI: Faixa{10, 20},
+	},
+	entity.EstagioVegetativo: {
+		Temperatura: Faixa{22, 28}, Umidade: Faixa{55, 70}, VPDFolha: Faixa{0.8, 1.2}, DLI: Faixa{20, 40},
+	},
+	entity.EstagioFloracao: {
+		Temperatura: Faixa{20, 26}, Umidade: Faixa{40, 55}, VPDFolha: Faixa{1.0, 1.5}, DLI: Faixa{30, 50},
+	},
+	entity.EstagioMaturacao: {
+		Temperatura: Faixa{18, 24}, Umidade: Faixa{35, 45}, VPDFolha: Faixa{1.2, 1.6}, DLI: Faixa{30, 50},
+	},
+}
+
+// FaixasParaEstagio retorna as faixas alvo do estágio. Estágios desconhecidos
+// usam as faixas do vegetativo.
+func FaixasParaEstagio(estagio entity.EstagioPlanta) FaixasAlvo {
+	if faixas, ok := faixasPorEstagio[estagio]; ok {
+		return faixas
+	}
+	return faixasPorEstagio[entity.EstagioVegetativo]
+}
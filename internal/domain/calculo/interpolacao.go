@@ -0,0 +1,31 @@
+package calculo
+
+import "math"
+
+// PontoMedido é um valor medido em uma posição do plano (ex.: PPFD em x, y cm).
+type PontoMedido struct {
+	X, Y  float64
+	Valor float64
+}
+
+// potenciaIDW é o expoente da distância: 2 é o padrão usual em mapas de luz e clima
+const potenciaIDW = 2
+
+// InterpolarIDW estima o valor em (x, y) pela média dos pontos ponderada pelo inverso do
+// quadrado da distância. Em cima de um ponto medido, retorna o próprio valor.
+func InterpolarIDW(pontos []PontoMedido, x, y float64) float64 {
+	var soma, pesos float64
+	for _, p := range pontos {
+		d := math.Hypot(p.X-x, p.Y-y)
+		if d < 1e-9 {
+			return p.Valor
+		}
+		peso := 1 / math.Pow(d, potenciaIDW)
+		soma += peso * p.Valor
+		pesos += peso
+	}
+	if pesos == 0 {
+		return 0
+	}
+	return soma / pesos
+}
@@ -0,0 +1,51 @@
+package calculo
+
+// EscalaPPM500 e EscalaPPM700 são os fatores usuais de conversão de EC (mS/cm) em PPM:
+// NaCl (Hanna, medidores americanos) e 442/KCl (Truncheon, medidores europeus).
+const (
+	EscalaPPM500 = 500
+	EscalaPPM700 = 700
+)
+
+// ECParaPPM converte EC em mS/cm para PPM na escala informada (padrão 500).
+func ECParaPPM(ec float64, escala int) float64 {
+	if escala <= 0 {
+		escala = EscalaPPM500
+	}
+	return ec * float64(escala)
+}
+
+// PPMParaEC converte PPM na escala informada (padrão 500) para EC em mS/cm.
+func PPMParaEC(ppm float64, escala int) float64 {
+	if escala <= 0 {
+		escala = EscalaPPM500
+	}
+	return ppm / float64(escala)
+}
+
+// FatorDoseEC retorna quanto as doses da tabela devem ser multiplicadas para a solução
+// chegar à EC alvo. ecTabela é a EC que as doses da tabela somam à água; a EC da água base
+// já está presente e só a diferença vem dos nutrientes. Retorna 0 quando a água base já
+// atinge o alvo ou a tabela não informa EC.
+func FatorDoseEC(ecAlvo, ecAguaBase, ecTabela float64) float64 {
+	if ecTabela <= 0 || ecAlvo <= ecAguaBase {
+		return 0
+	}
+	return (ecAlvo - ecAguaBase) / ecTabela
+}
+
+// QuantidadeProduto retorna a quantidade de produto (ml ou g) para a dose por litro no volume
+// informado de solução.
+func QuantidadeProduto(dosePorLitro, volumeLitros float64) float64 {
+	return dosePorLitro * volumeLitros
+}
+
+// VolumeSolucaoEstoque retorna quantos ml de uma solução estoque contêm a quantidade de produto
+// informada, sendo concentracao a quantidade de produto (ml ou g) em cada litro do estoque.
+// Uma concentração não positiva retorna 0.
+func VolumeSolucaoEstoque(quantidadeProduto, concentracao float64) float64 {
+	if concentracao <= 0 {
+		return 0
+	}
+	return quantidadeProduto / concentracao * 1000
+}
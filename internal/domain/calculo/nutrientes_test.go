@@ -0,0 +1,30 @@
+package calculo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversaoECPPM(t *testing.T) {
+	assert.Equal(t, 700.0, ECParaPPM(1.4, 0))
+	assert.InDelta(t, 980.0, ECParaPPM(1.4, EscalaPPM700), 1e-9)
+	assert.Equal(t, 1.2, PPMParaEC(600, EscalaPPM500))
+	assert.Equal(t, 1.0, PPMParaEC(700, EscalaPPM700))
+}
+
+func TestFatorDoseEC(t *testing.T) {
+	// água base com 0,4 mS/cm: faltam 1,2 mS/cm para o alvo e a tabela soma 1,6
+	assert.InDelta(t, 0.75, FatorDoseEC(1.6, 0.4, 1.6), 1e-9)
+	assert.InDelta(t, 1.25, FatorDoseEC(2.0, 0, 1.6), 1e-9)
+	assert.Equal(t, 0.0, FatorDoseEC(0.3, 0.4, 1.6))
+	assert.Equal(t, 0.0, FatorDoseEC(1.6, 0, 0))
+}
+
+func TestVolumeSolucaoEstoque(t *testing.T) {
+	// 2 ml/L em 20 L = 40 ml de produto; estoque com 250 ml de produto por litro = 160 ml
+	quantidade := QuantidadeProduto(2, 20)
+	assert.Equal(t, 40.0, quantidade)
+	assert.Equal(t, 160.0, VolumeSolucaoEstoque(quantidade, 250))
+	assert.Equal(t, 0.0, VolumeSolucaoEstoque(quantidade, 0))
+}
@@ -0,0 +1,66 @@
+package calculo
+
+import (
+	"math"
+	"time"
+)
+
+// alturaNascerSol é a altura do centro do Sol no nascer e no pôr do sol, em graus:
+// refração atmosférica (34') mais o raio aparente do disco (16').
+const alturaNascerSol = -0.833
+
+// DiaSolar descreve o nascer e o pôr do sol em uma data e coordenada.
+// Nos dias e noites polares não há nascer nem pôr do sol.
+type DiaSolar struct {
+	Nascer     *time.Time
+	PorDoSol   *time.Time
+	Duracao    time.Duration
+	DiaPolar   bool
+	NoitePolar bool
+}
+
+// Sol calcula o nascer e o pôr do sol na data de calendário de data (ano, mês e dia no fuso de
+// data) pela equação do nascer do sol (precisão de cerca de um minuto fora das regiões polares).
+// latitude e longitude em graus decimais, positivas ao norte e a leste; os horários retornados
+// ficam no fuso de data.
+func Sol(data time.Time, latitude, longitude float64) DiaSolar {
+	ano, mes, dia := data.Date()
+	// dias julianos desde J2000.0 ao meio-dia UTC da data
+	n := math.Round(float64(time.Date(ano, mes, dia, 12, 0, 0, 0, time.UTC).Unix())/86400 + 2440587.5 - 2451545.0)
+	meridiano := n - longitude/360
+
+	anomalia := math.Mod(357.5291+0.98560028*meridiano, 360)
+	m := radianos(anomalia)
+	centro := 1.9148*math.Sin(m) + 0.0200*math.Sin(2*m) + 0.0003*math.Sin(3*m)
+	longitudeEcliptica := radianos(math.Mod(anomalia+centro+180+102.9372, 360))
+	transito := 2451545.0 + meridiano + 0.0053*math.Sin(m) - 0.0069*math.Sin(2*longitudeEcliptica)
+
+	declinacao := math.Asin(math.Sin(longitudeEcliptica) * math.Sin(radianos(23.4397)))
+	phi := radianos(latitude)
+	cosAngulo := (math.Sin(radianos(alturaNascerSol)) - math.Sin(phi)*math.Sin(declinacao)) /
+		(math.Cos(phi) * math.Cos(declinacao))
+	switch {
+	case cosAngulo > 1:
+		return DiaSolar{NoitePolar: true}
+	case cosAngulo < -1:
+		return DiaSolar{DiaPolar: true, Duracao: 24 * time.Hour}
+	}
+
+	angulo := math.Acos(cosAngulo) * 180 / math.Pi
+	nascer := dataJuliana(transito - angulo/360).In(data.Location())
+	porDoSol := dataJuliana(transito + angulo/360).In(data.Location())
+	return DiaSolar{
+		Nascer:   &nascer,
+		PorDoSol: &porDoSol,
+		Duracao:  porDoSol.Sub(nascer),
+	}
+}
+
+func radianos(graus float64) float64 {
+	return graus * math.Pi / 180
+}
+
+// dataJuliana converte uma data juliana em instante UTC, com precisão de segundos
+func dataJuliana(jd float64) time.Time {
+	return time.Unix(int64(math.Round((jd-2440587.5)*86400)), 0).UTC()
+}
@@ -0,0 +1,43 @@
+package calculo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSol(t *testing.T) {
+	t.Run("São Paulo no Solstício de Inverno", func(t *testing.T) {
+		local, err := time.LoadLocation("America/Sao_Paulo")
+		require.NoError(t, err)
+
+		dia := Sol(time.Date(2026, 6, 21, 0, 0, 0, 0, local), -23.55, -46.63)
+
+		require.NotNil(t, dia.Nascer)
+		require.NotNil(t, dia.PorDoSol)
+		assert.WithinDuration(t, time.Date(2026, 6, 21, 6, 47, 0, 0, local), *dia.Nascer, 3*time.Minute)
+		assert.WithinDuration(t, time.Date(2026, 6, 21, 17, 29, 0, 0, local), *dia.PorDoSol, 3*time.Minute)
+		assert.InDelta(t, 10.7, dia.Duracao.Hours(), 0.1)
+		assert.Equal(t, local, dia.Nascer.Location())
+	})
+
+	t.Run("Equador no Equinócio", func(t *testing.T) {
+		dia := Sol(time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), 0, 0)
+
+		require.NotNil(t, dia.Nascer)
+		assert.InDelta(t, 12.1, dia.Duracao.Hours(), 0.1)
+	})
+
+	t.Run("Dia e Noite Polares", func(t *testing.T) {
+		verao := Sol(time.Date(2026, 6, 21, 0, 0, 0, 0, time.UTC), 69.65, 18.96)
+		inverno := Sol(time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC), 69.65, 18.96)
+
+		assert.True(t, verao.DiaPolar)
+		assert.Nil(t, verao.Nascer)
+		assert.Equal(t, 24*time.Hour, verao.Duracao)
+		assert.True(t, inverno.NoitePolar)
+		assert.Zero(t, inverno.Duracao)
+	})
+}
@@ -0,0 +1,37 @@
+package calculo
+
+import "time"
+
+// PontoSerie é uma medição de uma série temporal.
+type PontoSerie struct {
+	Instante time.Time
+	Valor    float64
+}
+
+// InclinacaoPorDia ajusta uma reta aos pontos por mínimos quadrados e retorna quanto o
+// valor varia por dia. Retorna false com menos de dois pontos ou com todos no mesmo instante.
+func InclinacaoPorDia(pontos []PontoSerie) (float64, bool) {
+	if len(pontos) < 2 {
+		return 0, false
+	}
+	// dias contados a partir do primeiro ponto, para não perder precisão com o Unix time
+	origem := pontos[0].Instante
+	var somaX, somaY float64
+	for _, p := range pontos {
+		somaX += p.Instante.Sub(origem).Hours() / 24
+		somaY += p.Valor
+	}
+	n := float64(len(pontos))
+	mediaX, mediaY := somaX/n, somaY/n
+
+	var cov, varX float64
+	for _, p := range pontos {
+		dx := p.Instante.Sub(origem).Hours()/24 - mediaX
+		cov += dx * (p.Valor - mediaY)
+		varX += dx * dx
+	}
+	if varX == 0 {
+		return 0, false
+	}
+	return cov / varX, true
+}
@@ -0,0 +1,27 @@
+package calculo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInclinacaoPorDia(t *testing.T) {
+	inicio := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	// EC subindo 0,1 mS/cm por dia, com leituras a cada 12 horas
+	pontos := []PontoSerie{
+		{Instante: inicio, Valor: 1.2},
+		{Instante: inicio.Add(12 * time.Hour), Valor: 1.25},
+		{Instante: inicio.Add(24 * time.Hour), Valor: 1.3},
+		{Instante: inicio.Add(72 * time.Hour), Valor: 1.5},
+	}
+	inclinacao, ok := InclinacaoPorDia(pontos)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.1, inclinacao, 1e-9)
+
+	_, ok = InclinacaoPorDia(pontos[:1])
+	assert.False(t, ok)
+	_, ok = InclinacaoPorDia([]PontoSerie{{Instante: inicio, Valor: 1}, {Instante: inicio, Valor: 2}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,204 @@
+package desenho
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+)
+
+const (
+	// larguraMapaPx é a largura aproximada da área do mapa nas imagens
+	larguraMapaPx = 600
+	// larguraLegendaPx é a barra de cores à direita do mapa no PNG
+	larguraLegendaPx = 20
+)
+
+// paradasCor é a escala do mapa de calor, do menor (azul) ao maior valor (vermelho)
+var paradasCor = []color.RGBA{
+	{R: 49, G: 54, B: 149, A: 255},
+	{R: 69, G: 117, B: 180, A: 255},
+	{R: 116, G: 173, B: 209, A: 255},
+	{R: 171, G: 217, B: 233, A: 255},
+	{R: 254, G: 224, B: 144, A: 255},
+	{R: 253, G: 174, B: 97, A: 255},
+	{R: 244, G: 109, B: 67, A: 255},
+	{R: 215, G: 48, B: 39, A: 255},
+}
+
+// MapaCalor é uma grade de valores interpolados sobre a planta baixa do ambiente. Valores[i][j]
+// é a célula da linha i (eixo Y) e coluna j (eixo X), cada uma com ResolucaoCm de lado.
+type MapaCalor struct {
+	Titulo      string
+	Comprimento float64
+	Largura     float64
+	ResolucaoCm float64
+	Min, Max    float64 // extremos da escala de cores
+	Valores     [][]float64
+	Pontos      []calculo.PontoMedido
+}
+
+// NovoMapaCalor interpola os pontos medidos (IDW) no centro de cada célula da grade. A escala
+// de cores vai do menor ao maior valor medido.
+func NovoMapaCalor(comprimento, largura, resolucaoCm float64, pontos []calculo.PontoMedido) MapaCalor {
+	mapa := MapaCalor{Comprimento: comprimento, Largura: largura, ResolucaoCm: resolucaoCm, Pontos: pontos}
+	colunas := int(math.Ceil(comprimento / resolucaoCm))
+	linhas := int(math.Ceil(largura / resolucaoCm))
+	mapa.Valores = make([][]float64, linhas)
+	for i := range mapa.Valores {
+		mapa.Valores[i] = make([]float64, colunas)
+		y := math.Min((float64(i)+0.5)*resolucaoCm, largura)
+		for j := range mapa.Valores[i] {
+			x := math.Min((float64(j)+0.5)*resolucaoCm, comprimento)
+			mapa.Valores[i][j] = calculo.InterpolarIDW(pontos, x, y)
+		}
+	}
+
+	mapa.Min, mapa.Max = math.Inf(1), math.Inf(-1)
+	for _, p := range pontos {
+		mapa.Min = math.Min(mapa.Min, p.Valor)
+		mapa.Max = math.Max(mapa.Max, p.Valor)
+	}
+	if len(pontos) == 0 {
+		mapa.Min, mapa.Max = 0, 0
+	}
+	return mapa
+}
+
+// PNG desenha o mapa com os pontos medidos em preto e a legenda de cores à direita
+// (valor máximo em cima).
+func (m MapaCalor) PNG() ([]byte, error) {
+	linhas, colunas := m.dimensoes()
+	if linhas == 0 || colunas == 0 {
+		return nil, fmt.Errorf("mapa de calor vazio")
+	}
+	celula := int(math.Max(1, math.Round(larguraMapaPx/float64(colunas))))
+	largura, altura := colunas*celula, linhas*celula
+	img := image.NewRGBA(image.Rect(0, 0, largura+larguraLegendaPx*2, altura))
+
+	for i, linha := range m.Valores {
+		for j, valor := range linha {
+			cor := m.cor(valor)
+			for py := i * celula; py < (i+1)*celula; py++ {
+				for px := j * celula; px < (j+1)*celula; px++ {
+					img.SetRGBA(px, py, cor)
+				}
+			}
+		}
+	}
+	for py := 0; py < altura; py++ {
+		cor := corNaEscala(1 - float64(py)/float64(max(altura-1, 1)))
+		for px := largura + larguraLegendaPx; px < largura+larguraLegendaPx*2; px++ {
+			img.SetRGBA(px, py, cor)
+		}
+	}
+
+	escala := float64(celula) / m.ResolucaoCm
+	raio := max(celula/4, 2)
+	preto := color.RGBA{A: 255}
+	for _, p := range m.Pontos {
+		cx, cy := int(p.X*escala), int(p.Y*escala)
+		for dy := -raio; dy <= raio; dy++ {
+			for dx := -raio; dx <= raio; dx++ {
+				if dx*dx+dy*dy <= raio*raio && cx+dx < largura {
+					img.SetRGBA(cx+dx, cy+dy, preto)
+				}
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, fmt.Errorf("falha ao gerar PNG do mapa de calor: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// SVG desenha o mapa com uma célula por retângulo, os pontos medidos com o valor e a
+// legenda da escala. O viewBox é em centímetros, como na planta baixa.
+func (m MapaCalor) SVG() []byte {
+	margem := math.Max(m.Comprimento, m.Largura) * 0.05
+	fonte := math.Max(math.Min(m.Comprimento, m.Largura)/30, 1)
+	rodape := fonte * 3
+	alturaPx := float64(larguraMapaPx) * (m.Largura + 2*margem + fonte*2 + rodape) / (m.Comprimento + 2*margem)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%.0f" viewBox="%s %s %s %s">`+"\n",
+		larguraMapaPx, alturaPx, num(-margem), num(-margem-fonte*2), num(m.Comprimento+2*margem), num(m.Largura+2*margem+fonte*2+rodape))
+	if m.Titulo != "" {
+		fmt.Fprintf(&b, `<text x="0" y="%s" font-family="sans-serif" font-size="%s">%s</text>`+"\n",
+			num(-fonte), num(fonte*1.2), escapar(m.Titulo))
+	}
+
+	b.WriteString(`<g shape-rendering="crispEdges">` + "\n")
+	for i, linha := range m.Valores {
+		for j, valor := range linha {
+			x, y := float64(j)*m.ResolucaoCm, float64(i)*m.ResolucaoCm
+			fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+				num(x), num(y), num(math.Min(m.ResolucaoCm, m.Comprimento-x)), num(math.Min(m.ResolucaoCm, m.Largura-y)), hex(m.cor(valor)))
+		}
+	}
+	b.WriteString("</g>\n")
+	fmt.Fprintf(&b, `<rect x="0" y="0" width="%s" height="%s" fill="none" stroke="#333" stroke-width="%s"/>`+"\n",
+		num(m.Comprimento), num(m.Largura), num(fonte/4))
+
+	for _, p := range m.Pontos {
+		fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="%s" fill="#000"/>`+"\n", num(p.X), num(p.Y), num(fonte/4))
+		fmt.Fprintf(&b, `<text x="%s" y="%s" font-family="sans-serif" font-size="%s" text-anchor="middle">%s</text>`+"\n",
+			num(p.X), num(p.Y-fonte/2), num(fonte*0.8), num(math.Round(p.Valor)))
+	}
+
+	// legenda: gradiente horizontal com os extremos da escala
+	yLegenda := m.Largura + fonte
+	b.WriteString(`<defs><linearGradient id="escala">`)
+	for i, cor := range paradasCor {
+		fmt.Fprintf(&b, `<stop offset="%s" stop-color="%s"/>`, num(float64(i)/float64(len(paradasCor)-1)), hex(cor))
+	}
+	b.WriteString("</linearGradient></defs>\n")
+	fmt.Fprintf(&b, `<rect x="0" y="%s" width="%s" height="%s" fill="url(#escala)"/>`+"\n",
+		num(yLegenda), num(m.Comprimento/2), num(fonte))
+	fmt.Fprintf(&b, `<text x="0" y="%s" font-family="sans-serif" font-size="%s">%s</text>`+"\n",
+		num(yLegenda+fonte*2), num(fonte*0.8), num(math.Round(m.Min)))
+	fmt.Fprintf(&b, `<text x="%s" y="%s" font-family="sans-serif" font-size="%s" text-anchor="end">%s</text>`+"\n",
+		num(m.Comprimento/2), num(yLegenda+fonte*2), num(fonte*0.8), num(math.Round(m.Max)))
+
+	b.WriteString("</svg>\n")
+	return b.Bytes()
+}
+
+func (m MapaCalor) dimensoes() (int, int) {
+	if len(m.Valores) == 0 {
+		return 0, 0
+	}
+	return len(m.Valores), len(m.Valores[0])
+}
+
+// cor posiciona o valor na escala [Min, Max]; fora dela usa a cor do extremo
+func (m MapaCalor) cor(valor float64) color.RGBA {
+	if m.Max <= m.Min {
+		return corNaEscala(0.5)
+	}
+	return corNaEscala((valor - m.Min) / (m.Max - m.Min))
+}
+
+// corNaEscala interpola linearmente entre as paradas de cor; t vai de 0 a 1
+func corNaEscala(t float64) color.RGBA {
+	t = math.Max(0, math.Min(1, t))
+	pos := t * float64(len(paradasCor)-1)
+	i := int(math.Floor(pos))
+	if i >= len(paradasCor)-1 {
+		return paradasCor[len(paradasCor)-1]
+	}
+	f := pos - float64(i)
+	a, b := paradasCor[i], paradasCor[i+1]
+	mistura := func(x, y uint8) uint8 { return uint8(math.Round(float64(x) + (float64(y)-float64(x))*f)) }
+	return color.RGBA{R: mistura(a.R, b.R), G: mistura(a.G, b.G), B: mistura(a.B, b.B), A: 255}
+}
+
+func hex(cor color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", cor.R, cor.G, cor.B)
+}
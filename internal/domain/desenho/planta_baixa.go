@@ -0,0 +1,96 @@
+// Package desenho gera imagens simples (SVG e PNG) a partir dos dados dos ambientes.
+package desenho
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+)
+
+// larguraMaximaPx é a largura da imagem; a altura acompanha a proporção do ambiente
+const larguraMaximaPx = 800.0
+
+// ItemPlantaBaixa é um item desenhado na planta baixa, com medidas em centímetros.
+type ItemPlantaBaixa struct {
+	Rotulo       string
+	X, Y         float64 // centro
+	Circular     bool
+	Largura      float64 // diâmetro nos circulares
+	Profundidade float64
+	Planta       bool // itens com planta são preenchidos em verde
+}
+
+// PlantaBaixa descreve o ambiente visto de cima: X acompanha o comprimento e Y a largura,
+// com a origem no canto superior esquerdo.
+type PlantaBaixa struct {
+	Titulo      string
+	Comprimento float64
+	Largura     float64
+	GradeCm     float64 // espaçamento das linhas de grade; 0 desenha sem grade
+	Itens       []ItemPlantaBaixa
+}
+
+// SVG desenha a planta baixa. O viewBox é em centímetros, então as coordenadas do SVG
+// coincidem com as do layout.
+func (p PlantaBaixa) SVG() []byte {
+	margem := math.Max(p.Comprimento, p.Largura) * 0.05
+	fonte := math.Max(math.Min(p.Comprimento, p.Largura)/30, 1)
+	traco := fonte / 8
+	larguraPx := larguraMaximaPx
+	alturaPx := larguraMaximaPx * (p.Largura + 2*margem + fonte*2) / (p.Comprimento + 2*margem)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="%s %s %s %s">`,
+		larguraPx, alturaPx, num(-margem), num(-margem-fonte*2), num(p.Comprimento+2*margem), num(p.Largura+2*margem+fonte*2))
+	b.WriteString("\n")
+	if p.Titulo != "" {
+		fmt.Fprintf(&b, `<text x="0" y="%s" font-family="sans-serif" font-size="%s">%s</text>`+"\n",
+			num(-fonte), num(fonte*1.2), escapar(p.Titulo))
+	}
+	fmt.Fprintf(&b, `<rect x="0" y="0" width="%s" height="%s" fill="#fafafa" stroke="#333" stroke-width="%s"/>`+"\n",
+		num(p.Comprimento), num(p.Largura), num(traco*2))
+
+	if p.GradeCm > 0 {
+		b.WriteString(`<g stroke="#ddd" stroke-width="` + num(traco) + `">` + "\n")
+		for x := p.GradeCm; x < p.Comprimento; x += p.GradeCm {
+			fmt.Fprintf(&b, `<line x1="%s" y1="0" x2="%s" y2="%s"/>`+"\n", num(x), num(x), num(p.Largura))
+		}
+		for y := p.GradeCm; y < p.Largura; y += p.GradeCm {
+			fmt.Fprintf(&b, `<line x1="0" y1="%s" x2="%s" y2="%s"/>`+"\n", num(y), num(p.Comprimento), num(y))
+		}
+		b.WriteString("</g>\n")
+	}
+
+	for _, item := range p.Itens {
+		preenchimento := "#d7ccc8"
+		if item.Planta {
+			preenchimento = "#a5d6a7"
+		}
+		if item.Circular {
+			fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="%s" fill="%s" stroke="#2e7d32" stroke-width="%s"/>`+"\n",
+				num(item.X), num(item.Y), num(item.Largura/2), preenchimento, num(traco))
+		} else {
+			fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s" stroke="#2e7d32" stroke-width="%s"/>`+"\n",
+				num(item.X-item.Largura/2), num(item.Y-item.Profundidade/2), num(item.Largura), num(item.Profundidade), preenchimento, num(traco))
+		}
+		if item.Rotulo != "" {
+			fmt.Fprintf(&b, `<text x="%s" y="%s" font-family="sans-serif" font-size="%s" text-anchor="middle" dominant-baseline="middle">%s</text>`+"\n",
+				num(item.X), num(item.Y), num(fonte), escapar(item.Rotulo))
+		}
+	}
+
+	b.WriteString("</svg>\n")
+	return b.Bytes()
+}
+
+// num formata uma coordenada com até duas casas, sem zeros à direita
+func num(valor float64) string {
+	return fmt.Sprintf("%g", math.Round(valor*100)/100)
+}
+
+func escapar(texto string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(texto))
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package dto
+
+// RegraAlertaDTO representa a criação ou atualização de uma regra de alerta de ambiente
+type RegraAlertaDTO struct {
+	Nome           string   `json:"nome" binding:"required,max=100"`
+	Metrica        string   `json:"metrica" binding:"required,oneof=temperatura umidade luminosidade co2 umidade_solo ppfd vpd_ar vpd_folha"`
+	Comparacao     string   `json:"comparacao" binding:"required,oneof=maior maior_igual menor menor_igual"`
+	Limite         *float64 `json:"limite" binding:"required"`
+	DuracaoMinutos int      `json:"duracao_minutos" binding:"gte=0,lte=1440"`                             // tempo mínimo com a condição satisfeita
+	HoraInicio     string   `json:"hora_inicio" binding:"required_with=HoraFim,omitempty,datetime=15:04"` // janela ativa (horário local)
+	HoraFim        string   `json:"hora_fim" binding:"required_with=HoraInicio,omitempty,datetime=15:04"` // pode virar a meia-noite
+	Estagios       []string `json:"estagios" binding:"omitempty,dive,oneof=germinacao plantula vegetativo floracao maturacao"`
+	Canais         []string `json:"canais" binding:"omitempty,dive,oneof=lembrete webhook email"` // padrão: lembrete
+	WebhookURL     string   `json:"webhook_url" binding:"omitempty,url,max=500"`
+	Email          string   `json:"email" binding:"omitempty,email,max=100"`
+	Ativa          *bool    `json:"ativa"` // padrão: true
+}
+
+// ConsultaAlertasDTO filtra a listagem de alertas do usuário
+type ConsultaAlertasDTO struct {
+	PaginationParams
+	AmbienteID uint   `form:"ambiente_id"`
+	Status     string `form:"status" binding:"omitempty,oneof=aberto reconhecido resolvido"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+
+// TokenCalendarioDTO é o token secreto do feed iCalendar, exibido uma única vez
+type TokenCalendarioDTO struct {
+	Token string `json:"token"`
+	URL   string `json:"url"` // endereço para assinar no aplicativo de calendário
+}
+
+// ImportacaoCalendarioDTO resume a importação de um arquivo .ics
+type ImportacaoCalendarioDTO struct {
+	Criadas   []entity.Tarefa `json:"criadas"`
+	Ignorados int             `json:"ignorados"`
+	Avisos    []string        `json:"avisos,omitempty"` // motivo de cada evento ignorado ou importado parcialmente
+}
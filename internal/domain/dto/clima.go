@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// LocalizacaoDTO define as coordenadas de um ambiente externo, em graus decimais
+type LocalizacaoDTO struct {
+	Latitude  *float64 `json:"latitude" binding:"required,gte=-90,lte=90"`
+	Longitude *float64 `json:"longitude" binding:"required,gte=-180,lte=180"`
+}
+
+// ConsultaClimaDTO define o período das consultas de sol e clima
+type ConsultaClimaDTO struct {
+	Inicio string `form:"inicio" binding:"omitempty,datetime=2006-01-02"`
+	Fim    string `form:"fim" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// SolDiaDTO descreve o nascer e o pôr do sol no local do ambiente em um dia
+type SolDiaDTO struct {
+	Data         string     `json:"data"` // AAAA-MM-DD no fuso local
+	Nascer       *time.Time `json:"nascer,omitempty"`
+	PorDoSol     *time.Time `json:"por_do_sol,omitempty"`
+	DuracaoHoras float64    `json:"duracao_horas"`
+	DiaPolar     bool       `json:"dia_polar,omitempty"`
+	NoitePolar   bool       `json:"noite_polar,omitempty"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// ComandoAtuadorDTO representa um comando publicado para um atuador
+type ComandoAtuadorDTO struct {
+	Topico  string `json:"topico" binding:"required,max=200"` // ex.: cmnd/exaustor/POWER
+	Payload string `json:"payload" binding:"max=4096"`        // ex.: ON
+	Retain  bool   `json:"retain"`                            // mantém o último comando no broker
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// ItemCronogramaDTO é uma tarefa do cronograma, agendada a partir do início do estágio
+type ItemCronogramaDTO struct {
+	Nome           string               `json:"nome" binding:"required,max=100"`
+	Descricao      string               `json:"descricao"`
+	Tipo           string               `json:"tipo" binding:"required,max=50"` // regar, adubar, podar, desfolhar...
+	Instrucoes     string               `json:"instrucoes"`
+	Estagio        entity.EstagioPlanta `json:"estagio" binding:"required"`
+	DiaInicio      int                  `json:"dia_inicio" binding:"gte=0,lte=365"`                    // 0 = primeiro dia do estágio
+	DiaFim         *int                 `json:"dia_fim" binding:"omitempty,gte=0,lte=365"`             // sem dia_fim, repete enquanto durar o estágio
+	FrequenciaDias int                  `json:"frequencia_dias" binding:"gte=0,lte=365"`               // 0 = tarefa única
+	Prioridade     string               `json:"prioridade" binding:"omitempty,oneof=baixa media alta"` // padrão: media
+}
+
+// CronogramaCultivoDTO representa a criação ou atualização de um cronograma; os itens
+// substituem os anteriores na ordem informada
+type CronogramaCultivoDTO struct {
+	Nome      string              `json:"nome" binding:"required,max=100"`
+	Descricao string              `json:"descricao"`
+	Itens     []ItemCronogramaDTO `json:"itens" binding:"required,min=1,max=200,dive"`
+}
+
+// AplicarCronogramaDTO escolhe as plantas que recebem o cronograma: uma, um lote ou as do diário
+type AplicarCronogramaDTO struct {
+	PlantaIDs       []uint `json:"planta_ids" binding:"omitempty,max=500,dive,gt=0"`
+	DiarioCultivoID *uint  `json:"diario_cultivo_id" binding:"omitempty,gt=0"`
+}
+
+// PlanejamentoCronogramaDTO resume o que o (re)planejamento de uma aplicação mudou nas tarefas
+type PlanejamentoCronogramaDTO struct {
+	Aplicacao        entity.AplicacaoCronograma `json:"aplicacao"`
+	Estagio          entity.EstagioPlanta       `json:"estagio,omitempty"` // vazio se a planta ainda não tem estágio
+	TarefasCriadas   []entity.Tarefa            `json:"tarefas_criadas"`
+	TarefasRemovidas int                        `json:"tarefas_removidas"`
+}
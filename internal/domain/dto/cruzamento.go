@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// CruzamentoDTO registra um cruzamento e cria a genética resultante no catálogo. Nas gerações
+// S (autocruzamento), o pai é a própria mãe. Os campos seguem o snake_case do cruzamento
+// retornado, não o camelCase do cadastro de genéticas.
+type CruzamentoDTO struct {
+	Nome          string `json:"nome" binding:"required,min=2,max=100"` // nome da genética resultante
+	MaeGeneticaID uint   `json:"mae_genetica_id" binding:"required,gt=0"`
+	PaiGeneticaID uint   `json:"pai_genetica_id" binding:"omitempty,gt=0"`
+	Geracao       string `json:"geracao" binding:"required,max=10"`                                       // F1, F2..., BX1..., S1...
+	TipoGenetica  string `json:"tipo_genetica" binding:"omitempty,oneof=sativa indica ruderalis hibrido"` // padrão: o dos pais, ou hibrido
+	TempoFloracao int    `json:"tempo_floracao" binding:"omitempty,gt=0"`                                 // padrão: média dos pais
+	Origem        string `json:"origem" binding:"max=100"`                                                // padrão: "Cruzamento próprio"
+	Objetivo      string `json:"objetivo"`
+}
+
+// PolinizacaoDTO registra o pólen aplicado em uma planta mãe do cruzamento
+type PolinizacaoDTO struct {
+	PlantaMaeID     uint       `json:"planta_mae_id" binding:"required,gt=0"`
+	PlantaDoadoraID *uint      `json:"planta_doadora_id" binding:"omitempty,gt=0"`
+	Data            *time.Time `json:"data"` // padrão: agora
+	Metodo          string     `json:"metodo" binding:"omitempty,oneof=pincel contato polen_armazenado aberta outro"`
+	Observacoes     string     `json:"observacoes"`
+}
+
+// LoteSementesDTO registra as sementes colhidas do cruzamento como um pacote do banco de sementes
+type LoteSementesDTO struct {
+	PolinizacaoID      *uint      `json:"polinizacao_id" binding:"omitempty,gt=0"`
+	Quantidade         int        `json:"quantidade" binding:"required,gt=0"`
+	DataColheita       *time.Time `json:"data_colheita"` // padrão: agora
+	LocalArmazenamento string     `json:"local_armazenamento" binding:"max=100"`
+	NotasViabilidade   string     `json:"notas_viabilidade"`
+}
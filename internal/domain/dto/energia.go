@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// EquipamentoDTO representa a criação ou atualização de um equipamento do ambiente
+type EquipamentoDTO struct {
+	Nome            string     `json:"nome" binding:"required,max=100"`
+	Tipo            string     `json:"tipo" binding:"required,oneof=iluminacao ventilacao exaustao desumidificador umidificador aquecedor ar_condicionado bomba outro"`
+	PotenciaWatts   float64    `json:"potencia_watts" binding:"required,gt=0,lte=100000"`
+	Quantidade      int        `json:"quantidade" binding:"gte=0,lte=1000"` // padrão: 1
+	Modo            string     `json:"modo" binding:"required,oneof=continuo fotoperiodo horas_dia ciclo"`
+	HorasDia        *float64   `json:"horas_dia" binding:"required_if=Modo horas_dia,omitempty,gte=0,lte=24"`
+	CicloPercentual *float64   `json:"ciclo_percentual" binding:"required_if=Modo ciclo,omitempty,gte=0,lte=100"`
+	InstaladoEm     *time.Time `json:"instalado_em"`
+	RemovidoEm      *time.Time `json:"removido_em"`
+}
+
+// TarifaEnergiaDTO representa a criação de uma tarifa de energia do usuário
+type TarifaEnergiaDTO struct {
+	Nome               string     `json:"nome" binding:"required,max=100"`
+	ValorKWh           float64    `json:"valor_kwh" binding:"required,gt=0,lte=100"`      // R$/kWh com tributos
+	AdicionalAmarela   *float64   `json:"adicional_amarela" binding:"omitempty,gte=0"`    // padrão: valor vigente da ANEEL
+	AdicionalVermelha1 *float64   `json:"adicional_vermelha_1" binding:"omitempty,gte=0"` // padrão: valor vigente da ANEEL
+	AdicionalVermelha2 *float64   `json:"adicional_vermelha_2" binding:"omitempty,gte=0"` // padrão: valor vigente da ANEEL
+	VigenteDesde       *time.Time `json:"vigente_desde"`                                  // padrão: agora
+}
+
+// BandeiraTarifariaDTO define a bandeira de um mês
+type BandeiraTarifariaDTO struct {
+	Bandeira string `json:"bandeira" binding:"required,oneof=verde amarela vermelha_1 vermelha_2"`
+}
+
+// ConsultaEnergiaDTO define o período do consumo de energia (padrão: últimos 30 dias)
+type ConsultaEnergiaDTO struct {
+	Inicio string `form:"inicio" binding:"omitempty,datetime=2006-01-02"`
+	Fim    string `form:"fim" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// ConsumoEquipamentoDTO resume o consumo de um equipamento no período
+type ConsumoEquipamentoDTO struct {
+	EquipamentoID uint                   `json:"equipamento_id"`
+	Nome          string                 `json:"nome"`
+	Tipo          entity.TipoEquipamento `json:"tipo"`
+	HorasLigado   float64                `json:"horas_ligado"`
+	KWh           float64                `json:"kwh"`
+	Custo         float64                `json:"custo"` // R$; exclui os dias sem tarifa
+}
+
+// ConsumoDiaDTO descreve o consumo estimado de um ambiente em um dia
+type ConsumoDiaDTO struct {
+	Data     string                   `json:"data"` // AAAA-MM-DD no fuso local
+	KWh      float64                  `json:"kwh"`
+	Custo    *float64                 `json:"custo,omitempty"` // ausente quando não há tarifa vigente
+	Bandeira entity.BandeiraTarifaria `json:"bandeira"`
+}
+
+// ConsumoEnergiaDTO é a estimativa de consumo e custo de energia de um ambiente
+type ConsumoEnergiaDTO struct {
+	AmbienteID    uint                    `json:"ambiente_id"`
+	Inicio        string                  `json:"inicio"`
+	Fim           string                  `json:"fim"`
+	KWh           float64                 `json:"kwh"`
+	Custo         float64                 `json:"custo"`
+	DiasSemTarifa int                     `json:"dias_sem_tarifa,omitempty"`
+	Dias          []ConsumoDiaDTO         `json:"dias"`
+	Equipamentos  []ConsumoEquipamentoDTO `json:"equipamentos"`
+}
+
+// ConsumoDiarioCultivoDTO soma o consumo dos ambientes das plantas do diário no período do cultivo
+type ConsumoDiarioCultivoDTO struct {
+	DiarioCultivoID uint                `json:"diario_cultivo_id"`
+	Inicio          string              `json:"inicio"`
+	Fim             string              `json:"fim"`
+	KWh             float64             `json:"kwh"`
+	Custo           float64             `json:"custo"`
+	DiasSemTarifa   int                 `json:"dias_sem_tarifa,omitempty"`
+	GramasColhidos  *float64            `json:"gramas_colhidos,omitempty"` // soma do peso seco das plantas
+	CustoPorGrama   *float64            `json:"custo_por_grama,omitempty"`
+	KWhPorGrama     *float64            `json:"kwh_por_grama,omitempty"`
+	Ambientes       []ConsumoEnergiaDTO `json:"ambientes"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// MudarEstagioDTO representa a transição de uma planta para um novo estágio de crescimento
+type MudarEstagioDTO struct {
+	Estagio    entity.EstagioPlanta `json:"estagio" binding:"required,oneof=germinacao plantula vegetativo floracao maturacao"`
+	DataInicio *time.Time           `json:"data_inicio"` // padrão: agora
+}
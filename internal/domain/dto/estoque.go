@@ -0,0 +1,84 @@
+package dto
+
+import "time"
+
+// ItemEstoqueDTO representa a criação ou atualização de um item do inventário. A quantidade
+// inicial só é usada na criação; depois o saldo muda pelas compras, consumos e ajustes.
+type ItemEstoqueDTO struct {
+	Nome              string     `json:"nome" binding:"required,max=100"`
+	Categoria         string     `json:"categoria" binding:"required,oneof=nutriente substrato vaso defensivo semente outro"`
+	Fabricante        string     `json:"fabricante" binding:"max=100"`
+	Unidade           string     `json:"unidade" binding:"required,oneof=ml l g kg un"`
+	EstoqueMinimo     *float64   `json:"estoque_minimo" binding:"omitempty,gte=0"`
+	Validade          *time.Time `json:"validade"`
+	Observacoes       string     `json:"observacoes"`
+	QuantidadeInicial float64    `json:"quantidade_inicial" binding:"gte=0"`
+	CustoUnitario     float64    `json:"custo_unitario" binding:"gte=0"` // da quantidade inicial
+}
+
+// ConsultaItensEstoqueDTO filtra o inventário do usuário
+type ConsultaItensEstoqueDTO struct {
+	Categoria      string `form:"categoria" binding:"omitempty,oneof=nutriente substrato vaso defensivo semente outro"`
+	AbaixoMinimo   bool   `form:"abaixo_minimo"`
+	VencendoEmDias *int   `form:"vencendo_em_dias" binding:"omitempty,gte=0,lte=3650"` // inclui os vencidos
+}
+
+// CompraEstoqueDTO registra a entrada de uma compra; a quantidade está na unidade do item
+type CompraEstoqueDTO struct {
+	Quantidade  float64    `json:"quantidade" binding:"required,gt=0"`
+	CustoTotal  float64    `json:"custo_total" binding:"gte=0"`
+	Data        *time.Time `json:"data"` // padrão: agora
+	Fornecedor  string     `json:"fornecedor" binding:"max=100"`
+	Validade    *time.Time `json:"validade"` // validade do lote comprado
+	Observacoes string     `json:"observacoes"`
+}
+
+// ConsumoEstoqueDTO registra a saída manual de um item, como a aplicação de um defensivo
+type ConsumoEstoqueDTO struct {
+	Quantidade      float64    `json:"quantidade" binding:"required,gt=0"`
+	Origem          string     `json:"origem" binding:"omitempty,oneof=manual tratamento transplante"` // padrão: manual
+	Data            *time.Time `json:"data"`                                                           // padrão: agora
+	PlantaID        *uint      `json:"planta_id" binding:"omitempty,gt=0"`
+	DiarioCultivoID *uint      `json:"diario_cultivo_id" binding:"omitempty,gt=0"`
+	Observacoes     string     `json:"observacoes"`
+}
+
+// AjusteEstoqueDTO corrige o saldo pela contagem do inventário
+type AjusteEstoqueDTO struct {
+	QuantidadeContada float64    `json:"quantidade_contada" binding:"gte=0"`
+	Data              *time.Time `json:"data"` // padrão: agora
+	Observacoes       string     `json:"observacoes"`
+}
+
+// ItemConsumidoDTO é um insumo usado na execução de uma tarefa, na unidade do item
+type ItemConsumidoDTO struct {
+	ItemID     uint    `json:"item_id" binding:"required,gt=0"`
+	Quantidade float64 `json:"quantidade" binding:"required,gt=0"`
+}
+
+// CustoCategoriaEstoqueDTO é o custo dos insumos de uma categoria no ciclo
+type CustoCategoriaEstoqueDTO struct {
+	Categoria string  `json:"categoria"`
+	Total     float64 `json:"total"`
+}
+
+// CustoItemEstoqueDTO é quanto de um item foi consumido no ciclo e quanto custou
+type CustoItemEstoqueDTO struct {
+	ItemID     uint    `json:"item_id"`
+	Nome       string  `json:"nome"`
+	Categoria  string  `json:"categoria"`
+	Quantidade float64 `json:"quantidade"`
+	Unidade    string  `json:"unidade"`
+	Total      float64 `json:"total"`
+}
+
+// CustoCicloDTO totaliza o custo dos insumos consumidos no ciclo de um diário de cultivo,
+// valorizados pelo custo médio das compras no momento do consumo
+type CustoCicloDTO struct {
+	DiarioCultivoID uint                       `json:"diario_cultivo_id"`
+	Total           float64                    `json:"total"`
+	Plantas         int                        `json:"plantas"`
+	CustoPorPlanta  *float64                   `json:"custo_por_planta,omitempty"`
+	PorCategoria    []CustoCategoriaEstoqueDTO `json:"por_categoria"`
+	PorItem         []CustoItemEstoqueDTO      `json:"por_item"`
+}
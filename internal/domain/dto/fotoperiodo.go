@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// FotoperiodoDTO representa a criação de uma programação de luz
+type FotoperiodoDTO struct {
+	Nome         string     `json:"nome" binding:"max=100"`
+	HoraLigar    string     `json:"hora_ligar" binding:"required,datetime=15:04"`    // horário local
+	HoraDesligar string     `json:"hora_desligar" binding:"required,datetime=15:04"` // igual a hora_ligar = 24h de luz
+	RampaMinutos int        `json:"rampa_minutos" binding:"gte=0,lte=240"`
+	VigenteDesde *time.Time `json:"vigente_desde"` // padrão: agora; datas futuras agendam a troca
+}
+
+// ViradaFotoperiodoDTO agenda a troca de horas de luz mantendo o horário de ligar e a rampa vigentes
+type ViradaFotoperiodoDTO struct {
+	Data     time.Time `json:"data" binding:"required"`
+	HorasLuz float64   `json:"horas_luz" binding:"required,gt=0,lte=24"`
+}
+
+// ConsultaHorasLuzDTO define o período da consulta de horas de luz (padrão: próximos 7 dias)
+type ConsultaHorasLuzDTO struct {
+	Inicio string `form:"inicio" binding:"omitempty,datetime=2006-01-02"`
+	Fim    string `form:"fim" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// HorasLuzDiaDTO descreve a luz programada de um ambiente em um dia
+type HorasLuzDiaDTO struct {
+	Data             string     `json:"data"`                     // AAAA-MM-DD no fuso local
+	FotoperiodoID    *uint      `json:"fotoperiodo_id,omitempty"` // ausente quando vem do sol (externos) ou de tempo_exposicao
+	Ligar            *time.Time `json:"ligar,omitempty"`
+	Desligar         *time.Time `json:"desligar,omitempty"`
+	HorasLuz         float64    `json:"horas_luz"`
+	HorasLuzEfetivas float64    `json:"horas_luz_efetivas"` // descontadas as rampas; usadas no DLI
+}
+
+// SugestaoFotoperiodoDTO indica se a programação de luz combina com o estágio das plantas
+type SugestaoFotoperiodoDTO struct {
+	AmbienteID        uint                  `json:"ambiente_id"`
+	Estagio           entity.EstagioPlanta  `json:"estagio,omitempty"`
+	HorasLuzAtuais    float64               `json:"horas_luz_atuais"`
+	HorasLuzSugeridas float64               `json:"horas_luz_sugeridas,omitempty"`
+	Mudar             bool                  `json:"mudar"`
+	Motivo            string                `json:"motivo"`
+	Virada            *ViradaFotoperiodoDTO `json:"virada,omitempty"` // pronta para POST /ambientes/{id}/fotoperiodos/virada
+}
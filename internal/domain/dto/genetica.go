@@ -11,6 +11,9 @@ type CreateGeneticaDTO struct {
 	Origem          string          `json:"origem" binding:"required"`
 	Caracteristicas string          `json:"caracteristicas"`
 	Plantas         []entity.Planta `json:"plantas,omitempty"`
+	MaeID           *uint           `json:"maeId" binding:"omitempty,gt=0"`
+	PaiID           *uint           `json:"paiId" binding:"omitempty,gt=0"`
+	Geracao         string          `json:"geracao" binding:"max=10"` // F1, F2, BX1, S1, IBL...
 }
 
 type UpdateGeneticaDTO struct {
@@ -21,6 +24,9 @@ type UpdateGeneticaDTO struct {
 	TempoFloracao   int    `json:"tempoFloracao"`
 	Origem          string `json:"origem"`
 	Caracteristicas string `json:"caracteristicas"`
+	MaeID           *uint  `json:"maeId"` // zero remove a mãe
+	PaiID           *uint  `json:"paiId"` // zero remove o pai
+	Geracao         string `json:"geracao" binding:"max=10"`
 }
 
 type GeneticaResponseDTO struct {
@@ -32,4 +38,71 @@ type GeneticaResponseDTO struct {
 	TempoFloracao   int    `json:"tempoFloracao"`
 	Origem          string `json:"origem"`
 	Caracteristicas string `json:"caracteristicas"`
+	MaeID           *uint  `json:"maeId,omitempty"`
+	PaiID           *uint  `json:"paiId,omitempty"`
+	Geracao         string `json:"geracao,omitempty"`
+}
+
+// ImportacaoGeneticasCSVDTO define o formato do CSV importado para o catálogo
+type ImportacaoGeneticasCSVDTO struct {
+	Delimitador string `form:"delimitador" binding:"omitempty,oneof=virgula ponto_virgula tab"` // padrão: virgula
+}
+
+// ExportacaoGeneticasDTO escolhe o formato da exportação do catálogo
+type ExportacaoGeneticasDTO struct {
+	Formato string `form:"formato" binding:"omitempty,oneof=json csv"` // padrão: json
+}
+
+// GeneticaIgnoradaDTO é uma genética do arquivo que não foi importada por já existir no catálogo
+// ou no próprio arquivo
+type GeneticaIgnoradaDTO struct {
+	Linha         int     `json:"linha"` // registro no JSON ou linha no CSV, a partir de 1
+	Nome          string  `json:"nome"`
+	Origem        string  `json:"origem"`
+	Motivo        string  `json:"motivo"`
+	DuplicadaDeID *uint   `json:"duplicada_de_id,omitempty"`
+	Similaridade  float64 `json:"similaridade"`
+}
+
+// ResultadoImportacaoGeneticasDTO resume a importação do catálogo
+type ResultadoImportacaoGeneticasDTO struct {
+	Criadas   []GeneticaResponseDTO `json:"criadas"`
+	Ignoradas []GeneticaIgnoradaDTO `json:"ignoradas"`
+}
+
+// GrupoGeneticasDuplicadasDTO reúne genéticas com nome e origem parecidos. Similaridade é a
+// menor entre os pares do grupo, de 0 a 1.
+type GrupoGeneticasDuplicadasDTO struct {
+	Geneticas    []GeneticaResponseDTO `json:"geneticas"`
+	Similaridade float64               `json:"similaridade"`
+}
+
+// MesclagemGeneticasDTO lista as genéticas duplicadas que serão incorporadas à genética mantida
+type MesclagemGeneticasDTO struct {
+	Duplicadas []uint `json:"duplicadas" binding:"required,min=1,max=50,dive,gt=0"`
+}
+
+// ResultadoMesclagemGeneticasDTO é a genética mantida e quantas referências passaram para ela
+type ResultadoMesclagemGeneticasDTO struct {
+	Genetica       GeneticaResponseDTO `json:"genetica"`
+	Removidas      []uint              `json:"removidas"`
+	PlantasMovidas int64               `json:"plantas_movidas"`
+	PacotesMovidos int64               `json:"pacotes_movidos"`
+}
+
+// ConsultaPedigreeDTO limita quantas gerações de ancestrais entram na árvore
+type ConsultaPedigreeDTO struct {
+	Profundidade int `form:"profundidade" binding:"omitempty,min=1,max=10"` // padrão: 5
+}
+
+// PedigreeGeneticaDTO é um nó da árvore de ancestrais de uma genética. Mae e Pai ficam vazios
+// quando a origem não foi cadastrada ou o limite de profundidade foi atingido.
+type PedigreeGeneticaDTO struct {
+	ID           uint                 `json:"id"`
+	Nome         string               `json:"nome"`
+	Origem       string               `json:"origem"`
+	TipoGenetica string               `json:"tipoGenetica"`
+	Geracao      string               `json:"geracao,omitempty"`
+	Mae          *PedigreeGeneticaDTO `json:"mae,omitempty"`
+	Pai          *PedigreeGeneticaDTO `json:"pai,omitempty"`
 }
@@ -0,0 +1,34 @@
+package dto
+
+import "gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+
+// PosicaoLayoutDTO posiciona uma planta e/ou um vaso na planta baixa do ambiente
+type PosicaoLayoutDTO struct {
+	PlantaID       *uint   `json:"planta_id" binding:"omitempty,gt=0"`
+	VasoID         *uint   `json:"vaso_id" binding:"omitempty,gt=0"`
+	Rotulo         string  `json:"rotulo" binding:"max=50"`
+	X              float64 `json:"x" binding:"gte=0"`                                     // cm, centro do item no eixo do comprimento
+	Y              float64 `json:"y" binding:"gte=0"`                                     // cm, centro do item no eixo da largura
+	Formato        string  `json:"formato" binding:"omitempty,oneof=circular retangular"` // padrão: circular com vaso, retangular sem
+	LarguraCm      float64 `json:"largura_cm" binding:"gte=0"`                            // diâmetro nos circulares; padrão: diâmetro do vaso
+	ProfundidadeCm float64 `json:"profundidade_cm" binding:"gte=0"`                       // retangulares; padrão: igual à largura
+}
+
+// ConsultaPlantaBaixaDTO define a grade desenhada na planta baixa
+type ConsultaPlantaBaixaDTO struct {
+	GradeCm float64 `form:"grade" binding:"omitempty,gte=5,lte=500"` // padrão: 30 cm; 0 usa o padrão
+}
+
+// OcupacaoLayoutDTO resume a ocupação da área de cultivo (canopy) do ambiente
+type OcupacaoLayoutDTO struct {
+	AmbienteID        uint                   `json:"ambiente_id"`
+	ComprimentoCm     float64                `json:"comprimento_cm"`
+	LarguraCm         float64                `json:"largura_cm"`
+	AreaTotalM2       float64                `json:"area_total_m2"`
+	AreaOcupadaM2     float64                `json:"area_ocupada_m2"`
+	AreaLivreM2       float64                `json:"area_livre_m2"`
+	PercentualOcupado float64                `json:"percentual_ocupado"`
+	Plantas           int                    `json:"plantas"`
+	PlantasPorM2      float64                `json:"plantas_por_m2"`
+	Itens             []entity.PosicaoLayout `json:"itens"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// LembreteDTO representa a criação ou atualização de um lembrete
+type LembreteDTO struct {
+	Mensagem   string    `json:"mensagem" binding:"required,max=2000"`
+	DataHora   time.Time `json:"data_hora" binding:"required"` // primeira ocorrência
+	Repetir    bool      `json:"repetir"`
+	Frequencia string    `json:"frequencia" binding:"required_if=Repetir true,omitempty,oneof=diaria semanal mensal"`
+	Canais     []string  `json:"canais" binding:"omitempty,dive,oneof=lembrete email webhook telegram"` // padrão: lembrete
+}
+
+// AdiarLembreteDTO adia a próxima entrega de um lembrete (soneca)
+type AdiarLembreteDTO struct {
+	Minutos int `json:"minutos" binding:"required,gte=1,lte=10080"` // até 7 dias
+}
+
+// ConsultaLembretesDTO filtra a listagem de lembretes do usuário
+type ConsultaLembretesDTO struct {
+	PaginationParams
+	Status string `form:"status" binding:"omitempty,oneof=agendado nao_lido lido"`
+}
+
+// PreferenciaNotificacaoDTO define os destinos dos canais externos, o horário de silêncio e as
+// categorias desativadas na central de notificações
+type PreferenciaNotificacaoDTO struct {
+	Email                 string   `json:"email" binding:"omitempty,email,max=100"`
+	WebhookURL            string   `json:"webhook_url" binding:"omitempty,url,max=500"`
+	TelegramChatID        string   `json:"telegram_chat_id" binding:"omitempty,max=50"`
+	SilencioInicio        string   `json:"silencio_inicio" binding:"required_with=SilencioFim,omitempty,datetime=15:04"` // horário local
+	SilencioFim           string   `json:"silencio_fim" binding:"required_with=SilencioInicio,omitempty,datetime=15:04"` // pode virar a meia-noite
+	CategoriasDesativadas []string `json:"categorias_desativadas" binding:"omitempty,dive,oneof=alerta lembrete tarefa cultivo estoque convite sistema"`
+}
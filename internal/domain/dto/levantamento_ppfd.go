@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// PontoPPFDDTO é uma medição do levantamento, em cm a partir do canto do ambiente
+type PontoPPFDDTO struct {
+	X    float64 `json:"x" binding:"gte=0"`
+	Y    float64 `json:"y" binding:"gte=0"`
+	PPFD float64 `json:"ppfd" binding:"gte=0,lte=3000"` // µmol/m²/s
+}
+
+// LevantamentoPPFDDTO representa o registro de um mapeamento de luz
+type LevantamentoPPFDDTO struct {
+	Data               *time.Time     `json:"data"` // padrão: agora
+	AlturaLuminariaCm  float64        `json:"altura_luminaria_cm" binding:"required,gt=0,lte=500"`
+	PotenciaPercentual *float64       `json:"potencia_percentual" binding:"omitempty,gt=0,lte=100"`
+	Observacoes        string         `json:"observacoes"`
+	Pontos             []PontoPPFDDTO `json:"pontos" binding:"required,min=3,max=1000,dive"`
+}
+
+// EstatisticaPPFDDTO resume as medições de um levantamento
+type EstatisticaPPFDDTO struct {
+	Pontos              int      `json:"pontos"`
+	Media               float64  `json:"media"`
+	Min                 float64  `json:"min"`
+	Max                 float64  `json:"max"`
+	DesvioPadrao        float64  `json:"desvio_padrao"`
+	CoeficienteVariacao float64  `json:"coeficiente_variacao"` // %
+	Uniformidade        float64  `json:"uniformidade"`         // mínimo / média; acima de 0,7 é considerado bom
+	UniformidadeMinMax  float64  `json:"uniformidade_min_max"` // mínimo / máximo
+	HorasLuz            *float64 `json:"horas_luz,omitempty"`  // horas de luz efetivas no dia do levantamento
+	DLIMedio            *float64 `json:"dli_medio,omitempty"`  // mol/m²/dia com a média e as horas de luz
+}
+
+// ResumoLevantamentoPPFDDTO é o levantamento com as estatísticas; a listagem omite os pontos
+type ResumoLevantamentoPPFDDTO struct {
+	ID                 uint               `json:"id"`
+	AmbienteID         uint               `json:"ambiente_id"`
+	Data               time.Time          `json:"data"`
+	AlturaLuminariaCm  float64            `json:"altura_luminaria_cm"`
+	PotenciaPercentual *float64           `json:"potencia_percentual,omitempty"`
+	Observacoes        string             `json:"observacoes,omitempty"`
+	Estatisticas       EstatisticaPPFDDTO `json:"estatisticas"`
+	Pontos             []entity.PontoPPFD `json:"pontos,omitempty"`
+}
+
+// ConsultaMapaPPFDDTO define o formato e a escala do mapa de calor
+type ConsultaMapaPPFDDTO struct {
+	Formato     string   `form:"formato" binding:"omitempty,oneof=png svg"`  // padrão: svg
+	ResolucaoCm float64  `form:"resolucao" binding:"omitempty,gte=1,lte=50"` // lado da célula; padrão: 5 cm
+	EscalaMin   *float64 `form:"escala_min" binding:"omitempty,gte=0"`       // fixa a escala para comparar mapas
+	EscalaMax   *float64 `form:"escala_max" binding:"omitempty,gt=0"`
+}
+
+// ConsultaComparacaoPPFDDTO indica o levantamento comparado
+type ConsultaComparacaoPPFDDTO struct {
+	Com uint `form:"com" binding:"required,gt=0"`
+}
+
+// DiferencaPontoPPFDDTO compara um ponto do levantamento base com o valor do comparado
+// no mesmo lugar, interpolado quando o comparado não mediu exatamente ali
+type DiferencaPontoPPFDDTO struct {
+	X           float64 `json:"x"`
+	Y           float64 `json:"y"`
+	Base        float64 `json:"base"`
+	Comparado   float64 `json:"comparado"`
+	Diferenca   float64 `json:"diferenca"`
+	Interpolado bool    `json:"interpolado"`
+}
+
+// ComparacaoPPFDDTO mostra o efeito de um ajuste de luz entre dois levantamentos do ambiente
+type ComparacaoPPFDDTO struct {
+	Base                     ResumoLevantamentoPPFDDTO `json:"base"`
+	Comparado                ResumoLevantamentoPPFDDTO `json:"comparado"`
+	DiferencaMedia           float64                   `json:"diferenca_media"`
+	DiferencaMediaPercentual float64                   `json:"diferenca_media_percentual"`
+	DiferencaUniformidade    float64                   `json:"diferenca_uniformidade"`
+	Pontos                   []DiferencaPontoPPFDDTO   `json:"pontos"`
+}
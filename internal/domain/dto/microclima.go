@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/importacao"
+)
+
+// LeituraMicroclimaDTO representa uma leitura de sensores enviada para um ambiente
+type LeituraMicroclimaDTO struct {
+	DataMedicao  *time.Time `json:"data_medicao"`                                                         // padrão: momento do recebimento
+	Temperatura  *float64   `json:"temperatura" binding:"required,gte=-50,lte=80"`                        // °C; obrigatória, 0 é um valor válido
+	Umidade      *float64   `json:"umidade" binding:"required,gte=0,lte=100"`                             // %; obrigatória, 0 é um valor válido
+	Luminosidade float64    `json:"luminosidade" binding:"gte=0"`                                         // lux
+	CO2          *float64   `json:"co2,omitempty" binding:"omitempty,gte=0"`                              // ppm
+	UmidadeSolo  *float64   `json:"umidade_solo,omitempty" binding:"omitempty,gte=0,lte=100"`             // %
+	PPFD         *float64   `json:"ppfd,omitempty" binding:"omitempty,gte=0"`                             // µmol/m²/s
+	PosicaoX     *float64   `json:"posicao_x,omitempty" binding:"required_with=PosicaoY,omitempty,gte=0"` // cm, no eixo do comprimento do ambiente
+	PosicaoY     *float64   `json:"posicao_y,omitempty" binding:"required_with=PosicaoX,omitempty,gte=0"` // cm, no eixo da largura do ambiente
+}
+
+// LoteLeiturasMicroclimaDTO agrupa várias leituras enviadas de uma vez
+type LoteLeiturasMicroclimaDTO struct {
+	Leituras []LeituraMicroclimaDTO `json:"leituras" binding:"required,min=1,max=5000,dive"`
+}
+
+// ConsultaMicroclimaDTO define o intervalo de tempo e a granularidade de uma consulta
+type ConsultaMicroclimaDTO struct {
+	Inicio    time.Time `form:"inicio" time_format:"2006-01-02T15:04:05Z07:00"`
+	Fim       time.Time `form:"fim" time_format:"2006-01-02T15:04:05Z07:00"`
+	Intervalo string    `form:"intervalo" binding:"omitempty,oneof=5m 1h 1d"`
+	Limit     int       `form:"limit,default=1000" binding:"min=1,max=10000"`
+
+	// Parâmetros das métricas derivadas
+	OffsetFolha *float64 `form:"offset_folha" binding:"omitempty,gte=-10,lte=10"` // °C somados à temperatura do ar (padrão: -2)
+	FatorLux    float64  `form:"fator_lux" binding:"omitempty,gt=0,lte=1"`        // conversão lux → PPFD quando não há sensor quântico
+	Estagio     string   `form:"estagio" binding:"omitempty,oneof=germinacao plantula vegetativo floracao maturacao"`
+}
+
+// IngestaoMicroclimaResponseDTO resume o resultado de uma ingestão
+type IngestaoMicroclimaResponseDTO struct {
+	AmbienteID uint `json:"ambiente_id"`
+	Inseridas  int  `json:"inseridas"`
+}
+
+// SerieMicroclimaResponseDTO retorna a série bruta de leituras
+type SerieMicroclimaResponseDTO struct {
+	AmbienteID uint                `json:"ambiente_id"`
+	Inicio     time.Time           `json:"inicio"`
+	Fim        time.Time           `json:"fim"`
+	Leituras   []entity.Microclima `json:"leituras"`
+}
+
+// ForaDaFaixaDTO sinaliza uma métrica fora da faixa alvo do estágio
+type ForaDaFaixaDTO struct {
+	Metrica  string  `json:"metrica"`
+	Valor    float64 `json:"valor"`
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Situacao string  `json:"situacao"` // abaixo, acima, risco_condensacao
+}
+
+// JanelaMicroclimaDTO combina os agregados de uma janela com as métricas derivadas
+type JanelaMicroclimaDTO struct {
+	entity.MicroclimaAgregado
+	VPDAr        float64          `json:"vpd_ar"`        // kPa
+	VPDFolha     float64          `json:"vpd_folha"`     // kPa
+	PontoOrvalho float64          `json:"ponto_orvalho"` // °C
+	PPFD         float64          `json:"ppfd"`          // µmol/m²/s (medido ou estimado a partir de lux)
+	ForaDaFaixa  []ForaDaFaixaDTO `json:"fora_da_faixa,omitempty"`
+}
+
+// DLIDiarioDTO representa a integral de luz acumulada em um dia
+type DLIDiarioDTO struct {
+	Data        string          `json:"data"` // AAAA-MM-DD
+	DLI         float64         `json:"dli"`  // mol/m²/dia
+	ForaDaFaixa *ForaDaFaixaDTO `json:"fora_da_faixa,omitempty"`
+	// HorasLuz e DLIEstimado vêm do fotoperíodo do ambiente: o DLI estimado projeta o PPFD
+	// médio das janelas com luz sobre as horas de luz efetivas, útil quando há falhas de leitura
+	HorasLuz    *float64 `json:"horas_luz,omitempty"`
+	DLIEstimado *float64 `json:"dli_estimado,omitempty"`
+}
+
+// AgregadoMicroclimaResponseDTO retorna a série reduzida em janelas de tempo
+type AgregadoMicroclimaResponseDTO struct {
+	AmbienteID  uint                  `json:"ambiente_id"`
+	Inicio      time.Time             `json:"inicio"`
+	Fim         time.Time             `json:"fim"`
+	Intervalo   string                `json:"intervalo"`
+	Estagio     entity.EstagioPlanta  `json:"estagio"`
+	OffsetFolha float64               `json:"offset_folha"`
+	Faixas      calculo.FaixasAlvo    `json:"faixas"`
+	Janelas     []JanelaMicroclimaDTO `json:"janelas"`
+	DLIDiario   []DLIDiarioDTO        `json:"dli_diario"`
+}
+
+// ImportacaoLineProtocolDTO configura a importação no formato line protocol do InfluxDB
+type ImportacaoLineProtocolDTO struct {
+	Precisao string `form:"precision" binding:"omitempty,oneof=ns us ms s"` // unidade do timestamp (padrão: ns)
+	Medicao  string `form:"measurement"`                                    // importa apenas essa measurement
+}
+
+// ImportacaoCSVDTO configura a importação de CSV de data loggers
+type ImportacaoCSVDTO struct {
+	Delimitador string `form:"delimitador" binding:"omitempty,oneof=virgula ponto_virgula tab"` // padrão: virgula
+	Decimal     string `form:"decimal" binding:"omitempty,oneof=ponto virgula"`                 // padrão: ponto
+	ColunaData  string `form:"coluna_data"`                                                     // padrão: detectada pelo nome
+	FormatoData string `form:"formato_data"`                                                    // layout Go, unix ou unix_ms
+	Fuso        string `form:"fuso"`                                                            // IANA, para datas sem deslocamento (padrão: UTC)
+	Colunas     string `form:"colunas"`                                                         // ex.: temperatura=Temp (C),umidade=RH (%)
+}
+
+// ImportacaoMicroclimaResponseDTO resume uma importação de histórico
+type ImportacaoMicroclimaResponseDTO struct {
+	AmbienteID uint                        `json:"ambiente_id"`
+	Inseridas  int                         `json:"inseridas"`
+	Rejeitadas int                         `json:"rejeitadas"`
+	Rejeicoes  []importacao.LinhaRejeitada `json:"rejeicoes"` // até importacao.MaxRejeicoesDetalhadas linhas
+}
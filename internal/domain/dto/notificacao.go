@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// ConsultaNotificacoesDTO filtra a listagem da central de notificações
+type ConsultaNotificacoesDTO struct {
+	PaginationParams
+	Categoria string `form:"categoria" binding:"omitempty,oneof=alerta lembrete tarefa cultivo estoque convite sistema"`
+	NaoLidas  bool   `form:"nao_lidas"`
+}
+
+// MarcarNotificacoesLidasDTO marca notificações como lidas em lote; sem IDs, marca todas as não
+// lidas da categoria (ou todas, sem categoria)
+type MarcarNotificacoesLidasDTO struct {
+	IDs       []uint `json:"ids" binding:"omitempty,max=500"`
+	Categoria string `json:"categoria" binding:"omitempty,oneof=alerta lembrete tarefa cultivo estoque convite sistema"`
+}
+
+// NotificacoesLidasDTO informa quantas notificações foram marcadas e quantas seguem não lidas
+type NotificacoesLidasDTO struct {
+	Marcadas int64 `json:"marcadas"`
+	NaoLidas int64 `json:"nao_lidas"`
+}
+
+// TicketStreamDTO é o ticket de uso único para abrir o stream de notificações
+type TicketStreamDTO struct {
+	Ticket   string    `json:"ticket"`
+	ExpiraEm time.Time `json:"expira_em"`
+}
+
+// ContagemNotificacoesDTO é o total de notificações não lidas, para o indicador do app
+type ContagemNotificacoesDTO struct {
+	NaoLidas int64 `json:"nao_lidas"`
+}
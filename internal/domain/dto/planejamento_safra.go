@@ -0,0 +1,48 @@
+package dto
+
+// ConsultaPlanejamentoSafraDTO define a genética e o início do planejamento de um ambiente externo
+type ConsultaPlanejamentoSafraDTO struct {
+	GeneticaID uint   `form:"genetica_id" binding:"required,gt=0"`
+	Inicio     string `form:"inicio" binding:"omitempty,datetime=2006-01-02"` // padrão: hoje; planeja os 12 meses seguintes
+}
+
+// MesSafraDTO resume a luz e o clima esperados em um mês da temporada
+type MesSafraDTO struct {
+	Mes            string   `json:"mes"`                       // AAAA-MM
+	HorasLuz       float64  `json:"horas_luz"`                 // duração do dia 15
+	TemperaturaMin *float64 `json:"temperatura_min,omitempty"` // média das mínimas no mesmo mês do último ano
+	TemperaturaMax *float64 `json:"temperatura_max,omitempty"` // média das máximas no mesmo mês do último ano
+	Precipitacao   *float64 `json:"precipitacao,omitempty"`    // mm acumulados no mesmo mês do último ano
+	Riscos         []string `json:"riscos,omitempty"`
+}
+
+// CenarioSafraDTO é a previsão de um ciclo para uma data de semeadura
+type CenarioSafraDTO struct {
+	Semeadura        string   `json:"semeadura"` // AAAA-MM-DD
+	InicioFloracao   string   `json:"inicio_floracao"`
+	Colheita         string   `json:"colheita"`
+	DiasVegetativo   int      `json:"dias_vegetativo"`
+	HorasLuzFloracao float64  `json:"horas_luz_floracao"` // duração do dia no início da floração
+	Recomendada      bool     `json:"recomendada"`
+	Riscos           []string `json:"riscos,omitempty"`
+}
+
+// JanelaSemeaduraDTO é um período contínuo de semeaduras recomendadas
+type JanelaSemeaduraDTO struct {
+	Inicio string `json:"inicio"` // AAAA-MM-DD
+	Fim    string `json:"fim"`
+}
+
+// PlanejamentoSafraDTO é o planejamento da temporada de uma genética em um ambiente externo
+type PlanejamentoSafraDTO struct {
+	AmbienteID        uint                 `json:"ambiente_id"`
+	GeneticaID        uint                 `json:"genetica_id"`
+	Genetica          string               `json:"genetica"`
+	Autoflorescente   bool                 `json:"autoflorescente"`
+	TempoFloracaoDias int                  `json:"tempo_floracao_dias"`
+	Latitude          float64              `json:"latitude"`
+	ClimaHistorico    bool                 `json:"clima_historico"` // há registros de clima do último ano para avaliar os riscos climáticos
+	Janelas           []JanelaSemeaduraDTO `json:"janelas"`
+	Cenarios          []CenarioSafraDTO    `json:"cenarios"` // uma semeadura por semana
+	Meses             []MesSafraDTO        `json:"meses"`
+}
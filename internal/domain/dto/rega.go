@@ -0,0 +1,101 @@
+package dto
+
+import "time"
+
+// DoseNutrienteDTO é a dose de um nutriente por litro de solução
+type DoseNutrienteDTO struct {
+	Nutriente string  `json:"nutriente" binding:"required,max=100"`
+	Dose      float64 `json:"dose" binding:"required,gt=0,lte=1000"`
+	Unidade   string  `json:"unidade" binding:"required,oneof=ml_l g_l"`
+}
+
+// ReceitaNutrienteDTO representa a criação ou atualização de uma receita; os itens substituem
+// os anteriores na ordem informada
+type ReceitaNutrienteDTO struct {
+	Nome      string             `json:"nome" binding:"required,max=100"`
+	Descricao string             `json:"descricao"`
+	ECAlvo    *float64           `json:"ec_alvo" binding:"omitempty,gt=0,lte=10"` // mS/cm
+	PHAlvo    *float64           `json:"ph_alvo" binding:"omitempty,gte=0,lte=14"`
+	Itens     []DoseNutrienteDTO `json:"itens" binding:"required,min=1,max=30,dive"`
+}
+
+// RegaDTO representa o registro de uma rega de uma planta ou do lote de um diário de cultivo.
+// Com receita e sem nutrientes, as doses da receita são copiadas para a rega.
+type RegaDTO struct {
+	PlantaID        *uint              `json:"planta_id" binding:"omitempty,gt=0"`
+	DiarioCultivoID *uint              `json:"diario_cultivo_id" binding:"omitempty,gt=0"` // rega do lote
+	Data            *time.Time         `json:"data"`                                       // padrão: agora
+	VolumeLitros    float64            `json:"volume_litros" binding:"required,gt=0,lte=10000"`
+	PHEntrada       *float64           `json:"ph_entrada" binding:"omitempty,gte=0,lte=14"`
+	ECEntrada       *float64           `json:"ec_entrada" binding:"omitempty,gte=0,lte=20"` // mS/cm
+	PPMEntrada      *float64           `json:"ppm_entrada" binding:"omitempty,gte=0,lte=10000"`
+	EscalaPPM       int                `json:"escala_ppm" binding:"omitempty,oneof=500 700"` // padrão: 500
+	PHRunoff        *float64           `json:"ph_runoff" binding:"omitempty,gte=0,lte=14"`
+	ECRunoff        *float64           `json:"ec_runoff" binding:"omitempty,gte=0,lte=20"`
+	TemperaturaAgua *float64           `json:"temperatura_agua" binding:"omitempty,gte=0,lte=50"`
+	ReceitaID       *uint              `json:"receita_id" binding:"omitempty,gt=0"`
+	Nutrientes      []DoseNutrienteDTO `json:"nutrientes" binding:"omitempty,max=30,dive"`
+	Observacoes     string             `json:"observacoes"`
+}
+
+// ConsultaRegasDTO filtra a listagem de regas do usuário
+type ConsultaRegasDTO struct {
+	PaginationParams
+	PlantaID        uint   `form:"planta_id"`
+	DiarioCultivoID uint   `form:"diario_cultivo_id"`
+	De              string `form:"de" binding:"omitempty,datetime=2006-01-02"`
+	Ate             string `form:"ate" binding:"omitempty,datetime=2006-01-02"` // inclusive
+}
+
+// ConsultaGraficoRegaDTO limita o período do gráfico de regas da planta
+type ConsultaGraficoRegaDTO struct {
+	De  string `form:"de" binding:"omitempty,datetime=2006-01-02"`
+	Ate string `form:"ate" binding:"omitempty,datetime=2006-01-02"` // inclusive
+}
+
+// PontoGraficoRegaDTO é uma rega no gráfico de EC/pH da planta
+type PontoGraficoRegaDTO struct {
+	RegaID       uint      `json:"rega_id"`
+	Data         time.Time `json:"data"`
+	Lote         bool      `json:"lote"`          // rega do lote do diário
+	VolumeLitros float64   `json:"volume_litros"` // parte da planta nas regas do lote
+	PHEntrada    *float64  `json:"ph_entrada,omitempty"`
+	ECEntrada    *float64  `json:"ec_entrada,omitempty"`
+	PHRunoff     *float64  `json:"ph_runoff,omitempty"`
+	ECRunoff     *float64  `json:"ec_runoff,omitempty"`
+}
+
+// GraficoRegaPlantaDTO é a série de EC/pH de entrada e drenagem da planta ao longo do tempo
+type GraficoRegaPlantaDTO struct {
+	PlantaID    uint                  `json:"planta_id"`
+	Regas       int                   `json:"regas"`
+	TotalLitros float64               `json:"total_litros"`
+	Pontos      []PontoGraficoRegaDTO `json:"pontos"`
+}
+
+// ConsumoPlantaRegaDTO é a água recebida por uma planta no ciclo
+type ConsumoPlantaRegaDTO struct {
+	PlantaID uint    `json:"planta_id"`
+	Nome     string  `json:"nome"`
+	Litros   float64 `json:"litros"`
+}
+
+// ResumoRegaCicloDTO totaliza a água e os nutrientes usados no ciclo de um diário de cultivo
+type ResumoRegaCicloDTO struct {
+	DiarioCultivoID uint                   `json:"diario_cultivo_id"`
+	Regas           int                    `json:"regas"`
+	TotalLitros     float64                `json:"total_litros"`
+	PHEntradaMedio  *float64               `json:"ph_entrada_medio,omitempty"`
+	ECEntradaMedia  *float64               `json:"ec_entrada_media,omitempty"`
+	PorPlanta       []ConsumoPlantaRegaDTO `json:"por_planta"`
+	Nutrientes      []TotalNutrienteDTO    `json:"nutrientes"`
+	Primeira        *time.Time             `json:"primeira,omitempty"`
+	Ultima          *time.Time             `json:"ultima,omitempty"`
+}
+
+// TotalNutrienteDTO é a quantidade de um nutriente aplicada no ciclo (dose x volume das regas)
+type TotalNutrienteDTO struct {
+	Nutriente  string  `json:"nutriente"`
+	Quantidade float64 `json:"quantidade"`
+	Unidade    string  `json:"unidade"` // ml ou g
+}
@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+// ReservatorioDTO representa a criação ou atualização de um reservatório; as plantas
+// substituem as anteriores
+type ReservatorioDTO struct {
+	Nome               string  `json:"nome" binding:"required,max=100"`
+	VolumeLitros       float64 `json:"volume_litros" binding:"required,gt=0,lte=100000"`
+	AmbienteID         *uint   `json:"ambiente_id" binding:"omitempty,gt=0"`
+	PlantaIDs          []uint  `json:"planta_ids" binding:"omitempty,max=500,dive,gt=0"`
+	IntervaloTrocaDias int     `json:"intervalo_troca_dias" binding:"gte=0,lte=90"` // 0 desativa as tarefas de troca
+}
+
+// ManutencaoReservatorioDTO registra uma troca de solução ou uma reposição do reservatório
+type ManutencaoReservatorioDTO struct {
+	Tipo         string     `json:"tipo" binding:"required,oneof=troca completar"`
+	Data         *time.Time `json:"data"`                                             // padrão: agora
+	VolumeLitros float64    `json:"volume_litros" binding:"required,gt=0,lte=100000"` // água ou solução adicionada
+	EC           *float64   `json:"ec" binding:"omitempty,gte=0,lte=20"`              // mS/cm depois da manutenção
+	PH           *float64   `json:"ph" binding:"omitempty,gte=0,lte=14"`
+	Observacoes  string     `json:"observacoes"`
+}
+
+// LeituraReservatorioDTO é uma medição da solução; ao menos uma grandeza deve ser informada
+type LeituraReservatorioDTO struct {
+	Data               *time.Time `json:"data"`                                         // padrão: agora
+	EC                 *float64   `json:"ec" binding:"omitempty,gte=0,lte=20"`          // mS/cm
+	PPM                *float64   `json:"ppm" binding:"omitempty,gte=0,lte=10000"`      // convertido em EC quando EC não vem
+	EscalaPPM          int        `json:"escala_ppm" binding:"omitempty,oneof=500 700"` // padrão: 500
+	PH                 *float64   `json:"ph" binding:"omitempty,gte=0,lte=14"`
+	TemperaturaAgua    *float64   `json:"temperatura_agua" binding:"omitempty,gte=0,lte=50"`
+	OxigenioDissolvido *float64   `json:"oxigenio_dissolvido" binding:"omitempty,gte=0,lte=30"` // mg/L
+	NivelLitros        *float64   `json:"nivel_litros" binding:"omitempty,gte=0,lte=100000"`
+}
+
+// ConsultaLeiturasReservatorioDTO limita o período das leituras do reservatório
+type ConsultaLeiturasReservatorioDTO struct {
+	De  string `form:"de" binding:"omitempty,datetime=2006-01-02"`
+	Ate string `form:"ate" binding:"omitempty,datetime=2006-01-02"` // inclusive
+}
+
+// TendenciaMetricaDTO é a deriva de uma grandeza desde a última troca
+type TendenciaMetricaDTO struct {
+	Leituras    int      `json:"leituras"`
+	Inicial     float64  `json:"inicial"`
+	Atual       float64  `json:"atual"`
+	VariacaoDia *float64 `json:"variacao_dia,omitempty"` // inclinação da reta ajustada às leituras
+	Tendencia   string   `json:"tendencia"`              // subindo, caindo, estavel ou indefinida
+}
+
+// DerivaReservatorioDTO analisa a solução do reservatório desde a última troca
+type DerivaReservatorioDTO struct {
+	ReservatorioID     uint                 `json:"reservatorio_id"`
+	InicioCiclo        time.Time            `json:"inicio_ciclo"`
+	DiasDesdeTroca     float64              `json:"dias_desde_troca"`
+	ProximaTroca       *time.Time           `json:"proxima_troca,omitempty"`
+	EC                 *TendenciaMetricaDTO `json:"ec,omitempty"`
+	PH                 *TendenciaMetricaDTO `json:"ph,omitempty"`
+	TemperaturaAgua    *float64             `json:"temperatura_agua,omitempty"`    // da leitura mais recente
+	OxigenioDissolvido *float64             `json:"oxigenio_dissolvido,omitempty"` // da leitura mais recente
+	LitrosCompletados  float64              `json:"litros_completados"`            // reposições desde a troca
+	ConsumoLitrosDia   *float64             `json:"consumo_litros_dia,omitempty"`
+	Diagnosticos       []string             `json:"diagnosticos"`
+}
@@ -0,0 +1,59 @@
+package dto
+
+import "time"
+
+// PacoteSementeDTO representa a criação ou atualização de um pacote do banco de sementes.
+// Na atualização, a quantidade informada passa a ser o saldo restante do pacote.
+type PacoteSementeDTO struct {
+	GeneticaID         uint       `json:"genetica_id" binding:"required,gt=0"`
+	Breeder            string     `json:"breeder" binding:"required,max=100"`
+	Quantidade         int        `json:"quantidade" binding:"gte=0"`
+	DataAquisicao      *time.Time `json:"data_aquisicao"`
+	LocalArmazenamento string     `json:"local_armazenamento" binding:"max=100"`
+	NotasViabilidade   string     `json:"notas_viabilidade"`
+}
+
+// ConsultaPacotesSementeDTO filtra o banco de sementes do usuário
+type ConsultaPacotesSementeDTO struct {
+	GeneticaID  uint   `form:"genetica_id"`
+	Breeder     string `form:"breeder"`
+	ComSementes bool   `form:"com_sementes"`
+}
+
+// PlantioPacoteDTO cria plantas a partir das sementes do pacote. Sem nome, as plantas recebem
+// o nome da genética; com mais de uma planta, o nome é numerado.
+type PlantioPacoteDTO struct {
+	Quantidade    int        `json:"quantidade" binding:"required,gt=0,lte=100"`
+	Nome          string     `json:"nome" binding:"omitempty,min=3,max=240"`
+	Especie       string     `json:"especie" binding:"omitempty,oneof=sativa indica ruderalis"` // padrão: tipo da genética
+	AmbienteID    uint       `json:"ambiente_id" binding:"required,gt=0"`
+	MeioCultivoID uint       `json:"meio_cultivo_id" binding:"required,gt=0"`
+	DataPlantio   *time.Time `json:"data_plantio"` // padrão: agora
+	Notas         string     `json:"notas"`
+}
+
+// GerminacaoSementeDTO registra o resultado de uma germinação de sementes do pacote
+type GerminacaoSementeDTO struct {
+	Data        *time.Time `json:"data"` // padrão: agora
+	Semeadas    int        `json:"semeadas" binding:"required,gt=0"`
+	Germinadas  int        `json:"germinadas" binding:"gte=0,ltefield=Semeadas"`
+	Metodo      string     `json:"metodo" binding:"omitempty,oneof=papel_toalha copo_agua direto_substrato jiffy outro"`
+	Observacoes string     `json:"observacoes"`
+}
+
+// ConsultaTaxasGerminacaoDTO escolhe como as germinações são agrupadas
+type ConsultaTaxasGerminacaoDTO struct {
+	Agrupar string `form:"agrupar" binding:"omitempty,oneof=breeder genetica"` // padrão: genetica
+}
+
+// TaxaGerminacaoDTO é a taxa de germinação de um breeder ou de uma genética, em percentual
+type TaxaGerminacaoDTO struct {
+	Breeder     string  `json:"breeder,omitempty"`
+	GeneticaID  *uint   `json:"genetica_id,omitempty"`
+	Genetica    string  `json:"genetica,omitempty"`
+	Pacotes     int     `json:"pacotes"`
+	Germinacoes int     `json:"germinacoes"`
+	Semeadas    int     `json:"semeadas"`
+	Germinadas  int     `json:"germinadas"`
+	Taxa        float64 `json:"taxa"`
+}
@@ -0,0 +1,77 @@
+package dto
+
+// ItemTabelaNutricaoDTO é a dose de um produto numa semana do estágio
+type ItemTabelaNutricaoDTO struct {
+	Estagio string   `json:"estagio" binding:"required,oneof=germinacao plantula vegetativo floracao maturacao"`
+	Semana  int      `json:"semana" binding:"required,min=1,max=52"` // semana dentro do estágio
+	Produto string   `json:"produto" binding:"required,max=100"`
+	Dose    float64  `json:"dose" binding:"gte=0,lte=1000"`
+	Unidade string   `json:"unidade" binding:"required,oneof=ml_l g_l"`
+	ECAlvo  *float64 `json:"ec_alvo" binding:"omitempty,gt=0,lte=10"` // mS/cm somada pelos nutrientes da semana
+	PHAlvo  *float64 `json:"ph_alvo" binding:"omitempty,gte=0,lte=14"`
+}
+
+// TabelaNutricaoDTO representa a criação, atualização ou importação em JSON de uma tabela
+// de alimentação; os itens substituem os anteriores
+type TabelaNutricaoDTO struct {
+	Nome       string                  `json:"nome" binding:"required,max=100"`
+	Fabricante string                  `json:"fabricante" binding:"max=100"`
+	Descricao  string                  `json:"descricao"`
+	Itens      []ItemTabelaNutricaoDTO `json:"itens" binding:"required,min=1,max=1000,dive"`
+}
+
+// ImportacaoTabelaNutricaoDTO identifica a tabela importada de um CSV, que só traz os itens
+type ImportacaoTabelaNutricaoDTO struct {
+	Nome        string `form:"nome" binding:"required,max=100"`
+	Fabricante  string `form:"fabricante" binding:"max=100"`
+	Delimitador string `form:"delimitador" binding:"omitempty,oneof=virgula ponto_virgula tab"` // padrão: virgula
+	Decimal     string `form:"decimal" binding:"omitempty,oneof=ponto virgula"`                 // padrão: ponto
+}
+
+// SolucaoEstoqueDTO descreve a solução estoque concentrada de um produto
+type SolucaoEstoqueDTO struct {
+	Produto      string  `json:"produto" binding:"required,max=100"`
+	Concentracao float64 `json:"concentracao" binding:"required,gt=0"` // ml ou g do produto por litro de estoque
+}
+
+// CalculoMisturaDTO pede as quantidades de cada produto para preparar o reservatório. A semana
+// vem da planta (estágio atual) ou de estagio e semana informados.
+type CalculoMisturaDTO struct {
+	PlantaID     *uint               `json:"planta_id" binding:"omitempty,gt=0"`
+	Estagio      string              `json:"estagio" binding:"omitempty,oneof=germinacao plantula vegetativo floracao maturacao"`
+	Semana       int                 `json:"semana" binding:"omitempty,min=1,max=52"`
+	VolumeLitros float64             `json:"volume_litros" binding:"required,gt=0,lte=100000"`
+	ECAlvo       *float64            `json:"ec_alvo" binding:"omitempty,gt=0,lte=10"` // mS/cm da solução pronta
+	ECAguaBase   *float64            `json:"ec_agua_base" binding:"omitempty,gte=0,lte=5"`
+	PPMAguaBase  *float64            `json:"ppm_agua_base" binding:"omitempty,gte=0,lte=3500"`
+	EscalaPPM    int                 `json:"escala_ppm" binding:"omitempty,oneof=500 700"` // padrão: 500
+	Estoques     []SolucaoEstoqueDTO `json:"estoques" binding:"omitempty,max=30,dive"`
+}
+
+// ProdutoMisturaDTO é quanto adicionar de um produto ao reservatório
+type ProdutoMisturaDTO struct {
+	Produto           string   `json:"produto"`
+	DoseTabela        float64  `json:"dose_tabela"`        // por litro, como na tabela
+	Dose              float64  `json:"dose"`               // por litro, ajustada à EC alvo
+	Unidade           string   `json:"unidade"`            // ml_l ou g_l
+	Quantidade        float64  `json:"quantidade"`         // de produto no volume do reservatório
+	UnidadeQuantidade string   `json:"unidade_quantidade"` // ml ou g
+	MlSolucaoEstoque  *float64 `json:"ml_solucao_estoque,omitempty"`
+}
+
+// ResultadoMisturaDTO traz as quantidades da semana para o volume e a EC alvo pedidos
+type ResultadoMisturaDTO struct {
+	TabelaID     uint                `json:"tabela_id"`
+	Estagio      string              `json:"estagio"`
+	Semana       int                 `json:"semana"`        // semana do estágio pedida ou atual da planta
+	SemanaTabela int                 `json:"semana_tabela"` // semana da tabela usada: a última até a pedida
+	VolumeLitros float64             `json:"volume_litros"`
+	Fator        float64             `json:"fator"` // multiplicador aplicado às doses da tabela
+	ECAguaBase   float64             `json:"ec_agua_base"`
+	ECTabela     *float64            `json:"ec_tabela,omitempty"`
+	ECEstimada   *float64            `json:"ec_estimada,omitempty"`
+	PPMEstimado  *float64            `json:"ppm_estimado,omitempty"`
+	EscalaPPM    int                 `json:"escala_ppm"`
+	PHAlvo       *float64            `json:"ph_alvo,omitempty"`
+	Produtos     []ProdutoMisturaDTO `json:"produtos"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// TarefaDTO representa a criação ou atualização de uma tarefa de cuidado
+type TarefaDTO struct {
+	Tipo            string    `json:"tipo" binding:"required,max=50"` // regar, adubar, podar, transplantar, monitorar...
+	Descricao       string    `json:"descricao"`
+	DataAgendada    time.Time `json:"data_agendada" binding:"required"`
+	Prioridade      string    `json:"prioridade" binding:"omitempty,oneof=baixa media alta"` // padrão: media
+	PlantaID        *uint     `json:"planta_id" binding:"omitempty,gt=0"`                    // o ambiente da planta é usado quando ambiente_id não é informado
+	AmbienteID      *uint     `json:"ambiente_id" binding:"omitempty,gt=0"`
+	DiarioCultivoID *uint     `json:"diario_cultivo_id" binding:"omitempty,gt=0"`
+	Recorrente      bool      `json:"recorrente"`
+	FrequenciaDias  *int      `json:"frequencia_dias" binding:"required_if=Recorrente true,omitempty,gte=1,lte=365"`
+}
+
+// FotoTarefaDTO é uma foto já enviada anexada à conclusão da tarefa
+type FotoTarefaDTO struct {
+	URL       string `json:"url" binding:"required,url,max=255"`
+	Descricao string `json:"descricao"`
+}
+
+// ConclusaoTarefaDTO registra a execução de uma tarefa
+type ConclusaoTarefaDTO struct {
+	DataConclusao *time.Time      `json:"data_conclusao"` // padrão: agora
+	Notas         string          `json:"notas"`
+	Fotos         []FotoTarefaDTO `json:"fotos" binding:"omitempty,max=20,dive"`
+	// Consumos dá baixa no estoque dos insumos usados, como o vaso e o substrato de um transplante
+	Consumos []ItemConsumidoDTO `json:"consumos" binding:"omitempty,max=30,dive"`
+}
+
+// TarefaConcluidaDTO retorna a tarefa concluída e, nas recorrentes, a próxima ocorrência
+type TarefaConcluidaDTO struct {
+	Tarefa  entity.Tarefa  `json:"tarefa"`
+	Proxima *entity.Tarefa `json:"proxima,omitempty"`
+}
+
+// ConsultaTarefasDTO filtra a listagem de tarefas do usuário
+type ConsultaTarefasDTO struct {
+	PaginationParams
+	Status          string `form:"status" binding:"omitempty,oneof=pendente concluida atrasada"`
+	PlantaID        uint   `form:"planta_id"`
+	AmbienteID      uint   `form:"ambiente_id"`
+	DiarioCultivoID uint   `form:"diario_cultivo_id"`
+	De              string `form:"de" binding:"omitempty,datetime=2006-01-02"`  // data agendada a partir do dia
+	Ate             string `form:"ate" binding:"omitempty,datetime=2006-01-02"` // data agendada até o dia, inclusive
+}
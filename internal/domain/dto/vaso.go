@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// VasoDTO representa a criação ou atualização de um vaso
+type VasoDTO struct {
+	Nome          string  `json:"nome" binding:"required,max=100"`
+	Material      string  `json:"material" binding:"max=50"`      // plástico, cerâmica, tecido...
+	Volume        float64 `json:"volume" binding:"required,gt=0"` // em litros
+	Diametro      float64 `json:"diametro" binding:"gte=0"`       // em cm
+	Cor           string  `json:"cor" binding:"max=30"`
+	FurosDrenagem int     `json:"furos_drenagem" binding:"gte=0"`
+}
+
+// SubstratoDTO representa a criação ou atualização de uma mistura de substrato
+type SubstratoDTO struct {
+	Nome         string  `json:"nome" binding:"required,max=100"`
+	Composicao   string  `json:"composicao"`
+	PH           float64 `json:"ph" binding:"gte=0,lte=14"`
+	RetencaoAgua float64 `json:"retencao_agua" binding:"gte=0,lte=1"` // fração do volume retida como água
+}
+
+// RecipienteDTO define o vaso e o substrato atuais da planta sem registrar transplante
+type RecipienteDTO struct {
+	VasoID      *uint `json:"vaso_id" binding:"omitempty,gt=0"`
+	SubstratoID *uint `json:"substrato_id" binding:"omitempty,gt=0"`
+}
+
+// RecipientePlantaDTO é o vaso e o substrato em que a planta está
+type RecipientePlantaDTO struct {
+	PlantaID  uint              `json:"planta_id"`
+	Vaso      *entity.Vaso      `json:"vaso,omitempty"`
+	Substrato *entity.Substrato `json:"substrato,omitempty"`
+}
+
+// TransplanteDTO registra a troca de vaso ou de substrato da planta. O que não for informado
+// continua igual ao recipiente atual.
+type TransplanteDTO struct {
+	VasoID      *uint      `json:"vaso_id" binding:"omitempty,gt=0"`
+	SubstratoID *uint      `json:"substrato_id" binding:"omitempty,gt=0"`
+	Data        *time.Time `json:"data"` // padrão: agora
+	Observacoes string     `json:"observacoes"`
+	// Consumos dá baixa no estoque dos insumos usados, como o vaso e o substrato novos
+	Consumos []ItemConsumidoDTO `json:"consumos" binding:"omitempty,max=30,dive"`
+}
+
+// SugestaoRegaDTO sugere o volume de rega pelo vaso e pela retenção do substrato e compara com
+// as regas individuais desde que a planta está no vaso atual
+type SugestaoRegaDTO struct {
+	PlantaID             uint       `json:"planta_id"`
+	VolumeVasoLitros     float64    `json:"volume_vaso_litros"`
+	RetencaoAgua         float64    `json:"retencao_agua"`
+	RetencaoEstimada     bool       `json:"retencao_estimada"` // substrato sem retenção cadastrada
+	AguaRetidaLitros     float64    `json:"agua_retida_litros"`
+	VolumeMinimoLitros   float64    `json:"volume_minimo_litros"`   // repõe a água consumida
+	VolumeSugeridoLitros float64    `json:"volume_sugerido_litros"` // com escoamento pelos furos
+	NoVasoDesde          time.Time  `json:"no_vaso_desde"`
+	Regas                int        `json:"regas"`
+	MediaRegasLitros     *float64   `json:"media_regas_litros,omitempty"`
+	IntervaloMedioDias   *float64   `json:"intervalo_medio_dias,omitempty"`
+	UltimaRega           *time.Time `json:"ultima_rega,omitempty"`
+	Avaliacao            string     `json:"avaliacao,omitempty"` // abaixo, adequada ou acima do sugerido
+}
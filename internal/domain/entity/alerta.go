@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MetricaAlerta define as métricas que podem disparar alertas.
+type MetricaAlerta string
+
+const (
+	MetricaTemperatura  MetricaAlerta = "temperatura"
+	MetricaUmidade      MetricaAlerta = "umidade"
+	MetricaLuminosidade MetricaAlerta = "luminosidade"
+	MetricaCO2          MetricaAlerta = "co2"
+	MetricaUmidadeSolo  MetricaAlerta = "umidade_solo"
+	MetricaPPFD         MetricaAlerta = "ppfd"
+	MetricaVPDAr        MetricaAlerta = "vpd_ar"
+	MetricaVPDFolha     MetricaAlerta = "vpd_folha"
+)
+
+// ComparacaoAlerta define como a leitura é comparada com o limite.
+type ComparacaoAlerta string
+
+const (
+	ComparacaoMaior      ComparacaoAlerta = "maior"
+	ComparacaoMaiorIgual ComparacaoAlerta = "maior_igual"
+	ComparacaoMenor      ComparacaoAlerta = "menor"
+	ComparacaoMenorIgual ComparacaoAlerta = "menor_igual"
+)
+
+// Compara indica se o valor satisfaz a comparação com o limite.
+func (c ComparacaoAlerta) Compara(valor, limite float64) bool {
+	switch c {
+	case ComparacaoMaior:
+		return valor > limite
+	case ComparacaoMaiorIgual:
+		return valor >= limite
+	case ComparacaoMenor:
+		return valor < limite
+	case ComparacaoMenorIgual:
+		return valor <= limite
+	}
+	return false
+}
+
+// RegraAlerta define uma condição de ambiente que deve gerar um alerta.
+type RegraAlerta struct {
+	gorm.Model
+	AmbienteID     uint             `gorm:"not null;index" json:"ambiente_id"`
+	UsuarioID      uint             `gorm:"not null" json:"usuario_id"`
+	Nome           string           `gorm:"size:100;not null" json:"nome"`
+	Metrica        MetricaAlerta    `gorm:"size:30;not null" json:"metrica"`
+	Comparacao     ComparacaoAlerta `gorm:"size:20;not null" json:"comparacao"`
+	Limite         float64          `gorm:"not null" json:"limite"`
+	DuracaoMinutos int              `gorm:"not null;default:0" json:"duracao_minutos"` // tempo mínimo com a condição satisfeita
+	HoraInicio     string           `gorm:"size:5" json:"hora_inicio,omitempty"`       // HH:MM, vazio = dia todo
+	HoraFim        string           `gorm:"size:5" json:"hora_fim,omitempty"`          // HH:MM, pode virar a meia-noite
+	Estagios       string           `gorm:"size:200" json:"estagios,omitempty"`        // separados por vírgula, vazio = todos
+	Canais         string           `gorm:"size:100" json:"canais"`                    // separados por vírgula: lembrete, webhook, email
+	WebhookURL     string           `gorm:"size:500" json:"webhook_url,omitempty"`
+	Email          string           `gorm:"size:100" json:"email,omitempty"`
+	Ativa          bool             `gorm:"not null;default:true" json:"ativa"`
+
+	// CondicaoDesde guarda desde quando a condição está satisfeita, para avaliar a duração
+	CondicaoDesde *time.Time `json:"condicao_desde,omitempty"`
+}
+
+// StatusAlerta define o ciclo de vida de um alerta.
+type StatusAlerta string
+
+const (
+	StatusAlertaAberto      StatusAlerta = "aberto"
+	StatusAlertaReconhecido StatusAlerta = "reconhecido"
+	StatusAlertaResolvido   StatusAlerta = "resolvido"
+)
+
+// Alerta representa uma ocorrência de uma regra de alerta.
+type Alerta struct {
+	gorm.Model
+	RegraAlertaID uint          `gorm:"not null;index" json:"regra_alerta_id"`
+	AmbienteID    uint          `gorm:"not null;index" json:"ambiente_id"`
+	UsuarioID     uint          `gorm:"not null;index" json:"usuario_id"`
+	Status        StatusAlerta  `gorm:"size:20;not null;index" json:"status"`
+	Metrica       MetricaAlerta `gorm:"size:30;not null" json:"metrica"`
+	Mensagem      string        `gorm:"type:text" json:"mensagem"`
+	ValorInicial  float64       `json:"valor_inicial"`
+	ValorPico     float64       `json:"valor_pico"`
+	UltimoValor   float64       `json:"ultimo_valor"`
+	IniciadoEm    time.Time     `gorm:"not null" json:"iniciado_em"`
+	UltimaLeitura time.Time     `json:"ultima_leitura"`
+	ReconhecidoEm *time.Time    `json:"reconhecido_em,omitempty"`
+	ResolvidoEm   *time.Time    `json:"resolvido_em,omitempty"`
+
+	RegraAlerta *RegraAlerta `gorm:"foreignKey:RegraAlertaID" json:"regra_alerta,omitempty"`
+}
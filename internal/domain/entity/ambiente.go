@@ -13,8 +13,10 @@ type Ambiente struct {
 	Comprimento    float64      `gorm:"not null" json:"comprimento" validate:"required,gt=0"`                               // em centímetros
 	Altura         float64      `gorm:"not null" json:"altura" validate:"required,gt=0"`                                    // em centímetros
 	Largura        float64      `gorm:"not null" json:"largura" validate:"required,gt=0"`                                   // em centímetros
-	TempoExposicao int          `gorm:"not null" json:"tempo_exposicao" validate:"required,gt=0"`                           // em horas
+	TempoExposicao int          `gorm:"not null" json:"tempo_exposicao" validate:"required,gt=0"`                           // em horas; vale quando não há Fotoperiodo programado
 	Orientacao     string       `gorm:"size:20" json:"orientacao" validate:"required,oneof=norte sul leste oeste"`          // norte, sul, etc.
+	Latitude       *float64     `json:"latitude,omitempty"`                                                                 // ambientes externos; arredondada para preservar a privacidade
+	Longitude      *float64     `json:"longitude,omitempty"`                                                                // ambientes externos; arredondada para preservar a privacidade
 	Fotos          []Foto       `gorm:"foreignKey:AmbienteID" json:"fotos,omitempty"`                                       // Fotos do ambiente
 	Microclima     []Microclima `gorm:"foreignKey:AmbienteID" json:"microclima,omitempty"`                                  // Microclimas do ambiente
 	Plantas        []Planta     `gorm:"foreignKey:AmbienteID" json:"plantas,omitempty"`                                     // Plantas associadas a este ambiente
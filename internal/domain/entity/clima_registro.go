@@ -1,8 +1,23 @@
 package entity
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClimaRegistro é o resumo diário do tempo no local de um ambiente externo, obtido de um
+// provedor de clima. Data é a data de calendário do local (meia-noite UTC); cada ambiente tem
+// um registro por dia, atualizado a cada sincronização (previsões viram observações).
 type ClimaRegistro struct {
-	Temperatura  float64 `json:"temperatura"`  // °C
-	Umidade      float64 `json:"umidade"`      // %
-	Luminosidade float64 `json:"luminosidade"` // lux
-	Precipitacao float64 `json:"precipitacao"` // mm
+	gorm.Model
+	AmbienteID     uint      `gorm:"not null;uniqueIndex:idx_clima_ambiente_data" json:"ambiente_id"`
+	Data           time.Time `gorm:"type:date;not null;uniqueIndex:idx_clima_ambiente_data" json:"data"`
+	Temperatura    float64   `json:"temperatura"`              // média do dia, °C
+	TemperaturaMin float64   `json:"temperatura_min"`          // °C
+	TemperaturaMax float64   `json:"temperatura_max"`          // °C
+	Umidade        float64   `json:"umidade"`                  // média do dia, %
+	Precipitacao   float64   `json:"precipitacao"`             // mm
+	RadiacaoSolar  *float64  `json:"radiacao_solar,omitempty"` // MJ/m² no dia
+	Fonte          string    `gorm:"size:50" json:"fonte"`     // provedor que forneceu o registro
 }
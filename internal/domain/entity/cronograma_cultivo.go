@@ -0,0 +1,35 @@
+package entity
+
+import "gorm.io/gorm"
+
+// CronogramaCultivo é um conjunto reutilizável de tarefas organizadas por estágio e dia
+// (ex.: "adubar a cada 3 dias na 2ª semana de vega", "desfolhar no dia 21 da floração").
+type CronogramaCultivo struct {
+	gorm.Model
+	Nome      string           `gorm:"size:100;not null" json:"nome"`
+	Descricao string           `gorm:"type:text" json:"descricao"`
+	UsuarioID uint             `gorm:"not null" json:"usuario_id"`
+	Itens     []TarefaTemplate `gorm:"foreignKey:CronogramaID" json:"itens"`
+}
+
+// ItensDoEstagio retorna, na ordem do cronograma, os itens agendados no estágio.
+func (c CronogramaCultivo) ItensDoEstagio(estagio EstagioPlanta) []TarefaTemplate {
+	var itens []TarefaTemplate
+	for _, item := range c.Itens {
+		if item.Estagio == estagio {
+			itens = append(itens, item)
+		}
+	}
+	return itens
+}
+
+// AplicacaoCronograma liga um cronograma a uma planta. As tarefas geradas guardam a aplicação
+// para serem replanejadas quando a planta muda de estágio.
+type AplicacaoCronograma struct {
+	gorm.Model
+	CronogramaID    uint               `gorm:"not null" json:"cronograma_id"`
+	Cronograma      *CronogramaCultivo `json:"cronograma,omitempty"`
+	PlantaID        uint               `gorm:"not null" json:"planta_id"`
+	DiarioCultivoID *uint              `json:"diario_cultivo_id,omitempty"`
+	UsuarioID       uint               `gorm:"not null" json:"usuario_id"`
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Cruzamento é um projeto de melhoramento do usuário: o cruzamento de uma genética mãe com uma
+// genética pai (ou com ela mesma, nas gerações S) que dá origem a uma nova genética do catálogo.
+type Cruzamento struct {
+	gorm.Model
+	UsuarioID     uint          `gorm:"not null" json:"usuario_id"`
+	Nome          string        `gorm:"size:100;not null" json:"nome"`
+	MaeGeneticaID uint          `gorm:"not null" json:"mae_genetica_id"`
+	MaeGenetica   *Genetica     `gorm:"foreignKey:MaeGeneticaID" json:"mae_genetica,omitempty"`
+	PaiGeneticaID uint          `gorm:"not null" json:"pai_genetica_id"`
+	PaiGenetica   *Genetica     `gorm:"foreignKey:PaiGeneticaID" json:"pai_genetica,omitempty"`
+	GeneticaID    uint          `gorm:"not null" json:"genetica_id"` // genética resultante
+	Genetica      *Genetica     `gorm:"foreignKey:GeneticaID" json:"genetica,omitempty"`
+	Geracao       string        `gorm:"size:10;not null" json:"geracao"` // F1, F2..., BX1..., S1...
+	Objetivo      string        `gorm:"type:text" json:"objetivo,omitempty"`
+	Polinizacoes  []Polinizacao `gorm:"foreignKey:CruzamentoID" json:"polinizacoes,omitempty"`
+}
+
+// Polinizacao registra o pólen de uma planta doadora aplicado em uma planta mãe do cruzamento.
+// Sem planta doadora, o pólen veio de fora do cultivo ou de um estoque sem planta cadastrada.
+type Polinizacao struct {
+	gorm.Model
+	CruzamentoID    uint      `gorm:"not null" json:"cruzamento_id"`
+	PlantaMaeID     uint      `gorm:"not null" json:"planta_mae_id"`
+	PlantaMae       *Planta   `gorm:"foreignKey:PlantaMaeID" json:"planta_mae,omitempty"`
+	PlantaDoadoraID *uint     `json:"planta_doadora_id,omitempty"`
+	PlantaDoadora   *Planta   `gorm:"foreignKey:PlantaDoadoraID" json:"planta_doadora,omitempty"`
+	Data            time.Time `gorm:"not null" json:"data"`
+	Metodo          string    `gorm:"size:30" json:"metodo,omitempty"` // pincel, contato, polen_armazenado, aberta...
+	Observacoes     string    `gorm:"type:text" json:"observacoes,omitempty"`
+}
+
+func (Polinizacao) TableName() string {
+	return "polinizacoes"
+}
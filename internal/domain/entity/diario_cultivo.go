@@ -18,6 +18,6 @@ type DiarioCultivo struct {
 	// Relacionamentos
 	Usuario   Usuario
 	Plantas   []Planta   `gorm:"many2many:diario_cultivo_plantas;"`
-	Ambientes []Ambiente `gorm:"many2many:diario_cultivo_ambientes;"`
+	Ambientes []Ambiente `gorm:"many2many:diario_ambientes;"`
 	Registros []RegistroDiario
 }
\ No newline at end of file
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TipoEquipamento define as categorias de equipamentos de um ambiente.
+type TipoEquipamento string
+
+const (
+	EquipamentoIluminacao      TipoEquipamento = "iluminacao"
+	EquipamentoVentilacao      TipoEquipamento = "ventilacao"
+	EquipamentoExaustao        TipoEquipamento = "exaustao"
+	EquipamentoDesumidificador TipoEquipamento = "desumidificador"
+	EquipamentoUmidificador    TipoEquipamento = "umidificador"
+	EquipamentoAquecedor       TipoEquipamento = "aquecedor"
+	EquipamentoArCondicionado  TipoEquipamento = "ar_condicionado"
+	EquipamentoBomba           TipoEquipamento = "bomba"
+	EquipamentoOutro           TipoEquipamento = "outro"
+)
+
+// ModoFuncionamento define como o tempo ligado do equipamento é estimado.
+type ModoFuncionamento string
+
+const (
+	FuncionamentoContinuo    ModoFuncionamento = "continuo"    // 24h por dia
+	FuncionamentoFotoperiodo ModoFuncionamento = "fotoperiodo" // acompanha as horas de luz do ambiente
+	FuncionamentoHorasDia    ModoFuncionamento = "horas_dia"   // horas fixas por dia (timer)
+	FuncionamentoCiclo       ModoFuncionamento = "ciclo"       // percentual do dia ligado (termostato, umidostato)
+)
+
+// Equipamento é um consumidor de energia instalado em um ambiente.
+type Equipamento struct {
+	gorm.Model
+	AmbienteID      uint              `gorm:"not null;index" json:"ambiente_id"`
+	Nome            string            `gorm:"size:100;not null" json:"nome"`
+	Tipo            TipoEquipamento   `gorm:"size:30;not null" json:"tipo"`
+	PotenciaWatts   float64           `gorm:"not null" json:"potencia_watts"` // potência real na tomada, por unidade
+	Quantidade      int               `gorm:"not null;default:1" json:"quantidade"`
+	Modo            ModoFuncionamento `gorm:"size:20;not null" json:"modo"`
+	HorasDia        *float64          `json:"horas_dia,omitempty"`        // modo horas_dia
+	CicloPercentual *float64          `json:"ciclo_percentual,omitempty"` // modo ciclo
+	InstaladoEm     *time.Time        `json:"instalado_em,omitempty"`     // vazio = desde sempre
+	RemovidoEm      *time.Time        `json:"removido_em,omitempty"`      // vazio = ainda em uso
+}
+
+// HorasLigado estima as horas de funcionamento em um dia com as horas de luz informadas.
+func (e Equipamento) HorasLigado(horasLuz float64) float64 {
+	switch e.Modo {
+	case FuncionamentoContinuo:
+		return 24
+	case FuncionamentoFotoperiodo:
+		return horasLuz
+	case FuncionamentoHorasDia:
+		if e.HorasDia != nil {
+			return *e.HorasDia
+		}
+	case FuncionamentoCiclo:
+		if e.CicloPercentual != nil {
+			return 24 * *e.CicloPercentual / 100
+		}
+	}
+	return 0
+}
+
+// EmUsoEntre indica se o equipamento estava instalado em algum momento do intervalo [inicio, fim).
+func (e Equipamento) EmUsoEntre(inicio, fim time.Time) bool {
+	if e.InstaladoEm != nil && !e.InstaladoEm.Before(fim) {
+		return false
+	}
+	return e.RemovidoEm == nil || e.RemovidoEm.After(inicio)
+}
@@ -6,11 +6,31 @@ import (
 	"gorm.io/gorm"
 )
 
+// EstagioPlanta define os estágios de crescimento de uma planta.
+type EstagioPlanta string
+
+const (
+	EstagioGerminacao EstagioPlanta = "germinacao"
+	EstagioPlantula   EstagioPlanta = "plantula"
+	EstagioVegetativo EstagioPlanta = "vegetativo"
+	EstagioFloracao   EstagioPlanta = "floracao"
+	EstagioMaturacao  EstagioPlanta = "maturacao"
+)
+
+// Valid indica se o estágio é um dos estágios conhecidos.
+func (e EstagioPlanta) Valid() bool {
+	switch e {
+	case EstagioGerminacao, EstagioPlantula, EstagioVegetativo, EstagioFloracao, EstagioMaturacao:
+		return true
+	}
+	return false
+}
+
 // EstagioCrescimento representa um estágio da planta
 type EstagioCrescimento struct {
 	gorm.Model
-	PlantaID   uint       `json:"planta_id"`
-	Estagio    string     `gorm:"size:100;not null" json:"estagio"`
-	DataInicio time.Time  `gorm:"not null" json:"data_inicio"`
-	DataFim    *time.Time `json:"data_fim,omitempty"`
+	PlantaID   uint          `json:"planta_id"`
+	Estagio    EstagioPlanta `gorm:"size:100;not null" json:"estagio"`
+	DataInicio time.Time     `gorm:"not null" json:"data_inicio"`
+	DataFim    *time.Time    `json:"data_fim,omitempty"`
 }
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CategoriaEstoque agrupa os insumos do inventário.
+type CategoriaEstoque string
+
+const (
+	CategoriaEstoqueNutriente CategoriaEstoque = "nutriente"
+	CategoriaEstoqueSubstrato CategoriaEstoque = "substrato"
+	CategoriaEstoqueVaso      CategoriaEstoque = "vaso"
+	CategoriaEstoqueDefensivo CategoriaEstoque = "defensivo" // pesticidas, fungicidas e afins
+	CategoriaEstoqueSemente   CategoriaEstoque = "semente"
+	CategoriaEstoqueOutro     CategoriaEstoque = "outro"
+)
+
+// UnidadeEstoque é a unidade em que a quantidade do item é controlada.
+type UnidadeEstoque string
+
+const (
+	UnidadeEstoqueMl      UnidadeEstoque = "ml"
+	UnidadeEstoqueLitro   UnidadeEstoque = "l"
+	UnidadeEstoqueGrama   UnidadeEstoque = "g"
+	UnidadeEstoqueKg      UnidadeEstoque = "kg"
+	UnidadeEstoqueUnidade UnidadeEstoque = "un"
+)
+
+// TipoMovimentacaoEstoque define o efeito da movimentação na quantidade do item.
+type TipoMovimentacaoEstoque string
+
+const (
+	MovimentacaoEstoqueCompra  TipoMovimentacaoEstoque = "compra"
+	MovimentacaoEstoqueConsumo TipoMovimentacaoEstoque = "consumo"
+	MovimentacaoEstoqueAjuste  TipoMovimentacaoEstoque = "ajuste" // contagem do inventário
+)
+
+// OrigemMovimentacaoEstoque identifica o que gerou a movimentação.
+type OrigemMovimentacaoEstoque string
+
+const (
+	OrigemEstoqueManual      OrigemMovimentacaoEstoque = "manual"
+	OrigemEstoqueRega        OrigemMovimentacaoEstoque = "rega"
+	OrigemEstoqueTarefa      OrigemMovimentacaoEstoque = "tarefa"
+	OrigemEstoqueTransplante OrigemMovimentacaoEstoque = "transplante"
+	OrigemEstoqueTratamento  OrigemMovimentacaoEstoque = "tratamento"
+)
+
+// ItemEstoque é um insumo do inventário do usuário. CustoMedio é o custo por unidade,
+// recalculado a cada compra pela média ponderada.
+type ItemEstoque struct {
+	gorm.Model
+	UsuarioID     uint             `gorm:"not null" json:"usuario_id"`
+	Nome          string           `gorm:"size:100;not null" json:"nome"`
+	Categoria     CategoriaEstoque `gorm:"size:20;not null" json:"categoria"`
+	Fabricante    string           `gorm:"size:100" json:"fabricante,omitempty"`
+	Unidade       UnidadeEstoque   `gorm:"size:5;not null" json:"unidade"`
+	Quantidade    float64          `gorm:"not null;default:0" json:"quantidade"`
+	EstoqueMinimo *float64         `json:"estoque_minimo,omitempty"` // abaixo dele o usuário é notificado
+	CustoMedio    float64          `gorm:"not null;default:0" json:"custo_medio"`
+	Validade      *time.Time       `json:"validade,omitempty"`
+	Observacoes   string           `gorm:"type:text" json:"observacoes,omitempty"`
+	// AlertaEstoqueEm evita repetir o aviso de estoque baixo até o item ser reposto
+	AlertaEstoqueEm *time.Time `json:"alerta_estoque_em,omitempty"`
+}
+
+func (ItemEstoque) TableName() string {
+	return "itens_estoque"
+}
+
+// AbaixoDoMinimo indica se a quantidade chegou ao estoque mínimo definido.
+func (i ItemEstoque) AbaixoDoMinimo() bool {
+	return i.EstoqueMinimo != nil && i.Quantidade <= *i.EstoqueMinimo
+}
+
+// MovimentacaoEstoque registra uma entrada ou saída do item. Quantidade é positiva nas
+// entradas e negativa nas saídas; CustoTotal é o valor pago na compra ou o custo médio do
+// que foi consumido.
+type MovimentacaoEstoque struct {
+	gorm.Model
+	ItemID          uint                      `gorm:"not null" json:"item_id"`
+	Item            *ItemEstoque              `gorm:"foreignKey:ItemID" json:"item,omitempty"`
+	UsuarioID       uint                      `gorm:"not null" json:"usuario_id"`
+	Tipo            TipoMovimentacaoEstoque   `gorm:"size:20;not null" json:"tipo"`
+	Origem          OrigemMovimentacaoEstoque `gorm:"size:20;not null" json:"origem"`
+	OrigemID        *uint                     `json:"origem_id,omitempty"` // rega ou tarefa que gerou o consumo
+	Data            time.Time                 `gorm:"not null" json:"data"`
+	Quantidade      float64                   `gorm:"not null" json:"quantidade"`
+	CustoTotal      float64                   `gorm:"not null;default:0" json:"custo_total"`
+	PlantaID        *uint                     `json:"planta_id,omitempty"`
+	DiarioCultivoID *uint                     `json:"diario_cultivo_id,omitempty"`
+	Fornecedor      string                    `gorm:"size:100" json:"fornecedor,omitempty"`
+	Observacoes     string                    `gorm:"type:text" json:"observacoes,omitempty"`
+}
+
+func (MovimentacaoEstoque) TableName() string {
+	return "movimentacoes_estoque"
+}
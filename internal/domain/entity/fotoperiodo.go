@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Fotoperiodo é a programação de luz de um ambiente a partir de VigenteDesde. As programações
+// não são sobrescritas: a vigente em um dia é a mais recente que começou até o fim desse dia,
+// o que mantém o histórico e permite agendar a virada (ex.: 18/6 → 12/12) com antecedência.
+type Fotoperiodo struct {
+	gorm.Model
+	AmbienteID   uint      `gorm:"not null;index" json:"ambiente_id"`
+	Nome         string    `gorm:"size:100" json:"nome,omitempty"`
+	HoraLigar    string    `gorm:"size:5;not null" json:"hora_ligar"`       // HH:MM (horário local)
+	HoraDesligar string    `gorm:"size:5;not null" json:"hora_desligar"`    // HH:MM, pode virar a meia-noite; igual a HoraLigar = 24h
+	RampaMinutos int       `gorm:"not null;default:0" json:"rampa_minutos"` // duração do nascer e do pôr do sol simulados
+	VigenteDesde time.Time `gorm:"not null;index" json:"vigente_desde"`
+}
+
+// HorasLuz retorna a duração do período com as luzes ligadas, em horas.
+func (f Fotoperiodo) HorasLuz() float64 {
+	ligar, okL := minutosHoraDia(f.HoraLigar)
+	desligar, okD := minutosHoraDia(f.HoraDesligar)
+	if !okL || !okD {
+		return 0
+	}
+	minutos := (desligar - ligar + 24*60) % (24 * 60)
+	if minutos == 0 {
+		minutos = 24 * 60
+	}
+	return float64(minutos) / 60
+}
+
+// HorasLuzEfetivas desconta as rampas, que entregam em média metade da intensidade:
+// as duas rampas juntas equivalem a RampaMinutos de luz plena a menos.
+func (f Fotoperiodo) HorasLuzEfetivas() float64 {
+	return f.HorasLuz() - float64(f.RampaMinutos)/60
+}
+
+// minutosHoraDia converte HH:MM em minutos desde a meia-noite
+func minutosHoraDia(hora string) (int, bool) {
+	t, err := time.Parse("15:04", hora)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
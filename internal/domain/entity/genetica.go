@@ -13,4 +13,10 @@ type Genetica struct {
 	Origem          string   `gorm:"size:100;not null" json:"origem" validate:"required, min=2, max=100"`                            // Origem da genética
 	Caracteristicas string   `gorm:"type:text" json:"caracteristicas,omitempty"`
 	Plantas         []Planta `gorm:"foreignKey:GeneticaID"`
+	// MaeID e PaiID apontam para as genéticas de origem; em autocruzamentos (S1) são a mesma
+	MaeID   *uint     `json:"maeId,omitempty"`
+	Mae     *Genetica `gorm:"foreignKey:MaeID" json:"mae,omitempty"`
+	PaiID   *uint     `json:"paiId,omitempty"`
+	Pai     *Genetica `gorm:"foreignKey:PaiID" json:"pai,omitempty"`
+	Geracao string    `gorm:"size:10" json:"geracao,omitempty"` // Geração filial, como F1, F2, BX1, S1 ou IBL
 }
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LevantamentoPPFD é um mapeamento de luz do ambiente: medições de PPFD em vários pontos da
+// área de cultivo com a luminária em uma altura, usado para avaliar a uniformidade e
+// comparar ajustes de posição, altura e potência ao longo do tempo.
+type LevantamentoPPFD struct {
+	gorm.Model
+	AmbienteID         uint        `gorm:"not null;index" json:"ambiente_id"`
+	Data               time.Time   `gorm:"not null" json:"data"`
+	AlturaLuminariaCm  float64     `gorm:"not null" json:"altura_luminaria_cm"` // distância da luminária ao topo das plantas
+	PotenciaPercentual *float64    `json:"potencia_percentual,omitempty"`       // ajuste do dimmer
+	Observacoes        string      `gorm:"type:text" json:"observacoes,omitempty"`
+	Pontos             []PontoPPFD `gorm:"foreignKey:LevantamentoID" json:"pontos,omitempty"`
+}
+
+// PontoPPFD é uma medição do levantamento, com coordenadas em cm na planta baixa do ambiente.
+type PontoPPFD struct {
+	gorm.Model
+	LevantamentoID uint    `gorm:"not null;index" json:"levantamento_id"`
+	X              float64 `gorm:"not null" json:"x"`
+	Y              float64 `gorm:"not null" json:"y"`
+	PPFD           float64 `gorm:"not null" json:"ppfd"` // µmol/m²/s
+}
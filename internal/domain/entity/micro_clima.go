@@ -8,9 +8,38 @@ import (
 
 type Microclima struct {
 	gorm.Model
-	AmbienteID   uint      `json:"ambiente_id"`
-	DataMedicao  time.Time `json:"data_medicao"`
-	Temperatura  float64   `json:"temperatura"`  // °C
-	Umidade      float64   `json:"umidade"`      // %
-	Luminosidade float64   `json:"luminosidade"` // lux
+	AmbienteID   uint      `gorm:"index:idx_micro_climas_ambiente_data,priority:1" json:"ambiente_id"`
+	DataMedicao  time.Time `gorm:"index:idx_micro_climas_ambiente_data,priority:2" json:"data_medicao"`
+	Temperatura  float64   `json:"temperatura"`            // °C
+	Umidade      float64   `json:"umidade"`                // %
+	Luminosidade float64   `json:"luminosidade"`           // lux
+	CO2          *float64  `json:"co2,omitempty"`          // ppm
+	UmidadeSolo  *float64  `json:"umidade_solo,omitempty"` // %
+	PPFD         *float64  `json:"ppfd,omitempty"`         // µmol/m²/s
+	PosicaoX     *float64  `json:"posicao_x,omitempty"`    // cm; ponto da medição na planta baixa do ambiente
+	PosicaoY     *float64  `json:"posicao_y,omitempty"`    // cm
+}
+
+// MicroclimaAgregado representa uma janela de tempo agregada de leituras de microclima.
+type MicroclimaAgregado struct {
+	Inicio          time.Time `json:"inicio"`
+	Leituras        int64     `json:"leituras"`
+	TemperaturaMin  float64   `json:"temperatura_min"`
+	TemperaturaMax  float64   `json:"temperatura_max"`
+	TemperaturaMed  float64   `json:"temperatura_med"`
+	UmidadeMin      float64   `json:"umidade_min"`
+	UmidadeMax      float64   `json:"umidade_max"`
+	UmidadeMed      float64   `json:"umidade_med"`
+	LuminosidadeMin float64   `json:"luminosidade_min"`
+	LuminosidadeMax float64   `json:"luminosidade_max"`
+	LuminosidadeMed float64   `json:"luminosidade_med"`
+	CO2Min          *float64  `json:"co2_min,omitempty"`
+	CO2Max          *float64  `json:"co2_max,omitempty"`
+	CO2Med          *float64  `json:"co2_med,omitempty"`
+	UmidadeSoloMin  *float64  `json:"umidade_solo_min,omitempty"`
+	UmidadeSoloMax  *float64  `json:"umidade_solo_max,omitempty"`
+	UmidadeSoloMed  *float64  `json:"umidade_solo_med,omitempty"`
+	PPFDMin         *float64  `json:"ppfd_min,omitempty"`
+	PPFDMax         *float64  `json:"ppfd_max,omitempty"`
+	PPFDMed         *float64  `json:"ppfd_med,omitempty"`
 }
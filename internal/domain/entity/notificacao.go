@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CategoriaNotificacao agrupa as notificações da central; o usuário pode desativar cada categoria.
+type CategoriaNotificacao string
+
+const (
+	CategoriaNotificacaoAlerta   CategoriaNotificacao = "alerta"   // alertas de ambiente
+	CategoriaNotificacaoLembrete CategoriaNotificacao = "lembrete" // lembretes agendados pelo usuário
+	CategoriaNotificacaoTarefa   CategoriaNotificacao = "tarefa"   // tarefas a vencer ou atrasadas
+	CategoriaNotificacaoCultivo  CategoriaNotificacao = "cultivo"  // sugestões de manejo, como a virada do fotoperíodo
+	CategoriaNotificacaoEstoque  CategoriaNotificacao = "estoque"  // insumos abaixo do estoque mínimo
+	CategoriaNotificacaoConvite  CategoriaNotificacao = "convite"  // convites de colaboração
+	CategoriaNotificacaoSistema  CategoriaNotificacao = "sistema"
+)
+
+// Notificacao é um item da central de notificações do usuário. PlantaID, DiarioCultivoID e
+// AmbienteID apontam para o registro relacionado; Link é o caminho do mais específico deles.
+type Notificacao struct {
+	gorm.Model
+	UsuarioID       uint                 `gorm:"not null" json:"usuario_id"`
+	Categoria       CategoriaNotificacao `gorm:"size:20;not null" json:"categoria"`
+	Titulo          string               `gorm:"size:200;not null" json:"titulo"`
+	Mensagem        string               `gorm:"type:text" json:"mensagem"`
+	PlantaID        *uint                `json:"planta_id,omitempty"`
+	DiarioCultivoID *uint                `json:"diario_cultivo_id,omitempty"`
+	AmbienteID      *uint                `json:"ambiente_id,omitempty"`
+	Link            string               `gorm:"size:200" json:"link,omitempty"` // ex.: /plantas/10
+	Lida            bool                 `json:"lida"`
+	LidaEm          *time.Time           `json:"lida_em,omitempty"`
+}
+
+func (Notificacao) TableName() string {
+	return "notificacoes"
+}
@@ -33,6 +33,8 @@ type Planta struct {
 	DataColheita *time.Time   `json:"data_colheita,omitempty" validate:"omitempty"`
 	Status       PlantaStatus `gorm:"size:100;not null" json:"status" validate:"required,oneof=ativa colhida morta"`
 	Notas        *string      `gorm:"type:text" json:"notas,omitempty"`
+	// PesoSecoGramas é o rendimento seco da colheita, usado nos custos por grama
+	PesoSecoGramas *float64 `json:"peso_seco_gramas,omitempty"`
 
 	FotoCapaID    *uint       `json:"foto_capa_id,omitempty"`
 	FotoCapa      *Foto       `gorm:"foreignKey:FotoCapaID" json:"foto_capa,omitempty"`
@@ -44,6 +46,13 @@ type Planta struct {
 	Ambiente      Ambiente    `gorm:"foreignKey:AmbienteID" json:"ambiente"`
 	PlantaMaeID   *uint       `json:"planta_mae_id,omitempty"`
 	PlantaMae     *Planta     `gorm:"foreignKey:PlantaMaeID" json:"planta_mae,omitempty"`
+	// PacoteSementeID é o pacote do banco de sementes de onde a planta saiu
+	PacoteSementeID *uint `json:"pacote_semente_id,omitempty"`
+	// VasoID e SubstratoID são o recipiente atual; os anteriores ficam nos transplantes
+	VasoID      *uint      `json:"vaso_id,omitempty"`
+	Vaso        *Vaso      `gorm:"foreignKey:VasoID" json:"vaso,omitempty"`
+	SubstratoID *uint      `json:"substrato_id,omitempty"`
+	Substrato   *Substrato `gorm:"foreignKey:SubstratoID" json:"substrato,omitempty"`
 
 	UsuarioID uint    `gorm:"not null" json:"usuario_id"`
 	Usuario   Usuario `gorm:"foreignKey:UsuarioID" json:"usuario"`
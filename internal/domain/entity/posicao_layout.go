@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// FormatoPosicao define a área ocupada por um item na planta baixa do ambiente.
+type FormatoPosicao string
+
+const (
+	FormatoCircular   FormatoPosicao = "circular"   // LarguraCm é o diâmetro (vasos redondos)
+	FormatoRetangular FormatoPosicao = "retangular" // LarguraCm no eixo X, ProfundidadeCm no eixo Y
+)
+
+// PosicaoLayout posiciona uma planta e/ou um vaso na planta baixa do ambiente. As coordenadas
+// são do centro do item, em centímetros, a partir do canto (0, 0): o eixo X acompanha o
+// Comprimento do ambiente e o eixo Y a Largura.
+type PosicaoLayout struct {
+	gorm.Model
+	AmbienteID     uint           `gorm:"not null;index" json:"ambiente_id"`
+	PlantaID       *uint          `json:"planta_id,omitempty"` // única por planta (índice parcial na migração)
+	Planta         *Planta        `gorm:"foreignKey:PlantaID" json:"planta,omitempty"`
+	VasoID         *uint          `json:"vaso_id,omitempty"`
+	Vaso           *Vaso          `gorm:"foreignKey:VasoID" json:"vaso,omitempty"`
+	Rotulo         string         `gorm:"size:50" json:"rotulo,omitempty"`
+	X              float64        `gorm:"not null" json:"x"` // cm
+	Y              float64        `gorm:"not null" json:"y"` // cm
+	Formato        FormatoPosicao `gorm:"size:20;not null" json:"formato"`
+	LarguraCm      float64        `gorm:"not null" json:"largura_cm"`
+	ProfundidadeCm float64        `gorm:"not null" json:"profundidade_cm"` // igual a LarguraCm nos circulares
+}
+
+// Area retorna a área ocupada pelo item, em cm².
+func (p PosicaoLayout) Area() float64 {
+	if p.Formato == FormatoCircular {
+		raio := p.LarguraCm / 2
+		return math.Pi * raio * raio
+	}
+	return p.LarguraCm * p.ProfundidadeCm
+}
+
+// CabeEm indica se o item fica inteiro dentro de um ambiente com as dimensões informadas.
+func (p PosicaoLayout) CabeEm(comprimento, largura float64) bool {
+	meiaLargura, meiaProfundidade := p.LarguraCm/2, p.ProfundidadeCm/2
+	return p.X-meiaLargura >= 0 && p.X+meiaLargura <= comprimento &&
+		p.Y-meiaProfundidade >= 0 && p.Y+meiaProfundidade <= largura
+}
+
+// Sobrepoe indica se os dois itens ocupam parte da mesma área; itens que apenas se
+// encostam não se sobrepõem.
+func (p PosicaoLayout) Sobrepoe(outra PosicaoLayout) bool {
+	const tolerancia = 1e-6
+	dx, dy := math.Abs(p.X-outra.X), math.Abs(p.Y-outra.Y)
+
+	switch {
+	case p.Formato == FormatoCircular && outra.Formato == FormatoCircular:
+		return math.Hypot(dx, dy) < (p.LarguraCm+outra.LarguraCm)/2-tolerancia
+	case p.Formato == FormatoCircular:
+		return circuloSobrepoeRetangulo(dx, dy, p.LarguraCm/2, outra.LarguraCm/2, outra.ProfundidadeCm/2, tolerancia)
+	case outra.Formato == FormatoCircular:
+		return circuloSobrepoeRetangulo(dx, dy, outra.LarguraCm/2, p.LarguraCm/2, p.ProfundidadeCm/2, tolerancia)
+	default:
+		return dx < (p.LarguraCm+outra.LarguraCm)/2-tolerancia && dy < (p.ProfundidadeCm+outra.ProfundidadeCm)/2-tolerancia
+	}
+}
+
+// circuloSobrepoeRetangulo compara a distância entre o centro do círculo e o ponto mais
+// próximo do retângulo; dx e dy são as distâncias entre os centros.
+func circuloSobrepoeRetangulo(dx, dy, raio, meiaLargura, meiaProfundidade, tolerancia float64) bool {
+	px := math.Max(dx-meiaLargura, 0)
+	py := math.Max(dy-meiaProfundidade, 0)
+	return math.Hypot(px, py) < raio-tolerancia
+}
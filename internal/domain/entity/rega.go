@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UnidadeDose define como a dose de um nutriente é medida por litro de solução.
+type UnidadeDose string
+
+const (
+	UnidadeDoseMlPorLitro UnidadeDose = "ml_l"
+	UnidadeDoseGPorLitro  UnidadeDose = "g_l"
+)
+
+// ReceitaNutriente é uma mistura de nutrientes reutilizável, com as doses por litro de solução
+// e os alvos de EC e pH da solução pronta.
+type ReceitaNutriente struct {
+	gorm.Model
+	UsuarioID uint                   `gorm:"not null" json:"usuario_id"`
+	Nome      string                 `gorm:"size:100;not null" json:"nome"`
+	Descricao string                 `gorm:"type:text" json:"descricao,omitempty"`
+	ECAlvo    *float64               `json:"ec_alvo,omitempty"` // mS/cm
+	PHAlvo    *float64               `json:"ph_alvo,omitempty"`
+	Itens     []ItemReceitaNutriente `gorm:"foreignKey:ReceitaID" json:"itens"`
+}
+
+func (ReceitaNutriente) TableName() string {
+	return "receitas_nutriente"
+}
+
+// ItemReceitaNutriente é a dose de um nutriente na receita.
+type ItemReceitaNutriente struct {
+	gorm.Model
+	ReceitaID uint        `gorm:"not null" json:"receita_id"`
+	Nutriente string      `gorm:"size:100;not null" json:"nutriente"`
+	Dose      float64     `gorm:"not null" json:"dose"`
+	Unidade   UnidadeDose `gorm:"size:10;not null" json:"unidade"`
+}
+
+func (ItemReceitaNutriente) TableName() string {
+	return "itens_receita_nutriente"
+}
+
+// Rega é uma irrigação ou fertirrigação de uma planta ou do lote de plantas de um diário de
+// cultivo. No lote, o volume é o total aplicado, dividido entre as plantas do diário.
+type Rega struct {
+	gorm.Model
+	UsuarioID       uint      `gorm:"not null" json:"usuario_id"`
+	PlantaID        *uint     `json:"planta_id,omitempty"`
+	DiarioCultivoID *uint     `json:"diario_cultivo_id,omitempty"` // rega do lote
+	Data            time.Time `gorm:"not null" json:"data"`
+	VolumeLitros    float64   `gorm:"not null" json:"volume_litros"`
+	// Solução de entrada: EC em mS/cm e PPM na escala informada (500 ou 700)
+	PHEntrada  *float64 `json:"ph_entrada,omitempty"`
+	ECEntrada  *float64 `json:"ec_entrada,omitempty"`
+	PPMEntrada *float64 `json:"ppm_entrada,omitempty"`
+	EscalaPPM  int      `json:"escala_ppm,omitempty"`
+	// Drenagem (runoff) medida depois da rega
+	PHRunoff        *float64        `json:"ph_runoff,omitempty"`
+	ECRunoff        *float64        `json:"ec_runoff,omitempty"`
+	TemperaturaAgua *float64        `json:"temperatura_agua,omitempty"` // °C
+	ReceitaID       *uint           `json:"receita_id,omitempty"`
+	Nutrientes      []NutrienteRega `gorm:"foreignKey:RegaID" json:"nutrientes"`
+	Observacoes     string          `gorm:"type:text" json:"observacoes,omitempty"`
+}
+
+// NutrienteRega é a dose por litro de um nutriente usada na rega.
+type NutrienteRega struct {
+	gorm.Model
+	RegaID    uint        `gorm:"not null" json:"rega_id"`
+	Nutriente string      `gorm:"size:100;not null" json:"nutriente"`
+	Dose      float64     `gorm:"not null" json:"dose"`
+	Unidade   UnidadeDose `gorm:"size:10;not null" json:"unidade"`
+}
+
+func (NutrienteRega) TableName() string {
+	return "nutrientes_rega"
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TipoManutencaoReservatorio define o que foi feito com a solução do reservatório.
+type TipoManutencaoReservatorio string
+
+const (
+	ManutencaoReservatorioTroca     TipoManutencaoReservatorio = "troca"     // solução descartada e preparada de novo
+	ManutencaoReservatorioCompletar TipoManutencaoReservatorio = "completar" // reposição do que as plantas consumiram
+)
+
+// TipoTarefaTrocaReservatorio é o tipo das tarefas de troca geradas pelo agendador
+const TipoTarefaTrocaReservatorio = "trocar_reservatorio"
+
+// Reservatorio é o tanque de solução nutritiva de um sistema hidropônico ou aeropônico.
+// Com IntervaloTrocaDias, o agendador cria a tarefa de troca a partir da última troca.
+type Reservatorio struct {
+	gorm.Model
+	UsuarioID          uint       `gorm:"not null" json:"usuario_id"`
+	Nome               string     `gorm:"size:100;not null" json:"nome"`
+	VolumeLitros       float64    `gorm:"not null" json:"volume_litros"`
+	AmbienteID         *uint      `json:"ambiente_id,omitempty"`
+	IntervaloTrocaDias int        `gorm:"not null;default:0" json:"intervalo_troca_dias"` // 0 desativa as tarefas de troca
+	UltimaTroca        *time.Time `json:"ultima_troca,omitempty"`
+	Plantas            []Planta   `gorm:"many2many:reservatorio_plantas;" json:"plantas,omitempty"`
+}
+
+// InicioCiclo é o início da solução atual: a última troca ou, sem trocas, a criação do reservatório.
+func (r Reservatorio) InicioCiclo() time.Time {
+	if r.UltimaTroca != nil {
+		return *r.UltimaTroca
+	}
+	return r.CreatedAt
+}
+
+// ManutencaoReservatorio registra uma troca de solução ou uma reposição (completar).
+type ManutencaoReservatorio struct {
+	gorm.Model
+	ReservatorioID uint                       `gorm:"not null" json:"reservatorio_id"`
+	Tipo           TipoManutencaoReservatorio `gorm:"size:20;not null" json:"tipo"`
+	Data           time.Time                  `gorm:"not null" json:"data"`
+	VolumeLitros   float64                    `gorm:"not null" json:"volume_litros"` // água ou solução adicionada
+	EC             *float64                   `json:"ec,omitempty"`                  // mS/cm depois da manutenção
+	PH             *float64                   `json:"ph,omitempty"`
+	Observacoes    string                     `gorm:"type:text" json:"observacoes,omitempty"`
+}
+
+func (ManutencaoReservatorio) TableName() string {
+	return "manutencoes_reservatorio"
+}
+
+// LeituraReservatorio é uma medição periódica da solução do reservatório.
+type LeituraReservatorio struct {
+	gorm.Model
+	ReservatorioID     uint      `gorm:"not null" json:"reservatorio_id"`
+	Data               time.Time `gorm:"not null" json:"data"`
+	EC                 *float64  `json:"ec,omitempty"` // mS/cm
+	PH                 *float64  `json:"ph,omitempty"`
+	TemperaturaAgua    *float64  `json:"temperatura_agua,omitempty"`    // °C
+	OxigenioDissolvido *float64  `json:"oxigenio_dissolvido,omitempty"` // mg/L
+	NivelLitros        *float64  `json:"nivel_litros,omitempty"`
+}
+
+func (LeituraReservatorio) TableName() string {
+	return "leituras_reservatorio"
+}
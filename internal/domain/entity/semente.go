@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PacoteSemente é um pacote de sementes do banco do usuário. QuantidadeRestante diminui
+// quando plantas são criadas a partir do pacote e quando sementes não germinam.
+type PacoteSemente struct {
+	gorm.Model
+	UsuarioID          uint       `gorm:"not null" json:"usuario_id"`
+	GeneticaID         uint       `gorm:"not null" json:"genetica_id"`
+	Genetica           *Genetica  `gorm:"foreignKey:GeneticaID" json:"genetica,omitempty"`
+	Breeder            string     `gorm:"size:100;not null" json:"breeder"`
+	QuantidadeInicial  int        `gorm:"not null" json:"quantidade_inicial"`
+	QuantidadeRestante int        `gorm:"not null" json:"quantidade_restante"`
+	DataAquisicao      *time.Time `json:"data_aquisicao,omitempty"`
+	LocalArmazenamento string     `gorm:"size:100" json:"local_armazenamento,omitempty"`
+	NotasViabilidade   string     `gorm:"type:text" json:"notas_viabilidade,omitempty"`
+	// CruzamentoID e PolinizacaoID indicam lotes colhidos de cruzamentos do próprio usuário
+	CruzamentoID  *uint `json:"cruzamento_id,omitempty"`
+	PolinizacaoID *uint `json:"polinizacao_id,omitempty"`
+}
+
+func (PacoteSemente) TableName() string {
+	return "pacotes_sementes"
+}
+
+// GerminacaoSemente registra o resultado de uma germinação de sementes do pacote.
+type GerminacaoSemente struct {
+	gorm.Model
+	PacoteID    uint           `gorm:"not null" json:"pacote_id"`
+	Pacote      *PacoteSemente `gorm:"foreignKey:PacoteID" json:"pacote,omitempty"`
+	Data        time.Time      `gorm:"not null" json:"data"`
+	Semeadas    int            `gorm:"not null" json:"semeadas"`
+	Germinadas  int            `gorm:"not null" json:"germinadas"`
+	Metodo      string         `gorm:"size:30" json:"metodo,omitempty"` // papel_toalha, copo_agua, direto_substrato...
+	Observacoes string         `gorm:"type:text" json:"observacoes,omitempty"`
+}
+
+func (GerminacaoSemente) TableName() string {
+	return "germinacoes_sementes"
+}
+
+// TaxaGerminacao é o percentual de sementes germinadas; zero quando nada foi semeado.
+func TaxaGerminacao(semeadas, germinadas int) float64 {
+	if semeadas <= 0 {
+		return 0
+	}
+	return float64(germinadas) * 100 / float64(semeadas)
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "gorm.io/gorm"
+
+// TabelaNutricao é a tabela de alimentação de uma linha de nutrientes: a dose de cada produto
+// por estágio e semana, como publicada pelo fabricante.
+type TabelaNutricao struct {
+	gorm.Model
+	UsuarioID  uint                 `gorm:"not null" json:"usuario_id"`
+	Nome       string               `gorm:"size:100;not null" json:"nome"`
+	Fabricante string               `gorm:"size:100" json:"fabricante,omitempty"`
+	Descricao  string               `gorm:"type:text" json:"descricao,omitempty"`
+	Itens      []ItemTabelaNutricao `gorm:"foreignKey:TabelaID" json:"itens"`
+}
+
+func (TabelaNutricao) TableName() string {
+	return "tabelas_nutricao"
+}
+
+// ItemTabelaNutricao é a dose de um produto numa semana do estágio. ECAlvo e PHAlvo são os da
+// solução da semana e se repetem em todos os produtos dela.
+type ItemTabelaNutricao struct {
+	gorm.Model
+	TabelaID uint          `gorm:"not null" json:"tabela_id"`
+	Estagio  EstagioPlanta `gorm:"size:20;not null" json:"estagio"`
+	Semana   int           `gorm:"not null" json:"semana"` // semana dentro do estágio, a partir de 1
+	Produto  string        `gorm:"size:100;not null" json:"produto"`
+	Dose     float64       `gorm:"not null" json:"dose"`
+	Unidade  UnidadeDose   `gorm:"size:10;not null" json:"unidade"`
+	ECAlvo   *float64      `json:"ec_alvo,omitempty"` // mS/cm, só dos nutrientes
+	PHAlvo   *float64      `json:"ph_alvo,omitempty"`
+}
+
+func (ItemTabelaNutricao) TableName() string {
+	return "itens_tabela_nutricao"
+}
@@ -6,26 +6,60 @@ import (
 	"gorm.io/gorm"
 )
 
+// StatusTarefa define o ciclo de vida de uma tarefa.
+type StatusTarefa string
 
+const (
+	StatusTarefaPendente  StatusTarefa = "pendente"
+	StatusTarefaConcluida StatusTarefa = "concluida"
+	StatusTarefaAtrasada  StatusTarefa = "atrasada" // pendente com DataAgendada já passada
+)
+
+// PrioridadeTarefa define a urgência de uma tarefa.
+type PrioridadeTarefa string
+
+const (
+	PrioridadeBaixa PrioridadeTarefa = "baixa"
+	PrioridadeMedia PrioridadeTarefa = "media"
+	PrioridadeAlta  PrioridadeTarefa = "alta"
+)
 
 type Tarefa struct {
 	gorm.Model
-	Tipo           string     `gorm:"size:50;not null" json:"tipo"` // regar, adubar, podar, etc.
-	Descricao      string     `gorm:"type:text" json:"descricao"`
-	DataAgendada   time.Time  `json:"data_agendada"`
-	DataConclusao  *time.Time `json:"data_conclusao"`
-	Status         string     `gorm:"size:20;not null" json:"status"` // pendente, concluída, atrasada
-	Prioridade     string     `gorm:"size:20" json:"prioridade"`      // baixa, média, alta
-	PlantaID       *uint      `json:"planta_id"`                      // NULL para tarefas gerais
-	AmbienteID     *uint      `json:"ambiente_id"`
-	UsuarioID      uint       `json:"usuario_id"`
-	Fotos          []Foto     `gorm:"polymorphic:Owner;" json:"fotos"`
-	Recorrente     bool       `json:"recorrente"`
-	FrequenciaDias *int       `json:"frequencia_dias"` // NULL para tarefas únicas
-
-	DiarioCultivoID uint `json:"diario_cultivo_id"`
+	Tipo           string           `gorm:"size:50;not null" json:"tipo"` // regar, adubar, podar, etc.
+	Descricao      string           `gorm:"type:text" json:"descricao"`
+	DataAgendada   time.Time        `json:"data_agendada"`
+	DataConclusao  *time.Time       `json:"data_conclusao"`
+	Status         StatusTarefa     `gorm:"size:20;not null" json:"status"` // pendente, concluida, atrasada
+	Prioridade     PrioridadeTarefa `gorm:"size:20" json:"prioridade"`      // baixa, media, alta
+	PlantaID       *uint            `json:"planta_id"`                      // NULL para tarefas gerais
+	AmbienteID     *uint            `json:"ambiente_id"`
+	UsuarioID      uint             `json:"usuario_id"`
+	Fotos          []Foto           `gorm:"polymorphic:Owner;polymorphicValue:tarefa" json:"fotos"`
+	Recorrente     bool             `json:"recorrente"`
+	FrequenciaDias *int             `json:"frequencia_dias"` // NULL para tarefas únicas
+	NotasConclusao *string          `gorm:"type:text" json:"notas_conclusao,omitempty"`
+	// TarefaAnteriorID liga a ocorrência de uma tarefa recorrente à ocorrência anterior da série
+	TarefaAnteriorID *uint `json:"tarefa_anterior_id,omitempty"`
+	// LembreteEnviadoEm evita que o agendador lembre a mesma ocorrência mais de uma vez
+	LembreteEnviadoEm *time.Time `json:"lembrete_enviado_em,omitempty"`
+	// TarefaTemplateID e AplicacaoCronogramaID identificam as tarefas geradas por um cronograma de cultivo
+	TarefaTemplateID      *uint `json:"tarefa_template_id,omitempty"`
+	AplicacaoCronogramaID *uint `json:"aplicacao_cronograma_id,omitempty"`
+	// ReservatorioID identifica as tarefas de troca de solução geradas para um reservatório
+	ReservatorioID *uint `json:"reservatorio_id,omitempty"`
+
+	DiarioCultivoID *uint `json:"diario_cultivo_id"`
+}
+
+// Atrasada indica se a tarefa ainda pendente já passou da data agendada.
+func (t Tarefa) Atrasada(agora time.Time) bool {
+	return t.Status != StatusTarefaConcluida && t.DataAgendada.Before(agora)
 }
 
+// TarefaTemplate é um modelo de tarefa. Como item de um CronogramaCultivo, é agendado a partir
+// do início do Estagio: a primeira ocorrência no dia DiaInicio (0 = primeiro dia do estágio),
+// repetindo a cada FrequenciaDias (0 = única) até DiaFim.
 type TarefaTemplate struct {
 	gorm.Model
 	Nome           string `gorm:"size:100;not null" json:"nome"`
@@ -34,4 +68,29 @@ type TarefaTemplate struct {
 	FrequenciaDias int    `json:"frequencia_dias"`
 	Instrucoes     string `gorm:"type:text" json:"instrucoes"`
 	EspecieID      *uint  `json:"especie_id"` // NULL para templates gerais
+
+	CronogramaID *uint            `json:"cronograma_id,omitempty"` // NULL para templates avulsos
+	Estagio      EstagioPlanta    `gorm:"size:100" json:"estagio,omitempty"`
+	DiaInicio    int              `gorm:"not null;default:0" json:"dia_inicio"`
+	DiaFim       *int             `json:"dia_fim,omitempty"` // NULL repete enquanto durar o estágio
+	Ordem        int              `gorm:"not null;default:0" json:"ordem"`
+	Prioridade   PrioridadeTarefa `gorm:"size:20" json:"prioridade,omitempty"`
+}
+
+// Continuo indica se o template se repete sem fim definido, enquanto a planta estiver no estágio.
+func (t TarefaTemplate) Continuo() bool {
+	return t.FrequenciaDias > 0 && t.DiaFim == nil
+}
+
+// DiasDoEstagio lista os dias do estágio em que o template gera tarefas. Nos contínuos,
+// apenas a primeira ocorrência é listada; as demais seguem como tarefa recorrente.
+func (t TarefaTemplate) DiasDoEstagio() []int {
+	if t.FrequenciaDias <= 0 || t.DiaFim == nil {
+		return []int{t.DiaInicio}
+	}
+	var dias []int
+	for dia := t.DiaInicio; dia <= *t.DiaFim; dia += t.FrequenciaDias {
+		dias = append(dias, dia)
+	}
+	return dias
 }
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BandeiraTarifaria define as bandeiras tarifárias da ANEEL, que acrescem um valor por kWh
+// conforme o custo de geração do mês.
+type BandeiraTarifaria string
+
+const (
+	BandeiraVerde     BandeiraTarifaria = "verde"
+	BandeiraAmarela   BandeiraTarifaria = "amarela"
+	BandeiraVermelha1 BandeiraTarifaria = "vermelha_1"
+	BandeiraVermelha2 BandeiraTarifaria = "vermelha_2"
+)
+
+// TarifaEnergia é o preço da energia do usuário a partir de VigenteDesde. ValorKWh já inclui
+// TE, TUSD e tributos; os adicionais são cobrados por kWh nos meses de bandeira amarela ou vermelha.
+type TarifaEnergia struct {
+	gorm.Model
+	UsuarioID          uint      `gorm:"not null;index" json:"usuario_id"`
+	Nome               string    `gorm:"size:100;not null" json:"nome"`
+	ValorKWh           float64   `gorm:"not null" json:"valor_kwh"`            // R$/kWh
+	AdicionalAmarela   float64   `gorm:"not null" json:"adicional_amarela"`    // R$/kWh
+	AdicionalVermelha1 float64   `gorm:"not null" json:"adicional_vermelha_1"` // R$/kWh
+	AdicionalVermelha2 float64   `gorm:"not null" json:"adicional_vermelha_2"` // R$/kWh
+	VigenteDesde       time.Time `gorm:"not null" json:"vigente_desde"`
+}
+
+// PrecoKWh retorna o preço do kWh com o adicional da bandeira.
+func (t TarifaEnergia) PrecoKWh(bandeira BandeiraTarifaria) float64 {
+	switch bandeira {
+	case BandeiraAmarela:
+		return t.ValorKWh + t.AdicionalAmarela
+	case BandeiraVermelha1:
+		return t.ValorKWh + t.AdicionalVermelha1
+	case BandeiraVermelha2:
+		return t.ValorKWh + t.AdicionalVermelha2
+	}
+	return t.ValorKWh
+}
+
+// BandeiraMensal registra a bandeira vigente em um mês (competência AAAA-MM).
+// Meses sem registro são tratados como bandeira verde.
+type BandeiraMensal struct {
+	gorm.Model
+	UsuarioID   uint              `gorm:"not null;uniqueIndex:idx_bandeira_usuario_competencia" json:"usuario_id"`
+	Competencia string            `gorm:"size:7;not null;uniqueIndex:idx_bandeira_usuario_competencia" json:"competencia"`
+	Bandeira    BandeiraTarifaria `gorm:"size:20;not null" json:"bandeira"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Transplante registra a troca de vaso ou de substrato de uma planta, guardando o recipiente
+// anterior e o novo.
+type Transplante struct {
+	gorm.Model
+	PlantaID            uint       `gorm:"not null" json:"planta_id"`
+	UsuarioID           uint       `gorm:"not null" json:"usuario_id"`
+	Data                time.Time  `gorm:"not null" json:"data"`
+	VasoAnteriorID      *uint      `json:"vaso_anterior_id,omitempty"`
+	VasoAnterior        *Vaso      `gorm:"foreignKey:VasoAnteriorID" json:"vaso_anterior,omitempty"`
+	VasoNovoID          *uint      `json:"vaso_novo_id,omitempty"`
+	VasoNovo            *Vaso      `gorm:"foreignKey:VasoNovoID" json:"vaso_novo,omitempty"`
+	SubstratoAnteriorID *uint      `json:"substrato_anterior_id,omitempty"`
+	SubstratoAnterior   *Substrato `gorm:"foreignKey:SubstratoAnteriorID" json:"substrato_anterior,omitempty"`
+	SubstratoNovoID     *uint      `json:"substrato_novo_id,omitempty"`
+	SubstratoNovo       *Substrato `gorm:"foreignKey:SubstratoNovoID" json:"substrato_novo,omitempty"`
+	Observacoes         string     `gorm:"type:text" json:"observacoes,omitempty"`
+}
+
+func (Transplante) TableName() string {
+	return "transplantes"
+}
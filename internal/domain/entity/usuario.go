@@ -2,6 +2,7 @@ package entity
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -23,14 +24,62 @@ type Usuario struct {
 	Preferencias json.RawMessage `gorm:"type:json" json:"preferencias"` // Configurações em JSON
 	Plantas      []Planta `gorm:"foreignKey:UsuarioID" json:"plantas"`
 	Tarefas      []Tarefa `gorm:"foreignKey:UsuarioID" json:"tarefas"`
+	// TokenCalendarioHash é o SHA-256 do token secreto do feed iCalendar; nil sem feed ativo
+	TokenCalendarioHash *string `gorm:"size:64" json:"-"`
 }
 
+// FrequenciaLembrete define o intervalo entre as repetições de um lembrete.
+type FrequenciaLembrete string
+
+const (
+	FrequenciaLembreteDiaria  FrequenciaLembrete = "diaria"
+	FrequenciaLembreteSemanal FrequenciaLembrete = "semanal"
+	FrequenciaLembreteMensal  FrequenciaLembrete = "mensal"
+)
+
+// Lembrete é um aviso agendado pelo usuário. Só os lembretes com ProximoEnvio são entregues pelo
+// disparador; os antigos sem agenda são notificações gravadas antes da central de notificações.
 type Lembrete struct {
 	gorm.Model
-	UsuarioID  uint      `json:"usuario_id"`
-	Mensagem   string    `gorm:"type:text;not null" json:"mensagem"`
-	DataHora   time.Time `json:"data_hora"`
-	Repetir    bool      `json:"repetir"`
-	Frequencia string    `gorm:"size:20" json:"frequencia"` // diária, semanal, etc.
-	Lido       bool      `json:"lido"`
+	UsuarioID  uint               `json:"usuario_id"`
+	Mensagem   string             `gorm:"type:text;not null" json:"mensagem"`
+	DataHora   time.Time          `json:"data_hora"` // próxima ocorrência; avança a cada repetição
+	Repetir    bool               `json:"repetir"`
+	Frequencia FrequenciaLembrete `gorm:"size:20" json:"frequencia"` // diaria, semanal, mensal
+	Lido       bool               `json:"lido"`
+	// Canais lista, separados por vírgula, os canais de entrega: lembrete (central do app), email, webhook, telegram
+	Canais string `gorm:"size:100" json:"canais"`
+	// ProximoEnvio é quando o disparador deve entregar: a ocorrência, o fim do adiamento ou o fim
+	// do horário de silêncio. Nil quando não há mais entregas
+	ProximoEnvio *time.Time `json:"proximo_envio,omitempty"`
+	UltimoEnvio  *time.Time `json:"ultimo_envio,omitempty"`
+}
+
+// PreferenciaNotificacao guarda os destinos dos canais externos, o horário de silêncio e as
+// categorias que o usuário não quer receber na central de notificações
+type PreferenciaNotificacao struct {
+	gorm.Model
+	UsuarioID      uint   `gorm:"not null" json:"usuario_id"`
+	Email          string `gorm:"size:100" json:"email,omitempty"`
+	WebhookURL     string `gorm:"size:500" json:"webhook_url,omitempty"`
+	TelegramChatID string `gorm:"size:50" json:"telegram_chat_id,omitempty"`
+	// SilencioInicio e SilencioFim (HH:MM, horário local) adiam as entregas; podem virar a meia-noite
+	SilencioInicio string `gorm:"size:5" json:"silencio_inicio,omitempty"`
+	SilencioFim    string `gorm:"size:5" json:"silencio_fim,omitempty"`
+	// CategoriasDesativadas lista, separadas por vírgula, as categorias descartadas pela central
+	CategoriasDesativadas string `gorm:"size:200" json:"categorias_desativadas"`
+}
+
+func (PreferenciaNotificacao) TableName() string {
+	return "preferencias_notificacao"
+}
+
+// CategoriaDesativada indica se o usuário desativou a categoria na central de notificações
+func (p PreferenciaNotificacao) CategoriaDesativada(categoria CategoriaNotificacao) bool {
+	for _, desativada := range strings.Split(p.CategoriasDesativadas, ",") {
+		if strings.TrimSpace(desativada) == string(categoria) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,454 @@
+// Package ical gera e lê calendários iCalendar (RFC 5545) no subconjunto usado pela API:
+// eventos com horário ou de dia inteiro, recorrência simples (FREQ e INTERVAL) e alarmes.
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	formatoDataHoraUTC = "20060102T150405Z"
+	formatoDataHora    = "20060102T150405"
+	formatoData        = "20060102"
+
+	// limiteLinha é o tamanho máximo, em octetos, de uma linha antes de ser dobrada
+	limiteLinha = 75
+	// limiteLinhaLeitura protege a leitura de linhas desdobradas muito longas
+	limiteLinhaLeitura = 1 << 20
+)
+
+// Frequências de recorrência suportadas
+const (
+	FrequenciaDiaria  = "DAILY"
+	FrequenciaSemanal = "WEEKLY"
+	FrequenciaMensal  = "MONTHLY"
+	FrequenciaAnual   = "YEARLY"
+)
+
+// Recorrencia é a regra RRULE de um evento, restrita a FREQ e INTERVAL
+type Recorrencia struct {
+	Frequencia string
+	Intervalo  int // 0 ou 1: toda ocorrência da frequência
+}
+
+// Dias converte a recorrência em intervalo de dias quando ela é diária ou semanal.
+func (r Recorrencia) Dias() (int, bool) {
+	intervalo := r.Intervalo
+	if intervalo <= 0 {
+		intervalo = 1
+	}
+	switch r.Frequencia {
+	case FrequenciaDiaria:
+		return intervalo, true
+	case FrequenciaSemanal:
+		return 7 * intervalo, true
+	}
+	return 0, false
+}
+
+// Evento é um VEVENT (na leitura, também VTODO). Nos eventos de dia inteiro só a data de
+// Inicio e Fim é usada, e Fim é exclusivo como no DTEND do RFC 5545.
+type Evento struct {
+	UID        string
+	Resumo     string
+	Descricao  string
+	Inicio     time.Time
+	Fim        time.Time // zero: o evento não tem duração
+	DiaInteiro bool
+	Categorias []string
+	// Recorrencia é nil para eventos únicos
+	Recorrencia *Recorrencia
+	// Alarme é a antecedência do aviso antes do início; nil sem alarme
+	Alarme *time.Duration
+	// Extras guarda propriedades X- (ex.: X-CULTIVO-TIPO), com os nomes em maiúsculas
+	Extras map[string]string
+}
+
+// Calendario é um VCALENDAR publicado pela API
+type Calendario struct {
+	Nome    string
+	Eventos []Evento
+}
+
+// Bytes serializa o calendário; agora é o DTSTAMP dos eventos.
+func (c Calendario) Bytes(agora time.Time) []byte {
+	var b bytes.Buffer
+	linha := func(conteudo string) {
+		escreverLinha(&b, conteudo)
+	}
+
+	linha("BEGIN:VCALENDAR")
+	linha("VERSION:2.0")
+	linha("PRODID:-//Cultivo API//Calendario//PT")
+	linha("CALSCALE:GREGORIAN")
+	linha("METHOD:PUBLISH")
+	if c.Nome != "" {
+		linha("X-WR-CALNAME:" + escapar(c.Nome))
+	}
+	for _, evento := range c.Eventos {
+		linha("BEGIN:VEVENT")
+		linha("UID:" + escapar(evento.UID))
+		linha("DTSTAMP:" + agora.UTC().Format(formatoDataHoraUTC))
+		if evento.DiaInteiro {
+			linha("DTSTART;VALUE=DATE:" + evento.Inicio.Format(formatoData))
+			if !evento.Fim.IsZero() {
+				linha("DTEND;VALUE=DATE:" + evento.Fim.Format(formatoData))
+			}
+		} else {
+			linha("DTSTART:" + evento.Inicio.UTC().Format(formatoDataHoraUTC))
+			if !evento.Fim.IsZero() {
+				linha("DTEND:" + evento.Fim.UTC().Format(formatoDataHoraUTC))
+			}
+		}
+		linha("SUMMARY:" + escapar(evento.Resumo))
+		if evento.Descricao != "" {
+			linha("DESCRIPTION:" + escapar(evento.Descricao))
+		}
+		if len(evento.Categorias) > 0 {
+			categorias := make([]string, len(evento.Categorias))
+			for i, categoria := range evento.Categorias {
+				categorias[i] = escapar(categoria)
+			}
+			linha("CATEGORIES:" + strings.Join(categorias, ","))
+		}
+		if evento.Recorrencia != nil {
+			regra := "RRULE:FREQ=" + evento.Recorrencia.Frequencia
+			if evento.Recorrencia.Intervalo > 1 {
+				regra += ";INTERVAL=" + strconv.Itoa(evento.Recorrencia.Intervalo)
+			}
+			linha(regra)
+		}
+		for _, nome := range ordenarChaves(evento.Extras) {
+			linha(nome + ":" + escapar(evento.Extras[nome]))
+		}
+		if evento.Alarme != nil {
+			linha("BEGIN:VALARM")
+			linha("ACTION:DISPLAY")
+			linha("DESCRIPTION:" + escapar(evento.Resumo))
+			linha("TRIGGER:" + duracao(-*evento.Alarme))
+			linha("END:VALARM")
+		}
+		linha("END:VEVENT")
+	}
+	linha("END:VCALENDAR")
+	return b.Bytes()
+}
+
+// Ler interpreta os VEVENT e VTODO de um calendário. Horários sem fuso (flutuantes) e datas
+// são interpretados em local; TZID desconhecidos também caem em local.
+func Ler(r io.Reader, local *time.Location) ([]Evento, error) {
+	if local == nil {
+		local = time.Local
+	}
+	linhas, err := desdobrar(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventos []Evento
+	var atual *Evento
+	var componente string
+	iniciouCalendario := false
+	aninhado := 0 // VALARM e outros componentes dentro do evento
+	for _, texto := range linhas {
+		if strings.TrimSpace(texto) == "" {
+			continue
+		}
+		p, err := lerPropriedade(texto)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.nome == "BEGIN" && strings.EqualFold(p.valor, "VCALENDAR"):
+			iniciouCalendario = true
+		case p.nome == "BEGIN" && atual == nil && (strings.EqualFold(p.valor, "VEVENT") || strings.EqualFold(p.valor, "VTODO")):
+			atual = &Evento{Extras: map[string]string{}}
+			componente = strings.ToUpper(p.valor)
+		case p.nome == "BEGIN" && atual != nil:
+			aninhado++
+		case p.nome == "END" && atual != nil && aninhado > 0:
+			aninhado--
+		case p.nome == "END" && atual != nil && strings.EqualFold(p.valor, componente):
+			eventos = append(eventos, *atual)
+			atual = nil
+		case atual != nil && aninhado == 0:
+			if err := aplicarPropriedade(atual, p, componente, local); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !iniciouCalendario {
+		return nil, errors.New("conteúdo não é um VCALENDAR")
+	}
+	if atual != nil {
+		return nil, fmt.Errorf("componente %s sem END", componente)
+	}
+	return eventos, nil
+}
+
+type propriedade struct {
+	nome       string
+	parametros map[string]string
+	valor      string
+}
+
+// lerPropriedade separa "NOME;PARAM=valor;PARAM2=\"a:b\":VALOR"
+func lerPropriedade(linha string) (propriedade, error) {
+	entreAspas := false
+	fimNome := -1
+	for i, c := range linha {
+		switch {
+		case c == '"':
+			entreAspas = !entreAspas
+		case c == ';' && fimNome < 0 && !entreAspas:
+			fimNome = i
+		case c == ':' && !entreAspas:
+			if fimNome < 0 {
+				fimNome = i
+			}
+			p := propriedade{
+				nome:       strings.ToUpper(linha[:fimNome]),
+				parametros: map[string]string{},
+				valor:      linha[i+1:],
+			}
+			if fimNome < i {
+				for _, parametro := range dividirParametros(linha[fimNome+1 : i]) {
+					chave, valor, _ := strings.Cut(parametro, "=")
+					p.parametros[strings.ToUpper(chave)] = strings.Trim(valor, `"`)
+				}
+			}
+			return p, nil
+		}
+	}
+	return propriedade{}, fmt.Errorf("linha inválida: %q", linha)
+}
+
+func dividirParametros(texto string) []string {
+	var partes []string
+	entreAspas := false
+	inicio := 0
+	for i, c := range texto {
+		switch {
+		case c == '"':
+			entreAspas = !entreAspas
+		case c == ';' && !entreAspas:
+			partes = append(partes, texto[inicio:i])
+			inicio = i + 1
+		}
+	}
+	return append(partes, texto[inicio:])
+}
+
+func aplicarPropriedade(evento *Evento, p propriedade, componente string, local *time.Location) error {
+	switch p.nome {
+	case "UID":
+		evento.UID = desescapar(p.valor)
+	case "SUMMARY":
+		evento.Resumo = desescapar(p.valor)
+	case "DESCRIPTION":
+		evento.Descricao = desescapar(p.valor)
+	case "CATEGORIES":
+		for _, categoria := range dividirTexto(p.valor) {
+			if categoria = strings.TrimSpace(categoria); categoria != "" {
+				evento.Categorias = append(evento.Categorias, categoria)
+			}
+		}
+	case "DTSTART", "DUE":
+		// nas pendências (VTODO) sem DTSTART, o prazo é a data da tarefa
+		if p.nome == "DUE" && (componente != "VTODO" || !evento.Inicio.IsZero()) {
+			return nil
+		}
+		inicio, diaInteiro, err := lerData(p, local)
+		if err != nil {
+			return err
+		}
+		evento.Inicio, evento.DiaInteiro = inicio, diaInteiro
+	case "DTEND":
+		fim, _, err := lerData(p, local)
+		if err != nil {
+			return err
+		}
+		evento.Fim = fim
+	case "RRULE":
+		evento.Recorrencia = lerRecorrencia(p.valor)
+	default:
+		if strings.HasPrefix(p.nome, "X-") {
+			evento.Extras[p.nome] = desescapar(p.valor)
+		}
+	}
+	return nil
+}
+
+func lerData(p propriedade, local *time.Location) (time.Time, bool, error) {
+	valor := strings.TrimSpace(p.valor)
+	if strings.EqualFold(p.parametros["VALUE"], "DATE") || len(valor) == len(formatoData) {
+		data, err := time.ParseInLocation(formatoData, valor, local)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("data inválida em %s: %q", p.nome, valor)
+		}
+		return data, true, nil
+	}
+	if strings.HasSuffix(valor, "Z") {
+		data, err := time.Parse(formatoDataHoraUTC, valor)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("data inválida em %s: %q", p.nome, valor)
+		}
+		return data, false, nil
+	}
+	fuso := local
+	if tzid := p.parametros["TZID"]; tzid != "" {
+		if carregado, err := time.LoadLocation(tzid); err == nil {
+			fuso = carregado
+		}
+	}
+	data, err := time.ParseInLocation(formatoDataHora, valor, fuso)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("data inválida em %s: %q", p.nome, valor)
+	}
+	return data, false, nil
+}
+
+func lerRecorrencia(valor string) *Recorrencia {
+	recorrencia := &Recorrencia{}
+	for _, parte := range strings.Split(valor, ";") {
+		chave, v, _ := strings.Cut(parte, "=")
+		switch strings.ToUpper(chave) {
+		case "FREQ":
+			recorrencia.Frequencia = strings.ToUpper(v)
+		case "INTERVAL":
+			recorrencia.Intervalo, _ = strconv.Atoi(v)
+		}
+	}
+	if recorrencia.Frequencia == "" {
+		return nil
+	}
+	return recorrencia
+}
+
+// desdobrar junta as linhas continuadas (iniciadas por espaço ou tab) e aceita LF ou CRLF
+func desdobrar(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), limiteLinhaLeitura)
+	var linhas []string
+	for scanner.Scan() {
+		texto := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(texto, " ") || strings.HasPrefix(texto, "\t")) && len(linhas) > 0 {
+			linhas[len(linhas)-1] += texto[1:]
+			continue
+		}
+		linhas = append(linhas, texto)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("falha ao ler calendário: %w", err)
+	}
+	return linhas, nil
+}
+
+// escreverLinha dobra a linha em até 75 octetos sem partir caracteres UTF-8
+func escreverLinha(b *bytes.Buffer, linha string) {
+	limite := limiteLinha
+	for len(linha) > limite {
+		corte := limite
+		for corte > 0 && !utf8.RuneStart(linha[corte]) {
+			corte--
+		}
+		b.WriteString(linha[:corte])
+		b.WriteString("\r\n ")
+		linha = linha[corte:]
+		limite = limiteLinha - 1 // o espaço da continuação conta no limite
+	}
+	b.WriteString(linha)
+	b.WriteString("\r\n")
+}
+
+var escapador = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapar(texto string) string {
+	return escapador.Replace(texto)
+}
+
+func desescapar(texto string) string {
+	var b strings.Builder
+	for i := 0; i < len(texto); i++ {
+		if texto[i] != '\\' || i == len(texto)-1 {
+			b.WriteByte(texto[i])
+			continue
+		}
+		i++
+		switch texto[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(texto[i])
+		}
+	}
+	return b.String()
+}
+
+// dividirTexto separa valores de lista pelas vírgulas não escapadas
+func dividirTexto(texto string) []string {
+	var partes []string
+	inicio := 0
+	for i := 0; i < len(texto); i++ {
+		switch texto[i] {
+		case '\\':
+			i++
+		case ',':
+			partes = append(partes, desescapar(texto[inicio:i]))
+			inicio = i + 1
+		}
+	}
+	return append(partes, desescapar(texto[inicio:]))
+}
+
+// duracao formata a duração no formato do RFC 5545 (ex.: -PT1H30M)
+func duracao(d time.Duration) string {
+	sinal := ""
+	if d < 0 {
+		sinal, d = "-", -d
+	}
+	d = d.Truncate(time.Second)
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	b.WriteString(sinal + "P")
+	if dias := d / (24 * time.Hour); dias > 0 {
+		fmt.Fprintf(&b, "%dD", dias)
+		d -= dias * 24 * time.Hour
+	}
+	if d > 0 {
+		b.WriteString("T")
+		if h := d / time.Hour; h > 0 {
+			fmt.Fprintf(&b, "%dH", h)
+			d -= h * time.Hour
+		}
+		if m := d / time.Minute; m > 0 {
+			fmt.Fprintf(&b, "%dM", m)
+			d -= m * time.Minute
+		}
+		if d > 0 {
+			fmt.Fprintf(&b, "%dS", d/time.Second)
+		}
+	}
+	return b.String()
+}
+
+func ordenarChaves(m map[string]string) []string {
+	chaves := make([]string, 0, len(m))
+	for chave := range m {
+		chaves = append(chaves, chave)
+	}
+	sort.Strings(chaves)
+	return chaves
+}
@@ -0,0 +1,167 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendario_Bytes(t *testing.T) {
+	agora := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	saoPaulo := time.FixedZone("BRT", -3*3600)
+	alarme := 90 * time.Minute
+	c := Calendario{
+		Nome: "Cultivo",
+		Eventos: []Evento{
+			{
+				UID:         "tarefa-1@cultivo-api",
+				Resumo:      "Regar; tenda 1, bancada",
+				Descricao:   "Linha 1\nLinha 2",
+				Inicio:      time.Date(2026, 3, 2, 9, 0, 0, 0, saoPaulo),
+				Fim:         time.Date(2026, 3, 2, 9, 30, 0, 0, saoPaulo),
+				Categorias:  []string{"regar"},
+				Recorrencia: &Recorrencia{Frequencia: FrequenciaDiaria, Intervalo: 3},
+				Alarme:      &alarme,
+				Extras:      map[string]string{"X-CULTIVO-TIPO": "regar"},
+			},
+			{
+				UID:        "colheita-2@cultivo-api",
+				Resumo:     "Janela de colheita",
+				Inicio:     time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC),
+				Fim:        time.Date(2026, 5, 24, 0, 0, 0, 0, time.UTC),
+				DiaInteiro: true,
+			},
+		},
+	}
+
+	saida := string(c.Bytes(agora))
+
+	assert.True(t, strings.HasPrefix(saida, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(saida, "END:VCALENDAR\r\n"))
+	assert.NotContains(t, strings.ReplaceAll(saida, "\r\n", ""), "\n")
+	assert.Contains(t, saida, "DTSTAMP:20260301T120000Z\r\n")
+	assert.Contains(t, saida, "DTSTART:20260302T120000Z\r\n")
+	assert.Contains(t, saida, "DTEND:20260302T123000Z\r\n")
+	assert.Contains(t, saida, `SUMMARY:Regar\; tenda 1\, bancada`+"\r\n")
+	assert.Contains(t, saida, `DESCRIPTION:Linha 1\nLinha 2`+"\r\n")
+	assert.Contains(t, saida, "RRULE:FREQ=DAILY;INTERVAL=3\r\n")
+	assert.Contains(t, saida, "X-CULTIVO-TIPO:regar\r\n")
+	assert.Contains(t, saida, "BEGIN:VALARM\r\nACTION:DISPLAY\r\n")
+	assert.Contains(t, saida, "TRIGGER:-PT1H30M\r\n")
+	assert.Contains(t, saida, "DTSTART;VALUE=DATE:20260510\r\nDTEND;VALUE=DATE:20260524\r\n")
+}
+
+func TestCalendario_BytesDobraLinhasLongas(t *testing.T) {
+	resumo := strings.Repeat("colheita é amanhã ", 20)
+	saida := string(Calendario{Eventos: []Evento{{
+		UID:    "x",
+		Resumo: resumo,
+		Inicio: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}}.Bytes(time.Now()))
+
+	for _, linha := range strings.Split(strings.TrimSuffix(saida, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(linha), 75)
+		assert.True(t, utf8.ValidString(linha), "linha partiu um caractere: %q", linha)
+	}
+
+	eventos, err := Ler(strings.NewReader(saida), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, eventos, 1)
+	assert.Equal(t, resumo, eventos[0].Resumo)
+}
+
+func TestLer(t *testing.T) {
+	local := time.FixedZone("BRT", -3*3600)
+	conteudo := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTIMEZONE",
+		"TZID:America/Sao_Paulo",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:abc-1",
+		"DTSTART:20260402T130000Z",
+		"SUMMARY:Trocar solução\\, medir pH",
+		"DESCRIPTION:Primeira linha\\nseg",
+		" unda linha",
+		"CATEGORIES:nutricao,reservatorio",
+		"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO",
+		"BEGIN:VALARM",
+		"DESCRIPTION:não é o evento",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:abc-2",
+		"DTSTART;VALUE=DATE:20260405",
+		"SUMMARY:Defoliação",
+		"X-CULTIVO-TIPO:podar",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"UID:abc-3",
+		"DUE;TZID=\"America/Sao_Paulo\":20260406T080000",
+		"SUMMARY:Comprar substrato",
+		"END:VTODO",
+		"BEGIN:VEVENT",
+		"UID:abc-4",
+		"DTSTART:20260407T070000",
+		"SUMMARY:Flutuante",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\n")
+
+	eventos, err := Ler(strings.NewReader(conteudo), local)
+
+	require.NoError(t, err)
+	require.Len(t, eventos, 4)
+
+	assert.Equal(t, "abc-1", eventos[0].UID)
+	assert.Equal(t, "Trocar solução, medir pH", eventos[0].Resumo)
+	assert.Equal(t, "Primeira linha\nsegunda linha", eventos[0].Descricao)
+	assert.Equal(t, []string{"nutricao", "reservatorio"}, eventos[0].Categorias)
+	assert.True(t, eventos[0].Inicio.Equal(time.Date(2026, 4, 2, 13, 0, 0, 0, time.UTC)))
+	require.NotNil(t, eventos[0].Recorrencia)
+	dias, ok := eventos[0].Recorrencia.Dias()
+	assert.True(t, ok)
+	assert.Equal(t, 14, dias)
+
+	assert.True(t, eventos[1].DiaInteiro)
+	assert.True(t, eventos[1].Inicio.Equal(time.Date(2026, 4, 5, 0, 0, 0, 0, local)))
+	assert.Equal(t, "podar", eventos[1].Extras["X-CULTIVO-TIPO"])
+
+	assert.Equal(t, "Comprar substrato", eventos[2].Resumo)
+	assert.False(t, eventos[2].Inicio.IsZero())
+	assert.Equal(t, 8, eventos[2].Inicio.Hour())
+
+	assert.True(t, eventos[3].Inicio.Equal(time.Date(2026, 4, 7, 7, 0, 0, 0, local)))
+	assert.Nil(t, eventos[3].Recorrencia)
+}
+
+func TestLer_Erros(t *testing.T) {
+	t.Run("Error - Sem VCALENDAR", func(t *testing.T) {
+		_, err := Ler(strings.NewReader("BEGIN:VEVENT\nEND:VEVENT\n"), time.UTC)
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - Data Inválida", func(t *testing.T) {
+		_, err := Ler(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:amanha\nEND:VEVENT\nEND:VCALENDAR\n"), time.UTC)
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - Evento Sem END", func(t *testing.T) {
+		_, err := Ler(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:1\n"), time.UTC)
+		assert.Error(t, err)
+	})
+}
+
+func TestRecorrencia_Dias(t *testing.T) {
+	dias, ok := Recorrencia{Frequencia: FrequenciaDiaria}.Dias()
+	assert.True(t, ok)
+	assert.Equal(t, 1, dias)
+
+	_, ok = Recorrencia{Frequencia: FrequenciaMensal}.Dias()
+	assert.False(t, ok)
+}
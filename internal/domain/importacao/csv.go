@@ -0,0 +1,215 @@
+package importacao
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatos especiais de data aceitos em OpcoesCSV.FormatoData
+const (
+	FormatoDataUnix   = "unix"    // segundos desde a época
+	FormatoDataUnixMs = "unix_ms" // milissegundos desde a época
+)
+
+// layoutsData são tentados em ordem quando o formato da data não é informado
+var layoutsData = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+}
+
+// nomesColunaData são reconhecidos como a coluna de data quando ela não é informada
+var nomesColunaData = []string{"data_medicao", "data", "timestamp", "time", "datetime", "date"}
+
+// OpcoesCSV configura a leitura de CSV de data loggers
+type OpcoesCSV struct {
+	Delimitador    rune              // padrão: vírgula
+	DecimalVirgula bool              // números no formato 24,5
+	ColunaData     string            // padrão: primeira coluna com nome usual de data, ou a primeira coluna
+	FormatoData    string            // layout Go, unix ou unix_ms; vazio tenta os formatos comuns
+	Local          *time.Location    // fuso das datas sem deslocamento (padrão: UTC)
+	Colunas        map[string]string // métrica → nome da coluna; vazio reconhece pelos nomes usuais
+}
+
+// LerCSV converte um CSV com cabeçalho em leituras. Células vazias em métricas opcionais
+// são ignoradas; temperatura e umidade são obrigatórias em todas as linhas.
+func LerCSV(r io.Reader, opcoes OpcoesCSV) (*Resultado, error) {
+	if opcoes.Local == nil {
+		opcoes.Local = time.UTC
+	}
+	leitor := csv.NewReader(r)
+	if opcoes.Delimitador != 0 {
+		leitor.Comma = opcoes.Delimitador
+	}
+	leitor.FieldsPerRecord = -1
+	leitor.LazyQuotes = true
+
+	cabecalho, err := leitor.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("arquivo CSV vazio")
+		}
+		return nil, fmt.Errorf("falha ao ler cabeçalho do CSV: %w", err)
+	}
+	colunaData, colunas, err := resolverColunas(cabecalho, opcoes)
+	if err != nil {
+		return nil, err
+	}
+
+	resultado := &Resultado{}
+	for {
+		registro, err := leitor.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var errCSV *csv.ParseError
+			if errors.As(err, &errCSV) {
+				resultado.rejeitar(errCSV.StartLine, "%v", errCSV.Err)
+				continue
+			}
+			return nil, fmt.Errorf("falha ao ler CSV: %w", err)
+		}
+		linha, _ := leitor.FieldPos(0)
+		if len(registro) == 1 && strings.TrimSpace(registro[0]) == "" {
+			continue
+		}
+
+		if colunaData >= len(registro) {
+			resultado.rejeitar(linha, "linha sem a coluna de data")
+			continue
+		}
+		data, err := converterData(strings.TrimSpace(registro[colunaData]), opcoes)
+		if err != nil {
+			resultado.rejeitar(linha, "%v", err)
+			continue
+		}
+
+		p := &parcial{linha: linha, data: data, valores: make(map[string]float64)}
+		valido := true
+		for metrica, indice := range colunas {
+			if indice >= len(registro) {
+				continue
+			}
+			bruto := strings.TrimSpace(registro[indice])
+			if bruto == "" {
+				continue
+			}
+			if opcoes.DecimalVirgula {
+				bruto = strings.Replace(strings.ReplaceAll(bruto, ".", ""), ",", ".", 1)
+			}
+			valor, err := strconv.ParseFloat(bruto, 64)
+			if err != nil {
+				resultado.rejeitar(linha, "valor inválido na coluna %s: %s", cabecalho[indice], registro[indice])
+				valido = false
+				break
+			}
+			p.valores[metrica] = valor
+		}
+		if !valido {
+			continue
+		}
+
+		leitura, err := p.leitura()
+		if err != nil {
+			resultado.rejeitar(linha, "%v", err)
+			continue
+		}
+		resultado.Leituras = append(resultado.Leituras, leitura)
+	}
+	return resultado, nil
+}
+
+// resolverColunas localiza a coluna de data e as colunas de cada métrica no cabeçalho
+func resolverColunas(cabecalho []string, opcoes OpcoesCSV) (int, map[string]int, error) {
+	indices := make(map[string]int, len(cabecalho))
+	for i, nome := range cabecalho {
+		nome = strings.TrimSpace(strings.TrimPrefix(nome, "\ufeff"))
+		cabecalho[i] = nome
+		indices[strings.ToLower(nome)] = i
+	}
+	buscar := func(nome string) (int, bool) {
+		i, ok := indices[strings.ToLower(strings.TrimSpace(nome))]
+		return i, ok
+	}
+
+	colunaData := 0
+	if opcoes.ColunaData != "" {
+		i, ok := buscar(opcoes.ColunaData)
+		if !ok {
+			return 0, nil, fmt.Errorf("coluna de data %q não encontrada no cabeçalho", opcoes.ColunaData)
+		}
+		colunaData = i
+	} else {
+		for _, nome := range nomesColunaData {
+			if i, ok := buscar(nome); ok {
+				colunaData = i
+				break
+			}
+		}
+	}
+
+	colunas := make(map[string]int)
+	if len(opcoes.Colunas) > 0 {
+		for metrica, nome := range opcoes.Colunas {
+			i, ok := buscar(nome)
+			if !ok {
+				return 0, nil, fmt.Errorf("coluna %q da métrica %s não encontrada no cabeçalho", nome, metrica)
+			}
+			colunas[metrica] = i
+		}
+	} else {
+		for i, nome := range cabecalho {
+			if metrica, ok := MetricaPorNome(nome); ok && i != colunaData {
+				if _, repetida := colunas[metrica]; !repetida {
+					colunas[metrica] = i
+				}
+			}
+		}
+	}
+
+	_, temTemperatura := colunas[MetricaTemperatura]
+	_, temUmidade := colunas[MetricaUmidade]
+	if !temTemperatura || !temUmidade {
+		return 0, nil, errors.New("o CSV precisa de colunas de temperatura e umidade (informe o mapeamento de colunas)")
+	}
+	return colunaData, colunas, nil
+}
+
+func converterData(valor string, opcoes OpcoesCSV) (time.Time, error) {
+	if valor == "" {
+		return time.Time{}, errors.New("data vazia")
+	}
+	switch opcoes.FormatoData {
+	case FormatoDataUnix, FormatoDataUnixMs:
+		numero, err := strconv.ParseInt(valor, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("data inválida: %s", valor)
+		}
+		if opcoes.FormatoData == FormatoDataUnixMs {
+			return time.UnixMilli(numero).UTC(), nil
+		}
+		return time.Unix(numero, 0).UTC(), nil
+	case "":
+		for _, layout := range layoutsData {
+			if data, err := time.ParseInLocation(layout, valor, opcoes.Local); err == nil {
+				return data, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("data em formato não reconhecido: %s", valor)
+	default:
+		data, err := time.ParseInLocation(opcoes.FormatoData, valor, opcoes.Local)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("data fora do formato %s: %s", opcoes.FormatoData, valor)
+		}
+		return data, nil
+	}
+}
@@ -0,0 +1,100 @@
+package importacao
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLerCSV(t *testing.T) {
+	t.Run("Success - Colunas Reconhecidas pelo Nome", func(t *testing.T) {
+		entrada := "\ufefftimestamp,Temperature,Humidity,CO2\n" +
+			"2026-05-01T12:00:00Z,24.5,60,\n" +
+			"2026-05-01T12:05:00Z,24.8,59.5,910\n"
+
+		resultado, err := LerCSV(strings.NewReader(entrada), OpcoesCSV{})
+
+		require.NoError(t, err)
+		assert.Zero(t, resultado.Rejeitadas)
+		require.Len(t, resultado.Leituras, 2)
+		assert.Equal(t, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC), resultado.Leituras[0].DataMedicao)
+		assert.Nil(t, resultado.Leituras[0].CO2)
+		require.NotNil(t, resultado.Leituras[1].CO2)
+		assert.Equal(t, 910.0, *resultado.Leituras[1].CO2)
+	})
+
+	t.Run("Success - Formato Brasileiro com Mapeamento e Fuso", func(t *testing.T) {
+		local, err := time.LoadLocation("America/Sao_Paulo")
+		require.NoError(t, err)
+		entrada := "Registro;Temp (C);UR (%)\n" +
+			"01/05/2026 09:00;23,4;71,2\n"
+
+		resultado, err := LerCSV(strings.NewReader(entrada), OpcoesCSV{
+			Delimitador:    ';',
+			DecimalVirgula: true,
+			ColunaData:     "Registro",
+			FormatoData:    "02/01/2006 15:04",
+			Local:          local,
+			Colunas:        map[string]string{MetricaTemperatura: "Temp (C)", MetricaUmidade: "UR (%)"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resultado.Leituras, 1)
+		assert.True(t, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC).Equal(resultado.Leituras[0].DataMedicao))
+		assert.Equal(t, 23.4, resultado.Leituras[0].Temperatura)
+		assert.Equal(t, 71.2, resultado.Leituras[0].Umidade)
+	})
+
+	t.Run("Success - Data Unix", func(t *testing.T) {
+		entrada := "time,temp,rh\n1746100800,20,50\n"
+
+		resultado, err := LerCSV(strings.NewReader(entrada), OpcoesCSV{FormatoData: FormatoDataUnix})
+
+		require.NoError(t, err)
+		require.Len(t, resultado.Leituras, 1)
+		assert.Equal(t, time.Unix(1746100800, 0).UTC(), resultado.Leituras[0].DataMedicao)
+	})
+
+	t.Run("Rejeita Linhas Inválidas sem Abortar", func(t *testing.T) {
+		entrada := "data,temperatura,umidade\n" +
+			"2026-05-01 12:00,24,60\n" +
+			"ontem,24,60\n" +
+			"2026-05-01 12:10,abc,60\n" +
+			"2026-05-01 12:15,24,\n" +
+			"2026-05-01 12:20,95,60\n" +
+			"\n" +
+			"2026-05-01 12:25,25,61\n"
+
+		resultado, err := LerCSV(strings.NewReader(entrada), OpcoesCSV{})
+
+		require.NoError(t, err)
+		assert.Len(t, resultado.Leituras, 2)
+		assert.Equal(t, 4, resultado.Rejeitadas)
+		require.Len(t, resultado.Rejeicoes, 4)
+		assert.Equal(t, 3, resultado.Rejeicoes[0].Linha)
+		assert.Equal(t, 6, resultado.Rejeicoes[3].Linha)
+	})
+
+	t.Run("Error - Sem Colunas Obrigatórias", func(t *testing.T) {
+		_, err := LerCSV(strings.NewReader("data,co2\n2026-05-01 12:00,800\n"), OpcoesCSV{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - Coluna Mapeada Inexistente", func(t *testing.T) {
+		_, err := LerCSV(strings.NewReader("data,temperatura,umidade\n"), OpcoesCSV{
+			Colunas: map[string]string{MetricaTemperatura: "T", MetricaUmidade: "umidade"},
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - Arquivo Vazio", func(t *testing.T) {
+		_, err := LerCSV(strings.NewReader(""), OpcoesCSV{})
+
+		assert.Error(t, err)
+	})
+}
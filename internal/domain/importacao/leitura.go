@@ -0,0 +1,135 @@
+// Package importacao converte históricos de sensores (Influx line protocol, CSV de
+// data loggers) em leituras de microclima, separando as linhas rejeitadas.
+package importacao
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// MaxRejeicoesDetalhadas limita quantas linhas rejeitadas são descritas no resultado;
+// acima disso apenas o total é contado.
+const MaxRejeicoesDetalhadas = 1000
+
+// Métricas reconhecidas nos arquivos importados
+const (
+	MetricaTemperatura  = "temperatura"
+	MetricaUmidade      = "umidade"
+	MetricaLuminosidade = "luminosidade"
+	MetricaCO2          = "co2"
+	MetricaUmidadeSolo  = "umidade_solo"
+	MetricaPPFD         = "ppfd"
+)
+
+// aliasesMetrica associa nomes usuais de campos e colunas às métricas
+var aliasesMetrica = map[string]string{
+	"temperatura":    MetricaTemperatura,
+	"temperature":    MetricaTemperatura,
+	"temp":           MetricaTemperatura,
+	"temp_c":         MetricaTemperatura,
+	"umidade":        MetricaUmidade,
+	"humidity":       MetricaUmidade,
+	"hum":            MetricaUmidade,
+	"rh":             MetricaUmidade,
+	"luminosidade":   MetricaLuminosidade,
+	"lux":            MetricaLuminosidade,
+	"illuminance":    MetricaLuminosidade,
+	"light":          MetricaLuminosidade,
+	"co2":            MetricaCO2,
+	"eco2":           MetricaCO2,
+	"carbon_dioxide": MetricaCO2,
+	"umidade_solo":   MetricaUmidadeSolo,
+	"soil_moisture":  MetricaUmidadeSolo,
+	"moisture":       MetricaUmidadeSolo,
+	"ppfd":           MetricaPPFD,
+	"par":            MetricaPPFD,
+}
+
+// MetricaPorNome resolve o nome de um campo ou coluna para a métrica correspondente
+func MetricaPorNome(nome string) (string, bool) {
+	metrica, ok := aliasesMetrica[strings.ToLower(strings.TrimSpace(nome))]
+	return metrica, ok
+}
+
+// LinhaRejeitada descreve uma linha do arquivo que não foi importada
+type LinhaRejeitada struct {
+	Linha  int    `json:"linha"`
+	Motivo string `json:"motivo"`
+}
+
+// Resultado reúne as leituras válidas e as rejeições de uma importação
+type Resultado struct {
+	Leituras   []entity.Microclima
+	Rejeitadas int
+	Rejeicoes  []LinhaRejeitada
+}
+
+func (r *Resultado) rejeitar(linha int, formato string, args ...any) {
+	r.Rejeitadas++
+	if len(r.Rejeicoes) < MaxRejeicoesDetalhadas {
+		r.Rejeicoes = append(r.Rejeicoes, LinhaRejeitada{Linha: linha, Motivo: fmt.Sprintf(formato, args...)})
+	}
+}
+
+// parcial acumula os valores de uma leitura até que ela esteja completa
+type parcial struct {
+	linha   int
+	data    time.Time
+	valores map[string]float64
+}
+
+// leitura valida os limites (os mesmos da ingestão pela API) e monta o Microclima
+func (p *parcial) leitura() (entity.Microclima, error) {
+	temperatura, okT := p.valores[MetricaTemperatura]
+	umidade, okU := p.valores[MetricaUmidade]
+	if !okT || !okU {
+		return entity.Microclima{}, fmt.Errorf("leitura sem temperatura e umidade")
+	}
+	for metrica, valor := range p.valores {
+		if err := validarLimite(metrica, valor); err != nil {
+			return entity.Microclima{}, err
+		}
+	}
+
+	leitura := entity.Microclima{
+		DataMedicao:  p.data,
+		Temperatura:  temperatura,
+		Umidade:      umidade,
+		Luminosidade: p.valores[MetricaLuminosidade],
+	}
+	opcional := func(metrica string) *float64 {
+		if valor, ok := p.valores[metrica]; ok {
+			return &valor
+		}
+		return nil
+	}
+	leitura.CO2 = opcional(MetricaCO2)
+	leitura.UmidadeSolo = opcional(MetricaUmidadeSolo)
+	leitura.PPFD = opcional(MetricaPPFD)
+	return leitura, nil
+}
+
+// limites espelha as regras de binding de dto.LeituraMicroclimaDTO
+var limites = map[string][2]float64{
+	MetricaTemperatura:  {-50, 80},
+	MetricaUmidade:      {0, 100},
+	MetricaUmidadeSolo:  {0, 100},
+	MetricaLuminosidade: {0, -1},
+	MetricaCO2:          {0, -1},
+	MetricaPPFD:         {0, -1},
+}
+
+func validarLimite(metrica string, valor float64) error {
+	if math.IsNaN(valor) || math.IsInf(valor, 0) {
+		return fmt.Errorf("%s com valor inválido", metrica)
+	}
+	limite := limites[metrica]
+	if valor < limite[0] || (limite[1] >= limite[0] && valor > limite[1]) {
+		return fmt.Errorf("%s fora dos limites aceitos: %g", metrica, valor)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package importacao
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tamanhoMaximoLinha limita o tamanho de cada linha lida dos arquivos importados
+const tamanhoMaximoLinha = 1 << 20
+
+// PrecisoesLineProtocol mapeia o parâmetro precision da API de escrita do Influx
+var PrecisoesLineProtocol = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+}
+
+// OpcoesLineProtocol configura a leitura de line protocol
+type OpcoesLineProtocol struct {
+	Precisao time.Duration // unidade do timestamp (padrão: nanossegundos)
+	Medicao  string        // se informado, ignora as linhas de outras measurements
+	Agora    time.Time     // usado nas linhas sem timestamp
+}
+
+// LerLineProtocol converte linhas no formato do InfluxDB em leituras. Campos com nomes
+// conhecidos (temperature, humidity, co2...) viram métricas e os demais são ignorados.
+// Linhas com o mesmo timestamp são combinadas, permitindo measurements separadas por sensor.
+func LerLineProtocol(r io.Reader, opcoes OpcoesLineProtocol) (*Resultado, error) {
+	if opcoes.Precisao <= 0 {
+		opcoes.Precisao = time.Nanosecond
+	}
+
+	resultado := &Resultado{}
+	porInstante := make(map[int64]*parcial)
+	var ordem []int64
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), tamanhoMaximoLinha)
+	numero := 0
+	for scanner.Scan() {
+		numero++
+		linha := strings.TrimSpace(scanner.Text())
+		if linha == "" || strings.HasPrefix(linha, "#") {
+			continue
+		}
+
+		medicao, campos, timestamp, err := dividirLinha(linha)
+		if err != nil {
+			resultado.rejeitar(numero, "%v", err)
+			continue
+		}
+		if opcoes.Medicao != "" && medicao != opcoes.Medicao {
+			continue
+		}
+
+		valores, err := camposReconhecidos(campos)
+		if err != nil {
+			resultado.rejeitar(numero, "%v", err)
+			continue
+		}
+		if len(valores) == 0 {
+			resultado.rejeitar(numero, "nenhum campo reconhecido")
+			continue
+		}
+
+		instante := opcoes.Agora.UnixNano()
+		if timestamp != "" {
+			t, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				resultado.rejeitar(numero, "timestamp inválido: %s", timestamp)
+				continue
+			}
+			instante = t * int64(opcoes.Precisao)
+		}
+
+		p, ok := porInstante[instante]
+		if !ok {
+			p = &parcial{linha: numero, data: time.Unix(0, instante).UTC(), valores: make(map[string]float64)}
+			porInstante[instante] = p
+			ordem = append(ordem, instante)
+		}
+		for metrica, valor := range valores {
+			p.valores[metrica] = valor
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("falha ao ler line protocol: %w", err)
+	}
+
+	for _, instante := range ordem {
+		p := porInstante[instante]
+		leitura, err := p.leitura()
+		if err != nil {
+			resultado.rejeitar(p.linha, "%v", err)
+			continue
+		}
+		resultado.Leituras = append(resultado.Leituras, leitura)
+	}
+	return resultado, nil
+}
+
+// dividirLinha separa measurement, conjunto de campos e timestamp, respeitando escapes
+// com barra invertida e strings entre aspas nos campos
+func dividirLinha(linha string) (medicao, campos, timestamp string, err error) {
+	secoes := dividir(linha, ' ', true)
+	if len(secoes) < 2 || len(secoes) > 3 {
+		return "", "", "", errors.New("linha fora do formato measurement[,tags] campos [timestamp]")
+	}
+	chave := dividir(secoes[0], ',', false)
+	medicao = desescapar(chave[0])
+	campos = secoes[1]
+	if len(secoes) == 3 {
+		timestamp = secoes[2]
+	}
+	return medicao, campos, timestamp, nil
+}
+
+// camposReconhecidos extrai os campos numéricos com nomes de métricas conhecidas
+func camposReconhecidos(campos string) (map[string]float64, error) {
+	valores := make(map[string]float64)
+	for _, campo := range dividir(campos, ',', true) {
+		partes := dividir(campo, '=', true)
+		if len(partes) != 2 {
+			return nil, fmt.Errorf("campo inválido: %s", campo)
+		}
+		metrica, ok := MetricaPorNome(desescapar(partes[0]))
+		if !ok {
+			continue
+		}
+		bruto := partes[1]
+		if strings.HasPrefix(bruto, `"`) {
+			return nil, fmt.Errorf("campo %s deve ser numérico", partes[0])
+		}
+		bruto = strings.TrimRight(bruto, "iu")
+		valor, err := strconv.ParseFloat(bruto, 64)
+		if err != nil {
+			return nil, fmt.Errorf("valor inválido no campo %s: %s", partes[0], partes[1])
+		}
+		valores[metrica] = valor
+	}
+	return valores, nil
+}
+
+// dividir separa s pelo separador, ignorando separadores escapados e, se aspas for true,
+// os que estiverem dentro de strings entre aspas duplas
+func dividir(s string, separador byte, aspas bool) []string {
+	var partes []string
+	inicio, dentroAspas := 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case c == '"' && aspas:
+			dentroAspas = !dentroAspas
+		case c == separador && !dentroAspas:
+			partes = append(partes, s[inicio:i])
+			inicio = i + 1
+		}
+	}
+	return append(partes, s[inicio:])
+}
+
+func desescapar(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package importacao
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLerLineProtocol(t *testing.T) {
+	t.Run("Success - Combina Measurements pelo Timestamp", func(t *testing.T) {
+		entrada := strings.Join([]string{
+			"# exportado do Influx",
+			"clima,sensor=sht31 temperature=24.5,humidity=61i 1746100800",
+			`co2,sensor=scd40,local=tenda\ 1 co2=850,firmware="1.2" 1746100800`,
+			"clima,sensor=sht31 temperature=25,humidity=60 1746101100",
+		}, "\n")
+
+		resultado, err := LerLineProtocol(strings.NewReader(entrada), OpcoesLineProtocol{Precisao: time.Second})
+
+		require.NoError(t, err)
+		assert.Zero(t, resultado.Rejeitadas)
+		require.Len(t, resultado.Leituras, 2)
+		assert.Equal(t, time.Unix(1746100800, 0).UTC(), resultado.Leituras[0].DataMedicao)
+		assert.Equal(t, 24.5, resultado.Leituras[0].Temperatura)
+		assert.Equal(t, 61.0, resultado.Leituras[0].Umidade)
+		require.NotNil(t, resultado.Leituras[0].CO2)
+		assert.Equal(t, 850.0, *resultado.Leituras[0].CO2)
+		assert.Nil(t, resultado.Leituras[1].CO2)
+	})
+
+	t.Run("Success - Filtra Measurement e Usa Agora sem Timestamp", func(t *testing.T) {
+		agora := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		entrada := "clima temperature=22,humidity=55\noutro temperature=99,humidity=99"
+
+		resultado, err := LerLineProtocol(strings.NewReader(entrada), OpcoesLineProtocol{Medicao: "clima", Agora: agora})
+
+		require.NoError(t, err)
+		require.Len(t, resultado.Leituras, 1)
+		assert.Equal(t, agora, resultado.Leituras[0].DataMedicao)
+		assert.Equal(t, 22.0, resultado.Leituras[0].Temperatura)
+	})
+
+	t.Run("Rejeita Linhas Inválidas sem Abortar", func(t *testing.T) {
+		entrada := strings.Join([]string{
+			"clima temperature=24,humidity=60 1746100800000000000",
+			"clima sem_campos",
+			"clima temperature=24,humidity=60 amanha",
+			`clima temperature="quente",humidity=60 1746100900000000000`,
+			"clima pressure=1013 1746101000000000000",
+			"clima temperature=24,humidity=140 1746101100000000000",
+			"clima temperature=24 1746101200000000000",
+		}, "\n")
+
+		resultado, err := LerLineProtocol(strings.NewReader(entrada), OpcoesLineProtocol{})
+
+		require.NoError(t, err)
+		assert.Len(t, resultado.Leituras, 1)
+		assert.Equal(t, 6, resultado.Rejeitadas)
+		linhas := make([]int, 0, len(resultado.Rejeicoes))
+		for _, rejeicao := range resultado.Rejeicoes {
+			linhas = append(linhas, rejeicao.Linha)
+		}
+		assert.ElementsMatch(t, []int{2, 3, 4, 5, 6, 7}, linhas)
+	})
+}
+
+func TestDividir(t *testing.T) {
+	assert.Equal(t, []string{`m\ 1,t=a`, `f="a b",g=1`, "10"}, dividir(`m\ 1,t=a f="a b",g=1 10`, ' ', true))
+	assert.Equal(t, "m 1", desescapar(`m\ 1`))
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type RegraAlertaRepositorio interface {
+	Criar(regra *entity.RegraAlerta) error
+	BuscarPorID(id uint) (*entity.RegraAlerta, error)
+	// ListarPorAmbiente retorna apenas as regras do usuário no ambiente
+	ListarPorAmbiente(ambienteID, usuarioID uint) ([]entity.RegraAlerta, error)
+	ListarAtivasPorAmbiente(ambienteID uint) ([]entity.RegraAlerta, error)
+	Atualizar(regra *entity.RegraAlerta) error
+	// AtualizarCondicao grava apenas o início da condição, sem sobrescrever a configuração da regra
+	AtualizarCondicao(id uint, desde *time.Time) error
+	Deletar(id uint) error
+}
+
+// FiltroAlertas restringe a listagem de alertas. Campos zerados não filtram.
+type FiltroAlertas struct {
+	UsuarioID  uint
+	AmbienteID uint
+	Status     entity.StatusAlerta
+	Page       int
+	Limit      int
+}
+
+type AlertaRepositorio interface {
+	Criar(alerta *entity.Alerta) error
+	Atualizar(alerta *entity.Alerta) error
+	BuscarPorID(id uint) (*entity.Alerta, error)
+	// BuscarAtivoPorRegra retorna o alerta aberto ou reconhecido da regra, ou gorm.ErrRecordNotFound
+	BuscarAtivoPorRegra(regraID uint) (*entity.Alerta, error)
+	Listar(filtro FiltroAlertas) ([]entity.Alerta, int64, error)
+}
+
+// FiltroLembretes restringe a listagem de lembretes. Campos zerados não filtram.
+type FiltroLembretes struct {
+	UsuarioID uint
+	// Status: agendado (com entrega futura), nao_lido (já entregue e não lido) ou lido
+	Status string
+	Page   int
+	Limit  int
+}
+
+type LembreteRepositorio interface {
+	Criar(lembrete *entity.Lembrete) error
+	BuscarPorID(id uint) (*entity.Lembrete, error)
+	// Listar ordena pela data, das mais recentes para as mais antigas
+	Listar(filtro FiltroLembretes) ([]entity.Lembrete, int64, error)
+	Atualizar(lembrete *entity.Lembrete) error
+	Deletar(id uint) error
+	// ListarAtivosPorUsuario retorna os lembretes agendados que ainda vão acontecer a partir
+	// de desde, incluindo os repetidos com início anterior
+	ListarAtivosPorUsuario(usuarioID uint, desde time.Time) ([]entity.Lembrete, error)
+	// ListarParaEnvio retorna os lembretes com entrega prevista até ate, dos mais antigos aos mais recentes
+	ListarParaEnvio(ate time.Time, limite int) ([]entity.Lembrete, error)
+}
+
+type PreferenciaNotificacaoRepositorio interface {
+	// BuscarPorUsuario retorna gorm.ErrRecordNotFound se o usuário ainda não configurou as preferências
+	BuscarPorUsuario(usuarioID uint) (*entity.PreferenciaNotificacao, error)
+	// Salvar cria ou atualiza as preferências do usuário
+	Salvar(preferencia *entity.PreferenciaNotificacao) error
+}
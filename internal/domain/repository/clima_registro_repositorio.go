@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type ClimaRegistroRepositorio interface {
+	// Salvar grava os registros, substituindo os que já existem para o mesmo ambiente e data
+	Salvar(registros []entity.ClimaRegistro) error
+	// ListarPorAmbiente retorna os registros entre as datas de calendário inicio e fim (inclusivas)
+	ListarPorAmbiente(ambienteID uint, inicio, fim time.Time) ([]entity.ClimaRegistro, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type CronogramaCultivoRepositorio interface {
+	// Criar grava o cronograma com os itens
+	Criar(cronograma *entity.CronogramaCultivo) error
+	// BuscarPorID retorna o cronograma com os itens ordenados
+	BuscarPorID(id uint) (*entity.CronogramaCultivo, error)
+	ListarPorUsuario(usuarioID uint) ([]entity.CronogramaCultivo, error)
+	// Atualizar substitui os itens do cronograma na mesma transação
+	Atualizar(cronograma *entity.CronogramaCultivo) error
+	// Deletar remove o cronograma com os itens e as aplicações
+	Deletar(id uint) error
+}
+
+type AplicacaoCronogramaRepositorio interface {
+	Criar(aplicacao *entity.AplicacaoCronograma) error
+	// BuscarPorID retorna a aplicação com o cronograma e os itens
+	BuscarPorID(id uint) (*entity.AplicacaoCronograma, error)
+	BuscarPorCronogramaEPlanta(cronogramaID, plantaID uint) (*entity.AplicacaoCronograma, error)
+	ListarPorCronograma(cronogramaID uint) ([]entity.AplicacaoCronograma, error)
+	// ListarPorPlanta retorna as aplicações da planta com o cronograma e os itens
+	ListarPorPlanta(plantaID uint) ([]entity.AplicacaoCronograma, error)
+	Deletar(id uint) error
+}
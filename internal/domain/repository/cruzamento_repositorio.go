@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type CruzamentoRepositorio interface {
+	// Criar grava a genética resultante e o cruzamento na mesma transação
+	Criar(cruzamento *entity.Cruzamento, genetica *entity.Genetica) error
+	// BuscarPorID carrega as genéticas do cruzamento
+	BuscarPorID(id uint) (*entity.Cruzamento, error)
+	// Listar carrega as genéticas e ordena dos cruzamentos mais recentes para os mais antigos
+	Listar(usuarioID uint) ([]entity.Cruzamento, error)
+	// Deletar remove o cruzamento e as polinizações dele e desvincula os lotes de sementes;
+	// a genética resultante continua no catálogo
+	Deletar(id uint) error
+	RegistrarPolinizacao(polinizacao *entity.Polinizacao) error
+	BuscarPolinizacao(id uint) (*entity.Polinizacao, error)
+	// ListarPolinizacoes carrega as plantas e ordena pela data
+	ListarPolinizacoes(cruzamentoID uint) ([]entity.Polinizacao, error)
+}
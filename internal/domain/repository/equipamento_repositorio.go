@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type EquipamentoRepositorio interface {
+	Criar(equipamento *entity.Equipamento) error
+	BuscarPorID(id uint) (*entity.Equipamento, error)
+	ListarPorAmbiente(ambienteID uint) ([]entity.Equipamento, error)
+	Atualizar(equipamento *entity.Equipamento) error
+	Deletar(id uint) error
+}
+
+type TarifaEnergiaRepositorio interface {
+	Criar(tarifa *entity.TarifaEnergia) error
+	BuscarPorID(id uint) (*entity.TarifaEnergia, error)
+	// ListarPorUsuario retorna as tarifas do usuário em ordem de vigência
+	ListarPorUsuario(usuarioID uint) ([]entity.TarifaEnergia, error)
+	Deletar(id uint) error
+	// SalvarBandeira cria ou substitui a bandeira do usuário na competência
+	SalvarBandeira(bandeira *entity.BandeiraMensal) error
+	ListarBandeiras(usuarioID uint) ([]entity.BandeiraMensal, error)
+}
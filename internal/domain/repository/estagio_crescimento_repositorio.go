@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type EstagioCrescimentoRepositorio interface {
+	// IniciarEstagio encerra o estágio aberto da planta e cria o novo na mesma transação
+	IniciarEstagio(estagio *entity.EstagioCrescimento) error
+	BuscarAtual(plantaID uint) (*entity.EstagioCrescimento, error)
+	ListarPorPlanta(plantaID uint) ([]entity.EstagioCrescimento, error)
+	ListarAtuaisPorAmbiente(ambienteID uint) ([]entity.EstagioCrescimento, error)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// FiltroItensEstoque restringe a listagem do inventário. Campos zerados não filtram.
+type FiltroItensEstoque struct {
+	UsuarioID    uint
+	Categoria    entity.CategoriaEstoque
+	AbaixoMinimo bool
+	VencendoAte  *time.Time // validade até esta data, inclusive as já vencidas
+}
+
+// FiltroConsumosCiclo seleciona os consumos de um ciclo: os lançados no diário e os das
+// plantas dele no período
+type FiltroConsumosCiclo struct {
+	DiarioCultivoID uint
+	PlantaIDs       []uint
+	De              time.Time
+	Ate             *time.Time
+}
+
+// AplicarMovimentacao recebe o item com o saldo atual do banco, já travado na transação, e ajusta
+// o item e a movimentação antes de serem gravados; um erro desfaz a transação
+type AplicarMovimentacao func(item *entity.ItemEstoque, movimentacao *entity.MovimentacaoEstoque) error
+
+// MovimentacoesEstoque são movimentações gravadas juntas: ou entram todas, ou nenhuma.
+// Confirmadas, quando definida, é chamada depois do commit (ex.: avisos de estoque baixo).
+type MovimentacoesEstoque struct {
+	Itens       []*entity.MovimentacaoEstoque
+	Aplicar     AplicarMovimentacao
+	Confirmadas func()
+}
+
+// Confirmar executa Confirmadas, se houver; os repositórios chamam depois do commit
+func (m *MovimentacoesEstoque) Confirmar() {
+	if m != nil && m.Confirmadas != nil {
+		m.Confirmadas()
+	}
+}
+
+type EstoqueRepositorio interface {
+	CriarItem(item *entity.ItemEstoque) error
+	BuscarItem(id uint) (*entity.ItemEstoque, error)
+	// ListarItens ordena por categoria e nome
+	ListarItens(filtro FiltroItensEstoque) ([]entity.ItemEstoque, error)
+	// AtualizarItem grava o cadastro do item; o saldo e o custo médio só mudam por movimentações
+	AtualizarItem(item *entity.ItemEstoque) error
+	// DeletarItem remove o item; as movimentações continuam contando no custo dos ciclos passados
+	DeletarItem(id uint) error
+	// RegistrarMovimentacoes trava os itens (SELECT ... FOR UPDATE) e grava as movimentações com o
+	// novo saldo, custo médio e validade de cada item numa transação só
+	RegistrarMovimentacoes(movimentacoes *MovimentacoesEstoque) error
+	// ListarMovimentacoes ordena pela data, das mais recentes para as mais antigas
+	ListarMovimentacoes(itemID uint) ([]entity.MovimentacaoEstoque, error)
+	// ListarConsumosCiclo retorna os consumos com o item, em ordem cronológica
+	ListarConsumosCiclo(filtro FiltroConsumosCiclo) ([]entity.MovimentacaoEstoque, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type FotoperiodoRepositorio interface {
+	Criar(fotoperiodo *entity.Fotoperiodo) error
+	BuscarPorID(id uint) (*entity.Fotoperiodo, error)
+	// ListarPorAmbiente retorna o histórico completo, em ordem de vigência
+	ListarPorAmbiente(ambienteID uint) ([]entity.Fotoperiodo, error)
+	Deletar(id uint) error
+}
@@ -10,4 +10,14 @@ type GeneticaRepositorio interface {
 	BuscarPorID(id uint) (*entity.Genetica, error)
 	Atualizar(genetica *entity.Genetica) error
 	Deletar(id uint) error
-}
\ No newline at end of file
+	// BuscarPorIDs retorna as genéticas encontradas, sem erro para IDs inexistentes
+	BuscarPorIDs(ids []uint) ([]entity.Genetica, error)
+	// ListarCatalogo retorna todas as genéticas, ordenadas por nome
+	ListarCatalogo() ([]entity.Genetica, error)
+	// CriarEmLote grava as genéticas na mesma transação
+	CriarEmLote(geneticas []entity.Genetica) error
+	// Mesclar atualiza o destino, aponta para ele as plantas, os pacotes de sementes, os
+	// cruzamentos e as genéticas filhas das duplicadas e remove as duplicadas, tudo na mesma
+	// transação
+	Mesclar(destino *entity.Genetica, duplicadas []uint) (plantas, pacotes int64, err error)
+}
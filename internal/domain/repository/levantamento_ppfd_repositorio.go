@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type LevantamentoPPFDRepositorio interface {
+	// Criar grava o levantamento junto com os pontos
+	Criar(levantamento *entity.LevantamentoPPFD) error
+	BuscarPorID(id uint) (*entity.LevantamentoPPFD, error)
+	// ListarPorAmbiente retorna os levantamentos com os pontos, do mais recente ao mais antigo
+	ListarPorAmbiente(ambienteID uint) ([]entity.LevantamentoPPFD, error)
+	Deletar(id uint) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type MicroclimaRepositorio interface {
+	Criar(leitura *entity.Microclima) error
+	CriarEmLote(leituras []entity.Microclima) error
+	ListarPorPeriodo(ambienteID uint, inicio, fim time.Time, limit int) ([]entity.Microclima, error)
+	Agregar(ambienteID uint, inicio, fim time.Time, intervalo time.Duration) ([]entity.MicroclimaAgregado, error)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// FiltroNotificacoes restringe a listagem da central de notificações
+type FiltroNotificacoes struct {
+	UsuarioID uint
+	Categoria string
+	NaoLidas  bool
+	Page      int
+	Limit     int
+}
+
+type NotificacaoRepositorio interface {
+	Criar(notificacao *entity.Notificacao) error
+	BuscarPorID(id uint) (*entity.Notificacao, error)
+	Listar(filtro FiltroNotificacoes) ([]entity.Notificacao, int64, error)
+	Atualizar(notificacao *entity.Notificacao) error
+	// ListarDesde retorna, da mais antiga para a mais nova, as notificações do usuário com ID maior que aposID
+	ListarDesde(usuarioID, aposID uint, limite int) ([]entity.Notificacao, error)
+	// UltimoID retorna o ID da notificação mais recente do usuário, ou 0 se não houver
+	UltimoID(usuarioID uint) (uint, error)
+	ContarNaoLidas(usuarioID uint) (int64, error)
+	// MarcarLidas marca como lidas as notificações não lidas do usuário; sem ids, todas as da
+	// categoria informada (ou todas, sem categoria)
+	MarcarLidas(usuarioID uint, ids []uint, categoria string, em time.Time) (int64, error)
+}
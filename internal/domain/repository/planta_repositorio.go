@@ -14,4 +14,8 @@ type PlantaRepositorio interface {
 	BuscarPorStatus(status string) ([]entity.Planta, error)
 	ExistePorNome(nome string) bool
 	CriarRegistroDiario(registro *entity.RegistroDiario) error
+	// ListarPorDiario retorna as plantas acompanhadas no diário de cultivo
+	ListarPorDiario(diarioID uint) ([]entity.Planta, error)
+	// ListarAtivasPorUsuario retorna as plantas ativas do usuário com a genética
+	ListarAtivasPorUsuario(usuarioID uint) ([]entity.Planta, error)
 }
\ No newline at end of file
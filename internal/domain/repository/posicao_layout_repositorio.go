@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type PosicaoLayoutRepositorio interface {
+	Criar(posicao *entity.PosicaoLayout) error
+	BuscarPorID(id uint) (*entity.PosicaoLayout, error)
+	// ListarPorAmbiente retorna as posições do ambiente com a planta e o vaso carregados
+	ListarPorAmbiente(ambienteID uint) ([]entity.PosicaoLayout, error)
+	Atualizar(posicao *entity.PosicaoLayout) error
+	Deletar(id uint) error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// FiltroRegas restringe a listagem de regas do usuário
+type FiltroRegas struct {
+	UsuarioID       uint
+	PlantaID        uint
+	DiarioCultivoID uint
+	De              *time.Time
+	Ate             *time.Time // exclusivo
+	Page            int
+	Limit           int
+}
+
+type RegaRepositorio interface {
+	// Criar grava a rega junto com os nutrientes
+	Criar(rega *entity.Rega) error
+	BuscarPorID(id uint) (*entity.Rega, error)
+	// Listar retorna as regas com os nutrientes, da mais recente para a mais antiga
+	Listar(filtro FiltroRegas) ([]entity.Rega, int64, error)
+	// Atualizar substitui os nutrientes da rega
+	Atualizar(rega *entity.Rega) error
+	Deletar(id uint) error
+	// ListarPorPlanta retorna, em ordem cronológica, as regas da planta e as dos lotes dos diários
+	// de que ela faz parte, opcionalmente a partir de de e antes de ate
+	ListarPorPlanta(plantaID uint, de, ate *time.Time) ([]entity.Rega, error)
+	// ListarPorDiario retorna, em ordem cronológica, as regas do lote do diário e as individuais
+	// das plantas dele
+	ListarPorDiario(diarioID uint) ([]entity.Rega, error)
+}
+
+type ReceitaNutrienteRepositorio interface {
+	// Criar grava a receita junto com os itens
+	Criar(receita *entity.ReceitaNutriente) error
+	BuscarPorID(id uint) (*entity.ReceitaNutriente, error)
+	ListarPorUsuario(usuarioID uint) ([]entity.ReceitaNutriente, error)
+	// Atualizar substitui os itens da receita
+	Atualizar(receita *entity.ReceitaNutriente) error
+	Deletar(id uint) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type ReservatorioRepositorio interface {
+	// Criar grava o reservatório e liga as plantas informadas
+	Criar(reservatorio *entity.Reservatorio) error
+	// BuscarPorID retorna o reservatório com as plantas
+	BuscarPorID(id uint) (*entity.Reservatorio, error)
+	ListarPorUsuario(usuarioID uint) ([]entity.Reservatorio, error)
+	// Atualizar substitui as plantas ligadas ao reservatório
+	Atualizar(reservatorio *entity.Reservatorio) error
+	Deletar(id uint) error
+	// ListarComTrocaAutomatica retorna os reservatórios com intervalo de troca definido
+	ListarComTrocaAutomatica() ([]entity.Reservatorio, error)
+	// CriarManutencao grava a manutenção; numa troca, atualiza também a última troca do reservatório
+	CriarManutencao(manutencao *entity.ManutencaoReservatorio) error
+	// ListarManutencoes ordena pela data, das mais recentes para as mais antigas
+	ListarManutencoes(reservatorioID uint) ([]entity.ManutencaoReservatorio, error)
+	CriarLeitura(leitura *entity.LeituraReservatorio) error
+	// ListarLeituras retorna as leituras em ordem cronológica; limites nulos não filtram
+	ListarLeituras(reservatorioID uint, de, ate *time.Time) ([]entity.LeituraReservatorio, error)
+}
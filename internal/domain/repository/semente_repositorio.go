@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"errors"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// ErrSementesInsuficientes indica que o saldo do pacote no banco não cobre a retirada, por
+// exemplo porque outra requisição usou as sementes antes
+var ErrSementesInsuficientes = errors.New("sementes insuficientes no pacote")
+
+// FiltroPacotesSemente restringe a listagem do banco de sementes. Campos zerados não filtram.
+type FiltroPacotesSemente struct {
+	UsuarioID    uint
+	GeneticaID   uint
+	CruzamentoID uint
+	Breeder      string // sem diferenciar maiúsculas
+	ComSementes  bool   // apenas pacotes com sementes restantes
+}
+
+type SementeRepositorio interface {
+	CriarPacote(pacote *entity.PacoteSemente) error
+	// BuscarPacote carrega a genética do pacote
+	BuscarPacote(id uint) (*entity.PacoteSemente, error)
+	// ListarPacotes carrega a genética e ordena por breeder e data de aquisição
+	ListarPacotes(filtro FiltroPacotesSemente) ([]entity.PacoteSemente, error)
+	AtualizarPacote(pacote *entity.PacoteSemente) error
+	// DeletarPacote remove o pacote; as germinações continuam contando nas taxas de germinação
+	DeletarPacote(id uint) error
+	// RegistrarGerminacao grava a germinação e tira do saldo do pacote no banco as sementes que não
+	// germinaram, na mesma transação. pacote recebe o saldo gravado; sem saldo, retorna
+	// ErrSementesInsuficientes.
+	RegistrarGerminacao(pacote *entity.PacoteSemente, germinacao *entity.GerminacaoSemente) error
+	// ListarGerminacoes ordena pela data, das mais recentes para as mais antigas
+	ListarGerminacoes(pacoteID uint) ([]entity.GerminacaoSemente, error)
+	// ListarGerminacoesUsuario carrega o pacote e a genética de cada germinação, inclusive de
+	// pacotes removidos
+	ListarGerminacoesUsuario(usuarioID uint) ([]entity.GerminacaoSemente, error)
+	// CriarPlantas grava as plantas e tira uma semente por planta do saldo do pacote no banco, na
+	// mesma transação. pacote recebe o saldo gravado; sem saldo, retorna ErrSementesInsuficientes.
+	CriarPlantas(pacote *entity.PacoteSemente, plantas []entity.Planta) error
+}
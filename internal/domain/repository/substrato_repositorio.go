@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type SubstratoRepositorio interface {
+	Criar(substrato *entity.Substrato) error
+	BuscarPorID(id uint) (*entity.Substrato, error)
+	// Listar ordena por nome
+	Listar() ([]entity.Substrato, error)
+	Atualizar(substrato *entity.Substrato) error
+	Deletar(id uint) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type TabelaNutricaoRepositorio interface {
+	// Criar grava a tabela junto com os itens
+	Criar(tabela *entity.TabelaNutricao) error
+	BuscarPorID(id uint) (*entity.TabelaNutricao, error)
+	ListarPorUsuario(usuarioID uint) ([]entity.TabelaNutricao, error)
+	// Atualizar substitui os itens da tabela
+	Atualizar(tabela *entity.TabelaNutricao) error
+	Deletar(id uint) error
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// FiltroTarefas restringe a listagem de tarefas. Campos zerados não filtram. Com Agora
+// informado, o status considera atrasadas as pendentes agendadas antes desse instante,
+// mesmo que ainda não tenham sido marcadas.
+type FiltroTarefas struct {
+	UsuarioID       uint
+	Status          entity.StatusTarefa
+	PlantaID        uint
+	AmbienteID      uint
+	DiarioCultivoID uint
+	De              *time.Time // data agendada a partir de
+	Ate             *time.Time // data agendada antes de
+	Agora           time.Time
+	Page            int
+	Limit           int
+}
+
+// ErrTarefaJaConcluida indica que outra requisição concluiu a tarefa antes
+var ErrTarefaJaConcluida = errors.New("tarefa já concluída")
+
+type TarefaRepositorio interface {
+	Criar(tarefa *entity.Tarefa) error
+	// BuscarPorID retorna a tarefa com as fotos
+	BuscarPorID(id uint) (*entity.Tarefa, error)
+	// Listar ordena pela data agendada, das mais próximas para as mais distantes
+	Listar(filtro FiltroTarefas) ([]entity.Tarefa, int64, error)
+	Atualizar(tarefa *entity.Tarefa) error
+	// Concluir grava a tarefa concluída e as baixas de estoque numa transação só. Retorna
+	// ErrTarefaJaConcluida se a tarefa já estava concluída no banco.
+	Concluir(tarefa *entity.Tarefa, consumos *MovimentacoesEstoque) error
+	Deletar(id uint) error
+	// MarcarAtrasadas passa para atrasada as pendentes agendadas antes de agora
+	MarcarAtrasadas(agora time.Time) (int64, error)
+	// BuscarSucessora retorna a próxima ocorrência da série, mesmo que já tenha sido excluída
+	BuscarSucessora(id uint) (*entity.Tarefa, error)
+	// ListarUltimasRecorrentes retorna as últimas ocorrências das séries recorrentes cuja
+	// próxima data cai até ate
+	ListarUltimasRecorrentes(ate time.Time) ([]entity.Tarefa, error)
+	// ListarParaLembrete retorna as tarefas não concluídas agendadas até ate que ainda não foram lembradas
+	ListarParaLembrete(ate time.Time) ([]entity.Tarefa, error)
+	MarcarLembreteEnviado(id uint, em time.Time) error
+	// ListarPorAplicacao retorna todas as tarefas geradas por uma aplicação de cronograma
+	ListarPorAplicacao(aplicacaoID uint) ([]entity.Tarefa, error)
+	// ListarAbertasPorUsuario retorna as tarefas pendentes e atrasadas do usuário
+	ListarAbertasPorUsuario(usuarioID uint) ([]entity.Tarefa, error)
+	// ListarAbertasPorReservatorio retorna as tarefas de troca ainda não concluídas do reservatório
+	ListarAbertasPorReservatorio(reservatorioID uint) ([]entity.Tarefa, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type TransplanteRepositorio interface {
+	// Registrar grava o transplante, o novo recipiente da planta e a baixa dos insumos na mesma
+	// transação; as movimentações recebem o ID do transplante como origem
+	Registrar(transplante *entity.Transplante, consumos *MovimentacoesEstoque) error
+	// ListarPorPlanta carrega os vasos e substratos, inclusive os removidos, em ordem cronológica
+	ListarPorPlanta(plantaID uint) ([]entity.Transplante, error)
+	// DefinirRecipiente troca o vaso e o substrato da planta sem registrar transplante
+	DefinirRecipiente(plantaID uint, vasoID, substratoID *uint) error
+}
@@ -12,4 +12,8 @@ type UsuarioRepositorio interface {
 	Deletar(id uint) error
 	BuscarPorEmail(email string) (*entity.Usuario, error)
 	ExistePorEmail(email string) bool
+	// BuscarPorTokenCalendario busca o usuário pelo hash do token do feed iCalendar
+	BuscarPorTokenCalendario(hash string) (*entity.Usuario, error)
+	// DefinirTokenCalendario troca o hash do token do feed; nil revoga o feed
+	DefinirTokenCalendario(usuarioID uint, hash *string) error
 }
\ No newline at end of file
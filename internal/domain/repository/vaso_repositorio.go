@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+type VasoRepositorio interface {
+	Criar(vaso *entity.Vaso) error
+	BuscarPorID(id uint) (*entity.Vaso, error)
+	// Listar ordena por volume e nome
+	Listar() ([]entity.Vaso, error)
+	Atualizar(vaso *entity.Vaso) error
+	Deletar(id uint) error
+}
@@ -0,0 +1,565 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// timeoutNotificacao limita o tempo de entrega de cada notificação
+const timeoutNotificacao = 10 * time.Second
+
+// AlertaService gerencia as regras de alerta de ambiente e o ciclo de vida dos alertas.
+type AlertaService interface {
+	CriarRegra(ambienteID, usuarioID uint, regraDto *dto.RegraAlertaDTO) (*entity.RegraAlerta, error)
+	ListarRegras(ambienteID, usuarioID uint) ([]entity.RegraAlerta, error)
+	BuscarRegra(id, usuarioID uint) (*entity.RegraAlerta, error)
+	AtualizarRegra(id, usuarioID uint, regraDto *dto.RegraAlertaDTO) (*entity.RegraAlerta, error)
+	DeletarRegra(id, usuarioID uint) error
+	ListarAlertas(usuarioID uint, consulta *dto.ConsultaAlertasDTO) ([]entity.Alerta, int64, error)
+	Reconhecer(id, usuarioID uint) (*entity.Alerta, error)
+	Resolver(id, usuarioID uint) (*entity.Alerta, error)
+
+	// LeiturasRegistradas avalia as regras ativas do ambiente contra as novas leituras
+	LeiturasRegistradas(ambienteID uint, leituras []entity.Microclima) error
+}
+
+type alertaService struct {
+	regraRepositorio    repository.RegraAlertaRepositorio
+	repositorio         repository.AlertaRepositorio
+	ambienteRepositorio repository.AmbienteRepositorio
+	estagioRepositorio  repository.EstagioCrescimentoRepositorio
+	canais              map[string]CanalNotificacao
+	local               *time.Location
+	agora               func() time.Time
+}
+
+// NewAlertaService cria o serviço de alertas. local é o fuso usado para as janelas de horário
+// das regras; os canais são indexados pelo nome e selecionados por regra.
+func NewAlertaService(
+	regraRepositorio repository.RegraAlertaRepositorio,
+	repositorio repository.AlertaRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	estagioRepositorio repository.EstagioCrescimentoRepositorio,
+	local *time.Location,
+	canais ...CanalNotificacao,
+) AlertaService {
+	if local == nil {
+		local = time.Local
+	}
+	indice := make(map[string]CanalNotificacao, len(canais))
+	for _, canal := range canais {
+		indice[canal.Nome()] = canal
+	}
+	return &alertaService{
+		regraRepositorio:    regraRepositorio,
+		repositorio:         repositorio,
+		ambienteRepositorio: ambienteRepositorio,
+		estagioRepositorio:  estagioRepositorio,
+		canais:              indice,
+		local:               local,
+		agora:               time.Now,
+	}
+}
+
+func (s *alertaService) CriarRegra(ambienteID, usuarioID uint, regraDto *dto.RegraAlertaDTO) (*entity.RegraAlerta, error) {
+	if ambienteID == 0 || usuarioID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	if _, err := s.ambienteRepositorio.BuscarPorID(ambienteID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar ambiente com ID %d: %w", ambienteID, err)
+	}
+
+	regra := &entity.RegraAlerta{AmbienteID: ambienteID, UsuarioID: usuarioID, Ativa: true}
+	if err := aplicarRegraDTO(regra, regraDto); err != nil {
+		return nil, err
+	}
+	if err := s.regraRepositorio.Criar(regra); err != nil {
+		return nil, fmt.Errorf("falha ao criar regra de alerta: %w", err)
+	}
+	return regra, nil
+}
+
+func (s *alertaService) ListarRegras(ambienteID, usuarioID uint) ([]entity.RegraAlerta, error) {
+	if ambienteID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	return s.regraRepositorio.ListarPorAmbiente(ambienteID, usuarioID)
+}
+
+func (s *alertaService) BuscarRegra(id, usuarioID uint) (*entity.RegraAlerta, error) {
+	regra, err := s.regraRepositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar regra de alerta com ID %d: %w", id, err)
+	}
+	if regra.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return regra, nil
+}
+
+func (s *alertaService) AtualizarRegra(id, usuarioID uint, regraDto *dto.RegraAlertaDTO) (*entity.RegraAlerta, error) {
+	regra, err := s.BuscarRegra(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if err := aplicarRegraDTO(regra, regraDto); err != nil {
+		return nil, err
+	}
+	// A condição passa a ser avaliada do zero com a nova configuração
+	regra.CondicaoDesde = nil
+	if err := s.regraRepositorio.Atualizar(regra); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar regra de alerta com ID %d: %w", id, err)
+	}
+	return regra, nil
+}
+
+func (s *alertaService) DeletarRegra(id, usuarioID uint) error {
+	if _, err := s.BuscarRegra(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.regraRepositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar regra de alerta com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *alertaService) ListarAlertas(usuarioID uint, consulta *dto.ConsultaAlertasDTO) ([]entity.Alerta, int64, error) {
+	return s.repositorio.Listar(repository.FiltroAlertas{
+		UsuarioID:  usuarioID,
+		AmbienteID: consulta.AmbienteID,
+		Status:     entity.StatusAlerta(consulta.Status),
+		Page:       consulta.Page,
+		Limit:      consulta.Limit,
+	})
+}
+
+func (s *alertaService) Reconhecer(id, usuarioID uint) (*entity.Alerta, error) {
+	alerta, err := s.buscarAlerta(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if alerta.Status != entity.StatusAlertaAberto {
+		return nil, utils.ErrInvalidInput
+	}
+	agora := s.agora()
+	alerta.Status = entity.StatusAlertaReconhecido
+	alerta.ReconhecidoEm = &agora
+	if err := s.repositorio.Atualizar(alerta); err != nil {
+		return nil, fmt.Errorf("falha ao reconhecer alerta com ID %d: %w", id, err)
+	}
+	return alerta, nil
+}
+
+// Resolver encerra o alerta manualmente. Se a condição continuar, um novo alerta
+// só é aberto depois que a regra voltar a ficar satisfeita pela duração configurada.
+func (s *alertaService) Resolver(id, usuarioID uint) (*entity.Alerta, error) {
+	alerta, err := s.buscarAlerta(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if alerta.Status == entity.StatusAlertaResolvido {
+		return nil, utils.ErrInvalidInput
+	}
+	agora := s.agora()
+	alerta.Status = entity.StatusAlertaResolvido
+	alerta.ResolvidoEm = &agora
+	if err := s.repositorio.Atualizar(alerta); err != nil {
+		return nil, fmt.Errorf("falha ao resolver alerta com ID %d: %w", id, err)
+	}
+	if err := s.regraRepositorio.AtualizarCondicao(alerta.RegraAlertaID, nil); err != nil {
+		return nil, err
+	}
+	return alerta, nil
+}
+
+func (s *alertaService) buscarAlerta(id, usuarioID uint) (*entity.Alerta, error) {
+	alerta, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar alerta com ID %d: %w", id, err)
+	}
+	if alerta.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return alerta, nil
+}
+
+func (s *alertaService) LeiturasRegistradas(ambienteID uint, leituras []entity.Microclima) error {
+	if len(leituras) == 0 {
+		return nil
+	}
+	regras, err := s.regraRepositorio.ListarAtivasPorAmbiente(ambienteID)
+	if err != nil || len(regras) == 0 {
+		return err
+	}
+
+	ordenadas := make([]entity.Microclima, len(leituras))
+	copy(ordenadas, leituras)
+	sort.SliceStable(ordenadas, func(i, j int) bool {
+		return ordenadas[i].DataMedicao.Before(ordenadas[j].DataMedicao)
+	})
+
+	// O estágio do ambiente só é consultado se alguma regra tiver escopo por estágio
+	var estagio entity.EstagioPlanta
+	estagioCarregado := false
+
+	var errs []error
+	for i := range regras {
+		regra := &regras[i]
+		if regra.Estagios != "" {
+			if !estagioCarregado {
+				estagios, err := s.estagioRepositorio.ListarAtuaisPorAmbiente(ambienteID)
+				if err != nil {
+					return err
+				}
+				estagio, _ = estagioPredominante(estagios)
+				estagioCarregado = true
+			}
+			if !contemItem(regra.Estagios, string(estagio)) {
+				continue
+			}
+		}
+		if err := s.avaliarRegra(regra, ordenadas); err != nil {
+			errs = append(errs, fmt.Errorf("regra de alerta %d: %w", regra.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// avaliarRegra percorre as leituras em ordem cronológica, abrindo um alerta quando a
+// condição se mantém pela duração da regra e resolvendo-o quando a condição deixa de valer.
+// Uma leitura fora da janela de horário interrompe a contagem da duração.
+func (s *alertaService) avaliarRegra(regra *entity.RegraAlerta, leituras []entity.Microclima) error {
+	ativo, err := s.repositorio.BuscarAtivoPorRegra(regra.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("falha ao buscar alerta ativo: %w", err)
+	}
+
+	duracao := time.Duration(regra.DuracaoMinutos) * time.Minute
+	desde := regra.CondicaoDesde
+	ativoAlterado := false
+	var notificacoes []Notificacao
+
+	for _, leitura := range leituras {
+		valor, ok := valorMetrica(regra.Metrica, leitura)
+		if !ok {
+			continue
+		}
+		if !s.dentroDoHorario(regra, leitura.DataMedicao) {
+			desde = nil
+			continue
+		}
+		momento := leitura.DataMedicao
+
+		if !regra.Comparacao.Compara(valor, regra.Limite) {
+			desde = nil
+			if ativo != nil {
+				ativo.Status = entity.StatusAlertaResolvido
+				ativo.ResolvidoEm = &momento
+				ativo.UltimoValor = valor
+				ativo.UltimaLeitura = momento
+				if err := s.repositorio.Atualizar(ativo); err != nil {
+					return fmt.Errorf("falha ao resolver alerta %d: %w", ativo.ID, err)
+				}
+				notificacoes = append(notificacoes, notificacaoAlerta(regra, ativo,
+					fmt.Sprintf("Resolvido: %s", regra.Nome),
+					fmt.Sprintf("%s voltou a %s (limite %s).", rotuloMetrica(regra.Metrica), formatarValor(valor), formatarValor(regra.Limite))))
+				ativo, ativoAlterado = nil, false
+			}
+			continue
+		}
+
+		if desde == nil {
+			inicio := momento
+			desde = &inicio
+		}
+		if ativo != nil {
+			ativo.UltimoValor = valor
+			ativo.UltimaLeitura = momento
+			if piorQue(regra.Comparacao, valor, ativo.ValorPico) {
+				ativo.ValorPico = valor
+			}
+			ativoAlterado = true
+			continue
+		}
+		if momento.Sub(*desde) < duracao {
+			continue
+		}
+
+		ativo = &entity.Alerta{
+			RegraAlertaID: regra.ID,
+			AmbienteID:    regra.AmbienteID,
+			UsuarioID:     regra.UsuarioID,
+			Status:        entity.StatusAlertaAberto,
+			Metrica:       regra.Metrica,
+			Mensagem:      s.mensagemAlerta(regra, valor, *desde),
+			ValorInicial:  valor,
+			ValorPico:     valor,
+			UltimoValor:   valor,
+			IniciadoEm:    *desde,
+			UltimaLeitura: momento,
+		}
+		if err := s.repositorio.Criar(ativo); err != nil {
+			return fmt.Errorf("falha ao abrir alerta: %w", err)
+		}
+		notificacoes = append(notificacoes, notificacaoAlerta(regra, ativo, regra.Nome, ativo.Mensagem))
+	}
+
+	if ativo != nil && ativoAlterado {
+		if err := s.repositorio.Atualizar(ativo); err != nil {
+			return fmt.Errorf("falha ao atualizar alerta %d: %w", ativo.ID, err)
+		}
+	}
+	if !mesmoInstante(desde, regra.CondicaoDesde) {
+		if err := s.regraRepositorio.AtualizarCondicao(regra.ID, desde); err != nil {
+			return err
+		}
+		regra.CondicaoDesde = desde
+	}
+
+	var errs []error
+	for _, notificacao := range notificacoes {
+		errs = append(errs, s.notificar(regra, notificacao))
+	}
+	return errors.Join(errs...)
+}
+
+// notificar entrega a notificação em todos os canais da regra; falhas em um canal não impedem os demais
+func (s *alertaService) notificar(regra *entity.RegraAlerta, notificacao Notificacao) error {
+	var errs []error
+	for _, nome := range canaisDaRegra(regra) {
+		canal, ok := s.canais[nome]
+		if !ok {
+			errs = append(errs, fmt.Errorf("canal de notificação %q não configurado", nome))
+			continue
+		}
+		envio := notificacao
+		switch nome {
+		case CanalWebhook:
+			envio.Destino = regra.WebhookURL
+		case CanalEmail:
+			envio.Destino = regra.Email
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutNotificacao)
+		err := canal.Enviar(ctx, envio)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("falha ao notificar pelo canal %s: %w", nome, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *alertaService) mensagemAlerta(regra *entity.RegraAlerta, valor float64, desde time.Time) string {
+	ambiente := fmt.Sprintf("ambiente %d", regra.AmbienteID)
+	if a, err := s.ambienteRepositorio.BuscarPorID(regra.AmbienteID); err == nil && a.Nome != "" {
+		ambiente = a.Nome
+	}
+	return fmt.Sprintf("%s em %s: %s (%s %s) desde %s.",
+		regra.Nome,
+		ambiente,
+		formatarValor(valor),
+		descricaoComparacao[regra.Comparacao],
+		formatarValor(regra.Limite),
+		desde.In(s.local).Format("02/01/2006 15:04"),
+	)
+}
+
+// dentroDoHorario indica se o instante está na janela ativa da regra, no fuso configurado
+func (s *alertaService) dentroDoHorario(regra *entity.RegraAlerta, instante time.Time) bool {
+	inicio, okInicio := minutosDoDia(regra.HoraInicio)
+	fim, okFim := minutosDoDia(regra.HoraFim)
+	if !okInicio || !okFim || inicio == fim {
+		return true
+	}
+	local := instante.In(s.local)
+	return dentroDaJanela(inicio, fim, local.Hour()*60+local.Minute())
+}
+
+// dentroDaJanela indica se o minuto do dia está em [inicio, fim), em minutos desde a meia-noite
+func dentroDaJanela(inicio, fim, minutos int) bool {
+	if inicio < fim {
+		return minutos >= inicio && minutos < fim
+	}
+	// Janela que atravessa a meia-noite (ex.: 20:00 às 06:00)
+	return minutos >= inicio || minutos < fim
+}
+
+// aplicarRegraDTO copia os campos do DTO para a regra, validando as combinações de canais
+func aplicarRegraDTO(regra *entity.RegraAlerta, regraDto *dto.RegraAlertaDTO) error {
+	comparacao := entity.ComparacaoAlerta(regraDto.Comparacao)
+	if _, ok := descricaoComparacao[comparacao]; !ok || regraDto.Limite == nil {
+		return utils.ErrInvalidInput
+	}
+	canais := regraDto.Canais
+	if len(canais) == 0 {
+		canais = []string{CanalLembrete}
+	}
+	for _, canal := range canais {
+		if (canal == CanalWebhook && regraDto.WebhookURL == "") || (canal == CanalEmail && regraDto.Email == "") {
+			return utils.ErrInvalidInput
+		}
+	}
+
+	regra.Nome = regraDto.Nome
+	regra.Metrica = entity.MetricaAlerta(regraDto.Metrica)
+	regra.Comparacao = comparacao
+	regra.Limite = *regraDto.Limite
+	regra.DuracaoMinutos = regraDto.DuracaoMinutos
+	regra.HoraInicio = regraDto.HoraInicio
+	regra.HoraFim = regraDto.HoraFim
+	regra.Estagios = strings.Join(regraDto.Estagios, ",")
+	regra.Canais = strings.Join(canais, ",")
+	regra.WebhookURL = regraDto.WebhookURL
+	regra.Email = regraDto.Email
+	if regraDto.Ativa != nil {
+		regra.Ativa = *regraDto.Ativa
+	}
+	return nil
+}
+
+func notificacaoAlerta(regra *entity.RegraAlerta, alerta *entity.Alerta, titulo, mensagem string) Notificacao {
+	ambienteID := regra.AmbienteID
+	return Notificacao{
+		UsuarioID:  regra.UsuarioID,
+		Categoria:  entity.CategoriaNotificacaoAlerta,
+		Titulo:     titulo,
+		Mensagem:   mensagem,
+		AmbienteID: &ambienteID,
+		Dados: map[string]any{
+			"alerta_id":   alerta.ID,
+			"regra_id":    regra.ID,
+			"ambiente_id": regra.AmbienteID,
+			"metrica":     regra.Metrica,
+			"status":      alerta.Status,
+			"valor":       alerta.UltimoValor,
+			"limite":      regra.Limite,
+		},
+	}
+}
+
+// valorMetrica extrai da leitura o valor da métrica; métricas opcionais ausentes retornam false
+func valorMetrica(metrica entity.MetricaAlerta, leitura entity.Microclima) (float64, bool) {
+	switch metrica {
+	case entity.MetricaTemperatura:
+		return leitura.Temperatura, true
+	case entity.MetricaUmidade:
+		return leitura.Umidade, true
+	case entity.MetricaLuminosidade:
+		return leitura.Luminosidade, true
+	case entity.MetricaCO2:
+		return valorOpcional(leitura.CO2)
+	case entity.MetricaUmidadeSolo:
+		return valorOpcional(leitura.UmidadeSolo)
+	case entity.MetricaPPFD:
+		if leitura.PPFD != nil {
+			return *leitura.PPFD, true
+		}
+		return calculo.LuxParaPPFD(leitura.Luminosidade, 0), true
+	case entity.MetricaVPDAr:
+		return calculo.VPDAr(leitura.Temperatura, leitura.Umidade), true
+	case entity.MetricaVPDFolha:
+		return calculo.VPDFolha(leitura.Temperatura, leitura.Umidade, calculo.OffsetFolhaPadrao), true
+	}
+	return 0, false
+}
+
+func valorOpcional(valor *float64) (float64, bool) {
+	if valor == nil {
+		return 0, false
+	}
+	return *valor, true
+}
+
+var descricaoComparacao = map[entity.ComparacaoAlerta]string{
+	entity.ComparacaoMaior:      "acima de",
+	entity.ComparacaoMaiorIgual: "a partir de",
+	entity.ComparacaoMenor:      "abaixo de",
+	entity.ComparacaoMenorIgual: "até",
+}
+
+var rotulosMetrica = map[entity.MetricaAlerta]string{
+	entity.MetricaTemperatura:  "Temperatura",
+	entity.MetricaUmidade:      "Umidade",
+	entity.MetricaLuminosidade: "Luminosidade",
+	entity.MetricaCO2:          "CO2",
+	entity.MetricaUmidadeSolo:  "Umidade do solo",
+	entity.MetricaPPFD:         "PPFD",
+	entity.MetricaVPDAr:        "VPD do ar",
+	entity.MetricaVPDFolha:     "VPD da folha",
+}
+
+func rotuloMetrica(metrica entity.MetricaAlerta) string {
+	if rotulo, ok := rotulosMetrica[metrica]; ok {
+		return rotulo
+	}
+	return string(metrica)
+}
+
+// piorQue indica se o valor está mais distante do limite que o pico atual, no sentido da comparação
+func piorQue(comparacao entity.ComparacaoAlerta, valor, pico float64) bool {
+	if comparacao == entity.ComparacaoMenor || comparacao == entity.ComparacaoMenorIgual {
+		return valor < pico
+	}
+	return valor > pico
+}
+
+func formatarValor(valor float64) string {
+	return strconv.FormatFloat(math.Round(valor*100)/100, 'f', -1, 64)
+}
+
+func canaisDaRegra(regra *entity.RegraAlerta) []string {
+	if regra.Canais == "" {
+		return []string{CanalLembrete}
+	}
+	return strings.Split(regra.Canais, ",")
+}
+
+func contemItem(lista, item string) bool {
+	for _, valor := range strings.Split(lista, ",") {
+		if strings.TrimSpace(valor) == item {
+			return true
+		}
+	}
+	return false
+}
+
+// minutosDoDia converte "HH:MM" em minutos desde a meia-noite
+func minutosDoDia(hora string) (int, bool) {
+	t, err := time.Parse("15:04", hora)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func mesmoInstante(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
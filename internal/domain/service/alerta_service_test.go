@@ -0,0 +1,273 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type mocksAlerta struct {
+	regraRepo    *test.MockRegraAlertaRepositorio
+	alertaRepo   *test.MockAlertaRepositorio
+	ambienteRepo *test.MockAmbienteRepositorio
+	estagioRepo  *test.MockEstagioCrescimentoRepositorio
+	canal        *test.MockCanalNotificacao
+}
+
+func novoAlertaService() (service.AlertaService, mocksAlerta) {
+	m := mocksAlerta{
+		regraRepo:    new(test.MockRegraAlertaRepositorio),
+		alertaRepo:   new(test.MockAlertaRepositorio),
+		ambienteRepo: new(test.MockAmbienteRepositorio),
+		estagioRepo:  new(test.MockEstagioCrescimentoRepositorio),
+		canal:        &test.MockCanalNotificacao{NomeCanal: service.CanalLembrete},
+	}
+	servico := service.NewAlertaService(m.regraRepo, m.alertaRepo, m.ambienteRepo, m.estagioRepo, time.UTC, m.canal)
+	return servico, m
+}
+
+func leituraTemperatura(data time.Time, temperatura float64) entity.Microclima {
+	return entity.Microclima{AmbienteID: 1, DataMedicao: data, Temperatura: temperatura, Umidade: 60}
+}
+
+func TestAlertaService_LeiturasRegistradas(t *testing.T) {
+	inicio := time.Date(2026, 5, 1, 2, 0, 0, 0, time.UTC)
+	novaRegra := func() entity.RegraAlerta {
+		regra := entity.RegraAlerta{
+			AmbienteID:     1,
+			UsuarioID:      7,
+			Nome:           "Calor na estufa",
+			Metrica:        entity.MetricaTemperatura,
+			Comparacao:     entity.ComparacaoMaior,
+			Limite:         35,
+			DuracaoMinutos: 30,
+			Canais:         service.CanalLembrete,
+			Ativa:          true,
+		}
+		regra.ID = 3
+		return regra
+	}
+
+	t.Run("Success - Abre Alerta Após Duração", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		m.regraRepo.On("ListarAtivasPorAmbiente", uint(1)).Return([]entity.RegraAlerta{novaRegra()}, nil).Once()
+		m.alertaRepo.On("BuscarAtivoPorRegra", uint(3)).Return((*entity.Alerta)(nil), gorm.ErrRecordNotFound).Once()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Nome: "Tenda 1"}, nil).Once()
+		m.alertaRepo.On("Criar", mock.MatchedBy(func(a *entity.Alerta) bool {
+			return a.Status == entity.StatusAlertaAberto &&
+				a.UsuarioID == 7 &&
+				a.IniciadoEm.Equal(inicio) &&
+				a.ValorInicial == 38.5
+		})).Return(nil).Once()
+		m.alertaRepo.On("Atualizar", mock.MatchedBy(func(a *entity.Alerta) bool {
+			return a.ValorPico == 39 && a.UltimoValor == 39
+		})).Return(nil).Once()
+		m.regraRepo.On("AtualizarCondicao", uint(3), mock.MatchedBy(func(desde *time.Time) bool {
+			return desde != nil && desde.Equal(inicio)
+		})).Return(nil).Once()
+		m.canal.On("Enviar", mock.Anything, mock.MatchedBy(func(n service.Notificacao) bool {
+			return n.UsuarioID == 7 && n.Titulo == "Calor na estufa"
+		})).Return(nil).Once()
+
+		// Fora de ordem de propósito: a avaliação segue a ordem cronológica
+		err := servico.LeiturasRegistradas(1, []entity.Microclima{
+			leituraTemperatura(inicio.Add(30*time.Minute), 38.5),
+			leituraTemperatura(inicio, 38),
+			leituraTemperatura(inicio.Add(15*time.Minute), 37),
+			leituraTemperatura(inicio.Add(45*time.Minute), 39),
+		})
+
+		assert.NoError(t, err)
+		m.alertaRepo.AssertExpectations(t)
+		m.regraRepo.AssertExpectations(t)
+		m.canal.AssertExpectations(t)
+	})
+
+	t.Run("Success - Condição Sem Duração Suficiente", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		m.regraRepo.On("ListarAtivasPorAmbiente", uint(1)).Return([]entity.RegraAlerta{novaRegra()}, nil).Once()
+		m.alertaRepo.On("BuscarAtivoPorRegra", uint(3)).Return((*entity.Alerta)(nil), gorm.ErrRecordNotFound).Once()
+		m.regraRepo.On("AtualizarCondicao", uint(3), mock.MatchedBy(func(desde *time.Time) bool {
+			return desde != nil && desde.Equal(inicio)
+		})).Return(nil).Once()
+
+		err := servico.LeiturasRegistradas(1, []entity.Microclima{
+			leituraTemperatura(inicio, 38),
+			leituraTemperatura(inicio.Add(10*time.Minute), 38),
+		})
+
+		assert.NoError(t, err)
+		m.alertaRepo.AssertNotCalled(t, "Criar", mock.Anything)
+		m.canal.AssertNotCalled(t, "Enviar", mock.Anything, mock.Anything)
+		m.regraRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Resolve Alerta Ativo", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		regra := novaRegra()
+		regra.CondicaoDesde = &inicio
+		ativo := &entity.Alerta{RegraAlertaID: 3, UsuarioID: 7, Status: entity.StatusAlertaReconhecido, ValorPico: 39}
+		ativo.ID = 11
+
+		m.regraRepo.On("ListarAtivasPorAmbiente", uint(1)).Return([]entity.RegraAlerta{regra}, nil).Once()
+		m.alertaRepo.On("BuscarAtivoPorRegra", uint(3)).Return(ativo, nil).Once()
+		m.alertaRepo.On("Atualizar", mock.MatchedBy(func(a *entity.Alerta) bool {
+			return a.ID == 11 && a.Status == entity.StatusAlertaResolvido && a.ResolvidoEm != nil
+		})).Return(nil).Once()
+		m.regraRepo.On("AtualizarCondicao", uint(3), (*time.Time)(nil)).Return(nil).Once()
+		m.canal.On("Enviar", mock.Anything, mock.MatchedBy(func(n service.Notificacao) bool {
+			return n.Titulo == "Resolvido: Calor na estufa"
+		})).Return(nil).Once()
+
+		err := servico.LeiturasRegistradas(1, []entity.Microclima{leituraTemperatura(inicio.Add(time.Hour), 30)})
+
+		assert.NoError(t, err)
+		m.alertaRepo.AssertExpectations(t)
+		m.regraRepo.AssertExpectations(t)
+		m.canal.AssertExpectations(t)
+	})
+
+	t.Run("Success - Fora da Janela de Horário", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		regra := novaRegra()
+		regra.DuracaoMinutos = 0
+		regra.HoraInicio, regra.HoraFim = "20:00", "01:00"
+		m.regraRepo.On("ListarAtivasPorAmbiente", uint(1)).Return([]entity.RegraAlerta{regra}, nil).Once()
+		m.alertaRepo.On("BuscarAtivoPorRegra", uint(3)).Return((*entity.Alerta)(nil), gorm.ErrRecordNotFound).Once()
+
+		err := servico.LeiturasRegistradas(1, []entity.Microclima{leituraTemperatura(inicio, 40)})
+
+		assert.NoError(t, err)
+		m.alertaRepo.AssertNotCalled(t, "Criar", mock.Anything)
+		m.regraRepo.AssertNotCalled(t, "AtualizarCondicao", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Saída da Janela Reinicia a Contagem", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		regra := novaRegra()
+		regra.HoraInicio, regra.HoraFim = "20:00", "01:00"
+		// a condição começou às 00:40, dentro da janela
+		antes := inicio.Add(-80 * time.Minute)
+		regra.CondicaoDesde = &antes
+		m.regraRepo.On("ListarAtivasPorAmbiente", uint(1)).Return([]entity.RegraAlerta{regra}, nil).Once()
+		m.alertaRepo.On("BuscarAtivoPorRegra", uint(3)).Return((*entity.Alerta)(nil), gorm.ErrRecordNotFound).Once()
+		m.regraRepo.On("AtualizarCondicao", uint(3), (*time.Time)(nil)).Return(nil).Once()
+
+		err := servico.LeiturasRegistradas(1, []entity.Microclima{leituraTemperatura(inicio, 40)})
+
+		assert.NoError(t, err)
+		m.alertaRepo.AssertNotCalled(t, "Criar", mock.Anything)
+		m.regraRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Fora do Escopo de Estágio", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		regra := novaRegra()
+		regra.Estagios = "floracao,maturacao"
+		m.regraRepo.On("ListarAtivasPorAmbiente", uint(1)).Return([]entity.RegraAlerta{regra}, nil).Once()
+		m.estagioRepo.On("ListarAtuaisPorAmbiente", uint(1)).Return([]entity.EstagioCrescimento{
+			{PlantaID: 1, Estagio: entity.EstagioVegetativo},
+		}, nil).Once()
+
+		err := servico.LeiturasRegistradas(1, []entity.Microclima{leituraTemperatura(inicio, 40)})
+
+		assert.NoError(t, err)
+		m.alertaRepo.AssertNotCalled(t, "BuscarAtivoPorRegra", mock.Anything)
+		m.estagioRepo.AssertExpectations(t)
+	})
+}
+
+func TestAlertaService_CriarRegra(t *testing.T) {
+	limite := 35.0
+
+	t.Run("Success", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		m.regraRepo.On("Criar", mock.MatchedBy(func(r *entity.RegraAlerta) bool {
+			return r.AmbienteID == 1 && r.UsuarioID == 7 && r.Canais == "lembrete" && r.Estagios == "floracao" && r.Ativa
+		})).Return(nil).Once()
+
+		regra, err := servico.CriarRegra(1, 7, &dto.RegraAlertaDTO{
+			Nome:       "Calor",
+			Metrica:    "temperatura",
+			Comparacao: "maior",
+			Limite:     &limite,
+			Estagios:   []string{"floracao"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 35.0, regra.Limite)
+		m.regraRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Webhook Sem URL", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+
+		_, err := servico.CriarRegra(1, 7, &dto.RegraAlertaDTO{
+			Nome:       "Calor",
+			Metrica:    "temperatura",
+			Comparacao: "maior",
+			Limite:     &limite,
+			Canais:     []string{"webhook"},
+		})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.regraRepo.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+}
+
+func TestAlertaService_ListarRegras(t *testing.T) {
+	t.Run("Success - Filtra pelo Usuário", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		m.regraRepo.On("ListarPorAmbiente", uint(1), uint(7)).Return([]entity.RegraAlerta{{UsuarioID: 7, Nome: "Calor"}}, nil).Once()
+
+		regras, err := servico.ListarRegras(1, 7)
+
+		assert.NoError(t, err)
+		assert.Len(t, regras, 1)
+		m.regraRepo.AssertExpectations(t)
+	})
+}
+
+func TestAlertaService_Reconhecer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		alerta := &entity.Alerta{UsuarioID: 7, Status: entity.StatusAlertaAberto}
+		m.alertaRepo.On("BuscarPorID", uint(11)).Return(alerta, nil).Once()
+		m.alertaRepo.On("Atualizar", alerta).Return(nil).Once()
+
+		resultado, err := servico.Reconhecer(11, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.StatusAlertaReconhecido, resultado.Status)
+		assert.NotNil(t, resultado.ReconhecidoEm)
+	})
+
+	t.Run("Error - Alerta de Outro Usuário", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		m.alertaRepo.On("BuscarPorID", uint(11)).Return(&entity.Alerta{UsuarioID: 8, Status: entity.StatusAlertaAberto}, nil).Once()
+
+		_, err := servico.Reconhecer(11, 7)
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		m.alertaRepo.AssertNotCalled(t, "Atualizar", mock.Anything)
+	})
+
+	t.Run("Error - Alerta Resolvido", func(t *testing.T) {
+		servico, m := novoAlertaService()
+		m.alertaRepo.On("BuscarPorID", uint(11)).Return(&entity.Alerta{UsuarioID: 7, Status: entity.StatusAlertaResolvido}, nil).Once()
+
+		_, err := servico.Reconhecer(11, 7)
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
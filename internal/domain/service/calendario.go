@@ -0,0 +1,368 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/ical"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// dominioUIDCalendario identifica os eventos publicados pela API; a importação os ignora
+	dominioUIDCalendario = "@cultivo-api"
+	// duracaoEventoTarefa é a duração das tarefas no calendário, que só guardam o horário de início
+	duracaoEventoTarefa = 30 * time.Minute
+	// margemJanelaColheita são os dias antes e depois da data prevista na janela de colheita
+	margemJanelaColheita = 7
+	// limiteEventosImportacao limita os eventos de um único arquivo importado
+	limiteEventosImportacao = 500
+)
+
+// CalendarioService publica as tarefas, lembretes e marcos do cultivo como um feed
+// iCalendar por usuário e importa arquivos .ics como tarefas.
+type CalendarioService interface {
+	// GerarToken cria um novo token secreto do feed, invalidando o anterior
+	GerarToken(usuarioID uint) (string, error)
+	RevogarToken(usuarioID uint) error
+	// Feed gera o calendário do usuário dono do token
+	Feed(token string) ([]byte, error)
+	// Importar cria uma tarefa para cada evento do arquivo; eventos gerados pelo próprio
+	// feed e eventos sem data são ignorados
+	Importar(usuarioID uint, arquivo io.Reader) (*dto.ImportacaoCalendarioDTO, error)
+}
+
+type calendarioService struct {
+	usuarioRepositorio  repository.UsuarioRepositorio
+	tarefaRepositorio   repository.TarefaRepositorio
+	lembreteRepositorio repository.LembreteRepositorio
+	plantaRepositorio   repository.PlantaRepositorio
+	estagioRepositorio  repository.EstagioCrescimentoRepositorio
+	tarefaService       TarefaService
+	local               *time.Location
+	// antecedenciaLembrete é o alarme das tarefas, igual ao lembrete enviado pelo agendador
+	antecedenciaLembrete time.Duration
+	agora                func() time.Time
+}
+
+// NewCalendarioService cria o serviço de calendário; local é o fuso das datas de dia inteiro
+// e dos horários importados sem fuso.
+func NewCalendarioService(
+	usuarioRepositorio repository.UsuarioRepositorio,
+	tarefaRepositorio repository.TarefaRepositorio,
+	lembreteRepositorio repository.LembreteRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	estagioRepositorio repository.EstagioCrescimentoRepositorio,
+	tarefaService TarefaService,
+	local *time.Location,
+	antecedenciaLembrete time.Duration,
+) CalendarioService {
+	if local == nil {
+		local = time.Local
+	}
+	return &calendarioService{
+		usuarioRepositorio:   usuarioRepositorio,
+		tarefaRepositorio:    tarefaRepositorio,
+		lembreteRepositorio:  lembreteRepositorio,
+		plantaRepositorio:    plantaRepositorio,
+		estagioRepositorio:   estagioRepositorio,
+		tarefaService:        tarefaService,
+		local:                local,
+		antecedenciaLembrete: antecedenciaLembrete,
+		agora:                time.Now,
+	}
+}
+
+func (s *calendarioService) GerarToken(usuarioID uint) (string, error) {
+	if usuarioID == 0 {
+		return "", utils.ErrInvalidInput
+	}
+	bruto := make([]byte, 32)
+	if _, err := rand.Read(bruto); err != nil {
+		return "", fmt.Errorf("falha ao gerar token do calendário: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(bruto)
+	hash := hashTokenCalendario(token)
+	if err := s.usuarioRepositorio.DefinirTokenCalendario(usuarioID, &hash); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", utils.ErrNotFound
+		}
+		return "", fmt.Errorf("falha ao salvar token do calendário: %w", err)
+	}
+	return token, nil
+}
+
+func (s *calendarioService) RevogarToken(usuarioID uint) error {
+	if err := s.usuarioRepositorio.DefinirTokenCalendario(usuarioID, nil); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao revogar token do calendário: %w", err)
+	}
+	return nil
+}
+
+func (s *calendarioService) Feed(token string) ([]byte, error) {
+	if token == "" {
+		return nil, utils.ErrNotFound
+	}
+	usuario, err := s.usuarioRepositorio.BuscarPorTokenCalendario(hashTokenCalendario(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar usuário do calendário: %w", err)
+	}
+
+	agora := s.agora()
+	plantas, err := s.plantaRepositorio.ListarAtivasPorUsuario(usuario.ID)
+	if err != nil {
+		return nil, err
+	}
+	nomes := make(map[uint]string, len(plantas))
+	for _, planta := range plantas {
+		nomes[planta.ID] = planta.Nome
+	}
+
+	tarefas, err := s.tarefaRepositorio.ListarAbertasPorUsuario(usuario.ID)
+	if err != nil {
+		return nil, err
+	}
+	eventos := s.eventosTarefas(tarefas, nomes)
+
+	lembretes, err := s.lembreteRepositorio.ListarAtivosPorUsuario(usuario.ID, agora)
+	if err != nil {
+		return nil, err
+	}
+	for _, lembrete := range lembretes {
+		eventos = append(eventos, eventoLembrete(lembrete))
+	}
+
+	for _, planta := range plantas {
+		estagios, err := s.estagioRepositorio.ListarPorPlanta(planta.ID)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao listar estágios da planta %d: %w", planta.ID, err)
+		}
+		eventos = append(eventos, s.marcosPlanta(planta, estagios, agora)...)
+	}
+
+	calendario := ical.Calendario{Nome: "Cultivo - " + usuario.Nome, Eventos: eventos}
+	return calendario.Bytes(agora), nil
+}
+
+// eventosTarefas publica cada ocorrência aberta; a última ocorrência aberta de uma série
+// recorrente leva a RRULE, cobrindo as ocorrências que o agendador ainda vai materializar.
+func (s *calendarioService) eventosTarefas(tarefas []entity.Tarefa, nomesPlantas map[uint]string) []ical.Evento {
+	comSucessora := make(map[uint]bool)
+	for _, tarefa := range tarefas {
+		if tarefa.TarefaAnteriorID != nil {
+			comSucessora[*tarefa.TarefaAnteriorID] = true
+		}
+	}
+
+	eventos := make([]ical.Evento, 0, len(tarefas))
+	for _, tarefa := range tarefas {
+		resumo := tarefa.Tipo
+		if tarefa.PlantaID != nil && nomesPlantas[*tarefa.PlantaID] != "" {
+			resumo += " - " + nomesPlantas[*tarefa.PlantaID]
+		}
+		if tarefa.Status == entity.StatusTarefaAtrasada {
+			resumo += " (atrasada)"
+		}
+		evento := ical.Evento{
+			UID:        fmt.Sprintf("tarefa-%d%s", tarefa.ID, dominioUIDCalendario),
+			Resumo:     resumo,
+			Descricao:  tarefa.Descricao,
+			Inicio:     tarefa.DataAgendada,
+			Fim:        tarefa.DataAgendada.Add(duracaoEventoTarefa),
+			Categorias: []string{tarefa.Tipo},
+			Extras:     map[string]string{"X-CULTIVO-TIPO": tarefa.Tipo},
+		}
+		if tarefa.Prioridade != "" {
+			evento.Extras["X-CULTIVO-PRIORIDADE"] = string(tarefa.Prioridade)
+		}
+		if s.antecedenciaLembrete > 0 {
+			antecedencia := s.antecedenciaLembrete
+			evento.Alarme = &antecedencia
+		}
+		if tarefa.Recorrente && tarefa.FrequenciaDias != nil && *tarefa.FrequenciaDias > 0 && !comSucessora[tarefa.ID] {
+			evento.Recorrencia = &ical.Recorrencia{Frequencia: ical.FrequenciaDiaria, Intervalo: *tarefa.FrequenciaDias}
+		}
+		eventos = append(eventos, evento)
+	}
+	return eventos
+}
+
+func eventoLembrete(lembrete entity.Lembrete) ical.Evento {
+	var alarme time.Duration
+	evento := ical.Evento{
+		UID:        fmt.Sprintf("lembrete-%d%s", lembrete.ID, dominioUIDCalendario),
+		Resumo:     lembrete.Mensagem,
+		Inicio:     lembrete.DataHora,
+		Categorias: []string{"lembrete"},
+		Alarme:     &alarme,
+	}
+	if lembrete.Repetir {
+		switch lembrete.Frequencia {
+		case entity.FrequenciaLembreteDiaria:
+			evento.Recorrencia = &ical.Recorrencia{Frequencia: ical.FrequenciaDiaria}
+		case entity.FrequenciaLembreteSemanal:
+			evento.Recorrencia = &ical.Recorrencia{Frequencia: ical.FrequenciaSemanal}
+		case entity.FrequenciaLembreteMensal:
+			evento.Recorrencia = &ical.Recorrencia{Frequencia: ical.FrequenciaMensal}
+		}
+	}
+	return evento
+}
+
+// marcosPlanta gera um evento de dia inteiro para o início de cada estágio e a janela de
+// colheita prevista a partir do início da floração e do tempo de floração da genética.
+func (s *calendarioService) marcosPlanta(planta entity.Planta, estagios []entity.EstagioCrescimento, agora time.Time) []ical.Evento {
+	var eventos []ical.Evento
+	var inicioFloracao *time.Time
+	for _, estagio := range estagios {
+		inicio := s.diaLocal(estagio.DataInicio)
+		eventos = append(eventos, ical.Evento{
+			UID:        fmt.Sprintf("estagio-%d%s", estagio.ID, dominioUIDCalendario),
+			Resumo:     fmt.Sprintf("%s: início de %s", planta.Nome, estagio.Estagio),
+			Inicio:     inicio,
+			Fim:        inicio.AddDate(0, 0, 1),
+			DiaInteiro: true,
+			Categorias: []string{"estagio"},
+		})
+		if estagio.Estagio == entity.EstagioFloracao && inicioFloracao == nil {
+			dataInicio := estagio.DataInicio
+			inicioFloracao = &dataInicio
+		}
+	}
+
+	// autoflorescentes florescem por idade, sem depender de um registro de floração
+	autoflorescente := planta.Genetica.TipoEspecie == "automatica" || planta.Genetica.TipoGenetica == "ruderalis"
+	if inicioFloracao == nil && autoflorescente && planta.DataPlantio != nil {
+		previsto := planta.DataPlantio.AddDate(0, 0, diasVegetativoAuto)
+		inicioFloracao = &previsto
+	}
+	if inicioFloracao == nil || planta.Genetica.TempoFloracao <= 0 {
+		return eventos
+	}
+
+	previsao := s.diaLocal(*inicioFloracao).AddDate(0, 0, planta.Genetica.TempoFloracao)
+	fim := previsao.AddDate(0, 0, margemJanelaColheita+1)
+	if !fim.After(agora) {
+		return eventos
+	}
+	return append(eventos, ical.Evento{
+		UID:        fmt.Sprintf("colheita-%d%s", planta.ID, dominioUIDCalendario),
+		Resumo:     planta.Nome + ": janela de colheita",
+		Descricao:  "Colheita prevista para " + previsao.Format("02/01/2006"),
+		Inicio:     previsao.AddDate(0, 0, -margemJanelaColheita),
+		Fim:        fim,
+		DiaInteiro: true,
+		Categorias: []string{"colheita"},
+	})
+}
+
+func (s *calendarioService) Importar(usuarioID uint, arquivo io.Reader) (*dto.ImportacaoCalendarioDTO, error) {
+	if usuarioID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	eventos, err := ical.Ler(arquivo, s.local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", utils.ErrInvalidInput, err)
+	}
+	if len(eventos) > limiteEventosImportacao {
+		return nil, fmt.Errorf("%w: o arquivo tem %d eventos; o limite é %d", utils.ErrInvalidInput, len(eventos), limiteEventosImportacao)
+	}
+
+	resultado := &dto.ImportacaoCalendarioDTO{Criadas: []entity.Tarefa{}}
+	ignorar := func(evento ical.Evento, motivo string) {
+		resultado.Ignorados++
+		resultado.Avisos = append(resultado.Avisos, fmt.Sprintf("%q: %s", nomeEvento(evento), motivo))
+	}
+	for _, evento := range eventos {
+		if strings.HasSuffix(evento.UID, dominioUIDCalendario) {
+			ignorar(evento, "evento publicado pelo próprio feed")
+			continue
+		}
+		if evento.Inicio.IsZero() {
+			ignorar(evento, "evento sem data")
+			continue
+		}
+
+		tarefaDto := &dto.TarefaDTO{
+			Tipo:         tipoTarefaImportada(evento),
+			Descricao:    strings.TrimSpace(evento.Resumo + "\n\n" + evento.Descricao),
+			DataAgendada: evento.Inicio,
+		}
+		if evento.DiaInteiro {
+			tarefaDto.DataAgendada = time.Date(evento.Inicio.Year(), evento.Inicio.Month(), evento.Inicio.Day(), horaTarefasCronograma, 0, 0, 0, s.local)
+		}
+		if prioridade := evento.Extras["X-CULTIVO-PRIORIDADE"]; prioridade != "" {
+			tarefaDto.Prioridade = prioridade
+		}
+		if evento.Recorrencia != nil {
+			// mesmo limite de frequencia_dias da criação pela API
+			if dias, ok := evento.Recorrencia.Dias(); ok && dias <= 365 {
+				tarefaDto.Recorrente = true
+				tarefaDto.FrequenciaDias = &dias
+			} else {
+				resultado.Avisos = append(resultado.Avisos, fmt.Sprintf("%q: recorrência não suportada, importado como tarefa única", nomeEvento(evento)))
+			}
+		}
+
+		tarefa, err := s.tarefaService.Criar(usuarioID, tarefaDto)
+		if err != nil {
+			if errors.Is(err, utils.ErrInvalidInput) {
+				ignorar(evento, err.Error())
+				continue
+			}
+			return nil, err
+		}
+		resultado.Criadas = append(resultado.Criadas, *tarefa)
+	}
+	return resultado, nil
+}
+
+// tipoTarefaImportada usa o tipo exportado pelo feed, a primeira categoria ou "geral"
+func tipoTarefaImportada(evento ical.Evento) string {
+	tipo := evento.Extras["X-CULTIVO-TIPO"]
+	if tipo == "" && len(evento.Categorias) > 0 {
+		tipo = evento.Categorias[0]
+	}
+	tipo = strings.ToLower(strings.TrimSpace(tipo))
+	if tipo == "" {
+		return "geral"
+	}
+	if runas := []rune(tipo); len(runas) > 50 {
+		tipo = string(runas[:50])
+	}
+	return tipo
+}
+
+func nomeEvento(evento ical.Evento) string {
+	if evento.Resumo != "" {
+		return evento.Resumo
+	}
+	return evento.UID
+}
+
+func (s *calendarioService) diaLocal(t time.Time) time.Time {
+	t = t.In(s.local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.local)
+}
+
+func hashTokenCalendario(token string) string {
+	soma := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(soma[:])
+}
@@ -0,0 +1,209 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mocksCalendario struct {
+	usuarios    *test.MockUsuarioRepositorio
+	tarefas     *test.MockTarefaRepositorio
+	lembretes   *test.MockLembreteRepositorio
+	plantaRepo  *test.MockPlantaRepositorio
+	estagioRepo *test.MockEstagioCrescimentoRepositorio
+}
+
+func novoCalendarioService() (service.CalendarioService, *mocksCalendario) {
+	m := &mocksCalendario{
+		usuarios:    new(test.MockUsuarioRepositorio),
+		tarefas:     new(test.MockTarefaRepositorio),
+		lembretes:   new(test.MockLembreteRepositorio),
+		plantaRepo:  new(test.MockPlantaRepositorio),
+		estagioRepo: new(test.MockEstagioCrescimentoRepositorio),
+	}
+	tarefaService := service.NewTarefaService(m.tarefas, m.plantaRepo, new(test.MockAmbienteRepositorio), new(MockDiarioCultivoRepository), nil, time.UTC)
+	servico := service.NewCalendarioService(m.usuarios, m.tarefas, m.lembretes, m.plantaRepo, m.estagioRepo, tarefaService, time.UTC, time.Hour)
+	return servico, m
+}
+
+func TestCalendarioService_GerarTokenEFeed(t *testing.T) {
+	servico, m := novoCalendarioService()
+	var hash string
+	m.usuarios.On("DefinirTokenCalendario", uint(7), mock.AnythingOfType("*string")).Run(func(args mock.Arguments) {
+		hash = *args.Get(1).(*string)
+	}).Return(nil).Once()
+
+	token, err := servico.GerarToken(7)
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Len(t, hash, 64)
+	assert.NotContains(t, hash, token)
+
+	usuario := &entity.Usuario{Nome: "Ana"}
+	usuario.ID = 7
+	m.usuarios.On("BuscarPorTokenCalendario", hash).Return(usuario, nil).Once()
+
+	agora := time.Now().UTC()
+	frequencia := 3
+	plantaID := uint(10)
+	regaAnterior := entity.Tarefa{Tipo: "regar", DataAgendada: agora.Add(-2 * time.Hour), Status: entity.StatusTarefaAtrasada, Recorrente: true, FrequenciaDias: &frequencia, UsuarioID: 7, PlantaID: &plantaID}
+	regaAnterior.ID = 1
+	regaProxima := entity.Tarefa{Tipo: "regar", DataAgendada: agora.AddDate(0, 0, 1), Status: entity.StatusTarefaPendente, Recorrente: true, FrequenciaDias: &frequencia, UsuarioID: 7, TarefaAnteriorID: &regaAnterior.ID}
+	regaProxima.ID = 2
+	m.tarefas.On("ListarAbertasPorUsuario", uint(7)).Return([]entity.Tarefa{regaAnterior, regaProxima}, nil).Once()
+
+	lembreteEm := agora.Add(time.Hour)
+	lembrete := entity.Lembrete{UsuarioID: 7, Mensagem: "Medir pH", DataHora: lembreteEm, Repetir: true, Frequencia: entity.FrequenciaLembreteSemanal, ProximoEnvio: &lembreteEm}
+	lembrete.ID = 5
+	m.lembretes.On("ListarAtivosPorUsuario", uint(7), mock.AnythingOfType("time.Time")).Return([]entity.Lembrete{lembrete}, nil).Once()
+
+	floracao := agora.AddDate(0, 0, -20)
+	planta := entity.Planta{Nome: "Gorilla", Status: entity.StatusGerminating, UsuarioID: 7, Genetica: entity.Genetica{Nome: "GG4", TipoEspecie: "feminizada", TempoFloracao: 60}}
+	planta.ID = 10
+	m.plantaRepo.On("ListarAtivasPorUsuario", uint(7)).Return([]entity.Planta{planta}, nil).Once()
+	estagio := entity.EstagioCrescimento{PlantaID: 10, Estagio: entity.EstagioFloracao, DataInicio: floracao}
+	estagio.ID = 3
+	m.estagioRepo.On("ListarPorPlanta", uint(10)).Return([]entity.EstagioCrescimento{estagio}, nil).Once()
+
+	calendario, err := servico.Feed(token)
+
+	require.NoError(t, err)
+	saida := string(calendario)
+	assert.Contains(t, saida, "X-WR-CALNAME:Cultivo - Ana\r\n")
+	assert.Contains(t, saida, "UID:tarefa-1@cultivo-api\r\n")
+	assert.Contains(t, saida, "SUMMARY:regar - Gorilla (atrasada)\r\n")
+	assert.Contains(t, saida, "UID:tarefa-2@cultivo-api\r\n")
+	// só a última ocorrência aberta da série leva a regra de recorrência
+	assert.Equal(t, 1, strings.Count(saida, "RRULE:FREQ=DAILY;INTERVAL=3\r\n"))
+	assert.Contains(t, saida, "TRIGGER:-PT1H\r\n")
+	assert.Contains(t, saida, "UID:lembrete-5@cultivo-api\r\n")
+	assert.Contains(t, saida, "RRULE:FREQ=WEEKLY\r\n")
+	assert.Contains(t, saida, "UID:estagio-3@cultivo-api\r\n")
+	assert.Contains(t, saida, "DTSTART;VALUE=DATE:"+floracao.Format("20060102")+"\r\n")
+
+	previsao := time.Date(floracao.Year(), floracao.Month(), floracao.Day()+60, 0, 0, 0, 0, time.UTC)
+	assert.Contains(t, saida, "UID:colheita-10@cultivo-api\r\n")
+	assert.Contains(t, saida, "DTSTART;VALUE=DATE:"+previsao.AddDate(0, 0, -7).Format("20060102")+"\r\n")
+	assert.Contains(t, saida, "DTEND;VALUE=DATE:"+previsao.AddDate(0, 0, 8).Format("20060102")+"\r\n")
+	m.usuarios.AssertExpectations(t)
+}
+
+func TestCalendarioService_Feed(t *testing.T) {
+	t.Run("Success - Autoflorescente Sem Floração Registrada", func(t *testing.T) {
+		servico, m := novoCalendarioService()
+		usuario := &entity.Usuario{Nome: "Ana"}
+		usuario.ID = 7
+		m.usuarios.On("BuscarPorTokenCalendario", mock.AnythingOfType("string")).Return(usuario, nil).Once()
+		m.tarefas.On("ListarAbertasPorUsuario", uint(7)).Return([]entity.Tarefa{}, nil).Once()
+		m.lembretes.On("ListarAtivosPorUsuario", uint(7), mock.AnythingOfType("time.Time")).Return([]entity.Lembrete{}, nil).Once()
+		plantio := time.Now().UTC().AddDate(0, 0, -10)
+		planta := entity.Planta{Nome: "Auto", DataPlantio: &plantio, Genetica: entity.Genetica{TipoEspecie: "automatica", TempoFloracao: 50}}
+		planta.ID = 11
+		m.plantaRepo.On("ListarAtivasPorUsuario", uint(7)).Return([]entity.Planta{planta}, nil).Once()
+		m.estagioRepo.On("ListarPorPlanta", uint(11)).Return([]entity.EstagioCrescimento{}, nil).Once()
+
+		calendario, err := servico.Feed("token")
+
+		require.NoError(t, err)
+		previsao := time.Date(plantio.Year(), plantio.Month(), plantio.Day()+28+50, 0, 0, 0, 0, time.UTC)
+		assert.Contains(t, string(calendario), "DTSTART;VALUE=DATE:"+previsao.AddDate(0, 0, -7).Format("20060102")+"\r\n")
+	})
+
+	t.Run("Error - Token Desconhecido", func(t *testing.T) {
+		servico, m := novoCalendarioService()
+		m.usuarios.On("BuscarPorTokenCalendario", mock.AnythingOfType("string")).Return((*entity.Usuario)(nil), gorm.ErrRecordNotFound).Once()
+
+		_, err := servico.Feed("revogado")
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+
+	t.Run("Error - Token Vazio", func(t *testing.T) {
+		servico, _ := novoCalendarioService()
+
+		_, err := servico.Feed("")
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestCalendarioService_RevogarToken(t *testing.T) {
+	servico, m := novoCalendarioService()
+	m.usuarios.On("DefinirTokenCalendario", uint(7), (*string)(nil)).Return(nil).Once()
+
+	require.NoError(t, servico.RevogarToken(7))
+	m.usuarios.AssertExpectations(t)
+}
+
+func TestCalendarioService_Importar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servico, m := novoCalendarioService()
+		var criadas []*entity.Tarefa
+		m.tarefas.On("Criar", mock.AnythingOfType("*entity.Tarefa")).Run(func(args mock.Arguments) {
+			criadas = append(criadas, args.Get(0).(*entity.Tarefa))
+		}).Return(nil)
+		conteudo := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"UID:externo-1",
+			"DTSTART:20300105T130000Z",
+			"SUMMARY:Trocar solução",
+			"CATEGORIES:Nutricao",
+			"RRULE:FREQ=WEEKLY",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:externo-2",
+			"DTSTART;VALUE=DATE:20300110",
+			"SUMMARY:Limpar filtros",
+			"RRULE:FREQ=MONTHLY",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:tarefa-9@cultivo-api",
+			"DTSTART:20300105T130000Z",
+			"SUMMARY:regar",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:externo-3",
+			"SUMMARY:Sem data",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		resultado, err := servico.Importar(7, strings.NewReader(conteudo))
+
+		require.NoError(t, err)
+		require.Len(t, resultado.Criadas, 2)
+		assert.Equal(t, 2, resultado.Ignorados)
+		assert.Len(t, resultado.Avisos, 3)
+
+		require.Len(t, criadas, 2)
+		assert.Equal(t, "nutricao", criadas[0].Tipo)
+		assert.Equal(t, "Trocar solução", criadas[0].Descricao)
+		assert.True(t, criadas[0].Recorrente)
+		require.NotNil(t, criadas[0].FrequenciaDias)
+		assert.Equal(t, 7, *criadas[0].FrequenciaDias)
+		assert.Equal(t, uint(7), criadas[0].UsuarioID)
+
+		assert.Equal(t, "geral", criadas[1].Tipo)
+		assert.False(t, criadas[1].Recorrente)
+		assert.Equal(t, time.Date(2030, 1, 10, 9, 0, 0, 0, time.UTC), criadas[1].DataAgendada)
+	})
+
+	t.Run("Error - Arquivo Inválido", func(t *testing.T) {
+		servico, _ := novoCalendarioService()
+
+		_, err := servico.Importar(7, strings.NewReader("não é um calendário"))
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
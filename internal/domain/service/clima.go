@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// casasDecimaisCoordenadas arredonda as coordenadas para cerca de 1 km, o bastante
+	// para o sol e o clima sem expor o endereço do cultivo
+	casasDecimaisCoordenadas = 2
+	// maxDiasClima limita o período das consultas de sol e das sincronizações de clima
+	maxDiasClima = 92
+	// timeoutClima limita cada chamada ao provedor de clima
+	timeoutClima = 30 * time.Second
+)
+
+// ClimaDiario é o resumo do tempo em um dia, como entregue pelo provedor.
+type ClimaDiario struct {
+	Data           string // AAAA-MM-DD no fuso da coordenada
+	Temperatura    float64
+	TemperaturaMin float64
+	TemperaturaMax float64
+	Umidade        float64
+	Precipitacao   float64
+	RadiacaoSolar  *float64 // MJ/m²
+}
+
+// WeatherProvider fornece o tempo diário (observado ou previsto) em uma coordenada.
+// inicio e fim são datas de calendário, inclusivas.
+type WeatherProvider interface {
+	Nome() string
+	ClimaDiario(ctx context.Context, latitude, longitude float64, inicio, fim time.Time) ([]ClimaDiario, error)
+}
+
+// ClimaService gerencia a localização dos ambientes externos, o sol e o tempo no local.
+type ClimaService interface {
+	// DefinirLocalizacao grava as coordenadas (arredondadas) de um ambiente externo
+	DefinirLocalizacao(ambienteID uint, localizacaoDto *dto.LocalizacaoDTO) (*entity.Ambiente, error)
+	RemoverLocalizacao(ambienteID uint) error
+	// Sol calcula nascer, pôr do sol e duração do dia (padrão: próximos 7 dias)
+	Sol(ambienteID uint, consulta *dto.ConsultaClimaDTO) ([]dto.SolDiaDTO, error)
+	// Sincronizar busca o tempo no provedor e grava um ClimaRegistro por dia (padrão: últimos 7 dias)
+	Sincronizar(ctx context.Context, ambienteID uint, consulta *dto.ConsultaClimaDTO) ([]entity.ClimaRegistro, error)
+	ListarClima(ambienteID uint, consulta *dto.ConsultaClimaDTO) ([]entity.ClimaRegistro, error)
+}
+
+type climaService struct {
+	repositorio         repository.ClimaRegistroRepositorio
+	ambienteRepositorio repository.AmbienteRepositorio
+	provedor            WeatherProvider
+	local               *time.Location
+	agora               func() time.Time
+}
+
+// NewClimaService cria o serviço de clima. local é o fuso em que as datas são interpretadas
+// e os horários do sol são apresentados.
+func NewClimaService(
+	repositorio repository.ClimaRegistroRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	provedor WeatherProvider,
+	local *time.Location,
+) ClimaService {
+	if local == nil {
+		local = time.Local
+	}
+	return &climaService{
+		repositorio:         repositorio,
+		ambienteRepositorio: ambienteRepositorio,
+		provedor:            provedor,
+		local:               local,
+		agora:               time.Now,
+	}
+}
+
+func (s *climaService) DefinirLocalizacao(ambienteID uint, localizacaoDto *dto.LocalizacaoDTO) (*entity.Ambiente, error) {
+	if localizacaoDto.Latitude == nil || localizacaoDto.Longitude == nil ||
+		math.Abs(*localizacaoDto.Latitude) > 90 || math.Abs(*localizacaoDto.Longitude) > 180 {
+		return nil, utils.ErrInvalidInput
+	}
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	if ambiente.Tipo != "externo" {
+		return nil, fmt.Errorf("%w: apenas ambientes externos têm localização", utils.ErrInvalidInput)
+	}
+
+	latitude := calculo.Arredondar(*localizacaoDto.Latitude, casasDecimaisCoordenadas)
+	longitude := calculo.Arredondar(*localizacaoDto.Longitude, casasDecimaisCoordenadas)
+	ambiente.Latitude = &latitude
+	ambiente.Longitude = &longitude
+	if err := s.ambienteRepositorio.Atualizar(ambiente); err != nil {
+		return nil, fmt.Errorf("falha ao salvar localização do ambiente %d: %w", ambienteID, err)
+	}
+	return ambiente, nil
+}
+
+func (s *climaService) RemoverLocalizacao(ambienteID uint) error {
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return err
+	}
+	ambiente.Latitude = nil
+	ambiente.Longitude = nil
+	if err := s.ambienteRepositorio.Atualizar(ambiente); err != nil {
+		return fmt.Errorf("falha ao remover localização do ambiente %d: %w", ambienteID, err)
+	}
+	return nil
+}
+
+func (s *climaService) Sol(ambienteID uint, consulta *dto.ConsultaClimaDTO) ([]dto.SolDiaDTO, error) {
+	inicio, fim, err := s.periodo(consulta, 0)
+	if err != nil {
+		return nil, err
+	}
+	ambiente, err := s.buscarLocalizado(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dias []dto.SolDiaDTO
+	for dia := inicio; !dia.After(fim); dia = dia.AddDate(0, 0, 1) {
+		sol := calculo.Sol(dia, *ambiente.Latitude, *ambiente.Longitude)
+		dias = append(dias, dto.SolDiaDTO{
+			Data:         dia.Format("2006-01-02"),
+			Nascer:       sol.Nascer,
+			PorDoSol:     sol.PorDoSol,
+			DuracaoHoras: calculo.Arredondar(sol.Duracao.Hours(), 2),
+			DiaPolar:     sol.DiaPolar,
+			NoitePolar:   sol.NoitePolar,
+		})
+	}
+	return dias, nil
+}
+
+func (s *climaService) Sincronizar(ctx context.Context, ambienteID uint, consulta *dto.ConsultaClimaDTO) ([]entity.ClimaRegistro, error) {
+	inicio, fim, err := s.periodo(consulta, -6)
+	if err != nil {
+		return nil, err
+	}
+	ambiente, err := s.buscarLocalizado(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeoutClima)
+	defer cancel()
+	dias, err := s.provedor.ClimaDiario(ctx, *ambiente.Latitude, *ambiente.Longitude, inicio, fim)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao consultar o provedor de clima %s: %w", s.provedor.Nome(), err)
+	}
+
+	registros := make([]entity.ClimaRegistro, 0, len(dias))
+	for _, dia := range dias {
+		data, err := time.Parse("2006-01-02", dia.Data)
+		if err != nil {
+			return nil, fmt.Errorf("provedor de clima %s retornou data inválida %q", s.provedor.Nome(), dia.Data)
+		}
+		registros = append(registros, entity.ClimaRegistro{
+			AmbienteID:     ambienteID,
+			Data:           data,
+			Temperatura:    dia.Temperatura,
+			TemperaturaMin: dia.TemperaturaMin,
+			TemperaturaMax: dia.TemperaturaMax,
+			Umidade:        dia.Umidade,
+			Precipitacao:   dia.Precipitacao,
+			RadiacaoSolar:  dia.RadiacaoSolar,
+			Fonte:          s.provedor.Nome(),
+		})
+	}
+	if err := s.repositorio.Salvar(registros); err != nil {
+		return nil, err
+	}
+	return registros, nil
+}
+
+func (s *climaService) ListarClima(ambienteID uint, consulta *dto.ConsultaClimaDTO) ([]entity.ClimaRegistro, error) {
+	inicio, fim, err := s.periodo(consulta, -6)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.buscarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	return s.repositorio.ListarPorAmbiente(ambienteID, inicio, fim)
+}
+
+// periodo interpreta as datas da consulta no fuso local. Sem datas, o período tem 7 dias e
+// começa deslocamento dias a partir de hoje.
+func (s *climaService) periodo(consulta *dto.ConsultaClimaDTO, deslocamento int) (time.Time, time.Time, error) {
+	agora := s.agora().In(s.local)
+	inicio := time.Date(agora.Year(), agora.Month(), agora.Day()+deslocamento, 0, 0, 0, 0, s.local)
+	if consulta.Inicio != "" {
+		data, err := time.ParseInLocation("2006-01-02", consulta.Inicio, s.local)
+		if err != nil {
+			return time.Time{}, time.Time{}, utils.ErrInvalidInput
+		}
+		inicio = data
+	}
+	fim := inicio.AddDate(0, 0, 6)
+	if consulta.Fim != "" {
+		data, err := time.ParseInLocation("2006-01-02", consulta.Fim, s.local)
+		if err != nil {
+			return time.Time{}, time.Time{}, utils.ErrInvalidInput
+		}
+		fim = data
+	}
+	if fim.Before(inicio) || fim.After(inicio.AddDate(0, 0, maxDiasClima)) {
+		return time.Time{}, time.Time{}, utils.ErrInvalidInput
+	}
+	return inicio, fim, nil
+}
+
+func (s *climaService) buscarAmbiente(ambienteID uint) (*entity.Ambiente, error) {
+	if ambienteID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	ambiente, err := s.ambienteRepositorio.BuscarPorID(ambienteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar ambiente com ID %d: %w", ambienteID, err)
+	}
+	return ambiente, nil
+}
+
+// buscarLocalizado exige que o ambiente tenha coordenadas cadastradas
+func (s *climaService) buscarLocalizado(ambienteID uint) (*entity.Ambiente, error) {
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	if ambiente.Latitude == nil || ambiente.Longitude == nil {
+		return nil, fmt.Errorf("%w: ambiente %d sem localização cadastrada", utils.ErrInvalidInput, ambienteID)
+	}
+	return ambiente, nil
+}
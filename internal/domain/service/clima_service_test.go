@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/clima"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const climaArquivoTeste = `[
+	{"data": "2026-06-01", "temperatura": 18.2, "temperatura_min": 12.1, "temperatura_max": 24.3, "umidade": 71, "precipitacao": 0.4, "radiacao_solar": 14.8},
+	{"data": "2026-06-02", "temperatura": 15.0, "temperatura_min": 13.0, "temperatura_max": 17.5, "umidade": 92, "precipitacao": 22.6},
+	{"data": "2026-06-09", "temperatura": 20.0, "temperatura_min": 14.0, "temperatura_max": 26.0, "umidade": 60, "precipitacao": 0}
+]`
+
+type mocksClima struct {
+	repo         *test.MockClimaRegistroRepositorio
+	ambienteRepo *test.MockAmbienteRepositorio
+}
+
+func novoClimaService(t *testing.T) (service.ClimaService, *mocksClima) {
+	t.Helper()
+	local, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	caminho := filepath.Join(t.TempDir(), "clima.json")
+	require.NoError(t, os.WriteFile(caminho, []byte(climaArquivoTeste), 0o600))
+	m := &mocksClima{
+		repo:         new(test.MockClimaRegistroRepositorio),
+		ambienteRepo: new(test.MockAmbienteRepositorio),
+	}
+	return service.NewClimaService(m.repo, m.ambienteRepo, clima.NewArquivo(caminho), local), m
+}
+
+func ambienteExterno(latitude, longitude float64) *entity.Ambiente {
+	return &entity.Ambiente{Tipo: "externo", Latitude: &latitude, Longitude: &longitude}
+}
+
+func TestClimaService_DefinirLocalizacao(t *testing.T) {
+	t.Run("Success - Arredonda Coordenadas", func(t *testing.T) {
+		servico, m := novoClimaService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Tipo: "externo"}, nil).Once()
+		m.ambienteRepo.On("Atualizar", mock.AnythingOfType("*entity.Ambiente")).Return(nil).Once()
+		latitude, longitude := -23.550520, -46.633308
+
+		ambiente, err := servico.DefinirLocalizacao(1, &dto.LocalizacaoDTO{Latitude: &latitude, Longitude: &longitude})
+
+		require.NoError(t, err)
+		assert.Equal(t, -23.55, *ambiente.Latitude)
+		assert.Equal(t, -46.63, *ambiente.Longitude)
+		m.ambienteRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Ambiente Interno", func(t *testing.T) {
+		servico, m := novoClimaService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Tipo: "interno"}, nil).Once()
+		latitude, longitude := -23.55, -46.63
+
+		_, err := servico.DefinirLocalizacao(1, &dto.LocalizacaoDTO{Latitude: &latitude, Longitude: &longitude})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.ambienteRepo.AssertNotCalled(t, "Atualizar", mock.Anything)
+	})
+}
+
+func TestClimaService_Sol(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servico, m := novoClimaService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(ambienteExterno(-23.55, -46.63), nil).Once()
+
+		dias, err := servico.Sol(1, &dto.ConsultaClimaDTO{Inicio: "2026-06-20", Fim: "2026-06-22"})
+
+		require.NoError(t, err)
+		require.Len(t, dias, 3)
+		assert.Equal(t, "2026-06-21", dias[1].Data)
+		assert.InDelta(t, 10.7, dias[1].DuracaoHoras, 0.1)
+		require.NotNil(t, dias[1].Nascer)
+		assert.Equal(t, "2026-06-21", dias[1].Nascer.Format("2006-01-02"))
+	})
+
+	t.Run("Error - Ambiente sem Localização", func(t *testing.T) {
+		servico, m := novoClimaService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Tipo: "externo"}, nil).Once()
+
+		_, err := servico.Sol(1, &dto.ConsultaClimaDTO{})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestClimaService_Sincronizar(t *testing.T) {
+	servico, m := novoClimaService(t)
+	m.ambienteRepo.On("BuscarPorID", uint(1)).Return(ambienteExterno(-23.55, -46.63), nil).Once()
+	m.repo.On("Salvar", mock.MatchedBy(func(registros []entity.ClimaRegistro) bool {
+		return len(registros) == 2
+	})).Return(nil).Once()
+
+	registros, err := servico.Sincronizar(context.Background(), 1, &dto.ConsultaClimaDTO{Inicio: "2026-06-01", Fim: "2026-06-07"})
+
+	require.NoError(t, err)
+	require.Len(t, registros, 2)
+	assert.Equal(t, uint(1), registros[0].AmbienteID)
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), registros[0].Data)
+	assert.Equal(t, "arquivo", registros[0].Fonte)
+	assert.Equal(t, 22.6, registros[1].Precipitacao)
+	m.repo.AssertExpectations(t)
+}
@@ -0,0 +1,537 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// horaTarefasCronograma é o horário local em que as tarefas geradas pelos cronogramas são agendadas
+const horaTarefasCronograma = 9
+
+// CronogramaCultivoService gerencia os cronogramas de cultivo e gera as tarefas das plantas em
+// que são aplicados. Só o estágio atual da planta é planejado; ao mudar de estágio, as tarefas
+// pendentes do estágio anterior são removidas e as do novo estágio, criadas.
+type CronogramaCultivoService interface {
+	ObservadorEstagio
+
+	Criar(usuarioID uint, cronogramaDto *dto.CronogramaCultivoDTO) (*entity.CronogramaCultivo, error)
+	Listar(usuarioID uint) ([]entity.CronogramaCultivo, error)
+	BuscarPorID(id, usuarioID uint) (*entity.CronogramaCultivo, error)
+	// Atualizar substitui os itens e replaneja as plantas em que o cronograma está aplicado
+	Atualizar(id, usuarioID uint, cronogramaDto *dto.CronogramaCultivoDTO) (*entity.CronogramaCultivo, error)
+	// Deletar remove o cronograma, as aplicações e as tarefas ainda não concluídas geradas por ele
+	Deletar(id, usuarioID uint) error
+	// Aplicar liga o cronograma às plantas (uma, um lote ou as do diário) e gera as tarefas do
+	// estágio atual de cada uma; reaplicar apenas replaneja
+	Aplicar(id, usuarioID uint, aplicarDto *dto.AplicarCronogramaDTO) ([]dto.PlanejamentoCronogramaDTO, error)
+	ListarAplicacoes(id, usuarioID uint) ([]entity.AplicacaoCronograma, error)
+	// RemoverAplicacao desliga o cronograma da planta e remove as tarefas ainda não concluídas
+	RemoverAplicacao(aplicacaoID, usuarioID uint) error
+}
+
+type cronogramaCultivoService struct {
+	repositorio          repository.CronogramaCultivoRepositorio
+	aplicacaoRepositorio repository.AplicacaoCronogramaRepositorio
+	tarefaRepositorio    repository.TarefaRepositorio
+	plantaRepositorio    repository.PlantaRepositorio
+	estagioRepositorio   repository.EstagioCrescimentoRepositorio
+	diarioRepositorio    repository.DiarioCultivoRepositorio
+	local                *time.Location
+	agora                func() time.Time
+}
+
+// NewCronogramaCultivoService cria o serviço de cronogramas; local é o fuso em que os dias
+// dos estágios são contados.
+func NewCronogramaCultivoService(
+	repositorio repository.CronogramaCultivoRepositorio,
+	aplicacaoRepositorio repository.AplicacaoCronogramaRepositorio,
+	tarefaRepositorio repository.TarefaRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	estagioRepositorio repository.EstagioCrescimentoRepositorio,
+	diarioRepositorio repository.DiarioCultivoRepositorio,
+	local *time.Location,
+) CronogramaCultivoService {
+	if local == nil {
+		local = time.Local
+	}
+	return &cronogramaCultivoService{
+		repositorio:          repositorio,
+		aplicacaoRepositorio: aplicacaoRepositorio,
+		tarefaRepositorio:    tarefaRepositorio,
+		plantaRepositorio:    plantaRepositorio,
+		estagioRepositorio:   estagioRepositorio,
+		diarioRepositorio:    diarioRepositorio,
+		local:                local,
+		agora:                time.Now,
+	}
+}
+
+func (s *cronogramaCultivoService) Criar(usuarioID uint, cronogramaDto *dto.CronogramaCultivoDTO) (*entity.CronogramaCultivo, error) {
+	if usuarioID == 0 || cronogramaDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	itens, err := montarItensCronograma(cronogramaDto.Itens)
+	if err != nil {
+		return nil, err
+	}
+
+	cronograma := &entity.CronogramaCultivo{
+		Nome:      cronogramaDto.Nome,
+		Descricao: cronogramaDto.Descricao,
+		UsuarioID: usuarioID,
+		Itens:     itens,
+	}
+	if err := s.repositorio.Criar(cronograma); err != nil {
+		return nil, fmt.Errorf("falha ao criar cronograma: %w", err)
+	}
+	return cronograma, nil
+}
+
+func (s *cronogramaCultivoService) Listar(usuarioID uint) ([]entity.CronogramaCultivo, error) {
+	return s.repositorio.ListarPorUsuario(usuarioID)
+}
+
+func (s *cronogramaCultivoService) BuscarPorID(id, usuarioID uint) (*entity.CronogramaCultivo, error) {
+	return s.buscarCronograma(id, usuarioID)
+}
+
+func (s *cronogramaCultivoService) Atualizar(id, usuarioID uint, cronogramaDto *dto.CronogramaCultivoDTO) (*entity.CronogramaCultivo, error) {
+	if cronogramaDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	cronograma, err := s.buscarCronograma(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	itens, err := montarItensCronograma(cronogramaDto.Itens)
+	if err != nil {
+		return nil, err
+	}
+
+	cronograma.Nome = cronogramaDto.Nome
+	cronograma.Descricao = cronogramaDto.Descricao
+	cronograma.Itens = itens
+	if err := s.repositorio.Atualizar(cronograma); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar cronograma com ID %d: %w", id, err)
+	}
+
+	// falhas no replanejamento não desfazem a edição; a próxima mudança de estágio corrige
+	aplicacoes, err := s.aplicacaoRepositorio.ListarPorCronograma(id)
+	if err != nil {
+		logrus.WithError(err).WithField("cronograma_id", id).Warn("Falha ao replanejar cronograma")
+		return cronograma, nil
+	}
+	for i := range aplicacoes {
+		if _, err := s.replanejar(&aplicacoes[i], cronograma); err != nil {
+			logrus.WithError(err).WithField("aplicacao_id", aplicacoes[i].ID).Warn("Falha ao replanejar cronograma")
+		}
+	}
+	return cronograma, nil
+}
+
+func (s *cronogramaCultivoService) Deletar(id, usuarioID uint) error {
+	if _, err := s.buscarCronograma(id, usuarioID); err != nil {
+		return err
+	}
+	aplicacoes, err := s.aplicacaoRepositorio.ListarPorCronograma(id)
+	if err != nil {
+		return err
+	}
+	for _, aplicacao := range aplicacoes {
+		if err := s.removerPendentes(aplicacao.ID); err != nil {
+			return err
+		}
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar cronograma com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *cronogramaCultivoService) Aplicar(id, usuarioID uint, aplicarDto *dto.AplicarCronogramaDTO) ([]dto.PlanejamentoCronogramaDTO, error) {
+	if aplicarDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	cronograma, err := s.buscarCronograma(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	plantas, err := s.plantasAlvo(usuarioID, aplicarDto)
+	if err != nil {
+		return nil, err
+	}
+
+	planejamentos := make([]dto.PlanejamentoCronogramaDTO, 0, len(plantas))
+	for i := range plantas {
+		planta := &plantas[i]
+		aplicacao, err := s.aplicacaoRepositorio.BuscarPorCronogramaEPlanta(id, planta.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			aplicacao = &entity.AplicacaoCronograma{
+				CronogramaID:    id,
+				PlantaID:        planta.ID,
+				DiarioCultivoID: aplicarDto.DiarioCultivoID,
+				UsuarioID:       usuarioID,
+			}
+			err = s.aplicacaoRepositorio.Criar(aplicacao)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("falha ao aplicar cronograma %d na planta %d: %w", id, planta.ID, err)
+		}
+
+		estagio, err := s.estagioAtual(planta.ID)
+		if err != nil {
+			return nil, err
+		}
+		planejamento, err := s.planejar(aplicacao, cronograma, planta, estagio)
+		if err != nil {
+			return nil, err
+		}
+		planejamentos = append(planejamentos, *planejamento)
+	}
+	return planejamentos, nil
+}
+
+func (s *cronogramaCultivoService) ListarAplicacoes(id, usuarioID uint) ([]entity.AplicacaoCronograma, error) {
+	if _, err := s.buscarCronograma(id, usuarioID); err != nil {
+		return nil, err
+	}
+	return s.aplicacaoRepositorio.ListarPorCronograma(id)
+}
+
+func (s *cronogramaCultivoService) RemoverAplicacao(aplicacaoID, usuarioID uint) error {
+	if aplicacaoID == 0 {
+		return utils.ErrInvalidInput
+	}
+	aplicacao, err := s.aplicacaoRepositorio.BuscarPorID(aplicacaoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao buscar aplicação de cronograma com ID %d: %w", aplicacaoID, err)
+	}
+	if aplicacao.UsuarioID != usuarioID {
+		return utils.ErrNotFound
+	}
+
+	if err := s.removerPendentes(aplicacaoID); err != nil {
+		return err
+	}
+	if err := s.aplicacaoRepositorio.Deletar(aplicacaoID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao remover aplicação de cronograma com ID %d: %w", aplicacaoID, err)
+	}
+	return nil
+}
+
+// EstagioIniciado replaneja os cronogramas aplicados na planta para o novo estágio
+func (s *cronogramaCultivoService) EstagioIniciado(planta *entity.Planta, estagio *entity.EstagioCrescimento) error {
+	aplicacoes, err := s.aplicacaoRepositorio.ListarPorPlanta(planta.ID)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i := range aplicacoes {
+		aplicacao := &aplicacoes[i]
+		if aplicacao.Cronograma == nil {
+			continue
+		}
+		if _, err := s.planejar(aplicacao, aplicacao.Cronograma, planta, estagio); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// replanejar busca a planta e o estágio atual da aplicação antes de planejar
+func (s *cronogramaCultivoService) replanejar(aplicacao *entity.AplicacaoCronograma, cronograma *entity.CronogramaCultivo) (*dto.PlanejamentoCronogramaDTO, error) {
+	planta, err := s.plantaRepositorio.BuscarPorID(aplicacao.PlantaID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar planta com ID %d: %w", aplicacao.PlantaID, err)
+	}
+	estagio, err := s.estagioAtual(planta.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.planejar(aplicacao, cronograma, planta, estagio)
+}
+
+// chaveOcorrencia identifica a ocorrência de um item do cronograma em uma data
+type chaveOcorrencia struct {
+	templateID uint
+	data       int64
+}
+
+// planejar alinha as tarefas da aplicação ao estágio atual da planta (nil se ainda não houver):
+// remove as pendentes que não pertencem mais à agenda e cria as que faltam, de hoje em diante.
+// Tarefas concluídas nunca são removidas nem recriadas.
+func (s *cronogramaCultivoService) planejar(
+	aplicacao *entity.AplicacaoCronograma,
+	cronograma *entity.CronogramaCultivo,
+	planta *entity.Planta,
+	estagio *entity.EstagioCrescimento,
+) (*dto.PlanejamentoCronogramaDTO, error) {
+	planejamento := &dto.PlanejamentoCronogramaDTO{Aplicacao: *aplicacao, TarefasCriadas: []entity.Tarefa{}}
+	hoje := s.inicioDoDia(s.agora())
+
+	// agenda completa do estágio atual, inclusive os dias que já passaram
+	var itens []entity.TarefaTemplate
+	var inicioEstagio time.Time
+	if estagio != nil {
+		planejamento.Estagio = estagio.Estagio
+		itens = cronograma.ItensDoEstagio(estagio.Estagio)
+		inicioEstagio = s.inicioDoDia(estagio.DataInicio)
+	}
+	itensAtuais := make(map[uint]entity.TarefaTemplate, len(itens))
+	agenda := make(map[chaveOcorrencia]bool)
+	for _, item := range itens {
+		itensAtuais[item.ID] = item
+		for _, dia := range item.DiasDoEstagio() {
+			agenda[chaveOcorrencia{item.ID, s.dataNoEstagio(inicioEstagio, dia).Unix()}] = true
+		}
+	}
+
+	existentes, err := s.tarefaRepositorio.ListarPorAplicacao(aplicacao.ID)
+	if err != nil {
+		return nil, err
+	}
+	existe := make(map[chaveOcorrencia]bool)
+	serieIniciada := make(map[uint]bool)
+	for _, tarefa := range existentes {
+		if tarefa.TarefaTemplateID == nil {
+			continue
+		}
+		chave := chaveOcorrencia{*tarefa.TarefaTemplateID, tarefa.DataAgendada.Unix()}
+		item, atual := itensAtuais[*tarefa.TarefaTemplateID]
+		// nos itens contínuos, as ocorrências materializadas da série também pertencem à agenda
+		noEstagio := atual && !tarefa.DataAgendada.Before(inicioEstagio)
+		naAgenda := agenda[chave] || (noEstagio && item.Continuo())
+
+		if tarefa.Status != entity.StatusTarefaConcluida && !naAgenda {
+			if err := s.tarefaRepositorio.Deletar(tarefa.ID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("falha ao remover tarefa %d do cronograma: %w", tarefa.ID, err)
+			}
+			planejamento.TarefasRemovidas++
+			continue
+		}
+		existe[chave] = true
+		if noEstagio && item.Continuo() {
+			serieIniciada[item.ID] = true
+		}
+	}
+
+	for _, item := range itens {
+		if item.Continuo() && serieIniciada[item.ID] {
+			continue
+		}
+		for _, dia := range item.DiasDoEstagio() {
+			data := s.dataNoEstagio(inicioEstagio, dia)
+			// a série contínua começa na primeira ocorrência de hoje em diante
+			for item.Continuo() && data.Before(hoje) {
+				dia += item.FrequenciaDias
+				data = s.dataNoEstagio(inicioEstagio, dia)
+			}
+			if data.Before(hoje) || existe[chaveOcorrencia{item.ID, data.Unix()}] {
+				continue
+			}
+			tarefa := tarefaDoItem(item, aplicacao, planta, data)
+			if err := s.tarefaRepositorio.Criar(tarefa); err != nil {
+				return nil, fmt.Errorf("falha ao criar tarefa do cronograma para a planta %d: %w", planta.ID, err)
+			}
+			planejamento.TarefasCriadas = append(planejamento.TarefasCriadas, *tarefa)
+		}
+	}
+	return planejamento, nil
+}
+
+// removerPendentes remove as tarefas ainda não concluídas geradas pela aplicação
+func (s *cronogramaCultivoService) removerPendentes(aplicacaoID uint) error {
+	tarefas, err := s.tarefaRepositorio.ListarPorAplicacao(aplicacaoID)
+	if err != nil {
+		return err
+	}
+	for _, tarefa := range tarefas {
+		if tarefa.Status == entity.StatusTarefaConcluida {
+			continue
+		}
+		if err := s.tarefaRepositorio.Deletar(tarefa.ID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("falha ao remover tarefa %d do cronograma: %w", tarefa.ID, err)
+		}
+	}
+	return nil
+}
+
+// plantasAlvo reúne, sem repetir, as plantas informadas e as do diário, todas do usuário
+func (s *cronogramaCultivoService) plantasAlvo(usuarioID uint, aplicarDto *dto.AplicarCronogramaDTO) ([]entity.Planta, error) {
+	var plantas []entity.Planta
+	vistas := make(map[uint]bool)
+	for _, plantaID := range aplicarDto.PlantaIDs {
+		if vistas[plantaID] {
+			continue
+		}
+		planta, err := s.plantaRepositorio.BuscarPorID(plantaID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("falha ao buscar planta com ID %d: %w", plantaID, err)
+		}
+		if err != nil || planta.UsuarioID != usuarioID {
+			return nil, fmt.Errorf("%w: planta %d não encontrada", utils.ErrInvalidInput, plantaID)
+		}
+		vistas[plantaID] = true
+		plantas = append(plantas, *planta)
+	}
+
+	if aplicarDto.DiarioCultivoID != nil {
+		diarioID := *aplicarDto.DiarioCultivoID
+		diario, err := s.diarioRepositorio.GetByID(diarioID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("falha ao buscar diário de cultivo com ID %d: %w", diarioID, err)
+		}
+		if err != nil || diario.UsuarioID != usuarioID {
+			return nil, fmt.Errorf("%w: diário de cultivo %d não encontrado", utils.ErrInvalidInput, diarioID)
+		}
+		doDiario, err := s.plantaRepositorio.ListarPorDiario(diarioID)
+		if err != nil {
+			return nil, err
+		}
+		for _, planta := range doDiario {
+			if vistas[planta.ID] || planta.UsuarioID != usuarioID {
+				continue
+			}
+			vistas[planta.ID] = true
+			plantas = append(plantas, planta)
+		}
+	}
+
+	if len(plantas) == 0 {
+		return nil, fmt.Errorf("%w: informe planta_ids ou um diário com plantas", utils.ErrInvalidInput)
+	}
+	return plantas, nil
+}
+
+// estagioAtual retorna nil quando a planta ainda não iniciou nenhum estágio
+func (s *cronogramaCultivoService) estagioAtual(plantaID uint) (*entity.EstagioCrescimento, error) {
+	estagio, err := s.estagioRepositorio.BuscarAtual(plantaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("falha ao buscar estágio da planta %d: %w", plantaID, err)
+	}
+	return estagio, nil
+}
+
+// buscarCronograma retorna ErrNotFound também para cronogramas de outro usuário
+func (s *cronogramaCultivoService) buscarCronograma(id, usuarioID uint) (*entity.CronogramaCultivo, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	cronograma, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar cronograma com ID %d: %w", id, err)
+	}
+	if cronograma.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return cronograma, nil
+}
+
+func (s *cronogramaCultivoService) inicioDoDia(t time.Time) time.Time {
+	t = t.In(s.local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.local)
+}
+
+// dataNoEstagio agenda o dia do estágio (0 = dia de início) no horário padrão das tarefas
+func (s *cronogramaCultivoService) dataNoEstagio(inicioEstagio time.Time, dia int) time.Time {
+	return time.Date(inicioEstagio.Year(), inicioEstagio.Month(), inicioEstagio.Day()+dia, horaTarefasCronograma, 0, 0, 0, s.local)
+}
+
+// montarItensCronograma valida os itens e os numera na ordem recebida
+func montarItensCronograma(itensDto []dto.ItemCronogramaDTO) ([]entity.TarefaTemplate, error) {
+	if len(itensDto) == 0 {
+		return nil, fmt.Errorf("%w: o cronograma precisa de ao menos um item", utils.ErrInvalidInput)
+	}
+	itens := make([]entity.TarefaTemplate, 0, len(itensDto))
+	for i, itemDto := range itensDto {
+		if !itemDto.Estagio.Valid() {
+			return nil, fmt.Errorf("%w: item %d com estágio %q inválido", utils.ErrInvalidInput, i+1, itemDto.Estagio)
+		}
+		if itemDto.DiaFim != nil {
+			if itemDto.FrequenciaDias == 0 {
+				return nil, fmt.Errorf("%w: item %d tem dia_fim sem frequencia_dias", utils.ErrInvalidInput, i+1)
+			}
+			if *itemDto.DiaFim < itemDto.DiaInicio {
+				return nil, fmt.Errorf("%w: item %d termina antes de começar", utils.ErrInvalidInput, i+1)
+			}
+		}
+		prioridade := entity.PrioridadeTarefa(itemDto.Prioridade)
+		if prioridade == "" {
+			prioridade = entity.PrioridadeMedia
+		}
+		itens = append(itens, entity.TarefaTemplate{
+			Nome:           itemDto.Nome,
+			Descricao:      itemDto.Descricao,
+			Tipo:           itemDto.Tipo,
+			FrequenciaDias: itemDto.FrequenciaDias,
+			Instrucoes:     itemDto.Instrucoes,
+			Estagio:        itemDto.Estagio,
+			DiaInicio:      itemDto.DiaInicio,
+			DiaFim:         itemDto.DiaFim,
+			Ordem:          i,
+			Prioridade:     prioridade,
+		})
+	}
+	return itens, nil
+}
+
+// tarefaDoItem cria a ocorrência do item para a planta; itens contínuos viram tarefas
+// recorrentes, materializadas pelo agendador até a planta mudar de estágio
+func tarefaDoItem(item entity.TarefaTemplate, aplicacao *entity.AplicacaoCronograma, planta *entity.Planta, data time.Time) *entity.Tarefa {
+	descricao := item.Nome
+	if item.Descricao != "" {
+		descricao += "\n" + item.Descricao
+	}
+	if item.Instrucoes != "" {
+		descricao += "\n\n" + item.Instrucoes
+	}
+	plantaID, templateID, aplicacaoID := planta.ID, item.ID, aplicacao.ID
+	tarefa := &entity.Tarefa{
+		Tipo:                  item.Tipo,
+		Descricao:             descricao,
+		DataAgendada:          data,
+		Status:                entity.StatusTarefaPendente,
+		Prioridade:            item.Prioridade,
+		PlantaID:              &plantaID,
+		UsuarioID:             aplicacao.UsuarioID,
+		DiarioCultivoID:       aplicacao.DiarioCultivoID,
+		TarefaTemplateID:      &templateID,
+		AplicacaoCronogramaID: &aplicacaoID,
+	}
+	if tarefa.Prioridade == "" {
+		tarefa.Prioridade = entity.PrioridadeMedia
+	}
+	if planta.AmbienteID != 0 {
+		ambienteID := planta.AmbienteID
+		tarefa.AmbienteID = &ambienteID
+	}
+	if item.Continuo() {
+		frequencia := item.FrequenciaDias
+		tarefa.Recorrente = true
+		tarefa.FrequenciaDias = &frequencia
+	}
+	return tarefa
+}
@@ -0,0 +1,219 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mocksCronograma struct {
+	repositorio  *test.MockCronogramaCultivoRepositorio
+	aplicacoes   *test.MockAplicacaoCronogramaRepositorio
+	tarefas      *test.MockTarefaRepositorio
+	plantaRepo   *test.MockPlantaRepositorio
+	estagioRepo  *test.MockEstagioCrescimentoRepositorio
+	diarioRepo   *MockDiarioCultivoRepository
+	tarefasNovas []*entity.Tarefa
+}
+
+func novoCronogramaCultivoService() (service.CronogramaCultivoService, *mocksCronograma) {
+	m := &mocksCronograma{
+		repositorio: new(test.MockCronogramaCultivoRepositorio),
+		aplicacoes:  new(test.MockAplicacaoCronogramaRepositorio),
+		tarefas:     new(test.MockTarefaRepositorio),
+		plantaRepo:  new(test.MockPlantaRepositorio),
+		estagioRepo: new(test.MockEstagioCrescimentoRepositorio),
+		diarioRepo:  new(MockDiarioCultivoRepository),
+	}
+	m.tarefas.On("Criar", mock.AnythingOfType("*entity.Tarefa")).Run(func(args mock.Arguments) {
+		m.tarefasNovas = append(m.tarefasNovas, args.Get(0).(*entity.Tarefa))
+	}).Return(nil).Maybe()
+	servico := service.NewCronogramaCultivoService(m.repositorio, m.aplicacoes, m.tarefas, m.plantaRepo, m.estagioRepo, m.diarioRepo, time.UTC)
+	return servico, m
+}
+
+// cronogramaVegFlora aduba a cada 3 dias na 2ª semana de vega, rega a cada 2 dias durante
+// toda a vega e desfolha no dia 21 da floração
+func cronogramaVegFlora() *entity.CronogramaCultivo {
+	diaFim := 13
+	itens := []entity.TarefaTemplate{
+		{Nome: "Adubar", Tipo: "adubar", Estagio: entity.EstagioVegetativo, DiaInicio: 7, DiaFim: &diaFim, FrequenciaDias: 3, Prioridade: entity.PrioridadeMedia},
+		{Nome: "Regar", Tipo: "regar", Estagio: entity.EstagioVegetativo, FrequenciaDias: 2, Prioridade: entity.PrioridadeMedia},
+		{Nome: "Desfolhar", Tipo: "podar", Estagio: entity.EstagioFloracao, DiaInicio: 21, Instrucoes: "Remover folhas que sombreiam os topos", Prioridade: entity.PrioridadeAlta},
+	}
+	for i := range itens {
+		itens[i].ID = uint(i + 1)
+	}
+	cronograma := &entity.CronogramaCultivo{Nome: "Indoor foto", UsuarioID: 7, Itens: itens}
+	cronograma.ID = 1
+	return cronograma
+}
+
+func plantaDoUsuario(id, usuarioID uint) *entity.Planta {
+	planta := &entity.Planta{Nome: "Planta", UsuarioID: usuarioID, AmbienteID: 4}
+	planta.ID = id
+	return planta
+}
+
+func diaUTC(t time.Time, dias int) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day()+dias, 9, 0, 0, 0, time.UTC)
+}
+
+func TestCronogramaCultivoService_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servico, m := novoCronogramaCultivoService()
+		m.repositorio.On("Criar", mock.AnythingOfType("*entity.CronogramaCultivo")).Return(nil).Once()
+		diaFim := 13
+
+		cronograma, err := servico.Criar(7, &dto.CronogramaCultivoDTO{
+			Nome: "Indoor foto",
+			Itens: []dto.ItemCronogramaDTO{
+				{Nome: "Adubar", Tipo: "adubar", Estagio: entity.EstagioVegetativo, DiaInicio: 7, DiaFim: &diaFim, FrequenciaDias: 3},
+				{Nome: "Desfolhar", Tipo: "podar", Estagio: entity.EstagioFloracao, DiaInicio: 21, Prioridade: "alta"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, cronograma.Itens, 2)
+		assert.Equal(t, []int{7, 10, 13}, cronograma.Itens[0].DiasDoEstagio())
+		assert.Equal(t, entity.PrioridadeMedia, cronograma.Itens[0].Prioridade)
+		assert.Equal(t, 1, cronograma.Itens[1].Ordem)
+	})
+
+	t.Run("Error - Itens Inválidos", func(t *testing.T) {
+		servico, m := novoCronogramaCultivoService()
+		diaFim := 5
+
+		_, err := servico.Criar(7, &dto.CronogramaCultivoDTO{Nome: "X", Itens: []dto.ItemCronogramaDTO{
+			{Nome: "Adubar", Tipo: "adubar", Estagio: entity.EstagioVegetativo, DiaFim: &diaFim},
+		}})
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+
+		_, err = servico.Criar(7, &dto.CronogramaCultivoDTO{Nome: "X", Itens: []dto.ItemCronogramaDTO{
+			{Nome: "Adubar", Tipo: "adubar", Estagio: "secagem"},
+		}})
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.repositorio.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+}
+
+func TestCronogramaCultivoService_Aplicar(t *testing.T) {
+	t.Run("Success - Gera O Estágio Atual A Partir De Hoje", func(t *testing.T) {
+		servico, m := novoCronogramaCultivoService()
+		inicioVega := time.Now().AddDate(0, 0, -10)
+		m.repositorio.On("BuscarPorID", uint(1)).Return(cronogramaVegFlora(), nil).Once()
+		m.plantaRepo.On("BuscarPorID", uint(3)).Return(plantaDoUsuario(3, 7), nil).Once()
+		m.aplicacoes.On("BuscarPorCronogramaEPlanta", uint(1), uint(3)).Return((*entity.AplicacaoCronograma)(nil), gorm.ErrRecordNotFound).Once()
+		m.aplicacoes.On("Criar", mock.AnythingOfType("*entity.AplicacaoCronograma")).Run(func(args mock.Arguments) {
+			args.Get(0).(*entity.AplicacaoCronograma).ID = 50
+		}).Return(nil).Once()
+		m.estagioRepo.On("BuscarAtual", uint(3)).Return(&entity.EstagioCrescimento{PlantaID: 3, Estagio: entity.EstagioVegetativo, DataInicio: inicioVega}, nil).Once()
+		m.tarefas.On("ListarPorAplicacao", uint(50)).Return([]entity.Tarefa{}, nil).Once()
+
+		planejamentos, err := servico.Aplicar(1, 7, &dto.AplicarCronogramaDTO{PlantaIDs: []uint{3, 3}})
+
+		require.NoError(t, err)
+		require.Len(t, planejamentos, 1)
+		assert.Equal(t, entity.EstagioVegetativo, planejamentos[0].Estagio)
+		// adubar nos dias 10 e 13 (o dia 7 já passou) e a série de rega a partir de hoje (dia 10)
+		require.Len(t, m.tarefasNovas, 3)
+		assert.Equal(t, "adubar", m.tarefasNovas[0].Tipo)
+		assert.True(t, m.tarefasNovas[0].DataAgendada.Equal(diaUTC(inicioVega, 10)))
+		assert.True(t, m.tarefasNovas[1].DataAgendada.Equal(diaUTC(inicioVega, 13)))
+		rega := m.tarefasNovas[2]
+		assert.Equal(t, "regar", rega.Tipo)
+		assert.True(t, rega.Recorrente)
+		assert.Equal(t, 2, *rega.FrequenciaDias)
+		assert.True(t, rega.DataAgendada.Equal(diaUTC(inicioVega, 10)))
+		assert.Equal(t, uint(50), *rega.AplicacaoCronogramaID)
+		assert.Equal(t, uint(4), *rega.AmbienteID)
+	})
+
+	t.Run("Success - Reaplicar Não Duplica", func(t *testing.T) {
+		servico, m := novoCronogramaCultivoService()
+		inicioVega := time.Now().AddDate(0, 0, -10)
+		aplicacao := &entity.AplicacaoCronograma{CronogramaID: 1, PlantaID: 3, UsuarioID: 7}
+		aplicacao.ID = 50
+		adubar, regar := uint(1), uint(2)
+		m.repositorio.On("BuscarPorID", uint(1)).Return(cronogramaVegFlora(), nil).Once()
+		m.plantaRepo.On("BuscarPorID", uint(3)).Return(plantaDoUsuario(3, 7), nil).Once()
+		m.aplicacoes.On("BuscarPorCronogramaEPlanta", uint(1), uint(3)).Return(aplicacao, nil).Once()
+		m.estagioRepo.On("BuscarAtual", uint(3)).Return(&entity.EstagioCrescimento{Estagio: entity.EstagioVegetativo, DataInicio: inicioVega}, nil).Once()
+		m.tarefas.On("ListarPorAplicacao", uint(50)).Return([]entity.Tarefa{
+			{TarefaTemplateID: &adubar, DataAgendada: diaUTC(inicioVega, 7), Status: entity.StatusTarefaConcluida},
+			{TarefaTemplateID: &adubar, DataAgendada: diaUTC(inicioVega, 10), Status: entity.StatusTarefaPendente},
+			{TarefaTemplateID: &adubar, DataAgendada: diaUTC(inicioVega, 13), Status: entity.StatusTarefaPendente},
+			{TarefaTemplateID: &regar, DataAgendada: diaUTC(inicioVega, 12), Status: entity.StatusTarefaPendente, Recorrente: true},
+		}, nil).Once()
+
+		planejamentos, err := servico.Aplicar(1, 7, &dto.AplicarCronogramaDTO{PlantaIDs: []uint{3}})
+
+		require.NoError(t, err)
+		assert.Empty(t, planejamentos[0].TarefasCriadas)
+		assert.Zero(t, planejamentos[0].TarefasRemovidas)
+		m.aplicacoes.AssertNotCalled(t, "Criar", mock.Anything)
+		m.tarefas.AssertNotCalled(t, "Deletar", mock.Anything)
+	})
+
+	t.Run("Error - Diário De Outro Usuário", func(t *testing.T) {
+		servico, m := novoCronogramaCultivoService()
+		diarioID := uint(9)
+		m.repositorio.On("BuscarPorID", uint(1)).Return(cronogramaVegFlora(), nil).Once()
+		m.diarioRepo.On("GetByID", diarioID).Return(&entity.DiarioCultivo{UsuarioID: 8}, nil).Once()
+
+		_, err := servico.Aplicar(1, 7, &dto.AplicarCronogramaDTO{DiarioCultivoID: &diarioID})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.aplicacoes.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+}
+
+func TestCronogramaCultivoService_EstagioIniciado_Replaneja(t *testing.T) {
+	servico, m := novoCronogramaCultivoService()
+	inicioVega := time.Now().AddDate(0, 0, -30)
+	aplicacao := entity.AplicacaoCronograma{CronogramaID: 1, PlantaID: 3, UsuarioID: 7, Cronograma: cronogramaVegFlora()}
+	aplicacao.ID = 50
+	adubar, regar := uint(1), uint(2)
+	concluida := entity.Tarefa{TarefaTemplateID: &adubar, DataAgendada: diaUTC(inicioVega, 13), Status: entity.StatusTarefaConcluida}
+	concluida.ID = 100
+	regaPendente := entity.Tarefa{TarefaTemplateID: &regar, DataAgendada: diaUTC(time.Now(), 1), Status: entity.StatusTarefaPendente, Recorrente: true}
+	regaPendente.ID = 101
+	m.aplicacoes.On("ListarPorPlanta", uint(3)).Return([]entity.AplicacaoCronograma{aplicacao}, nil).Once()
+	m.tarefas.On("ListarPorAplicacao", uint(50)).Return([]entity.Tarefa{concluida, regaPendente}, nil).Once()
+	m.tarefas.On("Deletar", uint(101)).Return(nil).Once()
+
+	err := servico.EstagioIniciado(plantaDoUsuario(3, 7), &entity.EstagioCrescimento{
+		PlantaID: 3, Estagio: entity.EstagioFloracao, DataInicio: time.Now(),
+	})
+
+	require.NoError(t, err)
+	m.tarefas.AssertExpectations(t)
+	m.tarefas.AssertNotCalled(t, "Deletar", uint(100))
+	require.Len(t, m.tarefasNovas, 1)
+	assert.Equal(t, "podar", m.tarefasNovas[0].Tipo)
+	assert.Equal(t, entity.PrioridadeAlta, m.tarefasNovas[0].Prioridade)
+	assert.Contains(t, m.tarefasNovas[0].Descricao, "Remover folhas")
+	assert.True(t, m.tarefasNovas[0].DataAgendada.Equal(diaUTC(time.Now(), 21)))
+}
+
+func TestCronogramaCultivoService_RemoverAplicacao(t *testing.T) {
+	servico, m := novoCronogramaCultivoService()
+	aplicacao := &entity.AplicacaoCronograma{UsuarioID: 8}
+	aplicacao.ID = 50
+	m.aplicacoes.On("BuscarPorID", uint(50)).Return(aplicacao, nil).Once()
+
+	err := servico.RemoverAplicacao(50, 7)
+
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	m.aplicacoes.AssertNotCalled(t, "Deletar", mock.Anything)
+}
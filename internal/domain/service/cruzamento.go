@@ -0,0 +1,368 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// origemCruzamentoPadrao é o breeder das genéticas criadas por cruzamentos sem origem informada
+const origemCruzamentoPadrao = "Cruzamento próprio"
+
+// CruzamentoService acompanha os projetos de melhoramento do usuário. Cada cruzamento cria a
+// genética resultante no catálogo, com mãe, pai e geração, e reúne as polinizações feitas nas
+// plantas e os lotes de sementes colhidos, guardados no banco de sementes.
+type CruzamentoService interface {
+	Criar(usuarioID uint, cruzamentoDto *dto.CruzamentoDTO) (*entity.Cruzamento, error)
+	Listar(usuarioID uint) ([]entity.Cruzamento, error)
+	// BuscarPorID carrega também as polinizações do cruzamento
+	BuscarPorID(id, usuarioID uint) (*entity.Cruzamento, error)
+	Deletar(id, usuarioID uint) error
+
+	RegistrarPolinizacao(id, usuarioID uint, polinizacaoDto *dto.PolinizacaoDTO) (*entity.Polinizacao, error)
+	ListarPolinizacoes(id, usuarioID uint) ([]entity.Polinizacao, error)
+	// RegistrarLote guarda as sementes colhidas como um pacote da genética resultante
+	RegistrarLote(id, usuarioID uint, loteDto *dto.LoteSementesDTO) (*entity.PacoteSemente, error)
+	ListarLotes(id, usuarioID uint) ([]entity.PacoteSemente, error)
+}
+
+type cruzamentoService struct {
+	repositorio         repository.CruzamentoRepositorio
+	geneticaRepositorio repository.GeneticaRepositorio
+	plantaRepositorio   repository.PlantaRepositorio
+	sementeRepositorio  repository.SementeRepositorio
+	agora               func() time.Time
+}
+
+// NewCruzamentoService cria o serviço de cruzamentos.
+func NewCruzamentoService(
+	repositorio repository.CruzamentoRepositorio,
+	geneticaRepositorio repository.GeneticaRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	sementeRepositorio repository.SementeRepositorio,
+) CruzamentoService {
+	return &cruzamentoService{
+		repositorio:         repositorio,
+		geneticaRepositorio: geneticaRepositorio,
+		plantaRepositorio:   plantaRepositorio,
+		sementeRepositorio:  sementeRepositorio,
+		agora:               time.Now,
+	}
+}
+
+func (s *cruzamentoService) Criar(usuarioID uint, cruzamentoDto *dto.CruzamentoDTO) (*entity.Cruzamento, error) {
+	if usuarioID == 0 || cruzamentoDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	nome := strings.TrimSpace(cruzamentoDto.Nome)
+	if nome == "" {
+		return nil, fmt.Errorf("%w: nome obrigatório", utils.ErrInvalidInput)
+	}
+	geracao := strings.ToUpper(strings.TrimSpace(cruzamentoDto.Geracao))
+	tipo, numero, ok := geracaoFilial(geracao)
+	if !ok {
+		return nil, fmt.Errorf("%w: geração %q inválida; use F1, F2, BX1, S1...", utils.ErrInvalidInput, cruzamentoDto.Geracao)
+	}
+	paiID := cruzamentoDto.PaiGeneticaID
+	if paiID == 0 {
+		if tipo != "S" {
+			return nil, fmt.Errorf("%w: informe a genética pai", utils.ErrInvalidInput)
+		}
+		paiID = cruzamentoDto.MaeGeneticaID
+	}
+
+	mae, err := s.buscarGeneticaOrigem(cruzamentoDto.MaeGeneticaID)
+	if err != nil {
+		return nil, err
+	}
+	pai := mae
+	if paiID != mae.ID {
+		if pai, err = s.buscarGeneticaOrigem(paiID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validarGeracao(mae, pai, tipo, numero); err != nil {
+		return nil, err
+	}
+
+	genetica := &entity.Genetica{
+		Nome:          nome,
+		Descricao:     fmt.Sprintf("%s × %s (%s)", mae.Nome, pai.Nome, geracao),
+		TipoGenetica:  cruzamentoDto.TipoGenetica,
+		TipoEspecie:   especieCruzamento(mae, pai, tipo),
+		TempoFloracao: cruzamentoDto.TempoFloracao,
+		Origem:        strings.TrimSpace(cruzamentoDto.Origem),
+		MaeID:         &mae.ID,
+		PaiID:         &pai.ID,
+		Geracao:       geracao,
+	}
+	if genetica.TipoGenetica == "" {
+		genetica.TipoGenetica = tipoGeneticaCruzamento(mae, pai)
+	}
+	if genetica.TempoFloracao == 0 {
+		genetica.TempoFloracao = int(math.Round(float64(mae.TempoFloracao+pai.TempoFloracao) / 2))
+	}
+	if genetica.Origem == "" {
+		genetica.Origem = origemCruzamentoPadrao
+	}
+	cruzamento := &entity.Cruzamento{
+		UsuarioID:     usuarioID,
+		Nome:          nome,
+		MaeGeneticaID: mae.ID,
+		PaiGeneticaID: pai.ID,
+		Geracao:       geracao,
+		Objetivo:      cruzamentoDto.Objetivo,
+	}
+	if err := s.repositorio.Criar(cruzamento, genetica); err != nil {
+		return nil, fmt.Errorf("falha ao criar cruzamento: %w", err)
+	}
+	cruzamento.MaeGenetica, cruzamento.PaiGenetica, cruzamento.Genetica = mae, pai, genetica
+	return cruzamento, nil
+}
+
+func (s *cruzamentoService) Listar(usuarioID uint) ([]entity.Cruzamento, error) {
+	return s.repositorio.Listar(usuarioID)
+}
+
+func (s *cruzamentoService) BuscarPorID(id, usuarioID uint) (*entity.Cruzamento, error) {
+	cruzamento, err := s.buscarCruzamento(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	polinizacoes, err := s.repositorio.ListarPolinizacoes(id)
+	if err != nil {
+		return nil, err
+	}
+	cruzamento.Polinizacoes = polinizacoes
+	return cruzamento, nil
+}
+
+func (s *cruzamentoService) Deletar(id, usuarioID uint) error {
+	if _, err := s.buscarCruzamento(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar cruzamento com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *cruzamentoService) RegistrarPolinizacao(id, usuarioID uint, polinizacaoDto *dto.PolinizacaoDTO) (*entity.Polinizacao, error) {
+	cruzamento, err := s.buscarCruzamento(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if polinizacaoDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	mae, err := s.buscarPlantaCruzamento(polinizacaoDto.PlantaMaeID, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if mae.GeneticaID != cruzamento.MaeGeneticaID {
+		return nil, fmt.Errorf("%w: a planta %d não é da genética mãe do cruzamento", utils.ErrInvalidInput, mae.ID)
+	}
+
+	polinizacao := &entity.Polinizacao{
+		CruzamentoID: cruzamento.ID,
+		PlantaMaeID:  mae.ID,
+		PlantaMae:    mae,
+		Data:         s.agora(),
+		Metodo:       polinizacaoDto.Metodo,
+		Observacoes:  polinizacaoDto.Observacoes,
+	}
+	if polinizacaoDto.Data != nil {
+		polinizacao.Data = *polinizacaoDto.Data
+	}
+	if polinizacaoDto.PlantaDoadoraID != nil {
+		doadoraID := *polinizacaoDto.PlantaDoadoraID
+		if doadoraID == mae.ID && cruzamento.MaeGeneticaID != cruzamento.PaiGeneticaID {
+			return nil, fmt.Errorf("%w: a planta mãe só poliniza a si mesma em autocruzamentos", utils.ErrInvalidInput)
+		}
+		doadora, err := s.buscarPlantaCruzamento(doadoraID, usuarioID)
+		if err != nil {
+			return nil, err
+		}
+		if doadora.GeneticaID != cruzamento.PaiGeneticaID {
+			return nil, fmt.Errorf("%w: a planta %d não é da genética pai do cruzamento", utils.ErrInvalidInput, doadora.ID)
+		}
+		polinizacao.PlantaDoadoraID = &doadora.ID
+		polinizacao.PlantaDoadora = doadora
+	}
+	if err := s.repositorio.RegistrarPolinizacao(polinizacao); err != nil {
+		return nil, fmt.Errorf("falha ao registrar polinização no cruzamento %d: %w", id, err)
+	}
+	return polinizacao, nil
+}
+
+func (s *cruzamentoService) ListarPolinizacoes(id, usuarioID uint) ([]entity.Polinizacao, error) {
+	if _, err := s.buscarCruzamento(id, usuarioID); err != nil {
+		return nil, err
+	}
+	return s.repositorio.ListarPolinizacoes(id)
+}
+
+func (s *cruzamentoService) RegistrarLote(id, usuarioID uint, loteDto *dto.LoteSementesDTO) (*entity.PacoteSemente, error) {
+	cruzamento, err := s.buscarCruzamento(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if loteDto == nil || loteDto.Quantidade <= 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	if loteDto.PolinizacaoID != nil {
+		polinizacao, err := s.repositorio.BuscarPolinizacao(*loteDto.PolinizacaoID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("falha ao buscar polinização com ID %d: %w", *loteDto.PolinizacaoID, err)
+		}
+		if err != nil || polinizacao.CruzamentoID != cruzamento.ID {
+			return nil, fmt.Errorf("%w: polinização %d não encontrada no cruzamento", utils.ErrInvalidInput, *loteDto.PolinizacaoID)
+		}
+	}
+
+	colheita := s.agora()
+	if loteDto.DataColheita != nil {
+		colheita = *loteDto.DataColheita
+	}
+	breeder := origemCruzamentoPadrao
+	if cruzamento.Genetica != nil && cruzamento.Genetica.Origem != "" {
+		breeder = cruzamento.Genetica.Origem
+	}
+	pacote := &entity.PacoteSemente{
+		UsuarioID:          usuarioID,
+		GeneticaID:         cruzamento.GeneticaID,
+		Breeder:            breeder,
+		QuantidadeInicial:  loteDto.Quantidade,
+		QuantidadeRestante: loteDto.Quantidade,
+		DataAquisicao:      &colheita,
+		LocalArmazenamento: loteDto.LocalArmazenamento,
+		NotasViabilidade:   loteDto.NotasViabilidade,
+		CruzamentoID:       &cruzamento.ID,
+		PolinizacaoID:      loteDto.PolinizacaoID,
+	}
+	if err := s.sementeRepositorio.CriarPacote(pacote); err != nil {
+		return nil, fmt.Errorf("falha ao registrar lote de sementes do cruzamento %d: %w", id, err)
+	}
+	pacote.Genetica = cruzamento.Genetica
+	return pacote, nil
+}
+
+func (s *cruzamentoService) ListarLotes(id, usuarioID uint) ([]entity.PacoteSemente, error) {
+	if _, err := s.buscarCruzamento(id, usuarioID); err != nil {
+		return nil, err
+	}
+	return s.sementeRepositorio.ListarPacotes(repository.FiltroPacotesSemente{UsuarioID: usuarioID, CruzamentoID: id})
+}
+
+// validarGeracao confere a geração com as genéticas de origem: F1 cruza genéticas diferentes,
+// Fn cruza genéticas F(n-1), Sn autocruza uma genética (S(n-1) a partir do S2) e BXn cruza uma
+// genética F1 (ou BX(n-1)) com uma das genéticas de origem dela
+func (s *cruzamentoService) validarGeracao(mae, pai *entity.Genetica, tipo string, numero int) error {
+	switch tipo {
+	case "F":
+		if numero == 1 {
+			if mae.ID == pai.ID {
+				return fmt.Errorf("%w: um F1 cruza duas genéticas diferentes; para autocruzamento use S1", utils.ErrInvalidInput)
+			}
+			return nil
+		}
+		anterior := fmt.Sprintf("F%d", numero-1)
+		if !strings.EqualFold(mae.Geracao, anterior) || !strings.EqualFold(pai.Geracao, anterior) {
+			return fmt.Errorf("%w: um F%d cruza genéticas %s", utils.ErrInvalidInput, numero, anterior)
+		}
+	case "S":
+		if mae.ID != pai.ID {
+			return fmt.Errorf("%w: autocruzamentos usam a mesma genética como mãe e pai", utils.ErrInvalidInput)
+		}
+		if anterior := fmt.Sprintf("S%d", numero-1); numero > 1 && !strings.EqualFold(mae.Geracao, anterior) {
+			return fmt.Errorf("%w: um S%d autocruza uma genética %s", utils.ErrInvalidInput, numero, anterior)
+		}
+	case "BX":
+		anterior := "F1"
+		if numero > 1 {
+			anterior = fmt.Sprintf("BX%d", numero-1)
+		}
+		conhecidas, err := carregarAncestrais(s.geneticaRepositorio, []entity.Genetica{*mae, *pai}, profundidadeMaximaLinhagem)
+		if err != nil {
+			return err
+		}
+		retrocruzamento := func(hibrida, recorrente *entity.Genetica) bool {
+			return strings.EqualFold(hibrida.Geracao, anterior) && ehAncestral(recorrente.ID, *hibrida, conhecidas)
+		}
+		if mae.ID == pai.ID || (!retrocruzamento(mae, pai) && !retrocruzamento(pai, mae)) {
+			return fmt.Errorf("%w: um BX%d cruza uma genética %s com uma das genéticas de origem dela", utils.ErrInvalidInput, numero, anterior)
+		}
+	}
+	return nil
+}
+
+func (s *cruzamentoService) buscarCruzamento(id, usuarioID uint) (*entity.Cruzamento, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	cruzamento, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar cruzamento com ID %d: %w", id, err)
+	}
+	if cruzamento.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return cruzamento, nil
+}
+
+func (s *cruzamentoService) buscarGeneticaOrigem(id uint) (*entity.Genetica, error) {
+	genetica, err := s.geneticaRepositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: genética %d não encontrada", utils.ErrInvalidInput, id)
+		}
+		return nil, fmt.Errorf("falha ao buscar genética com ID %d: %w", id, err)
+	}
+	return genetica, nil
+}
+
+// buscarPlantaCruzamento trata plantas de outros usuários como inexistentes
+func (s *cruzamentoService) buscarPlantaCruzamento(id, usuarioID uint) (*entity.Planta, error) {
+	planta, err := s.plantaRepositorio.BuscarPorID(id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("falha ao buscar planta com ID %d: %w", id, err)
+	}
+	if err != nil || planta.UsuarioID != usuarioID {
+		return nil, fmt.Errorf("%w: planta %d não encontrada", utils.ErrInvalidInput, id)
+	}
+	return planta, nil
+}
+
+// tipoGeneticaCruzamento mantém o tipo quando os pais são iguais; caso contrário, é híbrido
+func tipoGeneticaCruzamento(mae, pai *entity.Genetica) string {
+	if mae.TipoGenetica != "" && strings.EqualFold(mae.TipoGenetica, pai.TipoGenetica) {
+		return mae.TipoGenetica
+	}
+	return "hibrido"
+}
+
+// especieCruzamento: autocruzamentos dão sementes feminizadas; automáticas só se mantêm quando
+// os dois pais são automáticos
+func especieCruzamento(mae, pai *entity.Genetica, tipo string) string {
+	switch {
+	case tipo == "S":
+		return "feminizada"
+	case mae.TipoEspecie == "automatica" && pai.TipoEspecie == "automatica":
+		return "automatica"
+	}
+	return "regular"
+}
@@ -0,0 +1,267 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type cruzamentoMocks struct {
+	cruzamentos  *test.MockCruzamentoRepositorio
+	geneticaRepo *test.MockGeneticaRepositorio
+	plantaRepo   *test.MockPlantaRepositorio
+	sementes     *test.MockSementeRepositorio
+}
+
+func novoCruzamentoService() (service.CruzamentoService, cruzamentoMocks) {
+	m := cruzamentoMocks{
+		cruzamentos:  new(test.MockCruzamentoRepositorio),
+		geneticaRepo: new(test.MockGeneticaRepositorio),
+		plantaRepo:   new(test.MockPlantaRepositorio),
+		sementes:     new(test.MockSementeRepositorio),
+	}
+	return service.NewCruzamentoService(m.cruzamentos, m.geneticaRepo, m.plantaRepo, m.sementes), m
+}
+
+// geneticaLinhagem é uma genética com origem e geração, para montar linhagens nos testes
+func geneticaLinhagem(id uint, nome, tipo, geracao string, maeID, paiID *uint) *entity.Genetica {
+	genetica := &entity.Genetica{Nome: nome, TipoGenetica: tipo, TipoEspecie: "regular", TempoFloracao: 60,
+		Origem: "Sensi Seeds", Geracao: geracao, MaeID: maeID, PaiID: paiID}
+	genetica.ID = id
+	return genetica
+}
+
+// cruzamentoUsuario é um cruzamento F1 do usuário 7 entre as genéticas 1 e 2, que gerou a 10
+func cruzamentoUsuario(id uint) *entity.Cruzamento {
+	cruzamento := &entity.Cruzamento{UsuarioID: 7, Nome: "Gelato x Skunk", MaeGeneticaID: 1, PaiGeneticaID: 2,
+		GeneticaID: 10, Genetica: geneticaLinhagem(10, "Gelato x Skunk", "hibrido", "F1", ref(1), ref(2)), Geracao: "F1"}
+	cruzamento.Genetica.Origem = "Quintal Genetics"
+	cruzamento.ID = id
+	return cruzamento
+}
+
+func plantaCruzamento(id, usuarioID, geneticaID uint) *entity.Planta {
+	planta := &entity.Planta{UsuarioID: usuarioID, GeneticaID: geneticaID}
+	planta.ID = id
+	return planta
+}
+
+func ref(id uint) *uint {
+	return &id
+}
+
+func TestCruzamentoService_Criar(t *testing.T) {
+	t.Run("Success - F1 Cria a Genética Resultante", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		mae := geneticaLinhagem(1, "Gelato", "indica", "", nil, nil)
+		pai := geneticaLinhagem(2, "Durban Poison", "sativa", "", nil, nil)
+		pai.TempoFloracao = 65
+		m.geneticaRepo.On("BuscarPorID", uint(1)).Return(mae, nil).Once()
+		m.geneticaRepo.On("BuscarPorID", uint(2)).Return(pai, nil).Once()
+		var resultante *entity.Genetica
+		m.cruzamentos.On("Criar", mock.AnythingOfType("*entity.Cruzamento"), mock.AnythingOfType("*entity.Genetica")).
+			Run(func(args mock.Arguments) {
+				resultante = args.Get(1).(*entity.Genetica)
+				resultante.ID = 10
+				args.Get(0).(*entity.Cruzamento).GeneticaID = 10
+			}).Return(nil).Once()
+
+		cruzamento, err := servico.Criar(7, &dto.CruzamentoDTO{Nome: " Gelato Durban ", MaeGeneticaID: 1, PaiGeneticaID: 2, Geracao: "f1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "F1", cruzamento.Geracao)
+		assert.Equal(t, uint(10), cruzamento.GeneticaID)
+		require.NotNil(t, resultante)
+		assert.Equal(t, "Gelato Durban", resultante.Nome)
+		assert.Equal(t, "hibrido", resultante.TipoGenetica)
+		assert.Equal(t, "regular", resultante.TipoEspecie)
+		assert.Equal(t, 63, resultante.TempoFloracao) // média de 60 e 65, arredondada
+		assert.Equal(t, "Cruzamento próprio", resultante.Origem)
+		assert.Equal(t, ref(1), resultante.MaeID)
+		assert.Equal(t, ref(2), resultante.PaiID)
+		assert.Equal(t, "F1", resultante.Geracao)
+		m.cruzamentos.AssertExpectations(t)
+	})
+
+	t.Run("Success - S1 Usa a Mãe Como Pai", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		mae := geneticaLinhagem(1, "Gelato", "indica", "", nil, nil)
+		m.geneticaRepo.On("BuscarPorID", uint(1)).Return(mae, nil).Once()
+		var resultante *entity.Genetica
+		m.cruzamentos.On("Criar", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			resultante = args.Get(1).(*entity.Genetica)
+		}).Return(nil).Once()
+
+		cruzamento, err := servico.Criar(7, &dto.CruzamentoDTO{Nome: "Gelato S1", MaeGeneticaID: 1, Geracao: "S1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), cruzamento.PaiGeneticaID)
+		assert.Equal(t, "indica", resultante.TipoGenetica)
+		assert.Equal(t, "feminizada", resultante.TipoEspecie)
+		assert.Equal(t, ref(1), resultante.PaiID)
+		m.geneticaRepo.AssertNumberOfCalls(t, "BuscarPorID", 1)
+	})
+
+	t.Run("Success - F2 Entre Irmãos F1", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		f1 := geneticaLinhagem(10, "Gelato x Skunk", "hibrido", "F1", ref(1), ref(2))
+		m.geneticaRepo.On("BuscarPorID", uint(10)).Return(f1, nil).Once()
+		m.cruzamentos.On("Criar", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := servico.Criar(7, &dto.CruzamentoDTO{Nome: "Gelato x Skunk F2", MaeGeneticaID: 10, PaiGeneticaID: 10, Geracao: "F2"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success - BX1 Com a Mãe do F1", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		f1 := geneticaLinhagem(10, "Gelato x Skunk", "hibrido", "F1", ref(1), ref(2))
+		recorrente := geneticaLinhagem(1, "Gelato", "indica", "", nil, nil)
+		m.geneticaRepo.On("BuscarPorID", uint(10)).Return(f1, nil).Once()
+		m.geneticaRepo.On("BuscarPorID", uint(1)).Return(recorrente, nil).Once()
+		m.geneticaRepo.On("BuscarPorIDs", []uint{2}).Return([]entity.Genetica{*geneticaLinhagem(2, "Skunk #1", "sativa", "", nil, nil)}, nil).Once()
+		m.cruzamentos.On("Criar", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := servico.Criar(7, &dto.CruzamentoDTO{Nome: "Gelato BX1", MaeGeneticaID: 10, PaiGeneticaID: 1, Geracao: "BX1"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error - Geração Incompatível Com os Pais", func(t *testing.T) {
+		casos := map[string]*dto.CruzamentoDTO{
+			"F1 da mesma genética":     {Nome: "Gelato", MaeGeneticaID: 1, PaiGeneticaID: 1, Geracao: "F1"},
+			"F2 sem pais F1":           {Nome: "Gelato", MaeGeneticaID: 1, PaiGeneticaID: 2, Geracao: "F2"},
+			"S1 com pai diferente":     {Nome: "Gelato", MaeGeneticaID: 1, PaiGeneticaID: 2, Geracao: "S1"},
+			"BX1 sem parentesco":       {Nome: "Gelato", MaeGeneticaID: 1, PaiGeneticaID: 2, Geracao: "BX1"},
+			"geração desconhecida":     {Nome: "Gelato", MaeGeneticaID: 1, PaiGeneticaID: 2, Geracao: "P1"},
+			"F1 sem genética pai":      {Nome: "Gelato", MaeGeneticaID: 1, Geracao: "F1"},
+			"genética pai inexistente": {Nome: "Gelato", MaeGeneticaID: 1, PaiGeneticaID: 9, Geracao: "F1"},
+		}
+		for nome, cruzamentoDto := range casos {
+			t.Run(nome, func(t *testing.T) {
+				servico, m := novoCruzamentoService()
+				m.geneticaRepo.On("BuscarPorID", uint(1)).Return(geneticaLinhagem(1, "Gelato", "indica", "", nil, nil), nil)
+				m.geneticaRepo.On("BuscarPorID", uint(2)).Return(geneticaLinhagem(2, "Skunk #1", "sativa", "", nil, nil), nil)
+				m.geneticaRepo.On("BuscarPorID", uint(9)).Return((*entity.Genetica)(nil), gorm.ErrRecordNotFound)
+
+				_, err := servico.Criar(7, cruzamentoDto)
+
+				assert.ErrorIs(t, err, utils.ErrInvalidInput)
+				m.cruzamentos.AssertNotCalled(t, "Criar", mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
+
+func TestCruzamentoService_BuscarPorID(t *testing.T) {
+	t.Run("Error - Cruzamento de Outro Usuário", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		m.cruzamentos.On("BuscarPorID", uint(3)).Return(cruzamentoUsuario(3), nil).Once()
+
+		_, err := servico.BuscarPorID(3, 8)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+
+	t.Run("Success - Carrega as Polinizações", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		m.cruzamentos.On("BuscarPorID", uint(3)).Return(cruzamentoUsuario(3), nil).Once()
+		m.cruzamentos.On("ListarPolinizacoes", uint(3)).Return([]entity.Polinizacao{{CruzamentoID: 3, PlantaMaeID: 20}}, nil).Once()
+
+		cruzamento, err := servico.BuscarPorID(3, 7)
+		require.NoError(t, err)
+		assert.Len(t, cruzamento.Polinizacoes, 1)
+	})
+}
+
+func TestCruzamentoService_RegistrarPolinizacao(t *testing.T) {
+	t.Run("Success - Mãe e Doadora das Genéticas do Cruzamento", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		data := time.Date(2026, 8, 10, 9, 0, 0, 0, time.UTC)
+		m.cruzamentos.On("BuscarPorID", uint(3)).Return(cruzamentoUsuario(3), nil).Once()
+		m.plantaRepo.On("BuscarPorID", uint(20)).Return(plantaCruzamento(20, 7, 1), nil).Once()
+		m.plantaRepo.On("BuscarPorID", uint(21)).Return(plantaCruzamento(21, 7, 2), nil).Once()
+		m.cruzamentos.On("RegistrarPolinizacao", mock.AnythingOfType("*entity.Polinizacao")).Return(nil).Once()
+
+		polinizacao, err := servico.RegistrarPolinizacao(3, 7, &dto.PolinizacaoDTO{PlantaMaeID: 20, PlantaDoadoraID: ref(21), Data: &data, Metodo: "pincel"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), polinizacao.CruzamentoID)
+		assert.Equal(t, ref(21), polinizacao.PlantaDoadoraID)
+		assert.Equal(t, data, polinizacao.Data)
+	})
+
+	t.Run("Error - Plantas Que Não Servem ao Cruzamento", func(t *testing.T) {
+		casos := map[string]*dto.PolinizacaoDTO{
+			"mãe de outra genética":            {PlantaMaeID: 21},
+			"doadora de outra genética":        {PlantaMaeID: 20, PlantaDoadoraID: ref(22)},
+			"planta de outro usuário":          {PlantaMaeID: 23},
+			"mãe polinizando a si mesma no F1": {PlantaMaeID: 20, PlantaDoadoraID: ref(20)},
+		}
+		for nome, polinizacaoDto := range casos {
+			t.Run(nome, func(t *testing.T) {
+				servico, m := novoCruzamentoService()
+				m.cruzamentos.On("BuscarPorID", uint(3)).Return(cruzamentoUsuario(3), nil)
+				m.plantaRepo.On("BuscarPorID", uint(20)).Return(plantaCruzamento(20, 7, 1), nil)
+				m.plantaRepo.On("BuscarPorID", uint(21)).Return(plantaCruzamento(21, 7, 2), nil)
+				m.plantaRepo.On("BuscarPorID", uint(22)).Return(plantaCruzamento(22, 7, 5), nil)
+				m.plantaRepo.On("BuscarPorID", uint(23)).Return(plantaCruzamento(23, 8, 1), nil)
+
+				_, err := servico.RegistrarPolinizacao(3, 7, polinizacaoDto)
+
+				assert.ErrorIs(t, err, utils.ErrInvalidInput)
+				m.cruzamentos.AssertNotCalled(t, "RegistrarPolinizacao", mock.Anything)
+			})
+		}
+	})
+}
+
+func TestCruzamentoService_RegistrarLote(t *testing.T) {
+	t.Run("Success - Lote Entra no Banco de Sementes", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		colheita := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		m.cruzamentos.On("BuscarPorID", uint(3)).Return(cruzamentoUsuario(3), nil).Once()
+		m.cruzamentos.On("BuscarPolinizacao", uint(5)).Return(&entity.Polinizacao{CruzamentoID: 3}, nil).Once()
+		m.sementes.On("CriarPacote", mock.AnythingOfType("*entity.PacoteSemente")).Return(nil).Once()
+
+		pacote, err := servico.RegistrarLote(3, 7, &dto.LoteSementesDTO{PolinizacaoID: ref(5), Quantidade: 40, DataColheita: &colheita})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(10), pacote.GeneticaID)
+		assert.Equal(t, "Quintal Genetics", pacote.Breeder)
+		assert.Equal(t, 40, pacote.QuantidadeInicial)
+		assert.Equal(t, 40, pacote.QuantidadeRestante)
+		assert.Equal(t, &colheita, pacote.DataAquisicao)
+		assert.Equal(t, ref(3), pacote.CruzamentoID)
+		assert.Equal(t, ref(5), pacote.PolinizacaoID)
+	})
+
+	t.Run("Error - Polinização de Outro Cruzamento", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		m.cruzamentos.On("BuscarPorID", uint(3)).Return(cruzamentoUsuario(3), nil).Once()
+		m.cruzamentos.On("BuscarPolinizacao", uint(5)).Return(&entity.Polinizacao{CruzamentoID: 4}, nil).Once()
+
+		_, err := servico.RegistrarLote(3, 7, &dto.LoteSementesDTO{PolinizacaoID: ref(5), Quantidade: 40})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.sementes.AssertNotCalled(t, "CriarPacote", mock.Anything)
+	})
+
+	t.Run("Success - Lista os Lotes do Cruzamento", func(t *testing.T) {
+		servico, m := novoCruzamentoService()
+		m.cruzamentos.On("BuscarPorID", uint(3)).Return(cruzamentoUsuario(3), nil).Once()
+		m.sementes.On("ListarPacotes", repository.FiltroPacotesSemente{UsuarioID: 7, CruzamentoID: 3}).
+			Return([]entity.PacoteSemente{{GeneticaID: 10}}, nil).Once()
+
+		lotes, err := servico.ListarLotes(3, 7)
+		require.NoError(t, err)
+		assert.Len(t, lotes, 1)
+	})
+}
@@ -0,0 +1,505 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Adicionais das bandeiras tarifárias vigentes (ANEEL), em R$/kWh, usados quando a tarifa
+// é cadastrada sem os próprios valores.
+const (
+	adicionalAmarelaPadrao   = 0.01885
+	adicionalVermelha1Padrao = 0.04463
+	adicionalVermelha2Padrao = 0.07877
+)
+
+const (
+	// diasConsumoPadrao é o período do consumo quando a consulta não informa datas
+	diasConsumoPadrao = 30
+	// maxDiasConsumo limita o período da consulta de consumo de um ambiente
+	maxDiasConsumo = 366
+)
+
+// EnergiaService gerencia os equipamentos dos ambientes e estima o consumo e o custo de energia.
+type EnergiaService interface {
+	CriarEquipamento(ambienteID uint, equipamentoDto *dto.EquipamentoDTO) (*entity.Equipamento, error)
+	ListarEquipamentos(ambienteID uint) ([]entity.Equipamento, error)
+	AtualizarEquipamento(id uint, equipamentoDto *dto.EquipamentoDTO) (*entity.Equipamento, error)
+	DeletarEquipamento(id uint) error
+
+	CriarTarifa(usuarioID uint, tarifaDto *dto.TarifaEnergiaDTO) (*entity.TarifaEnergia, error)
+	ListarTarifas(usuarioID uint) ([]entity.TarifaEnergia, error)
+	DeletarTarifa(id, usuarioID uint) error
+	// DefinirBandeira registra a bandeira da competência (AAAA-MM); meses sem registro são verdes
+	DefinirBandeira(usuarioID uint, competencia string, bandeiraDto *dto.BandeiraTarifariaDTO) (*entity.BandeiraMensal, error)
+	ListarBandeiras(usuarioID uint) ([]entity.BandeiraMensal, error)
+
+	// ConsumoAmbiente estima o consumo dia a dia com as tarifas do usuário
+	ConsumoAmbiente(ambienteID, usuarioID uint, consulta *dto.ConsultaEnergiaDTO) (*dto.ConsumoEnergiaDTO, error)
+	// ConsumoDiario soma o consumo dos ambientes do diário, do início ao fim do cultivo,
+	// e divide pelo peso seco colhido das plantas
+	ConsumoDiario(diarioID, usuarioID uint) (*dto.ConsumoDiarioCultivoDTO, error)
+}
+
+type energiaService struct {
+	equipamentoRepositorio repository.EquipamentoRepositorio
+	tarifaRepositorio      repository.TarifaEnergiaRepositorio
+	ambienteRepositorio    repository.AmbienteRepositorio
+	diarioRepositorio      repository.DiarioCultivoRepositorio
+	calendarioLuz          CalendarioLuz
+	local                  *time.Location
+	agora                  func() time.Time
+}
+
+// NewEnergiaService cria o serviço de energia. O calendário de luz define as horas ligadas dos
+// equipamentos que acompanham o fotoperíodo; local é o fuso em que os dias são contados.
+func NewEnergiaService(
+	equipamentoRepositorio repository.EquipamentoRepositorio,
+	tarifaRepositorio repository.TarifaEnergiaRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	diarioRepositorio repository.DiarioCultivoRepositorio,
+	calendarioLuz CalendarioLuz,
+	local *time.Location,
+) EnergiaService {
+	if local == nil {
+		local = time.Local
+	}
+	return &energiaService{
+		equipamentoRepositorio: equipamentoRepositorio,
+		tarifaRepositorio:      tarifaRepositorio,
+		ambienteRepositorio:    ambienteRepositorio,
+		diarioRepositorio:      diarioRepositorio,
+		calendarioLuz:          calendarioLuz,
+		local:                  local,
+		agora:                  time.Now,
+	}
+}
+
+func (s *energiaService) CriarEquipamento(ambienteID uint, equipamentoDto *dto.EquipamentoDTO) (*entity.Equipamento, error) {
+	if _, err := s.buscarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	equipamento := &entity.Equipamento{AmbienteID: ambienteID}
+	if err := aplicarEquipamentoDTO(equipamento, equipamentoDto); err != nil {
+		return nil, err
+	}
+	if err := s.equipamentoRepositorio.Criar(equipamento); err != nil {
+		return nil, fmt.Errorf("falha ao criar equipamento do ambiente %d: %w", ambienteID, err)
+	}
+	return equipamento, nil
+}
+
+func (s *energiaService) ListarEquipamentos(ambienteID uint) ([]entity.Equipamento, error) {
+	if _, err := s.buscarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	return s.equipamentoRepositorio.ListarPorAmbiente(ambienteID)
+}
+
+func (s *energiaService) AtualizarEquipamento(id uint, equipamentoDto *dto.EquipamentoDTO) (*entity.Equipamento, error) {
+	equipamento, err := s.buscarEquipamento(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := aplicarEquipamentoDTO(equipamento, equipamentoDto); err != nil {
+		return nil, err
+	}
+	if err := s.equipamentoRepositorio.Atualizar(equipamento); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar equipamento com ID %d: %w", id, err)
+	}
+	return equipamento, nil
+}
+
+func (s *energiaService) DeletarEquipamento(id uint) error {
+	if _, err := s.buscarEquipamento(id); err != nil {
+		return err
+	}
+	return s.equipamentoRepositorio.Deletar(id)
+}
+
+func (s *energiaService) CriarTarifa(usuarioID uint, tarifaDto *dto.TarifaEnergiaDTO) (*entity.TarifaEnergia, error) {
+	if usuarioID == 0 || tarifaDto.ValorKWh <= 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	tarifa := &entity.TarifaEnergia{
+		UsuarioID:          usuarioID,
+		Nome:               tarifaDto.Nome,
+		ValorKWh:           tarifaDto.ValorKWh,
+		AdicionalAmarela:   valorOuPadrao(tarifaDto.AdicionalAmarela, adicionalAmarelaPadrao),
+		AdicionalVermelha1: valorOuPadrao(tarifaDto.AdicionalVermelha1, adicionalVermelha1Padrao),
+		AdicionalVermelha2: valorOuPadrao(tarifaDto.AdicionalVermelha2, adicionalVermelha2Padrao),
+		VigenteDesde:       s.agora(),
+	}
+	if tarifaDto.VigenteDesde != nil && !tarifaDto.VigenteDesde.IsZero() {
+		tarifa.VigenteDesde = *tarifaDto.VigenteDesde
+	}
+	if err := s.tarifaRepositorio.Criar(tarifa); err != nil {
+		return nil, fmt.Errorf("falha ao criar tarifa de energia: %w", err)
+	}
+	return tarifa, nil
+}
+
+func (s *energiaService) ListarTarifas(usuarioID uint) ([]entity.TarifaEnergia, error) {
+	return s.tarifaRepositorio.ListarPorUsuario(usuarioID)
+}
+
+func (s *energiaService) DeletarTarifa(id, usuarioID uint) error {
+	tarifa, err := s.tarifaRepositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao buscar tarifa de energia com ID %d: %w", id, err)
+	}
+	if tarifa.UsuarioID != usuarioID {
+		return utils.ErrNotFound
+	}
+	return s.tarifaRepositorio.Deletar(id)
+}
+
+func (s *energiaService) DefinirBandeira(usuarioID uint, competencia string, bandeiraDto *dto.BandeiraTarifariaDTO) (*entity.BandeiraMensal, error) {
+	if _, err := time.Parse("2006-01", competencia); err != nil {
+		return nil, utils.ErrInvalidInput
+	}
+	bandeira := entity.BandeiraTarifaria(bandeiraDto.Bandeira)
+	switch bandeira {
+	case entity.BandeiraVerde, entity.BandeiraAmarela, entity.BandeiraVermelha1, entity.BandeiraVermelha2:
+	default:
+		return nil, utils.ErrInvalidInput
+	}
+
+	mensal := &entity.BandeiraMensal{UsuarioID: usuarioID, Competencia: competencia, Bandeira: bandeira}
+	if err := s.tarifaRepositorio.SalvarBandeira(mensal); err != nil {
+		return nil, err
+	}
+	return mensal, nil
+}
+
+func (s *energiaService) ListarBandeiras(usuarioID uint) ([]entity.BandeiraMensal, error) {
+	return s.tarifaRepositorio.ListarBandeiras(usuarioID)
+}
+
+func (s *energiaService) ConsumoAmbiente(ambienteID, usuarioID uint, consulta *dto.ConsultaEnergiaDTO) (*dto.ConsumoEnergiaDTO, error) {
+	hoje := s.inicioDoDia(s.agora().In(s.local))
+	fim := hoje
+	if consulta.Fim != "" {
+		data, err := time.ParseInLocation("2006-01-02", consulta.Fim, s.local)
+		if err != nil {
+			return nil, utils.ErrInvalidInput
+		}
+		fim = data
+	}
+	inicio := fim.AddDate(0, 0, -(diasConsumoPadrao - 1))
+	if consulta.Inicio != "" {
+		data, err := time.ParseInLocation("2006-01-02", consulta.Inicio, s.local)
+		if err != nil {
+			return nil, utils.ErrInvalidInput
+		}
+		inicio = data
+	}
+	if fim.Before(inicio) || fim.After(inicio.AddDate(0, 0, maxDiasConsumo)) {
+		return nil, utils.ErrInvalidInput
+	}
+	if _, err := s.buscarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+
+	precos, err := s.carregarPrecos(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	return s.consumoAmbiente(ambienteID, precos, inicio, fim)
+}
+
+func (s *energiaService) ConsumoDiario(diarioID, usuarioID uint) (*dto.ConsumoDiarioCultivoDTO, error) {
+	diario, err := s.diarioRepositorio.GetByID(diarioID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar diário de cultivo com ID %d: %w", diarioID, err)
+	}
+	if diario.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+
+	inicio := s.inicioDoDia(diario.DataInicio.In(s.local))
+	fim := s.inicioDoDia(s.agora().In(s.local))
+	if diario.DataFim != nil {
+		fim = s.inicioDoDia(diario.DataFim.In(s.local))
+	}
+	if fim.Before(inicio) {
+		return nil, utils.ErrInvalidInput
+	}
+
+	// Os ambientes vêm do diário e das plantas acompanhadas nele; o consumo de cada
+	// ambiente é atribuído por inteiro ao diário
+	ambientes := make(map[uint]bool)
+	for _, ambiente := range diario.Ambientes {
+		ambientes[ambiente.ID] = true
+	}
+	var gramas float64
+	for _, planta := range diario.Plantas {
+		if planta.AmbienteID != 0 {
+			ambientes[planta.AmbienteID] = true
+		}
+		if planta.PesoSecoGramas != nil {
+			gramas += *planta.PesoSecoGramas
+		}
+	}
+	ids := make([]uint, 0, len(ambientes))
+	for id := range ambientes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	precos, err := s.carregarPrecos(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	resultado := &dto.ConsumoDiarioCultivoDTO{
+		DiarioCultivoID: diarioID,
+		Inicio:          inicio.Format("2006-01-02"),
+		Fim:             fim.Format("2006-01-02"),
+		Ambientes:       []dto.ConsumoEnergiaDTO{},
+	}
+	for _, id := range ids {
+		consumo, err := s.consumoAmbiente(id, precos, inicio, fim)
+		if err != nil {
+			return nil, err
+		}
+		resultado.KWh += consumo.KWh
+		resultado.Custo += consumo.Custo
+		resultado.DiasSemTarifa = max(resultado.DiasSemTarifa, consumo.DiasSemTarifa)
+		resultado.Ambientes = append(resultado.Ambientes, *consumo)
+	}
+	resultado.KWh = calculo.Arredondar(resultado.KWh, 3)
+	resultado.Custo = calculo.Arredondar(resultado.Custo, 2)
+
+	if gramas > 0 {
+		resultado.GramasColhidos = &gramas
+		custoPorGrama := calculo.Arredondar(resultado.Custo/gramas, 4)
+		kwhPorGrama := calculo.Arredondar(resultado.KWh/gramas, 4)
+		resultado.CustoPorGrama = &custoPorGrama
+		resultado.KWhPorGrama = &kwhPorGrama
+	}
+	return resultado, nil
+}
+
+// precosEnergia reúne as tarifas (em ordem de vigência) e as bandeiras do usuário por competência
+type precosEnergia struct {
+	tarifas   []entity.TarifaEnergia
+	bandeiras map[string]entity.BandeiraTarifaria
+}
+
+func (s *energiaService) carregarPrecos(usuarioID uint) (*precosEnergia, error) {
+	tarifas, err := s.tarifaRepositorio.ListarPorUsuario(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	bandeiras, err := s.tarifaRepositorio.ListarBandeiras(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	precos := &precosEnergia{tarifas: tarifas, bandeiras: make(map[string]entity.BandeiraTarifaria, len(bandeiras))}
+	for _, b := range bandeiras {
+		precos.bandeiras[b.Competencia] = b.Bandeira
+	}
+	return precos, nil
+}
+
+// tarifaDoDia retorna a tarifa mais recente iniciada até o fim do dia
+func (p *precosEnergia) tarifaDoDia(fimDoDia time.Time) *entity.TarifaEnergia {
+	var vigente *entity.TarifaEnergia
+	for i := range p.tarifas {
+		if p.tarifas[i].VigenteDesde.Before(fimDoDia) {
+			vigente = &p.tarifas[i]
+		}
+	}
+	return vigente
+}
+
+func (p *precosEnergia) bandeiraDoDia(dia time.Time) entity.BandeiraTarifaria {
+	if bandeira, ok := p.bandeiras[dia.Format("2006-01")]; ok {
+		return bandeira
+	}
+	return entity.BandeiraVerde
+}
+
+// consumoAmbiente estima o consumo de cada dia entre inicio e fim (meia-noite local, inclusivos)
+func (s *energiaService) consumoAmbiente(ambienteID uint, precos *precosEnergia, inicio, fim time.Time) (*dto.ConsumoEnergiaDTO, error) {
+	equipamentos, err := s.equipamentoRepositorio.ListarPorAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	horasLuz, err := s.horasLuz(ambienteID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+
+	resultado := &dto.ConsumoEnergiaDTO{
+		AmbienteID:   ambienteID,
+		Inicio:       inicio.Format("2006-01-02"),
+		Fim:          fim.Format("2006-01-02"),
+		Dias:         []dto.ConsumoDiaDTO{},
+		Equipamentos: make([]dto.ConsumoEquipamentoDTO, len(equipamentos)),
+	}
+	for i, equipamento := range equipamentos {
+		resultado.Equipamentos[i] = dto.ConsumoEquipamentoDTO{
+			EquipamentoID: equipamento.ID,
+			Nome:          equipamento.Nome,
+			Tipo:          equipamento.Tipo,
+		}
+	}
+
+	for i, dia := 0, inicio; !dia.After(fim); i, dia = i+1, dia.AddDate(0, 0, 1) {
+		proximo := dia.AddDate(0, 0, 1)
+		tarifa := precos.tarifaDoDia(proximo)
+		consumoDia := dto.ConsumoDiaDTO{Data: dia.Format("2006-01-02"), Bandeira: precos.bandeiraDoDia(dia)}
+		var preco float64
+		if tarifa != nil {
+			preco = tarifa.PrecoKWh(consumoDia.Bandeira)
+		} else {
+			resultado.DiasSemTarifa++
+		}
+
+		var luz float64
+		if i < len(horasLuz) {
+			luz = horasLuz[i].HorasLuzEfetivas
+		}
+		for j, equipamento := range equipamentos {
+			if !equipamento.EmUsoEntre(dia, proximo) {
+				continue
+			}
+			horas := equipamento.HorasLigado(luz)
+			kwh := equipamento.PotenciaWatts * float64(max(equipamento.Quantidade, 1)) / 1000 * horas
+			resultado.Equipamentos[j].HorasLigado += horas
+			resultado.Equipamentos[j].KWh += kwh
+			resultado.Equipamentos[j].Custo += kwh * preco
+			consumoDia.KWh += kwh
+		}
+
+		resultado.KWh += consumoDia.KWh
+		resultado.Custo += consumoDia.KWh * preco
+		consumoDia.KWh = calculo.Arredondar(consumoDia.KWh, 3)
+		if tarifa != nil {
+			custo := calculo.Arredondar(consumoDia.KWh*preco, 2)
+			consumoDia.Custo = &custo
+		}
+		resultado.Dias = append(resultado.Dias, consumoDia)
+	}
+
+	resultado.KWh = calculo.Arredondar(resultado.KWh, 3)
+	resultado.Custo = calculo.Arredondar(resultado.Custo, 2)
+	for i := range resultado.Equipamentos {
+		resultado.Equipamentos[i].HorasLigado = calculo.Arredondar(resultado.Equipamentos[i].HorasLigado, 2)
+		resultado.Equipamentos[i].KWh = calculo.Arredondar(resultado.Equipamentos[i].KWh, 3)
+		resultado.Equipamentos[i].Custo = calculo.Arredondar(resultado.Equipamentos[i].Custo, 2)
+	}
+	return resultado, nil
+}
+
+// horasLuz consulta o calendário de luz em blocos, já que um cultivo pode passar do limite de dias da consulta
+func (s *energiaService) horasLuz(ambienteID uint, inicio, fim time.Time) ([]dto.HorasLuzDiaDTO, error) {
+	var dias []dto.HorasLuzDiaDTO
+	for bloco := inicio; !bloco.After(fim); bloco = bloco.AddDate(0, 0, maxDiasConsumo) {
+		fimBloco := bloco.AddDate(0, 0, maxDiasConsumo-1)
+		if fimBloco.After(fim) {
+			fimBloco = fim
+		}
+		parte, err := s.calendarioLuz.HorasLuzNoPeriodo(ambienteID, bloco, fimBloco)
+		if err != nil {
+			return nil, err
+		}
+		dias = append(dias, parte...)
+	}
+	return dias, nil
+}
+
+func (s *energiaService) buscarAmbiente(ambienteID uint) (*entity.Ambiente, error) {
+	if ambienteID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	ambiente, err := s.ambienteRepositorio.BuscarPorID(ambienteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar ambiente com ID %d: %w", ambienteID, err)
+	}
+	return ambiente, nil
+}
+
+func (s *energiaService) buscarEquipamento(id uint) (*entity.Equipamento, error) {
+	equipamento, err := s.equipamentoRepositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar equipamento com ID %d: %w", id, err)
+	}
+	return equipamento, nil
+}
+
+// inicioDoDia retorna a meia-noite local da data de calendário de t
+func (s *energiaService) inicioDoDia(t time.Time) time.Time {
+	ano, mes, dia := t.Date()
+	return time.Date(ano, mes, dia, 0, 0, 0, 0, s.local)
+}
+
+func aplicarEquipamentoDTO(equipamento *entity.Equipamento, equipamentoDto *dto.EquipamentoDTO) error {
+	if equipamentoDto.PotenciaWatts <= 0 {
+		return utils.ErrInvalidInput
+	}
+	modo := entity.ModoFuncionamento(equipamentoDto.Modo)
+	switch modo {
+	case entity.FuncionamentoContinuo, entity.FuncionamentoFotoperiodo:
+	case entity.FuncionamentoHorasDia:
+		if equipamentoDto.HorasDia == nil || *equipamentoDto.HorasDia < 0 || *equipamentoDto.HorasDia > 24 {
+			return utils.ErrInvalidInput
+		}
+	case entity.FuncionamentoCiclo:
+		if equipamentoDto.CicloPercentual == nil || *equipamentoDto.CicloPercentual < 0 || *equipamentoDto.CicloPercentual > 100 {
+			return utils.ErrInvalidInput
+		}
+	default:
+		return utils.ErrInvalidInput
+	}
+	if equipamentoDto.InstaladoEm != nil && equipamentoDto.RemovidoEm != nil &&
+		!equipamentoDto.RemovidoEm.After(*equipamentoDto.InstaladoEm) {
+		return utils.ErrInvalidInput
+	}
+
+	equipamento.Nome = equipamentoDto.Nome
+	equipamento.Tipo = entity.TipoEquipamento(equipamentoDto.Tipo)
+	equipamento.PotenciaWatts = equipamentoDto.PotenciaWatts
+	equipamento.Quantidade = max(equipamentoDto.Quantidade, 1)
+	equipamento.Modo = modo
+	equipamento.HorasDia = nil
+	equipamento.CicloPercentual = nil
+	switch modo {
+	case entity.FuncionamentoHorasDia:
+		equipamento.HorasDia = equipamentoDto.HorasDia
+	case entity.FuncionamentoCiclo:
+		equipamento.CicloPercentual = equipamentoDto.CicloPercentual
+	}
+	equipamento.InstaladoEm = equipamentoDto.InstaladoEm
+	equipamento.RemovidoEm = equipamentoDto.RemovidoEm
+	return nil
+}
+
+func valorOuPadrao(valor *float64, padrao float64) float64 {
+	if valor != nil {
+		return *valor
+	}
+	return padrao
+}
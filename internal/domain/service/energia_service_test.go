@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mocksEnergia struct {
+	equipamentoRepo *test.MockEquipamentoRepositorio
+	tarifaRepo      *test.MockTarifaEnergiaRepositorio
+	ambienteRepo    *test.MockAmbienteRepositorio
+	diarioRepo      *MockDiarioCultivoRepository
+}
+
+func novoEnergiaService(t *testing.T) (service.EnergiaService, *mocksEnergia, *time.Location) {
+	t.Helper()
+	local, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	m := &mocksEnergia{
+		equipamentoRepo: new(test.MockEquipamentoRepositorio),
+		tarifaRepo:      new(test.MockTarifaEnergiaRepositorio),
+		ambienteRepo:    new(test.MockAmbienteRepositorio),
+		diarioRepo:      new(MockDiarioCultivoRepository),
+	}
+	calendario := calendarioLuzFixo{horasEfetivas: 12}
+	return service.NewEnergiaService(m.equipamentoRepo, m.tarifaRepo, m.ambienteRepo, m.diarioRepo, calendario, local), m, local
+}
+
+func TestEquipamento_HorasLigado(t *testing.T) {
+	horas, ciclo := 6.0, 25.0
+	assert.Equal(t, 24.0, entity.Equipamento{Modo: entity.FuncionamentoContinuo}.HorasLigado(12))
+	assert.Equal(t, 12.0, entity.Equipamento{Modo: entity.FuncionamentoFotoperiodo}.HorasLigado(12))
+	assert.Equal(t, 6.0, entity.Equipamento{Modo: entity.FuncionamentoHorasDia, HorasDia: &horas}.HorasLigado(12))
+	assert.Equal(t, 6.0, entity.Equipamento{Modo: entity.FuncionamentoCiclo, CicloPercentual: &ciclo}.HorasLigado(12))
+}
+
+func TestEnergiaService_CriarEquipamento(t *testing.T) {
+	t.Run("Success - Quantidade Padrão", func(t *testing.T) {
+		servico, m, _ := novoEnergiaService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		m.equipamentoRepo.On("Criar", mock.AnythingOfType("*entity.Equipamento")).Return(nil).Once()
+
+		equipamento, err := servico.CriarEquipamento(1, &dto.EquipamentoDTO{
+			Nome: "LED 480W", Tipo: "iluminacao", PotenciaWatts: 480, Modo: "fotoperiodo",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, equipamento.Quantidade)
+		assert.Equal(t, uint(1), equipamento.AmbienteID)
+		m.equipamentoRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Modo Horas por Dia sem Horas", func(t *testing.T) {
+		servico, m, _ := novoEnergiaService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+
+		_, err := servico.CriarEquipamento(1, &dto.EquipamentoDTO{
+			Nome: "Bomba", Tipo: "bomba", PotenciaWatts: 20, Modo: "horas_dia",
+		})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.equipamentoRepo.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+}
+
+func TestEnergiaService_CriarTarifa(t *testing.T) {
+	servico, m, _ := novoEnergiaService(t)
+	m.tarifaRepo.On("Criar", mock.AnythingOfType("*entity.TarifaEnergia")).Return(nil).Once()
+	amarela := 0.02
+
+	tarifa, err := servico.CriarTarifa(7, &dto.TarifaEnergiaDTO{Nome: "Residencial", ValorKWh: 0.95, AdicionalAmarela: &amarela})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), tarifa.UsuarioID)
+	assert.Equal(t, 0.02, tarifa.AdicionalAmarela)
+	assert.Equal(t, 0.04463, tarifa.AdicionalVermelha1)
+	assert.Equal(t, 0.07877, tarifa.AdicionalVermelha2)
+	assert.InDelta(t, 1.02877, tarifa.PrecoKWh(entity.BandeiraVermelha2), 1e-9)
+}
+
+func TestEnergiaService_DefinirBandeira(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servico, m, _ := novoEnergiaService(t)
+		m.tarifaRepo.On("SalvarBandeira", mock.MatchedBy(func(b *entity.BandeiraMensal) bool {
+			return b.UsuarioID == 7 && b.Competencia == "2026-06" && b.Bandeira == entity.BandeiraAmarela
+		})).Return(nil).Once()
+
+		_, err := servico.DefinirBandeira(7, "2026-06", &dto.BandeiraTarifariaDTO{Bandeira: "amarela"})
+
+		assert.NoError(t, err)
+		m.tarifaRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Competência Inválida", func(t *testing.T) {
+		servico, m, _ := novoEnergiaService(t)
+
+		_, err := servico.DefinirBandeira(7, "06/2026", &dto.BandeiraTarifariaDTO{Bandeira: "amarela"})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.tarifaRepo.AssertNotCalled(t, "SalvarBandeira", mock.Anything)
+	})
+}
+
+func TestEnergiaService_ConsumoAmbiente(t *testing.T) {
+	_, _, local := novoEnergiaService(t)
+	ciclo := 50.0
+	instalado := time.Date(2026, 5, 31, 0, 0, 0, 0, local)
+	equipamentos := []entity.Equipamento{
+		{Nome: "LED", Tipo: entity.EquipamentoIluminacao, PotenciaWatts: 600, Quantidade: 1, Modo: entity.FuncionamentoFotoperiodo},
+		{Nome: "Ventiladores", Tipo: entity.EquipamentoVentilacao, PotenciaWatts: 50, Quantidade: 2, Modo: entity.FuncionamentoContinuo},
+		{Nome: "Desumidificador", Tipo: entity.EquipamentoDesumidificador, PotenciaWatts: 300, Quantidade: 1,
+			Modo: entity.FuncionamentoCiclo, CicloPercentual: &ciclo, InstaladoEm: &instalado},
+	}
+	tarifas := []entity.TarifaEnergia{
+		{UsuarioID: 7, ValorKWh: 1, AdicionalVermelha1: 0.04463, VigenteDesde: time.Date(2026, 1, 1, 0, 0, 0, 0, local)},
+	}
+	bandeiras := []entity.BandeiraMensal{{UsuarioID: 7, Competencia: "2026-06", Bandeira: entity.BandeiraVermelha1}}
+	consulta := &dto.ConsultaEnergiaDTO{Inicio: "2026-05-30", Fim: "2026-06-01"}
+
+	t.Run("Success - Tarifa com Bandeira", func(t *testing.T) {
+		servico, m, _ := novoEnergiaService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		m.equipamentoRepo.On("ListarPorAmbiente", uint(1)).Return(equipamentos, nil).Once()
+		m.tarifaRepo.On("ListarPorUsuario", uint(7)).Return(tarifas, nil).Once()
+		m.tarifaRepo.On("ListarBandeiras", uint(7)).Return(bandeiras, nil).Once()
+
+		consumo, err := servico.ConsumoAmbiente(1, 7, consulta)
+
+		require.NoError(t, err)
+		require.Len(t, consumo.Dias, 3)
+		// 600W × 12h + 2 × 50W × 24h; o desumidificador entra a partir do segundo dia
+		assert.Equal(t, 9.6, consumo.Dias[0].KWh)
+		assert.Equal(t, 13.2, consumo.Dias[1].KWh)
+		assert.Equal(t, entity.BandeiraVerde, consumo.Dias[1].Bandeira)
+		assert.Equal(t, entity.BandeiraVermelha1, consumo.Dias[2].Bandeira)
+		require.NotNil(t, consumo.Dias[2].Custo)
+		assert.Equal(t, 13.79, *consumo.Dias[2].Custo)
+		assert.Equal(t, 36.0, consumo.KWh)
+		assert.Equal(t, 36.59, consumo.Custo)
+		assert.Zero(t, consumo.DiasSemTarifa)
+		require.Len(t, consumo.Equipamentos, 3)
+		assert.Equal(t, 36.0, consumo.Equipamentos[0].HorasLigado)
+		assert.Equal(t, 7.2, consumo.Equipamentos[2].KWh)
+	})
+
+	t.Run("Success - Sem Tarifa", func(t *testing.T) {
+		servico, m, _ := novoEnergiaService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		m.equipamentoRepo.On("ListarPorAmbiente", uint(1)).Return(equipamentos, nil).Once()
+		m.tarifaRepo.On("ListarPorUsuario", uint(7)).Return([]entity.TarifaEnergia{}, nil).Once()
+		m.tarifaRepo.On("ListarBandeiras", uint(7)).Return([]entity.BandeiraMensal{}, nil).Once()
+
+		consumo, err := servico.ConsumoAmbiente(1, 7, consulta)
+
+		require.NoError(t, err)
+		assert.Equal(t, 36.0, consumo.KWh)
+		assert.Zero(t, consumo.Custo)
+		assert.Equal(t, 3, consumo.DiasSemTarifa)
+		assert.Nil(t, consumo.Dias[0].Custo)
+	})
+
+	t.Run("Error - Período Invertido", func(t *testing.T) {
+		servico, _, _ := novoEnergiaService(t)
+
+		_, err := servico.ConsumoAmbiente(1, 7, &dto.ConsultaEnergiaDTO{Inicio: "2026-06-01", Fim: "2026-05-30"})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestEnergiaService_ConsumoDiario(t *testing.T) {
+	_, _, local := novoEnergiaService(t)
+	pesoA, pesoB := 100.0, 50.0
+	fim := time.Date(2026, 5, 31, 18, 0, 0, 0, local)
+	diario := &entity.DiarioCultivo{
+		DataInicio: time.Date(2026, 5, 30, 12, 0, 0, 0, local),
+		DataFim:    &fim,
+		UsuarioID:  7,
+		Plantas: []entity.Planta{
+			{AmbienteID: 1, PesoSecoGramas: &pesoA},
+			{AmbienteID: 1, PesoSecoGramas: &pesoB},
+		},
+	}
+	diario.ID = 3
+
+	t.Run("Success - Custo por Grama", func(t *testing.T) {
+		servico, m, _ := novoEnergiaService(t)
+		m.diarioRepo.On("GetByID", uint(3)).Return(diario, nil).Once()
+		m.equipamentoRepo.On("ListarPorAmbiente", uint(1)).Return([]entity.Equipamento{
+			{Nome: "Ventiladores", PotenciaWatts: 50, Quantidade: 2, Modo: entity.FuncionamentoContinuo},
+		}, nil).Once()
+		m.tarifaRepo.On("ListarPorUsuario", uint(7)).Return([]entity.TarifaEnergia{
+			{ValorKWh: 1, VigenteDesde: time.Date(2026, 1, 1, 0, 0, 0, 0, local)},
+		}, nil).Once()
+		m.tarifaRepo.On("ListarBandeiras", uint(7)).Return([]entity.BandeiraMensal{}, nil).Once()
+
+		consumo, err := servico.ConsumoDiario(3, 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2026-05-30", consumo.Inicio)
+		assert.Equal(t, "2026-05-31", consumo.Fim)
+		require.Len(t, consumo.Ambientes, 1)
+		assert.Equal(t, 4.8, consumo.KWh)
+		assert.Equal(t, 4.8, consumo.Custo)
+		require.NotNil(t, consumo.GramasColhidos)
+		assert.Equal(t, 150.0, *consumo.GramasColhidos)
+		assert.Equal(t, 0.032, *consumo.CustoPorGrama)
+		assert.Equal(t, 0.032, *consumo.KWhPorGrama)
+	})
+
+	t.Run("Error - Diário de Outro Usuário", func(t *testing.T) {
+		servico, m, _ := novoEnergiaService(t)
+		m.diarioRepo.On("GetByID", uint(3)).Return(diario, nil).Once()
+
+		_, err := servico.ConsumoDiario(3, 8)
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// EstagioCrescimentoService gerencia as transições de estágio das plantas.
+type EstagioCrescimentoService interface {
+	MudarEstagio(plantaID uint, estagioDto *dto.MudarEstagioDTO) (*entity.EstagioCrescimento, error)
+	ListarHistorico(plantaID uint) ([]entity.EstagioCrescimento, error)
+	// EstagioPredominante retorna o estágio mais comum entre as plantas ativas do ambiente
+	EstagioPredominante(ambienteID uint) (entity.EstagioPlanta, bool, error)
+}
+
+type estagioCrescimentoService struct {
+	repositorio       repository.EstagioCrescimentoRepositorio
+	plantaRepositorio repository.PlantaRepositorio
+	observadores      []ObservadorEstagio
+}
+
+func NewEstagioCrescimentoService(
+	repositorio repository.EstagioCrescimentoRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	observadores ...ObservadorEstagio,
+) EstagioCrescimentoService {
+	return &estagioCrescimentoService{
+		repositorio:       repositorio,
+		plantaRepositorio: plantaRepositorio,
+		observadores:      observadores,
+	}
+}
+
+func (s *estagioCrescimentoService) MudarEstagio(plantaID uint, estagioDto *dto.MudarEstagioDTO) (*entity.EstagioCrescimento, error) {
+	if !estagioDto.Estagio.Valid() {
+		return nil, utils.ErrInvalidInput
+	}
+	planta, err := s.plantaRepositorio.BuscarPorID(plantaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar planta com ID %d: %w", plantaID, err)
+	}
+
+	dataInicio := time.Now()
+	if estagioDto.DataInicio != nil && !estagioDto.DataInicio.IsZero() {
+		dataInicio = *estagioDto.DataInicio
+	}
+
+	estagio := &entity.EstagioCrescimento{
+		PlantaID:   plantaID,
+		Estagio:    estagioDto.Estagio,
+		DataInicio: dataInicio,
+	}
+	if err := s.repositorio.IniciarEstagio(estagio); err != nil {
+		return nil, fmt.Errorf("falha ao mudar estágio da planta %d: %w", plantaID, err)
+	}
+
+	// falhas dos observadores (ex.: sugestão de fotoperíodo) não desfazem a mudança de estágio
+	for _, observador := range s.observadores {
+		if err := observador.EstagioIniciado(planta, estagio); err != nil {
+			logrus.WithError(err).WithField("planta_id", plantaID).Warn("Falha ao processar mudança de estágio")
+		}
+	}
+	return estagio, nil
+}
+
+func (s *estagioCrescimentoService) ListarHistorico(plantaID uint) ([]entity.EstagioCrescimento, error) {
+	return s.repositorio.ListarPorPlanta(plantaID)
+}
+
+func (s *estagioCrescimentoService) EstagioPredominante(ambienteID uint) (entity.EstagioPlanta, bool, error) {
+	estagios, err := s.repositorio.ListarAtuaisPorAmbiente(ambienteID)
+	if err != nil {
+		return "", false, err
+	}
+	estagio, ok := estagioPredominante(estagios)
+	return estagio, ok, nil
+}
+
+// ordemEstagios desempata a contagem em favor do estágio mais avançado
+var ordemEstagios = map[entity.EstagioPlanta]int{
+	entity.EstagioGerminacao: 1,
+	entity.EstagioPlantula:   2,
+	entity.EstagioVegetativo: 3,
+	entity.EstagioFloracao:   4,
+	entity.EstagioMaturacao:  5,
+}
+
+func estagioPredominante(estagios []entity.EstagioCrescimento) (entity.EstagioPlanta, bool) {
+	if len(estagios) == 0 {
+		return "", false
+	}
+	contagem := make(map[entity.EstagioPlanta]int)
+	for _, e := range estagios {
+		contagem[e.Estagio]++
+	}
+	var escolhido entity.EstagioPlanta
+	for estagio, n := range contagem {
+		atual := contagem[escolhido]
+		if n > atual || (n == atual && ordemEstagios[estagio] > ordemEstagios[escolhido]) {
+			escolhido = estagio
+		}
+	}
+	return escolhido, true
+}
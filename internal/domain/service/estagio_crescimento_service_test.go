@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestEstagioCrescimentoService_MudarEstagio(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(test.MockEstagioCrescimentoRepositorio)
+		mockPlantaRepo := new(test.MockPlantaRepositorio)
+		servico := service.NewEstagioCrescimentoService(mockRepo, mockPlantaRepo)
+
+		data := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+		mockPlantaRepo.On("BuscarPorID", uint(1)).Return(&entity.Planta{}, nil).Once()
+		mockRepo.On("IniciarEstagio", mock.MatchedBy(func(e *entity.EstagioCrescimento) bool {
+			return e.PlantaID == 1 && e.Estagio == entity.EstagioFloracao && e.DataInicio.Equal(data)
+		})).Return(nil).Once()
+
+		estagio, err := servico.MudarEstagio(1, &dto.MudarEstagioDTO{Estagio: entity.EstagioFloracao, DataInicio: &data})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.EstagioFloracao, estagio.Estagio)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Planta Not Found", func(t *testing.T) {
+		mockRepo := new(test.MockEstagioCrescimentoRepositorio)
+		mockPlantaRepo := new(test.MockPlantaRepositorio)
+		servico := service.NewEstagioCrescimentoService(mockRepo, mockPlantaRepo)
+
+		mockPlantaRepo.On("BuscarPorID", uint(2)).Return((*entity.Planta)(nil), gorm.ErrRecordNotFound).Once()
+
+		_, err := servico.MudarEstagio(2, &dto.MudarEstagioDTO{Estagio: entity.EstagioVegetativo})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "IniciarEstagio", mock.Anything)
+	})
+}
+
+func TestEstagioCrescimentoService_EstagioPredominante(t *testing.T) {
+	mockRepo := new(test.MockEstagioCrescimentoRepositorio)
+	servico := service.NewEstagioCrescimentoService(mockRepo, new(test.MockPlantaRepositorio))
+
+	t.Run("Empate favorece o estágio mais avançado", func(t *testing.T) {
+		mockRepo.On("ListarAtuaisPorAmbiente", uint(1)).Return([]entity.EstagioCrescimento{
+			{Estagio: entity.EstagioVegetativo},
+			{Estagio: entity.EstagioFloracao},
+		}, nil).Once()
+
+		estagio, ok, err := servico.EstagioPredominante(1)
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, entity.EstagioFloracao, estagio)
+	})
+
+	t.Run("Ambiente sem plantas", func(t *testing.T) {
+		mockRepo.On("ListarAtuaisPorAmbiente", uint(2)).Return([]entity.EstagioCrescimento{}, nil).Once()
+
+		_, ok, err := servico.EstagioPredominante(2)
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,628 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ObservadorRega é notificado quando uma rega é registrada.
+type ObservadorRega interface {
+	RegaRegistrada(rega *entity.Rega) error
+}
+
+// BaixaEstoque identifica a operação que consumiu os insumos.
+type BaixaEstoque struct {
+	Origem          entity.OrigemMovimentacaoEstoque
+	OrigemID        *uint
+	PlantaID        *uint
+	DiarioCultivoID *uint
+	Data            time.Time
+}
+
+// ConsumidorEstoque dá baixa nos insumos usados em outras operações, como a conclusão de uma tarefa.
+type ConsumidorEstoque interface {
+	// PrepararBaixa valida todos os itens e monta as saídas para o repositório da operação
+	// gravá-las na mesma transação dela
+	PrepararBaixa(usuarioID uint, baixa BaixaEstoque, consumos []dto.ItemConsumidoDTO) (*repository.MovimentacoesEstoque, error)
+}
+
+// EstoqueService mantém o inventário de insumos do usuário. Compras recalculam o custo médio,
+// regas e tarefas concluídas dão baixa nos itens e o estoque abaixo do mínimo gera notificação.
+type EstoqueService interface {
+	ObservadorRega
+	ConsumidorEstoque
+
+	CriarItem(usuarioID uint, itemDto *dto.ItemEstoqueDTO) (*entity.ItemEstoque, error)
+	BuscarItem(id, usuarioID uint) (*entity.ItemEstoque, error)
+	ListarItens(usuarioID uint, consulta *dto.ConsultaItensEstoqueDTO) ([]entity.ItemEstoque, error)
+	AtualizarItem(id, usuarioID uint, itemDto *dto.ItemEstoqueDTO) (*entity.ItemEstoque, error)
+	DeletarItem(id, usuarioID uint) error
+
+	RegistrarCompra(id, usuarioID uint, compraDto *dto.CompraEstoqueDTO) (*entity.MovimentacaoEstoque, error)
+	RegistrarConsumo(id, usuarioID uint, consumoDto *dto.ConsumoEstoqueDTO) (*entity.MovimentacaoEstoque, error)
+	Ajustar(id, usuarioID uint, ajusteDto *dto.AjusteEstoqueDTO) (*entity.MovimentacaoEstoque, error)
+	ListarMovimentacoes(id, usuarioID uint) ([]entity.MovimentacaoEstoque, error)
+
+	// CustoCiclo totaliza o custo dos insumos consumidos no ciclo do diário de cultivo
+	CustoCiclo(diarioID, usuarioID uint) (*dto.CustoCicloDTO, error)
+}
+
+type estoqueService struct {
+	repositorio       repository.EstoqueRepositorio
+	plantaRepositorio repository.PlantaRepositorio
+	diarioRepositorio repository.DiarioCultivoRepositorio
+	canais            []CanalNotificacao
+	agora             func() time.Time
+}
+
+// NewEstoqueService cria o serviço de estoque; os canais recebem os avisos de estoque baixo.
+func NewEstoqueService(
+	repositorio repository.EstoqueRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	diarioRepositorio repository.DiarioCultivoRepositorio,
+	canais ...CanalNotificacao,
+) EstoqueService {
+	return &estoqueService{
+		repositorio:       repositorio,
+		plantaRepositorio: plantaRepositorio,
+		diarioRepositorio: diarioRepositorio,
+		canais:            canais,
+		agora:             time.Now,
+	}
+}
+
+func (s *estoqueService) CriarItem(usuarioID uint, itemDto *dto.ItemEstoqueDTO) (*entity.ItemEstoque, error) {
+	if usuarioID == 0 || itemDto == nil || itemDto.QuantidadeInicial < 0 || itemDto.CustoUnitario < 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	item := &entity.ItemEstoque{UsuarioID: usuarioID}
+	if err := aplicarItemEstoqueDTO(item, itemDto); err != nil {
+		return nil, err
+	}
+	item.CustoMedio = itemDto.CustoUnitario
+	if err := s.repositorio.CriarItem(item); err != nil {
+		return nil, fmt.Errorf("falha ao criar item de estoque: %w", err)
+	}
+	if itemDto.QuantidadeInicial > 0 {
+		// o saldo inicial entra como ajuste para o histórico explicar a quantidade do item
+		ajuste := &entity.MovimentacaoEstoque{
+			Tipo:       entity.MovimentacaoEstoqueAjuste,
+			Origem:     entity.OrigemEstoqueManual,
+			Data:       s.agora(),
+			Quantidade: itemDto.QuantidadeInicial,
+			CustoTotal: calculo.Arredondar(itemDto.QuantidadeInicial*itemDto.CustoUnitario, 2),
+		}
+		if err := s.movimentar(item, ajuste, nil); err != nil {
+			return nil, err
+		}
+	}
+	return item, nil
+}
+
+func (s *estoqueService) BuscarItem(id, usuarioID uint) (*entity.ItemEstoque, error) {
+	return s.buscarItem(id, usuarioID)
+}
+
+func (s *estoqueService) ListarItens(usuarioID uint, consulta *dto.ConsultaItensEstoqueDTO) ([]entity.ItemEstoque, error) {
+	filtro := repository.FiltroItensEstoque{
+		UsuarioID:    usuarioID,
+		Categoria:    entity.CategoriaEstoque(consulta.Categoria),
+		AbaixoMinimo: consulta.AbaixoMinimo,
+	}
+	if consulta.VencendoEmDias != nil {
+		ate := s.agora().AddDate(0, 0, *consulta.VencendoEmDias)
+		filtro.VencendoAte = &ate
+	}
+	return s.repositorio.ListarItens(filtro)
+}
+
+func (s *estoqueService) AtualizarItem(id, usuarioID uint, itemDto *dto.ItemEstoqueDTO) (*entity.ItemEstoque, error) {
+	item, err := s.buscarItem(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if itemDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	// o saldo e o custo médio estão na unidade atual
+	if entity.UnidadeEstoque(itemDto.Unidade) != item.Unidade && item.Quantidade != 0 {
+		return nil, fmt.Errorf("%w: a unidade só pode mudar com o estoque zerado", utils.ErrInvalidInput)
+	}
+	if err := aplicarItemEstoqueDTO(item, itemDto); err != nil {
+		return nil, err
+	}
+	if !item.AbaixoDoMinimo() {
+		item.AlertaEstoqueEm = nil
+	}
+	if err := s.repositorio.AtualizarItem(item); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar item de estoque com ID %d: %w", id, err)
+	}
+	return item, nil
+}
+
+func (s *estoqueService) DeletarItem(id, usuarioID uint) error {
+	if _, err := s.buscarItem(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.repositorio.DeletarItem(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar item de estoque com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *estoqueService) RegistrarCompra(id, usuarioID uint, compraDto *dto.CompraEstoqueDTO) (*entity.MovimentacaoEstoque, error) {
+	item, err := s.buscarItem(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if compraDto == nil || compraDto.Quantidade <= 0 || compraDto.CustoTotal < 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	compra := &entity.MovimentacaoEstoque{
+		Tipo:        entity.MovimentacaoEstoqueCompra,
+		Origem:      entity.OrigemEstoqueManual,
+		Data:        s.dataOuAgora(compraDto.Data),
+		Quantidade:  compraDto.Quantidade,
+		CustoTotal:  compraDto.CustoTotal,
+		Fornecedor:  compraDto.Fornecedor,
+		Observacoes: compraDto.Observacoes,
+	}
+	err = s.movimentar(item, compra, func(atual *entity.ItemEstoque, compra *entity.MovimentacaoEstoque) error {
+		// média ponderada entre o saldo atual e a compra
+		saldo := max(atual.Quantidade, 0)
+		atual.CustoMedio = calculo.Arredondar((saldo*atual.CustoMedio+compra.CustoTotal)/(saldo+compra.Quantidade), 4)
+		if compraDto.Validade != nil && !compraDto.Validade.IsZero() {
+			validade := *compraDto.Validade
+			atual.Validade = &validade
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return compra, nil
+}
+
+func (s *estoqueService) RegistrarConsumo(id, usuarioID uint, consumoDto *dto.ConsumoEstoqueDTO) (*entity.MovimentacaoEstoque, error) {
+	item, err := s.buscarItem(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if consumoDto == nil || consumoDto.Quantidade <= 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	origem := entity.OrigemMovimentacaoEstoque(consumoDto.Origem)
+	switch origem {
+	case "":
+		origem = entity.OrigemEstoqueManual
+	case entity.OrigemEstoqueManual, entity.OrigemEstoqueTratamento, entity.OrigemEstoqueTransplante:
+	default:
+		return nil, fmt.Errorf("%w: origem de consumo inválida", utils.ErrInvalidInput)
+	}
+	if err := s.validarDestino(usuarioID, consumoDto.PlantaID, consumoDto.DiarioCultivoID); err != nil {
+		return nil, err
+	}
+	consumo := s.consumo(item, consumoDto.Quantidade, BaixaEstoque{
+		Origem:          origem,
+		PlantaID:        consumoDto.PlantaID,
+		DiarioCultivoID: consumoDto.DiarioCultivoID,
+		Data:            s.dataOuAgora(consumoDto.Data),
+	})
+	consumo.Observacoes = consumoDto.Observacoes
+	if err := s.movimentar(item, consumo, nil); err != nil {
+		return nil, err
+	}
+	return consumo, nil
+}
+
+func (s *estoqueService) Ajustar(id, usuarioID uint, ajusteDto *dto.AjusteEstoqueDTO) (*entity.MovimentacaoEstoque, error) {
+	item, err := s.buscarItem(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if ajusteDto == nil || ajusteDto.QuantidadeContada < 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	ajuste := &entity.MovimentacaoEstoque{
+		Tipo:        entity.MovimentacaoEstoqueAjuste,
+		Origem:      entity.OrigemEstoqueManual,
+		Data:        s.dataOuAgora(ajusteDto.Data),
+		Observacoes: ajusteDto.Observacoes,
+	}
+	err = s.movimentar(item, ajuste, func(atual *entity.ItemEstoque, ajuste *entity.MovimentacaoEstoque) error {
+		// a diferença vem do saldo travado, que pode ter mudado desde a leitura do item
+		diferenca := calculo.Arredondar(ajusteDto.QuantidadeContada-atual.Quantidade, 4)
+		if diferenca == 0 {
+			return fmt.Errorf("%w: a contagem é igual ao saldo atual", utils.ErrInvalidInput)
+		}
+		ajuste.Quantidade = diferenca
+		ajuste.CustoTotal = calculo.Arredondar(diferenca*atual.CustoMedio, 2)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ajuste, nil
+}
+
+func (s *estoqueService) ListarMovimentacoes(id, usuarioID uint) ([]entity.MovimentacaoEstoque, error) {
+	if _, err := s.buscarItem(id, usuarioID); err != nil {
+		return nil, err
+	}
+	return s.repositorio.ListarMovimentacoes(id)
+}
+
+// RegaRegistrada dá baixa nos nutrientes da rega que têm item de mesmo nome no estoque. Nutrientes
+// sem item, ou com item numa unidade incompatível com a dose, são ignorados.
+func (s *estoqueService) RegaRegistrada(rega *entity.Rega) error {
+	if len(rega.Nutrientes) == 0 {
+		return nil
+	}
+	itens, err := s.repositorio.ListarItens(repository.FiltroItensEstoque{UsuarioID: rega.UsuarioID, Categoria: entity.CategoriaEstoqueNutriente})
+	if err != nil {
+		return err
+	}
+	porNome := make(map[string]*entity.ItemEstoque, len(itens))
+	for i := range itens {
+		porNome[nomeItemEstoque(itens[i].Nome)] = &itens[i]
+	}
+
+	regaID := rega.ID
+	baixa := BaixaEstoque{
+		Origem:          entity.OrigemEstoqueRega,
+		OrigemID:        &regaID,
+		PlantaID:        rega.PlantaID,
+		DiarioCultivoID: rega.DiarioCultivoID,
+		Data:            rega.Data,
+	}
+	var consumos []*entity.MovimentacaoEstoque
+	for _, nutriente := range rega.Nutrientes {
+		item, ok := porNome[nomeItemEstoque(nutriente.Nutriente)]
+		if !ok {
+			continue
+		}
+		aplicado := nutriente.Dose * rega.VolumeLitros
+		quantidade, ok := converterUnidadeEstoque(aplicado, entity.UnidadeEstoque(unidadeTotalNutriente(nutriente.Unidade)), item.Unidade)
+		if !ok || quantidade <= 0 {
+			continue
+		}
+		consumos = append(consumos, s.consumo(item, quantidade, baixa))
+	}
+	if len(consumos) == 0 {
+		return nil
+	}
+	if err := s.repositorio.RegistrarMovimentacoes(s.movimentacoes(consumos, nil)); err != nil {
+		return fmt.Errorf("falha ao dar baixa nos nutrientes da rega %d: %w", rega.ID, err)
+	}
+	return nil
+}
+
+func (s *estoqueService) PrepararBaixa(usuarioID uint, baixa BaixaEstoque, consumos []dto.ItemConsumidoDTO) (*repository.MovimentacoesEstoque, error) {
+	itens := make(map[uint]*entity.ItemEstoque, len(consumos))
+	for _, consumido := range consumos {
+		if consumido.Quantidade <= 0 {
+			return nil, fmt.Errorf("%w: quantidade consumida do item %d deve ser positiva", utils.ErrInvalidInput, consumido.ItemID)
+		}
+		if _, ok := itens[consumido.ItemID]; ok {
+			continue
+		}
+		item, err := s.buscarItem(consumido.ItemID, usuarioID)
+		if errors.Is(err, utils.ErrNotFound) || errors.Is(err, utils.ErrInvalidInput) {
+			return nil, fmt.Errorf("%w: item de estoque %d não encontrado", utils.ErrInvalidInput, consumido.ItemID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		itens[consumido.ItemID] = item
+	}
+	saidas := make([]*entity.MovimentacaoEstoque, 0, len(consumos))
+	for _, consumido := range consumos {
+		saidas = append(saidas, s.consumo(itens[consumido.ItemID], consumido.Quantidade, baixa))
+	}
+	return s.movimentacoes(saidas, nil), nil
+}
+
+func (s *estoqueService) CustoCiclo(diarioID, usuarioID uint) (*dto.CustoCicloDTO, error) {
+	diario, err := s.diarioRepositorio.GetByID(diarioID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar diário de cultivo com ID %d: %w", diarioID, err)
+	}
+	if diario.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	plantas, err := s.plantaRepositorio.ListarPorDiario(diarioID)
+	if err != nil {
+		return nil, err
+	}
+	plantaIDs := make([]uint, 0, len(plantas))
+	for _, planta := range plantas {
+		plantaIDs = append(plantaIDs, planta.ID)
+	}
+	consumos, err := s.repositorio.ListarConsumosCiclo(repository.FiltroConsumosCiclo{
+		DiarioCultivoID: diarioID,
+		PlantaIDs:       plantaIDs,
+		De:              diario.DataInicio,
+		Ate:             diario.DataFim,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	custo := &dto.CustoCicloDTO{
+		DiarioCultivoID: diarioID,
+		Plantas:         len(plantas),
+		PorCategoria:    []dto.CustoCategoriaEstoqueDTO{},
+		PorItem:         []dto.CustoItemEstoqueDTO{},
+	}
+	porItem := make(map[uint]*dto.CustoItemEstoqueDTO)
+	porCategoria := make(map[string]float64)
+	var ordemItens []uint
+	for _, consumo := range consumos {
+		total, ok := porItem[consumo.ItemID]
+		if !ok {
+			total = &dto.CustoItemEstoqueDTO{ItemID: consumo.ItemID}
+			if consumo.Item != nil {
+				total.Nome = consumo.Item.Nome
+				total.Categoria = string(consumo.Item.Categoria)
+				total.Unidade = string(consumo.Item.Unidade)
+			}
+			porItem[consumo.ItemID] = total
+			ordemItens = append(ordemItens, consumo.ItemID)
+		}
+		// consumos são saídas: a quantidade gravada é negativa
+		total.Quantidade -= consumo.Quantidade
+		total.Total += consumo.CustoTotal
+		porCategoria[total.Categoria] += consumo.CustoTotal
+		custo.Total += consumo.CustoTotal
+	}
+
+	for _, itemID := range ordemItens {
+		total := porItem[itemID]
+		total.Quantidade = calculo.Arredondar(total.Quantidade, 3)
+		total.Total = calculo.Arredondar(total.Total, 2)
+		custo.PorItem = append(custo.PorItem, *total)
+	}
+	sort.SliceStable(custo.PorItem, func(i, j int) bool { return custo.PorItem[i].Total > custo.PorItem[j].Total })
+	for categoria, total := range porCategoria {
+		custo.PorCategoria = append(custo.PorCategoria, dto.CustoCategoriaEstoqueDTO{Categoria: categoria, Total: calculo.Arredondar(total, 2)})
+	}
+	sort.Slice(custo.PorCategoria, func(i, j int) bool {
+		if custo.PorCategoria[i].Total != custo.PorCategoria[j].Total {
+			return custo.PorCategoria[i].Total > custo.PorCategoria[j].Total
+		}
+		return custo.PorCategoria[i].Categoria < custo.PorCategoria[j].Categoria
+	})
+	custo.Total = calculo.Arredondar(custo.Total, 2)
+	if custo.Plantas > 0 {
+		porPlanta := calculo.Arredondar(custo.Total/float64(custo.Plantas), 2)
+		custo.CustoPorPlanta = &porPlanta
+	}
+	return custo, nil
+}
+
+// consumo monta a saída do item valorizada pelo custo médio atual; ao ser gravada, a saída é
+// refeita sobre o saldo travado do item
+func (s *estoqueService) consumo(item *entity.ItemEstoque, quantidade float64, baixa BaixaEstoque) *entity.MovimentacaoEstoque {
+	data := baixa.Data
+	if data.IsZero() {
+		data = s.agora()
+	}
+	return &entity.MovimentacaoEstoque{
+		ItemID:          item.ID,
+		UsuarioID:       item.UsuarioID,
+		Tipo:            entity.MovimentacaoEstoqueConsumo,
+		Origem:          baixa.Origem,
+		OrigemID:        baixa.OrigemID,
+		Data:            data,
+		Quantidade:      -calculo.Arredondar(quantidade, 4),
+		CustoTotal:      calculo.Arredondar(quantidade*item.CustoMedio, 2),
+		PlantaID:        baixa.PlantaID,
+		DiarioCultivoID: baixa.DiarioCultivoID,
+	}
+}
+
+// movimentar grava uma movimentação do item sobre o saldo atual do banco. ajustar, quando
+// informado, altera o item travado antes da movimentação ser aplicada (ex.: custo médio da
+// compra). O item recebe o estado gravado.
+func (s *estoqueService) movimentar(item *entity.ItemEstoque, movimentacao *entity.MovimentacaoEstoque, ajustar repository.AplicarMovimentacao) error {
+	movimentacao.ItemID = item.ID
+	movimentacao.UsuarioID = item.UsuarioID
+	movimentacoes := s.movimentacoes([]*entity.MovimentacaoEstoque{movimentacao}, ajustar)
+	aplicar := movimentacoes.Aplicar
+	movimentacoes.Aplicar = func(atual *entity.ItemEstoque, movimentacao *entity.MovimentacaoEstoque) error {
+		if err := aplicar(atual, movimentacao); err != nil {
+			return err
+		}
+		*item = *atual
+		return nil
+	}
+	if err := s.repositorio.RegistrarMovimentacoes(movimentacoes); err != nil {
+		return fmt.Errorf("falha ao movimentar item de estoque %d: %w", item.ID, err)
+	}
+	return nil
+}
+
+// movimentacoes prepara as movimentações para o repositório aplicá-las com os itens travados. Os
+// avisos de estoque baixo só saem depois do commit; falhas na entrega não desfazem a movimentação.
+func (s *estoqueService) movimentacoes(itens []*entity.MovimentacaoEstoque, ajustar repository.AplicarMovimentacao) *repository.MovimentacoesEstoque {
+	var avisar []*entity.ItemEstoque
+	avisados := make(map[uint]bool)
+	return &repository.MovimentacoesEstoque{
+		Itens: itens,
+		Aplicar: func(item *entity.ItemEstoque, movimentacao *entity.MovimentacaoEstoque) error {
+			if ajustar != nil {
+				if err := ajustar(item, movimentacao); err != nil {
+					return err
+				}
+			}
+			if s.aplicarMovimentacao(item, movimentacao) && !avisados[item.ID] {
+				avisados[item.ID] = true
+				avisar = append(avisar, item)
+			}
+			return nil
+		},
+		Confirmadas: func() {
+			for _, item := range avisar {
+				if err := s.avisarEstoqueBaixo(item); err != nil {
+					logrus.WithError(err).WithField("item_id", item.ID).Warn("Falha ao avisar estoque baixo")
+				}
+			}
+		},
+	}
+}
+
+// aplicarMovimentacao aplica a movimentação ao saldo do item e diz se o aviso de estoque baixo deve
+// sair: ele é enviado uma vez quando o item chega ao mínimo e volta a valer depois que o item é
+// reposto. O saldo não fica negativo: uma saída maior que o saldo zera o item e registra só o que
+// havia, para o histórico continuar somando o saldo.
+func (s *estoqueService) aplicarMovimentacao(item *entity.ItemEstoque, movimentacao *entity.MovimentacaoEstoque) bool {
+	movimentacao.ItemID = item.ID
+	movimentacao.UsuarioID = item.UsuarioID
+	if movimentacao.Tipo == entity.MovimentacaoEstoqueConsumo {
+		retirado := min(-movimentacao.Quantidade, max(item.Quantidade, 0))
+		movimentacao.Quantidade = -calculo.Arredondar(retirado, 4)
+		movimentacao.CustoTotal = calculo.Arredondar(retirado*item.CustoMedio, 2)
+	}
+	item.Quantidade = max(calculo.Arredondar(item.Quantidade+movimentacao.Quantidade, 4), 0)
+	if !item.AbaixoDoMinimo() {
+		item.AlertaEstoqueEm = nil
+		return false
+	}
+	if item.AlertaEstoqueEm != nil {
+		return false
+	}
+	agora := s.agora()
+	item.AlertaEstoqueEm = &agora
+	return true
+}
+
+func (s *estoqueService) avisarEstoqueBaixo(item *entity.ItemEstoque) error {
+	notificacao := Notificacao{
+		UsuarioID: item.UsuarioID,
+		Categoria: entity.CategoriaNotificacaoEstoque,
+		Titulo:    fmt.Sprintf("Estoque baixo: %s", item.Nome),
+		Mensagem: fmt.Sprintf("Restam %s %s de %s; o mínimo definido é %s %s.",
+			formatarValor(item.Quantidade), item.Unidade, item.Nome, formatarValor(*item.EstoqueMinimo), item.Unidade),
+		Dados: map[string]any{
+			"item_id":        item.ID,
+			"quantidade":     item.Quantidade,
+			"estoque_minimo": *item.EstoqueMinimo,
+			"unidade":        item.Unidade,
+		},
+	}
+	var errs []error
+	for _, canal := range s.canais {
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutNotificacao)
+		err := canal.Enviar(ctx, notificacao)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("falha ao notificar pelo canal %s: %w", canal.Nome(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validarDestino confere que a planta e o diário do consumo são do usuário
+func (s *estoqueService) validarDestino(usuarioID uint, plantaID, diarioID *uint) error {
+	if plantaID != nil {
+		planta, err := s.plantaRepositorio.BuscarPorID(*plantaID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("falha ao buscar planta com ID %d: %w", *plantaID, err)
+		}
+		if err != nil || planta.UsuarioID != usuarioID {
+			return fmt.Errorf("%w: planta %d não encontrada", utils.ErrInvalidInput, *plantaID)
+		}
+	}
+	if diarioID != nil {
+		diario, err := s.diarioRepositorio.GetByID(*diarioID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("falha ao buscar diário de cultivo com ID %d: %w", *diarioID, err)
+		}
+		if err != nil || diario.UsuarioID != usuarioID {
+			return fmt.Errorf("%w: diário de cultivo %d não encontrado", utils.ErrInvalidInput, *diarioID)
+		}
+	}
+	return nil
+}
+
+func (s *estoqueService) dataOuAgora(data *time.Time) time.Time {
+	if data != nil && !data.IsZero() {
+		return *data
+	}
+	return s.agora()
+}
+
+// buscarItem retorna ErrNotFound também para itens de outro usuário
+func (s *estoqueService) buscarItem(id, usuarioID uint) (*entity.ItemEstoque, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	item, err := s.repositorio.BuscarItem(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar item de estoque com ID %d: %w", id, err)
+	}
+	if item.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return item, nil
+}
+
+func aplicarItemEstoqueDTO(item *entity.ItemEstoque, itemDto *dto.ItemEstoqueDTO) error {
+	nome := strings.TrimSpace(itemDto.Nome)
+	if nome == "" {
+		return fmt.Errorf("%w: nome do item é obrigatório", utils.ErrInvalidInput)
+	}
+	if itemDto.EstoqueMinimo != nil && *itemDto.EstoqueMinimo < 0 {
+		return fmt.Errorf("%w: estoque mínimo não pode ser negativo", utils.ErrInvalidInput)
+	}
+	item.Nome = nome
+	item.Categoria = entity.CategoriaEstoque(itemDto.Categoria)
+	item.Fabricante = itemDto.Fabricante
+	item.Unidade = entity.UnidadeEstoque(itemDto.Unidade)
+	item.EstoqueMinimo = itemDto.EstoqueMinimo
+	item.Validade = itemDto.Validade
+	item.Observacoes = itemDto.Observacoes
+	return nil
+}
+
+// nomeItemEstoque normaliza o nome para ligar o nutriente da rega ao item do estoque
+func nomeItemEstoque(nome string) string {
+	return strings.ToLower(strings.Join(strings.Fields(nome), " "))
+}
+
+// converterUnidadeEstoque converte entre ml e l ou entre g e kg; outras combinações não convertem
+func converterUnidadeEstoque(quantidade float64, de, para entity.UnidadeEstoque) (float64, bool) {
+	if de == para {
+		return quantidade, true
+	}
+	switch {
+	case de == entity.UnidadeEstoqueMl && para == entity.UnidadeEstoqueLitro,
+		de == entity.UnidadeEstoqueGrama && para == entity.UnidadeEstoqueKg:
+		return quantidade / 1000, true
+	case de == entity.UnidadeEstoqueLitro && para == entity.UnidadeEstoqueMl,
+		de == entity.UnidadeEstoqueKg && para == entity.UnidadeEstoqueGrama:
+		return quantidade * 1000, true
+	}
+	return 0, false
+}
@@ -0,0 +1,183 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type estoqueMocks struct {
+	estoque    *test.MockEstoqueRepositorio
+	plantaRepo *test.MockPlantaRepositorio
+	diarioRepo *MockDiarioCultivoRepository
+	canal      *test.MockCanalNotificacao
+}
+
+func novoEstoqueService() (service.EstoqueService, estoqueMocks) {
+	m := estoqueMocks{
+		estoque:    new(test.MockEstoqueRepositorio),
+		plantaRepo: new(test.MockPlantaRepositorio),
+		diarioRepo: new(MockDiarioCultivoRepository),
+		canal:      &test.MockCanalNotificacao{NomeCanal: service.CanalLembrete},
+	}
+	return service.NewEstoqueService(m.estoque, m.plantaRepo, m.diarioRepo, m.canal), m
+}
+
+// itemEstoque é um item do usuário 7 com saldo, custo médio por unidade e mínimo opcional
+func itemEstoque(id uint, nome string, unidade entity.UnidadeEstoque, quantidade, custoMedio float64, minimo *float64) *entity.ItemEstoque {
+	item := &entity.ItemEstoque{UsuarioID: 7, Nome: nome, Categoria: entity.CategoriaEstoqueNutriente, Unidade: unidade,
+		Quantidade: quantidade, CustoMedio: custoMedio, EstoqueMinimo: minimo}
+	item.ID = id
+	return item
+}
+
+func TestEstoqueService_RegistrarCompra(t *testing.T) {
+	servico, m := novoEstoqueService()
+	item := itemEstoque(3, "Bloom", entity.UnidadeEstoqueMl, 200, 0.1, valor(250))
+	alerta := time.Now().AddDate(0, 0, -1)
+	item.AlertaEstoqueEm = &alerta
+	m.estoque.On("BuscarItem", uint(3)).Return(item, nil).Once()
+	// o saldo travado no banco já teve 100 ml consumidos depois da leitura do item
+	travado := *item
+	travado.Quantidade = 100
+	m.estoque.On("RegistrarMovimentacoes", mock.AnythingOfType("*repository.MovimentacoesEstoque")).
+		Run(test.GravarMovimentacoes(&travado)).Return(nil).Once()
+
+	// 100 ml a 0,10 + 1000 ml por 160,00: custo médio de 0,1545 por ml
+	compra, err := servico.RegistrarCompra(3, 7, &dto.CompraEstoqueDTO{Quantidade: 1000, CustoTotal: 160, Fornecedor: "Growshop"})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.MovimentacaoEstoqueCompra, compra.Tipo)
+	assert.Equal(t, uint(7), compra.UsuarioID)
+	assert.Equal(t, uint(3), compra.ItemID)
+	assert.Equal(t, 1100.0, item.Quantidade)
+	assert.Equal(t, 0.1545, item.CustoMedio)
+	// reposto acima do mínimo: o aviso volta a valer
+	assert.Nil(t, item.AlertaEstoqueEm)
+}
+
+func TestEstoqueService_RegaRegistrada(t *testing.T) {
+	t.Run("Success - Baixa por Nome com Conversão e Aviso de Estoque Baixo", func(t *testing.T) {
+		servico, m := novoEstoqueService()
+		bloom := itemEstoque(3, "Bloom  A", entity.UnidadeEstoqueLitro, 1, 80, valor(0.5))
+		m.estoque.On("ListarItens", repository.FiltroItensEstoque{UsuarioID: 7, Categoria: entity.CategoriaEstoqueNutriente}).
+			Return([]entity.ItemEstoque{*bloom}, nil).Once()
+		var movimentacoes *repository.MovimentacoesEstoque
+		m.estoque.On("RegistrarMovimentacoes", mock.AnythingOfType("*repository.MovimentacoesEstoque")).
+			Run(func(args mock.Arguments) {
+				movimentacoes = args.Get(0).(*repository.MovimentacoesEstoque)
+				test.GravarMovimentacoes(bloom)(args)
+			}).Return(nil).Once()
+		m.canal.On("Enviar", mock.Anything, mock.MatchedBy(func(n service.Notificacao) bool {
+			return n.UsuarioID == 7 && n.Categoria == entity.CategoriaNotificacaoEstoque
+		})).Return(nil).Once()
+
+		plantaID := uint(10)
+		rega := &entity.Rega{UsuarioID: 7, PlantaID: &plantaID, VolumeLitros: 20, Data: time.Now(), Nutrientes: []entity.NutrienteRega{
+			{Nutriente: "bloom a", Dose: 30, Unidade: entity.UnidadeDoseMlPorLitro},
+			{Nutriente: "CalMag", Dose: 1, Unidade: entity.UnidadeDoseMlPorLitro}, // sem item no estoque
+		}}
+		rega.ID = 21
+
+		require.NoError(t, servico.RegaRegistrada(rega))
+
+		// 30 ml/L em 20 L = 600 ml = 0,6 L, a 80,00 o litro
+		require.NotNil(t, movimentacoes)
+		require.Len(t, movimentacoes.Itens, 1)
+		consumo := movimentacoes.Itens[0]
+		assert.Equal(t, entity.OrigemEstoqueRega, consumo.Origem)
+		assert.Equal(t, uint(21), *consumo.OrigemID)
+		assert.Equal(t, &plantaID, consumo.PlantaID)
+		assert.InDelta(t, -0.6, consumo.Quantidade, 1e-9)
+		assert.Equal(t, 48.0, consumo.CustoTotal)
+		assert.Equal(t, 0.4, bloom.Quantidade)
+		m.canal.AssertExpectations(t)
+	})
+
+	t.Run("Success - Aviso Não se Repete", func(t *testing.T) {
+		servico, m := novoEstoqueService()
+		bloom := itemEstoque(3, "Bloom", entity.UnidadeEstoqueMl, 300, 0.1, valor(500))
+		avisado := time.Now().AddDate(0, 0, -2)
+		bloom.AlertaEstoqueEm = &avisado
+		m.estoque.On("ListarItens", mock.Anything).Return([]entity.ItemEstoque{*bloom}, nil).Once()
+		m.estoque.On("RegistrarMovimentacoes", mock.Anything).Run(test.GravarMovimentacoes(bloom)).Return(nil).Once()
+
+		rega := &entity.Rega{UsuarioID: 7, VolumeLitros: 10, Data: time.Now(), Nutrientes: []entity.NutrienteRega{
+			{Nutriente: "Bloom", Dose: 2, Unidade: entity.UnidadeDoseMlPorLitro},
+		}}
+
+		require.NoError(t, servico.RegaRegistrada(rega))
+		m.canal.AssertNotCalled(t, "Enviar", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEstoqueService_PrepararBaixa(t *testing.T) {
+	t.Run("Error - Item de Outro Usuário Não Movimenta Nenhum", func(t *testing.T) {
+		servico, m := novoEstoqueService()
+		vaso := itemEstoque(3, "Vaso 11 L", entity.UnidadeEstoqueUnidade, 5, 12, nil)
+		alheio := itemEstoque(4, "Substrato", entity.UnidadeEstoqueLitro, 50, 2, nil)
+		alheio.UsuarioID = 8
+		m.estoque.On("BuscarItem", uint(3)).Return(vaso, nil).Once()
+		m.estoque.On("BuscarItem", uint(4)).Return(alheio, nil).Once()
+
+		_, err := servico.PrepararBaixa(7, service.BaixaEstoque{Origem: entity.OrigemEstoqueTransplante},
+			[]dto.ItemConsumidoDTO{{ItemID: 3, Quantidade: 1}, {ItemID: 4, Quantidade: 11}})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("Success - Saída Maior que o Saldo Travado Registra Só o Retirado", func(t *testing.T) {
+		servico, m := novoEstoqueService()
+		vaso := itemEstoque(3, "Vaso 11 L", entity.UnidadeEstoqueUnidade, 5, 12, nil)
+		m.estoque.On("BuscarItem", uint(3)).Return(vaso, nil).Once()
+		movimentacoes, err := servico.PrepararBaixa(7, service.BaixaEstoque{Origem: entity.OrigemEstoqueTransplante},
+			[]dto.ItemConsumidoDTO{{ItemID: 3, Quantidade: 3}})
+		require.NoError(t, err)
+
+		// outra baixa levou 4 vasos entre a validação e a gravação
+		travado := *vaso
+		travado.Quantidade = 1
+		test.GravarMovimentacoes(&travado)(mock.Arguments{movimentacoes})
+
+		require.Len(t, movimentacoes.Itens, 1)
+		assert.Equal(t, -1.0, movimentacoes.Itens[0].Quantidade)
+		assert.Equal(t, 12.0, movimentacoes.Itens[0].CustoTotal)
+		assert.Zero(t, travado.Quantidade)
+	})
+}
+
+func TestEstoqueService_CustoCiclo(t *testing.T) {
+	servico, m := novoEstoqueService()
+	inicio := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	diario := &entity.DiarioCultivo{UsuarioID: 7, DataInicio: inicio}
+	diario.ID = 5
+	m.diarioRepo.On("GetByID", uint(5)).Return(diario, nil).Once()
+	m.plantaRepo.On("ListarPorDiario", uint(5)).Return([]entity.Planta{plantaRegada(10, 7, "A"), plantaRegada(11, 7, "B")}, nil).Once()
+	bloom := itemEstoque(3, "Bloom", entity.UnidadeEstoqueMl, 0, 0, nil)
+	vaso := itemEstoque(4, "Vaso 11 L", entity.UnidadeEstoqueUnidade, 0, 0, nil)
+	vaso.Categoria = entity.CategoriaEstoqueVaso
+	m.estoque.On("ListarConsumosCiclo", repository.FiltroConsumosCiclo{DiarioCultivoID: 5, PlantaIDs: []uint{10, 11}, De: inicio}).
+		Return([]entity.MovimentacaoEstoque{
+			{ItemID: 3, Item: bloom, Quantidade: -600, CustoTotal: 48},
+			{ItemID: 4, Item: vaso, Quantidade: -2, CustoTotal: 24},
+			{ItemID: 3, Item: bloom, Quantidade: -400, CustoTotal: 32},
+		}, nil).Once()
+
+	custo, err := servico.CustoCiclo(5, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, 104.0, custo.Total)
+	assert.Equal(t, 52.0, *custo.CustoPorPlanta)
+	require.Len(t, custo.PorItem, 2)
+	assert.Equal(t, dto.CustoItemEstoqueDTO{ItemID: 3, Nome: "Bloom", Categoria: "nutriente", Quantidade: 1000, Unidade: "ml", Total: 80}, custo.PorItem[0])
+	assert.Equal(t, []dto.CustoCategoriaEstoqueDTO{{Categoria: "nutriente", Total: 80}, {Categoria: "vaso", Total: 24}}, custo.PorCategoria)
+}
@@ -0,0 +1,453 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// toleranciaHorasLuz evita sugerir trocas por diferenças de poucos minutos
+	toleranciaHorasLuz = 0.25
+	// maxDiasHorasLuz limita o período da consulta de horas de luz
+	maxDiasHorasLuz = 366
+	// horaLigarPadrao é usada na virada quando o ambiente ainda não tem programação
+	horaLigarPadrao = "06:00"
+)
+
+// horasLuzEstagio define, para plantas fotoperiódicas, as horas de luz aceitas em cada
+// estágio e a programação sugerida quando o ambiente está fora da faixa
+var horasLuzEstagio = map[entity.EstagioPlanta]struct {
+	faixa    calculo.Faixa
+	sugerida float64
+}{
+	entity.EstagioGerminacao: {calculo.Faixa{Min: 16, Max: 24}, 18},
+	entity.EstagioPlantula:   {calculo.Faixa{Min: 16, Max: 24}, 18},
+	entity.EstagioVegetativo: {calculo.Faixa{Min: 16, Max: 24}, 18},
+	entity.EstagioFloracao:   {calculo.Faixa{Min: 0, Max: 12}, 12},
+	entity.EstagioMaturacao:  {calculo.Faixa{Min: 0, Max: 12}, 12},
+}
+
+// CalendarioLuz informa as horas de luz programadas de um ambiente, dia a dia.
+// inicio e fim são datas de calendário (ano, mês e dia no fuso em que foram informadas), inclusivas.
+type CalendarioLuz interface {
+	HorasLuzNoPeriodo(ambienteID uint, inicio, fim time.Time) ([]dto.HorasLuzDiaDTO, error)
+}
+
+// ObservadorEstagio é notificado quando uma planta inicia um novo estágio.
+type ObservadorEstagio interface {
+	EstagioIniciado(planta *entity.Planta, estagio *entity.EstagioCrescimento) error
+}
+
+// FotoperiodoService gerencia as programações de luz dos ambientes.
+type FotoperiodoService interface {
+	CalendarioLuz
+	ObservadorEstagio
+
+	Criar(ambienteID uint, fotoperiodoDto *dto.FotoperiodoDTO) (*entity.Fotoperiodo, error)
+	// Virar agenda a troca de horas de luz (ex.: 18/6 → 12/12) mantendo o horário de ligar vigente
+	Virar(ambienteID uint, viradaDto *dto.ViradaFotoperiodoDTO) (*entity.Fotoperiodo, error)
+	ListarHistorico(ambienteID uint) ([]entity.Fotoperiodo, error)
+	BuscarVigente(ambienteID uint) (*entity.Fotoperiodo, error)
+	Deletar(id uint) error
+	HorasLuz(ambienteID uint, consulta *dto.ConsultaHorasLuzDTO) ([]dto.HorasLuzDiaDTO, error)
+	// Sugestao compara a programação de hoje com o estágio predominante das plantas do ambiente
+	Sugestao(ambienteID uint) (*dto.SugestaoFotoperiodoDTO, error)
+}
+
+type fotoperiodoService struct {
+	repositorio         repository.FotoperiodoRepositorio
+	ambienteRepositorio repository.AmbienteRepositorio
+	estagioRepositorio  repository.EstagioCrescimentoRepositorio
+	geneticaRepositorio repository.GeneticaRepositorio
+	canais              []CanalNotificacao
+	local               *time.Location
+	agora               func() time.Time
+}
+
+// NewFotoperiodoService cria o serviço de fotoperíodo. local é o fuso dos horários de ligar e
+// desligar; os canais recebem as sugestões de troca quando plantas entram em floração.
+func NewFotoperiodoService(
+	repositorio repository.FotoperiodoRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	estagioRepositorio repository.EstagioCrescimentoRepositorio,
+	geneticaRepositorio repository.GeneticaRepositorio,
+	local *time.Location,
+	canais ...CanalNotificacao,
+) FotoperiodoService {
+	if local == nil {
+		local = time.Local
+	}
+	return &fotoperiodoService{
+		repositorio:         repositorio,
+		ambienteRepositorio: ambienteRepositorio,
+		estagioRepositorio:  estagioRepositorio,
+		geneticaRepositorio: geneticaRepositorio,
+		canais:              canais,
+		local:               local,
+		agora:               time.Now,
+	}
+}
+
+func (s *fotoperiodoService) Criar(ambienteID uint, fotoperiodoDto *dto.FotoperiodoDTO) (*entity.Fotoperiodo, error) {
+	if _, err := s.buscarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+
+	fotoperiodo := &entity.Fotoperiodo{
+		AmbienteID:   ambienteID,
+		Nome:         fotoperiodoDto.Nome,
+		HoraLigar:    fotoperiodoDto.HoraLigar,
+		HoraDesligar: fotoperiodoDto.HoraDesligar,
+		RampaMinutos: fotoperiodoDto.RampaMinutos,
+		VigenteDesde: s.agora(),
+	}
+	if fotoperiodoDto.VigenteDesde != nil && !fotoperiodoDto.VigenteDesde.IsZero() {
+		fotoperiodo.VigenteDesde = *fotoperiodoDto.VigenteDesde
+	}
+	// o nascer e o pôr do sol simulados precisam caber no período de luz
+	if fotoperiodo.HorasLuz() == 0 || float64(2*fotoperiodo.RampaMinutos) > fotoperiodo.HorasLuz()*60 {
+		return nil, utils.ErrInvalidInput
+	}
+
+	if err := s.repositorio.Criar(fotoperiodo); err != nil {
+		return nil, fmt.Errorf("falha ao criar fotoperíodo do ambiente %d: %w", ambienteID, err)
+	}
+	return fotoperiodo, nil
+}
+
+func (s *fotoperiodoService) Virar(ambienteID uint, viradaDto *dto.ViradaFotoperiodoDTO) (*entity.Fotoperiodo, error) {
+	if viradaDto.HorasLuz <= 0 || viradaDto.HorasLuz > 24 {
+		return nil, utils.ErrInvalidInput
+	}
+	if _, err := s.buscarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	historico, err := s.repositorio.ListarPorAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	horaLigar, rampa := horaLigarPadrao, 0
+	if base := vigenteEm(historico, viradaDto.Data); base != nil {
+		horaLigar, rampa = base.HoraLigar, base.RampaMinutos
+	}
+	ligar, _ := minutosDoDia(horaLigar)
+	duracao := int(math.Round(viradaDto.HorasLuz * 60))
+	desligar := (ligar + duracao) % (24 * 60)
+	// as rampas precisam caber no novo período de luz
+	if 2*rampa > duracao {
+		rampa = duracao / 2
+	}
+
+	fotoperiodo := &entity.Fotoperiodo{
+		AmbienteID:   ambienteID,
+		Nome:         fmt.Sprintf("Virada para %s/%s", formatarValor(viradaDto.HorasLuz), formatarValor(24-viradaDto.HorasLuz)),
+		HoraLigar:    horaLigar,
+		HoraDesligar: fmt.Sprintf("%02d:%02d", desligar/60, desligar%60),
+		RampaMinutos: rampa,
+		VigenteDesde: viradaDto.Data,
+	}
+	if err := s.repositorio.Criar(fotoperiodo); err != nil {
+		return nil, fmt.Errorf("falha ao agendar virada de fotoperíodo do ambiente %d: %w", ambienteID, err)
+	}
+	return fotoperiodo, nil
+}
+
+func (s *fotoperiodoService) ListarHistorico(ambienteID uint) ([]entity.Fotoperiodo, error) {
+	if _, err := s.buscarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	return s.repositorio.ListarPorAmbiente(ambienteID)
+}
+
+func (s *fotoperiodoService) BuscarVigente(ambienteID uint) (*entity.Fotoperiodo, error) {
+	if _, err := s.buscarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	historico, err := s.repositorio.ListarPorAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	vigente := vigenteEm(historico, s.agora())
+	if vigente == nil {
+		return nil, utils.ErrNotFound
+	}
+	return vigente, nil
+}
+
+func (s *fotoperiodoService) Deletar(id uint) error {
+	if _, err := s.repositorio.BuscarPorID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao buscar fotoperíodo com ID %d: %w", id, err)
+	}
+	return s.repositorio.Deletar(id)
+}
+
+func (s *fotoperiodoService) HorasLuz(ambienteID uint, consulta *dto.ConsultaHorasLuzDTO) ([]dto.HorasLuzDiaDTO, error) {
+	agora := s.agora().In(s.local)
+	inicio := time.Date(agora.Year(), agora.Month(), agora.Day(), 0, 0, 0, 0, s.local)
+	if consulta.Inicio != "" {
+		data, err := time.ParseInLocation("2006-01-02", consulta.Inicio, s.local)
+		if err != nil {
+			return nil, utils.ErrInvalidInput
+		}
+		inicio = data
+	}
+	fim := inicio.AddDate(0, 0, 6)
+	if consulta.Fim != "" {
+		data, err := time.ParseInLocation("2006-01-02", consulta.Fim, s.local)
+		if err != nil {
+			return nil, utils.ErrInvalidInput
+		}
+		fim = data
+	}
+	return s.HorasLuzNoPeriodo(ambienteID, inicio, fim)
+}
+
+func (s *fotoperiodoService) HorasLuzNoPeriodo(ambienteID uint, inicio, fim time.Time) ([]dto.HorasLuzDiaDTO, error) {
+	inicio = s.inicioDoDia(inicio)
+	fim = s.inicioDoDia(fim)
+	if fim.Before(inicio) || fim.After(inicio.AddDate(0, 0, maxDiasHorasLuz)) {
+		return nil, utils.ErrInvalidInput
+	}
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	historico, err := s.repositorio.ListarPorAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dias []dto.HorasLuzDiaDTO
+	for dia := inicio; !dia.After(fim); dia = dia.AddDate(0, 0, 1) {
+		dias = append(dias, horasLuzDoDia(ambiente, historico, dia))
+	}
+	return dias, nil
+}
+
+func (s *fotoperiodoService) Sugestao(ambienteID uint) (*dto.SugestaoFotoperiodoDTO, error) {
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	historico, err := s.repositorio.ListarPorAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	estagios, err := s.estagioRepositorio.ListarAtuaisPorAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	estagio, ok := estagioPredominante(estagios)
+	if !ok {
+		hoje := horasLuzDoDia(ambiente, historico, s.inicioDoDia(s.agora().In(s.local)))
+		return &dto.SugestaoFotoperiodoDTO{
+			AmbienteID:     ambienteID,
+			HorasLuzAtuais: hoje.HorasLuz,
+			Motivo:         "Nenhuma planta ativa no ambiente",
+		}, nil
+	}
+	return s.sugerir(ambiente, historico, estagio), nil
+}
+
+// autoflorescente identifica autos pela espécie da planta ou pela genética dela, que é quem
+// costuma informar a automática
+func (s *fotoperiodoService) autoflorescente(planta *entity.Planta) (bool, error) {
+	if planta.Especie == entity.EspecieRuderalis {
+		return true, nil
+	}
+	if planta.GeneticaID == 0 {
+		return false, nil
+	}
+	if planta.Genetica.ID == planta.GeneticaID {
+		return geneticaAutoflorescente(&planta.Genetica), nil
+	}
+	genetica, err := s.geneticaRepositorio.BuscarPorID(planta.GeneticaID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("falha ao buscar genética com ID %d: %w", planta.GeneticaID, err)
+	}
+	return geneticaAutoflorescente(genetica), nil
+}
+
+// EstagioIniciado avisa o dono da planta quando ela entra em floração e o ambiente ainda
+// está com programação de vegetativo. Autoflorescentes não dependem do fotoperíodo.
+func (s *fotoperiodoService) EstagioIniciado(planta *entity.Planta, estagio *entity.EstagioCrescimento) error {
+	if estagio.Estagio != entity.EstagioFloracao {
+		return nil
+	}
+	auto, err := s.autoflorescente(planta)
+	if err != nil || auto {
+		return err
+	}
+	ambiente, err := s.buscarAmbiente(planta.AmbienteID)
+	if err != nil {
+		return err
+	}
+	historico, err := s.repositorio.ListarPorAmbiente(planta.AmbienteID)
+	if err != nil {
+		return err
+	}
+
+	sugestao := s.sugerir(ambiente, historico, entity.EstagioFloracao)
+	if !sugestao.Mudar {
+		return nil
+	}
+	notificacao := Notificacao{
+		UsuarioID:  planta.UsuarioID,
+		Categoria:  entity.CategoriaNotificacaoCultivo,
+		PlantaID:   &planta.ID,
+		AmbienteID: &ambiente.ID,
+		Titulo:     fmt.Sprintf("Fotoperíodo: %s entrou em floração", planta.Nome),
+		Mensagem: fmt.Sprintf("%s entrou em floração, mas %s está com %sh de luz. Agende a virada para %sh a partir de %s.",
+			planta.Nome, ambiente.Nome, formatarValor(sugestao.HorasLuzAtuais),
+			formatarValor(sugestao.HorasLuzSugeridas), sugestao.Virada.Data.Format("02/01/2006")),
+		Dados: map[string]any{
+			"ambiente_id":         ambiente.ID,
+			"planta_id":           planta.ID,
+			"horas_luz_sugeridas": sugestao.HorasLuzSugeridas,
+			"data":                sugestao.Virada.Data,
+		},
+	}
+
+	var errs []error
+	for _, canal := range s.canais {
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutNotificacao)
+		err := canal.Enviar(ctx, notificacao)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("falha ao notificar pelo canal %s: %w", canal.Nome(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sugerir compara as horas de luz de hoje com a faixa do estágio. Uma virada já agendada
+// para as horas sugeridas conta como resolvida.
+func (s *fotoperiodoService) sugerir(ambiente *entity.Ambiente, historico []entity.Fotoperiodo, estagio entity.EstagioPlanta) *dto.SugestaoFotoperiodoDTO {
+	agora := s.agora()
+	hoje := horasLuzDoDia(ambiente, historico, s.inicioDoDia(agora.In(s.local)))
+	sugestao := &dto.SugestaoFotoperiodoDTO{
+		AmbienteID:     ambiente.ID,
+		Estagio:        estagio,
+		HorasLuzAtuais: hoje.HorasLuz,
+	}
+	if ambiente.Tipo == "externo" {
+		sugestao.Motivo = "Ambientes externos seguem o fotoperíodo natural"
+		return sugestao
+	}
+
+	alvo, ok := horasLuzEstagio[estagio]
+	if !ok {
+		sugestao.Motivo = "Estágio sem faixa de horas de luz definida"
+		return sugestao
+	}
+	sugestao.HorasLuzSugeridas = alvo.sugerida
+	dentroDaFaixa := func(horas float64) bool {
+		return horas >= alvo.faixa.Min-toleranciaHorasLuz && horas <= alvo.faixa.Max+toleranciaHorasLuz
+	}
+	if dentroDaFaixa(hoje.HorasLuz) {
+		sugestao.Motivo = fmt.Sprintf("Programação compatível com o estágio %s", estagio)
+		return sugestao
+	}
+	for _, f := range historico {
+		if f.VigenteDesde.After(agora) && dentroDaFaixa(f.HorasLuz()) {
+			sugestao.Motivo = fmt.Sprintf("Troca para %sh já agendada para %s",
+				formatarValor(f.HorasLuz()), f.VigenteDesde.In(s.local).Format("02/01/2006"))
+			return sugestao
+		}
+	}
+
+	sugestao.Mudar = true
+	sugestao.Virada = &dto.ViradaFotoperiodoDTO{
+		Data:     s.inicioDoDia(agora.In(s.local)).AddDate(0, 0, 1),
+		HorasLuz: alvo.sugerida,
+	}
+	if alvo.sugerida < hoje.HorasLuz {
+		sugestao.Motivo = fmt.Sprintf("Plantas em %s: reduzir para %sh de luz induz e mantém a floração",
+			estagio, formatarValor(alvo.sugerida))
+	} else {
+		sugestao.Motivo = fmt.Sprintf("Plantas em %s: menos de %sh de luz pode antecipar a floração",
+			estagio, formatarValor(alvo.faixa.Min))
+	}
+	return sugestao
+}
+
+func (s *fotoperiodoService) buscarAmbiente(ambienteID uint) (*entity.Ambiente, error) {
+	if ambienteID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	ambiente, err := s.ambienteRepositorio.BuscarPorID(ambienteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar ambiente com ID %d: %w", ambienteID, err)
+	}
+	return ambiente, nil
+}
+
+// inicioDoDia retorna a meia-noite local da data de calendário de t
+func (s *fotoperiodoService) inicioDoDia(t time.Time) time.Time {
+	ano, mes, dia := t.Date()
+	return time.Date(ano, mes, dia, 0, 0, 0, 0, s.local)
+}
+
+// vigenteEm retorna a programação mais recente iniciada até o instante (historico em ordem de vigência)
+func vigenteEm(historico []entity.Fotoperiodo, instante time.Time) *entity.Fotoperiodo {
+	var vigente *entity.Fotoperiodo
+	for i := range historico {
+		if !historico[i].VigenteDesde.After(instante) {
+			vigente = &historico[i]
+		}
+	}
+	return vigente
+}
+
+// horasLuzDoDia usa a programação iniciada até o fim do dia; sem programação, recai no
+// fotoperíodo natural dos ambientes externos com localização ou no tempo de exposição cadastrado
+func horasLuzDoDia(ambiente *entity.Ambiente, historico []entity.Fotoperiodo, dia time.Time) dto.HorasLuzDiaDTO {
+	resultado := dto.HorasLuzDiaDTO{Data: dia.Format("2006-01-02")}
+	fimDoDia := dia.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	fotoperiodo := vigenteEm(historico, fimDoDia)
+	if fotoperiodo == nil && ambiente.Tipo == "externo" && ambiente.Latitude != nil && ambiente.Longitude != nil {
+		sol := calculo.Sol(dia, *ambiente.Latitude, *ambiente.Longitude)
+		resultado.Ligar = sol.Nascer
+		resultado.Desligar = sol.PorDoSol
+		resultado.HorasLuz = math.Round(sol.Duracao.Hours()*100) / 100
+		resultado.HorasLuzEfetivas = resultado.HorasLuz
+		return resultado
+	}
+	if fotoperiodo == nil {
+		resultado.HorasLuz = float64(ambiente.TempoExposicao)
+		resultado.HorasLuzEfetivas = resultado.HorasLuz
+		return resultado
+	}
+
+	minutos, _ := minutosDoDia(fotoperiodo.HoraLigar)
+	ligar := time.Date(dia.Year(), dia.Month(), dia.Day(), minutos/60, minutos%60, 0, 0, dia.Location())
+	desligar := ligar.Add(time.Duration(fotoperiodo.HorasLuz() * float64(time.Hour)))
+	id := fotoperiodo.ID
+	resultado.FotoperiodoID = &id
+	resultado.Ligar = &ligar
+	resultado.Desligar = &desligar
+	resultado.HorasLuz = fotoperiodo.HorasLuz()
+	resultado.HorasLuzEfetivas = fotoperiodo.HorasLuzEfetivas()
+	return resultado
+}
@@ -0,0 +1,237 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mocksFotoperiodo struct {
+	repo         *test.MockFotoperiodoRepositorio
+	ambienteRepo *test.MockAmbienteRepositorio
+	estagioRepo  *test.MockEstagioCrescimentoRepositorio
+	geneticaRepo *test.MockGeneticaRepositorio
+	canal        *test.MockCanalNotificacao
+}
+
+func novoFotoperiodoService(t *testing.T) (service.FotoperiodoService, *mocksFotoperiodo, *time.Location) {
+	t.Helper()
+	local, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	m := &mocksFotoperiodo{
+		repo:         new(test.MockFotoperiodoRepositorio),
+		ambienteRepo: new(test.MockAmbienteRepositorio),
+		estagioRepo:  new(test.MockEstagioCrescimentoRepositorio),
+		geneticaRepo: new(test.MockGeneticaRepositorio),
+		canal:        &test.MockCanalNotificacao{NomeCanal: service.CanalLembrete},
+	}
+	return service.NewFotoperiodoService(m.repo, m.ambienteRepo, m.estagioRepo, m.geneticaRepo, local, m.canal), m, local
+}
+
+func TestFotoperiodo_HorasLuz(t *testing.T) {
+	assert.Equal(t, 18.0, entity.Fotoperiodo{HoraLigar: "06:00", HoraDesligar: "00:00"}.HorasLuz())
+	assert.Equal(t, 12.0, entity.Fotoperiodo{HoraLigar: "20:00", HoraDesligar: "08:00"}.HorasLuz())
+	assert.Equal(t, 24.0, entity.Fotoperiodo{HoraLigar: "06:00", HoraDesligar: "06:00"}.HorasLuz())
+	assert.Equal(t, 17.5, entity.Fotoperiodo{HoraLigar: "06:00", HoraDesligar: "00:00", RampaMinutos: 30}.HorasLuzEfetivas())
+}
+
+func TestFotoperiodoService_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servico, m, _ := novoFotoperiodoService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		m.repo.On("Criar", mock.AnythingOfType("*entity.Fotoperiodo")).Return(nil).Once()
+
+		fotoperiodo, err := servico.Criar(1, &dto.FotoperiodoDTO{HoraLigar: "06:00", HoraDesligar: "00:00", RampaMinutos: 30})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), fotoperiodo.AmbienteID)
+		assert.False(t, fotoperiodo.VigenteDesde.IsZero())
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Rampas Maiores que o Período de Luz", func(t *testing.T) {
+		servico, m, _ := novoFotoperiodoService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+
+		_, err := servico.Criar(1, &dto.FotoperiodoDTO{HoraLigar: "06:00", HoraDesligar: "09:00", RampaMinutos: 120})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.repo.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Ambiente Not Found", func(t *testing.T) {
+		servico, m, _ := novoFotoperiodoService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(9)).Return((*entity.Ambiente)(nil), gorm.ErrRecordNotFound).Once()
+
+		_, err := servico.Criar(9, &dto.FotoperiodoDTO{HoraLigar: "06:00", HoraDesligar: "00:00"})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestFotoperiodoService_Virar(t *testing.T) {
+	servico, m, local := novoFotoperiodoService(t)
+	data := time.Date(2026, 6, 1, 0, 0, 0, 0, local)
+	historico := []entity.Fotoperiodo{
+		{HoraLigar: "06:00", HoraDesligar: "00:00", RampaMinutos: 30, VigenteDesde: data.AddDate(0, -1, 0)},
+	}
+	m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+	m.repo.On("ListarPorAmbiente", uint(1)).Return(historico, nil).Once()
+	m.repo.On("Criar", mock.AnythingOfType("*entity.Fotoperiodo")).Return(nil).Once()
+
+	fotoperiodo, err := servico.Virar(1, &dto.ViradaFotoperiodoDTO{Data: data, HorasLuz: 12})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "06:00", fotoperiodo.HoraLigar)
+	assert.Equal(t, "18:00", fotoperiodo.HoraDesligar)
+	assert.Equal(t, 30, fotoperiodo.RampaMinutos)
+	assert.Equal(t, "Virada para 12/12", fotoperiodo.Nome)
+	assert.True(t, data.Equal(fotoperiodo.VigenteDesde))
+}
+
+func TestFotoperiodoService_HorasLuzNoPeriodo(t *testing.T) {
+	servico, m, local := novoFotoperiodoService(t)
+	virada := time.Date(2026, 6, 3, 0, 0, 0, 0, local)
+	historico := []entity.Fotoperiodo{
+		{Model: gorm.Model{ID: 1}, HoraLigar: "06:00", HoraDesligar: "00:00", VigenteDesde: time.Date(2026, 6, 2, 10, 0, 0, 0, local)},
+		{Model: gorm.Model{ID: 2}, HoraLigar: "06:00", HoraDesligar: "18:00", RampaMinutos: 30, VigenteDesde: virada},
+	}
+	m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{TempoExposicao: 20}, nil).Once()
+	m.repo.On("ListarPorAmbiente", uint(1)).Return(historico, nil).Once()
+
+	dias, err := servico.HorasLuzNoPeriodo(1, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	require.Len(t, dias, 3)
+	// antes da primeira programação vale o tempo de exposição do ambiente
+	assert.Equal(t, "2026-06-01", dias[0].Data)
+	assert.Nil(t, dias[0].FotoperiodoID)
+	assert.Equal(t, 20.0, dias[0].HorasLuz)
+	// a programação criada no meio do dia já vale para ele
+	assert.Equal(t, uint(1), *dias[1].FotoperiodoID)
+	assert.Equal(t, 18.0, dias[1].HorasLuz)
+	assert.Equal(t, time.Date(2026, 6, 3, 0, 0, 0, 0, local), *dias[1].Desligar)
+	assert.Equal(t, 12.0, dias[2].HorasLuz)
+	assert.Equal(t, 11.5, dias[2].HorasLuzEfetivas)
+}
+
+func TestFotoperiodoService_HorasLuzNoPeriodo_Externo(t *testing.T) {
+	servico, m, local := novoFotoperiodoService(t)
+	latitude, longitude := -23.55, -46.63
+	ambiente := &entity.Ambiente{Tipo: "externo", TempoExposicao: 12, Latitude: &latitude, Longitude: &longitude}
+	m.ambienteRepo.On("BuscarPorID", uint(1)).Return(ambiente, nil).Once()
+	m.repo.On("ListarPorAmbiente", uint(1)).Return([]entity.Fotoperiodo{}, nil).Once()
+	solsticio := time.Date(2026, 6, 21, 0, 0, 0, 0, local)
+
+	dias, err := servico.HorasLuzNoPeriodo(1, solsticio, solsticio)
+
+	require.NoError(t, err)
+	require.Len(t, dias, 1)
+	// sem programação, o ambiente externo segue o fotoperíodo natural
+	assert.InDelta(t, 10.7, dias[0].HorasLuz, 0.1)
+	require.NotNil(t, dias[0].Ligar)
+	assert.Equal(t, 6, dias[0].Ligar.In(local).Hour())
+}
+
+func TestFotoperiodoService_Sugestao(t *testing.T) {
+	estagiosFloracao := []entity.EstagioCrescimento{
+		{PlantaID: 1, Estagio: entity.EstagioFloracao},
+		{PlantaID: 2, Estagio: entity.EstagioFloracao},
+	}
+	vegetativo := entity.Fotoperiodo{HoraLigar: "06:00", HoraDesligar: "00:00", VigenteDesde: time.Now().AddDate(0, 0, -30)}
+
+	t.Run("Success - Sugere Virada para 12/12", func(t *testing.T) {
+		servico, m, _ := novoFotoperiodoService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Model: gorm.Model{ID: 1}, Tipo: "interno"}, nil).Once()
+		m.repo.On("ListarPorAmbiente", uint(1)).Return([]entity.Fotoperiodo{vegetativo}, nil).Once()
+		m.estagioRepo.On("ListarAtuaisPorAmbiente", uint(1)).Return(estagiosFloracao, nil).Once()
+
+		sugestao, err := servico.Sugestao(1)
+
+		require.NoError(t, err)
+		assert.True(t, sugestao.Mudar)
+		assert.Equal(t, 18.0, sugestao.HorasLuzAtuais)
+		assert.Equal(t, 12.0, sugestao.Virada.HorasLuz)
+		assert.True(t, sugestao.Virada.Data.After(time.Now()))
+	})
+
+	t.Run("Success - Virada Já Agendada", func(t *testing.T) {
+		servico, m, _ := novoFotoperiodoService(t)
+		agendada := entity.Fotoperiodo{HoraLigar: "06:00", HoraDesligar: "18:00", VigenteDesde: time.Now().AddDate(0, 0, 3)}
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Model: gorm.Model{ID: 1}, Tipo: "interno"}, nil).Once()
+		m.repo.On("ListarPorAmbiente", uint(1)).Return([]entity.Fotoperiodo{vegetativo, agendada}, nil).Once()
+		m.estagioRepo.On("ListarAtuaisPorAmbiente", uint(1)).Return(estagiosFloracao, nil).Once()
+
+		sugestao, err := servico.Sugestao(1)
+
+		require.NoError(t, err)
+		assert.False(t, sugestao.Mudar)
+		assert.Nil(t, sugestao.Virada)
+	})
+
+	t.Run("Success - Ambiente Externo", func(t *testing.T) {
+		servico, m, _ := novoFotoperiodoService(t)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Model: gorm.Model{ID: 1}, Tipo: "externo", TempoExposicao: 14}, nil).Once()
+		m.repo.On("ListarPorAmbiente", uint(1)).Return([]entity.Fotoperiodo{}, nil).Once()
+		m.estagioRepo.On("ListarAtuaisPorAmbiente", uint(1)).Return(estagiosFloracao, nil).Once()
+
+		sugestao, err := servico.Sugestao(1)
+
+		require.NoError(t, err)
+		assert.False(t, sugestao.Mudar)
+		assert.Equal(t, 14.0, sugestao.HorasLuzAtuais)
+	})
+}
+
+func TestFotoperiodoService_EstagioIniciado(t *testing.T) {
+	vegetativo := entity.Fotoperiodo{HoraLigar: "06:00", HoraDesligar: "00:00", VigenteDesde: time.Now().AddDate(0, 0, -30)}
+
+	t.Run("Success - Notifica ao Entrar em Floração", func(t *testing.T) {
+		servico, m, _ := novoFotoperiodoService(t)
+		planta := &entity.Planta{Model: gorm.Model{ID: 5}, Nome: "Lemon", Especie: entity.EspecieSativa, AmbienteID: 1, UsuarioID: 3}
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Model: gorm.Model{ID: 1}, Nome: "Tenda", Tipo: "interno"}, nil).Once()
+		m.repo.On("ListarPorAmbiente", uint(1)).Return([]entity.Fotoperiodo{vegetativo}, nil).Once()
+		m.canal.On("Enviar", mock.Anything, mock.MatchedBy(func(n service.Notificacao) bool {
+			return n.UsuarioID == 3 && n.Dados["horas_luz_sugeridas"] == 12.0
+		})).Return(nil).Once()
+
+		err := servico.EstagioIniciado(planta, &entity.EstagioCrescimento{PlantaID: 5, Estagio: entity.EstagioFloracao})
+
+		assert.NoError(t, err)
+		m.canal.AssertExpectations(t)
+	})
+
+	t.Run("Success - Autoflorescente Não Gera Sugestão", func(t *testing.T) {
+		servico, m, _ := novoFotoperiodoService(t)
+		planta := &entity.Planta{Especie: entity.EspecieRuderalis, AmbienteID: 1}
+
+		err := servico.EstagioIniciado(planta, &entity.EstagioCrescimento{Estagio: entity.EstagioFloracao})
+
+		assert.NoError(t, err)
+		m.ambienteRepo.AssertNotCalled(t, "BuscarPorID", mock.Anything)
+		m.canal.AssertNotCalled(t, "Enviar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Genética Automática Não Gera Sugestão", func(t *testing.T) {
+		servico, m, _ := novoFotoperiodoService(t)
+		// a espécie cadastrada na planta é a dominante; a automática vem da genética
+		planta := &entity.Planta{Especie: entity.EspecieIndica, GeneticaID: 4, AmbienteID: 1}
+		m.geneticaRepo.On("BuscarPorID", uint(4)).Return(&entity.Genetica{Nome: "Gorilla Auto", TipoEspecie: "automatica"}, nil).Once()
+
+		err := servico.EstagioIniciado(planta, &entity.EstagioCrescimento{Estagio: entity.EstagioFloracao})
+
+		assert.NoError(t, err)
+		m.geneticaRepo.AssertExpectations(t)
+		m.ambienteRepo.AssertNotCalled(t, "BuscarPorID", mock.Anything)
+		m.canal.AssertNotCalled(t, "Enviar", mock.Anything, mock.Anything)
+	})
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"io"
+
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
@@ -13,6 +15,14 @@ type GeneticaService interface {
 	BuscarPorID(id uint) (*dto.GeneticaResponseDTO, error)
 	Atualizar(id uint, geneticaDto *dto.UpdateGeneticaDTO) (*dto.GeneticaResponseDTO, error)
 	Deletar(id uint) error
+	ImportarJSON(arquivo io.Reader) (*dto.ResultadoImportacaoGeneticasDTO, error)
+	ImportarCSV(arquivo io.Reader, opcoes *dto.ImportacaoGeneticasCSVDTO) (*dto.ResultadoImportacaoGeneticasDTO, error)
+	ExportarJSON() ([]byte, error)
+	ExportarCSV() ([]byte, error)
+	BuscarDuplicadas() ([]dto.GrupoGeneticasDuplicadasDTO, error)
+	Mesclar(id uint, mesclagemDto *dto.MesclagemGeneticasDTO) (*dto.ResultadoMesclagemGeneticasDTO, error)
+	// Pedigree retorna a árvore de ancestrais da genética
+	Pedigree(id uint, consulta *dto.ConsultaPedigreeDTO) (*dto.PedigreeGeneticaDTO, error)
 }
 
 // Implementação do serviço
@@ -37,20 +47,22 @@ func (s *geneticaService) Criar(geneticaDto *dto.CreateGeneticaDTO) (*dto.Geneti
 		TempoFloracao:   geneticaDto.TempoFloracao,
 		Origem:          geneticaDto.Origem,
 		Caracteristicas: geneticaDto.Caracteristicas,
+		MaeID:           geneticaDto.MaeID,
+		PaiID:           geneticaDto.PaiID,
+	}
+	geracao, err := normalizarGeracao(geneticaDto.Geracao)
+	if err != nil {
+		return nil, err
+	}
+	genetica.Geracao = geracao
+	if err := s.validarOrigem(0, genetica.MaeID, genetica.PaiID); err != nil {
+		return nil, err
 	}
 	if err := s.repositorio.Criar(&genetica); err != nil {
 		return nil, err
 	}
-	return &dto.GeneticaResponseDTO{
-		ID:              genetica.ID,
-		Nome:            genetica.Nome,
-		Descricao:       genetica.Descricao,
-		TipoGenetica:    genetica.TipoGenetica,
-		TipoEspecie:     genetica.TipoEspecie,
-		TempoFloracao:   genetica.TempoFloracao,
-		Origem:          genetica.Origem,
-		Caracteristicas: genetica.Caracteristicas,
-	}, nil
+	resposta := respostaGenetica(&genetica)
+	return &resposta, nil
 }
 
 func (s *geneticaService) ListarTodas(page, limit int) ([]dto.GeneticaResponseDTO, int64, error) {
@@ -61,16 +73,7 @@ func (s *geneticaService) ListarTodas(page, limit int) ([]dto.GeneticaResponseDT
 
 	responseDTOs := make([]dto.GeneticaResponseDTO, 0, len(geneticas))
 	for _, genetica := range geneticas {
-		responseDTOs = append(responseDTOs, dto.GeneticaResponseDTO{
-			ID:              genetica.ID,
-			Nome:            genetica.Nome,
-			Descricao:       genetica.Descricao,
-			TipoGenetica:    genetica.TipoGenetica,
-			TipoEspecie:     genetica.TipoEspecie,
-			TempoFloracao:   genetica.TempoFloracao,
-			Origem:          genetica.Origem,
-			Caracteristicas: genetica.Caracteristicas,
-		})
+		responseDTOs = append(responseDTOs, respostaGenetica(&genetica))
 	}
 
 	return responseDTOs, total, nil
@@ -85,16 +88,8 @@ func (s *geneticaService) BuscarPorID(id uint) (*dto.GeneticaResponseDTO, error)
 	if err != nil {
 		return nil, err
 	}
-	return &dto.GeneticaResponseDTO{
-		ID:              genetica.ID,
-		Nome:            genetica.Nome,
-		Descricao:       genetica.Descricao,
-		TipoGenetica:    genetica.TipoGenetica,
-		TipoEspecie:     genetica.TipoEspecie,
-		TempoFloracao:   genetica.TempoFloracao,
-		Origem:          genetica.Origem,
-		Caracteristicas: genetica.Caracteristicas,
-	}, nil
+	resposta := respostaGenetica(genetica)
+	return &resposta, nil
 }
 
 func (s *geneticaService) Atualizar(id uint, geneticaDto *dto.UpdateGeneticaDTO) (*dto.GeneticaResponseDTO, error) {
@@ -124,19 +119,29 @@ func (s *geneticaService) Atualizar(id uint, geneticaDto *dto.UpdateGeneticaDTO)
 	if geneticaDto.Caracteristicas != "" {
 		geneticaExistente.Caracteristicas = geneticaDto.Caracteristicas
 	}
+	if geneticaDto.Geracao != "" {
+		geracao, err := normalizarGeracao(geneticaDto.Geracao)
+		if err != nil {
+			return nil, err
+		}
+		geneticaExistente.Geracao = geracao
+	}
+	if geneticaDto.MaeID != nil || geneticaDto.PaiID != nil {
+		if geneticaDto.MaeID != nil {
+			geneticaExistente.MaeID = origemInformada(*geneticaDto.MaeID)
+		}
+		if geneticaDto.PaiID != nil {
+			geneticaExistente.PaiID = origemInformada(*geneticaDto.PaiID)
+		}
+		if err := s.validarOrigem(id, geneticaExistente.MaeID, geneticaExistente.PaiID); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.repositorio.Atualizar(geneticaExistente); err != nil {
 		return nil, err
 	}
 
-	return &dto.GeneticaResponseDTO{
-		ID:              geneticaExistente.ID,
-		Nome:            geneticaExistente.Nome,
-		Descricao:       geneticaExistente.Descricao,
-		TipoGenetica:    geneticaExistente.TipoGenetica,
-		TipoEspecie:     geneticaExistente.TipoEspecie,
-		TempoFloracao:   geneticaExistente.TempoFloracao,
-		Origem:          geneticaExistente.Origem,
-		Caracteristicas: geneticaExistente.Caracteristicas,
-	}, nil
+	resposta := respostaGenetica(geneticaExistente)
+	return &resposta, nil
 }
@@ -0,0 +1,600 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxGeneticasImportacao limita o tamanho de um arquivo de catálogo
+	maxGeneticasImportacao = 2000
+	// similaridadeMinimaNome é a semelhança mínima entre os nomes para duas genéticas serem
+	// tratadas como a mesma
+	similaridadeMinimaNome = 0.85
+	// similaridadeMinimaOrigem é a semelhança mínima entre os breeders
+	similaridadeMinimaOrigem = 0.8
+)
+
+// geneticaImportada é um registro do arquivo com a posição em que apareceu
+type geneticaImportada struct {
+	linha    int
+	genetica dto.CreateGeneticaDTO
+}
+
+func (s *geneticaService) ImportarJSON(arquivo io.Reader) (*dto.ResultadoImportacaoGeneticasDTO, error) {
+	var geneticas []dto.CreateGeneticaDTO
+	if err := json.NewDecoder(arquivo).Decode(&geneticas); err != nil {
+		var errSintaxe *json.SyntaxError
+		var errTipo *json.UnmarshalTypeError
+		if errors.As(err, &errSintaxe) || errors.As(err, &errTipo) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: JSON inválido: %v", utils.ErrInvalidInput, err)
+		}
+		return nil, err
+	}
+	if len(geneticas) > maxGeneticasImportacao {
+		return nil, fmt.Errorf("%w: o arquivo passa de %d genéticas", utils.ErrInvalidInput, maxGeneticasImportacao)
+	}
+	registros := make([]geneticaImportada, 0, len(geneticas))
+	for i, genetica := range geneticas {
+		registros = append(registros, geneticaImportada{linha: i + 1, genetica: genetica})
+	}
+	return s.importar(registros)
+}
+
+func (s *geneticaService) ImportarCSV(arquivo io.Reader, opcoes *dto.ImportacaoGeneticasCSVDTO) (*dto.ResultadoImportacaoGeneticasDTO, error) {
+	delimitador, ok := delimitadoresCSV[opcoes.Delimitador]
+	if !ok {
+		return nil, utils.ErrInvalidInput
+	}
+	registros, err := lerGeneticasCSV(arquivo, delimitador)
+	if err != nil {
+		return nil, err
+	}
+	return s.importar(registros)
+}
+
+// importar valida todos os registros antes de gravar: um registro inválido rejeita o arquivo.
+// Genéticas parecidas com uma do catálogo ou com outra do próprio arquivo são ignoradas.
+func (s *geneticaService) importar(registros []geneticaImportada) (*dto.ResultadoImportacaoGeneticasDTO, error) {
+	if len(registros) == 0 {
+		return nil, fmt.Errorf("%w: o arquivo não tem genéticas", utils.ErrInvalidInput)
+	}
+	for i := range registros {
+		if err := normalizarGeneticaImportada(&registros[i].genetica); err != nil {
+			return nil, fmt.Errorf("%w: registro %d: %v", utils.ErrInvalidInput, registros[i].linha, err)
+		}
+	}
+
+	catalogo, err := s.repositorio.ListarCatalogo()
+	if err != nil {
+		return nil, err
+	}
+
+	resultado := &dto.ResultadoImportacaoGeneticasDTO{
+		Criadas:   []dto.GeneticaResponseDTO{},
+		Ignoradas: []dto.GeneticaIgnoradaDTO{},
+	}
+	var novas []entity.Genetica
+	var linhasNovas []int
+	for _, registro := range registros {
+		genetica := registro.genetica
+		ignorada := dto.GeneticaIgnoradaDTO{Linha: registro.linha, Nome: genetica.Nome, Origem: genetica.Origem}
+		if i, similaridade := geneticaParecida(catalogo, genetica.Nome, genetica.Origem); i >= 0 {
+			existente := catalogo[i]
+			ignorada.Motivo = fmt.Sprintf("parecida com %q (%s) do catálogo", existente.Nome, existente.Origem)
+			ignorada.DuplicadaDeID = &existente.ID
+			ignorada.Similaridade = similaridade
+			resultado.Ignoradas = append(resultado.Ignoradas, ignorada)
+			continue
+		}
+		if i, similaridade := geneticaParecida(novas, genetica.Nome, genetica.Origem); i >= 0 {
+			ignorada.Motivo = fmt.Sprintf("repete o registro %d do arquivo", linhasNovas[i])
+			ignorada.Similaridade = similaridade
+			resultado.Ignoradas = append(resultado.Ignoradas, ignorada)
+			continue
+		}
+		novas = append(novas, entity.Genetica{
+			Nome:            genetica.Nome,
+			Descricao:       genetica.Descricao,
+			TipoGenetica:    genetica.TipoGenetica,
+			TipoEspecie:     genetica.TipoEspecie,
+			TempoFloracao:   genetica.TempoFloracao,
+			Origem:          genetica.Origem,
+			Caracteristicas: genetica.Caracteristicas,
+		})
+		linhasNovas = append(linhasNovas, registro.linha)
+	}
+
+	if len(novas) > 0 {
+		if err := s.repositorio.CriarEmLote(novas); err != nil {
+			return nil, fmt.Errorf("falha ao importar genéticas: %w", err)
+		}
+	}
+	for _, genetica := range novas {
+		resultado.Criadas = append(resultado.Criadas, respostaGenetica(&genetica))
+	}
+	return resultado, nil
+}
+
+func (s *geneticaService) ExportarJSON() ([]byte, error) {
+	catalogo, err := s.repositorio.ListarCatalogo()
+	if err != nil {
+		return nil, err
+	}
+	geneticas := make([]dto.GeneticaResponseDTO, 0, len(catalogo))
+	for i := range catalogo {
+		geneticas = append(geneticas, respostaGenetica(&catalogo[i]))
+	}
+	return json.MarshalIndent(geneticas, "", "  ")
+}
+
+func (s *geneticaService) ExportarCSV() ([]byte, error) {
+	catalogo, err := s.repositorio.ListarCatalogo()
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	escritor := csv.NewWriter(&buffer)
+	if err := escritor.Write([]string{"id", "nome", "origem", "tipo_genetica", "tipo_especie", "tempo_floracao", "descricao", "caracteristicas"}); err != nil {
+		return nil, err
+	}
+	for _, genetica := range catalogo {
+		registro := []string{
+			strconv.FormatUint(uint64(genetica.ID), 10),
+			genetica.Nome,
+			genetica.Origem,
+			genetica.TipoGenetica,
+			genetica.TipoEspecie,
+			strconv.Itoa(genetica.TempoFloracao),
+			genetica.Descricao,
+			genetica.Caracteristicas,
+		}
+		if err := escritor.Write(registro); err != nil {
+			return nil, err
+		}
+	}
+	escritor.Flush()
+	if err := escritor.Error(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (s *geneticaService) BuscarDuplicadas() ([]dto.GrupoGeneticasDuplicadasDTO, error) {
+	catalogo, err := s.repositorio.ListarCatalogo()
+	if err != nil {
+		return nil, err
+	}
+
+	// agrupa os pares parecidos por união de conjuntos: A~B e B~C ficam no mesmo grupo
+	raiz := make([]int, len(catalogo))
+	for i := range raiz {
+		raiz[i] = i
+	}
+	var encontrar func(int) int
+	encontrar = func(i int) int {
+		if raiz[i] != i {
+			raiz[i] = encontrar(raiz[i])
+		}
+		return raiz[i]
+	}
+	menorSimilaridade := make(map[int]float64)
+	for i := range catalogo {
+		for j := i + 1; j < len(catalogo); j++ {
+			similaridade, ok := similaridadeGeneticas(catalogo[i].Nome, catalogo[i].Origem, catalogo[j].Nome, catalogo[j].Origem)
+			if !ok {
+				continue
+			}
+			a, b := encontrar(i), encontrar(j)
+			minima := similaridade
+			for _, r := range []int{a, b} {
+				if v, existe := menorSimilaridade[r]; existe && v < minima {
+					minima = v
+				}
+			}
+			if a != b {
+				raiz[b] = a
+				delete(menorSimilaridade, b)
+			}
+			menorSimilaridade[a] = minima
+		}
+	}
+
+	membros := make(map[int][]int)
+	for i := range catalogo {
+		r := encontrar(i)
+		membros[r] = append(membros[r], i)
+	}
+	grupos := []dto.GrupoGeneticasDuplicadasDTO{}
+	for r, indices := range membros {
+		if len(indices) < 2 {
+			continue
+		}
+		// a mais antiga primeiro: é a candidata natural a ser mantida na mesclagem
+		sort.Slice(indices, func(i, j int) bool { return catalogo[indices[i]].ID < catalogo[indices[j]].ID })
+		grupo := dto.GrupoGeneticasDuplicadasDTO{Similaridade: menorSimilaridade[r]}
+		for _, i := range indices {
+			grupo.Geneticas = append(grupo.Geneticas, respostaGenetica(&catalogo[i]))
+		}
+		grupos = append(grupos, grupo)
+	}
+	sort.Slice(grupos, func(i, j int) bool {
+		return strings.ToLower(grupos[i].Geneticas[0].Nome) < strings.ToLower(grupos[j].Geneticas[0].Nome)
+	})
+	return grupos, nil
+}
+
+func (s *geneticaService) Mesclar(id uint, mesclagemDto *dto.MesclagemGeneticasDTO) (*dto.ResultadoMesclagemGeneticasDTO, error) {
+	if mesclagemDto == nil || len(mesclagemDto.Duplicadas) == 0 {
+		return nil, fmt.Errorf("%w: informe as genéticas duplicadas", utils.ErrInvalidInput)
+	}
+	destino, err := s.buscarGenetica(id)
+	if err != nil {
+		return nil, err
+	}
+
+	vistas := make(map[uint]bool)
+	duplicadas := make([]entity.Genetica, 0, len(mesclagemDto.Duplicadas))
+	for _, duplicadaID := range mesclagemDto.Duplicadas {
+		if duplicadaID == id {
+			return nil, fmt.Errorf("%w: a genética não pode ser mesclada com ela mesma", utils.ErrInvalidInput)
+		}
+		if vistas[duplicadaID] {
+			return nil, fmt.Errorf("%w: genética %d repetida na lista", utils.ErrInvalidInput, duplicadaID)
+		}
+		vistas[duplicadaID] = true
+		duplicada, err := s.repositorio.BuscarPorID(duplicadaID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: genética %d não encontrada", utils.ErrInvalidInput, duplicadaID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		// a genética mantida herda o que só as duplicadas tinham preenchido
+		if destino.Descricao == "" {
+			destino.Descricao = duplicada.Descricao
+		}
+		if destino.Caracteristicas == "" {
+			destino.Caracteristicas = duplicada.Caracteristicas
+		}
+		if destino.TempoFloracao == 0 {
+			destino.TempoFloracao = duplicada.TempoFloracao
+		}
+		duplicadas = append(duplicadas, *duplicada)
+	}
+
+	// mesclar uma genética com a própria mãe, por exemplo, a deixaria como origem dela mesma
+	conhecidas, err := carregarAncestrais(s.repositorio, append([]entity.Genetica{*destino}, duplicadas...), profundidadeMaximaLinhagem)
+	if err != nil {
+		return nil, err
+	}
+	for _, duplicada := range duplicadas {
+		if ehAncestral(duplicada.ID, *destino, conhecidas) || ehAncestral(destino.ID, duplicada, conhecidas) {
+			return nil, fmt.Errorf("%w: as genéticas %d e %d são da mesma linhagem", utils.ErrInvalidInput, destino.ID, duplicada.ID)
+		}
+		if destino.MaeID == nil && destino.PaiID == nil && !origemEntre(duplicada, vistas) {
+			destino.MaeID, destino.PaiID = duplicada.MaeID, duplicada.PaiID
+		}
+		if destino.Geracao == "" {
+			destino.Geracao = duplicada.Geracao
+		}
+	}
+
+	plantas, pacotes, err := s.repositorio.Mesclar(destino, mesclagemDto.Duplicadas)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao mesclar genéticas na genética %d: %w", id, err)
+	}
+	return &dto.ResultadoMesclagemGeneticasDTO{
+		Genetica:       respostaGenetica(destino),
+		Removidas:      mesclagemDto.Duplicadas,
+		PlantasMovidas: plantas,
+		PacotesMovidos: pacotes,
+	}, nil
+}
+
+// origemEntre diz se a mãe ou o pai da genética está entre os IDs
+func origemEntre(genetica entity.Genetica, ids map[uint]bool) bool {
+	return (genetica.MaeID != nil && ids[*genetica.MaeID]) || (genetica.PaiID != nil && ids[*genetica.PaiID])
+}
+
+func (s *geneticaService) buscarGenetica(id uint) (*entity.Genetica, error) {
+	genetica, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar genética com ID %d: %w", id, err)
+	}
+	return genetica, nil
+}
+
+func respostaGenetica(genetica *entity.Genetica) dto.GeneticaResponseDTO {
+	return dto.GeneticaResponseDTO{
+		ID:              genetica.ID,
+		Nome:            genetica.Nome,
+		Descricao:       genetica.Descricao,
+		TipoGenetica:    genetica.TipoGenetica,
+		TipoEspecie:     genetica.TipoEspecie,
+		TempoFloracao:   genetica.TempoFloracao,
+		Origem:          genetica.Origem,
+		Caracteristicas: genetica.Caracteristicas,
+		MaeID:           genetica.MaeID,
+		PaiID:           genetica.PaiID,
+		Geracao:         genetica.Geracao,
+	}
+}
+
+// aliasesTipoGenetica aceita os nomes usados por bancos de sementes, já sem acentos
+var aliasesTipoGenetica = map[string]string{
+	"sativa":    "sativa",
+	"indica":    "indica",
+	"ruderalis": "ruderalis",
+	"hibrido":   "hibrido",
+	"hibrida":   "hibrido",
+	"hybrid":    "hibrido",
+}
+
+var aliasesTipoEspecie = map[string]string{
+	"regular":       "regular",
+	"feminizada":    "feminizada",
+	"feminizado":    "feminizada",
+	"feminized":     "feminizada",
+	"fem":           "feminizada",
+	"automatica":    "automatica",
+	"automatico":    "automatica",
+	"auto":          "automatica",
+	"autoflower":    "automatica",
+	"autoflowering": "automatica",
+}
+
+// normalizarGeneticaImportada limpa os campos e traduz os tipos para os valores do catálogo
+func normalizarGeneticaImportada(genetica *dto.CreateGeneticaDTO) error {
+	genetica.Nome = strings.TrimSpace(genetica.Nome)
+	genetica.Origem = strings.TrimSpace(genetica.Origem)
+	genetica.Descricao = strings.TrimSpace(genetica.Descricao)
+	genetica.Caracteristicas = strings.TrimSpace(genetica.Caracteristicas)
+	genetica.Plantas = nil
+
+	if genetica.Nome == "" {
+		return errors.New("nome obrigatório")
+	}
+	if utf8.RuneCountInString(genetica.Nome) > 100 {
+		return fmt.Errorf("nome %q passa de 100 caracteres", genetica.Nome)
+	}
+	if genetica.Origem == "" {
+		return fmt.Errorf("origem obrigatória para %q", genetica.Nome)
+	}
+	if utf8.RuneCountInString(genetica.Origem) > 100 {
+		return fmt.Errorf("origem de %q passa de 100 caracteres", genetica.Nome)
+	}
+	if genetica.TempoFloracao <= 0 {
+		return fmt.Errorf("tempo de floração de %q deve ser maior que zero", genetica.Nome)
+	}
+	tipoGenetica, ok := aliasesTipoGenetica[textoComparavel(genetica.TipoGenetica)]
+	if !ok {
+		return fmt.Errorf("tipo de genética %q desconhecido", genetica.TipoGenetica)
+	}
+	genetica.TipoGenetica = tipoGenetica
+	tipoEspecie, ok := aliasesTipoEspecie[textoComparavel(genetica.TipoEspecie)]
+	if !ok {
+		return fmt.Errorf("tipo de espécie %q desconhecido", genetica.TipoEspecie)
+	}
+	genetica.TipoEspecie = tipoEspecie
+	return nil
+}
+
+// geneticaParecida devolve o índice da genética da lista mais parecida com nome e origem, ou -1
+func geneticaParecida(geneticas []entity.Genetica, nome, origem string) (int, float64) {
+	melhor, maior := -1, 0.0
+	for i := range geneticas {
+		similaridade, ok := similaridadeGeneticas(nome, origem, geneticas[i].Nome, geneticas[i].Origem)
+		if ok && similaridade > maior {
+			melhor, maior = i, similaridade
+		}
+	}
+	return melhor, maior
+}
+
+// similaridadeGeneticas compara duas genéticas pelo nome e pelo breeder, ignorando caixa,
+// acentos, pontuação e palavras como "seeds" ou "genetics". Números no nome precisam ser
+// iguais, para "Skunk #1" e "Skunk #11" não serem confundidas. Sem breeder em um dos lados,
+// vale só o nome.
+func similaridadeGeneticas(nomeA, origemA, nomeB, origemB string) (float64, bool) {
+	a, b := textoComparavel(nomeA), textoComparavel(nomeB)
+	if a == "" || b == "" || apenasDigitos(a) != apenasDigitos(b) {
+		return 0, false
+	}
+	similaridade := proporcaoSemelhanca(strings.ReplaceAll(a, " ", ""), strings.ReplaceAll(b, " ", ""))
+	if similaridade < similaridadeMinimaNome {
+		return similaridade, false
+	}
+	origemA, origemB = chaveOrigem(origemA), chaveOrigem(origemB)
+	if origemA != "" && origemB != "" {
+		semelhancaOrigem := proporcaoSemelhanca(origemA, origemB)
+		if semelhancaOrigem < similaridadeMinimaOrigem {
+			return similaridade, false
+		}
+		similaridade = math.Min(similaridade, semelhancaOrigem)
+	}
+	return calculo.Arredondar(similaridade, 2), true
+}
+
+// palavrasGenericasOrigem aparecem no nome de muitos breeders e não ajudam a diferenciá-los
+var palavrasGenericasOrigem = map[string]bool{
+	"seeds": true, "seed": true, "sementes": true, "semente": true, "genetics": true,
+	"genetica": true, "geneticas": true, "bank": true, "seedbank": true, "company": true, "co": true,
+}
+
+func chaveOrigem(origem string) string {
+	palavras := strings.Fields(textoComparavel(origem))
+	var chave strings.Builder
+	for _, palavra := range palavras {
+		if !palavrasGenericasOrigem[palavra] {
+			chave.WriteString(palavra)
+		}
+	}
+	if chave.Len() == 0 {
+		return strings.Join(palavras, "")
+	}
+	return chave.String()
+}
+
+var semAcentos = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// textoComparavel deixa o texto em minúsculas, sem acentos e com letras e números separados
+// por um único espaço
+func textoComparavel(texto string) string {
+	texto = semAcentos.Replace(strings.ToLower(texto))
+	return strings.Join(strings.FieldsFunc(texto, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func apenasDigitos(texto string) string {
+	var digitos []string
+	for _, palavra := range strings.FieldsFunc(texto, func(r rune) bool { return !unicode.IsDigit(r) }) {
+		digitos = append(digitos, strings.TrimLeft(palavra, "0"))
+	}
+	return strings.Join(digitos, " ")
+}
+
+// proporcaoSemelhanca é 1 menos a distância de edição dividida pelo tamanho do texto maior
+func proporcaoSemelhanca(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	maior := max(len(ra), len(rb))
+	if maior == 0 {
+		return 1
+	}
+	anterior := make([]int, len(rb)+1)
+	atual := make([]int, len(rb)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		atual[0] = i
+		for j := 1; j <= len(rb); j++ {
+			custo := 1
+			if ra[i-1] == rb[j-1] {
+				custo = 0
+			}
+			atual[j] = min(anterior[j]+1, atual[j-1]+1, anterior[j-1]+custo)
+		}
+		anterior, atual = atual, anterior
+	}
+	return 1 - float64(anterior[len(rb)])/float64(maior)
+}
+
+// colunasGeneticas reconhece as colunas do CSV pelo nome; a coluna id da exportação é ignorada
+var colunasGeneticas = map[string][]string{
+	"nome":            {"nome", "name", "genetica", "genética", "strain"},
+	"origem":          {"origem", "breeder", "banco"},
+	"tipo_genetica":   {"tipo_genetica", "tipogenetica", "tipo"},
+	"tipo_especie":    {"tipo_especie", "tipoespecie", "especie", "espécie"},
+	"tempo_floracao":  {"tempo_floracao", "tempofloracao", "floracao", "floração", "floracao_dias"},
+	"descricao":       {"descricao", "descrição", "description"},
+	"caracteristicas": {"caracteristicas", "características"},
+}
+
+// lerGeneticasCSV converte um CSV com cabeçalho (nome, origem, tipo_genetica, tipo_especie,
+// tempo_floracao e, opcionalmente, descricao e caracteristicas) em registros de importação
+func lerGeneticasCSV(arquivo io.Reader, delimitador rune) ([]geneticaImportada, error) {
+	leitor := csv.NewReader(arquivo)
+	leitor.Comma = delimitador
+	leitor.FieldsPerRecord = -1
+	leitor.TrimLeadingSpace = true
+
+	cabecalho, err := leitor.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: arquivo CSV vazio", utils.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, erroLeituraCSV(err)
+	}
+	indices := make(map[string]int)
+	for i, nome := range cabecalho {
+		nome = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(nome, "\ufeff")))
+		for coluna, nomes := range colunasGeneticas {
+			for _, aceito := range nomes {
+				if _, usada := indices[coluna]; nome == aceito && !usada {
+					indices[coluna] = i
+				}
+			}
+		}
+	}
+	obrigatorias := []string{"nome", "origem", "tipo_genetica", "tipo_especie", "tempo_floracao"}
+	for _, coluna := range obrigatorias {
+		if _, ok := indices[coluna]; !ok {
+			return nil, fmt.Errorf("%w: coluna %s ausente no cabeçalho do CSV", utils.ErrInvalidInput, coluna)
+		}
+	}
+
+	celula := func(registro []string, coluna string) string {
+		indice, ok := indices[coluna]
+		if !ok || indice >= len(registro) {
+			return ""
+		}
+		return strings.TrimSpace(registro[indice])
+	}
+
+	var registros []geneticaImportada
+	for {
+		registro, err := leitor.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, erroLeituraCSV(err)
+		}
+		linha, _ := leitor.FieldPos(0)
+		if len(registro) == 1 && strings.TrimSpace(registro[0]) == "" {
+			continue
+		}
+		if len(registros) == maxGeneticasImportacao {
+			return nil, fmt.Errorf("%w: o CSV passa de %d linhas", utils.ErrInvalidInput, maxGeneticasImportacao)
+		}
+
+		floracao, err := strconv.Atoi(celula(registro, "tempo_floracao"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: linha %d: tempo de floração inválido", utils.ErrInvalidInput, linha)
+		}
+		registros = append(registros, geneticaImportada{
+			linha: linha,
+			genetica: dto.CreateGeneticaDTO{
+				Nome:            celula(registro, "nome"),
+				Descricao:       celula(registro, "descricao"),
+				TipoGenetica:    celula(registro, "tipo_genetica"),
+				TipoEspecie:     celula(registro, "tipo_especie"),
+				TempoFloracao:   floracao,
+				Origem:          celula(registro, "origem"),
+				Caracteristicas: celula(registro, "caracteristicas"),
+			},
+		})
+	}
+	return registros, nil
+}
@@ -0,0 +1,243 @@
+package service_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/database/seeds"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func geneticaCatalogo(id uint, nome, origem string) entity.Genetica {
+	genetica := entity.Genetica{Nome: nome, Origem: origem, TipoGenetica: "hibrido", TipoEspecie: "feminizada", TempoFloracao: 60}
+	genetica.ID = id
+	return genetica
+}
+
+func TestGeneticaService_ImportarJSON(t *testing.T) {
+	t.Run("ignora duplicadas do catálogo e do arquivo", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		catalogo := []entity.Genetica{
+			geneticaCatalogo(1, "Northern Lights", "Sensi Seeds"),
+			geneticaCatalogo(2, "Skunk #1", "Sensi Seeds"),
+		}
+		mockRepo.On("ListarCatalogo").Return(catalogo, nil)
+		var gravadas []entity.Genetica
+		mockRepo.On("CriarEmLote", mock.Anything).Run(func(args mock.Arguments) {
+			gravadas = args.Get(0).([]entity.Genetica)
+		}).Return(nil)
+
+		arquivo := `[
+			{"nome": "northern  lights", "origem": "Sensi Seed Bank", "tipoGenetica": "indica", "tipoEspecie": "feminizada", "tempoFloracao": 49},
+			{"nome": "Skunk #11", "origem": "Sensi Seeds", "tipoGenetica": "Hybrid", "tipoEspecie": "Feminized", "tempoFloracao": 56},
+			{"nome": " White Widow ", "origem": "Green House Seeds", "tipoGenetica": "híbrida", "tipoEspecie": "fem", "tempoFloracao": 60},
+			{"nome": "White-Widow", "origem": "Green House", "tipoGenetica": "hibrido", "tipoEspecie": "regular", "tempoFloracao": 60},
+			{"nome": "Northern Lights", "origem": "Dutch Passion", "tipoGenetica": "indica", "tipoEspecie": "autoflower", "tempoFloracao": 63}
+		]`
+		s := service.NewGeneticaService(mockRepo)
+		resultado, err := s.ImportarJSON(strings.NewReader(arquivo))
+
+		require.NoError(t, err)
+		require.Len(t, resultado.Criadas, 3)
+		assert.Equal(t, "Skunk #11", resultado.Criadas[0].Nome)
+		assert.Equal(t, "hibrido", resultado.Criadas[0].TipoGenetica)
+		assert.Equal(t, "feminizada", resultado.Criadas[0].TipoEspecie)
+		assert.Equal(t, "White Widow", resultado.Criadas[1].Nome)
+		assert.Equal(t, "Dutch Passion", resultado.Criadas[2].Origem)
+		assert.Equal(t, "automatica", resultado.Criadas[2].TipoEspecie)
+		assert.Len(t, gravadas, 3)
+
+		require.Len(t, resultado.Ignoradas, 2)
+		assert.Equal(t, 1, resultado.Ignoradas[0].Linha)
+		require.NotNil(t, resultado.Ignoradas[0].DuplicadaDeID)
+		assert.Equal(t, uint(1), *resultado.Ignoradas[0].DuplicadaDeID)
+		assert.Equal(t, 4, resultado.Ignoradas[1].Linha)
+		assert.Nil(t, resultado.Ignoradas[1].DuplicadaDeID)
+		assert.Contains(t, resultado.Ignoradas[1].Motivo, "registro 3")
+	})
+
+	t.Run("registro inválido rejeita o arquivo", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		arquivo := `[
+			{"nome": "Gelato", "origem": "Seed Junky", "tipoGenetica": "hibrido", "tipoEspecie": "feminizada", "tempoFloracao": 58},
+			{"nome": "Gelato 33", "origem": "Seed Junky", "tipoGenetica": "hibrido", "tipoEspecie": "clone", "tempoFloracao": 58}
+		]`
+		s := service.NewGeneticaService(mockRepo)
+		_, err := s.ImportarJSON(strings.NewReader(arquivo))
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "registro 2")
+		mockRepo.AssertNotCalled(t, "CriarEmLote", mock.Anything)
+	})
+
+	t.Run("JSON inválido", func(t *testing.T) {
+		s := service.NewGeneticaService(new(MockGeneticaRepositorio))
+		_, err := s.ImportarJSON(strings.NewReader(`{"nome": `))
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("catálogo inicial não tem duplicadas", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		mockRepo.On("ListarCatalogo").Return([]entity.Genetica{}, nil)
+		mockRepo.On("CriarEmLote", mock.Anything).Return(nil)
+
+		s := service.NewGeneticaService(mockRepo)
+		resultado, err := s.ImportarJSON(bytes.NewReader(seeds.GeneticasIniciais))
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, resultado.Criadas)
+		assert.Empty(t, resultado.Ignoradas)
+	})
+}
+
+func TestGeneticaService_ImportarCSV(t *testing.T) {
+	mockRepo := new(MockGeneticaRepositorio)
+	mockRepo.On("ListarCatalogo").Return([]entity.Genetica{}, nil)
+	mockRepo.On("CriarEmLote", mock.Anything).Return(nil)
+
+	arquivo := "\ufeffName;Breeder;Tipo;Especie;Floracao_dias;Descricao\n" +
+		"Amnesia Haze;Royal Queen Seeds;sativa;feminizada;70;Haze de alta produção\n" +
+		"\n" +
+		"Critical;Royal Queen Seeds;indica;auto;50;\n"
+	s := service.NewGeneticaService(mockRepo)
+	resultado, err := s.ImportarCSV(strings.NewReader(arquivo), &dto.ImportacaoGeneticasCSVDTO{Delimitador: "ponto_virgula"})
+
+	require.NoError(t, err)
+	require.Len(t, resultado.Criadas, 2)
+	assert.Equal(t, "Amnesia Haze", resultado.Criadas[0].Nome)
+	assert.Equal(t, "Royal Queen Seeds", resultado.Criadas[0].Origem)
+	assert.Equal(t, 70, resultado.Criadas[0].TempoFloracao)
+	assert.Equal(t, "Haze de alta produção", resultado.Criadas[0].Descricao)
+	assert.Equal(t, "automatica", resultado.Criadas[1].TipoEspecie)
+
+	t.Run("coluna obrigatória ausente", func(t *testing.T) {
+		_, err := s.ImportarCSV(strings.NewReader("nome,origem\nGelato,Seed Junky\n"), &dto.ImportacaoGeneticasCSVDTO{})
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("floração inválida informa a linha", func(t *testing.T) {
+		arquivo := "nome,origem,tipo_genetica,tipo_especie,tempo_floracao\nGelato,Seed Junky,hibrido,feminizada,oito semanas\n"
+		_, err := s.ImportarCSV(strings.NewReader(arquivo), &dto.ImportacaoGeneticasCSVDTO{})
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "linha 2")
+	})
+}
+
+func TestGeneticaService_ExportarCSV(t *testing.T) {
+	mockRepo := new(MockGeneticaRepositorio)
+	genetica := geneticaCatalogo(7, "Gelato", "Seed Junky Genetics")
+	genetica.Descricao = "Sunset Sherbet, Thin Mint"
+	mockRepo.On("ListarCatalogo").Return([]entity.Genetica{genetica}, nil)
+
+	s := service.NewGeneticaService(mockRepo)
+	conteudo, err := s.ExportarCSV()
+	require.NoError(t, err)
+
+	registros, err := csv.NewReader(bytes.NewReader(conteudo)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, registros, 2)
+	assert.Equal(t, []string{"7", "Gelato", "Seed Junky Genetics", "hibrido", "feminizada", "60", "Sunset Sherbet, Thin Mint", ""}, registros[1])
+
+	// o arquivo exportado volta sem criar nada, já que tudo existe no catálogo
+	mockRepo.On("CriarEmLote", mock.Anything).Return(nil)
+	resultado, err := s.ImportarCSV(bytes.NewReader(conteudo), &dto.ImportacaoGeneticasCSVDTO{})
+	require.NoError(t, err)
+	assert.Empty(t, resultado.Criadas)
+	assert.Len(t, resultado.Ignoradas, 1)
+}
+
+func TestGeneticaService_BuscarDuplicadas(t *testing.T) {
+	mockRepo := new(MockGeneticaRepositorio)
+	mockRepo.On("ListarCatalogo").Return([]entity.Genetica{
+		geneticaCatalogo(3, "Blue Dream", "Humboldt Seed Company"),
+		geneticaCatalogo(1, "Gorilla Glue #4", "GG Strains"),
+		geneticaCatalogo(5, "Gorila Glue 4", "GG Strains"),
+		geneticaCatalogo(8, "Gorilla Glue #4", ""),
+		geneticaCatalogo(4, "Gorilla Glue #5", "GG Strains"),
+		geneticaCatalogo(9, "Blue Dream", "Humboldt Seeds"),
+		geneticaCatalogo(6, "Blue Dream", "Barney's Farm"),
+	}, nil)
+
+	s := service.NewGeneticaService(mockRepo)
+	grupos, err := s.BuscarDuplicadas()
+
+	require.NoError(t, err)
+	require.Len(t, grupos, 2)
+	assert.Equal(t, []uint{3, 9}, idsGeneticas(grupos[0].Geneticas))
+	assert.Equal(t, []uint{1, 5, 8}, idsGeneticas(grupos[1].Geneticas))
+	assert.Less(t, grupos[1].Similaridade, 1.0)
+	assert.GreaterOrEqual(t, grupos[1].Similaridade, 0.85)
+}
+
+func idsGeneticas(geneticas []dto.GeneticaResponseDTO) []uint {
+	ids := make([]uint, 0, len(geneticas))
+	for _, genetica := range geneticas {
+		ids = append(ids, genetica.ID)
+	}
+	return ids
+}
+
+func TestGeneticaService_Mesclar(t *testing.T) {
+	t.Run("sucesso", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		destino := geneticaCatalogo(1, "Gorilla Glue #4", "GG Strains")
+		duplicada := geneticaCatalogo(5, "Gorila Glue 4", "GG Strains")
+		duplicada.Descricao = "Muito resinosa"
+		mockRepo.On("BuscarPorID", uint(1)).Return(&destino, nil)
+		mockRepo.On("BuscarPorID", uint(5)).Return(&duplicada, nil)
+		mockRepo.On("Mesclar", mock.MatchedBy(func(g *entity.Genetica) bool {
+			return g.ID == 1 && g.Descricao == "Muito resinosa"
+		}), []uint{5}).Return(int64(3), int64(1), nil)
+
+		s := service.NewGeneticaService(mockRepo)
+		resultado, err := s.Mesclar(1, &dto.MesclagemGeneticasDTO{Duplicadas: []uint{5}})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), resultado.Genetica.ID)
+		assert.Equal(t, "Muito resinosa", resultado.Genetica.Descricao)
+		assert.Equal(t, []uint{5}, resultado.Removidas)
+		assert.Equal(t, int64(3), resultado.PlantasMovidas)
+		assert.Equal(t, int64(1), resultado.PacotesMovidos)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("genética mantida não encontrada", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		mockRepo.On("BuscarPorID", uint(1)).Return((*entity.Genetica)(nil), gorm.ErrRecordNotFound)
+
+		s := service.NewGeneticaService(mockRepo)
+		_, err := s.Mesclar(1, &dto.MesclagemGeneticasDTO{Duplicadas: []uint{5}})
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+
+	t.Run("duplicada inexistente", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		destino := geneticaCatalogo(1, "Gorilla Glue #4", "GG Strains")
+		mockRepo.On("BuscarPorID", uint(1)).Return(&destino, nil)
+		mockRepo.On("BuscarPorID", uint(5)).Return((*entity.Genetica)(nil), gorm.ErrRecordNotFound)
+
+		s := service.NewGeneticaService(mockRepo)
+		_, err := s.Mesclar(1, &dto.MesclagemGeneticasDTO{Duplicadas: []uint{5}})
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Mesclar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("mesclar com ela mesma", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		destino := geneticaCatalogo(1, "Gorilla Glue #4", "GG Strains")
+		mockRepo.On("BuscarPorID", uint(1)).Return(&destino, nil)
+
+		s := service.NewGeneticaService(mockRepo)
+		_, err := s.Mesclar(1, &dto.MesclagemGeneticasDTO{Duplicadas: []uint{1}})
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+)
+
+const (
+	// profundidadePedigreePadrao é quantas gerações de ancestrais a árvore mostra sem parâmetro
+	profundidadePedigreePadrao = 5
+	// profundidadeMaximaLinhagem limita a busca de ancestrais ao validar a linhagem
+	profundidadeMaximaLinhagem = 50
+)
+
+// formatoGeracao aceita gerações filiais (F1, F2...), retrocruzamentos (BX1, BX2...) e
+// autocruzamentos (S1, S2...)
+var formatoGeracao = regexp.MustCompile(`^(F|BX|S)([1-9][0-9]?)$`)
+
+// normalizarGeracao valida a geração informada na genética; além das gerações de cruzamento,
+// aceita IBL para linhagens estabilizadas
+func normalizarGeracao(valor string) (string, error) {
+	geracao := strings.ToUpper(strings.TrimSpace(valor))
+	if geracao == "" || geracao == "IBL" || formatoGeracao.MatchString(geracao) {
+		return geracao, nil
+	}
+	return "", fmt.Errorf("%w: geração %q inválida; use F1, F2, BX1, S1, IBL...", utils.ErrInvalidInput, valor)
+}
+
+// geracaoFilial separa a geração em tipo e número: BX2 vira ("BX", 2)
+func geracaoFilial(geracao string) (string, int, bool) {
+	partes := formatoGeracao.FindStringSubmatch(geracao)
+	if partes == nil {
+		return "", 0, false
+	}
+	numero, _ := strconv.Atoi(partes[2])
+	return partes[1], numero, true
+}
+
+// origemInformada trata zero como remoção da genética de origem
+func origemInformada(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+func (s *geneticaService) Pedigree(id uint, consulta *dto.ConsultaPedigreeDTO) (*dto.PedigreeGeneticaDTO, error) {
+	profundidade := profundidadePedigreePadrao
+	if consulta != nil && consulta.Profundidade > 0 {
+		profundidade = consulta.Profundidade
+	}
+	raiz, err := s.buscarGenetica(id)
+	if err != nil {
+		return nil, err
+	}
+	conhecidas, err := carregarAncestrais(s.repositorio, []entity.Genetica{*raiz}, profundidade)
+	if err != nil {
+		return nil, err
+	}
+	return montarPedigree(conhecidas, id, profundidade, make(map[uint]bool)), nil
+}
+
+// montarPedigree monta a árvore a partir das genéticas carregadas; uma genética que reaparece
+// entre os próprios ancestrais (dado inconsistente) não é expandida de novo
+func montarPedigree(conhecidas map[uint]entity.Genetica, id uint, restantes int, caminho map[uint]bool) *dto.PedigreeGeneticaDTO {
+	genetica, ok := conhecidas[id]
+	if !ok {
+		return nil
+	}
+	no := &dto.PedigreeGeneticaDTO{
+		ID:           genetica.ID,
+		Nome:         genetica.Nome,
+		Origem:       genetica.Origem,
+		TipoGenetica: genetica.TipoGenetica,
+		Geracao:      genetica.Geracao,
+	}
+	if restantes == 0 || caminho[id] {
+		return no
+	}
+	caminho[id] = true
+	if genetica.MaeID != nil {
+		no.Mae = montarPedigree(conhecidas, *genetica.MaeID, restantes-1, caminho)
+	}
+	if genetica.PaiID != nil {
+		no.Pai = montarPedigree(conhecidas, *genetica.PaiID, restantes-1, caminho)
+	}
+	delete(caminho, id)
+	return no
+}
+
+// carregarAncestrais busca, uma geração por vez, as genéticas de origem das genéticas
+// informadas, até o número de gerações pedido. O mapa inclui as próprias genéticas informadas.
+func carregarAncestrais(repositorio repository.GeneticaRepositorio, geneticas []entity.Genetica, geracoes int) (map[uint]entity.Genetica, error) {
+	conhecidas := make(map[uint]entity.Genetica, len(geneticas))
+	for _, genetica := range geneticas {
+		conhecidas[genetica.ID] = genetica
+	}
+	nivel := geneticas
+	for ; geracoes > 0 && len(nivel) > 0; geracoes-- {
+		var pendentes []uint
+		pedidas := make(map[uint]bool)
+		for _, genetica := range nivel {
+			for _, origem := range []*uint{genetica.MaeID, genetica.PaiID} {
+				if origem == nil || pedidas[*origem] {
+					continue
+				}
+				if _, carregada := conhecidas[*origem]; !carregada {
+					pendentes = append(pendentes, *origem)
+					pedidas[*origem] = true
+				}
+			}
+		}
+		if len(pendentes) == 0 {
+			break
+		}
+		encontradas, err := repositorio.BuscarPorIDs(pendentes)
+		if err != nil {
+			return nil, err
+		}
+		for _, genetica := range encontradas {
+			conhecidas[genetica.ID] = genetica
+		}
+		nivel = encontradas
+	}
+	return conhecidas, nil
+}
+
+// ehAncestral diz se ancestralID aparece na linhagem carregada da genética
+func ehAncestral(ancestralID uint, genetica entity.Genetica, conhecidas map[uint]entity.Genetica) bool {
+	visitadas := make(map[uint]bool)
+	pendentes := []entity.Genetica{genetica}
+	for len(pendentes) > 0 {
+		atual := pendentes[len(pendentes)-1]
+		pendentes = pendentes[:len(pendentes)-1]
+		for _, origem := range []*uint{atual.MaeID, atual.PaiID} {
+			if origem == nil || visitadas[*origem] {
+				continue
+			}
+			if *origem == ancestralID {
+				return true
+			}
+			visitadas[*origem] = true
+			if pai, ok := conhecidas[*origem]; ok {
+				pendentes = append(pendentes, pai)
+			}
+		}
+	}
+	return false
+}
+
+// validarOrigem confere se a mãe e o pai existem e, para uma genética já cadastrada, se ela não
+// passaria a ser ancestral dela mesma
+func (s *geneticaService) validarOrigem(id uint, maeID, paiID *uint) error {
+	var ids []uint
+	for _, origem := range []*uint{maeID, paiID} {
+		if origem != nil {
+			ids = append(ids, *origem)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	origens, err := s.repositorio.BuscarPorIDs(ids)
+	if err != nil {
+		return err
+	}
+	encontradas := make(map[uint]bool, len(origens))
+	for _, origem := range origens {
+		encontradas[origem.ID] = true
+	}
+	for _, origemID := range ids {
+		if id != 0 && origemID == id {
+			return fmt.Errorf("%w: a genética não pode ser mãe ou pai dela mesma", utils.ErrInvalidInput)
+		}
+		if !encontradas[origemID] {
+			return fmt.Errorf("%w: genética %d não encontrada", utils.ErrInvalidInput, origemID)
+		}
+	}
+	if id == 0 {
+		return nil
+	}
+	conhecidas, err := carregarAncestrais(s.repositorio, origens, profundidadeMaximaLinhagem)
+	if err != nil {
+		return err
+	}
+	for _, origem := range origens {
+		if ehAncestral(id, origem, conhecidas) {
+			return fmt.Errorf("%w: a genética %d descende da genética %d", utils.ErrInvalidInput, origem.ID, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneticaService_Pedigree(t *testing.T) {
+	// BX1 (30) = F1 (10) x Gelato (1); F1 = Gelato x Skunk (2)
+	gelato := geneticaLinhagem(1, "Gelato", "indica", "", nil, nil)
+	skunk := geneticaLinhagem(2, "Skunk #1", "sativa", "", nil, nil)
+	f1 := geneticaLinhagem(10, "Gelato x Skunk", "hibrido", "F1", ref(1), ref(2))
+	bx1 := geneticaLinhagem(30, "Gelato BX1", "hibrido", "BX1", ref(10), ref(1))
+
+	t.Run("Success - Monta a Árvore de Ancestrais", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		mockRepo.On("BuscarPorID", uint(30)).Return(bx1, nil).Once()
+		mockRepo.On("BuscarPorIDs", []uint{10, 1}).Return([]entity.Genetica{*f1, *gelato}, nil).Once()
+		mockRepo.On("BuscarPorIDs", []uint{2}).Return([]entity.Genetica{*skunk}, nil).Once()
+
+		s := service.NewGeneticaService(mockRepo)
+		pedigree, err := s.Pedigree(30, &dto.ConsultaPedigreeDTO{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "BX1", pedigree.Geracao)
+		require.NotNil(t, pedigree.Mae)
+		assert.Equal(t, uint(10), pedigree.Mae.ID)
+		require.NotNil(t, pedigree.Mae.Pai)
+		assert.Equal(t, "Skunk #1", pedigree.Mae.Pai.Nome)
+		assert.Equal(t, uint(1), pedigree.Mae.Mae.ID)
+		assert.Equal(t, uint(1), pedigree.Pai.ID)
+		assert.Nil(t, pedigree.Pai.Mae)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Respeita a Profundidade", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		mockRepo.On("BuscarPorID", uint(30)).Return(bx1, nil).Once()
+		mockRepo.On("BuscarPorIDs", []uint{10, 1}).Return([]entity.Genetica{*f1, *gelato}, nil).Once()
+
+		s := service.NewGeneticaService(mockRepo)
+		pedigree, err := s.Pedigree(30, &dto.ConsultaPedigreeDTO{Profundidade: 1})
+
+		require.NoError(t, err)
+		require.NotNil(t, pedigree.Mae)
+		assert.Nil(t, pedigree.Mae.Mae)
+		mockRepo.AssertNumberOfCalls(t, "BuscarPorIDs", 1)
+	})
+
+	t.Run("Error - Genética Não Encontrada", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		mockRepo.On("BuscarPorID", uint(99)).Return((*entity.Genetica)(nil), utils.ErrNotFound)
+
+		s := service.NewGeneticaService(mockRepo)
+		_, err := s.Pedigree(99, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestGeneticaService_AtualizarLinhagem(t *testing.T) {
+	t.Run("Error - Mãe Que Descende da Própria Genética", func(t *testing.T) {
+		// a genética 1 é avó da 30; torná-la filha da 30 fecharia um ciclo
+		mockRepo := new(MockGeneticaRepositorio)
+		gelato := geneticaLinhagem(1, "Gelato", "indica", "", nil, nil)
+		f1 := geneticaLinhagem(10, "Gelato x Skunk", "hibrido", "F1", ref(1), ref(2))
+		bx1 := geneticaLinhagem(30, "Gelato BX1", "hibrido", "BX1", ref(10), ref(1))
+		mockRepo.On("BuscarPorID", uint(1)).Return(gelato, nil).Once()
+		mockRepo.On("BuscarPorIDs", []uint{30}).Return([]entity.Genetica{*bx1}, nil).Once()
+		mockRepo.On("BuscarPorIDs", []uint{10, 1}).Return([]entity.Genetica{*f1, *gelato}, nil).Once()
+		mockRepo.On("BuscarPorIDs", []uint{2}).Return([]entity.Genetica{}, nil).Once()
+
+		s := service.NewGeneticaService(mockRepo)
+		_, err := s.Atualizar(1, &dto.UpdateGeneticaDTO{MaeID: ref(30)})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Atualizar", mock.Anything)
+	})
+
+	t.Run("Success - Define Origem e Geração", func(t *testing.T) {
+		mockRepo := new(MockGeneticaRepositorio)
+		mockRepo.On("BuscarPorID", uint(10)).Return(geneticaLinhagem(10, "Gelato x Skunk", "hibrido", "", nil, nil), nil).Once()
+		mockRepo.On("BuscarPorIDs", []uint{1, 2}).Return([]entity.Genetica{
+			*geneticaLinhagem(1, "Gelato", "indica", "", nil, nil),
+			*geneticaLinhagem(2, "Skunk #1", "sativa", "", nil, nil),
+		}, nil).Once()
+		mockRepo.On("Atualizar", mock.AnythingOfType("*entity.Genetica")).Return(nil).Once()
+
+		s := service.NewGeneticaService(mockRepo)
+		genetica, err := s.Atualizar(10, &dto.UpdateGeneticaDTO{MaeID: ref(1), PaiID: ref(2), Geracao: "f1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, ref(1), genetica.MaeID)
+		assert.Equal(t, ref(2), genetica.PaiID)
+		assert.Equal(t, "F1", genetica.Geracao)
+	})
+
+	t.Run("Error - Geração Inválida", func(t *testing.T) {
+		s := service.NewGeneticaService(new(MockGeneticaRepositorio))
+		_, err := s.Criar(&dto.CreateGeneticaDTO{Nome: "Gelato", Geracao: "F0"})
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
@@ -42,6 +42,26 @@ func (m *MockGeneticaRepositorio) Deletar(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockGeneticaRepositorio) BuscarPorIDs(ids []uint) ([]entity.Genetica, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]entity.Genetica), args.Error(1)
+}
+
+func (m *MockGeneticaRepositorio) ListarCatalogo() ([]entity.Genetica, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.Genetica), args.Error(1)
+}
+
+func (m *MockGeneticaRepositorio) CriarEmLote(geneticas []entity.Genetica) error {
+	args := m.Called(geneticas)
+	return args.Error(0)
+}
+
+func (m *MockGeneticaRepositorio) Mesclar(destino *entity.Genetica, duplicadas []uint) (int64, int64, error) {
+	args := m.Called(destino, duplicadas)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
 func TestGeneticaService_Criar(t *testing.T) {
 	mockRepo := new(MockGeneticaRepositorio)
 	service := service.NewGeneticaService(mockRepo)
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/desenho"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// gradePadraoCm é o espaçamento da grade da planta baixa quando a consulta não informa
+const gradePadraoCm = 30
+
+// LayoutService posiciona plantas e vasos na planta baixa dos ambientes e calcula a ocupação
+// da área de cultivo.
+type LayoutService interface {
+	// Posicionar valida que o item cabe no ambiente e não sobrepõe os demais
+	Posicionar(ambienteID uint, posicaoDto *dto.PosicaoLayoutDTO) (*entity.PosicaoLayout, error)
+	Atualizar(id uint, posicaoDto *dto.PosicaoLayoutDTO) (*entity.PosicaoLayout, error)
+	Remover(id uint) error
+	Ocupacao(ambienteID uint) (*dto.OcupacaoLayoutDTO, error)
+	// PlantaBaixaSVG desenha o ambiente visto de cima com os itens posicionados
+	PlantaBaixaSVG(ambienteID uint, consulta *dto.ConsultaPlantaBaixaDTO) ([]byte, error)
+}
+
+type layoutService struct {
+	repositorio         repository.PosicaoLayoutRepositorio
+	ambienteRepositorio repository.AmbienteRepositorio
+	plantaRepositorio   repository.PlantaRepositorio
+	vasoRepositorio     repository.VasoRepositorio
+}
+
+// NewLayoutService cria o serviço de layout dos ambientes.
+func NewLayoutService(
+	repositorio repository.PosicaoLayoutRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	vasoRepositorio repository.VasoRepositorio,
+) LayoutService {
+	return &layoutService{
+		repositorio:         repositorio,
+		ambienteRepositorio: ambienteRepositorio,
+		plantaRepositorio:   plantaRepositorio,
+		vasoRepositorio:     vasoRepositorio,
+	}
+}
+
+func (s *layoutService) Posicionar(ambienteID uint, posicaoDto *dto.PosicaoLayoutDTO) (*entity.PosicaoLayout, error) {
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	posicao := entity.PosicaoLayout{AmbienteID: ambienteID}
+	if err := s.aplicarPosicao(ambiente, &posicao, posicaoDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Criar(&posicao); err != nil {
+		return nil, fmt.Errorf("falha ao posicionar item no ambiente %d: %w", ambienteID, err)
+	}
+	return &posicao, nil
+}
+
+func (s *layoutService) Atualizar(id uint, posicaoDto *dto.PosicaoLayoutDTO) (*entity.PosicaoLayout, error) {
+	posicao, err := s.buscarPosicao(id)
+	if err != nil {
+		return nil, err
+	}
+	ambiente, err := s.buscarAmbiente(posicao.AmbienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.aplicarPosicao(ambiente, posicao, posicaoDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Atualizar(posicao); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar posição com ID %d: %w", id, err)
+	}
+	return posicao, nil
+}
+
+func (s *layoutService) Remover(id uint) error {
+	if id == 0 {
+		return utils.ErrInvalidInput
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao remover posição com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *layoutService) Ocupacao(ambienteID uint) (*dto.OcupacaoLayoutDTO, error) {
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	posicoes, err := s.repositorio.ListarPorAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	// cm² → m²
+	areaTotal := ambiente.Comprimento * ambiente.Largura / 10000
+	ocupacao := &dto.OcupacaoLayoutDTO{
+		AmbienteID:    ambienteID,
+		ComprimentoCm: ambiente.Comprimento,
+		LarguraCm:     ambiente.Largura,
+		AreaTotalM2:   calculo.Arredondar(areaTotal, 4),
+		Itens:         posicoes,
+	}
+	var areaOcupada float64
+	for _, posicao := range posicoes {
+		areaOcupada += posicao.Area() / 10000
+		if posicao.PlantaID != nil {
+			ocupacao.Plantas++
+		}
+	}
+	ocupacao.AreaOcupadaM2 = calculo.Arredondar(areaOcupada, 4)
+	ocupacao.AreaLivreM2 = calculo.Arredondar(areaTotal-areaOcupada, 4)
+	if areaTotal > 0 {
+		ocupacao.PercentualOcupado = calculo.Arredondar(areaOcupada/areaTotal*100, 1)
+		ocupacao.PlantasPorM2 = calculo.Arredondar(float64(ocupacao.Plantas)/areaTotal, 2)
+	}
+	return ocupacao, nil
+}
+
+func (s *layoutService) PlantaBaixaSVG(ambienteID uint, consulta *dto.ConsultaPlantaBaixaDTO) ([]byte, error) {
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	if ambiente.Comprimento <= 0 || ambiente.Largura <= 0 {
+		return nil, fmt.Errorf("%w: ambiente sem dimensões", utils.ErrInvalidInput)
+	}
+	posicoes, err := s.repositorio.ListarPorAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	grade := float64(gradePadraoCm)
+	if consulta != nil && consulta.GradeCm > 0 {
+		grade = consulta.GradeCm
+	}
+	plantaBaixa := desenho.PlantaBaixa{
+		Titulo:      fmt.Sprintf("%s (%s x %s cm)", ambiente.Nome, formatarValor(ambiente.Comprimento), formatarValor(ambiente.Largura)),
+		Comprimento: ambiente.Comprimento,
+		Largura:     ambiente.Largura,
+		GradeCm:     grade,
+	}
+	for _, posicao := range posicoes {
+		plantaBaixa.Itens = append(plantaBaixa.Itens, desenho.ItemPlantaBaixa{
+			Rotulo:       rotuloPosicao(posicao),
+			X:            posicao.X,
+			Y:            posicao.Y,
+			Circular:     posicao.Formato == entity.FormatoCircular,
+			Largura:      posicao.LarguraCm,
+			Profundidade: posicao.ProfundidadeCm,
+			Planta:       posicao.PlantaID != nil,
+		})
+	}
+	return plantaBaixa.SVG(), nil
+}
+
+// aplicarPosicao preenche a posição com os dados do DTO e valida planta, vaso, limites do
+// ambiente e sobreposição com os itens já posicionados.
+func (s *layoutService) aplicarPosicao(ambiente *entity.Ambiente, posicao *entity.PosicaoLayout, posicaoDto *dto.PosicaoLayoutDTO) error {
+	if posicaoDto == nil {
+		return utils.ErrInvalidInput
+	}
+	if ambiente.Comprimento <= 0 || ambiente.Largura <= 0 {
+		return fmt.Errorf("%w: ambiente sem dimensões", utils.ErrInvalidInput)
+	}
+
+	if posicaoDto.PlantaID != nil {
+		planta, err := s.plantaRepositorio.BuscarPorID(*posicaoDto.PlantaID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: planta %d não encontrada", utils.ErrInvalidInput, *posicaoDto.PlantaID)
+			}
+			return fmt.Errorf("falha ao buscar planta com ID %d: %w", *posicaoDto.PlantaID, err)
+		}
+		if planta.AmbienteID != ambiente.ID {
+			return fmt.Errorf("%w: planta %d está em outro ambiente", utils.ErrInvalidInput, planta.ID)
+		}
+	}
+
+	var vaso *entity.Vaso
+	if posicaoDto.VasoID != nil {
+		var err error
+		if vaso, err = s.vasoRepositorio.BuscarPorID(*posicaoDto.VasoID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: vaso %d não encontrado", utils.ErrInvalidInput, *posicaoDto.VasoID)
+			}
+			return fmt.Errorf("falha ao buscar vaso com ID %d: %w", *posicaoDto.VasoID, err)
+		}
+	}
+
+	formato := entity.FormatoPosicao(posicaoDto.Formato)
+	if formato == "" {
+		formato = entity.FormatoRetangular
+		if vaso != nil {
+			formato = entity.FormatoCircular
+		}
+	}
+	largura := posicaoDto.LarguraCm
+	if largura == 0 && vaso != nil {
+		largura = vaso.Diametro
+	}
+	if largura <= 0 {
+		return fmt.Errorf("%w: informe largura_cm ou um vaso com diâmetro", utils.ErrInvalidInput)
+	}
+	profundidade := posicaoDto.ProfundidadeCm
+	if formato == entity.FormatoCircular || profundidade == 0 {
+		profundidade = largura
+	}
+
+	posicao.PlantaID = posicaoDto.PlantaID
+	posicao.VasoID = posicaoDto.VasoID
+	posicao.Rotulo = posicaoDto.Rotulo
+	posicao.X = posicaoDto.X
+	posicao.Y = posicaoDto.Y
+	posicao.Formato = formato
+	posicao.LarguraCm = largura
+	posicao.ProfundidadeCm = profundidade
+
+	if !posicao.CabeEm(ambiente.Comprimento, ambiente.Largura) {
+		return fmt.Errorf("%w: item não cabe no ambiente (%s x %s cm)", utils.ErrInvalidInput,
+			formatarValor(ambiente.Comprimento), formatarValor(ambiente.Largura))
+	}
+
+	outras, err := s.repositorio.ListarPorAmbiente(ambiente.ID)
+	if err != nil {
+		return err
+	}
+	for _, outra := range outras {
+		if outra.ID == posicao.ID {
+			continue
+		}
+		if posicao.PlantaID != nil && outra.PlantaID != nil && *outra.PlantaID == *posicao.PlantaID {
+			return fmt.Errorf("%w: planta %d já está posicionada", utils.ErrInvalidInput, *posicao.PlantaID)
+		}
+		if posicao.Sobrepoe(outra) {
+			return fmt.Errorf("%w: item sobrepõe %q", utils.ErrInvalidInput, rotuloPosicao(outra))
+		}
+	}
+	return nil
+}
+
+func (s *layoutService) buscarAmbiente(ambienteID uint) (*entity.Ambiente, error) {
+	if ambienteID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	ambiente, err := s.ambienteRepositorio.BuscarPorID(ambienteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar ambiente com ID %d: %w", ambienteID, err)
+	}
+	return ambiente, nil
+}
+
+func (s *layoutService) buscarPosicao(id uint) (*entity.PosicaoLayout, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	posicao, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar posição com ID %d: %w", id, err)
+	}
+	return posicao, nil
+}
+
+// rotuloPosicao identifica o item na planta baixa: rótulo, nome da planta, nome do vaso ou ID
+func rotuloPosicao(posicao entity.PosicaoLayout) string {
+	switch {
+	case posicao.Rotulo != "":
+		return posicao.Rotulo
+	case posicao.Planta != nil:
+		return posicao.Planta.Nome
+	case posicao.Vaso != nil:
+		return posicao.Vaso.Nome
+	default:
+		return fmt.Sprintf("#%d", posicao.ID)
+	}
+}
@@ -0,0 +1,184 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mocksLayout struct {
+	repositorio  *test.MockPosicaoLayoutRepositorio
+	ambienteRepo *test.MockAmbienteRepositorio
+	plantaRepo   *test.MockPlantaRepositorio
+	vasoRepo     *test.MockVasoRepositorio
+}
+
+func novoLayoutService() (service.LayoutService, *mocksLayout) {
+	m := &mocksLayout{
+		repositorio:  new(test.MockPosicaoLayoutRepositorio),
+		ambienteRepo: new(test.MockAmbienteRepositorio),
+		plantaRepo:   new(test.MockPlantaRepositorio),
+		vasoRepo:     new(test.MockVasoRepositorio),
+	}
+	return service.NewLayoutService(m.repositorio, m.ambienteRepo, m.plantaRepo, m.vasoRepo), m
+}
+
+// tenda120 é uma tenda de 120 x 120 cm
+func tenda120() *entity.Ambiente {
+	ambiente := &entity.Ambiente{Nome: "Tenda", Comprimento: 120, Largura: 120, Altura: 200}
+	ambiente.ID = 1
+	return ambiente
+}
+
+func posicaoCircular(id uint, x, y, diametro float64) entity.PosicaoLayout {
+	posicao := entity.PosicaoLayout{AmbienteID: 1, X: x, Y: y, Formato: entity.FormatoCircular, LarguraCm: diametro, ProfundidadeCm: diametro}
+	posicao.ID = id
+	return posicao
+}
+
+func TestPosicaoLayout_Sobrepoe(t *testing.T) {
+	a := posicaoCircular(1, 15, 15, 30)
+	assert.False(t, a.Sobrepoe(posicaoCircular(2, 45, 15, 30)), "vasos encostados não se sobrepõem")
+	assert.True(t, a.Sobrepoe(posicaoCircular(2, 40, 15, 30)))
+
+	retangulo := entity.PosicaoLayout{X: 60, Y: 15, Formato: entity.FormatoRetangular, LarguraCm: 30, ProfundidadeCm: 30}
+	assert.False(t, a.Sobrepoe(retangulo))
+	retangulo.X = 40
+	assert.True(t, a.Sobrepoe(retangulo))
+	assert.True(t, retangulo.Sobrepoe(a))
+
+	// o canto do quadrado fica fora do círculo na diagonal
+	canto := entity.PosicaoLayout{X: 42, Y: 42, Formato: entity.FormatoRetangular, LarguraCm: 20, ProfundidadeCm: 20}
+	assert.False(t, a.Sobrepoe(canto))
+}
+
+func TestLayoutService_Posicionar(t *testing.T) {
+	t.Run("Success - Tamanho do Vaso", func(t *testing.T) {
+		servico, m := novoLayoutService()
+		plantaID, vasoID := uint(3), uint(4)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+		m.plantaRepo.On("BuscarPorID", plantaID).Return(&entity.Planta{AmbienteID: 1}, nil).Once()
+		m.vasoRepo.On("BuscarPorID", vasoID).Return(&entity.Vaso{Nome: "11 L", Diametro: 28}, nil).Once()
+		m.repositorio.On("ListarPorAmbiente", uint(1)).Return([]entity.PosicaoLayout{posicaoCircular(9, 15, 15, 30)}, nil).Once()
+		m.repositorio.On("Criar", mock.AnythingOfType("*entity.PosicaoLayout")).Return(nil).Once()
+
+		posicao, err := servico.Posicionar(1, &dto.PosicaoLayoutDTO{PlantaID: &plantaID, VasoID: &vasoID, X: 60, Y: 60})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.FormatoCircular, posicao.Formato)
+		assert.Equal(t, 28.0, posicao.LarguraCm)
+		assert.Equal(t, 28.0, posicao.ProfundidadeCm)
+		m.repositorio.AssertExpectations(t)
+	})
+
+	t.Run("Error - Fora do Ambiente", func(t *testing.T) {
+		servico, m := novoLayoutService()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+
+		_, err := servico.Posicionar(1, &dto.PosicaoLayoutDTO{X: 110, Y: 60, LarguraCm: 30})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.repositorio.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Sobreposição", func(t *testing.T) {
+		servico, m := novoLayoutService()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+		m.repositorio.On("ListarPorAmbiente", uint(1)).Return([]entity.PosicaoLayout{posicaoCircular(9, 15, 15, 30)}, nil).Once()
+
+		_, err := servico.Posicionar(1, &dto.PosicaoLayoutDTO{X: 35, Y: 15, LarguraCm: 30, Formato: "circular"})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "#9")
+		m.repositorio.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Planta de Outro Ambiente", func(t *testing.T) {
+		servico, m := novoLayoutService()
+		plantaID := uint(3)
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+		m.plantaRepo.On("BuscarPorID", plantaID).Return(&entity.Planta{AmbienteID: 2}, nil).Once()
+
+		_, err := servico.Posicionar(1, &dto.PosicaoLayoutDTO{PlantaID: &plantaID, X: 60, Y: 60, LarguraCm: 30})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("Error - Sem Tamanho", func(t *testing.T) {
+		servico, m := novoLayoutService()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+
+		_, err := servico.Posicionar(1, &dto.PosicaoLayoutDTO{X: 60, Y: 60})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("Error - Ambiente Não Encontrado", func(t *testing.T) {
+		servico, m := novoLayoutService()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return((*entity.Ambiente)(nil), gorm.ErrRecordNotFound).Once()
+
+		_, err := servico.Posicionar(1, &dto.PosicaoLayoutDTO{X: 60, Y: 60, LarguraCm: 30})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestLayoutService_Atualizar_IgnoraPropriaPosicao(t *testing.T) {
+	servico, m := novoLayoutService()
+	atual := posicaoCircular(9, 15, 15, 30)
+	m.repositorio.On("BuscarPorID", uint(9)).Return(&atual, nil).Once()
+	m.ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+	m.repositorio.On("ListarPorAmbiente", uint(1)).Return([]entity.PosicaoLayout{posicaoCircular(9, 15, 15, 30)}, nil).Once()
+	m.repositorio.On("Atualizar", mock.AnythingOfType("*entity.PosicaoLayout")).Return(nil).Once()
+
+	posicao, err := servico.Atualizar(9, &dto.PosicaoLayoutDTO{X: 20, Y: 20, LarguraCm: 30, Formato: "circular"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, posicao.X)
+	m.repositorio.AssertExpectations(t)
+}
+
+func TestLayoutService_Ocupacao(t *testing.T) {
+	servico, m := novoLayoutService()
+	plantaID := uint(3)
+	quadrado := entity.PosicaoLayout{AmbienteID: 1, PlantaID: &plantaID, X: 30, Y: 30, Formato: entity.FormatoRetangular, LarguraCm: 60, ProfundidadeCm: 60}
+	m.ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+	m.repositorio.On("ListarPorAmbiente", uint(1)).Return([]entity.PosicaoLayout{quadrado}, nil).Once()
+
+	ocupacao, err := servico.Ocupacao(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1.44, ocupacao.AreaTotalM2)
+	assert.Equal(t, 0.36, ocupacao.AreaOcupadaM2)
+	assert.Equal(t, 1.08, ocupacao.AreaLivreM2)
+	assert.Equal(t, 25.0, ocupacao.PercentualOcupado)
+	assert.Equal(t, 1, ocupacao.Plantas)
+	assert.Equal(t, 0.69, ocupacao.PlantasPorM2)
+}
+
+func TestLayoutService_PlantaBaixaSVG(t *testing.T) {
+	servico, m := novoLayoutService()
+	posicao := posicaoCircular(9, 30, 30, 30)
+	posicao.Rotulo = "Gelato <1>"
+	m.ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+	m.repositorio.On("ListarPorAmbiente", uint(1)).Return([]entity.PosicaoLayout{posicao}, nil).Once()
+
+	svg, err := servico.PlantaBaixaSVG(1, &dto.ConsultaPlantaBaixaDTO{})
+
+	require.NoError(t, err)
+	conteudo := string(svg)
+	assert.True(t, strings.HasPrefix(conteudo, "<svg"))
+	assert.Contains(t, conteudo, `<circle cx="30" cy="30" r="15"`)
+	assert.Contains(t, conteudo, "Gelato &lt;1&gt;")
+	// grade padrão de 30 cm: 3 linhas em cada eixo
+	assert.Equal(t, 6, strings.Count(conteudo, "<line"))
+}
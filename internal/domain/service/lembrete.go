@@ -0,0 +1,410 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// limiteLembretesPorDisparo limita as entregas de uma execução; o restante fica para a próxima
+const limiteLembretesPorDisparo = 200
+
+// LembreteService gerencia os lembretes do usuário e os entrega pelos canais escolhidos em
+// cada lembrete. O canal lembrete é o próprio app: o lembrete entregue aparece como não lido.
+type LembreteService interface {
+	Criar(usuarioID uint, lembreteDto *dto.LembreteDTO) (*entity.Lembrete, error)
+	Listar(usuarioID uint, consulta *dto.ConsultaLembretesDTO) ([]entity.Lembrete, int64, error)
+	BuscarPorID(id, usuarioID uint) (*entity.Lembrete, error)
+	// Atualizar reagenda a entrega para a nova data
+	Atualizar(id, usuarioID uint, lembreteDto *dto.LembreteDTO) (*entity.Lembrete, error)
+	Deletar(id, usuarioID uint) error
+	// Adiar entrega o lembrete de novo daqui a alguns minutos; nos repetidos, a série não muda
+	Adiar(id, usuarioID uint, adiarDto *dto.AdiarLembreteDTO) (*entity.Lembrete, error)
+	// MarcarLido cancela um adiamento pendente; os repetidos continuam na próxima ocorrência
+	MarcarLido(id, usuarioID uint) (*entity.Lembrete, error)
+	BuscarPreferencias(usuarioID uint) (*entity.PreferenciaNotificacao, error)
+	AtualizarPreferencias(usuarioID uint, preferenciaDto *dto.PreferenciaNotificacaoDTO) (*entity.PreferenciaNotificacao, error)
+	// DispararPendentes entrega os lembretes vencidos e retorna quantos foram entregues. Dentro do
+	// horário de silêncio do usuário, a entrega é adiada para o fim do silêncio.
+	DispararPendentes() (int, error)
+}
+
+type lembreteService struct {
+	repositorio            repository.LembreteRepositorio
+	preferenciaRepositorio repository.PreferenciaNotificacaoRepositorio
+	local                  *time.Location
+	agora                  func() time.Time
+	canais                 map[string]CanalNotificacao
+}
+
+// NewLembreteService cria o serviço de lembretes; local é o fuso do horário de silêncio e das
+// repetições. Os canais são indexados pelo nome e selecionados por lembrete.
+func NewLembreteService(
+	repositorio repository.LembreteRepositorio,
+	preferenciaRepositorio repository.PreferenciaNotificacaoRepositorio,
+	local *time.Location,
+	canais ...CanalNotificacao,
+) LembreteService {
+	if local == nil {
+		local = time.Local
+	}
+	indice := make(map[string]CanalNotificacao, len(canais))
+	for _, canal := range canais {
+		indice[canal.Nome()] = canal
+	}
+	return &lembreteService{
+		repositorio:            repositorio,
+		preferenciaRepositorio: preferenciaRepositorio,
+		local:                  local,
+		agora:                  time.Now,
+		canais:                 indice,
+	}
+}
+
+func (s *lembreteService) Criar(usuarioID uint, lembreteDto *dto.LembreteDTO) (*entity.Lembrete, error) {
+	if usuarioID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	lembrete := &entity.Lembrete{UsuarioID: usuarioID}
+	if err := s.aplicarLembreteDTO(lembrete, lembreteDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Criar(lembrete); err != nil {
+		return nil, fmt.Errorf("falha ao criar lembrete: %w", err)
+	}
+	return lembrete, nil
+}
+
+func (s *lembreteService) Listar(usuarioID uint, consulta *dto.ConsultaLembretesDTO) ([]entity.Lembrete, int64, error) {
+	return s.repositorio.Listar(repository.FiltroLembretes{
+		UsuarioID: usuarioID,
+		Status:    consulta.Status,
+		Page:      consulta.Page,
+		Limit:     consulta.Limit,
+	})
+}
+
+func (s *lembreteService) BuscarPorID(id, usuarioID uint) (*entity.Lembrete, error) {
+	return s.buscarLembrete(id, usuarioID)
+}
+
+func (s *lembreteService) Atualizar(id, usuarioID uint, lembreteDto *dto.LembreteDTO) (*entity.Lembrete, error) {
+	lembrete, err := s.buscarLembrete(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.aplicarLembreteDTO(lembrete, lembreteDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Atualizar(lembrete); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar lembrete com ID %d: %w", id, err)
+	}
+	return lembrete, nil
+}
+
+func (s *lembreteService) Deletar(id, usuarioID uint) error {
+	if _, err := s.buscarLembrete(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar lembrete com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *lembreteService) Adiar(id, usuarioID uint, adiarDto *dto.AdiarLembreteDTO) (*entity.Lembrete, error) {
+	if adiarDto == nil || adiarDto.Minutos <= 0 {
+		return nil, fmt.Errorf("%w: informe por quantos minutos adiar", utils.ErrInvalidInput)
+	}
+	lembrete, err := s.buscarLembrete(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	envio := s.agora().Add(time.Duration(adiarDto.Minutos) * time.Minute)
+	lembrete.ProximoEnvio = &envio
+	lembrete.Lido = false
+	if err := s.repositorio.Atualizar(lembrete); err != nil {
+		return nil, fmt.Errorf("falha ao adiar lembrete com ID %d: %w", id, err)
+	}
+	return lembrete, nil
+}
+
+func (s *lembreteService) MarcarLido(id, usuarioID uint) (*entity.Lembrete, error) {
+	lembrete, err := s.buscarLembrete(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	lembrete.Lido = true
+	if !lembrete.Repetir {
+		lembrete.ProximoEnvio = nil
+	} else if lembrete.ProximoEnvio != nil && !lembrete.ProximoEnvio.Equal(lembrete.DataHora) {
+		proxima := lembrete.DataHora
+		lembrete.ProximoEnvio = &proxima
+	}
+	if err := s.repositorio.Atualizar(lembrete); err != nil {
+		return nil, fmt.Errorf("falha ao marcar lembrete com ID %d como lido: %w", id, err)
+	}
+	return lembrete, nil
+}
+
+func (s *lembreteService) BuscarPreferencias(usuarioID uint) (*entity.PreferenciaNotificacao, error) {
+	return s.preferencias(usuarioID)
+}
+
+func (s *lembreteService) AtualizarPreferencias(usuarioID uint, preferenciaDto *dto.PreferenciaNotificacaoDTO) (*entity.PreferenciaNotificacao, error) {
+	if usuarioID == 0 || preferenciaDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	_, okInicio := minutosDoDia(preferenciaDto.SilencioInicio)
+	_, okFim := minutosDoDia(preferenciaDto.SilencioFim)
+	if (preferenciaDto.SilencioInicio != "" || preferenciaDto.SilencioFim != "") && (!okInicio || !okFim) {
+		return nil, fmt.Errorf("%w: informe o início e o fim do silêncio no formato HH:MM", utils.ErrInvalidInput)
+	}
+
+	preferencia, err := s.preferencias(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	preferencia.Email = preferenciaDto.Email
+	preferencia.WebhookURL = preferenciaDto.WebhookURL
+	preferencia.TelegramChatID = preferenciaDto.TelegramChatID
+	preferencia.SilencioInicio = preferenciaDto.SilencioInicio
+	preferencia.SilencioFim = preferenciaDto.SilencioFim
+	preferencia.CategoriasDesativadas = strings.Join(preferenciaDto.CategoriasDesativadas, ",")
+	if err := s.preferenciaRepositorio.Salvar(preferencia); err != nil {
+		return nil, fmt.Errorf("falha ao salvar preferências de notificação: %w", err)
+	}
+	return preferencia, nil
+}
+
+func (s *lembreteService) DispararPendentes() (int, error) {
+	agora := s.agora()
+	lembretes, err := s.repositorio.ListarParaEnvio(agora, limiteLembretesPorDisparo)
+	if err != nil {
+		return 0, err
+	}
+
+	preferencias := make(map[uint]*entity.PreferenciaNotificacao)
+	entregues := 0
+	var errs []error
+	for i := range lembretes {
+		lembrete := &lembretes[i]
+		preferencia, ok := preferencias[lembrete.UsuarioID]
+		if !ok {
+			preferencia, err = s.preferencias(lembrete.UsuarioID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			preferencias[lembrete.UsuarioID] = preferencia
+		}
+
+		if fim, silencio := s.fimDoSilencio(preferencia, agora); silencio {
+			lembrete.ProximoEnvio = &fim
+			if err := s.repositorio.Atualizar(lembrete); err != nil {
+				errs = append(errs, fmt.Errorf("falha ao adiar lembrete %d para o fim do silêncio: %w", lembrete.ID, err))
+			}
+			continue
+		}
+
+		// falhas de um canal externo não impedem os demais nem repetem a entrega
+		if err := s.entregar(lembrete, preferencia); err != nil {
+			errs = append(errs, fmt.Errorf("lembrete %d: %w", lembrete.ID, err))
+		}
+		registrarEntrega(lembrete, agora, s.local)
+		if err := s.repositorio.Atualizar(lembrete); err != nil {
+			errs = append(errs, fmt.Errorf("falha ao registrar entrega do lembrete %d: %w", lembrete.ID, err))
+			continue
+		}
+		entregues++
+	}
+	return entregues, errors.Join(errs...)
+}
+
+// entregar envia o lembrete pelos canais escolhidos; o canal lembrete publica na central de
+// notificações e os externos usam o destino das preferências do usuário
+func (s *lembreteService) entregar(lembrete *entity.Lembrete, preferencia *entity.PreferenciaNotificacao) error {
+	notificacao := Notificacao{
+		UsuarioID: lembrete.UsuarioID,
+		Categoria: entity.CategoriaNotificacaoLembrete,
+		Titulo:    "Lembrete",
+		Mensagem:  lembrete.Mensagem,
+		Dados:     map[string]any{"lembrete_id": lembrete.ID},
+	}
+	var errs []error
+	for _, nome := range canaisDoLembrete(lembrete) {
+		canal, ok := s.canais[nome]
+		if !ok {
+			errs = append(errs, fmt.Errorf("canal de notificação %q não configurado", nome))
+			continue
+		}
+		envio := notificacao
+		envio.Destino = destinoDoCanal(preferencia, nome)
+		if nome != CanalLembrete && envio.Destino == "" {
+			errs = append(errs, fmt.Errorf("sem destino para o canal %s nas preferências do usuário", nome))
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutNotificacao)
+		err := canal.Enviar(ctx, envio)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("falha ao notificar pelo canal %s: %w", nome, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// registrarEntrega marca o lembrete como entregue e, nos repetidos, agenda a próxima ocorrência
+// depois de agora, pulando as que passaram enquanto a entrega estava atrasada
+func registrarEntrega(lembrete *entity.Lembrete, agora time.Time, local *time.Location) {
+	lembrete.UltimoEnvio = &agora
+	lembrete.Lido = false
+	if !lembrete.Repetir {
+		lembrete.ProximoEnvio = nil
+		return
+	}
+	proxima, ok := proximaOcorrenciaLembrete(lembrete.DataHora, lembrete.Frequencia, agora, local)
+	if !ok {
+		lembrete.ProximoEnvio = nil
+		return
+	}
+	lembrete.DataHora = proxima
+	lembrete.ProximoEnvio = &proxima
+}
+
+func proximaOcorrenciaLembrete(ocorrencia time.Time, frequencia entity.FrequenciaLembrete, agora time.Time, local *time.Location) (time.Time, bool) {
+	dias, meses := 0, 0
+	switch frequencia {
+	case entity.FrequenciaLembreteDiaria:
+		dias = 1
+	case entity.FrequenciaLembreteSemanal:
+		dias = 7
+	case entity.FrequenciaLembreteMensal:
+		meses = 1
+	default:
+		return time.Time{}, false
+	}
+	// no fuso local, o horário se mantém nas mudanças de horário de verão
+	base := ocorrencia.In(local)
+	for n := 1; ; n++ {
+		proxima := base.AddDate(0, meses*n, dias*n)
+		if proxima.After(agora) {
+			return proxima, true
+		}
+	}
+}
+
+// fimDoSilencio indica se agora está no horário de silêncio do usuário e quando ele termina
+func (s *lembreteService) fimDoSilencio(preferencia *entity.PreferenciaNotificacao, agora time.Time) (time.Time, bool) {
+	inicio, okInicio := minutosDoDia(preferencia.SilencioInicio)
+	fim, okFim := minutosDoDia(preferencia.SilencioFim)
+	if !okInicio || !okFim || inicio == fim {
+		return time.Time{}, false
+	}
+	local := agora.In(s.local)
+	if !dentroDaJanela(inicio, fim, local.Hour()*60+local.Minute()) {
+		return time.Time{}, false
+	}
+	termino := time.Date(local.Year(), local.Month(), local.Day(), fim/60, fim%60, 0, 0, s.local)
+	if !termino.After(agora) {
+		termino = termino.AddDate(0, 0, 1)
+	}
+	return termino, true
+}
+
+// aplicarLembreteDTO copia os campos do DTO e agenda a entrega na data informada
+func (s *lembreteService) aplicarLembreteDTO(lembrete *entity.Lembrete, lembreteDto *dto.LembreteDTO) error {
+	if lembreteDto == nil || strings.TrimSpace(lembreteDto.Mensagem) == "" || lembreteDto.DataHora.IsZero() {
+		return utils.ErrInvalidInput
+	}
+	frequencia := entity.FrequenciaLembrete(lembreteDto.Frequencia)
+	if lembreteDto.Repetir {
+		if _, ok := proximaOcorrenciaLembrete(lembreteDto.DataHora, frequencia, lembreteDto.DataHora, s.local); !ok {
+			return fmt.Errorf("%w: lembretes repetidos precisam de frequencia diaria, semanal ou mensal", utils.ErrInvalidInput)
+		}
+	} else {
+		frequencia = ""
+	}
+
+	canais := lembreteDto.Canais
+	if len(canais) == 0 {
+		canais = []string{CanalLembrete}
+	}
+	for _, canal := range canais {
+		if canal == CanalLembrete {
+			continue
+		}
+		if _, ok := s.canais[canal]; !ok {
+			return fmt.Errorf("%w: canal %s não está disponível", utils.ErrInvalidInput, canal)
+		}
+	}
+
+	lembrete.Mensagem = lembreteDto.Mensagem
+	lembrete.DataHora = lembreteDto.DataHora
+	lembrete.Repetir = lembreteDto.Repetir
+	lembrete.Frequencia = frequencia
+	lembrete.Canais = strings.Join(canais, ",")
+	lembrete.Lido = false
+	envio := lembreteDto.DataHora
+	lembrete.ProximoEnvio = &envio
+	return nil
+}
+
+// preferencias retorna as preferências do usuário ou, se ainda não configuradas, as padrão (sem silêncio)
+func (s *lembreteService) preferencias(usuarioID uint) (*entity.PreferenciaNotificacao, error) {
+	preferencia, err := s.preferenciaRepositorio.BuscarPorUsuario(usuarioID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &entity.PreferenciaNotificacao{UsuarioID: usuarioID}, nil
+		}
+		return nil, fmt.Errorf("falha ao buscar preferências de notificação do usuário %d: %w", usuarioID, err)
+	}
+	return preferencia, nil
+}
+
+func (s *lembreteService) buscarLembrete(id, usuarioID uint) (*entity.Lembrete, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	lembrete, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar lembrete com ID %d: %w", id, err)
+	}
+	if lembrete.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return lembrete, nil
+}
+
+func canaisDoLembrete(lembrete *entity.Lembrete) []string {
+	if lembrete.Canais == "" {
+		return []string{CanalLembrete}
+	}
+	return strings.Split(lembrete.Canais, ",")
+}
+
+func destinoDoCanal(preferencia *entity.PreferenciaNotificacao, canal string) string {
+	switch canal {
+	case CanalEmail:
+		return preferencia.Email
+	case CanalWebhook:
+		return preferencia.WebhookURL
+	case CanalTelegram:
+		return preferencia.TelegramChatID
+	}
+	return ""
+}
@@ -0,0 +1,231 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mocksLembrete struct {
+	repositorio  *test.MockLembreteRepositorio
+	preferencias *test.MockPreferenciaNotificacaoRepositorio
+	inApp        *test.MockCanalNotificacao
+	telegram     *test.MockCanalNotificacao
+	email        *test.MockCanalNotificacao
+}
+
+func novoLembreteService() (service.LembreteService, *mocksLembrete) {
+	m := &mocksLembrete{
+		repositorio:  new(test.MockLembreteRepositorio),
+		preferencias: new(test.MockPreferenciaNotificacaoRepositorio),
+		inApp:        &test.MockCanalNotificacao{NomeCanal: service.CanalLembrete},
+		telegram:     &test.MockCanalNotificacao{NomeCanal: service.CanalTelegram},
+		email:        &test.MockCanalNotificacao{NomeCanal: service.CanalEmail},
+	}
+	servico := service.NewLembreteService(m.repositorio, m.preferencias, time.UTC, m.inApp, m.telegram, m.email)
+	return servico, m
+}
+
+func lembreteDoUsuario(id, usuarioID uint, dataHora time.Time) *entity.Lembrete {
+	envio := dataHora
+	lembrete := &entity.Lembrete{UsuarioID: usuarioID, Mensagem: "Medir pH", DataHora: dataHora, ProximoEnvio: &envio}
+	lembrete.ID = id
+	return lembrete
+}
+
+func TestLembreteService_Criar(t *testing.T) {
+	dataHora := time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		servico, m := novoLembreteService()
+		m.repositorio.On("Criar", mock.AnythingOfType("*entity.Lembrete")).Return(nil).Once()
+
+		lembrete, err := servico.Criar(7, &dto.LembreteDTO{Mensagem: "Medir pH", DataHora: dataHora, Repetir: true, Frequencia: "semanal"})
+
+		require.NoError(t, err)
+		assert.Equal(t, service.CanalLembrete, lembrete.Canais)
+		assert.Equal(t, entity.FrequenciaLembreteSemanal, lembrete.Frequencia)
+		require.NotNil(t, lembrete.ProximoEnvio)
+		assert.Equal(t, dataHora, *lembrete.ProximoEnvio)
+	})
+
+	t.Run("Error - Canal Não Configurado", func(t *testing.T) {
+		servico := service.NewLembreteService(new(test.MockLembreteRepositorio), new(test.MockPreferenciaNotificacaoRepositorio), time.UTC)
+
+		_, err := servico.Criar(7, &dto.LembreteDTO{Mensagem: "Medir pH", DataHora: dataHora, Canais: []string{"telegram"}})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("Error - Repetir Sem Frequência", func(t *testing.T) {
+		servico, _ := novoLembreteService()
+
+		_, err := servico.Criar(7, &dto.LembreteDTO{Mensagem: "Medir pH", DataHora: dataHora, Repetir: true})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestLembreteService_DispararPendentes(t *testing.T) {
+	t.Run("Success - Repetido Avança Para a Próxima Ocorrência", func(t *testing.T) {
+		servico, m := novoLembreteService()
+		agora := time.Now()
+		lembrete := lembreteDoUsuario(1, 7, agora.Add(-72*time.Hour+time.Minute))
+		lembrete.Repetir = true
+		lembrete.Frequencia = entity.FrequenciaLembreteDiaria
+		lembrete.Canais = "lembrete,telegram"
+		lembrete.Lido = true
+		m.repositorio.On("ListarParaEnvio", mock.AnythingOfType("time.Time"), 200).Return([]entity.Lembrete{*lembrete}, nil).Once()
+		m.preferencias.On("BuscarPorUsuario", uint(7)).Return(&entity.PreferenciaNotificacao{UsuarioID: 7, TelegramChatID: "42"}, nil).Once()
+		m.inApp.On("Enviar", mock.Anything, mock.MatchedBy(func(n service.Notificacao) bool {
+			return n.Categoria == entity.CategoriaNotificacaoLembrete && n.Destino == ""
+		})).Return(nil).Once()
+		m.telegram.On("Enviar", mock.Anything, mock.MatchedBy(func(n service.Notificacao) bool {
+			return n.Destino == "42" && n.Mensagem == "Medir pH" && n.UsuarioID == 7
+		})).Return(nil).Once()
+		var salvo *entity.Lembrete
+		m.repositorio.On("Atualizar", mock.AnythingOfType("*entity.Lembrete")).Run(func(args mock.Arguments) {
+			salvo = args.Get(0).(*entity.Lembrete)
+		}).Return(nil).Once()
+
+		entregues, err := servico.DispararPendentes()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, entregues)
+		require.NotNil(t, salvo)
+		assert.False(t, salvo.Lido)
+		require.NotNil(t, salvo.UltimoEnvio)
+		require.NotNil(t, salvo.ProximoEnvio)
+		assert.True(t, salvo.DataHora.After(agora))
+		assert.True(t, salvo.DataHora.Before(agora.Add(24*time.Hour)))
+		assert.True(t, salvo.ProximoEnvio.Equal(salvo.DataHora))
+		m.inApp.AssertExpectations(t)
+		m.telegram.AssertExpectations(t)
+	})
+
+	t.Run("Success - Falha de Canal Não Impede a Entrega no App", func(t *testing.T) {
+		servico, m := novoLembreteService()
+		lembrete := lembreteDoUsuario(2, 7, time.Now().Add(-time.Minute))
+		lembrete.Canais = "lembrete,email"
+		m.inApp.On("Enviar", mock.Anything, mock.AnythingOfType("service.Notificacao")).Return(nil).Once()
+		m.repositorio.On("ListarParaEnvio", mock.AnythingOfType("time.Time"), 200).Return([]entity.Lembrete{*lembrete}, nil).Once()
+		m.preferencias.On("BuscarPorUsuario", uint(7)).Return((*entity.PreferenciaNotificacao)(nil), gorm.ErrRecordNotFound).Once()
+		var salvo *entity.Lembrete
+		m.repositorio.On("Atualizar", mock.AnythingOfType("*entity.Lembrete")).Run(func(args mock.Arguments) {
+			salvo = args.Get(0).(*entity.Lembrete)
+		}).Return(nil).Once()
+
+		entregues, err := servico.DispararPendentes()
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, entregues)
+		require.NotNil(t, salvo)
+		assert.Nil(t, salvo.ProximoEnvio)
+		assert.NotNil(t, salvo.UltimoEnvio)
+		m.email.AssertNotCalled(t, "Enviar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Horário de Silêncio Adia a Entrega", func(t *testing.T) {
+		servico, m := novoLembreteService()
+		agora := time.Now().UTC()
+		lembrete := lembreteDoUsuario(3, 7, agora.Add(-time.Minute))
+		lembrete.Canais = "telegram"
+		fim := agora.Add(time.Hour)
+		m.repositorio.On("ListarParaEnvio", mock.AnythingOfType("time.Time"), 200).Return([]entity.Lembrete{*lembrete}, nil).Once()
+		m.preferencias.On("BuscarPorUsuario", uint(7)).Return(&entity.PreferenciaNotificacao{
+			UsuarioID:      7,
+			TelegramChatID: "42",
+			SilencioInicio: agora.Add(-time.Hour).Format("15:04"),
+			SilencioFim:    fim.Format("15:04"),
+		}, nil).Once()
+		var salvo *entity.Lembrete
+		m.repositorio.On("Atualizar", mock.AnythingOfType("*entity.Lembrete")).Run(func(args mock.Arguments) {
+			salvo = args.Get(0).(*entity.Lembrete)
+		}).Return(nil).Once()
+
+		entregues, err := servico.DispararPendentes()
+
+		require.NoError(t, err)
+		assert.Zero(t, entregues)
+		require.NotNil(t, salvo)
+		require.NotNil(t, salvo.ProximoEnvio)
+		assert.Equal(t, fim.Truncate(time.Minute), salvo.ProximoEnvio.UTC())
+		assert.Nil(t, salvo.UltimoEnvio)
+		m.telegram.AssertNotCalled(t, "Enviar", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLembreteService_AdiarEMarcarLido(t *testing.T) {
+	t.Run("Success - Adiar", func(t *testing.T) {
+		servico, m := novoLembreteService()
+		lembrete := lembreteDoUsuario(1, 7, time.Now().Add(-time.Hour))
+		lembrete.Lido = true
+		m.repositorio.On("BuscarPorID", uint(1)).Return(lembrete, nil).Once()
+		m.repositorio.On("Atualizar", lembrete).Return(nil).Once()
+
+		adiado, err := servico.Adiar(1, 7, &dto.AdiarLembreteDTO{Minutos: 10})
+
+		require.NoError(t, err)
+		assert.False(t, adiado.Lido)
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), *adiado.ProximoEnvio, 5*time.Second)
+	})
+
+	t.Run("Success - Marcar Lido Volta o Repetido à Próxima Ocorrência", func(t *testing.T) {
+		servico, m := novoLembreteService()
+		ocorrencia := time.Now().Add(24 * time.Hour)
+		lembrete := lembreteDoUsuario(1, 7, ocorrencia)
+		lembrete.Repetir = true
+		lembrete.Frequencia = entity.FrequenciaLembreteDiaria
+		adiado := time.Now().Add(10 * time.Minute)
+		lembrete.ProximoEnvio = &adiado
+		m.repositorio.On("BuscarPorID", uint(1)).Return(lembrete, nil).Once()
+		m.repositorio.On("Atualizar", lembrete).Return(nil).Once()
+
+		lido, err := servico.MarcarLido(1, 7)
+
+		require.NoError(t, err)
+		assert.True(t, lido.Lido)
+		assert.True(t, lido.ProximoEnvio.Equal(ocorrencia))
+	})
+
+	t.Run("Error - Lembrete de Outro Usuário", func(t *testing.T) {
+		servico, m := novoLembreteService()
+		m.repositorio.On("BuscarPorID", uint(1)).Return(lembreteDoUsuario(1, 8, time.Now()), nil).Once()
+
+		_, err := servico.MarcarLido(1, 7)
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestLembreteService_AtualizarPreferencias(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servico, m := novoLembreteService()
+		m.preferencias.On("BuscarPorUsuario", uint(7)).Return((*entity.PreferenciaNotificacao)(nil), gorm.ErrRecordNotFound).Once()
+		m.preferencias.On("Salvar", mock.AnythingOfType("*entity.PreferenciaNotificacao")).Return(nil).Once()
+
+		preferencia, err := servico.AtualizarPreferencias(7, &dto.PreferenciaNotificacaoDTO{TelegramChatID: "42", SilencioInicio: "22:00", SilencioFim: "07:00"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), preferencia.UsuarioID)
+		assert.Equal(t, "22:00", preferencia.SilencioInicio)
+		m.preferencias.AssertExpectations(t)
+	})
+
+	t.Run("Error - Silêncio Incompleto", func(t *testing.T) {
+		servico, _ := novoLembreteService()
+
+		_, err := servico.AtualizarPreferencias(7, &dto.PreferenciaNotificacaoDTO{SilencioInicio: "22:00"})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/desenho"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// resolucaoMapaPadraoCm é o lado das células do mapa de calor quando a consulta não informa
+	resolucaoMapaPadraoCm = 5
+	// maxCelulasMapa limita o tamanho da grade interpolada
+	maxCelulasMapa = 250000
+	// toleranciaPontoCm é a distância em que dois pontos de levantamentos diferentes são o mesmo lugar
+	toleranciaPontoCm = 2
+)
+
+// Formatos do mapa de calor
+const (
+	FormatoMapaSVG = "svg"
+	FormatoMapaPNG = "png"
+)
+
+// LevantamentoPPFDService registra mapeamentos de luz dos ambientes, calcula a uniformidade
+// e desenha mapas de calor para ajustar a posição, a altura e a potência das luminárias.
+type LevantamentoPPFDService interface {
+	Registrar(ambienteID uint, levantamentoDto *dto.LevantamentoPPFDDTO) (*dto.ResumoLevantamentoPPFDDTO, error)
+	// Listar retorna o histórico do ambiente, do mais recente ao mais antigo, sem os pontos
+	Listar(ambienteID uint) ([]dto.ResumoLevantamentoPPFDDTO, error)
+	Buscar(id uint) (*dto.ResumoLevantamentoPPFDDTO, error)
+	// Comparar mede a diferença do levantamento comparado em relação ao base, ponto a ponto
+	Comparar(baseID, comparadoID uint) (*dto.ComparacaoPPFDDTO, error)
+	// Mapa desenha o mapa de calor e retorna a imagem com o content type
+	Mapa(id uint, consulta *dto.ConsultaMapaPPFDDTO) ([]byte, string, error)
+	Deletar(id uint) error
+}
+
+type levantamentoPPFDService struct {
+	repositorio         repository.LevantamentoPPFDRepositorio
+	ambienteRepositorio repository.AmbienteRepositorio
+	calendarioLuz       CalendarioLuz
+	local               *time.Location
+	agora               func() time.Time
+}
+
+// NewLevantamentoPPFDService cria o serviço de levantamentos de PPFD. O calendário de luz, quando
+// informado, dá as horas de luz do dia do levantamento para estimar o DLI.
+func NewLevantamentoPPFDService(
+	repositorio repository.LevantamentoPPFDRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	calendarioLuz CalendarioLuz,
+	local *time.Location,
+) LevantamentoPPFDService {
+	if local == nil {
+		local = time.Local
+	}
+	return &levantamentoPPFDService{
+		repositorio:         repositorio,
+		ambienteRepositorio: ambienteRepositorio,
+		calendarioLuz:       calendarioLuz,
+		local:               local,
+		agora:               time.Now,
+	}
+}
+
+func (s *levantamentoPPFDService) Registrar(ambienteID uint, levantamentoDto *dto.LevantamentoPPFDDTO) (*dto.ResumoLevantamentoPPFDDTO, error) {
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	if len(levantamentoDto.Pontos) == 0 {
+		return nil, fmt.Errorf("%w: levantamento sem pontos", utils.ErrInvalidInput)
+	}
+
+	data := s.agora()
+	if levantamentoDto.Data != nil && !levantamentoDto.Data.IsZero() {
+		data = *levantamentoDto.Data
+	}
+	levantamento := entity.LevantamentoPPFD{
+		AmbienteID:         ambienteID,
+		Data:               data,
+		AlturaLuminariaCm:  levantamentoDto.AlturaLuminariaCm,
+		PotenciaPercentual: levantamentoDto.PotenciaPercentual,
+		Observacoes:        levantamentoDto.Observacoes,
+		Pontos:             make([]entity.PontoPPFD, 0, len(levantamentoDto.Pontos)),
+	}
+	for i, ponto := range levantamentoDto.Pontos {
+		if ambiente.Comprimento > 0 && ambiente.Largura > 0 && (ponto.X > ambiente.Comprimento || ponto.Y > ambiente.Largura) {
+			return nil, fmt.Errorf("%w: ponto %d fora do ambiente (%s x %s cm)", utils.ErrInvalidInput, i+1,
+				formatarValor(ambiente.Comprimento), formatarValor(ambiente.Largura))
+		}
+		levantamento.Pontos = append(levantamento.Pontos, entity.PontoPPFD{X: ponto.X, Y: ponto.Y, PPFD: ponto.PPFD})
+	}
+
+	if err := s.repositorio.Criar(&levantamento); err != nil {
+		return nil, fmt.Errorf("falha ao registrar levantamento de PPFD do ambiente %d: %w", ambienteID, err)
+	}
+	return s.resumir(&levantamento, true)
+}
+
+func (s *levantamentoPPFDService) Listar(ambienteID uint) ([]dto.ResumoLevantamentoPPFDDTO, error) {
+	if _, err := s.buscarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	levantamentos, err := s.repositorio.ListarPorAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	resumos := make([]dto.ResumoLevantamentoPPFDDTO, 0, len(levantamentos))
+	for i := range levantamentos {
+		resumo, err := s.resumir(&levantamentos[i], false)
+		if err != nil {
+			return nil, err
+		}
+		resumos = append(resumos, *resumo)
+	}
+	return resumos, nil
+}
+
+func (s *levantamentoPPFDService) Buscar(id uint) (*dto.ResumoLevantamentoPPFDDTO, error) {
+	levantamento, err := s.buscarLevantamento(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.resumir(levantamento, true)
+}
+
+func (s *levantamentoPPFDService) Comparar(baseID, comparadoID uint) (*dto.ComparacaoPPFDDTO, error) {
+	base, err := s.buscarLevantamento(baseID)
+	if err != nil {
+		return nil, err
+	}
+	comparado, err := s.buscarLevantamento(comparadoID)
+	if err != nil {
+		return nil, err
+	}
+	if base.AmbienteID != comparado.AmbienteID {
+		return nil, fmt.Errorf("%w: levantamentos de ambientes diferentes", utils.ErrInvalidInput)
+	}
+
+	resumoBase, err := s.resumir(base, false)
+	if err != nil {
+		return nil, err
+	}
+	resumoComparado, err := s.resumir(comparado, false)
+	if err != nil {
+		return nil, err
+	}
+	comparacao := &dto.ComparacaoPPFDDTO{
+		Base:                  *resumoBase,
+		Comparado:             *resumoComparado,
+		DiferencaMedia:        calculo.Arredondar(resumoComparado.Estatisticas.Media-resumoBase.Estatisticas.Media, 1),
+		DiferencaUniformidade: calculo.Arredondar(resumoComparado.Estatisticas.Uniformidade-resumoBase.Estatisticas.Uniformidade, 3),
+		Pontos:                make([]dto.DiferencaPontoPPFDDTO, 0, len(base.Pontos)),
+	}
+	if resumoBase.Estatisticas.Media > 0 {
+		comparacao.DiferencaMediaPercentual = calculo.Arredondar(comparacao.DiferencaMedia/resumoBase.Estatisticas.Media*100, 1)
+	}
+
+	medidosComparado := pontosMedidos(comparado.Pontos)
+	for _, ponto := range base.Pontos {
+		diferenca := dto.DiferencaPontoPPFDDTO{X: ponto.X, Y: ponto.Y, Base: ponto.PPFD, Interpolado: true}
+		if mesmo, ok := pontoProximo(comparado.Pontos, ponto.X, ponto.Y); ok {
+			diferenca.Comparado = mesmo.PPFD
+			diferenca.Interpolado = false
+		} else {
+			diferenca.Comparado = calculo.Arredondar(calculo.InterpolarIDW(medidosComparado, ponto.X, ponto.Y), 1)
+		}
+		diferenca.Diferenca = calculo.Arredondar(diferenca.Comparado-diferenca.Base, 1)
+		comparacao.Pontos = append(comparacao.Pontos, diferenca)
+	}
+	return comparacao, nil
+}
+
+func (s *levantamentoPPFDService) Mapa(id uint, consulta *dto.ConsultaMapaPPFDDTO) ([]byte, string, error) {
+	levantamento, err := s.buscarLevantamento(id)
+	if err != nil {
+		return nil, "", err
+	}
+	ambiente, err := s.buscarAmbiente(levantamento.AmbienteID)
+	if err != nil {
+		return nil, "", err
+	}
+	if ambiente.Comprimento <= 0 || ambiente.Largura <= 0 {
+		return nil, "", fmt.Errorf("%w: ambiente sem dimensões", utils.ErrInvalidInput)
+	}
+	if len(levantamento.Pontos) == 0 {
+		return nil, "", fmt.Errorf("%w: levantamento sem pontos", utils.ErrInvalidInput)
+	}
+
+	if consulta == nil {
+		consulta = &dto.ConsultaMapaPPFDDTO{}
+	}
+	resolucao := consulta.ResolucaoCm
+	if resolucao == 0 {
+		resolucao = resolucaoMapaPadraoCm
+	}
+	if celulas := math.Ceil(ambiente.Comprimento/resolucao) * math.Ceil(ambiente.Largura/resolucao); celulas > maxCelulasMapa {
+		return nil, "", fmt.Errorf("%w: resolução muito fina para o tamanho do ambiente", utils.ErrInvalidInput)
+	}
+
+	mapa := desenho.NovoMapaCalor(ambiente.Comprimento, ambiente.Largura, resolucao, pontosMedidos(levantamento.Pontos))
+	mapa.Titulo = fmt.Sprintf("%s - PPFD %s (luminária a %s cm)", ambiente.Nome,
+		levantamento.Data.In(s.local).Format("02/01/2006"), formatarValor(levantamento.AlturaLuminariaCm))
+	if consulta.EscalaMin != nil || consulta.EscalaMax != nil {
+		if consulta.EscalaMin == nil || consulta.EscalaMax == nil || *consulta.EscalaMax <= *consulta.EscalaMin {
+			return nil, "", fmt.Errorf("%w: informe escala_min menor que escala_max", utils.ErrInvalidInput)
+		}
+		mapa.Min, mapa.Max = *consulta.EscalaMin, *consulta.EscalaMax
+	}
+
+	if consulta.Formato == FormatoMapaPNG {
+		imagem, err := mapa.PNG()
+		if err != nil {
+			return nil, "", err
+		}
+		return imagem, "image/png", nil
+	}
+	return mapa.SVG(), "image/svg+xml", nil
+}
+
+func (s *levantamentoPPFDService) Deletar(id uint) error {
+	if id == 0 {
+		return utils.ErrInvalidInput
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar levantamento de PPFD com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+// resumir calcula as estatísticas do levantamento e, com o calendário de luz, o DLI médio
+func (s *levantamentoPPFDService) resumir(levantamento *entity.LevantamentoPPFD, comPontos bool) (*dto.ResumoLevantamentoPPFDDTO, error) {
+	resumo := &dto.ResumoLevantamentoPPFDDTO{
+		ID:                 levantamento.ID,
+		AmbienteID:         levantamento.AmbienteID,
+		Data:               levantamento.Data,
+		AlturaLuminariaCm:  levantamento.AlturaLuminariaCm,
+		PotenciaPercentual: levantamento.PotenciaPercentual,
+		Observacoes:        levantamento.Observacoes,
+		Estatisticas:       estatisticasPPFD(levantamento.Pontos),
+	}
+	if comPontos {
+		resumo.Pontos = levantamento.Pontos
+	}
+
+	if s.calendarioLuz != nil && len(levantamento.Pontos) > 0 {
+		dia := levantamento.Data.In(s.local)
+		dias, err := s.calendarioLuz.HorasLuzNoPeriodo(levantamento.AmbienteID, dia, dia)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao calcular horas de luz do ambiente %d: %w", levantamento.AmbienteID, err)
+		}
+		if len(dias) > 0 {
+			horas := dias[0].HorasLuzEfetivas
+			dli := calculo.DLI(resumo.Estatisticas.Media, horas)
+			resumo.Estatisticas.HorasLuz = &horas
+			resumo.Estatisticas.DLIMedio = &dli
+		}
+	}
+	return resumo, nil
+}
+
+func (s *levantamentoPPFDService) buscarAmbiente(ambienteID uint) (*entity.Ambiente, error) {
+	if ambienteID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	ambiente, err := s.ambienteRepositorio.BuscarPorID(ambienteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar ambiente com ID %d: %w", ambienteID, err)
+	}
+	return ambiente, nil
+}
+
+func (s *levantamentoPPFDService) buscarLevantamento(id uint) (*entity.LevantamentoPPFD, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	levantamento, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar levantamento de PPFD com ID %d: %w", id, err)
+	}
+	return levantamento, nil
+}
+
+// estatisticasPPFD calcula média, extremos, dispersão e uniformidade das medições
+func estatisticasPPFD(pontos []entity.PontoPPFD) dto.EstatisticaPPFDDTO {
+	estatisticas := dto.EstatisticaPPFDDTO{Pontos: len(pontos)}
+	if len(pontos) == 0 {
+		return estatisticas
+	}
+
+	minimo, maximo, soma := math.Inf(1), math.Inf(-1), 0.0
+	for _, ponto := range pontos {
+		minimo = math.Min(minimo, ponto.PPFD)
+		maximo = math.Max(maximo, ponto.PPFD)
+		soma += ponto.PPFD
+	}
+	media := soma / float64(len(pontos))
+	var variancia float64
+	for _, ponto := range pontos {
+		variancia += (ponto.PPFD - media) * (ponto.PPFD - media)
+	}
+	desvio := math.Sqrt(variancia / float64(len(pontos)))
+
+	estatisticas.Media = calculo.Arredondar(media, 1)
+	estatisticas.Min = minimo
+	estatisticas.Max = maximo
+	estatisticas.DesvioPadrao = calculo.Arredondar(desvio, 1)
+	if media > 0 {
+		estatisticas.CoeficienteVariacao = calculo.Arredondar(desvio/media*100, 1)
+		estatisticas.Uniformidade = calculo.Arredondar(minimo/media, 3)
+	}
+	if maximo > 0 {
+		estatisticas.UniformidadeMinMax = calculo.Arredondar(minimo/maximo, 3)
+	}
+	return estatisticas
+}
+
+func pontosMedidos(pontos []entity.PontoPPFD) []calculo.PontoMedido {
+	medidos := make([]calculo.PontoMedido, 0, len(pontos))
+	for _, ponto := range pontos {
+		medidos = append(medidos, calculo.PontoMedido{X: ponto.X, Y: ponto.Y, Valor: ponto.PPFD})
+	}
+	return medidos
+}
+
+// pontoProximo retorna a medição feita no mesmo lugar (dentro da tolerância), se houver
+func pontoProximo(pontos []entity.PontoPPFD, x, y float64) (entity.PontoPPFD, bool) {
+	for _, ponto := range pontos {
+		if math.Hypot(ponto.X-x, ponto.Y-y) <= toleranciaPontoCm {
+			return ponto, true
+		}
+	}
+	return entity.PontoPPFD{}, false
+}
@@ -0,0 +1,173 @@
+package service_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func novoLevantamentoPPFDService() (service.LevantamentoPPFDService, *test.MockLevantamentoPPFDRepositorio, *test.MockAmbienteRepositorio) {
+	repositorio := new(test.MockLevantamentoPPFDRepositorio)
+	ambienteRepo := new(test.MockAmbienteRepositorio)
+	calendario := calendarioLuzFixo{horasEfetivas: 12}
+	return service.NewLevantamentoPPFDService(repositorio, ambienteRepo, calendario, time.UTC), repositorio, ambienteRepo
+}
+
+// levantamentoQuatroCantos tem quatro pontos em uma tenda de 120 x 120 cm
+func levantamentoQuatroCantos(id uint, valores ...float64) *entity.LevantamentoPPFD {
+	levantamento := &entity.LevantamentoPPFD{
+		AmbienteID:        1,
+		Data:              time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+		AlturaLuminariaCm: 45,
+		Pontos: []entity.PontoPPFD{
+			{X: 30, Y: 30, PPFD: valores[0]},
+			{X: 90, Y: 30, PPFD: valores[1]},
+			{X: 30, Y: 90, PPFD: valores[2]},
+			{X: 90, Y: 90, PPFD: valores[3]},
+		},
+	}
+	levantamento.ID = id
+	return levantamento
+}
+
+func TestLevantamentoPPFDService_Registrar(t *testing.T) {
+	t.Run("Success - Estatísticas", func(t *testing.T) {
+		servico, repositorio, ambienteRepo := novoLevantamentoPPFDService()
+		ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+		repositorio.On("Criar", mock.AnythingOfType("*entity.LevantamentoPPFD")).Return(nil).Once()
+
+		resumo, err := servico.Registrar(1, &dto.LevantamentoPPFDDTO{
+			AlturaLuminariaCm: 45,
+			Pontos: []dto.PontoPPFDDTO{
+				{X: 30, Y: 30, PPFD: 600}, {X: 90, Y: 30, PPFD: 800},
+				{X: 30, Y: 90, PPFD: 800}, {X: 90, Y: 90, PPFD: 1000},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, resumo.Estatisticas.Pontos)
+		assert.Equal(t, 800.0, resumo.Estatisticas.Media)
+		assert.Equal(t, 600.0, resumo.Estatisticas.Min)
+		assert.Equal(t, 1000.0, resumo.Estatisticas.Max)
+		assert.Equal(t, 141.4, resumo.Estatisticas.DesvioPadrao)
+		assert.Equal(t, 0.75, resumo.Estatisticas.Uniformidade)
+		assert.Equal(t, 0.6, resumo.Estatisticas.UniformidadeMinMax)
+		require.NotNil(t, resumo.Estatisticas.DLIMedio)
+		assert.Equal(t, 34.56, *resumo.Estatisticas.DLIMedio)
+		assert.Len(t, resumo.Pontos, 4)
+		repositorio.AssertExpectations(t)
+	})
+
+	t.Run("Error - Ponto Fora do Ambiente", func(t *testing.T) {
+		servico, repositorio, ambienteRepo := novoLevantamentoPPFDService()
+		ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+
+		_, err := servico.Registrar(1, &dto.LevantamentoPPFDDTO{
+			AlturaLuminariaCm: 45,
+			Pontos:            []dto.PontoPPFDDTO{{X: 30, Y: 30, PPFD: 600}, {X: 130, Y: 30, PPFD: 800}, {X: 30, Y: 90, PPFD: 700}},
+		})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "ponto 2")
+		repositorio.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Ambiente Não Encontrado", func(t *testing.T) {
+		servico, _, ambienteRepo := novoLevantamentoPPFDService()
+		ambienteRepo.On("BuscarPorID", uint(1)).Return((*entity.Ambiente)(nil), gorm.ErrRecordNotFound).Once()
+
+		_, err := servico.Registrar(1, &dto.LevantamentoPPFDDTO{AlturaLuminariaCm: 45, Pontos: []dto.PontoPPFDDTO{{}}})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestLevantamentoPPFDService_Comparar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servico, repositorio, _ := novoLevantamentoPPFDService()
+		base := levantamentoQuatroCantos(1, 600, 800, 800, 1000)
+		comparado := levantamentoQuatroCantos(2, 800, 800, 800, 800)
+		// o ponto do canto foi medido 10 cm ao lado no segundo levantamento
+		comparado.Pontos[3].X = 80
+		repositorio.On("BuscarPorID", uint(1)).Return(base, nil).Once()
+		repositorio.On("BuscarPorID", uint(2)).Return(comparado, nil).Once()
+
+		comparacao, err := servico.Comparar(1, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, comparacao.DiferencaMedia)
+		assert.Equal(t, 0.25, comparacao.DiferencaUniformidade)
+		require.Len(t, comparacao.Pontos, 4)
+		assert.Equal(t, 200.0, comparacao.Pontos[0].Diferenca)
+		assert.False(t, comparacao.Pontos[0].Interpolado)
+		assert.True(t, comparacao.Pontos[3].Interpolado)
+		assert.Equal(t, -200.0, comparacao.Pontos[3].Diferenca)
+		assert.Nil(t, comparacao.Base.Pontos)
+	})
+
+	t.Run("Error - Ambientes Diferentes", func(t *testing.T) {
+		servico, repositorio, _ := novoLevantamentoPPFDService()
+		outro := levantamentoQuatroCantos(2, 800, 800, 800, 800)
+		outro.AmbienteID = 5
+		repositorio.On("BuscarPorID", uint(1)).Return(levantamentoQuatroCantos(1, 600, 800, 800, 1000), nil).Once()
+		repositorio.On("BuscarPorID", uint(2)).Return(outro, nil).Once()
+
+		_, err := servico.Comparar(1, 2)
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestLevantamentoPPFDService_Mapa(t *testing.T) {
+	t.Run("Success - PNG", func(t *testing.T) {
+		servico, repositorio, ambienteRepo := novoLevantamentoPPFDService()
+		repositorio.On("BuscarPorID", uint(1)).Return(levantamentoQuatroCantos(1, 600, 800, 800, 1000), nil).Once()
+		ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+
+		imagem, contentType, err := servico.Mapa(1, &dto.ConsultaMapaPPFDDTO{Formato: service.FormatoMapaPNG, ResolucaoCm: 10})
+
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+		img, err := png.Decode(bytes.NewReader(imagem))
+		require.NoError(t, err)
+		// 12 células de 50 px mais a legenda
+		assert.Equal(t, 640, img.Bounds().Dx())
+		assert.Equal(t, 600, img.Bounds().Dy())
+	})
+
+	t.Run("Success - SVG Padrão", func(t *testing.T) {
+		servico, repositorio, ambienteRepo := novoLevantamentoPPFDService()
+		repositorio.On("BuscarPorID", uint(1)).Return(levantamentoQuatroCantos(1, 600, 800, 800, 1000), nil).Once()
+		ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+
+		imagem, contentType, err := servico.Mapa(1, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "image/svg+xml", contentType)
+		assert.Contains(t, string(imagem), "Tenda - PPFD 01/06/2026 (luminária a 45 cm)")
+		// 24 x 24 células de 5 cm
+		assert.Equal(t, 576, bytes.Count(imagem, []byte("<rect"))-2)
+	})
+
+	t.Run("Error - Escala Incompleta", func(t *testing.T) {
+		servico, repositorio, ambienteRepo := novoLevantamentoPPFDService()
+		repositorio.On("BuscarPorID", uint(1)).Return(levantamentoQuatroCantos(1, 600, 800, 800, 1000), nil).Once()
+		ambienteRepo.On("BuscarPorID", uint(1)).Return(tenda120(), nil).Once()
+		minimo := 200.0
+
+		_, _, err := servico.Mapa(1, &dto.ConsultaMapaPPFDDTO{EscalaMin: &minimo})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
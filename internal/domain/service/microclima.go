@@ -0,0 +1,512 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/importacao"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// periodoPadraoMicroclima é usado quando a consulta não informa início
+const periodoPadraoMicroclima = 24 * time.Hour
+
+// intervalosAgregacao mapeia os intervalos aceitos pela API para durações
+var intervalosAgregacao = map[string]time.Duration{
+	"5m": 5 * time.Minute,
+	"1h": time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// MicroclimaService define as operações de ingestão e consulta de leituras de sensores.
+type MicroclimaService interface {
+	RegistrarLeitura(ambienteID uint, leituraDto *dto.LeituraMicroclimaDTO) (*entity.Microclima, error)
+	RegistrarLote(ambienteID uint, loteDto *dto.LoteLeiturasMicroclimaDTO) (*dto.IngestaoMicroclimaResponseDTO, error)
+	ListarLeituras(ambienteID uint, consulta *dto.ConsultaMicroclimaDTO) (*dto.SerieMicroclimaResponseDTO, error)
+	Agregar(ambienteID uint, consulta *dto.ConsultaMicroclimaDTO) (*dto.AgregadoMicroclimaResponseDTO, error)
+	// ImportarLineProtocol e ImportarCSV carregam históricos em lote, sem disparar os observadores
+	ImportarLineProtocol(ambienteID uint, corpo io.Reader, opcoes *dto.ImportacaoLineProtocolDTO) (*dto.ImportacaoMicroclimaResponseDTO, error)
+	ImportarCSV(ambienteID uint, corpo io.Reader, opcoes *dto.ImportacaoCSVDTO) (*dto.ImportacaoMicroclimaResponseDTO, error)
+}
+
+// ObservadorMicroclima é notificado após a persistência de novas leituras (ex.: avaliação de alertas).
+type ObservadorMicroclima interface {
+	LeiturasRegistradas(ambienteID uint, leituras []entity.Microclima) error
+}
+
+type microclimaService struct {
+	repositorio         repository.MicroclimaRepositorio
+	ambienteRepositorio repository.AmbienteRepositorio
+	estagioRepositorio  repository.EstagioCrescimentoRepositorio
+	calendarioLuz       CalendarioLuz
+	observadores        []ObservadorMicroclima
+	agora               func() time.Time
+}
+
+// NewMicroclimaService cria o serviço de microclima. calendarioLuz é opcional e complementa
+// o DLI diário com as horas de luz programadas do ambiente.
+func NewMicroclimaService(
+	repositorio repository.MicroclimaRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	estagioRepositorio repository.EstagioCrescimentoRepositorio,
+	calendarioLuz CalendarioLuz,
+	observadores ...ObservadorMicroclima,
+) MicroclimaService {
+	return &microclimaService{
+		repositorio:         repositorio,
+		ambienteRepositorio: ambienteRepositorio,
+		estagioRepositorio:  estagioRepositorio,
+		calendarioLuz:       calendarioLuz,
+		observadores:        observadores,
+		agora:               time.Now,
+	}
+}
+
+func (s *microclimaService) RegistrarLeitura(ambienteID uint, leituraDto *dto.LeituraMicroclimaDTO) (*entity.Microclima, error) {
+	if err := s.validarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+
+	if err := s.validarLeituras(ambienteID, []dto.LeituraMicroclimaDTO{*leituraDto}); err != nil {
+		return nil, err
+	}
+
+	leitura := s.paraEntidade(ambienteID, leituraDto)
+	if err := s.repositorio.Criar(&leitura); err != nil {
+		return nil, fmt.Errorf("falha ao registrar leitura do ambiente %d: %w", ambienteID, err)
+	}
+	s.notificarObservadores(ambienteID, []entity.Microclima{leitura})
+	return &leitura, nil
+}
+
+func (s *microclimaService) RegistrarLote(ambienteID uint, loteDto *dto.LoteLeiturasMicroclimaDTO) (*dto.IngestaoMicroclimaResponseDTO, error) {
+	if err := s.validarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+
+	if err := s.validarLeituras(ambienteID, loteDto.Leituras); err != nil {
+		return nil, err
+	}
+
+	leituras := make([]entity.Microclima, 0, len(loteDto.Leituras))
+	for i := range loteDto.Leituras {
+		leituras = append(leituras, s.paraEntidade(ambienteID, &loteDto.Leituras[i]))
+	}
+
+	if err := s.repositorio.CriarEmLote(leituras); err != nil {
+		return nil, fmt.Errorf("falha ao registrar lote de leituras do ambiente %d: %w", ambienteID, err)
+	}
+	s.notificarObservadores(ambienteID, leituras)
+	return &dto.IngestaoMicroclimaResponseDTO{AmbienteID: ambienteID, Inseridas: len(leituras)}, nil
+}
+
+func (s *microclimaService) ListarLeituras(ambienteID uint, consulta *dto.ConsultaMicroclimaDTO) (*dto.SerieMicroclimaResponseDTO, error) {
+	if err := s.validarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	inicio, fim, err := s.periodo(consulta)
+	if err != nil {
+		return nil, err
+	}
+
+	leituras, err := s.repositorio.ListarPorPeriodo(ambienteID, inicio, fim, consulta.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SerieMicroclimaResponseDTO{AmbienteID: ambienteID, Inicio: inicio, Fim: fim, Leituras: leituras}, nil
+}
+
+func (s *microclimaService) Agregar(ambienteID uint, consulta *dto.ConsultaMicroclimaDTO) (*dto.AgregadoMicroclimaResponseDTO, error) {
+	if err := s.validarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	inicio, fim, err := s.periodo(consulta)
+	if err != nil {
+		return nil, err
+	}
+
+	rotulo := consulta.Intervalo
+	if rotulo == "" {
+		rotulo = "1h"
+	}
+	intervalo, ok := intervalosAgregacao[rotulo]
+	if !ok {
+		return nil, utils.ErrInvalidInput
+	}
+
+	agregados, err := s.repositorio.Agregar(ambienteID, inicio, fim, intervalo)
+	if err != nil {
+		return nil, err
+	}
+
+	estagio, err := s.estagioDaConsulta(ambienteID, consulta)
+	if err != nil {
+		return nil, err
+	}
+	offsetFolha := calculo.OffsetFolhaPadrao
+	if consulta.OffsetFolha != nil {
+		offsetFolha = *consulta.OffsetFolha
+	}
+	faixas := calculo.FaixasParaEstagio(estagio)
+
+	janelas := make([]dto.JanelaMicroclimaDTO, 0, len(agregados))
+	for _, agregado := range agregados {
+		janelas = append(janelas, derivarJanela(agregado, offsetFolha, consulta.FatorLux, faixas))
+	}
+
+	dias := dliDiario(janelas, intervalo, faixas)
+	if err := s.estimarDLI(ambienteID, janelas, dias); err != nil {
+		return nil, err
+	}
+
+	return &dto.AgregadoMicroclimaResponseDTO{
+		AmbienteID:  ambienteID,
+		Inicio:      inicio,
+		Fim:         fim,
+		Intervalo:   rotulo,
+		Estagio:     estagio,
+		OffsetFolha: offsetFolha,
+		Faixas:      faixas,
+		Janelas:     janelas,
+		DLIDiario:   dias,
+	}, nil
+}
+
+func (s *microclimaService) ImportarLineProtocol(ambienteID uint, corpo io.Reader, opcoes *dto.ImportacaoLineProtocolDTO) (*dto.ImportacaoMicroclimaResponseDTO, error) {
+	if err := s.validarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	precisao := time.Nanosecond
+	if opcoes.Precisao != "" {
+		p, ok := importacao.PrecisoesLineProtocol[opcoes.Precisao]
+		if !ok {
+			return nil, utils.ErrInvalidInput
+		}
+		precisao = p
+	}
+
+	resultado, err := importacao.LerLineProtocol(corpo, importacao.OpcoesLineProtocol{
+		Precisao: precisao,
+		Medicao:  opcoes.Medicao,
+		Agora:    s.agora(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.gravarImportacao(ambienteID, resultado)
+}
+
+func (s *microclimaService) ImportarCSV(ambienteID uint, corpo io.Reader, opcoes *dto.ImportacaoCSVDTO) (*dto.ImportacaoMicroclimaResponseDTO, error) {
+	if err := s.validarAmbiente(ambienteID); err != nil {
+		return nil, err
+	}
+	opcoesCSV, err := opcoesImportacaoCSV(opcoes)
+	if err != nil {
+		return nil, err
+	}
+
+	resultado, err := importacao.LerCSV(corpo, opcoesCSV)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", utils.ErrInvalidInput, err)
+	}
+	return s.gravarImportacao(ambienteID, resultado)
+}
+
+// gravarImportacao insere as leituras válidas de uma importação em uma única transação.
+// Históricos não passam pelos observadores para não reabrir alertas do passado.
+func (s *microclimaService) gravarImportacao(ambienteID uint, resultado *importacao.Resultado) (*dto.ImportacaoMicroclimaResponseDTO, error) {
+	for i := range resultado.Leituras {
+		resultado.Leituras[i].AmbienteID = ambienteID
+	}
+	if err := s.repositorio.CriarEmLote(resultado.Leituras); err != nil {
+		return nil, fmt.Errorf("falha ao importar leituras do ambiente %d: %w", ambienteID, err)
+	}
+
+	rejeicoes := resultado.Rejeicoes
+	if rejeicoes == nil {
+		rejeicoes = []importacao.LinhaRejeitada{}
+	}
+	return &dto.ImportacaoMicroclimaResponseDTO{
+		AmbienteID: ambienteID,
+		Inseridas:  len(resultado.Leituras),
+		Rejeitadas: resultado.Rejeitadas,
+		Rejeicoes:  rejeicoes,
+	}, nil
+}
+
+// delimitadoresCSV mapeia os nomes aceitos na API para os separadores
+var delimitadoresCSV = map[string]rune{
+	"":              ',',
+	"virgula":       ',',
+	"ponto_virgula": ';',
+	"tab":           '\t',
+}
+
+func opcoesImportacaoCSV(opcoes *dto.ImportacaoCSVDTO) (importacao.OpcoesCSV, error) {
+	delimitador, ok := delimitadoresCSV[opcoes.Delimitador]
+	if !ok {
+		return importacao.OpcoesCSV{}, utils.ErrInvalidInput
+	}
+	local := time.UTC
+	if opcoes.Fuso != "" {
+		l, err := time.LoadLocation(opcoes.Fuso)
+		if err != nil {
+			return importacao.OpcoesCSV{}, fmt.Errorf("%w: fuso horário %q desconhecido", utils.ErrInvalidInput, opcoes.Fuso)
+		}
+		local = l
+	}
+
+	var colunas map[string]string
+	if opcoes.Colunas != "" {
+		colunas = make(map[string]string)
+		for _, par := range strings.Split(opcoes.Colunas, ",") {
+			nome, coluna, _ := strings.Cut(par, "=")
+			metrica, conhecida := importacao.MetricaPorNome(nome)
+			coluna = strings.TrimSpace(coluna)
+			if !conhecida || coluna == "" {
+				return importacao.OpcoesCSV{}, fmt.Errorf("%w: mapeamento de coluna inválido %q", utils.ErrInvalidInput, par)
+			}
+			colunas[metrica] = coluna
+		}
+	}
+
+	return importacao.OpcoesCSV{
+		Delimitador:    delimitador,
+		DecimalVirgula: opcoes.Decimal == "virgula",
+		ColunaData:     opcoes.ColunaData,
+		FormatoData:    opcoes.FormatoData,
+		Local:          local,
+		Colunas:        colunas,
+	}, nil
+}
+
+// estagioDaConsulta usa o estágio informado na consulta ou o predominante entre as plantas do ambiente
+func (s *microclimaService) estagioDaConsulta(ambienteID uint, consulta *dto.ConsultaMicroclimaDTO) (entity.EstagioPlanta, error) {
+	if consulta.Estagio != "" {
+		return entity.EstagioPlanta(consulta.Estagio), nil
+	}
+	estagios, err := s.estagioRepositorio.ListarAtuaisPorAmbiente(ambienteID)
+	if err != nil {
+		return "", err
+	}
+	estagio, ok := estagioPredominante(estagios)
+	if !ok {
+		return entity.EstagioVegetativo, nil
+	}
+	return estagio, nil
+}
+
+// derivarJanela calcula VPD, ponto de orvalho e PPFD da janela e compara com as faixas do estágio
+func derivarJanela(agregado entity.MicroclimaAgregado, offsetFolha, fatorLux float64, faixas calculo.FaixasAlvo) dto.JanelaMicroclimaDTO {
+	temperatura, umidade := agregado.TemperaturaMed, agregado.UmidadeMed
+	janela := dto.JanelaMicroclimaDTO{
+		MicroclimaAgregado: agregado,
+		VPDAr:              calculo.VPDAr(temperatura, umidade),
+		VPDFolha:           calculo.VPDFolha(temperatura, umidade, offsetFolha),
+		PontoOrvalho:       calculo.PontoOrvalho(temperatura, umidade),
+		PPFD:               calculo.LuxParaPPFD(agregado.LuminosidadeMed, fatorLux),
+	}
+	if agregado.PPFDMed != nil {
+		janela.PPFD = *agregado.PPFDMed
+	}
+
+	verificar := func(metrica string, valor float64, faixa calculo.Faixa) {
+		switch {
+		case valor < faixa.Min:
+			janela.ForaDaFaixa = append(janela.ForaDaFaixa, dto.ForaDaFaixaDTO{Metrica: metrica, Valor: valor, Min: faixa.Min, Max: faixa.Max, Situacao: "abaixo"})
+		case valor > faixa.Max:
+			janela.ForaDaFaixa = append(janela.ForaDaFaixa, dto.ForaDaFaixaDTO{Metrica: metrica, Valor: valor, Min: faixa.Min, Max: faixa.Max, Situacao: "acima"})
+		}
+	}
+	verificar("temperatura", temperatura, faixas.Temperatura)
+	verificar("umidade", umidade, faixas.Umidade)
+	verificar("vpd_folha", janela.VPDFolha, faixas.VPDFolha)
+
+	if margem := temperatura + offsetFolha - janela.PontoOrvalho; margem < calculo.MargemOrvalhoMinima {
+		janela.ForaDaFaixa = append(janela.ForaDaFaixa, dto.ForaDaFaixaDTO{
+			Metrica:  "ponto_orvalho",
+			Valor:    janela.PontoOrvalho,
+			Min:      temperatura + offsetFolha - calculo.MargemOrvalhoMinima,
+			Max:      temperatura + offsetFolha,
+			Situacao: "risco_condensacao",
+		})
+	}
+	return janela
+}
+
+// dliDiario soma a integral de luz de cada janela no dia em que ela começa
+func dliDiario(janelas []dto.JanelaMicroclimaDTO, intervalo time.Duration, faixas calculo.FaixasAlvo) []dto.DLIDiarioDTO {
+	var dias []dto.DLIDiarioDTO
+	indice := make(map[string]int)
+	for _, janela := range janelas {
+		data := janela.Inicio.Format("2006-01-02")
+		i, ok := indice[data]
+		if !ok {
+			i = len(dias)
+			indice[data] = i
+			dias = append(dias, dto.DLIDiarioDTO{Data: data})
+		}
+		dias[i].DLI += calculo.IntegralLuz(janela.PPFD, intervalo.Seconds())
+	}
+
+	for i := range dias {
+		dias[i].DLI = math.Round(dias[i].DLI*100) / 100
+		valor := dias[i].DLI
+		switch {
+		case valor < faixas.DLI.Min:
+			dias[i].ForaDaFaixa = &dto.ForaDaFaixaDTO{Metrica: "dli", Valor: valor, Min: faixas.DLI.Min, Max: faixas.DLI.Max, Situacao: "abaixo"}
+		case valor > faixas.DLI.Max:
+			dias[i].ForaDaFaixa = &dto.ForaDaFaixaDTO{Metrica: "dli", Valor: valor, Min: faixas.DLI.Min, Max: faixas.DLI.Max, Situacao: "acima"}
+		}
+	}
+	return dias
+}
+
+// estimarDLI completa cada dia com as horas de luz programadas e o DLI projetado a partir
+// do PPFD médio das janelas com luz
+func (s *microclimaService) estimarDLI(ambienteID uint, janelas []dto.JanelaMicroclimaDTO, dias []dto.DLIDiarioDTO) error {
+	if s.calendarioLuz == nil || len(dias) == 0 {
+		return nil
+	}
+	primeiro, errInicio := time.Parse("2006-01-02", dias[0].Data)
+	ultimo, errFim := time.Parse("2006-01-02", dias[len(dias)-1].Data)
+	if errInicio != nil || errFim != nil {
+		return nil
+	}
+	horas, err := s.calendarioLuz.HorasLuzNoPeriodo(ambienteID, primeiro, ultimo)
+	if err != nil {
+		return fmt.Errorf("falha ao consultar horas de luz do ambiente %d: %w", ambienteID, err)
+	}
+	horasPorDia := make(map[string]float64, len(horas))
+	for _, h := range horas {
+		horasPorDia[h.Data] = h.HorasLuzEfetivas
+	}
+
+	somaPPFD := make(map[string]float64)
+	janelasComLuz := make(map[string]int)
+	for _, janela := range janelas {
+		if janela.PPFD > 0 {
+			data := janela.Inicio.Format("2006-01-02")
+			somaPPFD[data] += janela.PPFD
+			janelasComLuz[data]++
+		}
+	}
+
+	for i := range dias {
+		horasLuz, ok := horasPorDia[dias[i].Data]
+		if !ok {
+			continue
+		}
+		dias[i].HorasLuz = &horasLuz
+		if n := janelasComLuz[dias[i].Data]; n > 0 {
+			estimado := calculo.DLI(somaPPFD[dias[i].Data]/float64(n), horasLuz)
+			dias[i].DLIEstimado = &estimado
+		}
+	}
+	return nil
+}
+
+// notificarObservadores repassa as leituras já persistidas; falhas são registradas em log
+// sem invalidar a ingestão.
+func (s *microclimaService) notificarObservadores(ambienteID uint, leituras []entity.Microclima) {
+	for _, observador := range s.observadores {
+		if err := observador.LeiturasRegistradas(ambienteID, leituras); err != nil {
+			logrus.WithError(err).WithField("ambiente_id", ambienteID).Warn("Falha ao processar leituras de microclima registradas")
+		}
+	}
+}
+
+func (s *microclimaService) validarAmbiente(ambienteID uint) error {
+	_, err := s.buscarAmbiente(ambienteID)
+	return err
+}
+
+func (s *microclimaService) buscarAmbiente(ambienteID uint) (*entity.Ambiente, error) {
+	if ambienteID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	ambiente, err := s.ambienteRepositorio.BuscarPorID(ambienteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar ambiente com ID %d: %w", ambienteID, err)
+	}
+	return ambiente, nil
+}
+
+// validarLeituras exige temperatura e umidade, como o binding, também de quem chama o service
+// sem passar pelo HTTP, e confere as posições
+func (s *microclimaService) validarLeituras(ambienteID uint, leituras []dto.LeituraMicroclimaDTO) error {
+	for i, leitura := range leituras {
+		if leitura.Temperatura == nil || leitura.Umidade == nil {
+			return fmt.Errorf("%w: leitura %d sem temperatura ou umidade", utils.ErrInvalidInput, i+1)
+		}
+	}
+	return s.validarPosicoes(ambienteID, leituras)
+}
+
+// validarPosicoes confere se as leituras com posição caem dentro da planta baixa do ambiente
+func (s *microclimaService) validarPosicoes(ambienteID uint, leituras []dto.LeituraMicroclimaDTO) error {
+	var ambiente *entity.Ambiente
+	for i, leitura := range leituras {
+		if leitura.PosicaoX == nil || leitura.PosicaoY == nil {
+			continue
+		}
+		if ambiente == nil {
+			var err error
+			if ambiente, err = s.buscarAmbiente(ambienteID); err != nil {
+				return err
+			}
+		}
+		if *leitura.PosicaoX > ambiente.Comprimento || *leitura.PosicaoY > ambiente.Largura {
+			return fmt.Errorf("%w: leitura %d fora do ambiente (%.0f x %.0f cm)", utils.ErrInvalidInput, i+1, ambiente.Comprimento, ambiente.Largura)
+		}
+	}
+	return nil
+}
+
+// periodo resolve o intervalo da consulta, usando as últimas 24 horas como padrão
+func (s *microclimaService) periodo(consulta *dto.ConsultaMicroclimaDTO) (time.Time, time.Time, error) {
+	fim := consulta.Fim
+	if fim.IsZero() {
+		fim = s.agora()
+	}
+	inicio := consulta.Inicio
+	if inicio.IsZero() {
+		inicio = fim.Add(-periodoPadraoMicroclima)
+	}
+	if !inicio.Before(fim) {
+		return time.Time{}, time.Time{}, utils.ErrInvalidInput
+	}
+	return inicio, fim, nil
+}
+
+func (s *microclimaService) paraEntidade(ambienteID uint, leituraDto *dto.LeituraMicroclimaDTO) entity.Microclima {
+	dataMedicao := s.agora()
+	if leituraDto.DataMedicao != nil && !leituraDto.DataMedicao.IsZero() {
+		dataMedicao = *leituraDto.DataMedicao
+	}
+	return entity.Microclima{
+		AmbienteID:   ambienteID,
+		DataMedicao:  dataMedicao,
+		Temperatura:  *leituraDto.Temperatura,
+		Umidade:      *leituraDto.Umidade,
+		Luminosidade: leituraDto.Luminosidade,
+		CO2:          leituraDto.CO2,
+		UmidadeSolo:  leituraDto.UmidadeSolo,
+		PPFD:         leituraDto.PPFD,
+		PosicaoX:     leituraDto.PosicaoX,
+		PosicaoY:     leituraDto.PosicaoY,
+	}
+}
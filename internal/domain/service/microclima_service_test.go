@@ -0,0 +1,309 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestMicroclimaService_RegistrarLote(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		data := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		co2 := 800.0
+		lote := &dto.LoteLeiturasMicroclimaDTO{Leituras: []dto.LeituraMicroclimaDTO{
+			{DataMedicao: &data, Temperatura: valor(25), Umidade: valor(60), Luminosidade: 30000, CO2: &co2},
+			{Temperatura: valor(26), Umidade: valor(58), Luminosidade: 31000},
+		}}
+
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		mockRepo.On("CriarEmLote", mock.MatchedBy(func(leituras []entity.Microclima) bool {
+			return len(leituras) == 2 &&
+				leituras[0].AmbienteID == 1 &&
+				leituras[0].DataMedicao.Equal(data) &&
+				*leituras[0].CO2 == co2 &&
+				!leituras[1].DataMedicao.IsZero()
+		})).Return(nil).Once()
+
+		resultado, err := servico.RegistrarLote(1, lote)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resultado.Inseridas)
+		mockRepo.AssertExpectations(t)
+		mockAmbienteRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Leitura Sem Umidade", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+
+		// 0 °C é válida; o que falta é a umidade
+		_, err := servico.RegistrarLote(1, &dto.LoteLeiturasMicroclimaDTO{Leituras: []dto.LeituraMicroclimaDTO{
+			{Temperatura: valor(0)},
+		}})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "CriarEmLote", mock.Anything)
+	})
+
+	t.Run("Error - Ambiente Not Found", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		mockAmbienteRepo.On("BuscarPorID", uint(99)).Return((*entity.Ambiente)(nil), gorm.ErrRecordNotFound).Once()
+
+		resultado, err := servico.RegistrarLote(99, &dto.LoteLeiturasMicroclimaDTO{Leituras: []dto.LeituraMicroclimaDTO{{}}})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.Nil(t, resultado)
+		mockRepo.AssertNotCalled(t, "CriarEmLote", mock.Anything)
+	})
+
+	t.Run("Error - Repository Error", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		mockRepo.On("CriarEmLote", mock.Anything).Return(errors.New("erro no repositório")).Once()
+
+		_, err := servico.RegistrarLote(1, &dto.LoteLeiturasMicroclimaDTO{Leituras: []dto.LeituraMicroclimaDTO{
+			{Temperatura: valor(25), Umidade: valor(60)},
+		}})
+
+		assert.EqualError(t, err, "falha ao registrar lote de leituras do ambiente 1: erro no repositório")
+	})
+
+	t.Run("Error - Posição Fora do Ambiente", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		dentro, fora := 50.0, 150.0
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Comprimento: 120, Largura: 120}, nil)
+
+		_, err := servico.RegistrarLote(1, &dto.LoteLeiturasMicroclimaDTO{Leituras: []dto.LeituraMicroclimaDTO{
+			{Temperatura: valor(25), Umidade: valor(60), PPFD: &dentro, PosicaoX: &dentro, PosicaoY: &dentro},
+			{Temperatura: valor(25), Umidade: valor(60), PPFD: &dentro, PosicaoX: &fora, PosicaoY: &dentro},
+		}})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "leitura 2")
+		mockRepo.AssertNotCalled(t, "CriarEmLote", mock.Anything)
+	})
+
+	t.Run("Error - Ambiente Removido Durante a Validação das Posições", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		posicao := 50.0
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Comprimento: 120, Largura: 120}, nil).Once()
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return((*entity.Ambiente)(nil), gorm.ErrRecordNotFound).Once()
+
+		_, err := servico.RegistrarLote(1, &dto.LoteLeiturasMicroclimaDTO{Leituras: []dto.LeituraMicroclimaDTO{
+			{Temperatura: valor(25), Umidade: valor(60), PosicaoX: &posicao, PosicaoY: &posicao},
+		}})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "CriarEmLote", mock.Anything)
+	})
+}
+
+func TestMicroclimaService_ImportarCSV(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		corpo := strings.NewReader("Hora;T;UR\n01/05/2026 09:00;23,5;70\n01/05/2026 09:05;;70\n")
+		opcoes := &dto.ImportacaoCSVDTO{
+			Delimitador: "ponto_virgula",
+			Decimal:     "virgula",
+			ColunaData:  "Hora",
+			FormatoData: "02/01/2006 15:04",
+			Fuso:        "America/Sao_Paulo",
+			Colunas:     "temperatura=T, umidade=UR",
+		}
+
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		mockRepo.On("CriarEmLote", mock.MatchedBy(func(leituras []entity.Microclima) bool {
+			return len(leituras) == 1 &&
+				leituras[0].AmbienteID == 1 &&
+				leituras[0].Temperatura == 23.5 &&
+				leituras[0].DataMedicao.Equal(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+		})).Return(nil).Once()
+
+		resultado, err := servico.ImportarCSV(1, corpo, opcoes)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resultado.Inseridas)
+		assert.Equal(t, 1, resultado.Rejeitadas)
+		assert.Equal(t, 3, resultado.Rejeicoes[0].Linha)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Mapeamento de Colunas Inválido", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+
+		_, err := servico.ImportarCSV(1, strings.NewReader("data,T,UR\n"), &dto.ImportacaoCSVDTO{Colunas: "pressao=T"})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "CriarEmLote", mock.Anything)
+	})
+
+	t.Run("Error - CSV sem Temperatura", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+
+		_, err := servico.ImportarCSV(1, strings.NewReader("data,umidade\n2026-05-01 12:00,60\n"), &dto.ImportacaoCSVDTO{})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestMicroclimaService_Agregar(t *testing.T) {
+	inicio := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	fim := inicio.Add(6 * time.Hour)
+
+	t.Run("Success - Intervalo Padrão", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		ppfd := 800.0
+		agregados := []entity.MicroclimaAgregado{
+			{Inicio: inicio, Leituras: 120, TemperaturaMed: 24, UmidadeMed: 50, PPFDMed: &ppfd},
+			{Inicio: inicio.Add(time.Hour), Leituras: 120, TemperaturaMed: 33, UmidadeMed: 45, PPFDMed: &ppfd},
+		}
+		estagios := []entity.EstagioCrescimento{
+			{PlantaID: 1, Estagio: entity.EstagioFloracao},
+			{PlantaID: 2, Estagio: entity.EstagioFloracao},
+			{PlantaID: 3, Estagio: entity.EstagioVegetativo},
+		}
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		mockRepo.On("Agregar", uint(1), inicio, fim, time.Hour).Return(agregados, nil).Once()
+		mockEstagioRepo.On("ListarAtuaisPorAmbiente", uint(1)).Return(estagios, nil).Once()
+
+		resultado, err := servico.Agregar(1, &dto.ConsultaMicroclimaDTO{Inicio: inicio, Fim: fim})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1h", resultado.Intervalo)
+		assert.Equal(t, entity.EstagioFloracao, resultado.Estagio)
+		assert.Len(t, resultado.Janelas, 2)
+		assert.InDelta(t, 1.49, resultado.Janelas[0].VPDAr, 0.01)
+		assert.InDelta(t, 1.15, resultado.Janelas[0].VPDFolha, 0.01)
+		assert.InDelta(t, 12.9, resultado.Janelas[0].PontoOrvalho, 0.1)
+		assert.Empty(t, resultado.Janelas[0].ForaDaFaixa)
+		assert.Equal(t, "temperatura", resultado.Janelas[1].ForaDaFaixa[0].Metrica)
+		assert.Equal(t, "acima", resultado.Janelas[1].ForaDaFaixa[0].Situacao)
+		// 800 µmol/m²/s durante duas horas = 5,76 mol/m²
+		assert.Len(t, resultado.DLIDiario, 1)
+		assert.InDelta(t, 5.76, resultado.DLIDiario[0].DLI, 0.001)
+		assert.Equal(t, "abaixo", resultado.DLIDiario[0].ForaDaFaixa.Situacao)
+		mockRepo.AssertExpectations(t)
+		mockEstagioRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - DLI Estimado pelo Fotoperíodo", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		calendario := calendarioLuzFixo{horasEfetivas: 12}
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, calendario)
+
+		ppfd, escuro := 700.0, 0.0
+		agregados := []entity.MicroclimaAgregado{
+			{Inicio: inicio, Leituras: 60, TemperaturaMed: 24, UmidadeMed: 55, PPFDMed: &ppfd},
+			{Inicio: inicio.Add(time.Hour), Leituras: 60, TemperaturaMed: 22, UmidadeMed: 60, PPFDMed: &escuro},
+		}
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		mockRepo.On("Agregar", uint(1), inicio, fim, time.Hour).Return(agregados, nil).Once()
+
+		resultado, err := servico.Agregar(1, &dto.ConsultaMicroclimaDTO{Inicio: inicio, Fim: fim, Estagio: "floracao"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 12.0, *resultado.DLIDiario[0].HorasLuz)
+		// apenas a janela com luz entra na média: 700 µmol/m²/s por 12 horas = 30,24 mol/m²
+		assert.InDelta(t, 30.24, *resultado.DLIDiario[0].DLIEstimado, 0.001)
+	})
+
+	t.Run("Success - Cinco Minutos", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		mockRepo.On("Agregar", uint(1), inicio, fim, 5*time.Minute).Return([]entity.MicroclimaAgregado{}, nil).Once()
+
+		resultado, err := servico.Agregar(1, &dto.ConsultaMicroclimaDTO{Inicio: inicio, Fim: fim, Intervalo: "5m", Estagio: "plantula"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "5m", resultado.Intervalo)
+		assert.Equal(t, entity.EstagioPlantula, resultado.Estagio)
+		mockEstagioRepo.AssertNotCalled(t, "ListarAtuaisPorAmbiente", mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Intervalo Invertido", func(t *testing.T) {
+		mockRepo := new(test.MockMicroclimaRepositorio)
+		mockAmbienteRepo := new(test.MockAmbienteRepositorio)
+		mockEstagioRepo := new(test.MockEstagioCrescimentoRepositorio)
+		servico := service.NewMicroclimaService(mockRepo, mockAmbienteRepo, mockEstagioRepo, nil)
+
+		mockAmbienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+
+		_, err := servico.Agregar(1, &dto.ConsultaMicroclimaDTO{Inicio: fim, Fim: inicio})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Agregar", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// calendarioLuzFixo devolve as mesmas horas de luz para todos os dias
+type calendarioLuzFixo struct {
+	horasEfetivas float64
+}
+
+func (c calendarioLuzFixo) HorasLuzNoPeriodo(_ uint, inicio, fim time.Time) ([]dto.HorasLuzDiaDTO, error) {
+	var dias []dto.HorasLuzDiaDTO
+	for dia := inicio; !dia.After(fim); dia = dia.AddDate(0, 0, 1) {
+		dias = append(dias, dto.HorasLuzDiaDTO{Data: dia.Format("2006-01-02"), HorasLuz: c.horasEfetivas, HorasLuzEfetivas: c.horasEfetivas})
+	}
+	return dias, nil
+}
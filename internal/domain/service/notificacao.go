@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Canais de notificação conhecidos
+const (
+	CanalLembrete = "lembrete" // central de notificações do app; o nome é mantido pelas regras já salvas
+	CanalWebhook  = "webhook"
+	CanalEmail    = "email"
+	CanalTelegram = "telegram"
+)
+
+// limiteNotificacoesPorLeitura limita quantas notificações novas o stream busca de uma vez
+const limiteNotificacoesPorLeitura = 100
+
+// Notificacao é a mensagem entregue ao usuário por um canal de notificação.
+type Notificacao struct {
+	UsuarioID uint                        `json:"usuario_id"`
+	Categoria entity.CategoriaNotificacao `json:"categoria,omitempty"` // vazia vira sistema na central
+	Titulo    string                      `json:"titulo"`
+	Mensagem  string                      `json:"mensagem"`
+	Dados     map[string]any              `json:"dados,omitempty"`
+	// PlantaID, DiarioCultivoID e AmbienteID viram os links da notificação na central
+	PlantaID        *uint `json:"-"`
+	DiarioCultivoID *uint `json:"-"`
+	AmbienteID      *uint `json:"-"`
+	// Destino é o endereço específico do canal (URL do webhook, e-mail, chat do Telegram); vazio para canais internos
+	Destino string `json:"-"`
+}
+
+// CanalNotificacao entrega notificações por um meio específico (lembrete, webhook, e-mail...).
+type CanalNotificacao interface {
+	Nome() string
+	Enviar(ctx context.Context, notificacao Notificacao) error
+}
+
+// NotificacaoService mantém a central de notificações do usuário e avisa os clientes conectados
+// ao stream quando ela muda.
+type NotificacaoService interface {
+	// Publicar grava a notificação na central; categorias desativadas pelo usuário são descartadas
+	// e retornam nil sem erro
+	Publicar(notificacao Notificacao) (*entity.Notificacao, error)
+	Listar(usuarioID uint, consulta *dto.ConsultaNotificacoesDTO) ([]entity.Notificacao, int64, error)
+	ContarNaoLidas(usuarioID uint) (int64, error)
+	MarcarLida(id, usuarioID uint) (*entity.Notificacao, error)
+	MarcarLidas(usuarioID uint, marcarDto *dto.MarcarNotificacoesLidasDTO) (*dto.NotificacoesLidasDTO, error)
+	// ListarDesde retorna as notificações do usuário criadas depois de aposID, da mais antiga para a mais nova
+	ListarDesde(usuarioID, aposID uint) ([]entity.Notificacao, error)
+	UltimoID(usuarioID uint) (uint, error)
+	// Assinar registra um ouvinte das mudanças na central do usuário. Os avisos são só sinais
+	// (coalescidos); o ouvinte busca as novidades com ListarDesde. cancelar libera a assinatura
+	Assinar(usuarioID uint) (avisos <-chan struct{}, cancelar func())
+}
+
+type notificacaoService struct {
+	repositorio            repository.NotificacaoRepositorio
+	preferenciaRepositorio repository.PreferenciaNotificacaoRepositorio
+	agora                  func() time.Time
+
+	mu         sync.Mutex
+	assinantes map[uint]map[chan struct{}]struct{}
+}
+
+func NewNotificacaoService(
+	repositorio repository.NotificacaoRepositorio,
+	preferenciaRepositorio repository.PreferenciaNotificacaoRepositorio,
+) NotificacaoService {
+	return &notificacaoService{
+		repositorio:            repositorio,
+		preferenciaRepositorio: preferenciaRepositorio,
+		agora:                  time.Now,
+		assinantes:             make(map[uint]map[chan struct{}]struct{}),
+	}
+}
+
+func (s *notificacaoService) Publicar(notificacao Notificacao) (*entity.Notificacao, error) {
+	if notificacao.UsuarioID == 0 || notificacao.Titulo == "" {
+		return nil, utils.ErrInvalidInput
+	}
+	categoria := notificacao.Categoria
+	if categoria == "" {
+		categoria = entity.CategoriaNotificacaoSistema
+	}
+
+	preferencia, err := s.preferenciaRepositorio.BuscarPorUsuario(notificacao.UsuarioID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("falha ao buscar preferências de notificação: %w", err)
+	}
+	if preferencia != nil && preferencia.CategoriaDesativada(categoria) {
+		return nil, nil
+	}
+
+	registro := &entity.Notificacao{
+		UsuarioID:       notificacao.UsuarioID,
+		Categoria:       categoria,
+		Titulo:          notificacao.Titulo,
+		Mensagem:        notificacao.Mensagem,
+		PlantaID:        notificacao.PlantaID,
+		DiarioCultivoID: notificacao.DiarioCultivoID,
+		AmbienteID:      notificacao.AmbienteID,
+		Link:            linkNotificacao(notificacao),
+	}
+	if err := s.repositorio.Criar(registro); err != nil {
+		return nil, fmt.Errorf("falha ao criar notificação: %w", err)
+	}
+	s.avisar(notificacao.UsuarioID)
+	return registro, nil
+}
+
+func (s *notificacaoService) Listar(usuarioID uint, consulta *dto.ConsultaNotificacoesDTO) ([]entity.Notificacao, int64, error) {
+	return s.repositorio.Listar(repository.FiltroNotificacoes{
+		UsuarioID: usuarioID,
+		Categoria: consulta.Categoria,
+		NaoLidas:  consulta.NaoLidas,
+		Page:      consulta.Page,
+		Limit:     consulta.Limit,
+	})
+}
+
+func (s *notificacaoService) ContarNaoLidas(usuarioID uint) (int64, error) {
+	return s.repositorio.ContarNaoLidas(usuarioID)
+}
+
+func (s *notificacaoService) MarcarLida(id, usuarioID uint) (*entity.Notificacao, error) {
+	notificacao, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar notificação com ID %d: %w", id, err)
+	}
+	if notificacao.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	if notificacao.Lida {
+		return notificacao, nil
+	}
+
+	agora := s.agora()
+	notificacao.Lida = true
+	notificacao.LidaEm = &agora
+	if err := s.repositorio.Atualizar(notificacao); err != nil {
+		return nil, fmt.Errorf("falha ao marcar notificação com ID %d como lida: %w", id, err)
+	}
+	s.avisar(usuarioID)
+	return notificacao, nil
+}
+
+func (s *notificacaoService) MarcarLidas(usuarioID uint, marcarDto *dto.MarcarNotificacoesLidasDTO) (*dto.NotificacoesLidasDTO, error) {
+	if marcarDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	marcadas, err := s.repositorio.MarcarLidas(usuarioID, marcarDto.IDs, marcarDto.Categoria, s.agora())
+	if err != nil {
+		return nil, err
+	}
+	naoLidas, err := s.repositorio.ContarNaoLidas(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if marcadas > 0 {
+		s.avisar(usuarioID)
+	}
+	return &dto.NotificacoesLidasDTO{Marcadas: marcadas, NaoLidas: naoLidas}, nil
+}
+
+func (s *notificacaoService) ListarDesde(usuarioID, aposID uint) ([]entity.Notificacao, error) {
+	return s.repositorio.ListarDesde(usuarioID, aposID, limiteNotificacoesPorLeitura)
+}
+
+func (s *notificacaoService) UltimoID(usuarioID uint) (uint, error) {
+	return s.repositorio.UltimoID(usuarioID)
+}
+
+func (s *notificacaoService) Assinar(usuarioID uint) (<-chan struct{}, func()) {
+	avisos := make(chan struct{}, 1)
+	s.mu.Lock()
+	if s.assinantes[usuarioID] == nil {
+		s.assinantes[usuarioID] = make(map[chan struct{}]struct{})
+	}
+	s.assinantes[usuarioID][avisos] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	cancelar := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.assinantes[usuarioID], avisos)
+			if len(s.assinantes[usuarioID]) == 0 {
+				delete(s.assinantes, usuarioID)
+			}
+		})
+	}
+	return avisos, cancelar
+}
+
+// avisar sinaliza os assinantes do usuário sem bloquear; um aviso pendente já cobre os seguintes
+func (s *notificacaoService) avisar(usuarioID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for avisos := range s.assinantes[usuarioID] {
+		select {
+		case avisos <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// linkNotificacao aponta para o registro mais específico relacionado à notificação
+func linkNotificacao(notificacao Notificacao) string {
+	switch {
+	case notificacao.PlantaID != nil:
+		return fmt.Sprintf("/plantas/%d", *notificacao.PlantaID)
+	case notificacao.DiarioCultivoID != nil:
+		return fmt.Sprintf("/diarios-cultivo/%d", *notificacao.DiarioCultivoID)
+	case notificacao.AmbienteID != nil:
+		return fmt.Sprintf("/ambientes/%d", *notificacao.AmbienteID)
+	}
+	return ""
+}
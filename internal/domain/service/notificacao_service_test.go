@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func novoNotificacaoService() (service.NotificacaoService, *test.MockNotificacaoRepositorio, *test.MockPreferenciaNotificacaoRepositorio) {
+	repositorio := new(test.MockNotificacaoRepositorio)
+	preferencias := new(test.MockPreferenciaNotificacaoRepositorio)
+	return service.NewNotificacaoService(repositorio, preferencias), repositorio, preferencias
+}
+
+func TestNotificacaoService_Publicar(t *testing.T) {
+	t.Run("Success - Grava Com Link e Avisa os Assinantes", func(t *testing.T) {
+		servico, repositorio, preferencias := novoNotificacaoService()
+		preferencias.On("BuscarPorUsuario", uint(7)).Return((*entity.PreferenciaNotificacao)(nil), gorm.ErrRecordNotFound).Once()
+		repositorio.On("Criar", mock.AnythingOfType("*entity.Notificacao")).Return(nil).Once()
+		avisos, cancelar := servico.Assinar(7)
+		defer cancelar()
+		outroUsuario, cancelarOutro := servico.Assinar(8)
+		defer cancelarOutro()
+
+		plantaID, ambienteID := uint(10), uint(3)
+		notificacao, err := servico.Publicar(service.Notificacao{
+			UsuarioID:  7,
+			Categoria:  entity.CategoriaNotificacaoTarefa,
+			Titulo:     "Tarefa: regar",
+			Mensagem:   "regar agendada para 05/01 09:00.",
+			PlantaID:   &plantaID,
+			AmbienteID: &ambienteID,
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, notificacao)
+		assert.Equal(t, entity.CategoriaNotificacaoTarefa, notificacao.Categoria)
+		assert.Equal(t, "/plantas/10", notificacao.Link)
+		assert.False(t, notificacao.Lida)
+		select {
+		case <-avisos:
+		case <-time.After(time.Second):
+			t.Fatal("assinante do usuário não foi avisado")
+		}
+		assert.Empty(t, outroUsuario)
+	})
+
+	t.Run("Success - Sem Categoria Vira Sistema", func(t *testing.T) {
+		servico, repositorio, preferencias := novoNotificacaoService()
+		preferencias.On("BuscarPorUsuario", uint(7)).Return(&entity.PreferenciaNotificacao{UsuarioID: 7, CategoriasDesativadas: "alerta"}, nil).Once()
+		repositorio.On("Criar", mock.AnythingOfType("*entity.Notificacao")).Return(nil).Once()
+
+		notificacao, err := servico.Publicar(service.Notificacao{UsuarioID: 7, Titulo: "Bem-vindo"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.CategoriaNotificacaoSistema, notificacao.Categoria)
+		assert.Empty(t, notificacao.Link)
+	})
+
+	t.Run("Success - Categoria Desativada É Descartada", func(t *testing.T) {
+		servico, repositorio, preferencias := novoNotificacaoService()
+		preferencias.On("BuscarPorUsuario", uint(7)).Return(&entity.PreferenciaNotificacao{UsuarioID: 7, CategoriasDesativadas: "tarefa,alerta"}, nil).Once()
+
+		notificacao, err := servico.Publicar(service.Notificacao{UsuarioID: 7, Categoria: entity.CategoriaNotificacaoAlerta, Titulo: "Calor na estufa"})
+
+		require.NoError(t, err)
+		assert.Nil(t, notificacao)
+		repositorio.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Sem Usuário", func(t *testing.T) {
+		servico, _, _ := novoNotificacaoService()
+
+		_, err := servico.Publicar(service.Notificacao{Titulo: "Calor na estufa"})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestNotificacaoService_MarcarLida(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servico, repositorio, _ := novoNotificacaoService()
+		notificacao := &entity.Notificacao{UsuarioID: 7, Titulo: "Lembrete"}
+		notificacao.ID = 4
+		repositorio.On("BuscarPorID", uint(4)).Return(notificacao, nil).Once()
+		repositorio.On("Atualizar", notificacao).Return(nil).Once()
+
+		lida, err := servico.MarcarLida(4, 7)
+
+		require.NoError(t, err)
+		assert.True(t, lida.Lida)
+		assert.NotNil(t, lida.LidaEm)
+	})
+
+	t.Run("Error - Notificação de Outro Usuário", func(t *testing.T) {
+		servico, repositorio, _ := novoNotificacaoService()
+		notificacao := &entity.Notificacao{UsuarioID: 8, Titulo: "Lembrete"}
+		repositorio.On("BuscarPorID", uint(4)).Return(notificacao, nil).Once()
+
+		_, err := servico.MarcarLida(4, 7)
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		repositorio.AssertNotCalled(t, "Atualizar", mock.Anything)
+	})
+}
+
+func TestNotificacaoService_MarcarLidas(t *testing.T) {
+	servico, repositorio, _ := novoNotificacaoService()
+	repositorio.On("MarcarLidas", uint(7), []uint(nil), "alerta", mock.AnythingOfType("time.Time")).Return(int64(3), nil).Once()
+	repositorio.On("ContarNaoLidas", uint(7)).Return(int64(2), nil).Once()
+	avisos, cancelar := servico.Assinar(7)
+	cancelar()
+	cancelar()
+
+	resultado, err := servico.MarcarLidas(7, &dto.MarcarNotificacoesLidasDTO{Categoria: "alerta"})
+
+	require.NoError(t, err)
+	assert.Equal(t, &dto.NotificacoesLidasDTO{Marcadas: 3, NaoLidas: 2}, resultado)
+	// assinatura cancelada não recebe mais avisos
+	assert.Empty(t, avisos)
+	repositorio.AssertExpectations(t)
+}
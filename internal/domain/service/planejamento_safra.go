@@ -0,0 +1,338 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Parâmetros do planejamento de safra externa. São médias de cultivo: cada genética responde
+// um pouco diferente, por isso o planejamento indica janelas e riscos, não datas exatas.
+const (
+	// limiarFloracaoHoras é a duração do dia abaixo da qual fotoperiódicas passam a florescer
+	limiarFloracaoHoras = 14.0
+	// diasJuvenil é a idade mínima para a planta fotoperiódica responder aos dias curtos
+	diasJuvenil = 28
+	// diasVegetativoAuto é o vegetativo típico de uma autoflorescente antes da floração
+	diasVegetativoAuto = 28
+	// minDiasVegetativo e maxDiasVegetativo delimitam o vegetativo que gera plantas de bom porte
+	minDiasVegetativo = 42
+	maxDiasVegetativo = 120
+	// minHorasLuzAuto é a duração média do dia abaixo da qual autoflorescentes produzem pouco
+	minHorasLuzAuto = 12.0
+	// tempoFloracaoPadrao é usado quando a genética não informa o tempo de floração
+	tempoFloracaoPadrao = 63
+	// semanasPlanejamento é a quantidade de semeaduras simuladas, uma por semana
+	semanasPlanejamento = 52
+
+	temperaturaGeada    = 2.0   // °C, média das mínimas
+	temperaturaFria     = 10.0  // °C, média das mínimas
+	temperaturaExtrema  = 35.0  // °C, média das máximas
+	precipitacaoChuvosa = 150.0 // mm no mês
+)
+
+// PlanejamentoSafraService planeja a temporada de ambientes externos a partir da latitude,
+// da curva de duração do dia e do clima registrado no último ano.
+type PlanejamentoSafraService interface {
+	Planejar(ambienteID uint, consulta *dto.ConsultaPlanejamentoSafraDTO) (*dto.PlanejamentoSafraDTO, error)
+}
+
+type planejamentoSafraService struct {
+	ambienteRepositorio repository.AmbienteRepositorio
+	geneticaRepositorio repository.GeneticaRepositorio
+	climaRepositorio    repository.ClimaRegistroRepositorio
+	local               *time.Location
+	agora               func() time.Time
+}
+
+// NewPlanejamentoSafraService cria o serviço de planejamento de safra. local é o fuso das datas.
+func NewPlanejamentoSafraService(
+	ambienteRepositorio repository.AmbienteRepositorio,
+	geneticaRepositorio repository.GeneticaRepositorio,
+	climaRepositorio repository.ClimaRegistroRepositorio,
+	local *time.Location,
+) PlanejamentoSafraService {
+	if local == nil {
+		local = time.Local
+	}
+	return &planejamentoSafraService{
+		ambienteRepositorio: ambienteRepositorio,
+		geneticaRepositorio: geneticaRepositorio,
+		climaRepositorio:    climaRepositorio,
+		local:               local,
+		agora:               time.Now,
+	}
+}
+
+// climaMes resume o clima registrado em um mês do calendário
+type climaMes struct {
+	minima, maxima, precipitacao float64
+}
+
+// temporada reúne o que o planejamento precisa saber sobre o local
+type temporada struct {
+	latitude, longitude float64
+	clima               map[time.Month]climaMes
+}
+
+func (t *temporada) horasLuz(dia time.Time) float64 {
+	return calculo.Sol(dia, t.latitude, t.longitude).Duracao.Hours()
+}
+
+// riscosClima lista os riscos climáticos do mês; sem histórico, não há o que avaliar
+func (t *temporada) riscosClima(mes time.Month) (geada, frio, calor, chuva bool) {
+	clima, ok := t.clima[mes]
+	if !ok {
+		return false, false, false, false
+	}
+	geada = clima.minima < temperaturaGeada
+	frio = !geada && clima.minima < temperaturaFria
+	return geada, frio, clima.maxima > temperaturaExtrema, clima.precipitacao > precipitacaoChuvosa
+}
+
+func (s *planejamentoSafraService) Planejar(ambienteID uint, consulta *dto.ConsultaPlanejamentoSafraDTO) (*dto.PlanejamentoSafraDTO, error) {
+	agora := s.agora().In(s.local)
+	inicio := time.Date(agora.Year(), agora.Month(), agora.Day(), 0, 0, 0, 0, s.local)
+	if consulta.Inicio != "" {
+		data, err := time.ParseInLocation("2006-01-02", consulta.Inicio, s.local)
+		if err != nil {
+			return nil, utils.ErrInvalidInput
+		}
+		inicio = data
+	}
+
+	ambiente, err := s.buscarAmbiente(ambienteID)
+	if err != nil {
+		return nil, err
+	}
+	if ambiente.Tipo != "externo" || ambiente.Latitude == nil || ambiente.Longitude == nil {
+		return nil, fmt.Errorf("%w: o planejamento de safra exige um ambiente externo com localização", utils.ErrInvalidInput)
+	}
+	genetica, err := s.buscarGenetica(consulta.GeneticaID)
+	if err != nil {
+		return nil, err
+	}
+	clima, err := s.climaDoUltimoAno(ambienteID, inicio)
+	if err != nil {
+		return nil, err
+	}
+
+	local := &temporada{latitude: *ambiente.Latitude, longitude: *ambiente.Longitude, clima: clima}
+	autoflorescente := geneticaAutoflorescente(genetica)
+	tempoFloracao := genetica.TempoFloracao
+	if tempoFloracao <= 0 {
+		tempoFloracao = tempoFloracaoPadrao
+	}
+
+	planejamento := &dto.PlanejamentoSafraDTO{
+		AmbienteID:        ambienteID,
+		GeneticaID:        genetica.ID,
+		Genetica:          genetica.Nome,
+		Autoflorescente:   autoflorescente,
+		TempoFloracaoDias: tempoFloracao,
+		Latitude:          local.latitude,
+		ClimaHistorico:    len(clima) > 0,
+		Janelas:           []dto.JanelaSemeaduraDTO{},
+		Cenarios:          make([]dto.CenarioSafraDTO, 0, semanasPlanejamento),
+		Meses:             make([]dto.MesSafraDTO, 0, 12),
+	}
+
+	for semana := 0; semana < semanasPlanejamento; semana++ {
+		cenario := simularSafra(local, inicio.AddDate(0, 0, 7*semana), autoflorescente, tempoFloracao)
+		planejamento.Cenarios = append(planejamento.Cenarios, cenario)
+		if !cenario.Recomendada {
+			continue
+		}
+		// semeaduras recomendadas em semanas seguidas formam uma janela
+		if n := len(planejamento.Janelas); n > 0 && semana > 0 && planejamento.Cenarios[semana-1].Recomendada {
+			planejamento.Janelas[n-1].Fim = cenario.Semeadura
+		} else {
+			planejamento.Janelas = append(planejamento.Janelas, dto.JanelaSemeaduraDTO{Inicio: cenario.Semeadura, Fim: cenario.Semeadura})
+		}
+	}
+
+	primeiroMes := time.Date(inicio.Year(), inicio.Month(), 15, 0, 0, 0, 0, s.local)
+	for i := 0; i < 12; i++ {
+		planejamento.Meses = append(planejamento.Meses, resumirMes(local, primeiroMes.AddDate(0, i, 0), autoflorescente))
+	}
+	return planejamento, nil
+}
+
+// simularSafra prevê floração e colheita de uma semeadura e lista os riscos do ciclo
+func simularSafra(local *temporada, semeadura time.Time, autoflorescente bool, tempoFloracao int) dto.CenarioSafraDTO {
+	cenario := dto.CenarioSafraDTO{Semeadura: semeadura.Format("2006-01-02")}
+
+	var floracao time.Time
+	if autoflorescente {
+		floracao = semeadura.AddDate(0, 0, diasVegetativoAuto)
+	} else {
+		// fotoperiódicas florescem no primeiro dia curto depois da fase juvenil
+		encontrada := false
+		for dia := semeadura.AddDate(0, 0, diasJuvenil); dia.Before(semeadura.AddDate(1, 0, 0)); dia = dia.AddDate(0, 0, 1) {
+			if local.horasLuz(dia) < limiarFloracaoHoras {
+				floracao, encontrada = dia, true
+				break
+			}
+		}
+		if !encontrada {
+			cenario.Riscos = append(cenario.Riscos,
+				fmt.Sprintf("os dias não ficam abaixo de %sh no ano seguinte: floração natural incerta", formatarValor(limiarFloracaoHoras)))
+			return cenario
+		}
+	}
+	colheita := floracao.AddDate(0, 0, tempoFloracao)
+	cenario.InicioFloracao = floracao.Format("2006-01-02")
+	cenario.Colheita = colheita.Format("2006-01-02")
+	cenario.DiasVegetativo = int(floracao.Sub(semeadura).Hours()/24 + 0.5)
+	cenario.HorasLuzFloracao = calculo.Arredondar(local.horasLuz(floracao), 2)
+
+	if autoflorescente {
+		var soma float64
+		dias := 0
+		for dia := semeadura; dia.Before(colheita); dia = dia.AddDate(0, 0, 7) {
+			soma += local.horasLuz(dia)
+			dias++
+		}
+		if dias > 0 && soma/float64(dias) < minHorasLuzAuto {
+			cenario.Riscos = append(cenario.Riscos, "dias curtos durante o ciclo reduzem a produção de autoflorescentes")
+		}
+	} else {
+		switch {
+		case cenario.DiasVegetativo < minDiasVegetativo:
+			cenario.Riscos = append(cenario.Riscos,
+				fmt.Sprintf("vegetativo curto (%d dias): planta pequena e pouca produção", cenario.DiasVegetativo))
+		case cenario.DiasVegetativo > maxDiasVegetativo:
+			cenario.Riscos = append(cenario.Riscos,
+				fmt.Sprintf("vegetativo longo (%d dias): planta muito grande para o espaço", cenario.DiasVegetativo))
+		}
+		if local.horasLuz(floracao.AddDate(0, 0, 14)) > local.horasLuz(floracao) {
+			cenario.Riscos = append(cenario.Riscos, "floração com os dias ainda crescendo: risco de revegetação")
+		}
+	}
+
+	for mes := time.Date(semeadura.Year(), semeadura.Month(), 1, 0, 0, 0, 0, semeadura.Location()); !mes.After(colheita); mes = mes.AddDate(0, 1, 0) {
+		geada, frio, calor, chuva := local.riscosClima(mes.Month())
+		emFloracao := mes.AddDate(0, 1, 0).After(floracao)
+		rotulo := mes.Format("01/2006")
+		if geada {
+			cenario.Riscos = append(cenario.Riscos, fmt.Sprintf("geada em %s", rotulo))
+		}
+		if frio && emFloracao {
+			cenario.Riscos = append(cenario.Riscos, fmt.Sprintf("noites frias na floração em %s", rotulo))
+		}
+		if calor {
+			cenario.Riscos = append(cenario.Riscos, fmt.Sprintf("calor extremo em %s", rotulo))
+		}
+		if chuva && emFloracao {
+			cenario.Riscos = append(cenario.Riscos, fmt.Sprintf("chuvas intensas na floração em %s: risco de mofo", rotulo))
+		}
+	}
+	cenario.Recomendada = len(cenario.Riscos) == 0
+	return cenario
+}
+
+// resumirMes descreve a luz e o clima esperados no mês de dia15
+func resumirMes(local *temporada, dia15 time.Time, autoflorescente bool) dto.MesSafraDTO {
+	horasLuz := local.horasLuz(dia15)
+	mes := dto.MesSafraDTO{Mes: dia15.Format("2006-01"), HorasLuz: calculo.Arredondar(horasLuz, 2)}
+	if clima, ok := local.clima[dia15.Month()]; ok {
+		minima, maxima, precipitacao := calculo.Arredondar(clima.minima, 1), calculo.Arredondar(clima.maxima, 1), calculo.Arredondar(clima.precipitacao, 1)
+		mes.TemperaturaMin, mes.TemperaturaMax, mes.Precipitacao = &minima, &maxima, &precipitacao
+	}
+
+	if !autoflorescente && horasLuz < limiarFloracaoHoras {
+		mes.Riscos = append(mes.Riscos,
+			fmt.Sprintf("dias abaixo de %sh: fotoperiódicas semeadas neste mês florescem cedo", formatarValor(limiarFloracaoHoras)))
+	}
+	geada, frio, calor, chuva := local.riscosClima(dia15.Month())
+	if geada {
+		mes.Riscos = append(mes.Riscos, "geada")
+	}
+	if frio {
+		mes.Riscos = append(mes.Riscos, "noites frias")
+	}
+	if calor {
+		mes.Riscos = append(mes.Riscos, "calor extremo")
+	}
+	if chuva {
+		mes.Riscos = append(mes.Riscos, "chuvas intensas")
+	}
+	return mes
+}
+
+// climaDoUltimoAno agrupa por mês os registros de clima dos 365 dias anteriores ao início.
+// A precipitação é extrapolada para o mês inteiro quando faltam dias.
+func (s *planejamentoSafraService) climaDoUltimoAno(ambienteID uint, inicio time.Time) (map[time.Month]climaMes, error) {
+	registros, err := s.climaRepositorio.ListarPorAmbiente(ambienteID, inicio.AddDate(-1, 0, 0), inicio.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	type acumulado struct {
+		minima, maxima, precipitacao float64
+		dias                         int
+	}
+	porMes := make(map[time.Month]*acumulado)
+	for _, registro := range registros {
+		a, ok := porMes[registro.Data.Month()]
+		if !ok {
+			a = &acumulado{}
+			porMes[registro.Data.Month()] = a
+		}
+		a.minima += registro.TemperaturaMin
+		a.maxima += registro.TemperaturaMax
+		a.precipitacao += registro.Precipitacao
+		a.dias++
+	}
+
+	clima := make(map[time.Month]climaMes, len(porMes))
+	for mes, a := range porMes {
+		diasNoMes := time.Date(2001, mes+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		clima[mes] = climaMes{
+			minima:       a.minima / float64(a.dias),
+			maxima:       a.maxima / float64(a.dias),
+			precipitacao: a.precipitacao * float64(diasNoMes) / float64(a.dias),
+		}
+	}
+	return clima, nil
+}
+
+func (s *planejamentoSafraService) buscarAmbiente(ambienteID uint) (*entity.Ambiente, error) {
+	if ambienteID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	ambiente, err := s.ambienteRepositorio.BuscarPorID(ambienteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar ambiente com ID %d: %w", ambienteID, err)
+	}
+	return ambiente, nil
+}
+
+func (s *planejamentoSafraService) buscarGenetica(geneticaID uint) (*entity.Genetica, error) {
+	if geneticaID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	genetica, err := s.geneticaRepositorio.BuscarPorID(geneticaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar genética com ID %d: %w", geneticaID, err)
+	}
+	return genetica, nil
+}
+
+// geneticaAutoflorescente indica se a genética floresce independente do fotoperíodo
+func geneticaAutoflorescente(genetica *entity.Genetica) bool {
+	return genetica.TipoEspecie == "automatica" || genetica.TipoGenetica == "ruderalis"
+}
@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mocksPlanejamentoSafra struct {
+	ambienteRepo *test.MockAmbienteRepositorio
+	geneticaRepo *test.MockGeneticaRepositorio
+	climaRepo    *test.MockClimaRegistroRepositorio
+}
+
+func novoPlanejamentoSafraService() (service.PlanejamentoSafraService, *mocksPlanejamentoSafra) {
+	m := &mocksPlanejamentoSafra{
+		ambienteRepo: new(test.MockAmbienteRepositorio),
+		geneticaRepo: new(test.MockGeneticaRepositorio),
+		climaRepo:    new(test.MockClimaRegistroRepositorio),
+	}
+	return service.NewPlanejamentoSafraService(m.ambienteRepo, m.geneticaRepo, m.climaRepo, time.UTC), m
+}
+
+// cenarioDe busca o cenário da semeadura informada
+func cenarioDe(t *testing.T, planejamento *dto.PlanejamentoSafraDTO, semeadura string) dto.CenarioSafraDTO {
+	t.Helper()
+	for _, cenario := range planejamento.Cenarios {
+		if cenario.Semeadura == semeadura {
+			return cenario
+		}
+	}
+	require.Failf(t, "cenário não encontrado", "semeadura %s", semeadura)
+	return dto.CenarioSafraDTO{}
+}
+
+func TestPlanejamentoSafraService_Planejar(t *testing.T) {
+	fotoperiodica := &entity.Genetica{Model: gorm.Model{ID: 5}, Nome: "Skunk", TipoGenetica: "hibrido", TipoEspecie: "feminizada", TempoFloracao: 63}
+	consulta := &dto.ConsultaPlanejamentoSafraDTO{GeneticaID: 5, Inicio: "2026-03-01"}
+
+	t.Run("Success - Fotoperiódica no Hemisfério Norte", func(t *testing.T) {
+		servico, m := novoPlanejamentoSafraService()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(ambienteExterno(45, 7), nil).Once()
+		m.geneticaRepo.On("BuscarPorID", uint(5)).Return(fotoperiodica, nil).Once()
+		m.climaRepo.On("ListarPorAmbiente", uint(1), mock.Anything, mock.Anything).Return([]entity.ClimaRegistro{}, nil).Once()
+
+		planejamento, err := servico.Planejar(1, consulta)
+
+		require.NoError(t, err)
+		assert.False(t, planejamento.Autoflorescente)
+		assert.False(t, planejamento.ClimaHistorico)
+		require.Len(t, planejamento.Cenarios, 52)
+		require.Len(t, planejamento.Meses, 12)
+		assert.Equal(t, "2026-03", planejamento.Meses[0].Mes)
+		assert.Greater(t, planejamento.Meses[3].HorasLuz, 15.0)
+
+		// em março a fase juvenil termina com dias curtos ainda crescendo
+		marco := cenarioDe(t, planejamento, "2026-03-01")
+		assert.False(t, marco.Recomendada)
+		assert.Contains(t, marco.Riscos, "floração com os dias ainda crescendo: risco de revegetação")
+
+		// em maio a floração começa com os dias encurtando em agosto
+		maio := cenarioDe(t, planejamento, "2026-05-17")
+		assert.True(t, maio.Recomendada, maio.Riscos)
+		assert.Equal(t, "2026-08", maio.InicioFloracao[:7])
+		assert.Equal(t, "2026-10", maio.Colheita[:7])
+		assert.Less(t, maio.HorasLuzFloracao, 14.0)
+
+		require.NotEmpty(t, planejamento.Janelas)
+		assert.LessOrEqual(t, planejamento.Janelas[0].Inicio, "2026-05-17")
+	})
+
+	t.Run("Success - Chuva na Colheita Registrada no Último Ano", func(t *testing.T) {
+		servico, m := novoPlanejamentoSafraService()
+		var outubro []entity.ClimaRegistro
+		for dia := 1; dia <= 31; dia++ {
+			outubro = append(outubro, entity.ClimaRegistro{
+				Data: time.Date(2025, 10, dia, 0, 0, 0, 0, time.UTC), TemperaturaMin: 12, TemperaturaMax: 22, Precipitacao: 10,
+			})
+		}
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(ambienteExterno(45, 7), nil).Once()
+		m.geneticaRepo.On("BuscarPorID", uint(5)).Return(fotoperiodica, nil).Once()
+		m.climaRepo.On("ListarPorAmbiente", uint(1), mock.Anything, mock.Anything).Return(outubro, nil).Once()
+
+		planejamento, err := servico.Planejar(1, consulta)
+
+		require.NoError(t, err)
+		assert.True(t, planejamento.ClimaHistorico)
+		maio := cenarioDe(t, planejamento, "2026-05-17")
+		assert.False(t, maio.Recomendada)
+		assert.Contains(t, maio.Riscos, "chuvas intensas na floração em 10/2026: risco de mofo")
+		assert.Contains(t, planejamento.Meses[7].Riscos, "chuvas intensas")
+	})
+
+	t.Run("Success - Autoflorescente com Dias Curtos", func(t *testing.T) {
+		servico, m := novoPlanejamentoSafraService()
+		auto := &entity.Genetica{Model: gorm.Model{ID: 6}, TipoGenetica: "hibrido", TipoEspecie: "automatica", TempoFloracao: 56}
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(ambienteExterno(-23.55, -46.63), nil).Once()
+		m.geneticaRepo.On("BuscarPorID", uint(6)).Return(auto, nil).Once()
+		m.climaRepo.On("ListarPorAmbiente", uint(1), mock.Anything, mock.Anything).Return([]entity.ClimaRegistro{}, nil).Once()
+
+		planejamento, err := servico.Planejar(1, &dto.ConsultaPlanejamentoSafraDTO{GeneticaID: 6, Inicio: "2026-05-01"})
+
+		require.NoError(t, err)
+		assert.True(t, planejamento.Autoflorescente)
+		inverno := planejamento.Cenarios[0]
+		assert.Equal(t, "2026-05-29", inverno.InicioFloracao)
+		assert.Equal(t, "2026-07-24", inverno.Colheita)
+		assert.False(t, inverno.Recomendada)
+		verao := cenarioDe(t, planejamento, "2026-10-30")
+		assert.True(t, verao.Recomendada, verao.Riscos)
+	})
+
+	t.Run("Error - Ambiente sem Localização", func(t *testing.T) {
+		servico, m := novoPlanejamentoSafraService()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{Tipo: "externo"}, nil).Once()
+
+		_, err := servico.Planejar(1, consulta)
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.geneticaRepo.AssertNotCalled(t, "BuscarPorID", mock.Anything)
+	})
+}
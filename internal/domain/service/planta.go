@@ -100,20 +100,21 @@ func (s *plantaService) Criar(plantaDto *dto.CreatePlantaDTO) (*dto.PlantaRespon
 		return nil, err
 	}
 	return &dto.PlantaResponseDTO{
-		ID:            planta.ID,
-		Nome:          planta.Nome,
-		ComecandoDe:   planta.ComecandoDe,
-		Especie:       string(planta.Especie),
-		DataPlantio:   utils.TimePtr(utils.DereferenceTimePtr(planta.DataPlantio)),
-		DataColheita:  planta.DataColheita,
-		Status:        string(planta.Status),
-		Notas:         utils.StringPtr(utils.DereferenceStringPtr(planta.Notas)),
-		GeneticaID:    planta.GeneticaID,
-		MeioCultivoID: planta.MeioCultivoID,
-		AmbienteID:    planta.AmbienteID,
-		UsuarioID:     planta.UsuarioID,
-		CreatedAt:     planta.CreatedAt,
-		UpdatedAt:     planta.UpdatedAt,
+		ID:             planta.ID,
+		Nome:           planta.Nome,
+		ComecandoDe:    planta.ComecandoDe,
+		Especie:        string(planta.Especie),
+		DataPlantio:    utils.TimePtr(utils.DereferenceTimePtr(planta.DataPlantio)),
+		DataColheita:   planta.DataColheita,
+		PesoSecoGramas: planta.PesoSecoGramas,
+		Status:         string(planta.Status),
+		Notas:          utils.StringPtr(utils.DereferenceStringPtr(planta.Notas)),
+		GeneticaID:     planta.GeneticaID,
+		MeioCultivoID:  planta.MeioCultivoID,
+		AmbienteID:     planta.AmbienteID,
+		UsuarioID:      planta.UsuarioID,
+		CreatedAt:      planta.CreatedAt,
+		UpdatedAt:      planta.UpdatedAt,
 	}, nil
 }
 
@@ -126,20 +127,21 @@ func (s *plantaService) BuscarPorID(id uint) (*dto.PlantaResponseDTO, error) {
 		return nil, fmt.Errorf("falha ao obter planta com ID %d: %w", id, err)
 	}
 	return &dto.PlantaResponseDTO{
-		ID:            planta.ID,
-		Nome:          planta.Nome,
-		ComecandoDe:   planta.ComecandoDe,
-		Especie:       string(planta.Especie),
-		DataPlantio:   utils.TimePtr(utils.DereferenceTimePtr(planta.DataPlantio)),
-		DataColheita:  planta.DataColheita,
-		Status:        string(planta.Status),
-		Notas:         utils.StringPtr(utils.DereferenceStringPtr(planta.Notas)),
-		GeneticaID:    planta.GeneticaID,
-		MeioCultivoID: planta.MeioCultivoID,
-		AmbienteID:    planta.AmbienteID,
-		UsuarioID:     planta.UsuarioID,
-		CreatedAt:     planta.CreatedAt,
-		UpdatedAt:     planta.UpdatedAt,
+		ID:             planta.ID,
+		Nome:           planta.Nome,
+		ComecandoDe:    planta.ComecandoDe,
+		Especie:        string(planta.Especie),
+		DataPlantio:    utils.TimePtr(utils.DereferenceTimePtr(planta.DataPlantio)),
+		DataColheita:   planta.DataColheita,
+		PesoSecoGramas: planta.PesoSecoGramas,
+		Status:         string(planta.Status),
+		Notas:          utils.StringPtr(utils.DereferenceStringPtr(planta.Notas)),
+		GeneticaID:     planta.GeneticaID,
+		MeioCultivoID:  planta.MeioCultivoID,
+		AmbienteID:     planta.AmbienteID,
+		UsuarioID:      planta.UsuarioID,
+		CreatedAt:      planta.CreatedAt,
+		UpdatedAt:      planta.UpdatedAt,
 	}, nil
 }
 
@@ -152,20 +154,21 @@ func (s *plantaService) ListarTodas(page, limit int) ([]dto.PlantaResponseDTO, i
 	responseDTOs := make([]dto.PlantaResponseDTO, 0, len(plantas))
 	for _, planta := range plantas {
 		responseDTOs = append(responseDTOs, dto.PlantaResponseDTO{
-			ID:            planta.ID,
-			Nome:          planta.Nome,
-			ComecandoDe:   planta.ComecandoDe,
-			Especie:       string(planta.Especie),
-			DataPlantio:   utils.TimePtr(utils.DereferenceTimePtr(planta.DataPlantio)),
-			DataColheita:  planta.DataColheita,
-			Status:        string(planta.Status),
-			Notas:         utils.StringPtr(utils.DereferenceStringPtr(planta.Notas)),
-			GeneticaID:    planta.GeneticaID,
-			MeioCultivoID: planta.MeioCultivoID,
-			AmbienteID:    planta.AmbienteID,
-			UsuarioID:     planta.UsuarioID,
-			CreatedAt:     planta.CreatedAt,
-			UpdatedAt:     planta.UpdatedAt,
+			ID:             planta.ID,
+			Nome:           planta.Nome,
+			ComecandoDe:    planta.ComecandoDe,
+			Especie:        string(planta.Especie),
+			DataPlantio:    utils.TimePtr(utils.DereferenceTimePtr(planta.DataPlantio)),
+			DataColheita:   planta.DataColheita,
+			PesoSecoGramas: planta.PesoSecoGramas,
+			Status:         string(planta.Status),
+			Notas:          utils.StringPtr(utils.DereferenceStringPtr(planta.Notas)),
+			GeneticaID:     planta.GeneticaID,
+			MeioCultivoID:  planta.MeioCultivoID,
+			AmbienteID:     planta.AmbienteID,
+			UsuarioID:      planta.UsuarioID,
+			CreatedAt:      planta.CreatedAt,
+			UpdatedAt:      planta.UpdatedAt,
 		})
 	}
 
@@ -194,6 +197,9 @@ func (s *plantaService) Atualizar(id uint, plantaDto *dto.UpdatePlantaDTO) (*dto
 	if !plantaDto.DataColheita.IsZero() {
 		plantaExistente.DataColheita = &plantaDto.DataColheita
 	}
+	if plantaDto.PesoSecoGramas != nil {
+		plantaExistente.PesoSecoGramas = plantaDto.PesoSecoGramas
+	}
 	if plantaDto.Status != "" {
 		plantaExistente.Status = entity.PlantaStatus(plantaDto.Status)
 	}
@@ -248,20 +254,21 @@ func (s *plantaService) Atualizar(id uint, plantaDto *dto.UpdatePlantaDTO) (*dto
 	}
 
 	return &dto.PlantaResponseDTO{
-		ID:            plantaExistente.ID,
-		Nome:          plantaExistente.Nome,
-		ComecandoDe:   plantaExistente.ComecandoDe,
-		Especie:       string(plantaExistente.Especie),
-		DataPlantio:   utils.TimePtr(utils.DereferenceTimePtr(plantaExistente.DataPlantio)),
-		DataColheita:  plantaExistente.DataColheita,
-		Status:        string(plantaExistente.Status),
-		Notas:         utils.StringPtr(utils.DereferenceStringPtr(plantaExistente.Notas)),
-		GeneticaID:    plantaExistente.GeneticaID,
-		MeioCultivoID: plantaExistente.MeioCultivoID,
-		AmbienteID:    plantaExistente.AmbienteID,
-		UsuarioID:     plantaExistente.UsuarioID,
-		CreatedAt:     plantaExistente.CreatedAt,
-		UpdatedAt:     plantaExistente.UpdatedAt,
+		ID:             plantaExistente.ID,
+		Nome:           plantaExistente.Nome,
+		ComecandoDe:    plantaExistente.ComecandoDe,
+		Especie:        string(plantaExistente.Especie),
+		DataPlantio:    utils.TimePtr(utils.DereferenceTimePtr(plantaExistente.DataPlantio)),
+		DataColheita:   plantaExistente.DataColheita,
+		PesoSecoGramas: plantaExistente.PesoSecoGramas,
+		Status:         string(plantaExistente.Status),
+		Notas:          utils.StringPtr(utils.DereferenceStringPtr(plantaExistente.Notas)),
+		GeneticaID:     plantaExistente.GeneticaID,
+		MeioCultivoID:  plantaExistente.MeioCultivoID,
+		AmbienteID:     plantaExistente.AmbienteID,
+		UsuarioID:      plantaExistente.UsuarioID,
+		CreatedAt:      plantaExistente.CreatedAt,
+		UpdatedAt:      plantaExistente.UpdatedAt,
 	}, nil
 }
 
@@ -0,0 +1,7 @@
+package service
+
+// PublicadorComandos publica comandos para atuadores (relés, exaustores, iluminação...).
+// Tópicos fora dos prefixos permitidos devem retornar um erro que envolva utils.ErrInvalidInput.
+type PublicadorComandos interface {
+	Publicar(topico string, payload []byte, retain bool) error
+}
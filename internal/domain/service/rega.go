@@ -0,0 +1,531 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RegaService registra as regas e fertirrigações das plantas e lotes, mantém as receitas de
+// nutrientes do usuário e resume EC, pH e água usada por planta e por ciclo.
+type RegaService interface {
+	Criar(usuarioID uint, regaDto *dto.RegaDTO) (*entity.Rega, error)
+	BuscarPorID(id, usuarioID uint) (*entity.Rega, error)
+	Listar(usuarioID uint, consulta *dto.ConsultaRegasDTO) ([]entity.Rega, int64, error)
+	Atualizar(id, usuarioID uint, regaDto *dto.RegaDTO) (*entity.Rega, error)
+	Deletar(id, usuarioID uint) error
+	// GraficoPlanta retorna a série de EC/pH das regas da planta, incluindo as do lote dos diários dela
+	GraficoPlanta(plantaID, usuarioID uint, consulta *dto.ConsultaGraficoRegaDTO) (*dto.GraficoRegaPlantaDTO, error)
+	// ResumoCiclo totaliza a água e os nutrientes usados no ciclo do diário de cultivo
+	ResumoCiclo(diarioID, usuarioID uint) (*dto.ResumoRegaCicloDTO, error)
+
+	CriarReceita(usuarioID uint, receitaDto *dto.ReceitaNutrienteDTO) (*entity.ReceitaNutriente, error)
+	ListarReceitas(usuarioID uint) ([]entity.ReceitaNutriente, error)
+	BuscarReceita(id, usuarioID uint) (*entity.ReceitaNutriente, error)
+	AtualizarReceita(id, usuarioID uint, receitaDto *dto.ReceitaNutrienteDTO) (*entity.ReceitaNutriente, error)
+	DeletarReceita(id, usuarioID uint) error
+}
+
+type regaService struct {
+	repositorio        repository.RegaRepositorio
+	receitaRepositorio repository.ReceitaNutrienteRepositorio
+	plantaRepositorio  repository.PlantaRepositorio
+	diarioRepositorio  repository.DiarioCultivoRepositorio
+	observadores       []ObservadorRega
+	local              *time.Location
+	agora              func() time.Time
+}
+
+// NewRegaService cria o serviço de regas; local é o fuso dos filtros por dia. Os observadores
+// recebem as regas registradas, como o estoque que dá baixa nos nutrientes.
+func NewRegaService(
+	repositorio repository.RegaRepositorio,
+	receitaRepositorio repository.ReceitaNutrienteRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	diarioRepositorio repository.DiarioCultivoRepositorio,
+	local *time.Location,
+	observadores ...ObservadorRega,
+) RegaService {
+	if local == nil {
+		local = time.Local
+	}
+	return &regaService{
+		repositorio:        repositorio,
+		receitaRepositorio: receitaRepositorio,
+		plantaRepositorio:  plantaRepositorio,
+		diarioRepositorio:  diarioRepositorio,
+		observadores:       observadores,
+		local:              local,
+		agora:              time.Now,
+	}
+}
+
+func (s *regaService) Criar(usuarioID uint, regaDto *dto.RegaDTO) (*entity.Rega, error) {
+	if usuarioID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	rega := &entity.Rega{UsuarioID: usuarioID}
+	if err := s.aplicarRegaDTO(rega, regaDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Criar(rega); err != nil {
+		return nil, fmt.Errorf("falha ao registrar rega: %w", err)
+	}
+	// falhas dos observadores (ex.: baixa no estoque) não desfazem a rega
+	for _, observador := range s.observadores {
+		if err := observador.RegaRegistrada(rega); err != nil {
+			logrus.WithError(err).WithField("rega_id", rega.ID).Warn("Falha ao processar rega registrada")
+		}
+	}
+	return rega, nil
+}
+
+func (s *regaService) BuscarPorID(id, usuarioID uint) (*entity.Rega, error) {
+	return s.buscarRega(id, usuarioID)
+}
+
+func (s *regaService) Listar(usuarioID uint, consulta *dto.ConsultaRegasDTO) ([]entity.Rega, int64, error) {
+	de, ate, err := s.periodo(consulta.De, consulta.Ate)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.repositorio.Listar(repository.FiltroRegas{
+		UsuarioID:       usuarioID,
+		PlantaID:        consulta.PlantaID,
+		DiarioCultivoID: consulta.DiarioCultivoID,
+		De:              de,
+		Ate:             ate,
+		Page:            consulta.Page,
+		Limit:           consulta.Limit,
+	})
+}
+
+func (s *regaService) Atualizar(id, usuarioID uint, regaDto *dto.RegaDTO) (*entity.Rega, error) {
+	rega, err := s.buscarRega(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.aplicarRegaDTO(rega, regaDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Atualizar(rega); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar rega com ID %d: %w", id, err)
+	}
+	return rega, nil
+}
+
+func (s *regaService) Deletar(id, usuarioID uint) error {
+	if _, err := s.buscarRega(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar rega com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *regaService) GraficoPlanta(plantaID, usuarioID uint, consulta *dto.ConsultaGraficoRegaDTO) (*dto.GraficoRegaPlantaDTO, error) {
+	planta, err := s.plantaRepositorio.BuscarPorID(plantaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar planta com ID %d: %w", plantaID, err)
+	}
+	if planta.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	de, ate, err := s.periodo(consulta.De, consulta.Ate)
+	if err != nil {
+		return nil, err
+	}
+	regas, err := s.repositorio.ListarPorPlanta(plantaID, de, ate)
+	if err != nil {
+		return nil, err
+	}
+
+	grafico := &dto.GraficoRegaPlantaDTO{PlantaID: plantaID, Pontos: make([]dto.PontoGraficoRegaDTO, 0, len(regas))}
+	plantasPorDiario := make(map[uint]int)
+	for _, rega := range regas {
+		volume := rega.VolumeLitros
+		lote := rega.DiarioCultivoID != nil
+		if lote {
+			plantas, ok := plantasPorDiario[*rega.DiarioCultivoID]
+			if !ok {
+				doDiario, err := s.plantaRepositorio.ListarPorDiario(*rega.DiarioCultivoID)
+				if err != nil {
+					return nil, err
+				}
+				plantas = len(doDiario)
+				plantasPorDiario[*rega.DiarioCultivoID] = plantas
+			}
+			if plantas > 0 {
+				volume /= float64(plantas)
+			}
+		}
+		grafico.Regas++
+		grafico.TotalLitros += volume
+		grafico.Pontos = append(grafico.Pontos, dto.PontoGraficoRegaDTO{
+			RegaID:       rega.ID,
+			Data:         rega.Data,
+			Lote:         lote,
+			VolumeLitros: calculo.Arredondar(volume, 3),
+			PHEntrada:    rega.PHEntrada,
+			ECEntrada:    rega.ECEntrada,
+			PHRunoff:     rega.PHRunoff,
+			ECRunoff:     rega.ECRunoff,
+		})
+	}
+	grafico.TotalLitros = calculo.Arredondar(grafico.TotalLitros, 3)
+	return grafico, nil
+}
+
+func (s *regaService) ResumoCiclo(diarioID, usuarioID uint) (*dto.ResumoRegaCicloDTO, error) {
+	diario, err := s.diarioRepositorio.GetByID(diarioID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar diário de cultivo com ID %d: %w", diarioID, err)
+	}
+	if diario.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	plantas, err := s.plantaRepositorio.ListarPorDiario(diarioID)
+	if err != nil {
+		return nil, err
+	}
+	regas, err := s.repositorio.ListarPorDiario(diarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	resumo := &dto.ResumoRegaCicloDTO{
+		DiarioCultivoID: diarioID,
+		PorPlanta:       make([]dto.ConsumoPlantaRegaDTO, 0, len(plantas)),
+		Nutrientes:      []dto.TotalNutrienteDTO{},
+	}
+	porPlanta := make(map[uint]float64, len(plantas))
+	nutrientes := make(map[string]*dto.TotalNutrienteDTO)
+	var somaPH, somaEC float64
+	var leiturasPH, leiturasEC int
+	for _, rega := range regas {
+		if rega.PlantaID != nil && !dentroDoCiclo(diario, rega.Data) {
+			// rega individual de uma planta que também participa de outro ciclo
+			continue
+		}
+		if rega.DiarioCultivoID != nil {
+			if len(plantas) > 0 {
+				parte := rega.VolumeLitros / float64(len(plantas))
+				for _, planta := range plantas {
+					porPlanta[planta.ID] += parte
+				}
+			}
+		} else {
+			porPlanta[*rega.PlantaID] += rega.VolumeLitros
+		}
+
+		resumo.Regas++
+		resumo.TotalLitros += rega.VolumeLitros
+		if resumo.Primeira == nil {
+			primeira := rega.Data
+			resumo.Primeira = &primeira
+		}
+		ultima := rega.Data
+		resumo.Ultima = &ultima
+		if rega.PHEntrada != nil {
+			somaPH += *rega.PHEntrada
+			leiturasPH++
+		}
+		if rega.ECEntrada != nil {
+			somaEC += *rega.ECEntrada
+			leiturasEC++
+		}
+		for _, nutriente := range rega.Nutrientes {
+			unidade := unidadeTotalNutriente(nutriente.Unidade)
+			chave := strings.ToLower(strings.TrimSpace(nutriente.Nutriente)) + "|" + unidade
+			total, ok := nutrientes[chave]
+			if !ok {
+				total = &dto.TotalNutrienteDTO{Nutriente: nutriente.Nutriente, Unidade: unidade}
+				nutrientes[chave] = total
+			}
+			total.Quantidade += nutriente.Dose * rega.VolumeLitros
+		}
+	}
+
+	resumo.TotalLitros = calculo.Arredondar(resumo.TotalLitros, 3)
+	if leiturasPH > 0 {
+		media := calculo.Arredondar(somaPH/float64(leiturasPH), 2)
+		resumo.PHEntradaMedio = &media
+	}
+	if leiturasEC > 0 {
+		media := calculo.Arredondar(somaEC/float64(leiturasEC), 2)
+		resumo.ECEntradaMedia = &media
+	}
+	for _, planta := range plantas {
+		resumo.PorPlanta = append(resumo.PorPlanta, dto.ConsumoPlantaRegaDTO{
+			PlantaID: planta.ID,
+			Nome:     planta.Nome,
+			Litros:   calculo.Arredondar(porPlanta[planta.ID], 3),
+		})
+	}
+	for _, total := range nutrientes {
+		total.Quantidade = calculo.Arredondar(total.Quantidade, 2)
+		resumo.Nutrientes = append(resumo.Nutrientes, *total)
+	}
+	sort.Slice(resumo.Nutrientes, func(i, j int) bool {
+		return resumo.Nutrientes[i].Nutriente < resumo.Nutrientes[j].Nutriente
+	})
+	return resumo, nil
+}
+
+func (s *regaService) CriarReceita(usuarioID uint, receitaDto *dto.ReceitaNutrienteDTO) (*entity.ReceitaNutriente, error) {
+	if usuarioID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	receita := &entity.ReceitaNutriente{UsuarioID: usuarioID}
+	if err := aplicarReceitaDTO(receita, receitaDto); err != nil {
+		return nil, err
+	}
+	if err := s.receitaRepositorio.Criar(receita); err != nil {
+		return nil, fmt.Errorf("falha ao criar receita de nutrientes: %w", err)
+	}
+	return receita, nil
+}
+
+func (s *regaService) ListarReceitas(usuarioID uint) ([]entity.ReceitaNutriente, error) {
+	return s.receitaRepositorio.ListarPorUsuario(usuarioID)
+}
+
+func (s *regaService) BuscarReceita(id, usuarioID uint) (*entity.ReceitaNutriente, error) {
+	return s.buscarReceita(id, usuarioID)
+}
+
+func (s *regaService) AtualizarReceita(id, usuarioID uint, receitaDto *dto.ReceitaNutrienteDTO) (*entity.ReceitaNutriente, error) {
+	receita, err := s.buscarReceita(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if err := aplicarReceitaDTO(receita, receitaDto); err != nil {
+		return nil, err
+	}
+	if err := s.receitaRepositorio.Atualizar(receita); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar receita de nutrientes com ID %d: %w", id, err)
+	}
+	return receita, nil
+}
+
+func (s *regaService) DeletarReceita(id, usuarioID uint) error {
+	if _, err := s.buscarReceita(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.receitaRepositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar receita de nutrientes com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+// aplicarRegaDTO valida o alvo da rega (planta ou lote), completa EC e PPM um a partir do
+// outro e copia as doses da receita quando a rega não informa nutrientes
+func (s *regaService) aplicarRegaDTO(rega *entity.Rega, regaDto *dto.RegaDTO) error {
+	if regaDto == nil || regaDto.VolumeLitros <= 0 {
+		return utils.ErrInvalidInput
+	}
+	if (regaDto.PlantaID == nil) == (regaDto.DiarioCultivoID == nil) {
+		return fmt.Errorf("%w: informe a planta ou o diário de cultivo do lote", utils.ErrInvalidInput)
+	}
+	if regaDto.PlantaID != nil {
+		planta, err := s.plantaRepositorio.BuscarPorID(*regaDto.PlantaID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("falha ao buscar planta com ID %d: %w", *regaDto.PlantaID, err)
+		}
+		if err != nil || planta.UsuarioID != rega.UsuarioID {
+			return fmt.Errorf("%w: planta %d não encontrada", utils.ErrInvalidInput, *regaDto.PlantaID)
+		}
+	}
+	if regaDto.DiarioCultivoID != nil {
+		diario, err := s.diarioRepositorio.GetByID(*regaDto.DiarioCultivoID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("falha ao buscar diário de cultivo com ID %d: %w", *regaDto.DiarioCultivoID, err)
+		}
+		if err != nil || diario.UsuarioID != rega.UsuarioID {
+			return fmt.Errorf("%w: diário de cultivo %d não encontrado", utils.ErrInvalidInput, *regaDto.DiarioCultivoID)
+		}
+	}
+
+	nutrientes := nutrientesDaRega(regaDto.Nutrientes)
+	if regaDto.ReceitaID != nil {
+		receita, err := s.buscarReceita(*regaDto.ReceitaID, rega.UsuarioID)
+		if errors.Is(err, utils.ErrNotFound) {
+			return fmt.Errorf("%w: receita %d não encontrada", utils.ErrInvalidInput, *regaDto.ReceitaID)
+		}
+		if err != nil {
+			return err
+		}
+		if len(nutrientes) == 0 {
+			for _, item := range receita.Itens {
+				nutrientes = append(nutrientes, entity.NutrienteRega{Nutriente: item.Nutriente, Dose: item.Dose, Unidade: item.Unidade})
+			}
+		}
+	}
+
+	escala := regaDto.EscalaPPM
+	if escala == 0 {
+		escala = calculo.EscalaPPM500
+	}
+	ec, ppm := regaDto.ECEntrada, regaDto.PPMEntrada
+	switch {
+	case ec != nil && ppm == nil:
+		convertido := calculo.Arredondar(calculo.ECParaPPM(*ec, escala), 0)
+		ppm = &convertido
+	case ppm != nil && ec == nil:
+		convertido := calculo.Arredondar(calculo.PPMParaEC(*ppm, escala), 2)
+		ec = &convertido
+	}
+
+	data := s.agora()
+	if regaDto.Data != nil && !regaDto.Data.IsZero() {
+		data = *regaDto.Data
+	}
+	rega.PlantaID = regaDto.PlantaID
+	rega.DiarioCultivoID = regaDto.DiarioCultivoID
+	rega.Data = data
+	rega.VolumeLitros = regaDto.VolumeLitros
+	rega.PHEntrada = regaDto.PHEntrada
+	rega.ECEntrada = ec
+	rega.PPMEntrada = ppm
+	rega.EscalaPPM = 0
+	if ppm != nil {
+		rega.EscalaPPM = escala
+	}
+	rega.PHRunoff = regaDto.PHRunoff
+	rega.ECRunoff = regaDto.ECRunoff
+	rega.TemperaturaAgua = regaDto.TemperaturaAgua
+	rega.ReceitaID = regaDto.ReceitaID
+	rega.Nutrientes = nutrientes
+	rega.Observacoes = regaDto.Observacoes
+	return nil
+}
+
+// periodo converte os dias de/ate (inclusive) no fuso local em um intervalo [de, ate)
+func (s *regaService) periodo(de, ate string) (*time.Time, *time.Time, error) {
+	var inicio, fim *time.Time
+	if de != "" {
+		data, err := time.ParseInLocation("2006-01-02", de, s.local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: data inicial inválida", utils.ErrInvalidInput)
+		}
+		inicio = &data
+	}
+	if ate != "" {
+		data, err := time.ParseInLocation("2006-01-02", ate, s.local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: data final inválida", utils.ErrInvalidInput)
+		}
+		data = data.AddDate(0, 0, 1)
+		fim = &data
+	}
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, nil, fmt.Errorf("%w: período inválido", utils.ErrInvalidInput)
+	}
+	return inicio, fim, nil
+}
+
+// buscarRega retorna ErrNotFound também para regas de outro usuário
+func (s *regaService) buscarRega(id, usuarioID uint) (*entity.Rega, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	rega, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar rega com ID %d: %w", id, err)
+	}
+	if rega.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return rega, nil
+}
+
+// buscarReceita retorna ErrNotFound também para receitas de outro usuário
+func (s *regaService) buscarReceita(id, usuarioID uint) (*entity.ReceitaNutriente, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	receita, err := s.receitaRepositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar receita de nutrientes com ID %d: %w", id, err)
+	}
+	if receita.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return receita, nil
+}
+
+func aplicarReceitaDTO(receita *entity.ReceitaNutriente, receitaDto *dto.ReceitaNutrienteDTO) error {
+	if receitaDto == nil || strings.TrimSpace(receitaDto.Nome) == "" || len(receitaDto.Itens) == 0 {
+		return utils.ErrInvalidInput
+	}
+	receita.Nome = strings.TrimSpace(receitaDto.Nome)
+	receita.Descricao = receitaDto.Descricao
+	receita.ECAlvo = receitaDto.ECAlvo
+	receita.PHAlvo = receitaDto.PHAlvo
+	receita.Itens = make([]entity.ItemReceitaNutriente, 0, len(receitaDto.Itens))
+	for _, item := range receitaDto.Itens {
+		receita.Itens = append(receita.Itens, entity.ItemReceitaNutriente{
+			Nutriente: strings.TrimSpace(item.Nutriente),
+			Dose:      item.Dose,
+			Unidade:   entity.UnidadeDose(item.Unidade),
+		})
+	}
+	return nil
+}
+
+func nutrientesDaRega(doses []dto.DoseNutrienteDTO) []entity.NutrienteRega {
+	nutrientes := make([]entity.NutrienteRega, 0, len(doses))
+	for _, dose := range doses {
+		nutrientes = append(nutrientes, entity.NutrienteRega{
+			Nutriente: strings.TrimSpace(dose.Nutriente),
+			Dose:      dose.Dose,
+			Unidade:   entity.UnidadeDose(dose.Unidade),
+		})
+	}
+	return nutrientes
+}
+
+// dentroDoCiclo indica se o instante está entre o início e o fim (se houver) do diário
+func dentroDoCiclo(diario *entity.DiarioCultivo, instante time.Time) bool {
+	if instante.Before(diario.DataInicio) {
+		return false
+	}
+	return diario.DataFim == nil || !instante.After(*diario.DataFim)
+}
+
+// unidadeTotalNutriente é a unidade da quantidade aplicada: a dose por litro vezes os litros
+func unidadeTotalNutriente(unidade entity.UnidadeDose) string {
+	if unidade == entity.UnidadeDoseGPorLitro {
+		return "g"
+	}
+	return "ml"
+}
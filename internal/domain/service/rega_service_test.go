@@ -0,0 +1,187 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type regaMocks struct {
+	regas      *test.MockRegaRepositorio
+	receitas   *test.MockReceitaNutrienteRepositorio
+	plantaRepo *test.MockPlantaRepositorio
+	diarioRepo *MockDiarioCultivoRepository
+}
+
+func novoRegaService() (service.RegaService, regaMocks) {
+	m := regaMocks{
+		regas:      new(test.MockRegaRepositorio),
+		receitas:   new(test.MockReceitaNutrienteRepositorio),
+		plantaRepo: new(test.MockPlantaRepositorio),
+		diarioRepo: new(MockDiarioCultivoRepository),
+	}
+	return service.NewRegaService(m.regas, m.receitas, m.plantaRepo, m.diarioRepo, time.UTC), m
+}
+
+func plantaRegada(id, usuarioID uint, nome string) entity.Planta {
+	planta := entity.Planta{Nome: nome, UsuarioID: usuarioID}
+	planta.ID = id
+	return planta
+}
+
+func valor(v float64) *float64 {
+	return &v
+}
+
+func TestRegaService_Criar(t *testing.T) {
+	t.Run("Success - Converte EC em PPM", func(t *testing.T) {
+		servico, m := novoRegaService()
+		planta := plantaRegada(10, 7, "Amnesia")
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+		m.regas.On("Criar", mock.AnythingOfType("*entity.Rega")).Return(nil).Once()
+
+		plantaID := uint(10)
+		rega, err := servico.Criar(7, &dto.RegaDTO{PlantaID: &plantaID, VolumeLitros: 1.5, ECEntrada: valor(1.4), EscalaPPM: 700})
+
+		require.NoError(t, err)
+		require.NotNil(t, rega.PPMEntrada)
+		assert.Equal(t, 980.0, *rega.PPMEntrada)
+		assert.Equal(t, 700, rega.EscalaPPM)
+		assert.False(t, rega.Data.IsZero())
+	})
+
+	t.Run("Success - Copia as Doses da Receita", func(t *testing.T) {
+		servico, m := novoRegaService()
+		diario := &entity.DiarioCultivo{UsuarioID: 7}
+		m.diarioRepo.On("GetByID", uint(3)).Return(diario, nil).Once()
+		receita := &entity.ReceitaNutriente{UsuarioID: 7, Nome: "Vega semana 2", Itens: []entity.ItemReceitaNutriente{
+			{Nutriente: "Grow", Dose: 2, Unidade: entity.UnidadeDoseMlPorLitro},
+			{Nutriente: "CalMag", Dose: 0.5, Unidade: entity.UnidadeDoseGPorLitro},
+		}}
+		m.receitas.On("BuscarPorID", uint(5)).Return(receita, nil).Once()
+		m.regas.On("Criar", mock.AnythingOfType("*entity.Rega")).Return(nil).Once()
+
+		diarioID, receitaID := uint(3), uint(5)
+		rega, err := servico.Criar(7, &dto.RegaDTO{DiarioCultivoID: &diarioID, VolumeLitros: 10, PPMEntrada: valor(600), ReceitaID: &receitaID})
+
+		require.NoError(t, err)
+		require.Len(t, rega.Nutrientes, 2)
+		assert.Equal(t, "CalMag", rega.Nutrientes[1].Nutriente)
+		assert.Equal(t, entity.UnidadeDoseGPorLitro, rega.Nutrientes[1].Unidade)
+		require.NotNil(t, rega.ECEntrada)
+		assert.Equal(t, 1.2, *rega.ECEntrada)
+	})
+
+	t.Run("Error - Planta e Lote ao Mesmo Tempo", func(t *testing.T) {
+		servico, m := novoRegaService()
+		plantaID, diarioID := uint(10), uint(3)
+
+		_, err := servico.Criar(7, &dto.RegaDTO{PlantaID: &plantaID, DiarioCultivoID: &diarioID, VolumeLitros: 1})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.regas.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Receita de Outro Usuário", func(t *testing.T) {
+		servico, m := novoRegaService()
+		planta := plantaRegada(10, 7, "Amnesia")
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+		m.receitas.On("BuscarPorID", uint(5)).Return(&entity.ReceitaNutriente{UsuarioID: 8}, nil).Once()
+
+		plantaID, receitaID := uint(10), uint(5)
+		_, err := servico.Criar(7, &dto.RegaDTO{PlantaID: &plantaID, VolumeLitros: 1, ReceitaID: &receitaID})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.regas.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+}
+
+func TestRegaService_GraficoPlanta(t *testing.T) {
+	servico, m := novoRegaService()
+	planta := plantaRegada(10, 7, "Amnesia")
+	m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+	m.plantaRepo.On("ListarPorDiario", uint(3)).Return([]entity.Planta{planta, plantaRegada(11, 7, "Gorilla")}, nil).Once()
+
+	plantaID, diarioID := uint(10), uint(3)
+	dia := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	regas := []entity.Rega{
+		{PlantaID: &plantaID, Data: dia, VolumeLitros: 1, PHEntrada: valor(6.2)},
+		{DiarioCultivoID: &diarioID, Data: dia.AddDate(0, 0, 2), VolumeLitros: 4, ECEntrada: valor(1.6)},
+		{DiarioCultivoID: &diarioID, Data: dia.AddDate(0, 0, 4), VolumeLitros: 3},
+	}
+	de := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ate := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	m.regas.On("ListarPorPlanta", uint(10), &de, &ate).Return(regas, nil).Once()
+
+	grafico, err := servico.GraficoPlanta(10, 7, &dto.ConsultaGraficoRegaDTO{De: "2024-05-01", Ate: "2024-05-31"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, grafico.Regas)
+	// o volume do lote é dividido entre as duas plantas do diário
+	assert.Equal(t, 4.5, grafico.TotalLitros)
+	require.Len(t, grafico.Pontos, 3)
+	assert.False(t, grafico.Pontos[0].Lote)
+	assert.True(t, grafico.Pontos[1].Lote)
+	assert.Equal(t, 2.0, grafico.Pontos[1].VolumeLitros)
+	m.plantaRepo.AssertNumberOfCalls(t, "ListarPorDiario", 1)
+}
+
+func TestRegaService_ResumoCiclo(t *testing.T) {
+	t.Run("Success - Totais por Planta e Nutriente", func(t *testing.T) {
+		servico, m := novoRegaService()
+		inicio := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		m.diarioRepo.On("GetByID", uint(3)).Return(&entity.DiarioCultivo{UsuarioID: 7, DataInicio: inicio}, nil).Once()
+		m.plantaRepo.On("ListarPorDiario", uint(3)).
+			Return([]entity.Planta{plantaRegada(10, 7, "Amnesia"), plantaRegada(11, 7, "Gorilla")}, nil).Once()
+
+		plantaID, diarioID := uint(10), uint(3)
+		regas := []entity.Rega{
+			// anterior ao ciclo: ignorada
+			{PlantaID: &plantaID, Data: inicio.AddDate(0, 0, -3), VolumeLitros: 9},
+			{DiarioCultivoID: &diarioID, Data: inicio.AddDate(0, 0, 1), VolumeLitros: 10, PHEntrada: valor(6.0), ECEntrada: valor(1.2),
+				Nutrientes: []entity.NutrienteRega{{Nutriente: "Grow", Dose: 2, Unidade: entity.UnidadeDoseMlPorLitro}}},
+			{PlantaID: &plantaID, Data: inicio.AddDate(0, 0, 3), VolumeLitros: 2, PHEntrada: valor(6.4),
+				Nutrientes: []entity.NutrienteRega{
+					{Nutriente: "grow ", Dose: 1, Unidade: entity.UnidadeDoseMlPorLitro},
+					{Nutriente: "CalMag", Dose: 0.5, Unidade: entity.UnidadeDoseGPorLitro},
+				}},
+		}
+		m.regas.On("ListarPorDiario", uint(3)).Return(regas, nil).Once()
+
+		resumo, err := servico.ResumoCiclo(3, 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, resumo.Regas)
+		assert.Equal(t, 12.0, resumo.TotalLitros)
+		assert.Equal(t, 6.2, *resumo.PHEntradaMedio)
+		assert.Equal(t, 1.2, *resumo.ECEntradaMedia)
+		assert.Equal(t, []dto.ConsumoPlantaRegaDTO{
+			{PlantaID: 10, Nome: "Amnesia", Litros: 7},
+			{PlantaID: 11, Nome: "Gorilla", Litros: 5},
+		}, resumo.PorPlanta)
+		assert.Equal(t, []dto.TotalNutrienteDTO{
+			{Nutriente: "CalMag", Quantidade: 1, Unidade: "g"},
+			{Nutriente: "Grow", Quantidade: 22, Unidade: "ml"},
+		}, resumo.Nutrientes)
+		assert.Equal(t, inicio.AddDate(0, 0, 1), *resumo.Primeira)
+		assert.Equal(t, inicio.AddDate(0, 0, 3), *resumo.Ultima)
+	})
+
+	t.Run("Error - Diário de Outro Usuário", func(t *testing.T) {
+		servico, m := novoRegaService()
+		m.diarioRepo.On("GetByID", uint(3)).Return(&entity.DiarioCultivo{UsuarioID: 8}, nil).Once()
+
+		_, err := servico.ResumoCiclo(3, 7)
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		m.regas.AssertNotCalled(t, "ListarPorDiario", mock.Anything)
+	})
+}
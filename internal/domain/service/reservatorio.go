@@ -0,0 +1,474 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Limites da análise de deriva. Abaixo das variações diárias a solução é considerada estável;
+// acima de 22 °C a água perde oxigênio e favorece patógenos de raiz.
+const (
+	limiteDerivaEC           = 0.05 // mS/cm por dia
+	limiteDerivaPH           = 0.1  // pH por dia
+	temperaturaAguaMaxima    = 22.0 // °C
+	oxigenioDissolvidoMinimo = 5.0  // mg/L
+)
+
+// ReservatorioService gerencia os reservatórios dos sistemas hidropônicos e aeropônicos: trocas
+// e reposições da solução, leituras periódicas, análise de deriva e tarefas de troca.
+type ReservatorioService interface {
+	Criar(usuarioID uint, reservatorioDto *dto.ReservatorioDTO) (*entity.Reservatorio, error)
+	BuscarPorID(id, usuarioID uint) (*entity.Reservatorio, error)
+	Listar(usuarioID uint) ([]entity.Reservatorio, error)
+	Atualizar(id, usuarioID uint, reservatorioDto *dto.ReservatorioDTO) (*entity.Reservatorio, error)
+	Deletar(id, usuarioID uint) error
+
+	// RegistrarManutencao grava uma troca ou reposição; a troca conclui as tarefas de troca abertas
+	RegistrarManutencao(id, usuarioID uint, manutencaoDto *dto.ManutencaoReservatorioDTO) (*entity.ManutencaoReservatorio, error)
+	ListarManutencoes(id, usuarioID uint) ([]entity.ManutencaoReservatorio, error)
+	RegistrarLeitura(id, usuarioID uint, leituraDto *dto.LeituraReservatorioDTO) (*entity.LeituraReservatorio, error)
+	ListarLeituras(id, usuarioID uint, consulta *dto.ConsultaLeiturasReservatorioDTO) ([]entity.LeituraReservatorio, error)
+	// AnalisarDeriva mostra como EC e pH variam desde a última troca e o que isso indica
+	AnalisarDeriva(id, usuarioID uint) (*dto.DerivaReservatorioDTO, error)
+
+	// GerarTarefasTroca cria a tarefa de troca dos reservatórios com intervalo definido que ainda
+	// não têm uma aberta; usado pelo agendador
+	GerarTarefasTroca() (int, error)
+}
+
+type reservatorioService struct {
+	repositorio         repository.ReservatorioRepositorio
+	plantaRepositorio   repository.PlantaRepositorio
+	ambienteRepositorio repository.AmbienteRepositorio
+	tarefaRepositorio   repository.TarefaRepositorio
+	local               *time.Location
+	agora               func() time.Time
+}
+
+// NewReservatorioService cria o serviço de reservatórios; local é o fuso dos filtros por dia.
+func NewReservatorioService(
+	repositorio repository.ReservatorioRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	tarefaRepositorio repository.TarefaRepositorio,
+	local *time.Location,
+) ReservatorioService {
+	if local == nil {
+		local = time.Local
+	}
+	return &reservatorioService{
+		repositorio:         repositorio,
+		plantaRepositorio:   plantaRepositorio,
+		ambienteRepositorio: ambienteRepositorio,
+		tarefaRepositorio:   tarefaRepositorio,
+		local:               local,
+		agora:               time.Now,
+	}
+}
+
+func (s *reservatorioService) Criar(usuarioID uint, reservatorioDto *dto.ReservatorioDTO) (*entity.Reservatorio, error) {
+	if usuarioID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	reservatorio := &entity.Reservatorio{UsuarioID: usuarioID}
+	if err := s.aplicarReservatorioDTO(reservatorio, reservatorioDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Criar(reservatorio); err != nil {
+		return nil, fmt.Errorf("falha ao criar reservatório: %w", err)
+	}
+	return reservatorio, nil
+}
+
+func (s *reservatorioService) BuscarPorID(id, usuarioID uint) (*entity.Reservatorio, error) {
+	return s.buscarReservatorio(id, usuarioID)
+}
+
+func (s *reservatorioService) Listar(usuarioID uint) ([]entity.Reservatorio, error) {
+	return s.repositorio.ListarPorUsuario(usuarioID)
+}
+
+func (s *reservatorioService) Atualizar(id, usuarioID uint, reservatorioDto *dto.ReservatorioDTO) (*entity.Reservatorio, error) {
+	reservatorio, err := s.buscarReservatorio(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	intervaloAnterior := reservatorio.IntervaloTrocaDias
+	if err := s.aplicarReservatorioDTO(reservatorio, reservatorioDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Atualizar(reservatorio); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar reservatório com ID %d: %w", id, err)
+	}
+	// a tarefa aberta foi agendada com o intervalo antigo; o agendador cria outra com o novo
+	if reservatorio.IntervaloTrocaDias != intervaloAnterior {
+		if err := s.removerTarefasAbertas(id); err != nil {
+			return nil, err
+		}
+	}
+	return reservatorio, nil
+}
+
+func (s *reservatorioService) Deletar(id, usuarioID uint) error {
+	if _, err := s.buscarReservatorio(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar reservatório com ID %d: %w", id, err)
+	}
+	return s.removerTarefasAbertas(id)
+}
+
+func (s *reservatorioService) RegistrarManutencao(id, usuarioID uint, manutencaoDto *dto.ManutencaoReservatorioDTO) (*entity.ManutencaoReservatorio, error) {
+	if _, err := s.buscarReservatorio(id, usuarioID); err != nil {
+		return nil, err
+	}
+	if manutencaoDto == nil || manutencaoDto.VolumeLitros <= 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	tipo := entity.TipoManutencaoReservatorio(manutencaoDto.Tipo)
+	if tipo != entity.ManutencaoReservatorioTroca && tipo != entity.ManutencaoReservatorioCompletar {
+		return nil, fmt.Errorf("%w: tipo de manutenção inválido", utils.ErrInvalidInput)
+	}
+	data := s.agora()
+	if manutencaoDto.Data != nil && !manutencaoDto.Data.IsZero() {
+		data = *manutencaoDto.Data
+	}
+	manutencao := &entity.ManutencaoReservatorio{
+		ReservatorioID: id,
+		Tipo:           tipo,
+		Data:           data,
+		VolumeLitros:   manutencaoDto.VolumeLitros,
+		EC:             manutencaoDto.EC,
+		PH:             manutencaoDto.PH,
+		Observacoes:    manutencaoDto.Observacoes,
+	}
+	if err := s.repositorio.CriarManutencao(manutencao); err != nil {
+		return nil, fmt.Errorf("falha ao registrar manutenção do reservatório %d: %w", id, err)
+	}
+	if tipo != entity.ManutencaoReservatorioTroca {
+		return manutencao, nil
+	}
+
+	tarefas, err := s.tarefaRepositorio.ListarAbertasPorReservatorio(id)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tarefas {
+		tarefas[i].Status = entity.StatusTarefaConcluida
+		tarefas[i].DataConclusao = &data
+		if err := s.tarefaRepositorio.Atualizar(&tarefas[i]); err != nil {
+			return nil, fmt.Errorf("falha ao concluir tarefa com ID %d: %w", tarefas[i].ID, err)
+		}
+	}
+	return manutencao, nil
+}
+
+func (s *reservatorioService) ListarManutencoes(id, usuarioID uint) ([]entity.ManutencaoReservatorio, error) {
+	if _, err := s.buscarReservatorio(id, usuarioID); err != nil {
+		return nil, err
+	}
+	return s.repositorio.ListarManutencoes(id)
+}
+
+func (s *reservatorioService) RegistrarLeitura(id, usuarioID uint, leituraDto *dto.LeituraReservatorioDTO) (*entity.LeituraReservatorio, error) {
+	if _, err := s.buscarReservatorio(id, usuarioID); err != nil {
+		return nil, err
+	}
+	if leituraDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	ec := leituraDto.EC
+	if ec == nil && leituraDto.PPM != nil {
+		escala := leituraDto.EscalaPPM
+		if escala == 0 {
+			escala = calculo.EscalaPPM500
+		}
+		convertido := calculo.Arredondar(calculo.PPMParaEC(*leituraDto.PPM, escala), 2)
+		ec = &convertido
+	}
+	if ec == nil && leituraDto.PH == nil && leituraDto.TemperaturaAgua == nil &&
+		leituraDto.OxigenioDissolvido == nil && leituraDto.NivelLitros == nil {
+		return nil, fmt.Errorf("%w: informe ao menos uma medição", utils.ErrInvalidInput)
+	}
+	data := s.agora()
+	if leituraDto.Data != nil && !leituraDto.Data.IsZero() {
+		data = *leituraDto.Data
+	}
+	leitura := &entity.LeituraReservatorio{
+		ReservatorioID:     id,
+		Data:               data,
+		EC:                 ec,
+		PH:                 leituraDto.PH,
+		TemperaturaAgua:    leituraDto.TemperaturaAgua,
+		OxigenioDissolvido: leituraDto.OxigenioDissolvido,
+		NivelLitros:        leituraDto.NivelLitros,
+	}
+	if err := s.repositorio.CriarLeitura(leitura); err != nil {
+		return nil, fmt.Errorf("falha ao registrar leitura do reservatório %d: %w", id, err)
+	}
+	return leitura, nil
+}
+
+func (s *reservatorioService) ListarLeituras(id, usuarioID uint, consulta *dto.ConsultaLeiturasReservatorioDTO) ([]entity.LeituraReservatorio, error) {
+	if _, err := s.buscarReservatorio(id, usuarioID); err != nil {
+		return nil, err
+	}
+	de, ate, err := s.periodo(consulta.De, consulta.Ate)
+	if err != nil {
+		return nil, err
+	}
+	return s.repositorio.ListarLeituras(id, de, ate)
+}
+
+func (s *reservatorioService) AnalisarDeriva(id, usuarioID uint) (*dto.DerivaReservatorioDTO, error) {
+	reservatorio, err := s.buscarReservatorio(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	inicio := reservatorio.InicioCiclo()
+	leituras, err := s.repositorio.ListarLeituras(id, &inicio, nil)
+	if err != nil {
+		return nil, err
+	}
+	manutencoes, err := s.repositorio.ListarManutencoes(id)
+	if err != nil {
+		return nil, err
+	}
+
+	agora := s.agora()
+	dias := agora.Sub(inicio).Hours() / 24
+	deriva := &dto.DerivaReservatorioDTO{
+		ReservatorioID: id,
+		InicioCiclo:    inicio,
+		DiasDesdeTroca: calculo.Arredondar(dias, 1),
+		Diagnosticos:   []string{},
+	}
+
+	var serieEC, seriePH []calculo.PontoSerie
+	for _, leitura := range leituras {
+		if leitura.EC != nil {
+			serieEC = append(serieEC, calculo.PontoSerie{Instante: leitura.Data, Valor: *leitura.EC})
+		}
+		if leitura.PH != nil {
+			seriePH = append(seriePH, calculo.PontoSerie{Instante: leitura.Data, Valor: *leitura.PH})
+		}
+		// as leituras vêm em ordem cronológica: ficam os valores mais recentes
+		if leitura.TemperaturaAgua != nil {
+			deriva.TemperaturaAgua = leitura.TemperaturaAgua
+		}
+		if leitura.OxigenioDissolvido != nil {
+			deriva.OxigenioDissolvido = leitura.OxigenioDissolvido
+		}
+	}
+	deriva.EC = tendenciaMetrica(serieEC, limiteDerivaEC, 2)
+	deriva.PH = tendenciaMetrica(seriePH, limiteDerivaPH, 2)
+
+	for _, manutencao := range manutencoes {
+		if manutencao.Tipo == entity.ManutencaoReservatorioCompletar && !manutencao.Data.Before(inicio) {
+			deriva.LitrosCompletados += manutencao.VolumeLitros
+		}
+	}
+	deriva.LitrosCompletados = calculo.Arredondar(deriva.LitrosCompletados, 2)
+	if dias >= 1 && deriva.LitrosCompletados > 0 {
+		consumo := calculo.Arredondar(deriva.LitrosCompletados/dias, 2)
+		deriva.ConsumoLitrosDia = &consumo
+	}
+
+	if deriva.EC != nil {
+		switch deriva.EC.Tendencia {
+		case "subindo":
+			deriva.Diagnosticos = append(deriva.Diagnosticos,
+				"EC subindo: as plantas estão absorvendo mais água que nutrientes; complete com água pura ou reduza a dose")
+		case "caindo":
+			deriva.Diagnosticos = append(deriva.Diagnosticos,
+				"EC caindo: as plantas estão absorvendo mais nutrientes que água; complete com solução nutritiva ou aumente a dose")
+		}
+	}
+	if deriva.PH != nil && deriva.PH.Tendencia != "estavel" && deriva.PH.Tendencia != "indefinida" {
+		deriva.Diagnosticos = append(deriva.Diagnosticos,
+			fmt.Sprintf("pH %s rápido: corrija o pH e confira a capacidade tampão da solução", deriva.PH.Tendencia))
+	}
+	if deriva.TemperaturaAgua != nil && *deriva.TemperaturaAgua > temperaturaAguaMaxima {
+		deriva.Diagnosticos = append(deriva.Diagnosticos,
+			fmt.Sprintf("água acima de %.0f °C: menos oxigênio dissolvido e risco de podridão de raiz", temperaturaAguaMaxima))
+	}
+	if deriva.OxigenioDissolvido != nil && *deriva.OxigenioDissolvido < oxigenioDissolvidoMinimo {
+		deriva.Diagnosticos = append(deriva.Diagnosticos,
+			fmt.Sprintf("oxigênio dissolvido abaixo de %.0f mg/L: aumente a aeração", oxigenioDissolvidoMinimo))
+	}
+	if reservatorio.IntervaloTrocaDias > 0 {
+		proxima := inicio.AddDate(0, 0, reservatorio.IntervaloTrocaDias)
+		deriva.ProximaTroca = &proxima
+		if agora.After(proxima) {
+			deriva.Diagnosticos = append(deriva.Diagnosticos,
+				fmt.Sprintf("troca atrasada: a solução está há %.0f dias no reservatório", dias))
+		}
+	}
+	return deriva, nil
+}
+
+func (s *reservatorioService) GerarTarefasTroca() (int, error) {
+	reservatorios, err := s.repositorio.ListarComTrocaAutomatica()
+	if err != nil {
+		return 0, err
+	}
+	criadas := 0
+	for _, reservatorio := range reservatorios {
+		abertas, err := s.tarefaRepositorio.ListarAbertasPorReservatorio(reservatorio.ID)
+		if err != nil {
+			return criadas, err
+		}
+		if len(abertas) > 0 {
+			continue
+		}
+		reservatorioID := reservatorio.ID
+		tarefa := &entity.Tarefa{
+			Tipo:           entity.TipoTarefaTrocaReservatorio,
+			Descricao:      fmt.Sprintf("Trocar a solução do reservatório %s (%.0f L)", reservatorio.Nome, reservatorio.VolumeLitros),
+			DataAgendada:   reservatorio.InicioCiclo().AddDate(0, 0, reservatorio.IntervaloTrocaDias),
+			Status:         entity.StatusTarefaPendente,
+			Prioridade:     entity.PrioridadeMedia,
+			AmbienteID:     reservatorio.AmbienteID,
+			UsuarioID:      reservatorio.UsuarioID,
+			ReservatorioID: &reservatorioID,
+		}
+		if err := s.tarefaRepositorio.Criar(tarefa); err != nil {
+			return criadas, fmt.Errorf("falha ao criar tarefa de troca do reservatório %d: %w", reservatorio.ID, err)
+		}
+		criadas++
+	}
+	return criadas, nil
+}
+
+func (s *reservatorioService) aplicarReservatorioDTO(reservatorio *entity.Reservatorio, reservatorioDto *dto.ReservatorioDTO) error {
+	if reservatorioDto == nil || reservatorioDto.VolumeLitros <= 0 || reservatorioDto.IntervaloTrocaDias < 0 {
+		return utils.ErrInvalidInput
+	}
+	if reservatorioDto.AmbienteID != nil {
+		if _, err := s.ambienteRepositorio.BuscarPorID(*reservatorioDto.AmbienteID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: ambiente %d não encontrado", utils.ErrInvalidInput, *reservatorioDto.AmbienteID)
+			}
+			return fmt.Errorf("falha ao buscar ambiente com ID %d: %w", *reservatorioDto.AmbienteID, err)
+		}
+	}
+	plantas := make([]entity.Planta, 0, len(reservatorioDto.PlantaIDs))
+	vistas := make(map[uint]bool, len(reservatorioDto.PlantaIDs))
+	for _, plantaID := range reservatorioDto.PlantaIDs {
+		if vistas[plantaID] {
+			continue
+		}
+		vistas[plantaID] = true
+		planta, err := s.plantaRepositorio.BuscarPorID(plantaID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("falha ao buscar planta com ID %d: %w", plantaID, err)
+		}
+		if err != nil || planta.UsuarioID != reservatorio.UsuarioID {
+			return fmt.Errorf("%w: planta %d não encontrada", utils.ErrInvalidInput, plantaID)
+		}
+		plantas = append(plantas, *planta)
+	}
+	reservatorio.Nome = reservatorioDto.Nome
+	reservatorio.VolumeLitros = reservatorioDto.VolumeLitros
+	reservatorio.AmbienteID = reservatorioDto.AmbienteID
+	reservatorio.IntervaloTrocaDias = reservatorioDto.IntervaloTrocaDias
+	reservatorio.Plantas = plantas
+	return nil
+}
+
+// removerTarefasAbertas exclui as tarefas de troca ainda não feitas do reservatório
+func (s *reservatorioService) removerTarefasAbertas(reservatorioID uint) error {
+	tarefas, err := s.tarefaRepositorio.ListarAbertasPorReservatorio(reservatorioID)
+	if err != nil {
+		return err
+	}
+	for _, tarefa := range tarefas {
+		if err := s.tarefaRepositorio.Deletar(tarefa.ID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("falha ao remover tarefa com ID %d: %w", tarefa.ID, err)
+		}
+	}
+	return nil
+}
+
+// periodo converte os dias de/ate (inclusive) no fuso local em um intervalo [de, ate)
+func (s *reservatorioService) periodo(de, ate string) (*time.Time, *time.Time, error) {
+	var inicio, fim *time.Time
+	if de != "" {
+		data, err := time.ParseInLocation("2006-01-02", de, s.local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: data inicial inválida", utils.ErrInvalidInput)
+		}
+		inicio = &data
+	}
+	if ate != "" {
+		data, err := time.ParseInLocation("2006-01-02", ate, s.local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: data final inválida", utils.ErrInvalidInput)
+		}
+		data = data.AddDate(0, 0, 1)
+		fim = &data
+	}
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, nil, fmt.Errorf("%w: período inválido", utils.ErrInvalidInput)
+	}
+	return inicio, fim, nil
+}
+
+// buscarReservatorio retorna ErrNotFound também para reservatórios de outro usuário
+func (s *reservatorioService) buscarReservatorio(id, usuarioID uint) (*entity.Reservatorio, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	reservatorio, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar reservatório com ID %d: %w", id, err)
+	}
+	if reservatorio.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return reservatorio, nil
+}
+
+// tendenciaMetrica classifica a deriva da série pela inclinação por dia; com uma só leitura a
+// tendência fica indefinida
+func tendenciaMetrica(serie []calculo.PontoSerie, limite float64, casas int) *dto.TendenciaMetricaDTO {
+	if len(serie) == 0 {
+		return nil
+	}
+	tendencia := &dto.TendenciaMetricaDTO{
+		Leituras:  len(serie),
+		Inicial:   serie[0].Valor,
+		Atual:     serie[len(serie)-1].Valor,
+		Tendencia: "indefinida",
+	}
+	inclinacao, ok := calculo.InclinacaoPorDia(serie)
+	if !ok {
+		return tendencia
+	}
+	variacao := calculo.Arredondar(inclinacao, casas+1)
+	tendencia.VariacaoDia = &variacao
+	switch {
+	case inclinacao >= limite:
+		tendencia.Tendencia = "subindo"
+	case inclinacao <= -limite:
+		tendencia.Tendencia = "caindo"
+	default:
+		tendencia.Tendencia = "estavel"
+	}
+	return tendencia
+}
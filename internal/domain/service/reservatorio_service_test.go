@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type reservatorioMocks struct {
+	reservatorios *test.MockReservatorioRepositorio
+	plantaRepo    *test.MockPlantaRepositorio
+	ambienteRepo  *test.MockAmbienteRepositorio
+	tarefaRepo    *test.MockTarefaRepositorio
+}
+
+func novoReservatorioService() (service.ReservatorioService, reservatorioMocks) {
+	m := reservatorioMocks{
+		reservatorios: new(test.MockReservatorioRepositorio),
+		plantaRepo:    new(test.MockPlantaRepositorio),
+		ambienteRepo:  new(test.MockAmbienteRepositorio),
+		tarefaRepo:    new(test.MockTarefaRepositorio),
+	}
+	return service.NewReservatorioService(m.reservatorios, m.plantaRepo, m.ambienteRepo, m.tarefaRepo, time.UTC), m
+}
+
+// reservatorioDWC é um reservatório de 40 L trocado há trocadoHaDias, com troca a cada 7 dias
+func reservatorioDWC(trocadoHaDias int) *entity.Reservatorio {
+	ultimaTroca := time.Now().AddDate(0, 0, -trocadoHaDias)
+	reservatorio := &entity.Reservatorio{UsuarioID: 7, Nome: "DWC", VolumeLitros: 40, IntervaloTrocaDias: 7, UltimaTroca: &ultimaTroca}
+	reservatorio.ID = 3
+	return reservatorio
+}
+
+func TestReservatorioService_Criar(t *testing.T) {
+	t.Run("Success - Plantas do Usuário Sem Repetição", func(t *testing.T) {
+		servico, m := novoReservatorioService()
+		planta := plantaRegada(10, 7, "Amnesia")
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+		m.reservatorios.On("Criar", mock.AnythingOfType("*entity.Reservatorio")).Return(nil).Once()
+
+		reservatorio, err := servico.Criar(7, &dto.ReservatorioDTO{Nome: "DWC", VolumeLitros: 40, PlantaIDs: []uint{10, 10}, IntervaloTrocaDias: 7})
+
+		require.NoError(t, err)
+		assert.Len(t, reservatorio.Plantas, 1)
+		assert.Equal(t, 7, reservatorio.IntervaloTrocaDias)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		servico, m := novoReservatorioService()
+		planta := plantaRegada(10, 8, "Amnesia")
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+
+		_, err := servico.Criar(7, &dto.ReservatorioDTO{Nome: "DWC", VolumeLitros: 40, PlantaIDs: []uint{10}})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.reservatorios.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+}
+
+func TestReservatorioService_RegistrarManutencao(t *testing.T) {
+	t.Run("Success - Troca Conclui a Tarefa Aberta", func(t *testing.T) {
+		servico, m := novoReservatorioService()
+		m.reservatorios.On("BuscarPorID", uint(3)).Return(reservatorioDWC(8), nil).Once()
+		m.reservatorios.On("CriarManutencao", mock.AnythingOfType("*entity.ManutencaoReservatorio")).Return(nil).Once()
+		aberta := entity.Tarefa{Tipo: entity.TipoTarefaTrocaReservatorio, Status: entity.StatusTarefaAtrasada}
+		aberta.ID = 50
+		m.tarefaRepo.On("ListarAbertasPorReservatorio", uint(3)).Return([]entity.Tarefa{aberta}, nil).Once()
+		m.tarefaRepo.On("Atualizar", mock.MatchedBy(func(tarefa *entity.Tarefa) bool {
+			return tarefa.ID == 50 && tarefa.Status == entity.StatusTarefaConcluida && tarefa.DataConclusao != nil
+		})).Return(nil).Once()
+
+		manutencao, err := servico.RegistrarManutencao(3, 7, &dto.ManutencaoReservatorioDTO{Tipo: "troca", VolumeLitros: 40, EC: valor(1.4)})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.ManutencaoReservatorioTroca, manutencao.Tipo)
+		m.tarefaRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Completar Não Mexe nas Tarefas", func(t *testing.T) {
+		servico, m := novoReservatorioService()
+		m.reservatorios.On("BuscarPorID", uint(3)).Return(reservatorioDWC(2), nil).Once()
+		m.reservatorios.On("CriarManutencao", mock.AnythingOfType("*entity.ManutencaoReservatorio")).Return(nil).Once()
+
+		_, err := servico.RegistrarManutencao(3, 7, &dto.ManutencaoReservatorioDTO{Tipo: "completar", VolumeLitros: 5})
+
+		require.NoError(t, err)
+		m.tarefaRepo.AssertNotCalled(t, "ListarAbertasPorReservatorio", mock.Anything)
+	})
+
+	t.Run("Error - Reservatório de Outro Usuário", func(t *testing.T) {
+		servico, m := novoReservatorioService()
+		reservatorio := reservatorioDWC(2)
+		reservatorio.UsuarioID = 8
+		m.reservatorios.On("BuscarPorID", uint(3)).Return(reservatorio, nil).Once()
+
+		_, err := servico.RegistrarManutencao(3, 7, &dto.ManutencaoReservatorioDTO{Tipo: "troca", VolumeLitros: 40})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestReservatorioService_RegistrarLeitura(t *testing.T) {
+	t.Run("Success - PPM Convertido em EC", func(t *testing.T) {
+		servico, m := novoReservatorioService()
+		m.reservatorios.On("BuscarPorID", uint(3)).Return(reservatorioDWC(2), nil).Once()
+		m.reservatorios.On("CriarLeitura", mock.AnythingOfType("*entity.LeituraReservatorio")).Return(nil).Once()
+
+		leitura, err := servico.RegistrarLeitura(3, 7, &dto.LeituraReservatorioDTO{PPM: valor(980), EscalaPPM: 700, PH: valor(5.9)})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1.4, *leitura.EC)
+	})
+
+	t.Run("Error - Sem Medições", func(t *testing.T) {
+		servico, m := novoReservatorioService()
+		m.reservatorios.On("BuscarPorID", uint(3)).Return(reservatorioDWC(2), nil).Once()
+
+		_, err := servico.RegistrarLeitura(3, 7, &dto.LeituraReservatorioDTO{})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestReservatorioService_AnalisarDeriva(t *testing.T) {
+	servico, m := novoReservatorioService()
+	reservatorio := reservatorioDWC(9)
+	m.reservatorios.On("BuscarPorID", uint(3)).Return(reservatorio, nil).Once()
+	inicio := *reservatorio.UltimaTroca
+	// EC subindo 0,1 por dia e pH estável ao longo de 8 dias
+	var leituras []entity.LeituraReservatorio
+	for dia := 0; dia <= 8; dia += 2 {
+		leituras = append(leituras, entity.LeituraReservatorio{
+			ReservatorioID: 3,
+			Data:           inicio.AddDate(0, 0, dia),
+			EC:             valor(1.2 + 0.1*float64(dia)),
+			PH:             valor(5.9),
+		})
+	}
+	leituras[len(leituras)-1].TemperaturaAgua = valor(24)
+	m.reservatorios.On("ListarLeituras", uint(3), mock.AnythingOfType("*time.Time"), (*time.Time)(nil)).Return(leituras, nil).Once()
+	m.reservatorios.On("ListarManutencoes", uint(3)).Return([]entity.ManutencaoReservatorio{
+		{Tipo: entity.ManutencaoReservatorioCompletar, Data: inicio.AddDate(0, 0, 4), VolumeLitros: 9},
+		{Tipo: entity.ManutencaoReservatorioCompletar, Data: inicio.AddDate(0, 0, 2), VolumeLitros: 9},
+		// reposição do ciclo anterior não entra
+		{Tipo: entity.ManutencaoReservatorioCompletar, Data: inicio.AddDate(0, 0, -3), VolumeLitros: 20},
+	}, nil).Once()
+
+	deriva, err := servico.AnalisarDeriva(3, 7)
+
+	require.NoError(t, err)
+	require.NotNil(t, deriva.EC)
+	assert.Equal(t, "subindo", deriva.EC.Tendencia)
+	assert.InDelta(t, 0.1, *deriva.EC.VariacaoDia, 1e-9)
+	assert.Equal(t, 5, deriva.EC.Leituras)
+	assert.Equal(t, "estavel", deriva.PH.Tendencia)
+	assert.Equal(t, 18.0, deriva.LitrosCompletados)
+	require.NotNil(t, deriva.ConsumoLitrosDia)
+	assert.Equal(t, 2.0, *deriva.ConsumoLitrosDia)
+	assert.Equal(t, 24.0, *deriva.TemperaturaAgua)
+	// EC subindo, água quente e troca vencida há 2 dias
+	assert.Len(t, deriva.Diagnosticos, 3)
+	assert.Contains(t, deriva.Diagnosticos[0], "EC subindo")
+}
+
+func TestReservatorioService_GerarTarefasTroca(t *testing.T) {
+	servico, m := novoReservatorioService()
+	semTarefa := reservatorioDWC(5)
+	ambienteID := uint(2)
+	semTarefa.AmbienteID = &ambienteID
+	comTarefa := reservatorioDWC(1)
+	comTarefa.ID = 4
+	m.reservatorios.On("ListarComTrocaAutomatica").Return([]entity.Reservatorio{*semTarefa, *comTarefa}, nil).Once()
+	m.tarefaRepo.On("ListarAbertasPorReservatorio", uint(3)).Return([]entity.Tarefa{}, nil).Once()
+	m.tarefaRepo.On("ListarAbertasPorReservatorio", uint(4)).Return([]entity.Tarefa{{Tipo: entity.TipoTarefaTrocaReservatorio}}, nil).Once()
+	var criada *entity.Tarefa
+	m.tarefaRepo.On("Criar", mock.AnythingOfType("*entity.Tarefa")).Run(func(args mock.Arguments) {
+		criada = args.Get(0).(*entity.Tarefa)
+	}).Return(nil).Once()
+
+	criadas, err := servico.GerarTarefasTroca()
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, criadas)
+	require.NotNil(t, criada)
+	assert.Equal(t, entity.TipoTarefaTrocaReservatorio, criada.Tipo)
+	assert.Equal(t, uint(3), *criada.ReservatorioID)
+	assert.Equal(t, &ambienteID, criada.AmbienteID)
+	assert.Equal(t, uint(7), criada.UsuarioID)
+	assert.True(t, criada.DataAgendada.Equal(semTarefa.UltimaTroca.AddDate(0, 0, 7)))
+	m.tarefaRepo.AssertExpectations(t)
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// SementeService mantém o banco de sementes do usuário. As plantas criadas a partir de um pacote
+// saem do saldo dele, e as germinações registradas alimentam as taxas por breeder e genética.
+type SementeService interface {
+	CriarPacote(usuarioID uint, pacoteDto *dto.PacoteSementeDTO) (*entity.PacoteSemente, error)
+	BuscarPacote(id, usuarioID uint) (*entity.PacoteSemente, error)
+	ListarPacotes(usuarioID uint, consulta *dto.ConsultaPacotesSementeDTO) ([]entity.PacoteSemente, error)
+	AtualizarPacote(id, usuarioID uint, pacoteDto *dto.PacoteSementeDTO) (*entity.PacoteSemente, error)
+	DeletarPacote(id, usuarioID uint) error
+
+	// Plantar cria as plantas a partir das sementes do pacote
+	Plantar(id, usuarioID uint, plantioDto *dto.PlantioPacoteDTO) ([]entity.Planta, error)
+	RegistrarGerminacao(id, usuarioID uint, germinacaoDto *dto.GerminacaoSementeDTO) (*entity.GerminacaoSemente, error)
+	ListarGerminacoes(id, usuarioID uint) ([]entity.GerminacaoSemente, error)
+	// TaxasGerminacao agrupa as germinações do usuário por breeder ou por genética
+	TaxasGerminacao(usuarioID uint, consulta *dto.ConsultaTaxasGerminacaoDTO) ([]dto.TaxaGerminacaoDTO, error)
+}
+
+type sementeService struct {
+	repositorio         repository.SementeRepositorio
+	geneticaRepositorio repository.GeneticaRepositorio
+	plantaRepositorio   repository.PlantaRepositorio
+	ambienteRepositorio repository.AmbienteRepositorio
+	meioRepositorio     repository.MeioCultivoRepositorio
+	agora               func() time.Time
+}
+
+// NewSementeService cria o serviço do banco de sementes.
+func NewSementeService(
+	repositorio repository.SementeRepositorio,
+	geneticaRepositorio repository.GeneticaRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	meioRepositorio repository.MeioCultivoRepositorio,
+) SementeService {
+	return &sementeService{
+		repositorio:         repositorio,
+		geneticaRepositorio: geneticaRepositorio,
+		plantaRepositorio:   plantaRepositorio,
+		ambienteRepositorio: ambienteRepositorio,
+		meioRepositorio:     meioRepositorio,
+		agora:               time.Now,
+	}
+}
+
+func (s *sementeService) CriarPacote(usuarioID uint, pacoteDto *dto.PacoteSementeDTO) (*entity.PacoteSemente, error) {
+	if usuarioID == 0 || pacoteDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	pacote := &entity.PacoteSemente{UsuarioID: usuarioID}
+	if err := s.aplicarPacoteDTO(pacote, pacoteDto); err != nil {
+		return nil, err
+	}
+	pacote.QuantidadeInicial = pacoteDto.Quantidade
+	if err := s.repositorio.CriarPacote(pacote); err != nil {
+		return nil, fmt.Errorf("falha ao criar pacote de sementes: %w", err)
+	}
+	return pacote, nil
+}
+
+func (s *sementeService) BuscarPacote(id, usuarioID uint) (*entity.PacoteSemente, error) {
+	return s.buscarPacote(id, usuarioID)
+}
+
+func (s *sementeService) ListarPacotes(usuarioID uint, consulta *dto.ConsultaPacotesSementeDTO) ([]entity.PacoteSemente, error) {
+	return s.repositorio.ListarPacotes(repository.FiltroPacotesSemente{
+		UsuarioID:   usuarioID,
+		GeneticaID:  consulta.GeneticaID,
+		Breeder:     strings.TrimSpace(consulta.Breeder),
+		ComSementes: consulta.ComSementes,
+	})
+}
+
+func (s *sementeService) AtualizarPacote(id, usuarioID uint, pacoteDto *dto.PacoteSementeDTO) (*entity.PacoteSemente, error) {
+	pacote, err := s.buscarPacote(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if pacoteDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	if err := s.aplicarPacoteDTO(pacote, pacoteDto); err != nil {
+		return nil, err
+	}
+	// uma contagem acima do inicial indica sementes acrescentadas ao pacote
+	if pacote.QuantidadeRestante > pacote.QuantidadeInicial {
+		pacote.QuantidadeInicial = pacote.QuantidadeRestante
+	}
+	if err := s.repositorio.AtualizarPacote(pacote); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar pacote de sementes com ID %d: %w", id, err)
+	}
+	return pacote, nil
+}
+
+func (s *sementeService) DeletarPacote(id, usuarioID uint) error {
+	if _, err := s.buscarPacote(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.repositorio.DeletarPacote(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar pacote de sementes com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *sementeService) Plantar(id, usuarioID uint, plantioDto *dto.PlantioPacoteDTO) ([]entity.Planta, error) {
+	pacote, err := s.buscarPacote(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if plantioDto == nil || plantioDto.Quantidade <= 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	if plantioDto.Quantidade > pacote.QuantidadeRestante {
+		return nil, fmt.Errorf("%w: o pacote tem apenas %d sementes", utils.ErrInvalidInput, pacote.QuantidadeRestante)
+	}
+	especie, err := especiePlantio(plantioDto.Especie, pacote.Genetica)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.ambienteRepositorio.BuscarPorID(plantioDto.AmbienteID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: ambiente %d não encontrado", utils.ErrInvalidInput, plantioDto.AmbienteID)
+		}
+		return nil, fmt.Errorf("falha ao buscar ambiente com ID %d: %w", plantioDto.AmbienteID, err)
+	}
+	if _, err := s.meioRepositorio.BuscarPorID(plantioDto.MeioCultivoID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: meio de cultivo %d não encontrado", utils.ErrInvalidInput, plantioDto.MeioCultivoID)
+		}
+		return nil, fmt.Errorf("falha ao buscar meio de cultivo com ID %d: %w", plantioDto.MeioCultivoID, err)
+	}
+
+	nome := strings.TrimSpace(plantioDto.Nome)
+	if nome == "" && pacote.Genetica != nil {
+		nome = pacote.Genetica.Nome
+	}
+	if nome == "" {
+		return nil, fmt.Errorf("%w: informe o nome das plantas", utils.ErrInvalidInput)
+	}
+	dataPlantio := s.agora()
+	if plantioDto.DataPlantio != nil && !plantioDto.DataPlantio.IsZero() {
+		dataPlantio = *plantioDto.DataPlantio
+	}
+	var notas *string
+	if plantioDto.Notas != "" {
+		notas = &plantioDto.Notas
+	}
+
+	pacoteID := pacote.ID
+	plantas := make([]entity.Planta, 0, plantioDto.Quantidade)
+	for _, nomePlanta := range s.nomesPlantas(nome, plantioDto.Quantidade) {
+		data := dataPlantio
+		plantas = append(plantas, entity.Planta{
+			Nome:            nomePlanta,
+			ComecandoDe:     "semente",
+			Especie:         especie,
+			DataPlantio:     &data,
+			Status:          entity.StatusGerminating,
+			Notas:           notas,
+			GeneticaID:      pacote.GeneticaID,
+			MeioCultivoID:   plantioDto.MeioCultivoID,
+			AmbienteID:      plantioDto.AmbienteID,
+			PacoteSementeID: &pacoteID,
+			UsuarioID:       usuarioID,
+		})
+	}
+	pacote.QuantidadeRestante -= plantioDto.Quantidade
+	if err := s.repositorio.CriarPlantas(pacote, plantas); err != nil {
+		if errors.Is(err, repository.ErrSementesInsuficientes) {
+			return nil, fmt.Errorf("%w: o pacote não tem mais %d sementes", utils.ErrInvalidInput, plantioDto.Quantidade)
+		}
+		return nil, fmt.Errorf("falha ao criar plantas do pacote de sementes %d: %w", id, err)
+	}
+	return plantas, nil
+}
+
+// RegistrarGerminacao tira do pacote as sementes que não germinaram; as germinadas saem do
+// saldo quando viram plantas.
+func (s *sementeService) RegistrarGerminacao(id, usuarioID uint, germinacaoDto *dto.GerminacaoSementeDTO) (*entity.GerminacaoSemente, error) {
+	pacote, err := s.buscarPacote(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if germinacaoDto == nil || germinacaoDto.Semeadas <= 0 || germinacaoDto.Germinadas < 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	if germinacaoDto.Germinadas > germinacaoDto.Semeadas {
+		return nil, fmt.Errorf("%w: germinadas não pode passar das semeadas", utils.ErrInvalidInput)
+	}
+	if germinacaoDto.Semeadas > pacote.QuantidadeRestante {
+		return nil, fmt.Errorf("%w: o pacote tem apenas %d sementes", utils.ErrInvalidInput, pacote.QuantidadeRestante)
+	}
+	data := s.agora()
+	if germinacaoDto.Data != nil && !germinacaoDto.Data.IsZero() {
+		data = *germinacaoDto.Data
+	}
+	germinacao := &entity.GerminacaoSemente{
+		PacoteID:    pacote.ID,
+		Data:        data,
+		Semeadas:    germinacaoDto.Semeadas,
+		Germinadas:  germinacaoDto.Germinadas,
+		Metodo:      germinacaoDto.Metodo,
+		Observacoes: germinacaoDto.Observacoes,
+	}
+	pacote.QuantidadeRestante -= germinacaoDto.Semeadas - germinacaoDto.Germinadas
+	if err := s.repositorio.RegistrarGerminacao(pacote, germinacao); err != nil {
+		if errors.Is(err, repository.ErrSementesInsuficientes) {
+			return nil, fmt.Errorf("%w: o pacote não tem mais %d sementes", utils.ErrInvalidInput, germinacaoDto.Semeadas)
+		}
+		return nil, fmt.Errorf("falha ao registrar germinação do pacote de sementes %d: %w", id, err)
+	}
+	return germinacao, nil
+}
+
+func (s *sementeService) ListarGerminacoes(id, usuarioID uint) ([]entity.GerminacaoSemente, error) {
+	if _, err := s.buscarPacote(id, usuarioID); err != nil {
+		return nil, err
+	}
+	return s.repositorio.ListarGerminacoes(id)
+}
+
+func (s *sementeService) TaxasGerminacao(usuarioID uint, consulta *dto.ConsultaTaxasGerminacaoDTO) ([]dto.TaxaGerminacaoDTO, error) {
+	porBreeder := consulta != nil && consulta.Agrupar == "breeder"
+	germinacoes, err := s.repositorio.ListarGerminacoesUsuario(usuarioID)
+	if err != nil {
+		return nil, err
+	}
+
+	grupos := make(map[string]*dto.TaxaGerminacaoDTO)
+	pacotes := make(map[string]map[uint]bool)
+	var ordem []string
+	for _, germinacao := range germinacoes {
+		if germinacao.Pacote == nil {
+			continue
+		}
+		pacote := germinacao.Pacote
+		chave := fmt.Sprintf("genetica:%d", pacote.GeneticaID)
+		if porBreeder {
+			chave = "breeder:" + strings.ToLower(strings.TrimSpace(pacote.Breeder))
+		}
+		taxa, ok := grupos[chave]
+		if !ok {
+			taxa = &dto.TaxaGerminacaoDTO{}
+			if porBreeder {
+				taxa.Breeder = strings.TrimSpace(pacote.Breeder)
+			} else {
+				geneticaID := pacote.GeneticaID
+				taxa.GeneticaID = &geneticaID
+				if pacote.Genetica != nil {
+					taxa.Genetica = pacote.Genetica.Nome
+				}
+			}
+			grupos[chave] = taxa
+			pacotes[chave] = make(map[uint]bool)
+			ordem = append(ordem, chave)
+		}
+		pacotes[chave][pacote.ID] = true
+		taxa.Germinacoes++
+		taxa.Semeadas += germinacao.Semeadas
+		taxa.Germinadas += germinacao.Germinadas
+	}
+
+	taxas := make([]dto.TaxaGerminacaoDTO, 0, len(ordem))
+	for _, chave := range ordem {
+		taxa := grupos[chave]
+		taxa.Pacotes = len(pacotes[chave])
+		taxa.Taxa = calculo.Arredondar(entity.TaxaGerminacao(taxa.Semeadas, taxa.Germinadas), 1)
+		taxas = append(taxas, *taxa)
+	}
+	sort.SliceStable(taxas, func(i, j int) bool {
+		if taxas[i].Taxa != taxas[j].Taxa {
+			return taxas[i].Taxa > taxas[j].Taxa
+		}
+		return taxas[i].Semeadas > taxas[j].Semeadas
+	})
+	return taxas, nil
+}
+
+// aplicarPacoteDTO valida a genética e copia os campos editáveis; a quantidade vira o saldo
+func (s *sementeService) aplicarPacoteDTO(pacote *entity.PacoteSemente, pacoteDto *dto.PacoteSementeDTO) error {
+	breeder := strings.TrimSpace(pacoteDto.Breeder)
+	if breeder == "" || pacoteDto.Quantidade < 0 {
+		return utils.ErrInvalidInput
+	}
+	genetica, err := s.geneticaRepositorio.BuscarPorID(pacoteDto.GeneticaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: genética %d não encontrada", utils.ErrInvalidInput, pacoteDto.GeneticaID)
+		}
+		return fmt.Errorf("falha ao buscar genética com ID %d: %w", pacoteDto.GeneticaID, err)
+	}
+	pacote.GeneticaID = genetica.ID
+	pacote.Genetica = genetica
+	pacote.Breeder = breeder
+	pacote.QuantidadeRestante = pacoteDto.Quantidade
+	pacote.DataAquisicao = pacoteDto.DataAquisicao
+	pacote.LocalArmazenamento = pacoteDto.LocalArmazenamento
+	pacote.NotasViabilidade = pacoteDto.NotasViabilidade
+	return nil
+}
+
+// nomesPlantas numera os nomes quando há mais de uma planta ou o nome já está em uso,
+// pulando os números que já existem
+func (s *sementeService) nomesPlantas(nome string, quantidade int) []string {
+	if quantidade == 1 && !s.plantaRepositorio.ExistePorNome(nome) {
+		return []string{nome}
+	}
+	nomes := make([]string, 0, quantidade)
+	for numero := 1; len(nomes) < quantidade; numero++ {
+		candidato := fmt.Sprintf("%s #%d", nome, numero)
+		if !s.plantaRepositorio.ExistePorNome(candidato) {
+			nomes = append(nomes, candidato)
+		}
+	}
+	return nomes
+}
+
+// especiePlantio usa a espécie informada ou, sem ela, o tipo da genética; genéticas híbridas
+// exigem a espécie
+func especiePlantio(especie string, genetica *entity.Genetica) (entity.Especie, error) {
+	if especie == "" && genetica != nil {
+		especie = genetica.TipoGenetica
+	}
+	switch entity.Especie(especie) {
+	case entity.EspecieSativa, entity.EspecieIndica, entity.EspecieRuderalis:
+		return entity.Especie(especie), nil
+	}
+	return "", fmt.Errorf("%w: informe a espécie das plantas (sativa, indica ou ruderalis)", utils.ErrInvalidInput)
+}
+
+// buscarPacote retorna ErrNotFound também para pacotes de outro usuário
+func (s *sementeService) buscarPacote(id, usuarioID uint) (*entity.PacoteSemente, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	pacote, err := s.repositorio.BuscarPacote(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar pacote de sementes com ID %d: %w", id, err)
+	}
+	if pacote.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return pacote, nil
+}
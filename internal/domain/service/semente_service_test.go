@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type sementeMocks struct {
+	sementes     *test.MockSementeRepositorio
+	geneticaRepo *test.MockGeneticaRepositorio
+	plantaRepo   *test.MockPlantaRepositorio
+	ambienteRepo *test.MockAmbienteRepositorio
+	meioRepo     *test.MockMeioCultivoRepositorio
+}
+
+func novoSementeService() (service.SementeService, sementeMocks) {
+	m := sementeMocks{
+		sementes:     new(test.MockSementeRepositorio),
+		geneticaRepo: new(test.MockGeneticaRepositorio),
+		plantaRepo:   new(test.MockPlantaRepositorio),
+		ambienteRepo: new(test.MockAmbienteRepositorio),
+		meioRepo:     new(test.MockMeioCultivoRepositorio),
+	}
+	return service.NewSementeService(m.sementes, m.geneticaRepo, m.plantaRepo, m.ambienteRepo, m.meioRepo), m
+}
+
+// pacoteSemente é um pacote do usuário 7 com a genética informada
+func pacoteSemente(id uint, genetica *entity.Genetica, breeder string, restante int) *entity.PacoteSemente {
+	pacote := &entity.PacoteSemente{UsuarioID: 7, GeneticaID: genetica.ID, Genetica: genetica, Breeder: breeder,
+		QuantidadeInicial: 10, QuantidadeRestante: restante}
+	pacote.ID = id
+	return pacote
+}
+
+func geneticaSemente(id uint, nome, tipo string) *entity.Genetica {
+	genetica := &entity.Genetica{Nome: nome, TipoGenetica: tipo}
+	genetica.ID = id
+	return genetica
+}
+
+func TestSementeService_Plantar(t *testing.T) {
+	t.Run("Success - Numera as Plantas e Baixa o Pacote", func(t *testing.T) {
+		servico, m := novoSementeService()
+		pacote := pacoteSemente(4, geneticaSemente(2, "Northern Lights", "indica"), "Sensi", 5)
+		m.sementes.On("BuscarPacote", uint(4)).Return(pacote, nil).Once()
+		m.ambienteRepo.On("BuscarPorID", uint(1)).Return(&entity.Ambiente{}, nil).Once()
+		m.meioRepo.On("BuscarPorID", uint(3)).Return(&entity.MeioCultivo{}, nil).Once()
+		m.plantaRepo.On("ExistePorNome", "Northern Lights #1").Return(true).Once()
+		m.plantaRepo.On("ExistePorNome", mock.Anything).Return(false)
+		m.sementes.On("CriarPlantas", pacote, mock.AnythingOfType("[]entity.Planta")).Return(nil).Once()
+
+		plantas, err := servico.Plantar(4, 7, &dto.PlantioPacoteDTO{Quantidade: 2, AmbienteID: 1, MeioCultivoID: 3})
+
+		require.NoError(t, err)
+		require.Len(t, plantas, 2)
+		assert.Equal(t, "Northern Lights #2", plantas[0].Nome)
+		assert.Equal(t, "Northern Lights #3", plantas[1].Nome)
+		assert.Equal(t, entity.EspecieIndica, plantas[0].Especie)
+		assert.Equal(t, "semente", plantas[0].ComecandoDe)
+		assert.Equal(t, uint(2), plantas[0].GeneticaID)
+		require.NotNil(t, plantas[0].PacoteSementeID)
+		assert.Equal(t, uint(4), *plantas[0].PacoteSementeID)
+		assert.Equal(t, 3, pacote.QuantidadeRestante)
+		m.sementes.AssertExpectations(t)
+	})
+
+	t.Run("Error - Sementes Insuficientes", func(t *testing.T) {
+		servico, m := novoSementeService()
+		pacote := pacoteSemente(4, geneticaSemente(2, "Northern Lights", "indica"), "Sensi", 1)
+		m.sementes.On("BuscarPacote", uint(4)).Return(pacote, nil).Once()
+
+		_, err := servico.Plantar(4, 7, &dto.PlantioPacoteDTO{Quantidade: 2, AmbienteID: 1, MeioCultivoID: 3})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.sementes.AssertNotCalled(t, "CriarPlantas", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Genética Híbrida Sem Espécie", func(t *testing.T) {
+		servico, m := novoSementeService()
+		pacote := pacoteSemente(4, geneticaSemente(2, "Gorilla Glue", "hibrido"), "GG Strains", 5)
+		m.sementes.On("BuscarPacote", uint(4)).Return(pacote, nil).Once()
+
+		_, err := servico.Plantar(4, 7, &dto.PlantioPacoteDTO{Quantidade: 1, AmbienteID: 1, MeioCultivoID: 3})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("Error - Pacote de Outro Usuário", func(t *testing.T) {
+		servico, m := novoSementeService()
+		pacote := pacoteSemente(4, geneticaSemente(2, "Northern Lights", "indica"), "Sensi", 5)
+		pacote.UsuarioID = 8
+		m.sementes.On("BuscarPacote", uint(4)).Return(pacote, nil).Once()
+
+		_, err := servico.Plantar(4, 7, &dto.PlantioPacoteDTO{Quantidade: 1, AmbienteID: 1, MeioCultivoID: 3})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestSementeService_RegistrarGerminacao(t *testing.T) {
+	t.Run("Success - Baixa Só as Sementes que Não Germinaram", func(t *testing.T) {
+		servico, m := novoSementeService()
+		pacote := pacoteSemente(4, geneticaSemente(2, "Northern Lights", "indica"), "Sensi", 10)
+		m.sementes.On("BuscarPacote", uint(4)).Return(pacote, nil).Once()
+		m.sementes.On("RegistrarGerminacao", pacote, mock.AnythingOfType("*entity.GerminacaoSemente")).Return(nil).Once()
+
+		germinacao, err := servico.RegistrarGerminacao(4, 7, &dto.GerminacaoSementeDTO{Semeadas: 5, Germinadas: 4, Metodo: "papel_toalha"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(4), germinacao.PacoteID)
+		assert.False(t, germinacao.Data.IsZero())
+		assert.Equal(t, 9, pacote.QuantidadeRestante)
+	})
+
+	t.Run("Error - Mais Semeadas que o Saldo", func(t *testing.T) {
+		servico, m := novoSementeService()
+		pacote := pacoteSemente(4, geneticaSemente(2, "Northern Lights", "indica"), "Sensi", 3)
+		m.sementes.On("BuscarPacote", uint(4)).Return(pacote, nil).Once()
+
+		_, err := servico.RegistrarGerminacao(4, 7, &dto.GerminacaoSementeDTO{Semeadas: 5, Germinadas: 5})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("Error - Saldo Usado por Outra Requisição", func(t *testing.T) {
+		servico, m := novoSementeService()
+		pacote := pacoteSemente(4, geneticaSemente(2, "Northern Lights", "indica"), "Sensi", 5)
+		m.sementes.On("BuscarPacote", uint(4)).Return(pacote, nil).Once()
+		m.sementes.On("RegistrarGerminacao", pacote, mock.AnythingOfType("*entity.GerminacaoSemente")).
+			Return(repository.ErrSementesInsuficientes).Once()
+
+		_, err := servico.RegistrarGerminacao(4, 7, &dto.GerminacaoSementeDTO{Semeadas: 5, Germinadas: 1})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestSementeService_TaxasGerminacao(t *testing.T) {
+	nl := geneticaSemente(2, "Northern Lights", "indica")
+	haze := geneticaSemente(3, "Super Silver Haze", "sativa")
+	sensiNL := pacoteSemente(4, nl, "Sensi", 0)
+	sensiHaze := pacoteSemente(5, haze, "sensi ", 0)
+	outroNL := pacoteSemente(6, nl, "Dutch Passion", 0)
+	germinacoes := []entity.GerminacaoSemente{
+		{PacoteID: 4, Pacote: sensiNL, Semeadas: 4, Germinadas: 4},
+		{PacoteID: 5, Pacote: sensiHaze, Semeadas: 6, Germinadas: 3},
+		{PacoteID: 6, Pacote: outroNL, Semeadas: 5, Germinadas: 4},
+		{PacoteID: 4, Pacote: sensiNL, Semeadas: 1, Germinadas: 0},
+	}
+
+	t.Run("Success - Por Genética", func(t *testing.T) {
+		servico, m := novoSementeService()
+		m.sementes.On("ListarGerminacoesUsuario", uint(7)).Return(germinacoes, nil).Once()
+
+		taxas, err := servico.TaxasGerminacao(7, &dto.ConsultaTaxasGerminacaoDTO{})
+
+		require.NoError(t, err)
+		require.Len(t, taxas, 2)
+		assert.Equal(t, "Northern Lights", taxas[0].Genetica)
+		assert.Equal(t, 2, taxas[0].Pacotes)
+		assert.Equal(t, 3, taxas[0].Germinacoes)
+		assert.Equal(t, 10, taxas[0].Semeadas)
+		assert.Equal(t, 80.0, taxas[0].Taxa)
+		assert.Equal(t, "Super Silver Haze", taxas[1].Genetica)
+		assert.Equal(t, 50.0, taxas[1].Taxa)
+	})
+
+	t.Run("Success - Por Breeder", func(t *testing.T) {
+		servico, m := novoSementeService()
+		m.sementes.On("ListarGerminacoesUsuario", uint(7)).Return(germinacoes, nil).Once()
+
+		taxas, err := servico.TaxasGerminacao(7, &dto.ConsultaTaxasGerminacaoDTO{Agrupar: "breeder"})
+
+		require.NoError(t, err)
+		require.Len(t, taxas, 2)
+		assert.Equal(t, "Dutch Passion", taxas[0].Breeder)
+		assert.Equal(t, 80.0, taxas[0].Taxa)
+		// grafias diferentes do mesmo breeder entram no mesmo grupo
+		assert.Equal(t, "Sensi", taxas[1].Breeder)
+		assert.Equal(t, 2, taxas[1].Pacotes)
+		assert.Equal(t, 11, taxas[1].Semeadas)
+		assert.Equal(t, 7, taxas[1].Germinadas)
+		assert.Equal(t, 63.6, taxas[1].Taxa)
+		assert.Nil(t, taxas[1].GeneticaID)
+	})
+}
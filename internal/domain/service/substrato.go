@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// SubstratoService mantém o catálogo de misturas de substrato, com pH e retenção de água.
+type SubstratoService interface {
+	Criar(substratoDto *dto.SubstratoDTO) (*entity.Substrato, error)
+	BuscarPorID(id uint) (*entity.Substrato, error)
+	Listar() ([]entity.Substrato, error)
+	Atualizar(id uint, substratoDto *dto.SubstratoDTO) (*entity.Substrato, error)
+	Deletar(id uint) error
+}
+
+type substratoService struct {
+	repositorio repository.SubstratoRepositorio
+}
+
+// NewSubstratoService cria o serviço do catálogo de substratos.
+func NewSubstratoService(repositorio repository.SubstratoRepositorio) SubstratoService {
+	return &substratoService{repositorio: repositorio}
+}
+
+func (s *substratoService) Criar(substratoDto *dto.SubstratoDTO) (*entity.Substrato, error) {
+	substrato := &entity.Substrato{}
+	if err := aplicarSubstratoDTO(substrato, substratoDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Criar(substrato); err != nil {
+		return nil, fmt.Errorf("falha ao criar substrato: %w", err)
+	}
+	return substrato, nil
+}
+
+func (s *substratoService) BuscarPorID(id uint) (*entity.Substrato, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	substrato, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar substrato com ID %d: %w", id, err)
+	}
+	return substrato, nil
+}
+
+func (s *substratoService) Listar() ([]entity.Substrato, error) {
+	return s.repositorio.Listar()
+}
+
+func (s *substratoService) Atualizar(id uint, substratoDto *dto.SubstratoDTO) (*entity.Substrato, error) {
+	substrato, err := s.BuscarPorID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := aplicarSubstratoDTO(substrato, substratoDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Atualizar(substrato); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar substrato com ID %d: %w", id, err)
+	}
+	return substrato, nil
+}
+
+// Deletar mantém as plantas e transplantes que usavam o substrato; o histórico ainda o exibe
+func (s *substratoService) Deletar(id uint) error {
+	if id == 0 {
+		return utils.ErrInvalidInput
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar substrato com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func aplicarSubstratoDTO(substrato *entity.Substrato, substratoDto *dto.SubstratoDTO) error {
+	if substratoDto == nil {
+		return utils.ErrInvalidInput
+	}
+	nome := strings.TrimSpace(substratoDto.Nome)
+	if nome == "" {
+		return fmt.Errorf("%w: o substrato precisa de nome", utils.ErrInvalidInput)
+	}
+	if substratoDto.PH < 0 || substratoDto.PH > 14 {
+		return fmt.Errorf("%w: pH deve estar entre 0 e 14", utils.ErrInvalidInput)
+	}
+	if substratoDto.RetencaoAgua < 0 || substratoDto.RetencaoAgua > 1 {
+		return fmt.Errorf("%w: retenção de água deve estar entre 0 e 1", utils.ErrInvalidInput)
+	}
+	substrato.Nome = nome
+	substrato.Composicao = substratoDto.Composicao
+	substrato.PH = substratoDto.PH
+	substrato.RetencaoAgua = substratoDto.RetencaoAgua
+	return nil
+}
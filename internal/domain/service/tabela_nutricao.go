@@ -0,0 +1,552 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// maxItensTabelaNutricao limita as linhas de uma tabela importada
+const maxItensTabelaNutricao = 1000
+
+// TabelaNutricaoService mantém as tabelas de alimentação do usuário e calcula quanto de cada
+// produto adicionar ao reservatório para a semana da planta.
+type TabelaNutricaoService interface {
+	Criar(usuarioID uint, tabelaDto *dto.TabelaNutricaoDTO) (*entity.TabelaNutricao, error)
+	Listar(usuarioID uint) ([]entity.TabelaNutricao, error)
+	BuscarPorID(id, usuarioID uint) (*entity.TabelaNutricao, error)
+	Atualizar(id, usuarioID uint, tabelaDto *dto.TabelaNutricaoDTO) (*entity.TabelaNutricao, error)
+	Deletar(id, usuarioID uint) error
+	// ImportarJSON cria uma tabela a partir de um documento no formato de TabelaNutricaoDTO
+	ImportarJSON(usuarioID uint, arquivo io.Reader) (*entity.TabelaNutricao, error)
+	// ImportarCSV cria uma tabela a partir de um CSV com uma linha por produto e semana
+	ImportarCSV(usuarioID uint, arquivo io.Reader, opcoes *dto.ImportacaoTabelaNutricaoDTO) (*entity.TabelaNutricao, error)
+	// CalcularMistura retorna as quantidades de cada produto para o volume e a EC alvo informados
+	CalcularMistura(id, usuarioID uint, calculoDto *dto.CalculoMisturaDTO) (*dto.ResultadoMisturaDTO, error)
+}
+
+type tabelaNutricaoService struct {
+	repositorio        repository.TabelaNutricaoRepositorio
+	plantaRepositorio  repository.PlantaRepositorio
+	estagioRepositorio repository.EstagioCrescimentoRepositorio
+	local              *time.Location
+	agora              func() time.Time
+}
+
+// NewTabelaNutricaoService cria o serviço de tabelas de nutrição; local é o fuso usado para
+// contar as semanas do estágio da planta.
+func NewTabelaNutricaoService(
+	repositorio repository.TabelaNutricaoRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	estagioRepositorio repository.EstagioCrescimentoRepositorio,
+	local *time.Location,
+) TabelaNutricaoService {
+	if local == nil {
+		local = time.Local
+	}
+	return &tabelaNutricaoService{
+		repositorio:        repositorio,
+		plantaRepositorio:  plantaRepositorio,
+		estagioRepositorio: estagioRepositorio,
+		local:              local,
+		agora:              time.Now,
+	}
+}
+
+func (s *tabelaNutricaoService) Criar(usuarioID uint, tabelaDto *dto.TabelaNutricaoDTO) (*entity.TabelaNutricao, error) {
+	if usuarioID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	tabela := &entity.TabelaNutricao{UsuarioID: usuarioID}
+	if err := aplicarTabelaNutricaoDTO(tabela, tabelaDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Criar(tabela); err != nil {
+		return nil, fmt.Errorf("falha ao criar tabela de nutrição: %w", err)
+	}
+	return tabela, nil
+}
+
+func (s *tabelaNutricaoService) Listar(usuarioID uint) ([]entity.TabelaNutricao, error) {
+	return s.repositorio.ListarPorUsuario(usuarioID)
+}
+
+func (s *tabelaNutricaoService) BuscarPorID(id, usuarioID uint) (*entity.TabelaNutricao, error) {
+	return s.buscarTabela(id, usuarioID)
+}
+
+func (s *tabelaNutricaoService) Atualizar(id, usuarioID uint, tabelaDto *dto.TabelaNutricaoDTO) (*entity.TabelaNutricao, error) {
+	tabela, err := s.buscarTabela(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if err := aplicarTabelaNutricaoDTO(tabela, tabelaDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Atualizar(tabela); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar tabela de nutrição com ID %d: %w", id, err)
+	}
+	return tabela, nil
+}
+
+func (s *tabelaNutricaoService) Deletar(id, usuarioID uint) error {
+	if _, err := s.buscarTabela(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar tabela de nutrição com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *tabelaNutricaoService) ImportarJSON(usuarioID uint, arquivo io.Reader) (*entity.TabelaNutricao, error) {
+	var tabelaDto dto.TabelaNutricaoDTO
+	if err := json.NewDecoder(arquivo).Decode(&tabelaDto); err != nil {
+		var errSintaxe *json.SyntaxError
+		var errTipo *json.UnmarshalTypeError
+		if errors.As(err, &errSintaxe) || errors.As(err, &errTipo) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: JSON inválido: %v", utils.ErrInvalidInput, err)
+		}
+		return nil, err
+	}
+	return s.Criar(usuarioID, &tabelaDto)
+}
+
+func (s *tabelaNutricaoService) ImportarCSV(usuarioID uint, arquivo io.Reader, opcoes *dto.ImportacaoTabelaNutricaoDTO) (*entity.TabelaNutricao, error) {
+	delimitador, ok := delimitadoresCSV[opcoes.Delimitador]
+	if !ok {
+		return nil, utils.ErrInvalidInput
+	}
+	itens, err := lerTabelaNutricaoCSV(arquivo, delimitador, opcoes.Decimal == "virgula")
+	if err != nil {
+		return nil, err
+	}
+	return s.Criar(usuarioID, &dto.TabelaNutricaoDTO{Nome: opcoes.Nome, Fabricante: opcoes.Fabricante, Itens: itens})
+}
+
+func (s *tabelaNutricaoService) CalcularMistura(id, usuarioID uint, calculoDto *dto.CalculoMisturaDTO) (*dto.ResultadoMisturaDTO, error) {
+	if calculoDto == nil || calculoDto.VolumeLitros <= 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	tabela, err := s.buscarTabela(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	estagio, semana, err := s.semanaDaMistura(usuarioID, calculoDto)
+	if err != nil {
+		return nil, err
+	}
+
+	itens, semanaTabela := itensDaSemana(tabela.Itens, estagio, semana)
+	if len(itens) == 0 {
+		return nil, fmt.Errorf("%w: a tabela não tem doses para o estágio %s", utils.ErrInvalidInput, estagio)
+	}
+
+	escala := calculoDto.EscalaPPM
+	if escala == 0 {
+		escala = calculo.EscalaPPM500
+	}
+	ecBase := 0.0
+	switch {
+	case calculoDto.ECAguaBase != nil:
+		ecBase = *calculoDto.ECAguaBase
+	case calculoDto.PPMAguaBase != nil:
+		ecBase = calculo.PPMParaEC(*calculoDto.PPMAguaBase, escala)
+	}
+
+	resultado := &dto.ResultadoMisturaDTO{
+		TabelaID:     tabela.ID,
+		Estagio:      string(estagio),
+		Semana:       semana,
+		SemanaTabela: semanaTabela,
+		VolumeLitros: calculoDto.VolumeLitros,
+		Fator:        1,
+		ECAguaBase:   calculo.Arredondar(ecBase, 2),
+		EscalaPPM:    escala,
+		Produtos:     make([]dto.ProdutoMisturaDTO, 0, len(itens)),
+	}
+	var ecTabela *float64
+	for _, item := range itens {
+		if ecTabela == nil && item.ECAlvo != nil {
+			ecTabela = item.ECAlvo
+		}
+		if resultado.PHAlvo == nil && item.PHAlvo != nil {
+			resultado.PHAlvo = item.PHAlvo
+		}
+	}
+	if calculoDto.ECAlvo != nil {
+		if ecTabela == nil {
+			return nil, fmt.Errorf("%w: a tabela não informa a EC da semana %d do estágio %s", utils.ErrInvalidInput, semanaTabela, estagio)
+		}
+		resultado.Fator = calculo.FatorDoseEC(*calculoDto.ECAlvo, ecBase, *ecTabela)
+		if resultado.Fator == 0 {
+			return nil, fmt.Errorf("%w: a água base já atinge a EC alvo", utils.ErrInvalidInput)
+		}
+	}
+	if ecTabela != nil {
+		resultado.ECTabela = ecTabela
+		ec := calculo.Arredondar(ecBase+*ecTabela*resultado.Fator, 2)
+		ppm := calculo.Arredondar(calculo.ECParaPPM(ec, escala), 0)
+		resultado.ECEstimada = &ec
+		resultado.PPMEstimado = &ppm
+	}
+
+	estoques := make(map[string]float64, len(calculoDto.Estoques))
+	for _, estoque := range calculoDto.Estoques {
+		estoques[chaveProduto(estoque.Produto)] = estoque.Concentracao
+	}
+	for _, item := range itens {
+		dose := item.Dose * resultado.Fator
+		quantidade := calculo.QuantidadeProduto(dose, calculoDto.VolumeLitros)
+		produto := dto.ProdutoMisturaDTO{
+			Produto:           item.Produto,
+			DoseTabela:        item.Dose,
+			Dose:              calculo.Arredondar(dose, 3),
+			Unidade:           string(item.Unidade),
+			Quantidade:        calculo.Arredondar(quantidade, 2),
+			UnidadeQuantidade: unidadeTotalNutriente(item.Unidade),
+		}
+		if concentracao, ok := estoques[chaveProduto(item.Produto)]; ok {
+			ml := calculo.Arredondar(calculo.VolumeSolucaoEstoque(quantidade, concentracao), 1)
+			produto.MlSolucaoEstoque = &ml
+		}
+		resultado.Produtos = append(resultado.Produtos, produto)
+	}
+	resultado.Fator = calculo.Arredondar(resultado.Fator, 3)
+	return resultado, nil
+}
+
+// semanaDaMistura usa o estágio e a semana informados ou, na falta deles, os da planta
+func (s *tabelaNutricaoService) semanaDaMistura(usuarioID uint, calculoDto *dto.CalculoMisturaDTO) (entity.EstagioPlanta, int, error) {
+	estagio := entity.EstagioPlanta(calculoDto.Estagio)
+	semana := calculoDto.Semana
+	if calculoDto.PlantaID != nil && (estagio == "" || semana == 0) {
+		planta, err := s.plantaRepositorio.BuscarPorID(*calculoDto.PlantaID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", 0, fmt.Errorf("falha ao buscar planta com ID %d: %w", *calculoDto.PlantaID, err)
+		}
+		if err != nil || planta.UsuarioID != usuarioID {
+			return "", 0, fmt.Errorf("%w: planta %d não encontrada", utils.ErrInvalidInput, *calculoDto.PlantaID)
+		}
+		atual, err := s.estagioRepositorio.BuscarAtual(planta.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", 0, fmt.Errorf("%w: a planta %d não tem estágio iniciado", utils.ErrInvalidInput, planta.ID)
+		}
+		if err != nil {
+			return "", 0, fmt.Errorf("falha ao buscar estágio da planta %d: %w", planta.ID, err)
+		}
+		if estagio == "" {
+			estagio = atual.Estagio
+		}
+		if semana == 0 && estagio == atual.Estagio {
+			semana = s.semanaDoEstagio(atual.DataInicio)
+		}
+	}
+	if !estagio.Valid() || semana <= 0 {
+		return "", 0, fmt.Errorf("%w: informe a planta ou o estágio e a semana", utils.ErrInvalidInput)
+	}
+	return estagio, semana, nil
+}
+
+// semanaDoEstagio conta as semanas pelo dia no fuso local; o dia de início é o 1º dia da semana 1
+func (s *tabelaNutricaoService) semanaDoEstagio(inicio time.Time) int {
+	inicio = inicio.In(s.local)
+	hoje := s.agora().In(s.local)
+	de := time.Date(inicio.Year(), inicio.Month(), inicio.Day(), 0, 0, 0, 0, time.UTC)
+	ate := time.Date(hoje.Year(), hoje.Month(), hoje.Day(), 0, 0, 0, 0, time.UTC)
+	dias := int(math.Max(ate.Sub(de).Hours()/24, 0))
+	return dias/7 + 1
+}
+
+// buscarTabela retorna ErrNotFound também para tabelas de outro usuário
+func (s *tabelaNutricaoService) buscarTabela(id, usuarioID uint) (*entity.TabelaNutricao, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	tabela, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar tabela de nutrição com ID %d: %w", id, err)
+	}
+	if tabela.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return tabela, nil
+}
+
+// itensDaSemana retorna os itens da última semana da tabela até a pedida (ou da primeira, se a
+// pedida vier antes de todas), já que as tabelas repetem a dose final até o fim do estágio
+func itensDaSemana(itens []entity.ItemTabelaNutricao, estagio entity.EstagioPlanta, semana int) ([]entity.ItemTabelaNutricao, int) {
+	escolhida := 0
+	for _, item := range itens {
+		if item.Estagio != estagio {
+			continue
+		}
+		switch {
+		case escolhida == 0,
+			item.Semana <= semana && item.Semana > escolhida,
+			escolhida > semana && item.Semana < escolhida:
+			escolhida = item.Semana
+		}
+	}
+	var daSemana []entity.ItemTabelaNutricao
+	for _, item := range itens {
+		if item.Estagio == estagio && item.Semana == escolhida {
+			daSemana = append(daSemana, item)
+		}
+	}
+	return daSemana, escolhida
+}
+
+// aplicarTabelaNutricaoDTO valida os itens, inclusive os importados sem passar pelo binding,
+// e exige a mesma EC e pH em todos os produtos de uma semana
+func aplicarTabelaNutricaoDTO(tabela *entity.TabelaNutricao, tabelaDto *dto.TabelaNutricaoDTO) error {
+	if tabelaDto == nil || strings.TrimSpace(tabelaDto.Nome) == "" {
+		return fmt.Errorf("%w: informe o nome da tabela", utils.ErrInvalidInput)
+	}
+	if len(tabelaDto.Itens) == 0 || len(tabelaDto.Itens) > maxItensTabelaNutricao {
+		return fmt.Errorf("%w: a tabela deve ter de 1 a %d itens", utils.ErrInvalidInput, maxItensTabelaNutricao)
+	}
+
+	type alvosSemana struct{ ec, ph *float64 }
+	alvos := make(map[string]alvosSemana)
+	itens := make([]entity.ItemTabelaNutricao, 0, len(tabelaDto.Itens))
+	for i, itemDto := range tabelaDto.Itens {
+		estagio, ok := estagioTabelaNutricao(itemDto.Estagio)
+		if !ok {
+			return fmt.Errorf("%w: item %d: estágio inválido: %s", utils.ErrInvalidInput, i+1, itemDto.Estagio)
+		}
+		unidade, ok := unidadeDoseTabela(itemDto.Unidade)
+		if !ok {
+			return fmt.Errorf("%w: item %d: unidade inválida: %s", utils.ErrInvalidInput, i+1, itemDto.Unidade)
+		}
+		produto := strings.TrimSpace(itemDto.Produto)
+		if produto == "" || itemDto.Semana <= 0 || itemDto.Dose < 0 {
+			return fmt.Errorf("%w: item %d: informe produto, semana e dose", utils.ErrInvalidInput, i+1)
+		}
+
+		chave := fmt.Sprintf("%s/%d", estagio, itemDto.Semana)
+		anterior, visto := alvos[chave]
+		if visto && (divergem(anterior.ec, itemDto.ECAlvo) || divergem(anterior.ph, itemDto.PHAlvo)) {
+			return fmt.Errorf("%w: item %d: EC e pH devem ser iguais em todos os produtos da semana %d de %s",
+				utils.ErrInvalidInput, i+1, itemDto.Semana, estagio)
+		}
+		if !visto || anterior.ec == nil && anterior.ph == nil {
+			alvos[chave] = alvosSemana{itemDto.ECAlvo, itemDto.PHAlvo}
+		}
+
+		itens = append(itens, entity.ItemTabelaNutricao{
+			Estagio: estagio,
+			Semana:  itemDto.Semana,
+			Produto: produto,
+			Dose:    itemDto.Dose,
+			Unidade: unidade,
+			ECAlvo:  itemDto.ECAlvo,
+			PHAlvo:  itemDto.PHAlvo,
+		})
+	}
+
+	tabela.Nome = strings.TrimSpace(tabelaDto.Nome)
+	tabela.Fabricante = strings.TrimSpace(tabelaDto.Fabricante)
+	tabela.Descricao = tabelaDto.Descricao
+	tabela.Itens = itens
+	return nil
+}
+
+// divergem compara dois alvos opcionais; um alvo ausente não diverge de nenhum
+func divergem(a, b *float64) bool {
+	return a != nil && b != nil && math.Abs(*a-*b) > 1e-9
+}
+
+// aliasesEstagio aceita os nomes usados nas tabelas dos fabricantes
+var aliasesEstagio = map[string]entity.EstagioPlanta{
+	"germinacao": entity.EstagioGerminacao,
+	"germinação": entity.EstagioGerminacao,
+	"plantula":   entity.EstagioPlantula,
+	"plântula":   entity.EstagioPlantula,
+	"muda":       entity.EstagioPlantula,
+	"seedling":   entity.EstagioPlantula,
+	"vegetativo": entity.EstagioVegetativo,
+	"vega":       entity.EstagioVegetativo,
+	"veg":        entity.EstagioVegetativo,
+	"grow":       entity.EstagioVegetativo,
+	"floracao":   entity.EstagioFloracao,
+	"floração":   entity.EstagioFloracao,
+	"flora":      entity.EstagioFloracao,
+	"bloom":      entity.EstagioFloracao,
+	"flower":     entity.EstagioFloracao,
+	"maturacao":  entity.EstagioMaturacao,
+	"maturação":  entity.EstagioMaturacao,
+	"flush":      entity.EstagioMaturacao,
+	"ripen":      entity.EstagioMaturacao,
+}
+
+func estagioTabelaNutricao(valor string) (entity.EstagioPlanta, bool) {
+	estagio, ok := aliasesEstagio[strings.ToLower(strings.TrimSpace(valor))]
+	return estagio, ok
+}
+
+func unidadeDoseTabela(valor string) (entity.UnidadeDose, bool) {
+	switch strings.ToLower(strings.ReplaceAll(strings.TrimSpace(valor), " ", "")) {
+	case "ml_l", "ml/l":
+		return entity.UnidadeDoseMlPorLitro, true
+	case "g_l", "g/l":
+		return entity.UnidadeDoseGPorLitro, true
+	}
+	return "", false
+}
+
+func chaveProduto(produto string) string {
+	return strings.ToLower(strings.TrimSpace(produto))
+}
+
+// colunasTabelaNutricao reconhece as colunas do CSV pelo nome; ec e ph são opcionais
+var colunasTabelaNutricao = map[string][]string{
+	"estagio": {"estagio", "estágio", "stage"},
+	"semana":  {"semana", "week"},
+	"produto": {"produto", "product", "nutriente"},
+	"dose":    {"dose"},
+	"unidade": {"unidade", "unit"},
+	"ec":      {"ec_alvo", "ec"},
+	"ph":      {"ph_alvo", "ph"},
+}
+
+// lerTabelaNutricaoCSV converte um CSV com cabeçalho (estagio, semana, produto, dose, unidade e,
+// opcionalmente, ec_alvo e ph_alvo) nos itens da tabela; qualquer linha inválida rejeita o arquivo
+func lerTabelaNutricaoCSV(arquivo io.Reader, delimitador rune, decimalVirgula bool) ([]dto.ItemTabelaNutricaoDTO, error) {
+	leitor := csv.NewReader(arquivo)
+	leitor.Comma = delimitador
+	leitor.FieldsPerRecord = -1
+	leitor.TrimLeadingSpace = true
+
+	cabecalho, err := leitor.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: arquivo CSV vazio", utils.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, erroLeituraCSV(err)
+	}
+	indices := make(map[string]int)
+	for i, nome := range cabecalho {
+		nome = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(nome, "\ufeff")))
+		for coluna, nomes := range colunasTabelaNutricao {
+			for _, aceito := range nomes {
+				if _, usada := indices[coluna]; nome == aceito && !usada {
+					indices[coluna] = i
+				}
+			}
+		}
+	}
+	obrigatorias := []string{"estagio", "semana", "produto", "dose", "unidade"}
+	for _, coluna := range obrigatorias {
+		if _, ok := indices[coluna]; !ok {
+			return nil, fmt.Errorf("%w: coluna %s ausente no cabeçalho do CSV", utils.ErrInvalidInput, coluna)
+		}
+	}
+
+	celula := func(registro []string, coluna string) string {
+		indice, ok := indices[coluna]
+		if !ok || indice >= len(registro) {
+			return ""
+		}
+		return strings.TrimSpace(registro[indice])
+	}
+	numero := func(bruto string) (float64, error) {
+		if decimalVirgula {
+			bruto = strings.Replace(strings.ReplaceAll(bruto, ".", ""), ",", ".", 1)
+		}
+		return strconv.ParseFloat(bruto, 64)
+	}
+	opcional := func(bruto string) (*float64, error) {
+		if bruto == "" {
+			return nil, nil
+		}
+		valor, err := numero(bruto)
+		if err != nil {
+			return nil, err
+		}
+		return &valor, nil
+	}
+
+	var itens []dto.ItemTabelaNutricaoDTO
+	for {
+		registro, err := leitor.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, erroLeituraCSV(err)
+		}
+		linha, _ := leitor.FieldPos(0)
+		if len(registro) == 1 && strings.TrimSpace(registro[0]) == "" {
+			continue
+		}
+		if len(itens) == maxItensTabelaNutricao {
+			return nil, fmt.Errorf("%w: o CSV passa de %d linhas", utils.ErrInvalidInput, maxItensTabelaNutricao)
+		}
+
+		semana, err := strconv.Atoi(celula(registro, "semana"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: linha %d: semana inválida", utils.ErrInvalidInput, linha)
+		}
+		dose, err := numero(celula(registro, "dose"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: linha %d: dose inválida", utils.ErrInvalidInput, linha)
+		}
+		ec, err := opcional(celula(registro, "ec"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: linha %d: EC inválida", utils.ErrInvalidInput, linha)
+		}
+		ph, err := opcional(celula(registro, "ph"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: linha %d: pH inválido", utils.ErrInvalidInput, linha)
+		}
+		itens = append(itens, dto.ItemTabelaNutricaoDTO{
+			Estagio: celula(registro, "estagio"),
+			Semana:  semana,
+			Produto: celula(registro, "produto"),
+			Dose:    dose,
+			Unidade: celula(registro, "unidade"),
+			ECAlvo:  ec,
+			PHAlvo:  ph,
+		})
+	}
+	// ordena por estágio e semana para a tabela ficar legível mesmo com o CSV fora de ordem
+	sort.SliceStable(itens, func(i, j int) bool {
+		a, _ := estagioTabelaNutricao(itens[i].Estagio)
+		b, _ := estagioTabelaNutricao(itens[j].Estagio)
+		if a != b {
+			return ordemEstagios[a] < ordemEstagios[b]
+		}
+		return itens[i].Semana < itens[j].Semana
+	})
+	return itens, nil
+}
+
+// erroLeituraCSV trata erros de formato como entrada inválida e repassa os de leitura do corpo
+func erroLeituraCSV(err error) error {
+	var errCSV *csv.ParseError
+	if errors.As(err, &errCSV) {
+		return fmt.Errorf("%w: CSV inválido: %v", utils.ErrInvalidInput, err)
+	}
+	return err
+}
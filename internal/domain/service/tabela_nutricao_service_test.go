@@ -0,0 +1,181 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type tabelaNutricaoMocks struct {
+	tabelas    *test.MockTabelaNutricaoRepositorio
+	plantaRepo *test.MockPlantaRepositorio
+	estagios   *test.MockEstagioCrescimentoRepositorio
+}
+
+func novoTabelaNutricaoService() (service.TabelaNutricaoService, tabelaNutricaoMocks) {
+	m := tabelaNutricaoMocks{
+		tabelas:    new(test.MockTabelaNutricaoRepositorio),
+		plantaRepo: new(test.MockPlantaRepositorio),
+		estagios:   new(test.MockEstagioCrescimentoRepositorio),
+	}
+	return service.NewTabelaNutricaoService(m.tabelas, m.plantaRepo, m.estagios, time.UTC), m
+}
+
+// tabelaFloracao tem as semanas 1 e 2 da floração com dois produtos, EC só dos nutrientes
+func tabelaFloracao() *entity.TabelaNutricao {
+	ec1, ec2, ph := 1.2, 1.6, 6.0
+	tabela := &entity.TabelaNutricao{UsuarioID: 7, Nome: "Linha Flora", Itens: []entity.ItemTabelaNutricao{
+		{Estagio: entity.EstagioFloracao, Semana: 1, Produto: "Bloom", Dose: 2, Unidade: entity.UnidadeDoseMlPorLitro, ECAlvo: &ec1},
+		{Estagio: entity.EstagioFloracao, Semana: 2, Produto: "Bloom", Dose: 3, Unidade: entity.UnidadeDoseMlPorLitro, ECAlvo: &ec2, PHAlvo: &ph},
+		{Estagio: entity.EstagioFloracao, Semana: 2, Produto: "PK 13/14", Dose: 0.4, Unidade: entity.UnidadeDoseGPorLitro, ECAlvo: &ec2, PHAlvo: &ph},
+	}}
+	tabela.ID = 4
+	return tabela
+}
+
+func TestTabelaNutricaoService_ImportarCSV(t *testing.T) {
+	t.Run("Success - Ponto e Vírgula, Decimal com Vírgula e Apelidos de Estágio", func(t *testing.T) {
+		servico, m := novoTabelaNutricaoService()
+		m.tabelas.On("Criar", mock.AnythingOfType("*entity.TabelaNutricao")).Return(nil).Once()
+		csv := "Stage;Week;Product;Dose;Unit;EC\n" +
+			"bloom;1;Bloom;2,5;ml/l;1,4\n" +
+			"veg;2;Grow;3;ml_l;1,2\n" +
+			"veg;1;Grow;1,5;ml_l;0,8\n" +
+			"\n"
+
+		tabela, err := servico.ImportarCSV(7, strings.NewReader(csv), &dto.ImportacaoTabelaNutricaoDTO{Nome: "Linha A", Delimitador: "ponto_virgula", Decimal: "virgula"})
+
+		require.NoError(t, err)
+		require.Len(t, tabela.Itens, 3)
+		// ordenada por estágio e semana
+		assert.Equal(t, entity.EstagioVegetativo, tabela.Itens[0].Estagio)
+		assert.Equal(t, 1, tabela.Itens[0].Semana)
+		assert.Equal(t, 1.5, tabela.Itens[0].Dose)
+		assert.Equal(t, entity.EstagioFloracao, tabela.Itens[2].Estagio)
+		assert.Equal(t, entity.UnidadeDoseMlPorLitro, tabela.Itens[2].Unidade)
+		assert.Equal(t, 1.4, *tabela.Itens[2].ECAlvo)
+	})
+
+	t.Run("Error - EC Diferente na Mesma Semana", func(t *testing.T) {
+		servico, m := novoTabelaNutricaoService()
+		csv := "estagio,semana,produto,dose,unidade,ec_alvo\n" +
+			"floracao,1,Bloom,2,ml_l,1.4\n" +
+			"floracao,1,PK,0.5,g_l,1.6\n"
+
+		_, err := servico.ImportarCSV(7, strings.NewReader(csv), &dto.ImportacaoTabelaNutricaoDTO{Nome: "Linha A"})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.tabelas.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Coluna Obrigatória Ausente", func(t *testing.T) {
+		servico, _ := novoTabelaNutricaoService()
+
+		_, err := servico.ImportarCSV(7, strings.NewReader("estagio,semana,produto\nveg,1,Grow\n"), &dto.ImportacaoTabelaNutricaoDTO{Nome: "Linha A"})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "dose")
+	})
+}
+
+func TestTabelaNutricaoService_ImportarJSON(t *testing.T) {
+	servico, m := novoTabelaNutricaoService()
+	m.tabelas.On("Criar", mock.AnythingOfType("*entity.TabelaNutricao")).Return(nil).Once()
+
+	tabela, err := servico.ImportarJSON(7, strings.NewReader(`{"nome":"Linha B","itens":[{"estagio":"vegetativo","semana":1,"produto":"Grow","dose":1,"unidade":"ml_l"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "Linha B", tabela.Nome)
+
+	_, err = servico.ImportarJSON(7, strings.NewReader(`{"nome":`))
+	assert.ErrorIs(t, err, utils.ErrInvalidInput)
+}
+
+func TestTabelaNutricaoService_CalcularMistura(t *testing.T) {
+	t.Run("Success - Semana da Planta, EC Alvo, Água Base e Solução Estoque", func(t *testing.T) {
+		servico, m := novoTabelaNutricaoService()
+		m.tabelas.On("BuscarPorID", uint(4)).Return(tabelaFloracao(), nil).Once()
+		planta := plantaRegada(10, 7, "Amnesia")
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+		// 20 dias de floração: semana 3, que a tabela não tem, então repete a semana 2
+		m.estagios.On("BuscarAtual", uint(10)).Return(&entity.EstagioCrescimento{PlantaID: 10, Estagio: entity.EstagioFloracao, DataInicio: time.Now().AddDate(0, 0, -20)}, nil).Once()
+
+		plantaID := uint(10)
+		resultado, err := servico.CalcularMistura(4, 7, &dto.CalculoMisturaDTO{
+			PlantaID:     &plantaID,
+			VolumeLitros: 20,
+			ECAlvo:       valor(1.6),
+			PPMAguaBase:  valor(200),
+			Estoques:     []dto.SolucaoEstoqueDTO{{Produto: "pk 13/14", Concentracao: 100}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "floracao", resultado.Estagio)
+		assert.Equal(t, 3, resultado.Semana)
+		assert.Equal(t, 2, resultado.SemanaTabela)
+		assert.Equal(t, 0.4, resultado.ECAguaBase)
+		// faltam 1,2 mS/cm e a semana soma 1,6: doses a 75%
+		assert.Equal(t, 0.75, resultado.Fator)
+		assert.Equal(t, 1.6, *resultado.ECEstimada)
+		assert.Equal(t, 800.0, *resultado.PPMEstimado)
+		assert.Equal(t, 6.0, *resultado.PHAlvo)
+		require.Len(t, resultado.Produtos, 2)
+		assert.Equal(t, dto.ProdutoMisturaDTO{Produto: "Bloom", DoseTabela: 3, Dose: 2.25, Unidade: "ml_l", Quantidade: 45, UnidadeQuantidade: "ml"}, resultado.Produtos[0])
+		assert.Equal(t, 6.0, resultado.Produtos[1].Quantidade)
+		assert.Equal(t, "g", resultado.Produtos[1].UnidadeQuantidade)
+		// 6 g de produto num estoque de 100 g/L
+		require.NotNil(t, resultado.Produtos[1].MlSolucaoEstoque)
+		assert.Equal(t, 60.0, *resultado.Produtos[1].MlSolucaoEstoque)
+	})
+
+	t.Run("Success - Estágio e Semana Informados Sem EC Alvo", func(t *testing.T) {
+		servico, m := novoTabelaNutricaoService()
+		m.tabelas.On("BuscarPorID", uint(4)).Return(tabelaFloracao(), nil).Once()
+
+		resultado, err := servico.CalcularMistura(4, 7, &dto.CalculoMisturaDTO{Estagio: "floracao", Semana: 1, VolumeLitros: 10})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, resultado.Fator)
+		assert.Equal(t, 1.2, *resultado.ECEstimada)
+		require.Len(t, resultado.Produtos, 1)
+		assert.Equal(t, 20.0, resultado.Produtos[0].Quantidade)
+		m.plantaRepo.AssertNotCalled(t, "BuscarPorID", mock.Anything)
+	})
+
+	t.Run("Error - Água Base Acima da EC Alvo", func(t *testing.T) {
+		servico, m := novoTabelaNutricaoService()
+		m.tabelas.On("BuscarPorID", uint(4)).Return(tabelaFloracao(), nil).Once()
+
+		_, err := servico.CalcularMistura(4, 7, &dto.CalculoMisturaDTO{Estagio: "floracao", Semana: 2, VolumeLitros: 10, ECAlvo: valor(0.5), ECAguaBase: valor(0.6)})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("Error - Estágio Fora da Tabela", func(t *testing.T) {
+		servico, m := novoTabelaNutricaoService()
+		m.tabelas.On("BuscarPorID", uint(4)).Return(tabelaFloracao(), nil).Once()
+
+		_, err := servico.CalcularMistura(4, 7, &dto.CalculoMisturaDTO{Estagio: "vegetativo", Semana: 2, VolumeLitros: 10})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+
+	t.Run("Error - Tabela de Outro Usuário", func(t *testing.T) {
+		servico, m := novoTabelaNutricaoService()
+		tabela := tabelaFloracao()
+		tabela.UsuarioID = 8
+		m.tabelas.On("BuscarPorID", uint(4)).Return(tabela, nil).Once()
+
+		_, err := servico.CalcularMistura(4, 7, &dto.CalculoMisturaDTO{Estagio: "floracao", Semana: 1, VolumeLitros: 10})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
@@ -0,0 +1,487 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// TarefaService gerencia as tarefas de cuidado das plantas e ambientes do usuário.
+// Pendentes com a data agendada já passada são retornadas como atrasadas.
+type TarefaService interface {
+	Criar(usuarioID uint, tarefaDto *dto.TarefaDTO) (*entity.Tarefa, error)
+	BuscarPorID(id, usuarioID uint) (*entity.Tarefa, error)
+	Listar(usuarioID uint, consulta *dto.ConsultaTarefasDTO) ([]entity.Tarefa, int64, error)
+	Atualizar(id, usuarioID uint, tarefaDto *dto.TarefaDTO) (*entity.Tarefa, error)
+	// Concluir registra a execução com notas e fotos; nas recorrentes, agenda a próxima ocorrência
+	Concluir(id, usuarioID uint, conclusaoDto *dto.ConclusaoTarefaDTO) (*dto.TarefaConcluidaDTO, error)
+	Deletar(id, usuarioID uint) error
+	// AtualizarAtrasadas grava o status atrasada nas pendentes vencidas e retorna quantas mudaram
+	AtualizarAtrasadas() (int64, error)
+	// MaterializarRecorrentes cria antecipadamente as ocorrências das séries recorrentes
+	// agendadas até horizonte a partir de agora e retorna quantas foram criadas
+	MaterializarRecorrentes(horizonte time.Duration) (int, error)
+	// DispararLembretes notifica uma única vez as tarefas que vencem dentro da antecedência
+	// (ou já venceram) e retorna quantas foram lembradas
+	DispararLembretes(antecedencia time.Duration) (int, error)
+}
+
+type tarefaService struct {
+	repositorio         repository.TarefaRepositorio
+	plantaRepositorio   repository.PlantaRepositorio
+	ambienteRepositorio repository.AmbienteRepositorio
+	diarioRepositorio   repository.DiarioCultivoRepositorio
+	estoque             ConsumidorEstoque
+	local               *time.Location
+	agora               func() time.Time
+	canais              []CanalNotificacao
+}
+
+// NewTarefaService cria o serviço de tarefas; local é o fuso dos filtros por dia e
+// dos horários nos lembretes enviados pelos canais. estoque dá baixa nos insumos
+// informados na conclusão e pode ser nil quando o inventário não é usado.
+func NewTarefaService(
+	repositorio repository.TarefaRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	ambienteRepositorio repository.AmbienteRepositorio,
+	diarioRepositorio repository.DiarioCultivoRepositorio,
+	estoque ConsumidorEstoque,
+	local *time.Location,
+	canais ...CanalNotificacao,
+) TarefaService {
+	if local == nil {
+		local = time.Local
+	}
+	return &tarefaService{
+		repositorio:         repositorio,
+		plantaRepositorio:   plantaRepositorio,
+		ambienteRepositorio: ambienteRepositorio,
+		diarioRepositorio:   diarioRepositorio,
+		estoque:             estoque,
+		local:               local,
+		agora:               time.Now,
+		canais:              canais,
+	}
+}
+
+func (s *tarefaService) Criar(usuarioID uint, tarefaDto *dto.TarefaDTO) (*entity.Tarefa, error) {
+	if usuarioID == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+
+	tarefa := &entity.Tarefa{UsuarioID: usuarioID, Status: entity.StatusTarefaPendente}
+	if err := s.aplicarTarefaDTO(tarefa, tarefaDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Criar(tarefa); err != nil {
+		return nil, fmt.Errorf("falha ao criar tarefa: %w", err)
+	}
+	s.normalizarStatus(tarefa)
+	return tarefa, nil
+}
+
+func (s *tarefaService) BuscarPorID(id, usuarioID uint) (*entity.Tarefa, error) {
+	tarefa, err := s.buscarTarefa(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	s.normalizarStatus(tarefa)
+	return tarefa, nil
+}
+
+func (s *tarefaService) Listar(usuarioID uint, consulta *dto.ConsultaTarefasDTO) ([]entity.Tarefa, int64, error) {
+	filtro := repository.FiltroTarefas{
+		UsuarioID:       usuarioID,
+		Status:          entity.StatusTarefa(consulta.Status),
+		PlantaID:        consulta.PlantaID,
+		AmbienteID:      consulta.AmbienteID,
+		DiarioCultivoID: consulta.DiarioCultivoID,
+		Agora:           s.agora(),
+		Page:            consulta.Page,
+		Limit:           consulta.Limit,
+	}
+	if consulta.De != "" {
+		de, err := time.ParseInLocation("2006-01-02", consulta.De, s.local)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: data inicial inválida", utils.ErrInvalidInput)
+		}
+		filtro.De = &de
+	}
+	if consulta.Ate != "" {
+		ate, err := time.ParseInLocation("2006-01-02", consulta.Ate, s.local)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: data final inválida", utils.ErrInvalidInput)
+		}
+		ate = ate.AddDate(0, 0, 1)
+		filtro.Ate = &ate
+	}
+	if filtro.De != nil && filtro.Ate != nil && !filtro.De.Before(*filtro.Ate) {
+		return nil, 0, fmt.Errorf("%w: período inválido", utils.ErrInvalidInput)
+	}
+
+	tarefas, total, err := s.repositorio.Listar(filtro)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range tarefas {
+		s.normalizarStatus(&tarefas[i])
+	}
+	return tarefas, total, nil
+}
+
+func (s *tarefaService) Atualizar(id, usuarioID uint, tarefaDto *dto.TarefaDTO) (*entity.Tarefa, error) {
+	tarefa, err := s.buscarTarefa(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	dataAnterior := tarefa.DataAgendada
+	if err := s.aplicarTarefaDTO(tarefa, tarefaDto); err != nil {
+		return nil, err
+	}
+	// a nova data merece um novo lembrete
+	if !tarefa.DataAgendada.Equal(dataAnterior) {
+		tarefa.LembreteEnviadoEm = nil
+	}
+	// reagendar uma atrasada volta a deixá-la pendente
+	if tarefa.Status == entity.StatusTarefaAtrasada && !tarefa.Atrasada(s.agora()) {
+		tarefa.Status = entity.StatusTarefaPendente
+	}
+
+	if err := s.repositorio.Atualizar(tarefa); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar tarefa com ID %d: %w", id, err)
+	}
+	s.normalizarStatus(tarefa)
+	return tarefa, nil
+}
+
+func (s *tarefaService) Concluir(id, usuarioID uint, conclusaoDto *dto.ConclusaoTarefaDTO) (*dto.TarefaConcluidaDTO, error) {
+	tarefa, err := s.buscarTarefa(id, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if tarefa.Status == entity.StatusTarefaConcluida {
+		return nil, fmt.Errorf("%w: tarefa já concluída", utils.ErrInvalidInput)
+	}
+
+	conclusao := s.agora()
+	if conclusaoDto.DataConclusao != nil && !conclusaoDto.DataConclusao.IsZero() {
+		conclusao = *conclusaoDto.DataConclusao
+	}
+	var consumos *repository.MovimentacoesEstoque
+	if len(conclusaoDto.Consumos) > 0 {
+		if consumos, err = s.prepararBaixa(tarefa, conclusao, conclusaoDto.Consumos); err != nil {
+			return nil, err
+		}
+	}
+	tarefa.Status = entity.StatusTarefaConcluida
+	tarefa.DataConclusao = &conclusao
+	if conclusaoDto.Notas != "" {
+		notas := conclusaoDto.Notas
+		tarefa.NotasConclusao = &notas
+	}
+	for _, foto := range conclusaoDto.Fotos {
+		tarefa.Fotos = append(tarefa.Fotos, entity.Foto{
+			URL:        foto.URL,
+			Descricao:  foto.Descricao,
+			UsuarioID:  usuarioID,
+			AmbienteID: tarefa.AmbienteID,
+		})
+	}
+	if err := s.repositorio.Concluir(tarefa, consumos); err != nil {
+		if errors.Is(err, repository.ErrTarefaJaConcluida) {
+			return nil, fmt.Errorf("%w: tarefa já concluída", utils.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("falha ao concluir tarefa com ID %d: %w", id, err)
+	}
+
+	resultado := &dto.TarefaConcluidaDTO{Tarefa: *tarefa}
+	if tarefa.Recorrente && tarefa.FrequenciaDias != nil && *tarefa.FrequenciaDias > 0 {
+		// o agendador pode já ter criado a próxima ocorrência; uma sucessora excluída encerrou a série
+		sucessora, err := s.repositorio.BuscarSucessora(id)
+		switch {
+		case err == nil:
+			if !sucessora.DeletedAt.Valid {
+				s.normalizarStatus(sucessora)
+				resultado.Proxima = sucessora
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			proxima := proximaOcorrencia(tarefa, conclusao)
+			if err := s.repositorio.Criar(proxima); err != nil {
+				return nil, fmt.Errorf("falha ao agendar a próxima ocorrência da tarefa %d: %w", id, err)
+			}
+			resultado.Proxima = proxima
+		default:
+			return nil, fmt.Errorf("falha ao buscar a próxima ocorrência da tarefa %d: %w", id, err)
+		}
+	}
+	return resultado, nil
+}
+
+func (s *tarefaService) Deletar(id, usuarioID uint) error {
+	if _, err := s.buscarTarefa(id, usuarioID); err != nil {
+		return err
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar tarefa com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *tarefaService) AtualizarAtrasadas() (int64, error) {
+	return s.repositorio.MarcarAtrasadas(s.agora())
+}
+
+func (s *tarefaService) MaterializarRecorrentes(horizonte time.Duration) (int, error) {
+	agora := s.agora()
+	ate := agora.Add(horizonte)
+	ultimas, err := s.repositorio.ListarUltimasRecorrentes(ate)
+	if err != nil {
+		return 0, err
+	}
+
+	criadas := 0
+	var errs []error
+	for i := range ultimas {
+		ultima := &ultimas[i]
+		if ultima.FrequenciaDias == nil || *ultima.FrequenciaDias <= 0 {
+			continue
+		}
+		// ocorrências que já teriam vencido não são criadas, como na conclusão
+		for proxima := proximaOcorrencia(ultima, agora); !proxima.DataAgendada.After(ate); proxima = proximaOcorrencia(ultima, agora) {
+			if err := s.repositorio.Criar(proxima); err != nil {
+				errs = append(errs, fmt.Errorf("falha ao criar ocorrência da tarefa %d: %w", ultima.ID, err))
+				break
+			}
+			criadas++
+			ultima = proxima
+		}
+	}
+	return criadas, errors.Join(errs...)
+}
+
+func (s *tarefaService) DispararLembretes(antecedencia time.Duration) (int, error) {
+	if len(s.canais) == 0 {
+		return 0, nil
+	}
+	agora := s.agora()
+	tarefas, err := s.repositorio.ListarParaLembrete(agora.Add(antecedencia))
+	if err != nil {
+		return 0, err
+	}
+
+	lembradas := 0
+	var errs []error
+	for i := range tarefas {
+		tarefa := &tarefas[i]
+		// basta um canal entregar; os que falharam não são repetidos para não duplicar os demais
+		entregue, err := s.notificar(s.notificacaoTarefa(tarefa, agora))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("falha ao lembrar tarefa %d: %w", tarefa.ID, err))
+		}
+		if !entregue {
+			continue
+		}
+		if err := s.repositorio.MarcarLembreteEnviado(tarefa.ID, agora); err != nil {
+			errs = append(errs, fmt.Errorf("falha ao marcar lembrete da tarefa %d: %w", tarefa.ID, err))
+			continue
+		}
+		lembradas++
+	}
+	return lembradas, errors.Join(errs...)
+}
+
+// notificacaoTarefa descreve a tarefa que vence em breve ou que já está atrasada
+func (s *tarefaService) notificacaoTarefa(tarefa *entity.Tarefa, agora time.Time) Notificacao {
+	data := tarefa.DataAgendada.In(s.local).Format("02/01 15:04")
+	titulo := fmt.Sprintf("Tarefa: %s", tarefa.Tipo)
+	mensagem := fmt.Sprintf("%s agendada para %s.", tarefa.Tipo, data)
+	if tarefa.Atrasada(agora) {
+		titulo = fmt.Sprintf("Tarefa atrasada: %s", tarefa.Tipo)
+		mensagem = fmt.Sprintf("%s estava agendada para %s e ainda não foi concluída.", tarefa.Tipo, data)
+	}
+	if tarefa.Descricao != "" {
+		mensagem += " " + tarefa.Descricao
+	}
+
+	dados := map[string]any{
+		"tarefa_id":     tarefa.ID,
+		"tipo":          tarefa.Tipo,
+		"data_agendada": tarefa.DataAgendada,
+		"prioridade":    tarefa.Prioridade,
+	}
+	if tarefa.PlantaID != nil {
+		dados["planta_id"] = *tarefa.PlantaID
+	}
+	if tarefa.AmbienteID != nil {
+		dados["ambiente_id"] = *tarefa.AmbienteID
+	}
+	return Notificacao{
+		UsuarioID:       tarefa.UsuarioID,
+		Categoria:       entity.CategoriaNotificacaoTarefa,
+		Titulo:          titulo,
+		Mensagem:        mensagem,
+		Dados:           dados,
+		PlantaID:        tarefa.PlantaID,
+		DiarioCultivoID: tarefa.DiarioCultivoID,
+		AmbienteID:      tarefa.AmbienteID,
+	}
+}
+
+// notificar envia por todos os canais e indica se ao menos um entregou
+func (s *tarefaService) notificar(notificacao Notificacao) (bool, error) {
+	entregue := false
+	var errs []error
+	for _, canal := range s.canais {
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutNotificacao)
+		err := canal.Enviar(ctx, notificacao)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("falha ao notificar pelo canal %s: %w", canal.Nome(), err))
+			continue
+		}
+		entregue = true
+	}
+	return entregue, errors.Join(errs...)
+}
+
+// aplicarTarefaDTO valida as referências da tarefa e copia os campos editáveis
+func (s *tarefaService) aplicarTarefaDTO(tarefa *entity.Tarefa, tarefaDto *dto.TarefaDTO) error {
+	if tarefaDto == nil || tarefaDto.Tipo == "" || tarefaDto.DataAgendada.IsZero() {
+		return utils.ErrInvalidInput
+	}
+	if tarefaDto.Recorrente && (tarefaDto.FrequenciaDias == nil || *tarefaDto.FrequenciaDias <= 0) {
+		return fmt.Errorf("%w: tarefas recorrentes precisam de frequencia_dias", utils.ErrInvalidInput)
+	}
+
+	ambienteID := tarefaDto.AmbienteID
+	if tarefaDto.PlantaID != nil {
+		planta, err := s.plantaRepositorio.BuscarPorID(*tarefaDto.PlantaID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("falha ao buscar planta com ID %d: %w", *tarefaDto.PlantaID, err)
+		}
+		if err != nil || planta.UsuarioID != tarefa.UsuarioID {
+			return fmt.Errorf("%w: planta %d não encontrada", utils.ErrInvalidInput, *tarefaDto.PlantaID)
+		}
+		if ambienteID == nil && planta.AmbienteID != 0 {
+			id := planta.AmbienteID
+			ambienteID = &id
+		}
+	}
+	if tarefaDto.AmbienteID != nil {
+		if _, err := s.ambienteRepositorio.BuscarPorID(*tarefaDto.AmbienteID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: ambiente %d não encontrado", utils.ErrInvalidInput, *tarefaDto.AmbienteID)
+			}
+			return fmt.Errorf("falha ao buscar ambiente com ID %d: %w", *tarefaDto.AmbienteID, err)
+		}
+	}
+	if tarefaDto.DiarioCultivoID != nil {
+		diario, err := s.diarioRepositorio.GetByID(*tarefaDto.DiarioCultivoID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("falha ao buscar diário de cultivo com ID %d: %w", *tarefaDto.DiarioCultivoID, err)
+		}
+		if err != nil || diario.UsuarioID != tarefa.UsuarioID {
+			return fmt.Errorf("%w: diário de cultivo %d não encontrado", utils.ErrInvalidInput, *tarefaDto.DiarioCultivoID)
+		}
+	}
+
+	prioridade := entity.PrioridadeTarefa(tarefaDto.Prioridade)
+	if prioridade == "" {
+		prioridade = entity.PrioridadeMedia
+	}
+	tarefa.Tipo = tarefaDto.Tipo
+	tarefa.Descricao = tarefaDto.Descricao
+	tarefa.DataAgendada = tarefaDto.DataAgendada
+	tarefa.Prioridade = prioridade
+	tarefa.PlantaID = tarefaDto.PlantaID
+	tarefa.AmbienteID = ambienteID
+	tarefa.DiarioCultivoID = tarefaDto.DiarioCultivoID
+	tarefa.Recorrente = tarefaDto.Recorrente
+	tarefa.FrequenciaDias = nil
+	if tarefaDto.Recorrente {
+		tarefa.FrequenciaDias = tarefaDto.FrequenciaDias
+	}
+	return nil
+}
+
+// buscarTarefa retorna ErrNotFound também para tarefas de outro usuário
+func (s *tarefaService) buscarTarefa(id, usuarioID uint) (*entity.Tarefa, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	tarefa, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar tarefa com ID %d: %w", id, err)
+	}
+	if tarefa.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return tarefa, nil
+}
+
+// prepararBaixa monta as saídas de estoque dos insumos usados na tarefa, gravadas junto com a
+// conclusão; transplantes ficam com a própria origem para o histórico do item
+func (s *tarefaService) prepararBaixa(tarefa *entity.Tarefa, conclusao time.Time, consumos []dto.ItemConsumidoDTO) (*repository.MovimentacoesEstoque, error) {
+	if s.estoque == nil {
+		return nil, fmt.Errorf("%w: controle de estoque indisponível", utils.ErrInvalidInput)
+	}
+	origem := entity.OrigemEstoqueTarefa
+	if tarefa.Tipo == string(entity.Transplantar) {
+		origem = entity.OrigemEstoqueTransplante
+	}
+	tarefaID := tarefa.ID
+	return s.estoque.PrepararBaixa(tarefa.UsuarioID, BaixaEstoque{
+		Origem:          origem,
+		OrigemID:        &tarefaID,
+		PlantaID:        tarefa.PlantaID,
+		DiarioCultivoID: tarefa.DiarioCultivoID,
+		Data:            conclusao,
+	}, consumos)
+}
+
+// normalizarStatus mostra como atrasada a pendente vencida que ainda não foi marcada
+func (s *tarefaService) normalizarStatus(tarefa *entity.Tarefa) {
+	if tarefa.Status == entity.StatusTarefaPendente && tarefa.Atrasada(s.agora()) {
+		tarefa.Status = entity.StatusTarefaAtrasada
+	}
+}
+
+// proximaOcorrencia agenda a tarefa recorrente na frequência a partir da data agendada,
+// pulando as ocorrências que já teriam vencido na conclusão (ou agora, no agendador)
+func proximaOcorrencia(tarefa *entity.Tarefa, conclusao time.Time) *entity.Tarefa {
+	data := tarefa.DataAgendada.AddDate(0, 0, *tarefa.FrequenciaDias)
+	for !data.After(conclusao) {
+		data = data.AddDate(0, 0, *tarefa.FrequenciaDias)
+	}
+	anteriorID := tarefa.ID
+	return &entity.Tarefa{
+		Tipo:             tarefa.Tipo,
+		Descricao:        tarefa.Descricao,
+		DataAgendada:     data,
+		Status:           entity.StatusTarefaPendente,
+		Prioridade:       tarefa.Prioridade,
+		PlantaID:         tarefa.PlantaID,
+		AmbienteID:       tarefa.AmbienteID,
+		UsuarioID:        tarefa.UsuarioID,
+		Recorrente:       true,
+		FrequenciaDias:   tarefa.FrequenciaDias,
+		TarefaAnteriorID: &anteriorID,
+		DiarioCultivoID:  tarefa.DiarioCultivoID,
+		// as ocorrências seguem ligadas ao cronograma para serem replanejadas com ele
+		TarefaTemplateID:      tarefa.TarefaTemplateID,
+		AplicacaoCronogramaID: tarefa.AplicacaoCronogramaID,
+	}
+}
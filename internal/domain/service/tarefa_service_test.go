@@ -0,0 +1,345 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mocksTarefa struct {
+	repositorio  *test.MockTarefaRepositorio
+	plantaRepo   *test.MockPlantaRepositorio
+	ambienteRepo *test.MockAmbienteRepositorio
+	diarioRepo   *MockDiarioCultivoRepository
+	estoque      *test.MockConsumidorEstoque
+	canal        *test.MockCanalNotificacao
+}
+
+func novoTarefaService() (service.TarefaService, *mocksTarefa) {
+	m := &mocksTarefa{
+		repositorio:  new(test.MockTarefaRepositorio),
+		plantaRepo:   new(test.MockPlantaRepositorio),
+		ambienteRepo: new(test.MockAmbienteRepositorio),
+		diarioRepo:   new(MockDiarioCultivoRepository),
+		estoque:      new(test.MockConsumidorEstoque),
+		canal:        &test.MockCanalNotificacao{NomeCanal: service.CanalLembrete},
+	}
+	return service.NewTarefaService(m.repositorio, m.plantaRepo, m.ambienteRepo, m.diarioRepo, m.estoque, time.UTC, m.canal), m
+}
+
+func tarefaDoUsuario(id, usuarioID uint, dataAgendada time.Time) *entity.Tarefa {
+	tarefa := &entity.Tarefa{Tipo: "regar", DataAgendada: dataAgendada, Status: entity.StatusTarefaPendente, UsuarioID: usuarioID}
+	tarefa.ID = id
+	return tarefa
+}
+
+func TestTarefaService_Criar(t *testing.T) {
+	t.Run("Success - Ambiente da Planta", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		plantaID := uint(3)
+		m.plantaRepo.On("BuscarPorID", plantaID).Return(&entity.Planta{UsuarioID: 7, AmbienteID: 2}, nil).Once()
+		m.repositorio.On("Criar", mock.AnythingOfType("*entity.Tarefa")).Return(nil).Once()
+
+		tarefa, err := servico.Criar(7, &dto.TarefaDTO{Tipo: "regar", DataAgendada: time.Now().Add(time.Hour), PlantaID: &plantaID})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.StatusTarefaPendente, tarefa.Status)
+		assert.Equal(t, entity.PrioridadeMedia, tarefa.Prioridade)
+		require.NotNil(t, tarefa.AmbienteID)
+		assert.Equal(t, uint(2), *tarefa.AmbienteID)
+		m.repositorio.AssertExpectations(t)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		plantaID := uint(3)
+		m.plantaRepo.On("BuscarPorID", plantaID).Return(&entity.Planta{UsuarioID: 8}, nil).Once()
+
+		_, err := servico.Criar(7, &dto.TarefaDTO{Tipo: "regar", DataAgendada: time.Now(), PlantaID: &plantaID})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.repositorio.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Recorrente sem Frequência", func(t *testing.T) {
+		servico, m := novoTarefaService()
+
+		_, err := servico.Criar(7, &dto.TarefaDTO{Tipo: "regar", DataAgendada: time.Now(), Recorrente: true})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.repositorio.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Diário Não Encontrado", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		diarioID := uint(4)
+		m.diarioRepo.On("GetByID", diarioID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := servico.Criar(7, &dto.TarefaDTO{Tipo: "regar", DataAgendada: time.Now(), DiarioCultivoID: &diarioID})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestTarefaService_BuscarPorID(t *testing.T) {
+	t.Run("Success - Pendente Vencida Aparece Atrasada", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		m.repositorio.On("BuscarPorID", uint(1)).Return(tarefaDoUsuario(1, 7, time.Now().Add(-time.Hour)), nil).Once()
+
+		tarefa, err := servico.BuscarPorID(1, 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.StatusTarefaAtrasada, tarefa.Status)
+	})
+
+	t.Run("Error - Outro Usuário", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		m.repositorio.On("BuscarPorID", uint(1)).Return(tarefaDoUsuario(1, 8, time.Now()), nil).Once()
+
+		_, err := servico.BuscarPorID(1, 7)
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestTarefaService_Listar(t *testing.T) {
+	servico, m := novoTarefaService()
+	futura := tarefaDoUsuario(1, 7, time.Now().Add(time.Hour))
+	vencida := tarefaDoUsuario(2, 7, time.Now().Add(-time.Hour))
+	m.repositorio.On("Listar", mock.MatchedBy(func(filtro repository.FiltroTarefas) bool {
+		return filtro.UsuarioID == 7 &&
+			filtro.PlantaID == 3 &&
+			filtro.De.Equal(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)) &&
+			filtro.Ate.Equal(time.Date(2026, 6, 8, 0, 0, 0, 0, time.UTC)) &&
+			!filtro.Agora.IsZero()
+	})).Return([]entity.Tarefa{*vencida, *futura}, int64(2), nil).Once()
+
+	tarefas, total, err := servico.Listar(7, &dto.ConsultaTarefasDTO{
+		PaginationParams: dto.PaginationParams{Page: 1, Limit: 10},
+		PlantaID:         3,
+		De:               "2026-06-01",
+		Ate:              "2026-06-07",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, entity.StatusTarefaAtrasada, tarefas[0].Status)
+	assert.Equal(t, entity.StatusTarefaPendente, tarefas[1].Status)
+}
+
+func TestTarefaService_Concluir(t *testing.T) {
+	t.Run("Success - Recorrente Agenda Próxima", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		frequencia := 3
+		agendada := time.Now().Add(-4 * 24 * time.Hour)
+		tarefa := tarefaDoUsuario(1, 7, agendada)
+		tarefa.Recorrente = true
+		tarefa.FrequenciaDias = &frequencia
+		m.repositorio.On("BuscarPorID", uint(1)).Return(tarefa, nil).Once()
+		m.repositorio.On("Concluir", mock.AnythingOfType("*entity.Tarefa"), (*repository.MovimentacoesEstoque)(nil)).Return(nil).Once()
+		m.repositorio.On("BuscarSucessora", uint(1)).Return(nil, gorm.ErrRecordNotFound).Once()
+		m.repositorio.On("Criar", mock.AnythingOfType("*entity.Tarefa")).Return(nil).Once()
+
+		resultado, err := servico.Concluir(1, 7, &dto.ConclusaoTarefaDTO{
+			Notas: "Regada com 2 L",
+			Fotos: []dto.FotoTarefaDTO{{URL: "https://fotos.example/1.jpg"}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.StatusTarefaConcluida, resultado.Tarefa.Status)
+		assert.Equal(t, "Regada com 2 L", *resultado.Tarefa.NotasConclusao)
+		require.Len(t, resultado.Tarefa.Fotos, 1)
+		assert.Equal(t, uint(7), resultado.Tarefa.Fotos[0].UsuarioID)
+		require.NotNil(t, resultado.Proxima)
+		// a ocorrência de 3 dias depois já venceu: pula para 6 dias depois da agendada
+		assert.True(t, resultado.Proxima.DataAgendada.Equal(agendada.AddDate(0, 0, 6)))
+		assert.Equal(t, entity.StatusTarefaPendente, resultado.Proxima.Status)
+		assert.Equal(t, uint(1), *resultado.Proxima.TarefaAnteriorID)
+		m.repositorio.AssertExpectations(t)
+	})
+
+	t.Run("Success - Próxima Já Criada Pelo Agendador", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		frequencia := 7
+		tarefa := tarefaDoUsuario(1, 7, time.Now())
+		tarefa.Recorrente = true
+		tarefa.FrequenciaDias = &frequencia
+		sucessora := tarefaDoUsuario(2, 7, time.Now().AddDate(0, 0, 7))
+		m.repositorio.On("BuscarPorID", uint(1)).Return(tarefa, nil).Once()
+		m.repositorio.On("Concluir", mock.AnythingOfType("*entity.Tarefa"), (*repository.MovimentacoesEstoque)(nil)).Return(nil).Once()
+		m.repositorio.On("BuscarSucessora", uint(1)).Return(sucessora, nil).Once()
+
+		resultado, err := servico.Concluir(1, 7, &dto.ConclusaoTarefaDTO{})
+
+		require.NoError(t, err)
+		assert.Same(t, sucessora, resultado.Proxima)
+		m.repositorio.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+
+	t.Run("Error - Já Concluída", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		tarefa := tarefaDoUsuario(1, 7, time.Now())
+		tarefa.Status = entity.StatusTarefaConcluida
+		m.repositorio.On("BuscarPorID", uint(1)).Return(tarefa, nil).Once()
+
+		_, err := servico.Concluir(1, 7, &dto.ConclusaoTarefaDTO{})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.repositorio.AssertNotCalled(t, "Concluir", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Concluída por Outra Requisição", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		m.repositorio.On("BuscarPorID", uint(1)).Return(tarefaDoUsuario(1, 7, time.Now()), nil).Once()
+		m.repositorio.On("Concluir", mock.AnythingOfType("*entity.Tarefa"), (*repository.MovimentacoesEstoque)(nil)).
+			Return(repository.ErrTarefaJaConcluida).Once()
+
+		_, err := servico.Concluir(1, 7, &dto.ConclusaoTarefaDTO{})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.repositorio.AssertNotCalled(t, "BuscarSucessora", mock.Anything)
+	})
+
+	t.Run("Success - Transplante Dá Baixa nos Insumos", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		tarefa := tarefaDoUsuario(1, 7, time.Now())
+		tarefa.Tipo = "transplantar"
+		plantaID := uint(10)
+		tarefa.PlantaID = &plantaID
+		consumos := []dto.ItemConsumidoDTO{{ItemID: 3, Quantidade: 1}, {ItemID: 4, Quantidade: 11}}
+		m.repositorio.On("BuscarPorID", uint(1)).Return(tarefa, nil).Once()
+		baixa := &repository.MovimentacoesEstoque{}
+		m.estoque.On("PrepararBaixa", uint(7), mock.MatchedBy(func(baixa service.BaixaEstoque) bool {
+			return baixa.Origem == entity.OrigemEstoqueTransplante && *baixa.OrigemID == 1 && *baixa.PlantaID == 10
+		}), consumos).Return(baixa, nil).Once()
+		// a baixa é gravada na mesma transação da conclusão
+		m.repositorio.On("Concluir", mock.MatchedBy(func(tarefa *entity.Tarefa) bool {
+			return tarefa.Status == entity.StatusTarefaConcluida
+		}), baixa).Return(nil).Once()
+
+		resultado, err := servico.Concluir(1, 7, &dto.ConclusaoTarefaDTO{Consumos: consumos})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.StatusTarefaConcluida, resultado.Tarefa.Status)
+		m.estoque.AssertExpectations(t)
+		m.repositorio.AssertExpectations(t)
+	})
+
+	t.Run("Error - Item de Estoque Inválido Não Conclui", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		m.repositorio.On("BuscarPorID", uint(1)).Return(tarefaDoUsuario(1, 7, time.Now()), nil).Once()
+		m.estoque.On("PrepararBaixa", uint(7), mock.Anything, mock.Anything).Return(nil, utils.ErrInvalidInput).Once()
+
+		_, err := servico.Concluir(1, 7, &dto.ConclusaoTarefaDTO{Consumos: []dto.ItemConsumidoDTO{{ItemID: 99, Quantidade: 1}}})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.repositorio.AssertNotCalled(t, "Concluir", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTarefaService_Atualizar_ReagendarAtrasada(t *testing.T) {
+	servico, m := novoTarefaService()
+	tarefa := tarefaDoUsuario(1, 7, time.Now().Add(-time.Hour))
+	tarefa.Status = entity.StatusTarefaAtrasada
+	m.repositorio.On("BuscarPorID", uint(1)).Return(tarefa, nil).Once()
+	m.repositorio.On("Atualizar", mock.AnythingOfType("*entity.Tarefa")).Return(nil).Once()
+
+	atualizada, err := servico.Atualizar(1, 7, &dto.TarefaDTO{Tipo: "regar", DataAgendada: time.Now().Add(24 * time.Hour)})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusTarefaPendente, atualizada.Status)
+}
+
+func TestTarefaService_Deletar(t *testing.T) {
+	servico, m := novoTarefaService()
+	m.repositorio.On("BuscarPorID", uint(1)).Return(tarefaDoUsuario(1, 8, time.Now()), nil).Once()
+
+	err := servico.Deletar(1, 7)
+
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	m.repositorio.AssertNotCalled(t, "Deletar", mock.Anything)
+}
+
+func TestTarefaService_MaterializarRecorrentes(t *testing.T) {
+	servico, m := novoTarefaService()
+	semanal, diaria := 7, 1
+	// a semanal venceu há 10 dias: as ocorrências passadas são puladas
+	semanalVencida := tarefaDoUsuario(1, 7, time.Now().AddDate(0, 0, -10))
+	semanalVencida.Recorrente = true
+	semanalVencida.FrequenciaDias = &semanal
+	diariaAmanha := tarefaDoUsuario(2, 7, time.Now().AddDate(0, 0, 1))
+	diariaAmanha.Recorrente = true
+	diariaAmanha.FrequenciaDias = &diaria
+	m.repositorio.On("ListarUltimasRecorrentes", mock.AnythingOfType("time.Time")).
+		Return([]entity.Tarefa{*semanalVencida, *diariaAmanha}, nil).Once()
+	var criadas []*entity.Tarefa
+	m.repositorio.On("Criar", mock.AnythingOfType("*entity.Tarefa")).Run(func(args mock.Arguments) {
+		tarefa := args.Get(0).(*entity.Tarefa)
+		tarefa.ID = uint(100 + len(criadas))
+		criadas = append(criadas, tarefa)
+	}).Return(nil)
+
+	total, err := servico.MaterializarRecorrentes(4 * 24 * time.Hour)
+
+	require.NoError(t, err)
+	// semanal: só a de daqui a 4 dias; diária: de 2 a 4 dias à frente
+	assert.Equal(t, 4, total)
+	require.Len(t, criadas, 4)
+	assert.True(t, criadas[0].DataAgendada.Equal(semanalVencida.DataAgendada.AddDate(0, 0, 14)))
+	assert.Equal(t, uint(1), *criadas[0].TarefaAnteriorID)
+	assert.Equal(t, uint(2), *criadas[1].TarefaAnteriorID)
+	assert.Equal(t, criadas[1].ID, *criadas[2].TarefaAnteriorID)
+	assert.Equal(t, criadas[2].ID, *criadas[3].TarefaAnteriorID)
+	assert.True(t, criadas[3].DataAgendada.Equal(diariaAmanha.DataAgendada.AddDate(0, 0, 3)))
+}
+
+func TestTarefaService_DispararLembretes(t *testing.T) {
+	t.Run("Success - Marca As Entregues", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		planta := uint(3)
+		proxima := tarefaDoUsuario(1, 7, time.Now().Add(30*time.Minute))
+		proxima.PlantaID = &planta
+		atrasada := tarefaDoUsuario(2, 8, time.Now().Add(-2*time.Hour))
+		m.repositorio.On("ListarParaLembrete", mock.AnythingOfType("time.Time")).
+			Return([]entity.Tarefa{*proxima, *atrasada}, nil).Once()
+		m.canal.On("Enviar", mock.Anything, mock.MatchedBy(func(n service.Notificacao) bool {
+			return n.UsuarioID == 7 && n.Titulo == "Tarefa: regar" && n.Dados["planta_id"] == uint(3)
+		})).Return(nil).Once()
+		m.canal.On("Enviar", mock.Anything, mock.MatchedBy(func(n service.Notificacao) bool {
+			return n.UsuarioID == 8 && n.Titulo == "Tarefa atrasada: regar"
+		})).Return(nil).Once()
+		m.repositorio.On("MarcarLembreteEnviado", uint(1), mock.AnythingOfType("time.Time")).Return(nil).Once()
+		m.repositorio.On("MarcarLembreteEnviado", uint(2), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		total, err := servico.DispararLembretes(time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		m.canal.AssertExpectations(t)
+		m.repositorio.AssertExpectations(t)
+	})
+
+	t.Run("Error - Canal Falhou Fica Para A Próxima", func(t *testing.T) {
+		servico, m := novoTarefaService()
+		m.repositorio.On("ListarParaLembrete", mock.AnythingOfType("time.Time")).
+			Return([]entity.Tarefa{*tarefaDoUsuario(1, 7, time.Now())}, nil).Once()
+		m.canal.On("Enviar", mock.Anything, mock.Anything).Return(errors.New("indisponível")).Once()
+
+		total, err := servico.DispararLembretes(time.Hour)
+
+		assert.Error(t, err)
+		assert.Zero(t, total)
+		m.repositorio.AssertNotCalled(t, "MarcarLembreteEnviado", mock.Anything, mock.Anything)
+	})
+}
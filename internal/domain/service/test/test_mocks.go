@@ -1,7 +1,13 @@
 package test
 
 import (
+	"context"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -115,6 +121,26 @@ func (m *MockGeneticaRepositorio) Deletar(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockGeneticaRepositorio) BuscarPorIDs(ids []uint) ([]entity.Genetica, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]entity.Genetica), args.Error(1)
+}
+
+func (m *MockGeneticaRepositorio) ListarCatalogo() ([]entity.Genetica, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.Genetica), args.Error(1)
+}
+
+func (m *MockGeneticaRepositorio) CriarEmLote(geneticas []entity.Genetica) error {
+	args := m.Called(geneticas)
+	return args.Error(0)
+}
+
+func (m *MockGeneticaRepositorio) Mesclar(destino *entity.Genetica, duplicadas []uint) (int64, int64, error) {
+	args := m.Called(destino, duplicadas)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
 // MockMeioCultivoRepositorio é um mock para a interface MeioCultivoRepositorio.
 type MockMeioCultivoRepositorio struct {
 	mock.Mock
@@ -218,4 +244,958 @@ func (m *MockRegistroDiarioRepositorio) Delete(id uint) error {
 func (m *MockRegistroDiarioRepositorio) ListarPorDiarioCultivoID(diarioCultivoID uint, page, limit int) ([]entity.RegistroDiario, int64, error) {
 	args := m.Called(diarioCultivoID, page, limit)
 	return args.Get(0).([]entity.RegistroDiario), args.Get(1).(int64), args.Error(2)
-}
\ No newline at end of file
+}
+// MockMicroclimaRepositorio é um mock para a interface MicroclimaRepositorio.
+type MockMicroclimaRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockMicroclimaRepositorio) Criar(leitura *entity.Microclima) error {
+	args := m.Called(leitura)
+	return args.Error(0)
+}
+
+func (m *MockMicroclimaRepositorio) CriarEmLote(leituras []entity.Microclima) error {
+	args := m.Called(leituras)
+	return args.Error(0)
+}
+
+func (m *MockMicroclimaRepositorio) ListarPorPeriodo(ambienteID uint, inicio, fim time.Time, limit int) ([]entity.Microclima, error) {
+	args := m.Called(ambienteID, inicio, fim, limit)
+	return args.Get(0).([]entity.Microclima), args.Error(1)
+}
+
+func (m *MockMicroclimaRepositorio) Agregar(ambienteID uint, inicio, fim time.Time, intervalo time.Duration) ([]entity.MicroclimaAgregado, error) {
+	args := m.Called(ambienteID, inicio, fim, intervalo)
+	return args.Get(0).([]entity.MicroclimaAgregado), args.Error(1)
+}
+
+// MockEstagioCrescimentoRepositorio é um mock para a interface EstagioCrescimentoRepositorio.
+type MockEstagioCrescimentoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockEstagioCrescimentoRepositorio) IniciarEstagio(estagio *entity.EstagioCrescimento) error {
+	args := m.Called(estagio)
+	return args.Error(0)
+}
+
+func (m *MockEstagioCrescimentoRepositorio) BuscarAtual(plantaID uint) (*entity.EstagioCrescimento, error) {
+	args := m.Called(plantaID)
+	return args.Get(0).(*entity.EstagioCrescimento), args.Error(1)
+}
+
+func (m *MockEstagioCrescimentoRepositorio) ListarPorPlanta(plantaID uint) ([]entity.EstagioCrescimento, error) {
+	args := m.Called(plantaID)
+	return args.Get(0).([]entity.EstagioCrescimento), args.Error(1)
+}
+
+func (m *MockEstagioCrescimentoRepositorio) ListarAtuaisPorAmbiente(ambienteID uint) ([]entity.EstagioCrescimento, error) {
+	args := m.Called(ambienteID)
+	return args.Get(0).([]entity.EstagioCrescimento), args.Error(1)
+}
+
+// MockRegraAlertaRepositorio é um mock para a interface RegraAlertaRepositorio.
+type MockRegraAlertaRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockRegraAlertaRepositorio) Criar(regra *entity.RegraAlerta) error {
+	args := m.Called(regra)
+	return args.Error(0)
+}
+
+func (m *MockRegraAlertaRepositorio) BuscarPorID(id uint) (*entity.RegraAlerta, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.RegraAlerta), args.Error(1)
+}
+
+func (m *MockRegraAlertaRepositorio) ListarPorAmbiente(ambienteID, usuarioID uint) ([]entity.RegraAlerta, error) {
+	args := m.Called(ambienteID, usuarioID)
+	return args.Get(0).([]entity.RegraAlerta), args.Error(1)
+}
+
+func (m *MockRegraAlertaRepositorio) ListarAtivasPorAmbiente(ambienteID uint) ([]entity.RegraAlerta, error) {
+	args := m.Called(ambienteID)
+	return args.Get(0).([]entity.RegraAlerta), args.Error(1)
+}
+
+func (m *MockRegraAlertaRepositorio) Atualizar(regra *entity.RegraAlerta) error {
+	args := m.Called(regra)
+	return args.Error(0)
+}
+
+func (m *MockRegraAlertaRepositorio) AtualizarCondicao(id uint, desde *time.Time) error {
+	args := m.Called(id, desde)
+	return args.Error(0)
+}
+
+func (m *MockRegraAlertaRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockAlertaRepositorio é um mock para a interface AlertaRepositorio.
+type MockAlertaRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockAlertaRepositorio) Criar(alerta *entity.Alerta) error {
+	args := m.Called(alerta)
+	return args.Error(0)
+}
+
+func (m *MockAlertaRepositorio) Atualizar(alerta *entity.Alerta) error {
+	args := m.Called(alerta)
+	return args.Error(0)
+}
+
+func (m *MockAlertaRepositorio) BuscarPorID(id uint) (*entity.Alerta, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Alerta), args.Error(1)
+}
+
+func (m *MockAlertaRepositorio) BuscarAtivoPorRegra(regraID uint) (*entity.Alerta, error) {
+	args := m.Called(regraID)
+	return args.Get(0).(*entity.Alerta), args.Error(1)
+}
+
+func (m *MockAlertaRepositorio) Listar(filtro repository.FiltroAlertas) ([]entity.Alerta, int64, error) {
+	args := m.Called(filtro)
+	return args.Get(0).([]entity.Alerta), args.Get(1).(int64), args.Error(2)
+}
+
+// MockCanalNotificacao é um mock para a interface CanalNotificacao.
+type MockCanalNotificacao struct {
+	mock.Mock
+	NomeCanal string
+}
+
+func (m *MockCanalNotificacao) Nome() string {
+	return m.NomeCanal
+}
+
+func (m *MockCanalNotificacao) Enviar(ctx context.Context, notificacao service.Notificacao) error {
+	args := m.Called(ctx, notificacao)
+	return args.Error(0)
+}
+
+// MockFotoperiodoRepositorio é um mock para a interface FotoperiodoRepositorio.
+type MockFotoperiodoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockFotoperiodoRepositorio) Criar(fotoperiodo *entity.Fotoperiodo) error {
+	args := m.Called(fotoperiodo)
+	return args.Error(0)
+}
+
+func (m *MockFotoperiodoRepositorio) BuscarPorID(id uint) (*entity.Fotoperiodo, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Fotoperiodo), args.Error(1)
+}
+
+func (m *MockFotoperiodoRepositorio) ListarPorAmbiente(ambienteID uint) ([]entity.Fotoperiodo, error) {
+	args := m.Called(ambienteID)
+	return args.Get(0).([]entity.Fotoperiodo), args.Error(1)
+}
+
+func (m *MockFotoperiodoRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockEquipamentoRepositorio é um mock para a interface EquipamentoRepositorio.
+type MockEquipamentoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockEquipamentoRepositorio) Criar(equipamento *entity.Equipamento) error {
+	args := m.Called(equipamento)
+	return args.Error(0)
+}
+
+func (m *MockEquipamentoRepositorio) BuscarPorID(id uint) (*entity.Equipamento, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Equipamento), args.Error(1)
+}
+
+func (m *MockEquipamentoRepositorio) ListarPorAmbiente(ambienteID uint) ([]entity.Equipamento, error) {
+	args := m.Called(ambienteID)
+	return args.Get(0).([]entity.Equipamento), args.Error(1)
+}
+
+func (m *MockEquipamentoRepositorio) Atualizar(equipamento *entity.Equipamento) error {
+	args := m.Called(equipamento)
+	return args.Error(0)
+}
+
+func (m *MockEquipamentoRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockTarifaEnergiaRepositorio é um mock para a interface TarifaEnergiaRepositorio.
+type MockTarifaEnergiaRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockTarifaEnergiaRepositorio) Criar(tarifa *entity.TarifaEnergia) error {
+	args := m.Called(tarifa)
+	return args.Error(0)
+}
+
+func (m *MockTarifaEnergiaRepositorio) BuscarPorID(id uint) (*entity.TarifaEnergia, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.TarifaEnergia), args.Error(1)
+}
+
+func (m *MockTarifaEnergiaRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.TarifaEnergia, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.TarifaEnergia), args.Error(1)
+}
+
+func (m *MockTarifaEnergiaRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTarifaEnergiaRepositorio) SalvarBandeira(bandeira *entity.BandeiraMensal) error {
+	args := m.Called(bandeira)
+	return args.Error(0)
+}
+
+func (m *MockTarifaEnergiaRepositorio) ListarBandeiras(usuarioID uint) ([]entity.BandeiraMensal, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.BandeiraMensal), args.Error(1)
+}
+
+// MockClimaRegistroRepositorio é um mock para a interface ClimaRegistroRepositorio.
+type MockClimaRegistroRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockClimaRegistroRepositorio) Salvar(registros []entity.ClimaRegistro) error {
+	args := m.Called(registros)
+	return args.Error(0)
+}
+
+func (m *MockClimaRegistroRepositorio) ListarPorAmbiente(ambienteID uint, inicio, fim time.Time) ([]entity.ClimaRegistro, error) {
+	args := m.Called(ambienteID, inicio, fim)
+	return args.Get(0).([]entity.ClimaRegistro), args.Error(1)
+}
+
+// MockPosicaoLayoutRepositorio é um mock para a interface PosicaoLayoutRepositorio.
+type MockPosicaoLayoutRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockPosicaoLayoutRepositorio) Criar(posicao *entity.PosicaoLayout) error {
+	args := m.Called(posicao)
+	return args.Error(0)
+}
+
+func (m *MockPosicaoLayoutRepositorio) BuscarPorID(id uint) (*entity.PosicaoLayout, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.PosicaoLayout), args.Error(1)
+}
+
+func (m *MockPosicaoLayoutRepositorio) ListarPorAmbiente(ambienteID uint) ([]entity.PosicaoLayout, error) {
+	args := m.Called(ambienteID)
+	return args.Get(0).([]entity.PosicaoLayout), args.Error(1)
+}
+
+func (m *MockPosicaoLayoutRepositorio) Atualizar(posicao *entity.PosicaoLayout) error {
+	args := m.Called(posicao)
+	return args.Error(0)
+}
+
+func (m *MockPosicaoLayoutRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockVasoRepositorio é um mock para a interface VasoRepositorio.
+type MockVasoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockVasoRepositorio) Criar(vaso *entity.Vaso) error {
+	args := m.Called(vaso)
+	return args.Error(0)
+}
+
+func (m *MockVasoRepositorio) BuscarPorID(id uint) (*entity.Vaso, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Vaso), args.Error(1)
+}
+
+func (m *MockVasoRepositorio) Listar() ([]entity.Vaso, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.Vaso), args.Error(1)
+}
+
+func (m *MockVasoRepositorio) Atualizar(vaso *entity.Vaso) error {
+	args := m.Called(vaso)
+	return args.Error(0)
+}
+
+func (m *MockVasoRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockLevantamentoPPFDRepositorio é um mock para a interface LevantamentoPPFDRepositorio.
+type MockLevantamentoPPFDRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockLevantamentoPPFDRepositorio) Criar(levantamento *entity.LevantamentoPPFD) error {
+	args := m.Called(levantamento)
+	return args.Error(0)
+}
+
+func (m *MockLevantamentoPPFDRepositorio) BuscarPorID(id uint) (*entity.LevantamentoPPFD, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.LevantamentoPPFD), args.Error(1)
+}
+
+func (m *MockLevantamentoPPFDRepositorio) ListarPorAmbiente(ambienteID uint) ([]entity.LevantamentoPPFD, error) {
+	args := m.Called(ambienteID)
+	return args.Get(0).([]entity.LevantamentoPPFD), args.Error(1)
+}
+
+func (m *MockLevantamentoPPFDRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockTarefaRepositorio é um mock para a interface TarefaRepositorio.
+type MockTarefaRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockTarefaRepositorio) Criar(tarefa *entity.Tarefa) error {
+	args := m.Called(tarefa)
+	return args.Error(0)
+}
+
+func (m *MockTarefaRepositorio) BuscarPorID(id uint) (*entity.Tarefa, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Tarefa), args.Error(1)
+}
+
+func (m *MockTarefaRepositorio) Listar(filtro repository.FiltroTarefas) ([]entity.Tarefa, int64, error) {
+	args := m.Called(filtro)
+	return args.Get(0).([]entity.Tarefa), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTarefaRepositorio) Atualizar(tarefa *entity.Tarefa) error {
+	args := m.Called(tarefa)
+	return args.Error(0)
+}
+
+func (m *MockTarefaRepositorio) Concluir(tarefa *entity.Tarefa, consumos *repository.MovimentacoesEstoque) error {
+	args := m.Called(tarefa, consumos)
+	return args.Error(0)
+}
+
+func (m *MockTarefaRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTarefaRepositorio) MarcarAtrasadas(agora time.Time) (int64, error) {
+	args := m.Called(agora)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTarefaRepositorio) BuscarSucessora(id uint) (*entity.Tarefa, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tarefa), args.Error(1)
+}
+
+func (m *MockTarefaRepositorio) ListarUltimasRecorrentes(ate time.Time) ([]entity.Tarefa, error) {
+	args := m.Called(ate)
+	return args.Get(0).([]entity.Tarefa), args.Error(1)
+}
+
+func (m *MockTarefaRepositorio) ListarParaLembrete(ate time.Time) ([]entity.Tarefa, error) {
+	args := m.Called(ate)
+	return args.Get(0).([]entity.Tarefa), args.Error(1)
+}
+
+func (m *MockTarefaRepositorio) MarcarLembreteEnviado(id uint, em time.Time) error {
+	args := m.Called(id, em)
+	return args.Error(0)
+}
+
+func (m *MockTarefaRepositorio) ListarPorAplicacao(aplicacaoID uint) ([]entity.Tarefa, error) {
+	args := m.Called(aplicacaoID)
+	return args.Get(0).([]entity.Tarefa), args.Error(1)
+}
+
+func (m *MockPlantaRepositorio) ListarPorDiario(diarioID uint) ([]entity.Planta, error) {
+	args := m.Called(diarioID)
+	return args.Get(0).([]entity.Planta), args.Error(1)
+}
+
+// MockCronogramaCultivoRepositorio é um mock para a interface CronogramaCultivoRepositorio.
+type MockCronogramaCultivoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockCronogramaCultivoRepositorio) Criar(cronograma *entity.CronogramaCultivo) error {
+	args := m.Called(cronograma)
+	return args.Error(0)
+}
+
+func (m *MockCronogramaCultivoRepositorio) BuscarPorID(id uint) (*entity.CronogramaCultivo, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.CronogramaCultivo), args.Error(1)
+}
+
+func (m *MockCronogramaCultivoRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.CronogramaCultivo, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.CronogramaCultivo), args.Error(1)
+}
+
+func (m *MockCronogramaCultivoRepositorio) Atualizar(cronograma *entity.CronogramaCultivo) error {
+	args := m.Called(cronograma)
+	return args.Error(0)
+}
+
+func (m *MockCronogramaCultivoRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockAplicacaoCronogramaRepositorio é um mock para a interface AplicacaoCronogramaRepositorio.
+type MockAplicacaoCronogramaRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockAplicacaoCronogramaRepositorio) Criar(aplicacao *entity.AplicacaoCronograma) error {
+	args := m.Called(aplicacao)
+	return args.Error(0)
+}
+
+func (m *MockAplicacaoCronogramaRepositorio) BuscarPorID(id uint) (*entity.AplicacaoCronograma, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.AplicacaoCronograma), args.Error(1)
+}
+
+func (m *MockAplicacaoCronogramaRepositorio) BuscarPorCronogramaEPlanta(cronogramaID, plantaID uint) (*entity.AplicacaoCronograma, error) {
+	args := m.Called(cronogramaID, plantaID)
+	return args.Get(0).(*entity.AplicacaoCronograma), args.Error(1)
+}
+
+func (m *MockAplicacaoCronogramaRepositorio) ListarPorCronograma(cronogramaID uint) ([]entity.AplicacaoCronograma, error) {
+	args := m.Called(cronogramaID)
+	return args.Get(0).([]entity.AplicacaoCronograma), args.Error(1)
+}
+
+func (m *MockAplicacaoCronogramaRepositorio) ListarPorPlanta(plantaID uint) ([]entity.AplicacaoCronograma, error) {
+	args := m.Called(plantaID)
+	return args.Get(0).([]entity.AplicacaoCronograma), args.Error(1)
+}
+
+func (m *MockAplicacaoCronogramaRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTarefaRepositorio) ListarAbertasPorUsuario(usuarioID uint) ([]entity.Tarefa, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.Tarefa), args.Error(1)
+}
+
+func (m *MockPlantaRepositorio) ListarAtivasPorUsuario(usuarioID uint) ([]entity.Planta, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.Planta), args.Error(1)
+}
+
+func (m *MockUsuarioRepositorio) BuscarPorTokenCalendario(hash string) (*entity.Usuario, error) {
+	args := m.Called(hash)
+	return args.Get(0).(*entity.Usuario), args.Error(1)
+}
+
+func (m *MockUsuarioRepositorio) DefinirTokenCalendario(usuarioID uint, hash *string) error {
+	args := m.Called(usuarioID, hash)
+	return args.Error(0)
+}
+
+// MockLembreteRepositorio é um mock para a interface LembreteRepositorio.
+type MockLembreteRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockLembreteRepositorio) Criar(lembrete *entity.Lembrete) error {
+	args := m.Called(lembrete)
+	return args.Error(0)
+}
+
+func (m *MockLembreteRepositorio) ListarAtivosPorUsuario(usuarioID uint, desde time.Time) ([]entity.Lembrete, error) {
+	args := m.Called(usuarioID, desde)
+	return args.Get(0).([]entity.Lembrete), args.Error(1)
+}
+
+func (m *MockLembreteRepositorio) BuscarPorID(id uint) (*entity.Lembrete, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Lembrete), args.Error(1)
+}
+
+func (m *MockLembreteRepositorio) Listar(filtro repository.FiltroLembretes) ([]entity.Lembrete, int64, error) {
+	args := m.Called(filtro)
+	return args.Get(0).([]entity.Lembrete), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockLembreteRepositorio) Atualizar(lembrete *entity.Lembrete) error {
+	args := m.Called(lembrete)
+	return args.Error(0)
+}
+
+func (m *MockLembreteRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockLembreteRepositorio) ListarParaEnvio(ate time.Time, limite int) ([]entity.Lembrete, error) {
+	args := m.Called(ate, limite)
+	return args.Get(0).([]entity.Lembrete), args.Error(1)
+}
+
+// MockPreferenciaNotificacaoRepositorio é um mock para a interface PreferenciaNotificacaoRepositorio.
+type MockPreferenciaNotificacaoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockPreferenciaNotificacaoRepositorio) BuscarPorUsuario(usuarioID uint) (*entity.PreferenciaNotificacao, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).(*entity.PreferenciaNotificacao), args.Error(1)
+}
+
+func (m *MockPreferenciaNotificacaoRepositorio) Salvar(preferencia *entity.PreferenciaNotificacao) error {
+	args := m.Called(preferencia)
+	return args.Error(0)
+}
+
+// MockNotificacaoRepositorio é um mock para a interface NotificacaoRepositorio.
+type MockNotificacaoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockNotificacaoRepositorio) Criar(notificacao *entity.Notificacao) error {
+	args := m.Called(notificacao)
+	return args.Error(0)
+}
+
+func (m *MockNotificacaoRepositorio) BuscarPorID(id uint) (*entity.Notificacao, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Notificacao), args.Error(1)
+}
+
+func (m *MockNotificacaoRepositorio) Listar(filtro repository.FiltroNotificacoes) ([]entity.Notificacao, int64, error) {
+	args := m.Called(filtro)
+	return args.Get(0).([]entity.Notificacao), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificacaoRepositorio) Atualizar(notificacao *entity.Notificacao) error {
+	args := m.Called(notificacao)
+	return args.Error(0)
+}
+
+func (m *MockNotificacaoRepositorio) ListarDesde(usuarioID, aposID uint, limite int) ([]entity.Notificacao, error) {
+	args := m.Called(usuarioID, aposID, limite)
+	return args.Get(0).([]entity.Notificacao), args.Error(1)
+}
+
+func (m *MockNotificacaoRepositorio) UltimoID(usuarioID uint) (uint, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockNotificacaoRepositorio) ContarNaoLidas(usuarioID uint) (int64, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificacaoRepositorio) MarcarLidas(usuarioID uint, ids []uint, categoria string, em time.Time) (int64, error) {
+	args := m.Called(usuarioID, ids, categoria, em)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockRegaRepositorio é um mock para a interface RegaRepositorio.
+type MockRegaRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockRegaRepositorio) Criar(rega *entity.Rega) error {
+	args := m.Called(rega)
+	return args.Error(0)
+}
+
+func (m *MockRegaRepositorio) BuscarPorID(id uint) (*entity.Rega, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Rega), args.Error(1)
+}
+
+func (m *MockRegaRepositorio) Listar(filtro repository.FiltroRegas) ([]entity.Rega, int64, error) {
+	args := m.Called(filtro)
+	return args.Get(0).([]entity.Rega), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRegaRepositorio) Atualizar(rega *entity.Rega) error {
+	args := m.Called(rega)
+	return args.Error(0)
+}
+
+func (m *MockRegaRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRegaRepositorio) ListarPorPlanta(plantaID uint, de, ate *time.Time) ([]entity.Rega, error) {
+	args := m.Called(plantaID, de, ate)
+	return args.Get(0).([]entity.Rega), args.Error(1)
+}
+
+func (m *MockRegaRepositorio) ListarPorDiario(diarioID uint) ([]entity.Rega, error) {
+	args := m.Called(diarioID)
+	return args.Get(0).([]entity.Rega), args.Error(1)
+}
+
+// MockReceitaNutrienteRepositorio é um mock para a interface ReceitaNutrienteRepositorio.
+type MockReceitaNutrienteRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockReceitaNutrienteRepositorio) Criar(receita *entity.ReceitaNutriente) error {
+	args := m.Called(receita)
+	return args.Error(0)
+}
+
+func (m *MockReceitaNutrienteRepositorio) BuscarPorID(id uint) (*entity.ReceitaNutriente, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.ReceitaNutriente), args.Error(1)
+}
+
+func (m *MockReceitaNutrienteRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.ReceitaNutriente, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.ReceitaNutriente), args.Error(1)
+}
+
+func (m *MockReceitaNutrienteRepositorio) Atualizar(receita *entity.ReceitaNutriente) error {
+	args := m.Called(receita)
+	return args.Error(0)
+}
+
+func (m *MockReceitaNutrienteRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockTabelaNutricaoRepositorio é um mock para a interface TabelaNutricaoRepositorio.
+type MockTabelaNutricaoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockTabelaNutricaoRepositorio) Criar(tabela *entity.TabelaNutricao) error {
+	args := m.Called(tabela)
+	return args.Error(0)
+}
+
+func (m *MockTabelaNutricaoRepositorio) BuscarPorID(id uint) (*entity.TabelaNutricao, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.TabelaNutricao), args.Error(1)
+}
+
+func (m *MockTabelaNutricaoRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.TabelaNutricao, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.TabelaNutricao), args.Error(1)
+}
+
+func (m *MockTabelaNutricaoRepositorio) Atualizar(tabela *entity.TabelaNutricao) error {
+	args := m.Called(tabela)
+	return args.Error(0)
+}
+
+func (m *MockTabelaNutricaoRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTarefaRepositorio) ListarAbertasPorReservatorio(reservatorioID uint) ([]entity.Tarefa, error) {
+	args := m.Called(reservatorioID)
+	return args.Get(0).([]entity.Tarefa), args.Error(1)
+}
+
+// MockReservatorioRepositorio é um mock para a interface ReservatorioRepositorio.
+type MockReservatorioRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockReservatorioRepositorio) Criar(reservatorio *entity.Reservatorio) error {
+	args := m.Called(reservatorio)
+	return args.Error(0)
+}
+
+func (m *MockReservatorioRepositorio) BuscarPorID(id uint) (*entity.Reservatorio, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Reservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.Reservatorio, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.Reservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioRepositorio) Atualizar(reservatorio *entity.Reservatorio) error {
+	args := m.Called(reservatorio)
+	return args.Error(0)
+}
+
+func (m *MockReservatorioRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockReservatorioRepositorio) ListarComTrocaAutomatica() ([]entity.Reservatorio, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.Reservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioRepositorio) CriarManutencao(manutencao *entity.ManutencaoReservatorio) error {
+	args := m.Called(manutencao)
+	return args.Error(0)
+}
+
+func (m *MockReservatorioRepositorio) ListarManutencoes(reservatorioID uint) ([]entity.ManutencaoReservatorio, error) {
+	args := m.Called(reservatorioID)
+	return args.Get(0).([]entity.ManutencaoReservatorio), args.Error(1)
+}
+
+func (m *MockReservatorioRepositorio) CriarLeitura(leitura *entity.LeituraReservatorio) error {
+	args := m.Called(leitura)
+	return args.Error(0)
+}
+
+func (m *MockReservatorioRepositorio) ListarLeituras(reservatorioID uint, de, ate *time.Time) ([]entity.LeituraReservatorio, error) {
+	args := m.Called(reservatorioID, de, ate)
+	return args.Get(0).([]entity.LeituraReservatorio), args.Error(1)
+}
+
+// MockConsumidorEstoque é um mock para a interface service.ConsumidorEstoque.
+type MockConsumidorEstoque struct {
+	mock.Mock
+}
+
+func (m *MockConsumidorEstoque) PrepararBaixa(usuarioID uint, baixa service.BaixaEstoque, consumos []dto.ItemConsumidoDTO) (*repository.MovimentacoesEstoque, error) {
+	args := m.Called(usuarioID, baixa, consumos)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.MovimentacoesEstoque), args.Error(1)
+}
+
+// MockEstoqueRepositorio é um mock para a interface EstoqueRepositorio.
+type MockEstoqueRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockEstoqueRepositorio) CriarItem(item *entity.ItemEstoque) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockEstoqueRepositorio) BuscarItem(id uint) (*entity.ItemEstoque, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.ItemEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueRepositorio) ListarItens(filtro repository.FiltroItensEstoque) ([]entity.ItemEstoque, error) {
+	args := m.Called(filtro)
+	return args.Get(0).([]entity.ItemEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueRepositorio) AtualizarItem(item *entity.ItemEstoque) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockEstoqueRepositorio) DeletarItem(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockEstoqueRepositorio) RegistrarMovimentacoes(movimentacoes *repository.MovimentacoesEstoque) error {
+	args := m.Called(movimentacoes)
+	return args.Error(0)
+}
+
+// GravarMovimentacoes simula o repositório: aplica as movimentações aos itens, como se fossem as
+// linhas travadas no banco, e confirma a transação. Use em Run de RegistrarMovimentacoes.
+func GravarMovimentacoes(itens ...*entity.ItemEstoque) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		movimentacoes := args.Get(0).(*repository.MovimentacoesEstoque)
+		for _, movimentacao := range movimentacoes.Itens {
+			for _, item := range itens {
+				if item.ID == movimentacao.ItemID {
+					if err := movimentacoes.Aplicar(item, movimentacao); err != nil {
+						panic(err)
+					}
+				}
+			}
+		}
+		movimentacoes.Confirmar()
+	}
+}
+
+func (m *MockEstoqueRepositorio) ListarMovimentacoes(itemID uint) ([]entity.MovimentacaoEstoque, error) {
+	args := m.Called(itemID)
+	return args.Get(0).([]entity.MovimentacaoEstoque), args.Error(1)
+}
+
+func (m *MockEstoqueRepositorio) ListarConsumosCiclo(filtro repository.FiltroConsumosCiclo) ([]entity.MovimentacaoEstoque, error) {
+	args := m.Called(filtro)
+	return args.Get(0).([]entity.MovimentacaoEstoque), args.Error(1)
+}
+
+type MockSementeRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockSementeRepositorio) CriarPacote(pacote *entity.PacoteSemente) error {
+	args := m.Called(pacote)
+	return args.Error(0)
+}
+
+func (m *MockSementeRepositorio) BuscarPacote(id uint) (*entity.PacoteSemente, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.PacoteSemente), args.Error(1)
+}
+
+func (m *MockSementeRepositorio) ListarPacotes(filtro repository.FiltroPacotesSemente) ([]entity.PacoteSemente, error) {
+	args := m.Called(filtro)
+	return args.Get(0).([]entity.PacoteSemente), args.Error(1)
+}
+
+func (m *MockSementeRepositorio) AtualizarPacote(pacote *entity.PacoteSemente) error {
+	args := m.Called(pacote)
+	return args.Error(0)
+}
+
+func (m *MockSementeRepositorio) DeletarPacote(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSementeRepositorio) RegistrarGerminacao(pacote *entity.PacoteSemente, germinacao *entity.GerminacaoSemente) error {
+	args := m.Called(pacote, germinacao)
+	return args.Error(0)
+}
+
+func (m *MockSementeRepositorio) ListarGerminacoes(pacoteID uint) ([]entity.GerminacaoSemente, error) {
+	args := m.Called(pacoteID)
+	return args.Get(0).([]entity.GerminacaoSemente), args.Error(1)
+}
+
+func (m *MockSementeRepositorio) ListarGerminacoesUsuario(usuarioID uint) ([]entity.GerminacaoSemente, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.GerminacaoSemente), args.Error(1)
+}
+
+func (m *MockSementeRepositorio) CriarPlantas(pacote *entity.PacoteSemente, plantas []entity.Planta) error {
+	args := m.Called(pacote, plantas)
+	return args.Error(0)
+}
+
+type MockSubstratoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockSubstratoRepositorio) Criar(substrato *entity.Substrato) error {
+	args := m.Called(substrato)
+	return args.Error(0)
+}
+
+func (m *MockSubstratoRepositorio) BuscarPorID(id uint) (*entity.Substrato, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Substrato), args.Error(1)
+}
+
+func (m *MockSubstratoRepositorio) Listar() ([]entity.Substrato, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.Substrato), args.Error(1)
+}
+
+func (m *MockSubstratoRepositorio) Atualizar(substrato *entity.Substrato) error {
+	args := m.Called(substrato)
+	return args.Error(0)
+}
+
+func (m *MockSubstratoRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockTransplanteRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockTransplanteRepositorio) Registrar(transplante *entity.Transplante, consumos *repository.MovimentacoesEstoque) error {
+	args := m.Called(transplante, consumos)
+	return args.Error(0)
+}
+
+func (m *MockTransplanteRepositorio) ListarPorPlanta(plantaID uint) ([]entity.Transplante, error) {
+	args := m.Called(plantaID)
+	return args.Get(0).([]entity.Transplante), args.Error(1)
+}
+
+func (m *MockTransplanteRepositorio) DefinirRecipiente(plantaID uint, vasoID, substratoID *uint) error {
+	args := m.Called(plantaID, vasoID, substratoID)
+	return args.Error(0)
+}
+
+type MockCruzamentoRepositorio struct {
+	mock.Mock
+}
+
+func (m *MockCruzamentoRepositorio) Criar(cruzamento *entity.Cruzamento, genetica *entity.Genetica) error {
+	args := m.Called(cruzamento, genetica)
+	return args.Error(0)
+}
+
+func (m *MockCruzamentoRepositorio) BuscarPorID(id uint) (*entity.Cruzamento, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Cruzamento), args.Error(1)
+}
+
+func (m *MockCruzamentoRepositorio) Listar(usuarioID uint) ([]entity.Cruzamento, error) {
+	args := m.Called(usuarioID)
+	return args.Get(0).([]entity.Cruzamento), args.Error(1)
+}
+
+func (m *MockCruzamentoRepositorio) Deletar(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCruzamentoRepositorio) RegistrarPolinizacao(polinizacao *entity.Polinizacao) error {
+	args := m.Called(polinizacao)
+	return args.Error(0)
+}
+
+func (m *MockCruzamentoRepositorio) BuscarPolinizacao(id uint) (*entity.Polinizacao, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Polinizacao), args.Error(1)
+}
+
+func (m *MockCruzamentoRepositorio) ListarPolinizacoes(cruzamentoID uint) ([]entity.Polinizacao, error) {
+	args := m.Called(cruzamentoID)
+	return args.Get(0).([]entity.Polinizacao), args.Error(1)
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/calculo"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// retencaoAguaPadrao é usada quando o substrato não tem retenção cadastrada, próxima de
+	// uma mistura de terra com perlita
+	retencaoAguaPadrao = 0.4
+	// fracaoSecagem é quanto da água retida a planta consome entre duas regas
+	fracaoSecagem = 0.5
+	// fracaoEscoamento é o excedente que deve sair pelos furos para lavar o substrato
+	fracaoEscoamento = 0.2
+)
+
+// TransplanteService controla o vaso e o substrato de cada planta. Transplantes guardam o
+// recipiente anterior e o novo, e o volume do vaso orienta a sugestão de rega.
+type TransplanteService interface {
+	BuscarRecipiente(plantaID, usuarioID uint) (*dto.RecipientePlantaDTO, error)
+	// DefinirRecipiente corrige o vaso e o substrato atuais sem registrar transplante
+	DefinirRecipiente(plantaID, usuarioID uint, recipienteDto *dto.RecipienteDTO) (*dto.RecipientePlantaDTO, error)
+	Transplantar(plantaID, usuarioID uint, transplanteDto *dto.TransplanteDTO) (*entity.Transplante, error)
+	ListarTransplantes(plantaID, usuarioID uint) ([]entity.Transplante, error)
+	// SugerirRega calcula o volume de rega para o vaso atual da planta
+	SugerirRega(plantaID, usuarioID uint) (*dto.SugestaoRegaDTO, error)
+}
+
+type transplanteService struct {
+	repositorio          repository.TransplanteRepositorio
+	plantaRepositorio    repository.PlantaRepositorio
+	vasoRepositorio      repository.VasoRepositorio
+	substratoRepositorio repository.SubstratoRepositorio
+	regaRepositorio      repository.RegaRepositorio
+	estoque              ConsumidorEstoque
+	agora                func() time.Time
+}
+
+// NewTransplanteService cria o serviço de transplantes; estoque pode ser nil quando os insumos
+// do transplante não são controlados.
+func NewTransplanteService(
+	repositorio repository.TransplanteRepositorio,
+	plantaRepositorio repository.PlantaRepositorio,
+	vasoRepositorio repository.VasoRepositorio,
+	substratoRepositorio repository.SubstratoRepositorio,
+	regaRepositorio repository.RegaRepositorio,
+	estoque ConsumidorEstoque,
+) TransplanteService {
+	return &transplanteService{
+		repositorio:          repositorio,
+		plantaRepositorio:    plantaRepositorio,
+		vasoRepositorio:      vasoRepositorio,
+		substratoRepositorio: substratoRepositorio,
+		regaRepositorio:      regaRepositorio,
+		estoque:              estoque,
+		agora:                time.Now,
+	}
+}
+
+func (s *transplanteService) BuscarRecipiente(plantaID, usuarioID uint) (*dto.RecipientePlantaDTO, error) {
+	planta, err := s.buscarPlanta(plantaID, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	return s.recipiente(planta)
+}
+
+func (s *transplanteService) DefinirRecipiente(plantaID, usuarioID uint, recipienteDto *dto.RecipienteDTO) (*dto.RecipientePlantaDTO, error) {
+	planta, err := s.buscarPlanta(plantaID, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if recipienteDto == nil {
+		return nil, utils.ErrInvalidInput
+	}
+	resposta := &dto.RecipientePlantaDTO{PlantaID: planta.ID}
+	if resposta.Vaso, err = s.buscarVaso(recipienteDto.VasoID); err != nil {
+		return nil, err
+	}
+	if resposta.Substrato, err = s.buscarSubstrato(recipienteDto.SubstratoID); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.DefinirRecipiente(planta.ID, recipienteDto.VasoID, recipienteDto.SubstratoID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return resposta, nil
+}
+
+// Transplantar valida os insumos informados antes de registrar o transplante e grava a baixa
+// deles na mesma transação, com o transplante como origem.
+func (s *transplanteService) Transplantar(plantaID, usuarioID uint, transplanteDto *dto.TransplanteDTO) (*entity.Transplante, error) {
+	planta, err := s.buscarPlanta(plantaID, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if transplanteDto == nil || (transplanteDto.VasoID == nil && transplanteDto.SubstratoID == nil) {
+		return nil, fmt.Errorf("%w: informe o vaso ou o substrato novo", utils.ErrInvalidInput)
+	}
+	if len(transplanteDto.Consumos) > 0 && s.estoque == nil {
+		return nil, fmt.Errorf("%w: controle de estoque indisponível", utils.ErrInvalidInput)
+	}
+
+	transplante := &entity.Transplante{
+		PlantaID:            planta.ID,
+		UsuarioID:           usuarioID,
+		Data:                s.agora(),
+		VasoAnteriorID:      planta.VasoID,
+		VasoNovoID:          planta.VasoID,
+		SubstratoAnteriorID: planta.SubstratoID,
+		SubstratoNovoID:     planta.SubstratoID,
+		Observacoes:         transplanteDto.Observacoes,
+	}
+	if transplanteDto.Data != nil && !transplanteDto.Data.IsZero() {
+		transplante.Data = *transplanteDto.Data
+	}
+	if transplanteDto.VasoID != nil {
+		if transplante.VasoNovo, err = s.buscarVaso(transplanteDto.VasoID); err != nil {
+			return nil, err
+		}
+		transplante.VasoNovoID = transplanteDto.VasoID
+	}
+	if transplanteDto.SubstratoID != nil {
+		if transplante.SubstratoNovo, err = s.buscarSubstrato(transplanteDto.SubstratoID); err != nil {
+			return nil, err
+		}
+		transplante.SubstratoNovoID = transplanteDto.SubstratoID
+	}
+	if mesmoID(transplante.VasoAnteriorID, transplante.VasoNovoID) && mesmoID(transplante.SubstratoAnteriorID, transplante.SubstratoNovoID) {
+		return nil, fmt.Errorf("%w: o transplante não muda o vaso nem o substrato", utils.ErrInvalidInput)
+	}
+
+	var consumos *repository.MovimentacoesEstoque
+	if len(transplanteDto.Consumos) > 0 {
+		baixa := BaixaEstoque{
+			Origem:   entity.OrigemEstoqueTransplante,
+			PlantaID: &planta.ID,
+			Data:     transplante.Data,
+		}
+		if consumos, err = s.estoque.PrepararBaixa(usuarioID, baixa, transplanteDto.Consumos); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repositorio.Registrar(transplante, consumos); err != nil {
+		return nil, fmt.Errorf("falha ao registrar transplante da planta %d: %w", planta.ID, err)
+	}
+	return transplante, nil
+}
+
+func (s *transplanteService) ListarTransplantes(plantaID, usuarioID uint) ([]entity.Transplante, error) {
+	if _, err := s.buscarPlanta(plantaID, usuarioID); err != nil {
+		return nil, err
+	}
+	return s.repositorio.ListarPorPlanta(plantaID)
+}
+
+func (s *transplanteService) SugerirRega(plantaID, usuarioID uint) (*dto.SugestaoRegaDTO, error) {
+	planta, err := s.buscarPlanta(plantaID, usuarioID)
+	if err != nil {
+		return nil, err
+	}
+	if planta.VasoID == nil {
+		return nil, fmt.Errorf("%w: a planta não tem vaso definido", utils.ErrInvalidInput)
+	}
+	vaso, err := s.buscarVaso(planta.VasoID)
+	if err != nil {
+		return nil, err
+	}
+	substrato, err := s.buscarSubstrato(planta.SubstratoID)
+	if err != nil {
+		return nil, err
+	}
+
+	sugestao := &dto.SugestaoRegaDTO{
+		PlantaID:         planta.ID,
+		VolumeVasoLitros: vaso.Volume,
+		RetencaoAgua:     retencaoAguaPadrao,
+		RetencaoEstimada: true,
+	}
+	if substrato != nil && substrato.RetencaoAgua > 0 {
+		sugestao.RetencaoAgua = substrato.RetencaoAgua
+		sugestao.RetencaoEstimada = false
+	}
+	aguaRetida := vaso.Volume * sugestao.RetencaoAgua
+	sugestao.AguaRetidaLitros = calculo.Arredondar(aguaRetida, 2)
+	sugestao.VolumeMinimoLitros = calculo.Arredondar(aguaRetida*fracaoSecagem, 2)
+	sugestao.VolumeSugeridoLitros = calculo.Arredondar(aguaRetida*fracaoSecagem*(1+fracaoEscoamento), 2)
+
+	desde, err := s.noVasoDesde(planta)
+	if err != nil {
+		return nil, err
+	}
+	sugestao.NoVasoDesde = desde
+	regas, err := s.regaRepositorio.ListarPorPlanta(planta.ID, &desde, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.compararRegas(sugestao, planta.ID, regas)
+	return sugestao, nil
+}
+
+// compararRegas resume as regas desde o vaso atual. O volume médio considera só as regas
+// individuais, já que o volume das regas em lote é do diário inteiro.
+func (s *transplanteService) compararRegas(sugestao *dto.SugestaoRegaDTO, plantaID uint, regas []entity.Rega) {
+	sugestao.Regas = len(regas)
+	if len(regas) == 0 {
+		return
+	}
+	ultima := regas[len(regas)-1].Data
+	sugestao.UltimaRega = &ultima
+	if len(regas) > 1 {
+		intervalo := calculo.Arredondar(ultima.Sub(regas[0].Data).Hours()/24/float64(len(regas)-1), 1)
+		sugestao.IntervaloMedioDias = &intervalo
+	}
+
+	total, individuais := 0.0, 0
+	for _, rega := range regas {
+		if rega.PlantaID != nil && *rega.PlantaID == plantaID {
+			total += rega.VolumeLitros
+			individuais++
+		}
+	}
+	if individuais == 0 {
+		return
+	}
+	media := calculo.Arredondar(total/float64(individuais), 2)
+	sugestao.MediaRegasLitros = &media
+	switch {
+	case media < sugestao.VolumeMinimoLitros:
+		sugestao.Avaliacao = "abaixo"
+	case media > sugestao.VolumeSugeridoLitros*(1+fracaoEscoamento):
+		sugestao.Avaliacao = "acima"
+	default:
+		sugestao.Avaliacao = "adequada"
+	}
+}
+
+// noVasoDesde é a data do último transplante que trocou o vaso ou, sem ele, a do plantio
+func (s *transplanteService) noVasoDesde(planta *entity.Planta) (time.Time, error) {
+	transplantes, err := s.repositorio.ListarPorPlanta(planta.ID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for i := len(transplantes) - 1; i >= 0; i-- {
+		if !mesmoID(transplantes[i].VasoAnteriorID, transplantes[i].VasoNovoID) {
+			return transplantes[i].Data, nil
+		}
+	}
+	if planta.DataPlantio != nil {
+		return *planta.DataPlantio, nil
+	}
+	return planta.CreatedAt, nil
+}
+
+func (s *transplanteService) recipiente(planta *entity.Planta) (*dto.RecipientePlantaDTO, error) {
+	resposta := &dto.RecipientePlantaDTO{PlantaID: planta.ID}
+	var err error
+	if resposta.Vaso, err = s.buscarVaso(planta.VasoID); err != nil && !errors.Is(err, utils.ErrInvalidInput) {
+		return nil, err
+	}
+	if resposta.Substrato, err = s.buscarSubstrato(planta.SubstratoID); err != nil && !errors.Is(err, utils.ErrInvalidInput) {
+		return nil, err
+	}
+	return resposta, nil
+}
+
+// buscarVaso retorna nil quando o ID não é informado
+func (s *transplanteService) buscarVaso(id *uint) (*entity.Vaso, error) {
+	if id == nil {
+		return nil, nil
+	}
+	vaso, err := s.vasoRepositorio.BuscarPorID(*id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: vaso %d não encontrado", utils.ErrInvalidInput, *id)
+		}
+		return nil, fmt.Errorf("falha ao buscar vaso com ID %d: %w", *id, err)
+	}
+	return vaso, nil
+}
+
+// buscarSubstrato retorna nil quando o ID não é informado
+func (s *transplanteService) buscarSubstrato(id *uint) (*entity.Substrato, error) {
+	if id == nil {
+		return nil, nil
+	}
+	substrato, err := s.substratoRepositorio.BuscarPorID(*id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: substrato %d não encontrado", utils.ErrInvalidInput, *id)
+		}
+		return nil, fmt.Errorf("falha ao buscar substrato com ID %d: %w", *id, err)
+	}
+	return substrato, nil
+}
+
+// buscarPlanta retorna ErrNotFound também para plantas de outro usuário
+func (s *transplanteService) buscarPlanta(id, usuarioID uint) (*entity.Planta, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	planta, err := s.plantaRepositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar planta com ID %d: %w", id, err)
+	}
+	if planta.UsuarioID != usuarioID {
+		return nil, utils.ErrNotFound
+	}
+	return planta, nil
+}
+
+func mesmoID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type transplanteMocks struct {
+	transplantes  *test.MockTransplanteRepositorio
+	plantaRepo    *test.MockPlantaRepositorio
+	vasoRepo      *test.MockVasoRepositorio
+	substratoRepo *test.MockSubstratoRepositorio
+	regaRepo      *test.MockRegaRepositorio
+	estoque       *test.MockConsumidorEstoque
+}
+
+func novoTransplanteService() (service.TransplanteService, transplanteMocks) {
+	m := transplanteMocks{
+		transplantes:  new(test.MockTransplanteRepositorio),
+		plantaRepo:    new(test.MockPlantaRepositorio),
+		vasoRepo:      new(test.MockVasoRepositorio),
+		substratoRepo: new(test.MockSubstratoRepositorio),
+		regaRepo:      new(test.MockRegaRepositorio),
+		estoque:       new(test.MockConsumidorEstoque),
+	}
+	return service.NewTransplanteService(m.transplantes, m.plantaRepo, m.vasoRepo, m.substratoRepo, m.regaRepo, m.estoque), m
+}
+
+func vasoLitros(id uint, volume float64) *entity.Vaso {
+	vaso := &entity.Vaso{Nome: "Vaso", Volume: volume}
+	vaso.ID = id
+	return vaso
+}
+
+func TestTransplanteService_Transplantar(t *testing.T) {
+	t.Run("Success - Guarda o Recipiente Anterior e Dá Baixa nos Insumos", func(t *testing.T) {
+		servico, m := novoTransplanteService()
+		vasoAtual, substratoAtual := uint(1), uint(5)
+		planta := plantaRegada(10, 7, "Northern Lights #1")
+		planta.VasoID, planta.SubstratoID = &vasoAtual, &substratoAtual
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+		m.vasoRepo.On("BuscarPorID", uint(2)).Return(vasoLitros(2, 11), nil).Once()
+		consumos := []dto.ItemConsumidoDTO{{ItemID: 3, Quantidade: 1}, {ItemID: 4, Quantidade: 8}}
+		baixa := &repository.MovimentacoesEstoque{}
+		m.estoque.On("PrepararBaixa", uint(7), mock.MatchedBy(func(b service.BaixaEstoque) bool {
+			return b.Origem == entity.OrigemEstoqueTransplante && b.PlantaID != nil && *b.PlantaID == 10
+		}), consumos).Return(baixa, nil).Once()
+		// a baixa é gravada na mesma transação do transplante
+		m.transplantes.On("Registrar", mock.AnythingOfType("*entity.Transplante"), baixa).Return(nil).Once()
+
+		novoVaso := uint(2)
+		transplante, err := servico.Transplantar(10, 7, &dto.TransplanteDTO{VasoID: &novoVaso, Consumos: consumos})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), transplante.UsuarioID)
+		assert.Equal(t, &vasoAtual, transplante.VasoAnteriorID)
+		assert.Equal(t, &novoVaso, transplante.VasoNovoID)
+		// substrato não informado: continua o mesmo
+		assert.Equal(t, &substratoAtual, transplante.SubstratoAnteriorID)
+		assert.Equal(t, &substratoAtual, transplante.SubstratoNovoID)
+		m.estoque.AssertExpectations(t)
+		m.transplantes.AssertExpectations(t)
+	})
+
+	t.Run("Error - Mesmo Vaso e Substrato", func(t *testing.T) {
+		servico, m := novoTransplanteService()
+		vasoAtual := uint(2)
+		planta := plantaRegada(10, 7, "Northern Lights #1")
+		planta.VasoID = &vasoAtual
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+		m.vasoRepo.On("BuscarPorID", uint(2)).Return(vasoLitros(2, 11), nil).Once()
+
+		_, err := servico.Transplantar(10, 7, &dto.TransplanteDTO{VasoID: &vasoAtual})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		m.transplantes.AssertNotCalled(t, "Registrar", mock.Anything)
+	})
+
+	t.Run("Error - Planta de Outro Usuário", func(t *testing.T) {
+		servico, m := novoTransplanteService()
+		planta := plantaRegada(10, 8, "Northern Lights #1")
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+
+		novoVaso := uint(2)
+		_, err := servico.Transplantar(10, 7, &dto.TransplanteDTO{VasoID: &novoVaso})
+
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	})
+}
+
+func TestTransplanteService_SugerirRega(t *testing.T) {
+	t.Run("Success - Volume pelo Vaso e Substrato Comparado às Regas", func(t *testing.T) {
+		servico, m := novoTransplanteService()
+		vasoID, substratoID := uint(2), uint(5)
+		planta := plantaRegada(10, 7, "Northern Lights #1")
+		planta.VasoID, planta.SubstratoID = &vasoID, &substratoID
+		substrato := &entity.Substrato{Nome: "Terra e perlita", RetencaoAgua: 0.5}
+		substrato.ID = substratoID
+		transplantadaEm := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+		m.vasoRepo.On("BuscarPorID", uint(2)).Return(vasoLitros(2, 11), nil).Once()
+		m.substratoRepo.On("BuscarPorID", uint(5)).Return(substrato, nil).Once()
+		vasoAnterior := uint(1)
+		m.transplantes.On("ListarPorPlanta", uint(10)).Return([]entity.Transplante{
+			{PlantaID: 10, Data: transplantadaEm, VasoAnteriorID: &vasoAnterior, VasoNovoID: &vasoID},
+		}, nil).Once()
+		plantaID, diarioID := uint(10), uint(3)
+		m.regaRepo.On("ListarPorPlanta", uint(10), &transplantadaEm, (*time.Time)(nil)).Return([]entity.Rega{
+			{PlantaID: &plantaID, VolumeLitros: 3, Data: transplantadaEm.AddDate(0, 0, 1)},
+			{DiarioCultivoID: &diarioID, VolumeLitros: 40, Data: transplantadaEm.AddDate(0, 0, 3)}, // lote: fora da média
+			{PlantaID: &plantaID, VolumeLitros: 4, Data: transplantadaEm.AddDate(0, 0, 5)},
+		}, nil).Once()
+
+		sugestao, err := servico.SugerirRega(10, 7)
+
+		require.NoError(t, err)
+		// 11 l com 50% de retenção: 5,5 l retidos, metade consumida e 20% de escoamento
+		assert.Equal(t, 5.5, sugestao.AguaRetidaLitros)
+		assert.Equal(t, 2.75, sugestao.VolumeMinimoLitros)
+		assert.Equal(t, 3.3, sugestao.VolumeSugeridoLitros)
+		assert.False(t, sugestao.RetencaoEstimada)
+		assert.Equal(t, transplantadaEm, sugestao.NoVasoDesde)
+		assert.Equal(t, 3, sugestao.Regas)
+		require.NotNil(t, sugestao.MediaRegasLitros)
+		assert.Equal(t, 3.5, *sugestao.MediaRegasLitros)
+		require.NotNil(t, sugestao.IntervaloMedioDias)
+		assert.Equal(t, 2.0, *sugestao.IntervaloMedioDias)
+		assert.Equal(t, "adequada", sugestao.Avaliacao)
+	})
+
+	t.Run("Error - Planta Sem Vaso", func(t *testing.T) {
+		servico, m := novoTransplanteService()
+		planta := plantaRegada(10, 7, "Northern Lights #1")
+		m.plantaRepo.On("BuscarPorID", uint(10)).Return(&planta, nil).Once()
+
+		_, err := servico.SugerirRega(10, 7)
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
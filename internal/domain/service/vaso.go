@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"gorm.io/gorm"
+)
+
+// VasoService mantém o catálogo de vasos usados nas plantas e no layout dos ambientes.
+type VasoService interface {
+	Criar(vasoDto *dto.VasoDTO) (*entity.Vaso, error)
+	BuscarPorID(id uint) (*entity.Vaso, error)
+	Listar() ([]entity.Vaso, error)
+	Atualizar(id uint, vasoDto *dto.VasoDTO) (*entity.Vaso, error)
+	Deletar(id uint) error
+}
+
+type vasoService struct {
+	repositorio repository.VasoRepositorio
+}
+
+// NewVasoService cria o serviço do catálogo de vasos.
+func NewVasoService(repositorio repository.VasoRepositorio) VasoService {
+	return &vasoService{repositorio: repositorio}
+}
+
+func (s *vasoService) Criar(vasoDto *dto.VasoDTO) (*entity.Vaso, error) {
+	vaso := &entity.Vaso{}
+	if err := aplicarVasoDTO(vaso, vasoDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Criar(vaso); err != nil {
+		return nil, fmt.Errorf("falha ao criar vaso: %w", err)
+	}
+	return vaso, nil
+}
+
+func (s *vasoService) BuscarPorID(id uint) (*entity.Vaso, error) {
+	if id == 0 {
+		return nil, utils.ErrInvalidInput
+	}
+	vaso, err := s.repositorio.BuscarPorID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar vaso com ID %d: %w", id, err)
+	}
+	return vaso, nil
+}
+
+func (s *vasoService) Listar() ([]entity.Vaso, error) {
+	return s.repositorio.Listar()
+}
+
+func (s *vasoService) Atualizar(id uint, vasoDto *dto.VasoDTO) (*entity.Vaso, error) {
+	vaso, err := s.BuscarPorID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := aplicarVasoDTO(vaso, vasoDto); err != nil {
+		return nil, err
+	}
+	if err := s.repositorio.Atualizar(vaso); err != nil {
+		return nil, fmt.Errorf("falha ao atualizar vaso com ID %d: %w", id, err)
+	}
+	return vaso, nil
+}
+
+// Deletar mantém as plantas e transplantes que usavam o vaso; o histórico ainda o exibe
+func (s *vasoService) Deletar(id uint) error {
+	if id == 0 {
+		return utils.ErrInvalidInput
+	}
+	if err := s.repositorio.Deletar(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("falha ao deletar vaso com ID %d: %w", id, err)
+	}
+	return nil
+}
+
+func aplicarVasoDTO(vaso *entity.Vaso, vasoDto *dto.VasoDTO) error {
+	if vasoDto == nil {
+		return utils.ErrInvalidInput
+	}
+	nome := strings.TrimSpace(vasoDto.Nome)
+	if nome == "" || vasoDto.Volume <= 0 || vasoDto.Diametro < 0 || vasoDto.FurosDrenagem < 0 {
+		return fmt.Errorf("%w: o vaso precisa de nome e volume positivo", utils.ErrInvalidInput)
+	}
+	vaso.Nome = nome
+	vaso.Material = vasoDto.Material
+	vaso.Volume = vasoDto.Volume
+	vaso.Diametro = vasoDto.Diametro
+	vaso.Cor = vasoDto.Cor
+	vaso.FurosDrenagem = vasoDto.FurosDrenagem
+	return nil
+}
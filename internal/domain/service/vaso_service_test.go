@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service/test"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestVasoService_Criar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo := new(test.MockVasoRepositorio)
+		servico := service.NewVasoService(repo)
+		repo.On("Criar", mock.AnythingOfType("*entity.Vaso")).Return(nil).Once()
+
+		vaso, err := servico.Criar(&dto.VasoDTO{Nome: " Vaso de tecido 11 l ", Material: "tecido", Volume: 11, Diametro: 25})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Vaso de tecido 11 l", vaso.Nome)
+		assert.Equal(t, 11.0, vaso.Volume)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Volume Zerado", func(t *testing.T) {
+		repo := new(test.MockVasoRepositorio)
+		servico := service.NewVasoService(repo)
+
+		_, err := servico.Criar(&dto.VasoDTO{Nome: "Vaso", Volume: 0})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		repo.AssertNotCalled(t, "Criar", mock.Anything)
+	})
+}
+
+func TestVasoService_Deletar_NaoEncontrado(t *testing.T) {
+	repo := new(test.MockVasoRepositorio)
+	servico := service.NewVasoService(repo)
+	repo.On("Deletar", uint(9)).Return(gorm.ErrRecordNotFound).Once()
+
+	err := servico.Deletar(9)
+
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+}
+
+func TestSubstratoService_Atualizar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo := new(test.MockSubstratoRepositorio)
+		servico := service.NewSubstratoService(repo)
+		substrato := &entity.Substrato{Nome: "Coco", RetencaoAgua: 0.6}
+		substrato.ID = 5
+		repo.On("BuscarPorID", uint(5)).Return(substrato, nil).Once()
+		repo.On("Atualizar", substrato).Return(nil).Once()
+
+		atualizado, err := servico.Atualizar(5, &dto.SubstratoDTO{Nome: "Coco e perlita", PH: 6, RetencaoAgua: 0.5})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Coco e perlita", atualizado.Nome)
+		assert.Equal(t, 0.5, atualizado.RetencaoAgua)
+	})
+
+	t.Run("Error - Retenção Acima de 1", func(t *testing.T) {
+		repo := new(test.MockSubstratoRepositorio)
+		servico := service.NewSubstratoService(repo)
+		substrato := &entity.Substrato{Nome: "Coco"}
+		substrato.ID = 5
+		repo.On("BuscarPorID", uint(5)).Return(substrato, nil).Once()
+
+		_, err := servico.Atualizar(5, &dto.SubstratoDTO{Nome: "Coco", RetencaoAgua: 60})
+
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+		repo.AssertNotCalled(t, "Atualizar", mock.Anything)
+	})
+}
@@ -0,0 +1,140 @@
+// Package agendador executa rotinas periódicas dentro do próprio processo da API
+// (tarefas recorrentes, atrasos, lembretes). Cada execução é protegida por uma trava
+// para que, com várias réplicas, apenas uma delas rode a mesma rotina por vez.
+package agendador
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Rotina é um trabalho executado a cada Intervalo enquanto o agendador estiver ativo
+type Rotina struct {
+	Nome      string
+	Intervalo time.Duration
+	Executar  func(ctx context.Context) error
+}
+
+// Trava garante exclusividade entre réplicas. Tentar não espera: se outra réplica já
+// detém a chave, retorna obtida=false e a execução é pulada.
+type Trava interface {
+	Tentar(ctx context.Context, chave int64) (liberar func(), obtida bool, err error)
+}
+
+// Agendador dispara as rotinas em goroutines próprias, uma vez ao iniciar e depois a
+// cada intervalo, até Parar ser chamado.
+type Agendador struct {
+	trava   Trava
+	rotinas []Rotina
+
+	mu       sync.Mutex
+	cancelar context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewAgendador cria o agendador sem iniciá-lo
+func NewAgendador(trava Trava, rotinas ...Rotina) *Agendador {
+	return &Agendador{trava: trava, rotinas: rotinas}
+}
+
+// Iniciar começa a executar as rotinas; chamadas repetidas são ignoradas
+func (a *Agendador) Iniciar(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cancelar != nil {
+		return
+	}
+
+	ctx, a.cancelar = context.WithCancel(ctx)
+	for _, rotina := range a.rotinas {
+		if rotina.Intervalo <= 0 || rotina.Executar == nil {
+			logrus.Warnf("Rotina %q ignorada: intervalo ou função inválidos", rotina.Nome)
+			continue
+		}
+		a.wg.Add(1)
+		go a.laco(ctx, rotina)
+	}
+	logrus.Infof("Agendador iniciado com %d rotina(s)", len(a.rotinas))
+}
+
+// Parar cancela as rotinas e espera as execuções em andamento terminarem ou o ctx expirar
+func (a *Agendador) Parar(ctx context.Context) error {
+	a.mu.Lock()
+	cancelar := a.cancelar
+	a.mu.Unlock()
+	if cancelar == nil {
+		return nil
+	}
+	cancelar()
+
+	terminou := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(terminou)
+	}()
+	select {
+	case <-terminou:
+		logrus.Info("Agendador encerrado")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("agendador não encerrou a tempo: %w", ctx.Err())
+	}
+}
+
+func (a *Agendador) laco(ctx context.Context, rotina Rotina) {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(rotina.Intervalo)
+	defer ticker.Stop()
+	for {
+		a.executar(ctx, rotina)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// executar roda a rotina uma vez se a trava for obtida; erros e pânicos só são registrados
+// para que a rotina continue nas próximas execuções
+func (a *Agendador) executar(ctx context.Context, rotina Rotina) {
+	log := logrus.WithField("rotina", rotina.Nome)
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("Pânico na rotina do agendador: %v", r)
+		}
+	}()
+
+	liberar, obtida, err := a.trava.Tentar(ctx, ChaveTrava(rotina.Nome))
+	if err != nil {
+		if ctx.Err() == nil {
+			log.WithError(err).Warn("Falha ao obter a trava da rotina")
+		}
+		return
+	}
+	if !obtida {
+		log.Debug("Rotina em execução em outra réplica")
+		return
+	}
+	defer liberar()
+
+	inicio := time.Now()
+	if err := rotina.Executar(ctx); err != nil {
+		log.WithError(err).Error("Falha na rotina do agendador")
+		return
+	}
+	log.WithField("duracao", time.Since(inicio)).Debug("Rotina executada")
+}
+
+// ChaveTrava deriva do nome da rotina a chave numérica usada pela trava
+func ChaveTrava(nome string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("cultivo-api:" + nome))
+	return int64(h.Sum64())
+}
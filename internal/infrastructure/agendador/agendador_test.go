@@ -0,0 +1,171 @@
+package agendador
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// travaMemoria simula advisory locks compartilhados por várias réplicas
+type travaMemoria struct {
+	mu     sync.Mutex
+	presas map[int64]bool
+	falha  error
+}
+
+func novaTravaMemoria() *travaMemoria {
+	return &travaMemoria{presas: make(map[int64]bool)}
+}
+
+func (t *travaMemoria) Tentar(_ context.Context, chave int64) (func(), bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.falha != nil {
+		return nil, false, t.falha
+	}
+	if t.presas[chave] {
+		return nil, false, nil
+	}
+	t.presas[chave] = true
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.presas, chave)
+	}, true, nil
+}
+
+func TestAgendador_ExecutaAoIniciarEPeriodicamente(t *testing.T) {
+	var execucoes atomic.Int32
+	a := NewAgendador(novaTravaMemoria(), Rotina{
+		Nome:      "contador",
+		Intervalo: 10 * time.Millisecond,
+		Executar: func(context.Context) error {
+			execucoes.Add(1)
+			return nil
+		},
+	})
+
+	a.Iniciar(context.Background())
+	assert.Eventually(t, func() bool { return execucoes.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, a.Parar(context.Background()))
+
+	depois := execucoes.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, depois, execucoes.Load())
+}
+
+func TestAgendador_ReplicasNaoExecutamAoMesmoTempo(t *testing.T) {
+	trava := novaTravaMemoria()
+	var emExecucao, simultaneas, execucoes atomic.Int32
+	rotina := Rotina{
+		Nome:      "exclusiva",
+		Intervalo: 5 * time.Millisecond,
+		Executar: func(context.Context) error {
+			if emExecucao.Add(1) > 1 {
+				simultaneas.Add(1)
+			}
+			execucoes.Add(1)
+			time.Sleep(15 * time.Millisecond)
+			emExecucao.Add(-1)
+			return nil
+		},
+	}
+	replicas := []*Agendador{NewAgendador(trava, rotina), NewAgendador(trava, rotina), NewAgendador(trava, rotina)}
+
+	for _, replica := range replicas {
+		replica.Iniciar(context.Background())
+	}
+	assert.Eventually(t, func() bool { return execucoes.Load() >= 4 }, time.Second, 5*time.Millisecond)
+	for _, replica := range replicas {
+		require.NoError(t, replica.Parar(context.Background()))
+	}
+
+	assert.Zero(t, simultaneas.Load())
+}
+
+func TestAgendador_ErrosEPanicosNaoInterrompem(t *testing.T) {
+	var execucoes atomic.Int32
+	a := NewAgendador(novaTravaMemoria(), Rotina{
+		Nome:      "instavel",
+		Intervalo: 5 * time.Millisecond,
+		Executar: func(context.Context) error {
+			if execucoes.Add(1)%2 == 0 {
+				panic("falha inesperada")
+			}
+			return errors.New("falha")
+		},
+	})
+
+	a.Iniciar(context.Background())
+	assert.Eventually(t, func() bool { return execucoes.Load() >= 4 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, a.Parar(context.Background()))
+}
+
+func TestAgendador_PararEsperaExecucaoEmAndamento(t *testing.T) {
+	iniciou := make(chan struct{})
+	var terminou atomic.Bool
+	a := NewAgendador(novaTravaMemoria(), Rotina{
+		Nome:      "lenta",
+		Intervalo: time.Hour,
+		Executar: func(ctx context.Context) error {
+			close(iniciou)
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			terminou.Store(true)
+			return nil
+		},
+	})
+
+	a.Iniciar(context.Background())
+	<-iniciou
+	require.NoError(t, a.Parar(context.Background()))
+	assert.True(t, terminou.Load())
+
+	t.Run("Error - Prazo Expirado", func(t *testing.T) {
+		bloqueada := NewAgendador(novaTravaMemoria(), Rotina{
+			Nome:      "presa",
+			Intervalo: time.Hour,
+			Executar: func(context.Context) error {
+				time.Sleep(200 * time.Millisecond)
+				return nil
+			},
+		})
+		bloqueada.Iniciar(context.Background())
+		time.Sleep(10 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, bloqueada.Parar(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestAgendador_FalhaNaTravaPulaExecucao(t *testing.T) {
+	trava := novaTravaMemoria()
+	trava.falha = errors.New("banco indisponível")
+	var execucoes atomic.Int32
+	a := NewAgendador(trava, Rotina{
+		Nome:      "sem-trava",
+		Intervalo: 5 * time.Millisecond,
+		Executar: func(context.Context) error {
+			execucoes.Add(1)
+			return nil
+		},
+	})
+
+	a.Iniciar(context.Background())
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, a.Parar(context.Background()))
+
+	assert.Zero(t, execucoes.Load())
+}
+
+func TestChaveTrava(t *testing.T) {
+	assert.Equal(t, ChaveTrava("tarefas-atrasadas"), ChaveTrava("tarefas-atrasadas"))
+	assert.NotEqual(t, ChaveTrava("tarefas-atrasadas"), ChaveTrava("tarefas-lembretes"))
+}
@@ -0,0 +1,54 @@
+package agendador
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// timeoutLiberacao limita a espera pelo pg_advisory_unlock, que roda mesmo após o cancelamento
+const timeoutLiberacao = 5 * time.Second
+
+// TravaPostgres usa advisory locks de sessão do Postgres. A trava fica presa a uma conexão
+// dedicada do pool, então é liberada pelo próprio banco se o processo morrer.
+type TravaPostgres struct {
+	db *sql.DB
+}
+
+// NewTravaPostgres cria a trava sobre o pool de conexões da aplicação
+func NewTravaPostgres(db *sql.DB) *TravaPostgres {
+	return &TravaPostgres{db: db}
+}
+
+func (t *TravaPostgres) Tentar(ctx context.Context, chave int64) (func(), bool, error) {
+	conn, err := t.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("falha ao obter conexão para a trava: %w", err)
+	}
+
+	var obtida bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", chave).Scan(&obtida); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("falha ao tentar a trava %d: %w", chave, err)
+	}
+	if !obtida {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	liberar := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutLiberacao)
+		defer cancel()
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", chave); err != nil {
+			logrus.WithError(err).Warnf("Falha ao liberar a trava %d; descartando a conexão", chave)
+			// devolver ao pool uma conexão que ainda detém a trava a manteria presa
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}
+	return liberar, true, nil
+}
@@ -0,0 +1,69 @@
+package clima
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+)
+
+// Arquivo lê o tempo diário de um arquivo JSON local, ignorando as coordenadas. Serve aos
+// testes e ao desenvolvimento sem acesso à internet. O arquivo é uma lista de dias:
+//
+//	[{"data": "2026-06-01", "temperatura": 18.2, "temperatura_min": 12.1, "temperatura_max": 24.3,
+//	  "umidade": 71, "precipitacao": 0, "radiacao_solar": 14.8}]
+type Arquivo struct {
+	caminho string
+}
+
+// NewArquivo cria o provedor; o arquivo é lido a cada consulta.
+func NewArquivo(caminho string) *Arquivo {
+	return &Arquivo{caminho: caminho}
+}
+
+func (p *Arquivo) Nome() string {
+	return "arquivo"
+}
+
+type diaArquivo struct {
+	Data           string   `json:"data"`
+	Temperatura    float64  `json:"temperatura"`
+	TemperaturaMin float64  `json:"temperatura_min"`
+	TemperaturaMax float64  `json:"temperatura_max"`
+	Umidade        float64  `json:"umidade"`
+	Precipitacao   float64  `json:"precipitacao"`
+	RadiacaoSolar  *float64 `json:"radiacao_solar"`
+}
+
+func (p *Arquivo) ClimaDiario(_ context.Context, _, _ float64, inicio, fim time.Time) ([]service.ClimaDiario, error) {
+	conteudo, err := os.ReadFile(p.caminho)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler arquivo de clima: %w", err)
+	}
+	var registros []diaArquivo
+	if err := json.Unmarshal(conteudo, &registros); err != nil {
+		return nil, fmt.Errorf("arquivo de clima inválido: %w", err)
+	}
+
+	primeiro, ultimo := inicio.Format("2006-01-02"), fim.Format("2006-01-02")
+	var dias []service.ClimaDiario
+	for _, r := range registros {
+		// datas AAAA-MM-DD se ordenam como texto
+		if r.Data < primeiro || r.Data > ultimo {
+			continue
+		}
+		dias = append(dias, service.ClimaDiario{
+			Data:           r.Data,
+			Temperatura:    r.Temperatura,
+			TemperaturaMin: r.TemperaturaMin,
+			TemperaturaMax: r.TemperaturaMax,
+			Umidade:        r.Umidade,
+			Precipitacao:   r.Precipitacao,
+			RadiacaoSolar:  r.RadiacaoSolar,
+		})
+	}
+	return dias, nil
+}
@@ -0,0 +1,75 @@
+package clima
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArquivo_ClimaDiario(t *testing.T) {
+	provedor := NewArquivo("testdata/clima.json")
+	inicio := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	dias, err := provedor.ClimaDiario(context.Background(), -23.55, -46.63, inicio, inicio.AddDate(0, 0, 5))
+
+	require.NoError(t, err)
+	require.Len(t, dias, 2)
+	assert.Equal(t, "2026-06-01", dias[0].Data)
+	assert.Equal(t, 24.3, dias[0].TemperaturaMax)
+	require.NotNil(t, dias[0].RadiacaoSolar)
+	assert.Nil(t, dias[1].RadiacaoSolar)
+	assert.Equal(t, 22.6, dias[1].Precipitacao)
+
+	_, err = NewArquivo("testdata/inexistente.json").ClimaDiario(context.Background(), 0, 0, inicio, inicio)
+	assert.Error(t, err)
+}
+
+func TestOpenMeteo_ClimaDiario(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		servidor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "-23.55", r.URL.Query().Get("latitude"))
+			assert.Equal(t, "2026-06-01", r.URL.Query().Get("start_date"))
+			assert.Equal(t, "2026-06-03", r.URL.Query().Get("end_date"))
+			_, _ = w.Write([]byte(`{"daily": {
+				"time": ["2026-06-01", "2026-06-02", "2026-06-03"],
+				"temperature_2m_mean": [18.2, null, null],
+				"temperature_2m_min": [12.1, 13.0, null],
+				"temperature_2m_max": [24.3, 17.0, null],
+				"relative_humidity_2m_mean": [71, 92, null],
+				"precipitation_sum": [0.4, 22.6, null],
+				"shortwave_radiation_sum": [14.8, null, null]
+			}}`))
+		}))
+		defer servidor.Close()
+		provedor := NewOpenMeteo(servidor.URL, servidor.Client())
+		inicio := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		dias, err := provedor.ClimaDiario(context.Background(), -23.55, -46.63, inicio, inicio.AddDate(0, 0, 2))
+
+		require.NoError(t, err)
+		require.Len(t, dias, 2)
+		assert.Equal(t, 18.2, dias[0].Temperatura)
+		assert.Equal(t, 14.8, *dias[0].RadiacaoSolar)
+		// sem média, usa o ponto médio entre mínima e máxima
+		assert.Equal(t, 15.0, dias[1].Temperatura)
+		assert.Nil(t, dias[1].RadiacaoSolar)
+	})
+
+	t.Run("Error - Status de Erro", func(t *testing.T) {
+		servidor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": true, "reason": "Parameter 'start_date' is out of allowed range"}`))
+		}))
+		defer servidor.Close()
+
+		_, err := NewOpenMeteo(servidor.URL, servidor.Client()).ClimaDiario(context.Background(), 0, 0, time.Now(), time.Now())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "out of allowed range")
+	})
+}
@@ -0,0 +1,120 @@
+// Package clima implementa os provedores de tempo diário usados pelos ambientes externos.
+package clima
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+)
+
+// URLOpenMeteo é o endpoint de previsão do Open-Meteo, que também devolve os últimos 92 dias.
+const URLOpenMeteo = "https://api.open-meteo.com/v1/forecast"
+
+const variaveisOpenMeteo = "temperature_2m_mean,temperature_2m_min,temperature_2m_max," +
+	"relative_humidity_2m_mean,precipitation_sum,shortwave_radiation_sum"
+
+// OpenMeteo consulta o tempo diário na API pública do Open-Meteo (sem chave de acesso).
+type OpenMeteo struct {
+	url     string
+	cliente *http.Client
+}
+
+// NewOpenMeteo cria o provedor. Se endpoint for vazio, usa URLOpenMeteo; se cliente for nil,
+// usa http.DefaultClient. O prazo de cada consulta vem do contexto.
+func NewOpenMeteo(endpoint string, cliente *http.Client) *OpenMeteo {
+	if endpoint == "" {
+		endpoint = URLOpenMeteo
+	}
+	if cliente == nil {
+		cliente = http.DefaultClient
+	}
+	return &OpenMeteo{url: endpoint, cliente: cliente}
+}
+
+func (p *OpenMeteo) Nome() string {
+	return "open-meteo"
+}
+
+// respostaOpenMeteo traz uma lista por variável, alinhada com as datas; dias sem dado vêm como null
+type respostaOpenMeteo struct {
+	Daily struct {
+		Time          []string   `json:"time"`
+		Temperatura   []*float64 `json:"temperature_2m_mean"`
+		Minima        []*float64 `json:"temperature_2m_min"`
+		Maxima        []*float64 `json:"temperature_2m_max"`
+		Umidade       []*float64 `json:"relative_humidity_2m_mean"`
+		Precipitacao  []*float64 `json:"precipitation_sum"`
+		RadiacaoSolar []*float64 `json:"shortwave_radiation_sum"`
+	} `json:"daily"`
+	Reason string `json:"reason"`
+}
+
+func (p *OpenMeteo) ClimaDiario(ctx context.Context, latitude, longitude float64, inicio, fim time.Time) ([]service.ClimaDiario, error) {
+	consulta := url.Values{}
+	consulta.Set("latitude", strconv.FormatFloat(latitude, 'f', -1, 64))
+	consulta.Set("longitude", strconv.FormatFloat(longitude, 'f', -1, 64))
+	consulta.Set("daily", variaveisOpenMeteo)
+	consulta.Set("timezone", "auto")
+	consulta.Set("start_date", inicio.Format("2006-01-02"))
+	consulta.Set("end_date", fim.Format("2006-01-02"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url+"?"+consulta.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao montar requisição do Open-Meteo: %w", err)
+	}
+	resp, err := p.cliente.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao chamar o Open-Meteo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var resposta respostaOpenMeteo
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&resposta); err != nil {
+		return nil, fmt.Errorf("resposta inválida do Open-Meteo (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("o Open-Meteo respondeu com status %d: %s", resp.StatusCode, resposta.Reason)
+	}
+
+	diario := resposta.Daily
+	dias := make([]service.ClimaDiario, 0, len(diario.Time))
+	for i, data := range diario.Time {
+		// dias ainda sem observação nem previsão não são registrados
+		if valor(diario.Minima, i) == nil || valor(diario.Maxima, i) == nil {
+			continue
+		}
+		dia := service.ClimaDiario{
+			Data:           data,
+			TemperaturaMin: *valor(diario.Minima, i),
+			TemperaturaMax: *valor(diario.Maxima, i),
+			RadiacaoSolar:  valor(diario.RadiacaoSolar, i),
+		}
+		if t := valor(diario.Temperatura, i); t != nil {
+			dia.Temperatura = *t
+		} else {
+			dia.Temperatura = (dia.TemperaturaMin + dia.TemperaturaMax) / 2
+		}
+		if u := valor(diario.Umidade, i); u != nil {
+			dia.Umidade = *u
+		}
+		if chuva := valor(diario.Precipitacao, i); chuva != nil {
+			dia.Precipitacao = *chuva
+		}
+		dias = append(dias, dia)
+	}
+	return dias, nil
+}
+
+func valor(serie []*float64, i int) *float64 {
+	if i >= len(serie) {
+		return nil
+	}
+	return serie[i]
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+)
+
+// AlertaRepositorio implementa a interface repository.AlertaRepositorio
+type AlertaRepositorio struct {
+	db *gorm.DB
+}
+
+// NewAlertaRepositorio cria uma nova instância do AlertaRepositorio
+func NewAlertaRepositorio(db *gorm.DB) *AlertaRepositorio {
+	return &AlertaRepositorio{db: db}
+}
+
+func (r *AlertaRepositorio) Criar(alerta *entity.Alerta) error {
+	if alerta == nil {
+		return errors.New("alerta não pode ser nulo")
+	}
+	return r.db.Create(alerta).Error
+}
+
+func (r *AlertaRepositorio) Atualizar(alerta *entity.Alerta) error {
+	if alerta == nil {
+		return errors.New("alerta não pode ser nulo")
+	}
+	return r.db.Omit("RegraAlerta").Save(alerta).Error
+}
+
+func (r *AlertaRepositorio) BuscarPorID(id uint) (*entity.Alerta, error) {
+	var alerta entity.Alerta
+	if err := r.db.Preload("RegraAlerta").First(&alerta, id).Error; err != nil {
+		return nil, err
+	}
+	return &alerta, nil
+}
+
+func (r *AlertaRepositorio) BuscarAtivoPorRegra(regraID uint) (*entity.Alerta, error) {
+	var alerta entity.Alerta
+	err := r.db.Where("regra_alerta_id = ? AND status <> ?", regraID, entity.StatusAlertaResolvido).
+		Order("iniciado_em desc").
+		First(&alerta).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alerta, nil
+}
+
+// Listar retorna os alertas filtrados, dos mais recentes para os mais antigos
+func (r *AlertaRepositorio) Listar(filtro repository.FiltroAlertas) ([]entity.Alerta, int64, error) {
+	query := r.db.Model(&entity.Alerta{})
+	if filtro.UsuarioID != 0 {
+		query = query.Where("usuario_id = ?", filtro.UsuarioID)
+	}
+	if filtro.AmbienteID != 0 {
+		query = query.Where("ambiente_id = ?", filtro.AmbienteID)
+	}
+	if filtro.Status != "" {
+		query = query.Where("status = ?", filtro.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("falha ao contar alertas: %w", err)
+	}
+
+	var alertas []entity.Alerta
+	offset := (filtro.Page - 1) * filtro.Limit
+	if err := query.Order("iniciado_em desc").Offset(offset).Limit(filtro.Limit).Find(&alertas).Error; err != nil {
+		return nil, 0, fmt.Errorf("falha ao listar alertas: %w", err)
+	}
+	return alertas, total, nil
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// AplicacaoCronogramaRepositorio implementa a interface repository.AplicacaoCronogramaRepositorio
+type AplicacaoCronogramaRepositorio struct {
+	db *gorm.DB
+}
+
+// NewAplicacaoCronogramaRepositorio cria uma nova instância do AplicacaoCronogramaRepositorio
+func NewAplicacaoCronogramaRepositorio(db *gorm.DB) *AplicacaoCronogramaRepositorio {
+	return &AplicacaoCronogramaRepositorio{db: db}
+}
+
+func (r *AplicacaoCronogramaRepositorio) Criar(aplicacao *entity.AplicacaoCronograma) error {
+	if aplicacao == nil {
+		return errors.New("aplicação não pode ser nula")
+	}
+	return r.db.Omit("Cronograma").Create(aplicacao).Error
+}
+
+func (r *AplicacaoCronogramaRepositorio) BuscarPorID(id uint) (*entity.AplicacaoCronograma, error) {
+	var aplicacao entity.AplicacaoCronograma
+	if err := r.db.Preload("Cronograma.Itens", ordenarItensCronograma).First(&aplicacao, id).Error; err != nil {
+		return nil, err
+	}
+	return &aplicacao, nil
+}
+
+func (r *AplicacaoCronogramaRepositorio) BuscarPorCronogramaEPlanta(cronogramaID, plantaID uint) (*entity.AplicacaoCronograma, error) {
+	var aplicacao entity.AplicacaoCronograma
+	err := r.db.Where("cronograma_id = ? AND planta_id = ?", cronogramaID, plantaID).First(&aplicacao).Error
+	if err != nil {
+		return nil, err
+	}
+	return &aplicacao, nil
+}
+
+func (r *AplicacaoCronogramaRepositorio) ListarPorCronograma(cronogramaID uint) ([]entity.AplicacaoCronograma, error) {
+	var aplicacoes []entity.AplicacaoCronograma
+	if err := r.db.Where("cronograma_id = ?", cronogramaID).Order("id").Find(&aplicacoes).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar aplicações do cronograma %d: %w", cronogramaID, err)
+	}
+	return aplicacoes, nil
+}
+
+func (r *AplicacaoCronogramaRepositorio) ListarPorPlanta(plantaID uint) ([]entity.AplicacaoCronograma, error) {
+	var aplicacoes []entity.AplicacaoCronograma
+	err := r.db.Preload("Cronograma.Itens", ordenarItensCronograma).
+		Where("planta_id = ?", plantaID).
+		Order("id").
+		Find(&aplicacoes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar aplicações da planta %d: %w", plantaID, err)
+	}
+	return aplicacoes, nil
+}
+
+func (r *AplicacaoCronogramaRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.AplicacaoCronograma{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
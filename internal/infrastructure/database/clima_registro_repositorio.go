@@ -0,0 +1,52 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// ClimaRegistroRepositorio implementa a interface repository.ClimaRegistroRepositorio
+type ClimaRegistroRepositorio struct {
+	db *gorm.DB
+}
+
+// NewClimaRegistroRepositorio cria uma nova instância do ClimaRegistroRepositorio
+func NewClimaRegistroRepositorio(db *gorm.DB) *ClimaRegistroRepositorio {
+	return &ClimaRegistroRepositorio{db: db}
+}
+
+// Salvar grava os registros, substituindo os que já existem para o mesmo ambiente e data
+func (r *ClimaRegistroRepositorio) Salvar(registros []entity.ClimaRegistro) error {
+	if len(registros) == 0 {
+		return nil
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "ambiente_id"}, {Name: "data"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"updated_at", "temperatura", "temperatura_min", "temperatura_max",
+			"umidade", "precipitacao", "radiacao_solar", "fonte",
+		}),
+	}).Create(&registros).Error
+	if err != nil {
+		return fmt.Errorf("falha ao salvar registros de clima: %w", err)
+	}
+	return nil
+}
+
+// ListarPorAmbiente retorna os registros entre as datas de calendário inicio e fim (inclusivas)
+func (r *ClimaRegistroRepositorio) ListarPorAmbiente(ambienteID uint, inicio, fim time.Time) ([]entity.ClimaRegistro, error) {
+	var registros []entity.ClimaRegistro
+	err := r.db.
+		Where("ambiente_id = ? AND data BETWEEN ? AND ?", ambienteID, inicio.Format("2006-01-02"), fim.Format("2006-01-02")).
+		Order("data").
+		Find(&registros).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar registros de clima do ambiente %d: %w", ambienteID, err)
+	}
+	return registros, nil
+}
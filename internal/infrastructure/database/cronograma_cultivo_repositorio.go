@@ -0,0 +1,93 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// CronogramaCultivoRepositorio implementa a interface repository.CronogramaCultivoRepositorio
+type CronogramaCultivoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewCronogramaCultivoRepositorio cria uma nova instância do CronogramaCultivoRepositorio
+func NewCronogramaCultivoRepositorio(db *gorm.DB) *CronogramaCultivoRepositorio {
+	return &CronogramaCultivoRepositorio{db: db}
+}
+
+func (r *CronogramaCultivoRepositorio) Criar(cronograma *entity.CronogramaCultivo) error {
+	if cronograma == nil {
+		return errors.New("cronograma não pode ser nulo")
+	}
+	return r.db.Create(cronograma).Error
+}
+
+func (r *CronogramaCultivoRepositorio) BuscarPorID(id uint) (*entity.CronogramaCultivo, error) {
+	var cronograma entity.CronogramaCultivo
+	if err := r.db.Preload("Itens", ordenarItensCronograma).First(&cronograma, id).Error; err != nil {
+		return nil, err
+	}
+	return &cronograma, nil
+}
+
+func (r *CronogramaCultivoRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.CronogramaCultivo, error) {
+	var cronogramas []entity.CronogramaCultivo
+	err := r.db.Preload("Itens", ordenarItensCronograma).
+		Where("usuario_id = ?", usuarioID).
+		Order("nome, id").
+		Find(&cronogramas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar cronogramas do usuário %d: %w", usuarioID, err)
+	}
+	return cronogramas, nil
+}
+
+func (r *CronogramaCultivoRepositorio) Atualizar(cronograma *entity.CronogramaCultivo) error {
+	if cronograma == nil {
+		return errors.New("cronograma não pode ser nulo")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cronograma_id = ?", cronograma.ID).Delete(&entity.TarefaTemplate{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover itens do cronograma %d: %w", cronograma.ID, err)
+		}
+		for i := range cronograma.Itens {
+			cronograma.Itens[i].ID = 0
+			cronograma.Itens[i].CronogramaID = &cronograma.ID
+		}
+		if err := tx.Omit("Itens").Save(cronograma).Error; err != nil {
+			return err
+		}
+		if len(cronograma.Itens) == 0 {
+			return nil
+		}
+		return tx.Create(&cronograma.Itens).Error
+	})
+}
+
+func (r *CronogramaCultivoRepositorio) Deletar(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entity.CronogramaCultivo{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("cronograma_id = ?", id).Delete(&entity.TarefaTemplate{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover itens do cronograma %d: %w", id, err)
+		}
+		if err := tx.Where("cronograma_id = ?", id).Delete(&entity.AplicacaoCronograma{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover aplicações do cronograma %d: %w", id, err)
+		}
+		return nil
+	})
+}
+
+// ordenarItensCronograma mantém os itens na ordem definida pelo usuário e, depois, pelo dia
+func ordenarItensCronograma(db *gorm.DB) *gorm.DB {
+	return db.Order("ordem, dia_inicio, id")
+}
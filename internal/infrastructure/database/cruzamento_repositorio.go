@@ -0,0 +1,106 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CruzamentoRepositorio implementa a interface repository.CruzamentoRepositorio
+type CruzamentoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewCruzamentoRepositorio cria uma nova instância do CruzamentoRepositorio
+func NewCruzamentoRepositorio(db *gorm.DB) *CruzamentoRepositorio {
+	return &CruzamentoRepositorio{db: db}
+}
+
+func (r *CruzamentoRepositorio) Criar(cruzamento *entity.Cruzamento, genetica *entity.Genetica) error {
+	if cruzamento == nil || genetica == nil {
+		return errors.New("cruzamento e genética resultante não podem ser nulos")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(genetica).Error; err != nil {
+			return fmt.Errorf("falha ao criar genética resultante do cruzamento: %w", err)
+		}
+		cruzamento.GeneticaID = genetica.ID
+		if err := tx.Omit(clause.Associations).Create(cruzamento).Error; err != nil {
+			return err
+		}
+		cruzamento.Genetica = genetica
+		return nil
+	})
+}
+
+func (r *CruzamentoRepositorio) BuscarPorID(id uint) (*entity.Cruzamento, error) {
+	var cruzamento entity.Cruzamento
+	err := r.db.Preload("MaeGenetica").Preload("PaiGenetica").Preload("Genetica").First(&cruzamento, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cruzamento, nil
+}
+
+func (r *CruzamentoRepositorio) Listar(usuarioID uint) ([]entity.Cruzamento, error) {
+	var cruzamentos []entity.Cruzamento
+	err := r.db.Preload("MaeGenetica").Preload("PaiGenetica").Preload("Genetica").
+		Where("usuario_id = ?", usuarioID).
+		Order("created_at DESC, id DESC").
+		Find(&cruzamentos).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar cruzamentos do usuário %d: %w", usuarioID, err)
+	}
+	return cruzamentos, nil
+}
+
+func (r *CruzamentoRepositorio) Deletar(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entity.Cruzamento{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("cruzamento_id = ?", id).Delete(&entity.Polinizacao{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover polinizações do cruzamento %d: %w", id, err)
+		}
+		err := tx.Model(&entity.PacoteSemente{}).Where("cruzamento_id = ?", id).
+			Updates(map[string]any{"cruzamento_id": nil, "polinizacao_id": nil}).Error
+		if err != nil {
+			return fmt.Errorf("falha ao desvincular lotes de sementes do cruzamento %d: %w", id, err)
+		}
+		return nil
+	})
+}
+
+func (r *CruzamentoRepositorio) RegistrarPolinizacao(polinizacao *entity.Polinizacao) error {
+	if polinizacao == nil {
+		return errors.New("polinização não pode ser nula")
+	}
+	return r.db.Omit(clause.Associations).Create(polinizacao).Error
+}
+
+func (r *CruzamentoRepositorio) BuscarPolinizacao(id uint) (*entity.Polinizacao, error) {
+	var polinizacao entity.Polinizacao
+	if err := r.db.First(&polinizacao, id).Error; err != nil {
+		return nil, err
+	}
+	return &polinizacao, nil
+}
+
+func (r *CruzamentoRepositorio) ListarPolinizacoes(cruzamentoID uint) ([]entity.Polinizacao, error) {
+	var polinizacoes []entity.Polinizacao
+	err := r.db.Preload("PlantaMae").Preload("PlantaDoadora").
+		Where("cruzamento_id = ?", cruzamentoID).
+		Order("data, id").
+		Find(&polinizacoes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar polinizações do cruzamento %d: %w", cruzamentoID, err)
+	}
+	return polinizacoes, nil
+}
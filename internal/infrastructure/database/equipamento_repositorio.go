@@ -0,0 +1,61 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// EquipamentoRepositorio implementa a interface repository.EquipamentoRepositorio
+type EquipamentoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewEquipamentoRepositorio cria uma nova instância do EquipamentoRepositorio
+func NewEquipamentoRepositorio(db *gorm.DB) *EquipamentoRepositorio {
+	return &EquipamentoRepositorio{db: db}
+}
+
+func (r *EquipamentoRepositorio) Criar(equipamento *entity.Equipamento) error {
+	if equipamento == nil {
+		return errors.New("equipamento não pode ser nulo")
+	}
+	return r.db.Create(equipamento).Error
+}
+
+func (r *EquipamentoRepositorio) BuscarPorID(id uint) (*entity.Equipamento, error) {
+	var equipamento entity.Equipamento
+	if err := r.db.First(&equipamento, id).Error; err != nil {
+		return nil, err
+	}
+	return &equipamento, nil
+}
+
+func (r *EquipamentoRepositorio) ListarPorAmbiente(ambienteID uint) ([]entity.Equipamento, error) {
+	var equipamentos []entity.Equipamento
+	if err := r.db.Where("ambiente_id = ?", ambienteID).Order("id").Find(&equipamentos).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar equipamentos do ambiente %d: %w", ambienteID, err)
+	}
+	return equipamentos, nil
+}
+
+func (r *EquipamentoRepositorio) Atualizar(equipamento *entity.Equipamento) error {
+	if equipamento == nil {
+		return errors.New("equipamento não pode ser nulo")
+	}
+	return r.db.Save(equipamento).Error
+}
+
+func (r *EquipamentoRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.Equipamento{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
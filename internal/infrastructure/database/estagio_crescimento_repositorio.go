@@ -0,0 +1,73 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// EstagioCrescimentoRepositorio implementa a interface repository.EstagioCrescimentoRepositorio
+type EstagioCrescimentoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewEstagioCrescimentoRepositorio cria uma nova instância do EstagioCrescimentoRepositorio
+func NewEstagioCrescimentoRepositorio(db *gorm.DB) *EstagioCrescimentoRepositorio {
+	return &EstagioCrescimentoRepositorio{db: db}
+}
+
+// IniciarEstagio encerra o estágio em aberto da planta e registra o novo estágio
+func (r *EstagioCrescimentoRepositorio) IniciarEstagio(estagio *entity.EstagioCrescimento) error {
+	if estagio == nil {
+		return errors.New("estágio de crescimento não pode ser nulo")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.EstagioCrescimento{}).
+			Where("planta_id = ? AND data_fim IS NULL", estagio.PlantaID).
+			Update("data_fim", estagio.DataInicio).Error
+		if err != nil {
+			return fmt.Errorf("falha ao encerrar estágio atual da planta %d: %w", estagio.PlantaID, err)
+		}
+		if err := tx.Create(estagio).Error; err != nil {
+			return fmt.Errorf("falha ao criar estágio da planta %d: %w", estagio.PlantaID, err)
+		}
+		return nil
+	})
+}
+
+// BuscarAtual retorna o estágio em aberto da planta
+func (r *EstagioCrescimentoRepositorio) BuscarAtual(plantaID uint) (*entity.EstagioCrescimento, error) {
+	var estagio entity.EstagioCrescimento
+	err := r.db.Where("planta_id = ? AND data_fim IS NULL", plantaID).
+		Order("data_inicio desc").
+		First(&estagio).Error
+	if err != nil {
+		return nil, err
+	}
+	return &estagio, nil
+}
+
+// ListarPorPlanta retorna o histórico de estágios da planta em ordem cronológica
+func (r *EstagioCrescimentoRepositorio) ListarPorPlanta(plantaID uint) ([]entity.EstagioCrescimento, error) {
+	var estagios []entity.EstagioCrescimento
+	if err := r.db.Where("planta_id = ?", plantaID).Order("data_inicio asc").Find(&estagios).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar estágios da planta %d: %w", plantaID, err)
+	}
+	return estagios, nil
+}
+
+// ListarAtuaisPorAmbiente retorna o estágio em aberto de cada planta ativa do ambiente
+func (r *EstagioCrescimentoRepositorio) ListarAtuaisPorAmbiente(ambienteID uint) ([]entity.EstagioCrescimento, error) {
+	var estagios []entity.EstagioCrescimento
+	err := r.db.
+		Joins("JOIN plantas ON plantas.id = estagio_crescimentos.planta_id AND plantas.deleted_at IS NULL").
+		Where("plantas.ambiente_id = ? AND plantas.status = ? AND estagio_crescimentos.data_fim IS NULL", ambienteID, entity.StatusGerminating).
+		Find(&estagios).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar estágios do ambiente %d: %w", ambienteID, err)
+	}
+	return estagios, nil
+}
@@ -0,0 +1,175 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EstoqueRepositorio implementa a interface repository.EstoqueRepositorio
+type EstoqueRepositorio struct {
+	db *gorm.DB
+}
+
+// NewEstoqueRepositorio cria uma nova instância do EstoqueRepositorio
+func NewEstoqueRepositorio(db *gorm.DB) *EstoqueRepositorio {
+	return &EstoqueRepositorio{db: db}
+}
+
+func (r *EstoqueRepositorio) CriarItem(item *entity.ItemEstoque) error {
+	if item == nil {
+		return errors.New("item de estoque não pode ser nulo")
+	}
+	return r.db.Create(item).Error
+}
+
+func (r *EstoqueRepositorio) BuscarItem(id uint) (*entity.ItemEstoque, error) {
+	var item entity.ItemEstoque
+	if err := r.db.First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *EstoqueRepositorio) ListarItens(filtro repository.FiltroItensEstoque) ([]entity.ItemEstoque, error) {
+	query := r.db.Where("usuario_id = ?", filtro.UsuarioID)
+	if filtro.Categoria != "" {
+		query = query.Where("categoria = ?", filtro.Categoria)
+	}
+	if filtro.AbaixoMinimo {
+		query = query.Where("estoque_minimo IS NOT NULL AND quantidade <= estoque_minimo")
+	}
+	if filtro.VencendoAte != nil {
+		query = query.Where("validade IS NOT NULL AND validade <= ?", *filtro.VencendoAte)
+	}
+	var itens []entity.ItemEstoque
+	if err := query.Order("categoria, nome, id").Find(&itens).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar itens de estoque do usuário %d: %w", filtro.UsuarioID, err)
+	}
+	return itens, nil
+}
+
+func (r *EstoqueRepositorio) AtualizarItem(item *entity.ItemEstoque) error {
+	if item == nil {
+		return errors.New("item de estoque não pode ser nulo")
+	}
+	// o saldo e o custo médio são mantidos pelas movimentações, que podem ter mudado o item
+	// depois de ele ser lido
+	return r.db.Omit("Quantidade", "CustoMedio").Save(item).Error
+}
+
+func (r *EstoqueRepositorio) DeletarItem(id uint) error {
+	result := r.db.Delete(&entity.ItemEstoque{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *EstoqueRepositorio) RegistrarMovimentacoes(movimentacoes *repository.MovimentacoesEstoque) error {
+	if movimentacoes == nil || len(movimentacoes.Itens) == 0 {
+		return nil
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return registrarMovimentacoes(tx, movimentacoes)
+	})
+	if err != nil {
+		return err
+	}
+	movimentacoes.Confirmar()
+	return nil
+}
+
+// registrarMovimentacoes grava as movimentações na transação tx. Os itens são lidos com
+// SELECT ... FOR UPDATE, em ordem de ID para evitar deadlocks, então movimentações simultâneas do
+// mesmo item esperam umas pelas outras em vez de sobrescrever o saldo.
+func registrarMovimentacoes(tx *gorm.DB, movimentacoes *repository.MovimentacoesEstoque) error {
+	if movimentacoes == nil || len(movimentacoes.Itens) == 0 {
+		return nil
+	}
+	var ids []uint
+	vistos := make(map[uint]bool)
+	for _, movimentacao := range movimentacoes.Itens {
+		if !vistos[movimentacao.ItemID] {
+			vistos[movimentacao.ItemID] = true
+			ids = append(ids, movimentacao.ItemID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var itens []entity.ItemEstoque
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&itens).Error
+	if err != nil {
+		return fmt.Errorf("falha ao travar itens de estoque: %w", err)
+	}
+	travados := make(map[uint]*entity.ItemEstoque, len(itens))
+	for i := range itens {
+		travados[itens[i].ID] = &itens[i]
+	}
+
+	for _, movimentacao := range movimentacoes.Itens {
+		item, ok := travados[movimentacao.ItemID]
+		if !ok {
+			return fmt.Errorf("item de estoque %d: %w", movimentacao.ItemID, gorm.ErrRecordNotFound)
+		}
+		if movimentacoes.Aplicar != nil {
+			if err := movimentacoes.Aplicar(item, movimentacao); err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit("Item").Create(movimentacao).Error; err != nil {
+			return err
+		}
+	}
+	for _, id := range ids {
+		item := travados[id]
+		err := tx.Model(item).
+			Select("Quantidade", "CustoMedio", "Validade", "AlertaEstoqueEm").
+			Updates(item).Error
+		if err != nil {
+			return fmt.Errorf("falha ao atualizar saldo do item de estoque %d: %w", item.ID, err)
+		}
+	}
+	return nil
+}
+
+func (r *EstoqueRepositorio) ListarMovimentacoes(itemID uint) ([]entity.MovimentacaoEstoque, error) {
+	var movimentacoes []entity.MovimentacaoEstoque
+	err := r.db.Where("item_id = ?", itemID).
+		Order("data DESC, id DESC").
+		Find(&movimentacoes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar movimentações do item de estoque %d: %w", itemID, err)
+	}
+	return movimentacoes, nil
+}
+
+func (r *EstoqueRepositorio) ListarConsumosCiclo(filtro repository.FiltroConsumosCiclo) ([]entity.MovimentacaoEstoque, error) {
+	query := r.db.Preload("Item", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("tipo = ?", entity.MovimentacaoEstoqueConsumo)
+	porPlanta := r.db.Where("planta_id IN ? AND data >= ?", filtro.PlantaIDs, filtro.De)
+	if filtro.Ate != nil {
+		porPlanta = porPlanta.Where("data <= ?", *filtro.Ate)
+	}
+	if len(filtro.PlantaIDs) > 0 {
+		query = query.Where(r.db.Where("diario_cultivo_id = ?", filtro.DiarioCultivoID).Or(porPlanta))
+	} else {
+		query = query.Where("diario_cultivo_id = ?", filtro.DiarioCultivoID)
+	}
+	var consumos []entity.MovimentacaoEstoque
+	if err := query.Order("data, id").Find(&consumos).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar consumos do diário de cultivo %d: %w", filtro.DiarioCultivoID, err)
+	}
+	return consumos, nil
+}
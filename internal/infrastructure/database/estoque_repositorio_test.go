@@ -0,0 +1,51 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstoqueRepositorio_DeletarItem(t *testing.T) {
+	t.Run("Success - Consumos Continuam no Custo do Ciclo", func(t *testing.T) {
+		db := setupTestDB(t, &entity.ItemEstoque{}, &entity.MovimentacaoEstoque{})
+		repo := NewEstoqueRepositorio(db)
+
+		inicio := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		diarioID := uint(5)
+		item := entity.ItemEstoque{UsuarioID: 7, Nome: "Bloom", Categoria: entity.CategoriaEstoqueNutriente, Unidade: entity.UnidadeEstoqueMl}
+		require.NoError(t, db.Create(&item).Error)
+		consumos := []entity.MovimentacaoEstoque{
+			{ItemID: item.ID, UsuarioID: 7, Tipo: entity.MovimentacaoEstoqueConsumo, Origem: entity.OrigemEstoqueRega,
+				Data: inicio.AddDate(0, 0, 3), Quantidade: -600, CustoTotal: 48, DiarioCultivoID: &diarioID},
+			{ItemID: item.ID, UsuarioID: 7, Tipo: entity.MovimentacaoEstoqueConsumo, Origem: entity.OrigemEstoqueRega,
+				Data: inicio.AddDate(0, 0, 10), Quantidade: -400, CustoTotal: 32, DiarioCultivoID: &diarioID},
+		}
+		require.NoError(t, db.Omit("Item").Create(&consumos).Error)
+		filtro := repository.FiltroConsumosCiclo{DiarioCultivoID: diarioID, De: inicio}
+		custoTotal := func(movimentacoes []entity.MovimentacaoEstoque) float64 {
+			total := 0.0
+			for _, movimentacao := range movimentacoes {
+				total += movimentacao.CustoTotal
+			}
+			return total
+		}
+		antes, err := repo.ListarConsumosCiclo(filtro)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.DeletarItem(item.ID))
+
+		depois, err := repo.ListarConsumosCiclo(filtro)
+		require.NoError(t, err)
+		require.Len(t, depois, 2)
+		assert.Equal(t, custoTotal(antes), custoTotal(depois))
+		require.NotNil(t, depois[0].Item)
+		assert.Equal(t, "Bloom", depois[0].Item.Nome)
+		_, err = repo.BuscarItem(item.ID)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// FotoperiodoRepositorio implementa a interface repository.FotoperiodoRepositorio
+type FotoperiodoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewFotoperiodoRepositorio cria uma nova instância do FotoperiodoRepositorio
+func NewFotoperiodoRepositorio(db *gorm.DB) *FotoperiodoRepositorio {
+	return &FotoperiodoRepositorio{db: db}
+}
+
+func (r *FotoperiodoRepositorio) Criar(fotoperiodo *entity.Fotoperiodo) error {
+	if fotoperiodo == nil {
+		return errors.New("fotoperíodo não pode ser nulo")
+	}
+	return r.db.Create(fotoperiodo).Error
+}
+
+func (r *FotoperiodoRepositorio) BuscarPorID(id uint) (*entity.Fotoperiodo, error) {
+	var fotoperiodo entity.Fotoperiodo
+	if err := r.db.First(&fotoperiodo, id).Error; err != nil {
+		return nil, err
+	}
+	return &fotoperiodo, nil
+}
+
+// ListarPorAmbiente retorna as programações do ambiente em ordem de vigência
+func (r *FotoperiodoRepositorio) ListarPorAmbiente(ambienteID uint) ([]entity.Fotoperiodo, error) {
+	var fotoperiodos []entity.Fotoperiodo
+	err := r.db.Where("ambiente_id = ?", ambienteID).
+		Order("vigente_desde, id").
+		Find(&fotoperiodos).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar fotoperíodos do ambiente %d: %w", ambienteID, err)
+	}
+	return fotoperiodos, nil
+}
+
+func (r *FotoperiodoRepositorio) Deletar(id uint) error {
+	resultado := r.db.Delete(&entity.Fotoperiodo{}, id)
+	if resultado.Error != nil {
+		return fmt.Errorf("falha ao deletar fotoperíodo %d: %w", id, resultado.Error)
+	}
+	if resultado.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package database
 
 import (
+	"errors"
+	"fmt"
+
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GeneticaRepositorio struct {
@@ -46,3 +50,75 @@ func (r *GeneticaRepositorio) Deletar(id uint) error {
 	}
 	return result.Error
 }
+
+func (r *GeneticaRepositorio) BuscarPorIDs(ids []uint) ([]entity.Genetica, error) {
+	var geneticas []entity.Genetica
+	if len(ids) == 0 {
+		return geneticas, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&geneticas).Error; err != nil {
+		return nil, fmt.Errorf("falha ao buscar genéticas: %w", err)
+	}
+	return geneticas, nil
+}
+
+func (r *GeneticaRepositorio) ListarCatalogo() ([]entity.Genetica, error) {
+	var geneticas []entity.Genetica
+	if err := r.db.Order("nome, origem, id").Find(&geneticas).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar catálogo de genéticas: %w", err)
+	}
+	return geneticas, nil
+}
+
+func (r *GeneticaRepositorio) CriarEmLote(geneticas []entity.Genetica) error {
+	if len(geneticas) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Omit(clause.Associations).Create(&geneticas).Error
+	})
+}
+
+func (r *GeneticaRepositorio) Mesclar(destino *entity.Genetica, duplicadas []uint) (int64, int64, error) {
+	if destino == nil || len(duplicadas) == 0 {
+		return 0, 0, errors.New("genética de destino e duplicadas não podem ser nulas")
+	}
+	var plantas, pacotes int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(destino).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&entity.Planta{}).Where("genetica_id IN ?", duplicadas).Update("genetica_id", destino.ID)
+		if result.Error != nil {
+			return fmt.Errorf("falha ao mover plantas para a genética %d: %w", destino.ID, result.Error)
+		}
+		plantas = result.RowsAffected
+		result = tx.Model(&entity.PacoteSemente{}).Where("genetica_id IN ?", duplicadas).Update("genetica_id", destino.ID)
+		if result.Error != nil {
+			return fmt.Errorf("falha ao mover pacotes de sementes para a genética %d: %w", destino.ID, result.Error)
+		}
+		pacotes = result.RowsAffected
+		for _, coluna := range []string{"mae_genetica_id", "pai_genetica_id", "genetica_id"} {
+			if err := tx.Model(&entity.Cruzamento{}).Where(coluna+" IN ?", duplicadas).Update(coluna, destino.ID).Error; err != nil {
+				return fmt.Errorf("falha ao mover cruzamentos para a genética %d: %w", destino.ID, err)
+			}
+		}
+		for _, coluna := range []string{"mae_id", "pai_id"} {
+			if err := tx.Model(&entity.Genetica{}).Where(coluna+" IN ?", duplicadas).Update(coluna, destino.ID).Error; err != nil {
+				return fmt.Errorf("falha ao mover genéticas filhas para a genética %d: %w", destino.ID, err)
+			}
+		}
+		result = tx.Delete(&entity.Genetica{}, duplicadas)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(duplicadas)) {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return plantas, pacotes, nil
+}
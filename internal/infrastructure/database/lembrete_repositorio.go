@@ -0,0 +1,109 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+)
+
+// LembreteRepositorio implementa a interface repository.LembreteRepositorio
+type LembreteRepositorio struct {
+	db *gorm.DB
+}
+
+// NewLembreteRepositorio cria uma nova instância do LembreteRepositorio
+func NewLembreteRepositorio(db *gorm.DB) *LembreteRepositorio {
+	return &LembreteRepositorio{db: db}
+}
+
+func (r *LembreteRepositorio) Criar(lembrete *entity.Lembrete) error {
+	if lembrete == nil {
+		return errors.New("lembrete não pode ser nulo")
+	}
+	return r.db.Create(lembrete).Error
+}
+
+func (r *LembreteRepositorio) BuscarPorID(id uint) (*entity.Lembrete, error) {
+	var lembrete entity.Lembrete
+	if err := r.db.First(&lembrete, id).Error; err != nil {
+		return nil, err
+	}
+	return &lembrete, nil
+}
+
+func (r *LembreteRepositorio) Listar(filtro repository.FiltroLembretes) ([]entity.Lembrete, int64, error) {
+	query := r.db.Model(&entity.Lembrete{})
+	if filtro.UsuarioID != 0 {
+		query = query.Where("usuario_id = ?", filtro.UsuarioID)
+	}
+	switch filtro.Status {
+	case "agendado":
+		query = query.Where("proximo_envio IS NOT NULL")
+	case "nao_lido":
+		// entregues pelo disparador ou gravados já entregues pelo canal lembrete
+		query = query.Where("lido = ? AND (ultimo_envio IS NOT NULL OR proximo_envio IS NULL)", false)
+	case "lido":
+		query = query.Where("lido = ?", true)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("falha ao contar lembretes: %w", err)
+	}
+
+	var lembretes []entity.Lembrete
+	offset := (filtro.Page - 1) * filtro.Limit
+	if err := query.Order("data_hora desc, id desc").Offset(offset).Limit(filtro.Limit).Find(&lembretes).Error; err != nil {
+		return nil, 0, fmt.Errorf("falha ao listar lembretes: %w", err)
+	}
+	return lembretes, total, nil
+}
+
+func (r *LembreteRepositorio) Atualizar(lembrete *entity.Lembrete) error {
+	if lembrete == nil {
+		return errors.New("lembrete não pode ser nulo")
+	}
+	return r.db.Save(lembrete).Error
+}
+
+func (r *LembreteRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.Lembrete{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *LembreteRepositorio) ListarAtivosPorUsuario(usuarioID uint, desde time.Time) ([]entity.Lembrete, error) {
+	var lembretes []entity.Lembrete
+	err := r.db.
+		Where("usuario_id = ? AND proximo_envio IS NOT NULL", usuarioID).
+		Where("data_hora >= ? OR repetir = ?", desde, true).
+		Order("data_hora, id").
+		Find(&lembretes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar lembretes do usuário %d: %w", usuarioID, err)
+	}
+	return lembretes, nil
+}
+
+func (r *LembreteRepositorio) ListarParaEnvio(ate time.Time, limite int) ([]entity.Lembrete, error) {
+	var lembretes []entity.Lembrete
+	err := r.db.
+		Where("proximo_envio <= ?", ate).
+		Order("proximo_envio, id").
+		Limit(limite).
+		Find(&lembretes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar lembretes para envio: %w", err)
+	}
+	return lembretes, nil
+}
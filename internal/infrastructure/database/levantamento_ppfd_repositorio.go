@@ -0,0 +1,63 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// LevantamentoPPFDRepositorio implementa a interface repository.LevantamentoPPFDRepositorio
+type LevantamentoPPFDRepositorio struct {
+	db *gorm.DB
+}
+
+// NewLevantamentoPPFDRepositorio cria uma nova instância do LevantamentoPPFDRepositorio
+func NewLevantamentoPPFDRepositorio(db *gorm.DB) *LevantamentoPPFDRepositorio {
+	return &LevantamentoPPFDRepositorio{db: db}
+}
+
+func (r *LevantamentoPPFDRepositorio) Criar(levantamento *entity.LevantamentoPPFD) error {
+	if levantamento == nil {
+		return errors.New("levantamento não pode ser nulo")
+	}
+	return r.db.Create(levantamento).Error
+}
+
+func (r *LevantamentoPPFDRepositorio) BuscarPorID(id uint) (*entity.LevantamentoPPFD, error) {
+	var levantamento entity.LevantamentoPPFD
+	if err := r.db.Preload("Pontos", ordenarPontosPPFD).First(&levantamento, id).Error; err != nil {
+		return nil, err
+	}
+	return &levantamento, nil
+}
+
+func (r *LevantamentoPPFDRepositorio) ListarPorAmbiente(ambienteID uint) ([]entity.LevantamentoPPFD, error) {
+	var levantamentos []entity.LevantamentoPPFD
+	err := r.db.Preload("Pontos", ordenarPontosPPFD).
+		Where("ambiente_id = ?", ambienteID).
+		Order("data DESC, id DESC").
+		Find(&levantamentos).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar levantamentos de PPFD do ambiente %d: %w", ambienteID, err)
+	}
+	return levantamentos, nil
+}
+
+func (r *LevantamentoPPFDRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.LevantamentoPPFD{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ordenarPontosPPFD mantém os pontos na ordem da grade: linha a linha (Y) e, em cada linha, por X
+func ordenarPontosPPFD(db *gorm.DB) *gorm.DB {
+	return db.Order("y, x, id")
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// tamanhoLoteMicroclima limita a quantidade de linhas por INSERT em ingestões em lote
+const tamanhoLoteMicroclima = 500
+
+// MicroclimaRepositorio implementa a interface repository.MicroclimaRepositorio
+type MicroclimaRepositorio struct {
+	db   *gorm.DB
+	fuso string
+}
+
+// NewMicroclimaRepositorio cria uma nova instância do MicroclimaRepositorio. As agregações
+// alinham as janelas ao fuso informado; o fuso local do processo, que o banco não conhece,
+// vira UTC.
+func NewMicroclimaRepositorio(db *gorm.DB, fuso *time.Location) *MicroclimaRepositorio {
+	nome := "UTC"
+	if fuso != nil && fuso != time.Local {
+		nome = fuso.String()
+	}
+	return &MicroclimaRepositorio{db: db, fuso: nome}
+}
+
+// Criar insere uma única leitura de microclima
+func (r *MicroclimaRepositorio) Criar(leitura *entity.Microclima) error {
+	if leitura == nil {
+		return errors.New("leitura de microclima não pode ser nula")
+	}
+	return r.db.Create(leitura).Error
+}
+
+// CriarEmLote insere várias leituras em uma única transação
+func (r *MicroclimaRepositorio) CriarEmLote(leituras []entity.Microclima) error {
+	if len(leituras) == 0 {
+		return nil
+	}
+	if err := r.db.CreateInBatches(leituras, tamanhoLoteMicroclima).Error; err != nil {
+		return fmt.Errorf("falha ao inserir leituras de microclima em lote: %w", err)
+	}
+	return nil
+}
+
+// ListarPorPeriodo retorna a série bruta de leituras de um ambiente em ordem cronológica
+func (r *MicroclimaRepositorio) ListarPorPeriodo(ambienteID uint, inicio, fim time.Time, limit int) ([]entity.Microclima, error) {
+	var leituras []entity.Microclima
+	err := r.db.
+		Where("ambiente_id = ? AND data_medicao >= ? AND data_medicao < ?", ambienteID, inicio, fim).
+		Order("data_medicao asc").
+		Limit(limit).
+		Find(&leituras).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar leituras de microclima: %w", err)
+	}
+	return leituras, nil
+}
+
+// Agregar calcula mínimo, máximo e média por janela de tempo diretamente no banco. As janelas
+// começam na hora local (ex.: dias à meia-noite do fuso, não de UTC).
+func (r *MicroclimaRepositorio) Agregar(ambienteID uint, inicio, fim time.Time, intervalo time.Duration) ([]entity.MicroclimaAgregado, error) {
+	segundos := int64(intervalo / time.Second)
+	if segundos <= 0 {
+		return nil, errors.New("intervalo de agregação inválido")
+	}
+
+	var agregados []entity.MicroclimaAgregado
+	err := r.db.Raw(`
+		SELECT
+			date_bin(make_interval(secs => ?), data_medicao AT TIME ZONE ?, TIMESTAMP '2000-01-01') AT TIME ZONE ? AS inicio,
+			count(*) AS leituras,
+			min(temperatura) AS temperatura_min,
+			max(temperatura) AS temperatura_max,
+			avg(temperatura) AS temperatura_med,
+			min(umidade) AS umidade_min,
+			max(umidade) AS umidade_max,
+			avg(umidade) AS umidade_med,
+			min(luminosidade) AS luminosidade_min,
+			max(luminosidade) AS luminosidade_max,
+			avg(luminosidade) AS luminosidade_med,
+			min(co2) AS co2_min,
+			max(co2) AS co2_max,
+			avg(co2) AS co2_med,
+			min(umidade_solo) AS umidade_solo_min,
+			max(umidade_solo) AS umidade_solo_max,
+			avg(umidade_solo) AS umidade_solo_med,
+			min(ppfd) AS ppfd_min,
+			max(ppfd) AS ppfd_max,
+			avg(ppfd) AS ppfd_med
+		FROM micro_climas
+		WHERE ambiente_id = ? AND data_medicao >= ? AND data_medicao < ? AND deleted_at IS NULL
+		GROUP BY 1
+		ORDER BY 1`,
+		segundos, r.fuso, r.fuso, ambienteID, inicio, fim,
+	).Scan(&agregados).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao agregar leituras de microclima: %w", err)
+	}
+	return agregados, nil
+}
@@ -0,0 +1,4 @@
+-- 000004_microclima_sensores.down.sql
+DROP INDEX IF EXISTS idx_micro_climas_ambiente_data;
+ALTER TABLE micro_climas DROP COLUMN IF EXISTS umidade_solo;
+ALTER TABLE micro_climas DROP COLUMN IF EXISTS co2;
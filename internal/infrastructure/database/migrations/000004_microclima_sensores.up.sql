@@ -0,0 +1,8 @@
+-- 000004_microclima_sensores.up.sql
+
+-- Novas métricas de sensores
+ALTER TABLE micro_climas ADD COLUMN IF NOT EXISTS co2 NUMERIC;
+ALTER TABLE micro_climas ADD COLUMN IF NOT EXISTS umidade_solo NUMERIC;
+
+-- Consultas por ambiente e intervalo de tempo são o caminho principal de leitura
+CREATE INDEX IF NOT EXISTS idx_micro_climas_ambiente_data ON micro_climas(ambiente_id, data_medicao);
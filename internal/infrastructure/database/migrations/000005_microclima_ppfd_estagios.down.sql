@@ -0,0 +1,3 @@
+-- 000005_microclima_ppfd_estagios.down.sql
+DROP INDEX IF EXISTS idx_estagio_crescimentos_planta_aberto;
+ALTER TABLE micro_climas DROP COLUMN IF EXISTS ppfd;
@@ -0,0 +1,7 @@
+-- 000005_microclima_ppfd_estagios.up.sql
+
+-- Leituras de PPFD medidas diretamente por sensores quânticos
+ALTER TABLE micro_climas ADD COLUMN IF NOT EXISTS ppfd NUMERIC;
+
+-- Busca do estágio em aberto de cada planta
+CREATE INDEX IF NOT EXISTS idx_estagio_crescimentos_planta_aberto ON estagio_crescimentos(planta_id) WHERE data_fim IS NULL;
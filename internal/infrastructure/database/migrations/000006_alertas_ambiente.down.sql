@@ -0,0 +1,4 @@
+-- 000006_alertas_ambiente.down.sql
+DROP TABLE IF EXISTS alertas;
+DROP TABLE IF EXISTS regra_alertas;
+DROP TABLE IF EXISTS lembretes;
@@ -0,0 +1,65 @@
+-- 000006_alertas_ambiente.up.sql
+
+-- Cria a tabela lembretes (entity.Lembrete)
+CREATE TABLE IF NOT EXISTS lembretes (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER REFERENCES usuarios(id) ON DELETE CASCADE,
+    mensagem TEXT NOT NULL,
+    data_hora TIMESTAMP WITH TIME ZONE,
+    repetir BOOLEAN DEFAULT FALSE,
+    frequencia VARCHAR(20),
+    lido BOOLEAN DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS idx_lembretes_usuario_id ON lembretes(usuario_id);
+
+-- Cria a tabela regra_alertas
+CREATE TABLE IF NOT EXISTS regra_alertas (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    ambiente_id INTEGER NOT NULL REFERENCES ambientes(id) ON DELETE CASCADE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    nome VARCHAR(100) NOT NULL,
+    metrica VARCHAR(30) NOT NULL,
+    comparacao VARCHAR(20) NOT NULL,
+    limite NUMERIC NOT NULL,
+    duracao_minutos INTEGER NOT NULL DEFAULT 0,
+    hora_inicio VARCHAR(5),
+    hora_fim VARCHAR(5),
+    estagios VARCHAR(200),
+    canais VARCHAR(100),
+    webhook_url VARCHAR(500),
+    email VARCHAR(100),
+    ativa BOOLEAN NOT NULL DEFAULT TRUE,
+    condicao_desde TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_regra_alertas_ambiente_id ON regra_alertas(ambiente_id);
+
+-- Cria a tabela alertas
+CREATE TABLE IF NOT EXISTS alertas (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    regra_alerta_id INTEGER NOT NULL REFERENCES regra_alertas(id) ON DELETE CASCADE,
+    ambiente_id INTEGER NOT NULL REFERENCES ambientes(id) ON DELETE CASCADE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    metrica VARCHAR(30) NOT NULL,
+    mensagem TEXT,
+    valor_inicial NUMERIC,
+    valor_pico NUMERIC,
+    ultimo_valor NUMERIC,
+    iniciado_em TIMESTAMP WITH TIME ZONE NOT NULL,
+    ultima_leitura TIMESTAMP WITH TIME ZONE,
+    reconhecido_em TIMESTAMP WITH TIME ZONE,
+    resolvido_em TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_alertas_regra_alerta_id ON alertas(regra_alerta_id);
+CREATE INDEX IF NOT EXISTS idx_alertas_usuario_status ON alertas(usuario_id, status);
+-- Garante a deduplicação: no máximo um alerta ativo por regra
+CREATE UNIQUE INDEX IF NOT EXISTS idx_alertas_regra_ativo ON alertas(regra_alerta_id) WHERE status <> 'resolvido' AND deleted_at IS NULL;
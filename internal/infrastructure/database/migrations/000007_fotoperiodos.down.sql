@@ -0,0 +1,2 @@
+-- 000007_fotoperiodos.down.sql
+DROP TABLE IF EXISTS fotoperiodos;
@@ -0,0 +1,16 @@
+-- 000007_fotoperiodos.up.sql
+
+-- Cria a tabela fotoperiodos (programações de luz por ambiente, com histórico)
+CREATE TABLE IF NOT EXISTS fotoperiodos (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    ambiente_id INTEGER NOT NULL REFERENCES ambientes(id) ON DELETE CASCADE,
+    nome VARCHAR(100),
+    hora_ligar VARCHAR(5) NOT NULL,
+    hora_desligar VARCHAR(5) NOT NULL,
+    rampa_minutos INTEGER NOT NULL DEFAULT 0,
+    vigente_desde TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_fotoperiodos_ambiente_vigencia ON fotoperiodos(ambiente_id, vigente_desde);
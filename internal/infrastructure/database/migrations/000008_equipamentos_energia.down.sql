@@ -0,0 +1,5 @@
+-- 000008_equipamentos_energia.down.sql
+ALTER TABLE plantas DROP COLUMN IF EXISTS peso_seco_gramas;
+DROP TABLE IF EXISTS bandeira_mensals;
+DROP TABLE IF EXISTS tarifa_energias;
+DROP TABLE IF EXISTS equipamentos;
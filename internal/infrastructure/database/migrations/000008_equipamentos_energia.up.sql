@@ -0,0 +1,51 @@
+-- 000008_equipamentos_energia.up.sql
+
+-- Cria a tabela equipamentos (consumidores de energia por ambiente)
+CREATE TABLE IF NOT EXISTS equipamentos (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    ambiente_id INTEGER NOT NULL REFERENCES ambientes(id) ON DELETE CASCADE,
+    nome VARCHAR(100) NOT NULL,
+    tipo VARCHAR(30) NOT NULL,
+    potencia_watts NUMERIC NOT NULL,
+    quantidade INTEGER NOT NULL DEFAULT 1,
+    modo VARCHAR(20) NOT NULL,
+    horas_dia NUMERIC,
+    ciclo_percentual NUMERIC,
+    instalado_em TIMESTAMP WITH TIME ZONE,
+    removido_em TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_equipamentos_ambiente_id ON equipamentos(ambiente_id);
+
+-- Cria a tabela tarifa_energias
+CREATE TABLE IF NOT EXISTS tarifa_energias (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    nome VARCHAR(100) NOT NULL,
+    valor_kwh NUMERIC NOT NULL,
+    adicional_amarela NUMERIC NOT NULL DEFAULT 0,
+    adicional_vermelha1 NUMERIC NOT NULL DEFAULT 0,
+    adicional_vermelha2 NUMERIC NOT NULL DEFAULT 0,
+    vigente_desde TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tarifa_energias_usuario_id ON tarifa_energias(usuario_id);
+
+-- Cria a tabela bandeira_mensals (bandeira tarifária por mês)
+CREATE TABLE IF NOT EXISTS bandeira_mensals (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    competencia VARCHAR(7) NOT NULL,
+    bandeira VARCHAR(20) NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bandeira_usuario_competencia ON bandeira_mensals(usuario_id, competencia);
+
+-- Peso seco colhido por planta, usado no custo por grama
+ALTER TABLE plantas ADD COLUMN IF NOT EXISTS peso_seco_gramas NUMERIC;
@@ -0,0 +1,4 @@
+-- 000009_clima_externo.down.sql
+DROP TABLE IF EXISTS clima_registros;
+ALTER TABLE ambientes DROP COLUMN IF EXISTS longitude;
+ALTER TABLE ambientes DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,23 @@
+-- 000009_clima_externo.up.sql
+
+-- Coordenadas dos ambientes externos (arredondadas pela aplicação)
+ALTER TABLE ambientes ADD COLUMN IF NOT EXISTS latitude NUMERIC;
+ALTER TABLE ambientes ADD COLUMN IF NOT EXISTS longitude NUMERIC;
+
+-- Cria a tabela clima_registros (resumo diário do tempo no local do ambiente)
+CREATE TABLE IF NOT EXISTS clima_registros (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    ambiente_id INTEGER NOT NULL REFERENCES ambientes(id) ON DELETE CASCADE,
+    data DATE NOT NULL,
+    temperatura NUMERIC,
+    temperatura_min NUMERIC,
+    temperatura_max NUMERIC,
+    umidade NUMERIC,
+    precipitacao NUMERIC,
+    radiacao_solar NUMERIC,
+    fonte VARCHAR(50)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_clima_ambiente_data ON clima_registros(ambiente_id, data);
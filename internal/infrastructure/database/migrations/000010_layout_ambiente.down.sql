@@ -0,0 +1,4 @@
+-- 000010_layout_ambiente.down.sql
+ALTER TABLE micro_climas DROP COLUMN IF EXISTS posicao_y;
+ALTER TABLE micro_climas DROP COLUMN IF EXISTS posicao_x;
+DROP TABLE IF EXISTS posicao_layouts;
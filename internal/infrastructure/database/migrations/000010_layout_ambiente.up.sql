@@ -0,0 +1,24 @@
+-- 000010_layout_ambiente.up.sql
+
+-- Cria a tabela posicao_layouts (plantas e vasos na planta baixa do ambiente)
+CREATE TABLE IF NOT EXISTS posicao_layouts (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    ambiente_id INTEGER NOT NULL REFERENCES ambientes(id) ON DELETE CASCADE,
+    planta_id INTEGER REFERENCES plantas(id) ON DELETE CASCADE,
+    vaso_id INTEGER REFERENCES vasos(id) ON DELETE SET NULL,
+    rotulo VARCHAR(50),
+    x NUMERIC NOT NULL,
+    y NUMERIC NOT NULL,
+    formato VARCHAR(20) NOT NULL,
+    largura_cm NUMERIC NOT NULL,
+    profundidade_cm NUMERIC NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_posicao_layouts_ambiente_id ON posicao_layouts(ambiente_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_posicao_layouts_planta ON posicao_layouts(planta_id) WHERE planta_id IS NOT NULL AND deleted_at IS NULL;
+
+-- Posição (cm) opcional das leituras de microclima, para levantamentos de PPFD e temperatura
+ALTER TABLE micro_climas ADD COLUMN IF NOT EXISTS posicao_x NUMERIC;
+ALTER TABLE micro_climas ADD COLUMN IF NOT EXISTS posicao_y NUMERIC;
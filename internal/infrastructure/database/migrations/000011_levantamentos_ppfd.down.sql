@@ -0,0 +1,3 @@
+-- 000011_levantamentos_ppfd.down.sql
+DROP TABLE IF EXISTS ponto_ppfds;
+DROP TABLE IF EXISTS levantamento_ppfds;
@@ -0,0 +1,28 @@
+-- 000011_levantamentos_ppfd.up.sql
+
+-- Cria a tabela levantamento_ppfds (mapeamentos de luz dos ambientes)
+CREATE TABLE IF NOT EXISTS levantamento_ppfds (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    ambiente_id INTEGER NOT NULL REFERENCES ambientes(id) ON DELETE CASCADE,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    altura_luminaria_cm NUMERIC NOT NULL,
+    potencia_percentual NUMERIC,
+    observacoes TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_levantamento_ppfds_ambiente_id ON levantamento_ppfds(ambiente_id);
+
+-- Cria a tabela ponto_ppfds (medições de cada levantamento)
+CREATE TABLE IF NOT EXISTS ponto_ppfds (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    levantamento_id INTEGER NOT NULL REFERENCES levantamento_ppfds(id) ON DELETE CASCADE,
+    x NUMERIC NOT NULL,
+    y NUMERIC NOT NULL,
+    ppfd NUMERIC NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ponto_ppfds_levantamento_id ON ponto_ppfds(levantamento_id);
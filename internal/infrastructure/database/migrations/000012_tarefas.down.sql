@@ -0,0 +1,6 @@
+-- 000012_tarefas.down.sql
+DROP INDEX IF EXISTS idx_fotos_owner;
+DROP INDEX IF EXISTS idx_tarefas_status_data;
+DROP INDEX IF EXISTS idx_tarefas_usuario_data;
+ALTER TABLE tarefas DROP COLUMN IF EXISTS tarefa_anterior_id;
+ALTER TABLE tarefas DROP COLUMN IF EXISTS notas_conclusao;
@@ -0,0 +1,9 @@
+-- 000012_tarefas.up.sql
+
+-- Notas da conclusão e encadeamento das ocorrências de tarefas recorrentes
+ALTER TABLE tarefas ADD COLUMN IF NOT EXISTS notas_conclusao TEXT;
+ALTER TABLE tarefas ADD COLUMN IF NOT EXISTS tarefa_anterior_id INTEGER REFERENCES tarefas(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tarefas_usuario_data ON tarefas(usuario_id, data_agendada);
+CREATE INDEX IF NOT EXISTS idx_tarefas_status_data ON tarefas(status, data_agendada);
+CREATE INDEX IF NOT EXISTS idx_fotos_owner ON fotos(owner_type, owner_id);
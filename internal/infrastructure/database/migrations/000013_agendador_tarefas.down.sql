@@ -0,0 +1,4 @@
+-- 000013_agendador_tarefas.down.sql
+DROP INDEX IF EXISTS idx_tarefas_lembrete_pendente;
+DROP INDEX IF EXISTS idx_tarefas_anterior;
+ALTER TABLE tarefas DROP COLUMN IF EXISTS lembrete_enviado_em;
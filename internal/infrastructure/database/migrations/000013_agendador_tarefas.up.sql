@@ -0,0 +1,8 @@
+-- 000013_agendador_tarefas.up.sql
+
+-- Controle de lembretes enviados pelo agendador
+ALTER TABLE tarefas ADD COLUMN IF NOT EXISTS lembrete_enviado_em TIMESTAMP WITH TIME ZONE;
+
+-- Cada ocorrência tem no máximo uma sucessora, mesmo com várias réplicas gerando a série
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tarefas_anterior ON tarefas(tarefa_anterior_id) WHERE tarefa_anterior_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tarefas_lembrete_pendente ON tarefas(data_agendada) WHERE lembrete_enviado_em IS NULL AND status <> 'concluida';
@@ -0,0 +1,13 @@
+-- 000014_cronogramas_cultivo.down.sql
+DROP INDEX IF EXISTS idx_tarefas_aplicacao_cronograma_id;
+ALTER TABLE tarefas DROP COLUMN IF EXISTS aplicacao_cronograma_id;
+ALTER TABLE tarefas DROP COLUMN IF EXISTS tarefa_template_id;
+DROP TABLE IF EXISTS aplicacao_cronogramas;
+DROP INDEX IF EXISTS idx_tarefa_templates_cronograma_id;
+ALTER TABLE tarefa_templates DROP COLUMN IF EXISTS prioridade;
+ALTER TABLE tarefa_templates DROP COLUMN IF EXISTS ordem;
+ALTER TABLE tarefa_templates DROP COLUMN IF EXISTS dia_fim;
+ALTER TABLE tarefa_templates DROP COLUMN IF EXISTS dia_inicio;
+ALTER TABLE tarefa_templates DROP COLUMN IF EXISTS estagio;
+ALTER TABLE tarefa_templates DROP COLUMN IF EXISTS cronograma_id;
+DROP TABLE IF EXISTS cronograma_cultivos;
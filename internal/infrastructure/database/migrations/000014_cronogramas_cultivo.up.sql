@@ -0,0 +1,41 @@
+-- 000014_cronogramas_cultivo.up.sql
+
+-- Cria a tabela cronograma_cultivos (conjuntos reutilizáveis de tarefas por estágio)
+CREATE TABLE IF NOT EXISTS cronograma_cultivos (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    nome VARCHAR(100) NOT NULL,
+    descricao TEXT,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_cronograma_cultivos_usuario_id ON cronograma_cultivos(usuario_id);
+
+-- Itens do cronograma: estágio e dia de início, repetição e ordem
+ALTER TABLE tarefa_templates ADD COLUMN IF NOT EXISTS cronograma_id INTEGER REFERENCES cronograma_cultivos(id) ON DELETE CASCADE;
+ALTER TABLE tarefa_templates ADD COLUMN IF NOT EXISTS estagio VARCHAR(100);
+ALTER TABLE tarefa_templates ADD COLUMN IF NOT EXISTS dia_inicio INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tarefa_templates ADD COLUMN IF NOT EXISTS dia_fim INTEGER;
+ALTER TABLE tarefa_templates ADD COLUMN IF NOT EXISTS ordem INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tarefa_templates ADD COLUMN IF NOT EXISTS prioridade VARCHAR(20);
+CREATE INDEX IF NOT EXISTS idx_tarefa_templates_cronograma_id ON tarefa_templates(cronograma_id);
+
+-- Cria a tabela aplicacao_cronogramas (cronogramas aplicados às plantas)
+CREATE TABLE IF NOT EXISTS aplicacao_cronogramas (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    cronograma_id INTEGER NOT NULL REFERENCES cronograma_cultivos(id) ON DELETE CASCADE,
+    planta_id INTEGER NOT NULL REFERENCES plantas(id) ON DELETE CASCADE,
+    diario_cultivo_id INTEGER REFERENCES diario_cultivos(id) ON DELETE SET NULL,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_aplicacao_cronogramas_planta ON aplicacao_cronogramas(cronograma_id, planta_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_aplicacao_cronogramas_planta_id ON aplicacao_cronogramas(planta_id);
+
+-- Origem das tarefas geradas pelos cronogramas
+ALTER TABLE tarefas ADD COLUMN IF NOT EXISTS tarefa_template_id INTEGER REFERENCES tarefa_templates(id) ON DELETE SET NULL;
+ALTER TABLE tarefas ADD COLUMN IF NOT EXISTS aplicacao_cronograma_id INTEGER REFERENCES aplicacao_cronogramas(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_tarefas_aplicacao_cronograma_id ON tarefas(aplicacao_cronograma_id);
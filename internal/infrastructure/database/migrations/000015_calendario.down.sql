@@ -0,0 +1,3 @@
+-- 000015_calendario.down.sql
+DROP INDEX IF EXISTS idx_usuarios_token_calendario;
+ALTER TABLE usuarios DROP COLUMN IF EXISTS token_calendario_hash;
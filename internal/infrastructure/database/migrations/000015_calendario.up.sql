@@ -0,0 +1,5 @@
+-- 000015_calendario.up.sql
+
+-- Token secreto do feed iCalendar; só o hash SHA-256 é guardado
+ALTER TABLE usuarios ADD COLUMN IF NOT EXISTS token_calendario_hash VARCHAR(64);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_usuarios_token_calendario ON usuarios(token_calendario_hash) WHERE token_calendario_hash IS NOT NULL;
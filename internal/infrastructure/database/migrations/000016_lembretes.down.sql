@@ -0,0 +1,6 @@
+-- 000016_lembretes.down.sql
+DROP TABLE IF EXISTS preferencias_notificacao;
+DROP INDEX IF EXISTS idx_lembretes_proximo_envio;
+ALTER TABLE lembretes DROP COLUMN IF EXISTS ultimo_envio;
+ALTER TABLE lembretes DROP COLUMN IF EXISTS proximo_envio;
+ALTER TABLE lembretes DROP COLUMN IF EXISTS canais;
@@ -0,0 +1,22 @@
+-- 000016_lembretes.up.sql
+
+-- Agenda de entrega dos lembretes; as notificações já gravadas ficam sem próximo envio
+ALTER TABLE lembretes ADD COLUMN IF NOT EXISTS canais VARCHAR(100);
+ALTER TABLE lembretes ADD COLUMN IF NOT EXISTS proximo_envio TIMESTAMP WITH TIME ZONE;
+ALTER TABLE lembretes ADD COLUMN IF NOT EXISTS ultimo_envio TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_lembretes_proximo_envio ON lembretes(proximo_envio) WHERE proximo_envio IS NOT NULL AND deleted_at IS NULL;
+
+-- Destinos dos canais externos e horário de silêncio por usuário
+CREATE TABLE IF NOT EXISTS preferencias_notificacao (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    email VARCHAR(100),
+    webhook_url VARCHAR(500),
+    telegram_chat_id VARCHAR(50),
+    silencio_inicio VARCHAR(5),
+    silencio_fim VARCHAR(5)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_preferencias_notificacao_usuario ON preferencias_notificacao(usuario_id) WHERE deleted_at IS NULL;
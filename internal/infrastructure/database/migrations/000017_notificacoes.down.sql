@@ -0,0 +1,3 @@
+-- 000017_notificacoes.down.sql
+ALTER TABLE preferencias_notificacao DROP COLUMN IF EXISTS categorias_desativadas;
+DROP TABLE IF EXISTS notificacoes;
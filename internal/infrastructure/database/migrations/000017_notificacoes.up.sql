@@ -0,0 +1,24 @@
+-- 000017_notificacoes.up.sql
+
+-- Central de notificações do usuário, com links para a planta, o diário ou o ambiente relacionado
+CREATE TABLE IF NOT EXISTS notificacoes (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    categoria VARCHAR(20) NOT NULL,
+    titulo VARCHAR(200) NOT NULL,
+    mensagem TEXT,
+    planta_id INTEGER REFERENCES plantas(id) ON DELETE SET NULL,
+    diario_cultivo_id INTEGER REFERENCES diario_cultivos(id) ON DELETE SET NULL,
+    ambiente_id INTEGER REFERENCES ambientes(id) ON DELETE SET NULL,
+    link VARCHAR(200),
+    lida BOOLEAN NOT NULL DEFAULT FALSE,
+    lida_em TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_notificacoes_usuario ON notificacoes(usuario_id, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_notificacoes_nao_lidas ON notificacoes(usuario_id) WHERE lida = FALSE AND deleted_at IS NULL;
+
+-- Categorias desativadas pelo usuário, separadas por vírgula
+ALTER TABLE preferencias_notificacao ADD COLUMN IF NOT EXISTS categorias_desativadas VARCHAR(200);
@@ -0,0 +1,5 @@
+-- 000018_regas.down.sql
+DROP TABLE IF EXISTS nutrientes_rega;
+DROP TABLE IF EXISTS regas;
+DROP TABLE IF EXISTS itens_receita_nutriente;
+DROP TABLE IF EXISTS receitas_nutriente;
@@ -0,0 +1,65 @@
+-- 000018_regas.up.sql
+
+-- Receitas de nutrientes reutilizáveis, com as doses por litro de solução
+CREATE TABLE IF NOT EXISTS receitas_nutriente (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    nome VARCHAR(100) NOT NULL,
+    descricao TEXT,
+    ec_alvo DOUBLE PRECISION,
+    ph_alvo DOUBLE PRECISION
+);
+CREATE INDEX IF NOT EXISTS idx_receitas_nutriente_usuario ON receitas_nutriente(usuario_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS itens_receita_nutriente (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    receita_id INTEGER NOT NULL REFERENCES receitas_nutriente(id) ON DELETE CASCADE,
+    nutriente VARCHAR(100) NOT NULL,
+    dose DOUBLE PRECISION NOT NULL,
+    unidade VARCHAR(10) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_itens_receita_nutriente_receita ON itens_receita_nutriente(receita_id) WHERE deleted_at IS NULL;
+
+-- Regas de uma planta ou do lote de plantas de um diário de cultivo
+CREATE TABLE IF NOT EXISTS regas (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    planta_id INTEGER REFERENCES plantas(id) ON DELETE CASCADE,
+    diario_cultivo_id INTEGER REFERENCES diario_cultivos(id) ON DELETE CASCADE,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    volume_litros DOUBLE PRECISION NOT NULL,
+    ph_entrada DOUBLE PRECISION,
+    ec_entrada DOUBLE PRECISION,
+    ppm_entrada DOUBLE PRECISION,
+    escala_ppm INTEGER,
+    ph_runoff DOUBLE PRECISION,
+    ec_runoff DOUBLE PRECISION,
+    temperatura_agua DOUBLE PRECISION,
+    receita_id INTEGER REFERENCES receitas_nutriente(id) ON DELETE SET NULL,
+    observacoes TEXT,
+    CONSTRAINT chk_regas_alvo CHECK ((planta_id IS NULL) <> (diario_cultivo_id IS NULL))
+);
+CREATE INDEX IF NOT EXISTS idx_regas_planta ON regas(planta_id, data) WHERE planta_id IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_regas_diario_cultivo ON regas(diario_cultivo_id, data) WHERE diario_cultivo_id IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_regas_usuario ON regas(usuario_id, data) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS nutrientes_rega (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    rega_id INTEGER NOT NULL REFERENCES regas(id) ON DELETE CASCADE,
+    nutriente VARCHAR(100) NOT NULL,
+    dose DOUBLE PRECISION NOT NULL,
+    unidade VARCHAR(10) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_nutrientes_rega_rega ON nutrientes_rega(rega_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- 000019_tabelas_nutricao.down.sql
+DROP TABLE IF EXISTS itens_tabela_nutricao;
+DROP TABLE IF EXISTS tabelas_nutricao;
@@ -0,0 +1,30 @@
+-- 000019_tabelas_nutricao.up.sql
+
+-- Tabelas de alimentação das linhas de nutrientes, com as doses por estágio e semana
+CREATE TABLE IF NOT EXISTS tabelas_nutricao (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    nome VARCHAR(100) NOT NULL,
+    fabricante VARCHAR(100),
+    descricao TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_tabelas_nutricao_usuario ON tabelas_nutricao(usuario_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS itens_tabela_nutricao (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    tabela_id INTEGER NOT NULL REFERENCES tabelas_nutricao(id) ON DELETE CASCADE,
+    estagio VARCHAR(20) NOT NULL,
+    semana INTEGER NOT NULL CHECK (semana > 0),
+    produto VARCHAR(100) NOT NULL,
+    dose DOUBLE PRECISION NOT NULL,
+    unidade VARCHAR(10) NOT NULL,
+    ec_alvo DOUBLE PRECISION,
+    ph_alvo DOUBLE PRECISION
+);
+CREATE INDEX IF NOT EXISTS idx_itens_tabela_nutricao_tabela ON itens_tabela_nutricao(tabela_id, estagio, semana) WHERE deleted_at IS NULL;
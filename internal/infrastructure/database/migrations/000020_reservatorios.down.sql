@@ -0,0 +1,7 @@
+-- 000020_reservatorios.down.sql
+DROP INDEX IF EXISTS idx_tarefas_reservatorio_id;
+ALTER TABLE tarefas DROP COLUMN IF EXISTS reservatorio_id;
+DROP TABLE IF EXISTS leituras_reservatorio;
+DROP TABLE IF EXISTS manutencoes_reservatorio;
+DROP TABLE IF EXISTS reservatorio_plantas;
+DROP TABLE IF EXISTS reservatorios;
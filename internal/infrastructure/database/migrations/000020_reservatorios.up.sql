@@ -0,0 +1,57 @@
+-- 000020_reservatorios.up.sql
+
+-- Reservatórios de solução nutritiva dos sistemas hidropônicos e aeropônicos
+CREATE TABLE IF NOT EXISTS reservatorios (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    nome VARCHAR(100) NOT NULL,
+    volume_litros DOUBLE PRECISION NOT NULL,
+    ambiente_id INTEGER REFERENCES ambientes(id) ON DELETE SET NULL,
+    intervalo_troca_dias INTEGER NOT NULL DEFAULT 0,
+    ultima_troca TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_reservatorios_usuario ON reservatorios(usuario_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_reservatorios_troca ON reservatorios(intervalo_troca_dias) WHERE intervalo_troca_dias > 0 AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS reservatorio_plantas (
+    reservatorio_id INTEGER NOT NULL REFERENCES reservatorios(id) ON DELETE CASCADE,
+    planta_id INTEGER NOT NULL REFERENCES plantas(id) ON DELETE CASCADE,
+    PRIMARY KEY (reservatorio_id, planta_id)
+);
+
+CREATE TABLE IF NOT EXISTS manutencoes_reservatorio (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    reservatorio_id INTEGER NOT NULL REFERENCES reservatorios(id) ON DELETE CASCADE,
+    tipo VARCHAR(20) NOT NULL,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    volume_litros DOUBLE PRECISION NOT NULL,
+    ec DOUBLE PRECISION,
+    ph DOUBLE PRECISION,
+    observacoes TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_manutencoes_reservatorio ON manutencoes_reservatorio(reservatorio_id, data) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS leituras_reservatorio (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    reservatorio_id INTEGER NOT NULL REFERENCES reservatorios(id) ON DELETE CASCADE,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    ec DOUBLE PRECISION,
+    ph DOUBLE PRECISION,
+    temperatura_agua DOUBLE PRECISION,
+    oxigenio_dissolvido DOUBLE PRECISION,
+    nivel_litros DOUBLE PRECISION
+);
+CREATE INDEX IF NOT EXISTS idx_leituras_reservatorio ON leituras_reservatorio(reservatorio_id, data) WHERE deleted_at IS NULL;
+
+-- Tarefas de troca de solução geradas pelo agendador
+ALTER TABLE tarefas ADD COLUMN IF NOT EXISTS reservatorio_id INTEGER REFERENCES reservatorios(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_tarefas_reservatorio_id ON tarefas(reservatorio_id);
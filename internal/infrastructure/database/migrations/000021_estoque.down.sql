@@ -0,0 +1,3 @@
+-- 000021_estoque.down.sql
+DROP TABLE IF EXISTS movimentacoes_estoque;
+DROP TABLE IF EXISTS itens_estoque;
@@ -0,0 +1,43 @@
+-- 000021_estoque.up.sql
+
+-- Inventário de insumos: nutrientes, substratos, vasos, defensivos e sementes
+CREATE TABLE IF NOT EXISTS itens_estoque (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    nome VARCHAR(100) NOT NULL,
+    categoria VARCHAR(20) NOT NULL,
+    fabricante VARCHAR(100),
+    unidade VARCHAR(5) NOT NULL,
+    quantidade DOUBLE PRECISION NOT NULL DEFAULT 0,
+    estoque_minimo DOUBLE PRECISION,
+    custo_medio DOUBLE PRECISION NOT NULL DEFAULT 0,
+    validade TIMESTAMP WITH TIME ZONE,
+    observacoes TEXT,
+    alerta_estoque_em TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_itens_estoque_usuario ON itens_estoque(usuario_id, categoria) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS movimentacoes_estoque (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    item_id INTEGER NOT NULL REFERENCES itens_estoque(id) ON DELETE CASCADE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    tipo VARCHAR(20) NOT NULL,
+    origem VARCHAR(20) NOT NULL,
+    origem_id INTEGER,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    quantidade DOUBLE PRECISION NOT NULL,
+    custo_total DOUBLE PRECISION NOT NULL DEFAULT 0,
+    planta_id INTEGER REFERENCES plantas(id) ON DELETE SET NULL,
+    diario_cultivo_id INTEGER REFERENCES diario_cultivos(id) ON DELETE SET NULL,
+    fornecedor VARCHAR(100),
+    observacoes TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_movimentacoes_estoque_item ON movimentacoes_estoque(item_id, data) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_movimentacoes_estoque_diario ON movimentacoes_estoque(diario_cultivo_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_movimentacoes_estoque_planta ON movimentacoes_estoque(planta_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- 000022_banco_sementes.down.sql
+DROP INDEX IF EXISTS idx_plantas_pacote_semente;
+ALTER TABLE plantas DROP COLUMN IF EXISTS pacote_semente_id;
+DROP TABLE IF EXISTS germinacoes_sementes;
+DROP TABLE IF EXISTS pacotes_sementes;
@@ -0,0 +1,35 @@
+-- 000022_banco_sementes.up.sql
+
+-- Banco de sementes: pacotes por genética e resultados de germinação
+CREATE TABLE IF NOT EXISTS pacotes_sementes (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    genetica_id INTEGER NOT NULL REFERENCES geneticas(id) ON DELETE CASCADE,
+    breeder VARCHAR(100) NOT NULL,
+    quantidade_inicial INTEGER NOT NULL,
+    quantidade_restante INTEGER NOT NULL CHECK (quantidade_restante >= 0),
+    data_aquisicao TIMESTAMP WITH TIME ZONE,
+    local_armazenamento VARCHAR(100),
+    notas_viabilidade TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_pacotes_sementes_usuario ON pacotes_sementes(usuario_id, genetica_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS germinacoes_sementes (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    pacote_id INTEGER NOT NULL REFERENCES pacotes_sementes(id) ON DELETE CASCADE,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    semeadas INTEGER NOT NULL,
+    germinadas INTEGER NOT NULL,
+    metodo VARCHAR(30),
+    observacoes TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_germinacoes_sementes_pacote ON germinacoes_sementes(pacote_id, data) WHERE deleted_at IS NULL;
+
+ALTER TABLE plantas ADD COLUMN IF NOT EXISTS pacote_semente_id INTEGER REFERENCES pacotes_sementes(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_plantas_pacote_semente ON plantas(pacote_semente_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- 000023_vasos_transplantes.down.sql
+DROP TABLE IF EXISTS transplantes;
+ALTER TABLE plantas DROP COLUMN IF EXISTS substrato_id;
+ALTER TABLE plantas DROP COLUMN IF EXISTS vaso_id;
@@ -0,0 +1,22 @@
+-- 000023_vasos_transplantes.up.sql
+
+-- Recipiente atual da planta: vaso e substrato
+ALTER TABLE plantas ADD COLUMN IF NOT EXISTS vaso_id INTEGER REFERENCES vasos(id) ON DELETE SET NULL;
+ALTER TABLE plantas ADD COLUMN IF NOT EXISTS substrato_id INTEGER REFERENCES substratos(id) ON DELETE SET NULL;
+
+-- Histórico de transplantes com o recipiente anterior e o novo
+CREATE TABLE IF NOT EXISTS transplantes (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    planta_id INTEGER NOT NULL REFERENCES plantas(id) ON DELETE CASCADE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    vaso_anterior_id INTEGER REFERENCES vasos(id) ON DELETE SET NULL,
+    vaso_novo_id INTEGER REFERENCES vasos(id) ON DELETE SET NULL,
+    substrato_anterior_id INTEGER REFERENCES substratos(id) ON DELETE SET NULL,
+    substrato_novo_id INTEGER REFERENCES substratos(id) ON DELETE SET NULL,
+    observacoes TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_transplantes_planta ON transplantes(planta_id, data) WHERE deleted_at IS NULL;
@@ -0,0 +1,11 @@
+-- 000024_cruzamentos.down.sql
+DROP INDEX IF EXISTS idx_pacotes_sementes_cruzamento;
+ALTER TABLE pacotes_sementes DROP COLUMN IF EXISTS polinizacao_id;
+ALTER TABLE pacotes_sementes DROP COLUMN IF EXISTS cruzamento_id;
+DROP TABLE IF EXISTS polinizacoes;
+DROP TABLE IF EXISTS cruzamentos;
+DROP INDEX IF EXISTS idx_geneticas_pai;
+DROP INDEX IF EXISTS idx_geneticas_mae;
+ALTER TABLE geneticas DROP COLUMN IF EXISTS geracao;
+ALTER TABLE geneticas DROP COLUMN IF EXISTS pai_id;
+ALTER TABLE geneticas DROP COLUMN IF EXISTS mae_id;
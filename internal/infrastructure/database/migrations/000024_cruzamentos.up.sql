@@ -0,0 +1,43 @@
+-- 000024_cruzamentos.up.sql
+
+-- Linhagem das genéticas: genéticas de origem e geração filial
+ALTER TABLE geneticas ADD COLUMN IF NOT EXISTS mae_id INTEGER REFERENCES geneticas(id) ON DELETE SET NULL;
+ALTER TABLE geneticas ADD COLUMN IF NOT EXISTS pai_id INTEGER REFERENCES geneticas(id) ON DELETE SET NULL;
+ALTER TABLE geneticas ADD COLUMN IF NOT EXISTS geracao VARCHAR(10);
+CREATE INDEX IF NOT EXISTS idx_geneticas_mae ON geneticas(mae_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_geneticas_pai ON geneticas(pai_id) WHERE deleted_at IS NULL;
+
+-- Cruzamentos do usuário e as polinizações feitas em cada planta mãe
+CREATE TABLE IF NOT EXISTS cruzamentos (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    nome VARCHAR(100) NOT NULL,
+    mae_genetica_id INTEGER NOT NULL REFERENCES geneticas(id) ON DELETE CASCADE,
+    pai_genetica_id INTEGER NOT NULL REFERENCES geneticas(id) ON DELETE CASCADE,
+    genetica_id INTEGER NOT NULL REFERENCES geneticas(id) ON DELETE CASCADE,
+    geracao VARCHAR(10) NOT NULL,
+    objetivo TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_cruzamentos_usuario ON cruzamentos(usuario_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS polinizacoes (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    cruzamento_id INTEGER NOT NULL REFERENCES cruzamentos(id) ON DELETE CASCADE,
+    planta_mae_id INTEGER NOT NULL REFERENCES plantas(id) ON DELETE CASCADE,
+    planta_doadora_id INTEGER REFERENCES plantas(id) ON DELETE SET NULL,
+    data TIMESTAMP WITH TIME ZONE NOT NULL,
+    metodo VARCHAR(30),
+    observacoes TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_polinizacoes_cruzamento ON polinizacoes(cruzamento_id, data) WHERE deleted_at IS NULL;
+
+-- Lotes de sementes colhidos dos cruzamentos
+ALTER TABLE pacotes_sementes ADD COLUMN IF NOT EXISTS cruzamento_id INTEGER REFERENCES cruzamentos(id) ON DELETE SET NULL;
+ALTER TABLE pacotes_sementes ADD COLUMN IF NOT EXISTS polinizacao_id INTEGER REFERENCES polinizacoes(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_pacotes_sementes_cruzamento ON pacotes_sementes(cruzamento_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+)
+
+// NotificacaoRepositorio implementa a interface repository.NotificacaoRepositorio
+type NotificacaoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewNotificacaoRepositorio cria uma nova instância do NotificacaoRepositorio
+func NewNotificacaoRepositorio(db *gorm.DB) *NotificacaoRepositorio {
+	return &NotificacaoRepositorio{db: db}
+}
+
+func (r *NotificacaoRepositorio) Criar(notificacao *entity.Notificacao) error {
+	if notificacao == nil {
+		return errors.New("notificação não pode ser nula")
+	}
+	return r.db.Create(notificacao).Error
+}
+
+func (r *NotificacaoRepositorio) BuscarPorID(id uint) (*entity.Notificacao, error) {
+	var notificacao entity.Notificacao
+	if err := r.db.First(&notificacao, id).Error; err != nil {
+		return nil, err
+	}
+	return &notificacao, nil
+}
+
+func (r *NotificacaoRepositorio) Listar(filtro repository.FiltroNotificacoes) ([]entity.Notificacao, int64, error) {
+	query := r.db.Model(&entity.Notificacao{}).Where("usuario_id = ?", filtro.UsuarioID)
+	if filtro.Categoria != "" {
+		query = query.Where("categoria = ?", filtro.Categoria)
+	}
+	if filtro.NaoLidas {
+		query = query.Where("lida = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("falha ao contar notificações: %w", err)
+	}
+
+	var notificacoes []entity.Notificacao
+	offset := (filtro.Page - 1) * filtro.Limit
+	if err := query.Order("id desc").Offset(offset).Limit(filtro.Limit).Find(&notificacoes).Error; err != nil {
+		return nil, 0, fmt.Errorf("falha ao listar notificações: %w", err)
+	}
+	return notificacoes, total, nil
+}
+
+func (r *NotificacaoRepositorio) Atualizar(notificacao *entity.Notificacao) error {
+	if notificacao == nil {
+		return errors.New("notificação não pode ser nula")
+	}
+	return r.db.Save(notificacao).Error
+}
+
+func (r *NotificacaoRepositorio) ListarDesde(usuarioID, aposID uint, limite int) ([]entity.Notificacao, error) {
+	var notificacoes []entity.Notificacao
+	err := r.db.
+		Where("usuario_id = ? AND id > ?", usuarioID, aposID).
+		Order("id").
+		Limit(limite).
+		Find(&notificacoes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar notificações novas do usuário %d: %w", usuarioID, err)
+	}
+	return notificacoes, nil
+}
+
+func (r *NotificacaoRepositorio) UltimoID(usuarioID uint) (uint, error) {
+	var ultimo uint
+	err := r.db.Model(&entity.Notificacao{}).
+		Where("usuario_id = ?", usuarioID).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&ultimo).Error
+	if err != nil {
+		return 0, fmt.Errorf("falha ao buscar a última notificação do usuário %d: %w", usuarioID, err)
+	}
+	return ultimo, nil
+}
+
+func (r *NotificacaoRepositorio) ContarNaoLidas(usuarioID uint) (int64, error) {
+	var total int64
+	err := r.db.Model(&entity.Notificacao{}).
+		Where("usuario_id = ? AND lida = ?", usuarioID, false).
+		Count(&total).Error
+	if err != nil {
+		return 0, fmt.Errorf("falha ao contar notificações não lidas: %w", err)
+	}
+	return total, nil
+}
+
+func (r *NotificacaoRepositorio) MarcarLidas(usuarioID uint, ids []uint, categoria string, em time.Time) (int64, error) {
+	query := r.db.Model(&entity.Notificacao{}).Where("usuario_id = ? AND lida = ?", usuarioID, false)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	} else if categoria != "" {
+		query = query.Where("categoria = ?", categoria)
+	}
+	result := query.Updates(map[string]any{"lida": true, "lida_em": em})
+	if result.Error != nil {
+		return 0, fmt.Errorf("falha ao marcar notificações como lidas: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -141,3 +141,29 @@ func (r *PlantaRepositorio) CriarRegistroDiario(registro *entity.RegistroDiario)
 	result := r.db.Create(registro)
 	return result.Error
 }
+
+func (r *PlantaRepositorio) ListarPorDiario(diarioID uint) ([]entity.Planta, error) {
+	var plantas []entity.Planta
+	err := r.db.
+		Joins("JOIN diario_cultivo_plantas dcp ON dcp.planta_id = plantas.id").
+		Where("dcp.diario_cultivo_id = ?", diarioID).
+		Order("plantas.id").
+		Find(&plantas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar plantas do diário %d: %w", diarioID, err)
+	}
+	return plantas, nil
+}
+
+func (r *PlantaRepositorio) ListarAtivasPorUsuario(usuarioID uint) ([]entity.Planta, error) {
+	var plantas []entity.Planta
+	err := r.db.
+		Preload("Genetica").
+		Where("usuario_id = ? AND status = ?", usuarioID, entity.StatusGerminating).
+		Order("id").
+		Find(&plantas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar plantas ativas do usuário %d: %w", usuarioID, err)
+	}
+	return plantas, nil
+}
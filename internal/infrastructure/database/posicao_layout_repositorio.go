@@ -0,0 +1,65 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// PosicaoLayoutRepositorio implementa a interface repository.PosicaoLayoutRepositorio
+type PosicaoLayoutRepositorio struct {
+	db *gorm.DB
+}
+
+// NewPosicaoLayoutRepositorio cria uma nova instância do PosicaoLayoutRepositorio
+func NewPosicaoLayoutRepositorio(db *gorm.DB) *PosicaoLayoutRepositorio {
+	return &PosicaoLayoutRepositorio{db: db}
+}
+
+func (r *PosicaoLayoutRepositorio) Criar(posicao *entity.PosicaoLayout) error {
+	if posicao == nil {
+		return errors.New("posição não pode ser nula")
+	}
+	return r.db.Create(posicao).Error
+}
+
+func (r *PosicaoLayoutRepositorio) BuscarPorID(id uint) (*entity.PosicaoLayout, error) {
+	var posicao entity.PosicaoLayout
+	if err := r.db.First(&posicao, id).Error; err != nil {
+		return nil, err
+	}
+	return &posicao, nil
+}
+
+func (r *PosicaoLayoutRepositorio) ListarPorAmbiente(ambienteID uint) ([]entity.PosicaoLayout, error) {
+	var posicoes []entity.PosicaoLayout
+	err := r.db.Preload("Planta").Preload("Vaso").
+		Where("ambiente_id = ?", ambienteID).
+		Order("id").
+		Find(&posicoes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar layout do ambiente %d: %w", ambienteID, err)
+	}
+	return posicoes, nil
+}
+
+func (r *PosicaoLayoutRepositorio) Atualizar(posicao *entity.PosicaoLayout) error {
+	if posicao == nil {
+		return errors.New("posição não pode ser nula")
+	}
+	return r.db.Omit("Planta", "Vaso").Save(posicao).Error
+}
+
+func (r *PosicaoLayoutRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.PosicaoLayout{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// PreferenciaNotificacaoRepositorio implementa a interface repository.PreferenciaNotificacaoRepositorio
+type PreferenciaNotificacaoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewPreferenciaNotificacaoRepositorio cria uma nova instância do PreferenciaNotificacaoRepositorio
+func NewPreferenciaNotificacaoRepositorio(db *gorm.DB) *PreferenciaNotificacaoRepositorio {
+	return &PreferenciaNotificacaoRepositorio{db: db}
+}
+
+func (r *PreferenciaNotificacaoRepositorio) BuscarPorUsuario(usuarioID uint) (*entity.PreferenciaNotificacao, error) {
+	var preferencia entity.PreferenciaNotificacao
+	if err := r.db.Where("usuario_id = ?", usuarioID).First(&preferencia).Error; err != nil {
+		return nil, err
+	}
+	return &preferencia, nil
+}
+
+func (r *PreferenciaNotificacaoRepositorio) Salvar(preferencia *entity.PreferenciaNotificacao) error {
+	if preferencia == nil {
+		return errors.New("preferência de notificação não pode ser nula")
+	}
+	if preferencia.ID == 0 {
+		return r.db.Create(preferencia).Error
+	}
+	return r.db.Save(preferencia).Error
+}
@@ -0,0 +1,217 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+)
+
+// RegaRepositorio implementa a interface repository.RegaRepositorio
+type RegaRepositorio struct {
+	db *gorm.DB
+}
+
+// NewRegaRepositorio cria uma nova instância do RegaRepositorio
+func NewRegaRepositorio(db *gorm.DB) *RegaRepositorio {
+	return &RegaRepositorio{db: db}
+}
+
+func (r *RegaRepositorio) Criar(rega *entity.Rega) error {
+	if rega == nil {
+		return errors.New("rega não pode ser nula")
+	}
+	return r.db.Create(rega).Error
+}
+
+func (r *RegaRepositorio) BuscarPorID(id uint) (*entity.Rega, error) {
+	var rega entity.Rega
+	if err := r.db.Preload("Nutrientes", ordenarPorID).First(&rega, id).Error; err != nil {
+		return nil, err
+	}
+	return &rega, nil
+}
+
+func (r *RegaRepositorio) Listar(filtro repository.FiltroRegas) ([]entity.Rega, int64, error) {
+	query := r.db.Model(&entity.Rega{}).Where("usuario_id = ?", filtro.UsuarioID)
+	if filtro.PlantaID != 0 {
+		query = query.Where("planta_id = ?", filtro.PlantaID)
+	}
+	if filtro.DiarioCultivoID != 0 {
+		query = query.Where("diario_cultivo_id = ?", filtro.DiarioCultivoID)
+	}
+	if filtro.De != nil {
+		query = query.Where("data >= ?", *filtro.De)
+	}
+	if filtro.Ate != nil {
+		query = query.Where("data < ?", *filtro.Ate)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("falha ao contar regas: %w", err)
+	}
+
+	var regas []entity.Rega
+	offset := (filtro.Page - 1) * filtro.Limit
+	err := query.Preload("Nutrientes", ordenarPorID).
+		Order("data desc, id desc").
+		Offset(offset).
+		Limit(filtro.Limit).
+		Find(&regas).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("falha ao listar regas: %w", err)
+	}
+	return regas, total, nil
+}
+
+func (r *RegaRepositorio) Atualizar(rega *entity.Rega) error {
+	if rega == nil {
+		return errors.New("rega não pode ser nula")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rega_id = ?", rega.ID).Delete(&entity.NutrienteRega{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover nutrientes da rega %d: %w", rega.ID, err)
+		}
+		for i := range rega.Nutrientes {
+			rega.Nutrientes[i].ID = 0
+			rega.Nutrientes[i].RegaID = rega.ID
+		}
+		if err := tx.Omit("Nutrientes").Save(rega).Error; err != nil {
+			return err
+		}
+		if len(rega.Nutrientes) == 0 {
+			return nil
+		}
+		return tx.Create(&rega.Nutrientes).Error
+	})
+}
+
+func (r *RegaRepositorio) Deletar(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entity.Rega{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("rega_id = ?", id).Delete(&entity.NutrienteRega{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover nutrientes da rega %d: %w", id, err)
+		}
+		return nil
+	})
+}
+
+func (r *RegaRepositorio) ListarPorPlanta(plantaID uint, de, ate *time.Time) ([]entity.Rega, error) {
+	query := r.db.Preload("Nutrientes", ordenarPorID).
+		Where("(planta_id = ? OR diario_cultivo_id IN (SELECT diario_cultivo_id FROM diario_cultivo_plantas WHERE planta_id = ?))", plantaID, plantaID)
+	if de != nil {
+		query = query.Where("data >= ?", *de)
+	}
+	if ate != nil {
+		query = query.Where("data < ?", *ate)
+	}
+
+	var regas []entity.Rega
+	if err := query.Order("data, id").Find(&regas).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar regas da planta %d: %w", plantaID, err)
+	}
+	return regas, nil
+}
+
+func (r *RegaRepositorio) ListarPorDiario(diarioID uint) ([]entity.Rega, error) {
+	var regas []entity.Rega
+	err := r.db.Preload("Nutrientes", ordenarPorID).
+		Where("(diario_cultivo_id = ? OR planta_id IN (SELECT planta_id FROM diario_cultivo_plantas WHERE diario_cultivo_id = ?))", diarioID, diarioID).
+		Order("data, id").
+		Find(&regas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar regas do diário de cultivo %d: %w", diarioID, err)
+	}
+	return regas, nil
+}
+
+// ReceitaNutrienteRepositorio implementa a interface repository.ReceitaNutrienteRepositorio
+type ReceitaNutrienteRepositorio struct {
+	db *gorm.DB
+}
+
+// NewReceitaNutrienteRepositorio cria uma nova instância do ReceitaNutrienteRepositorio
+func NewReceitaNutrienteRepositorio(db *gorm.DB) *ReceitaNutrienteRepositorio {
+	return &ReceitaNutrienteRepositorio{db: db}
+}
+
+func (r *ReceitaNutrienteRepositorio) Criar(receita *entity.ReceitaNutriente) error {
+	if receita == nil {
+		return errors.New("receita não pode ser nula")
+	}
+	return r.db.Create(receita).Error
+}
+
+func (r *ReceitaNutrienteRepositorio) BuscarPorID(id uint) (*entity.ReceitaNutriente, error) {
+	var receita entity.ReceitaNutriente
+	if err := r.db.Preload("Itens", ordenarPorID).First(&receita, id).Error; err != nil {
+		return nil, err
+	}
+	return &receita, nil
+}
+
+func (r *ReceitaNutrienteRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.ReceitaNutriente, error) {
+	var receitas []entity.ReceitaNutriente
+	err := r.db.Preload("Itens", ordenarPorID).
+		Where("usuario_id = ?", usuarioID).
+		Order("nome, id").
+		Find(&receitas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar receitas de nutrientes do usuário %d: %w", usuarioID, err)
+	}
+	return receitas, nil
+}
+
+func (r *ReceitaNutrienteRepositorio) Atualizar(receita *entity.ReceitaNutriente) error {
+	if receita == nil {
+		return errors.New("receita não pode ser nula")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("receita_id = ?", receita.ID).Delete(&entity.ItemReceitaNutriente{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover itens da receita %d: %w", receita.ID, err)
+		}
+		for i := range receita.Itens {
+			receita.Itens[i].ID = 0
+			receita.Itens[i].ReceitaID = receita.ID
+		}
+		if err := tx.Omit("Itens").Save(receita).Error; err != nil {
+			return err
+		}
+		if len(receita.Itens) == 0 {
+			return nil
+		}
+		return tx.Create(&receita.Itens).Error
+	})
+}
+
+func (r *ReceitaNutrienteRepositorio) Deletar(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entity.ReceitaNutriente{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("receita_id = ?", id).Delete(&entity.ItemReceitaNutriente{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover itens da receita %d: %w", id, err)
+		}
+		return nil
+	})
+}
+
+// ordenarPorID mantém os itens filhos na ordem em que foram informados
+func ordenarPorID(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
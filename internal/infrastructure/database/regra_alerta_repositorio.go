@@ -0,0 +1,78 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// RegraAlertaRepositorio implementa a interface repository.RegraAlertaRepositorio
+type RegraAlertaRepositorio struct {
+	db *gorm.DB
+}
+
+// NewRegraAlertaRepositorio cria uma nova instância do RegraAlertaRepositorio
+func NewRegraAlertaRepositorio(db *gorm.DB) *RegraAlertaRepositorio {
+	return &RegraAlertaRepositorio{db: db}
+}
+
+func (r *RegraAlertaRepositorio) Criar(regra *entity.RegraAlerta) error {
+	if regra == nil {
+		return errors.New("regra de alerta não pode ser nula")
+	}
+	return r.db.Create(regra).Error
+}
+
+func (r *RegraAlertaRepositorio) BuscarPorID(id uint) (*entity.RegraAlerta, error) {
+	var regra entity.RegraAlerta
+	if err := r.db.First(&regra, id).Error; err != nil {
+		return nil, err
+	}
+	return &regra, nil
+}
+
+func (r *RegraAlertaRepositorio) ListarPorAmbiente(ambienteID, usuarioID uint) ([]entity.RegraAlerta, error) {
+	var regras []entity.RegraAlerta
+	if err := r.db.Where("ambiente_id = ? AND usuario_id = ?", ambienteID, usuarioID).Order("id").Find(&regras).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar regras de alerta do ambiente %d: %w", ambienteID, err)
+	}
+	return regras, nil
+}
+
+func (r *RegraAlertaRepositorio) ListarAtivasPorAmbiente(ambienteID uint) ([]entity.RegraAlerta, error) {
+	var regras []entity.RegraAlerta
+	if err := r.db.Where("ambiente_id = ? AND ativa = ?", ambienteID, true).Order("id").Find(&regras).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar regras de alerta ativas do ambiente %d: %w", ambienteID, err)
+	}
+	return regras, nil
+}
+
+func (r *RegraAlertaRepositorio) Atualizar(regra *entity.RegraAlerta) error {
+	if regra == nil {
+		return errors.New("regra de alerta não pode ser nula")
+	}
+	return r.db.Save(regra).Error
+}
+
+func (r *RegraAlertaRepositorio) AtualizarCondicao(id uint, desde *time.Time) error {
+	err := r.db.Model(&entity.RegraAlerta{}).Where("id = ?", id).Update("condicao_desde", desde).Error
+	if err != nil {
+		return fmt.Errorf("falha ao atualizar condição da regra de alerta %d: %w", id, err)
+	}
+	return nil
+}
+
+func (r *RegraAlertaRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.RegraAlerta{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"testing"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestDB cria um banco SQLite em memória com as tabelas informadas
+func setupTestDB(t *testing.T, modelos ...interface{}) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(modelos...))
+	return db
+}
+
+func TestRegraAlertaRepositorio_ListarPorAmbiente(t *testing.T) {
+	t.Run("Success - Ignora Regras de Outro Usuário", func(t *testing.T) {
+		db := setupTestDB(t, &entity.RegraAlerta{})
+		repo := NewRegraAlertaRepositorio(db)
+
+		regras := []entity.RegraAlerta{
+			{AmbienteID: 1, UsuarioID: 7, Nome: "Calor", Metrica: "temperatura", Comparacao: "maior", Limite: 30, Canais: "lembrete"},
+			{AmbienteID: 1, UsuarioID: 8, Nome: "Webhook alheio", Metrica: "temperatura", Comparacao: "maior", Limite: 30,
+				Canais: "webhook", WebhookURL: "https://exemplo.com/hook"},
+			{AmbienteID: 2, UsuarioID: 7, Nome: "Outro ambiente", Metrica: "umidade", Comparacao: "menor", Limite: 40, Canais: "lembrete"},
+		}
+		require.NoError(t, db.Create(&regras).Error)
+
+		encontradas, err := repo.ListarPorAmbiente(1, 7)
+
+		require.NoError(t, err)
+		require.Len(t, encontradas, 1)
+		assert.Equal(t, "Calor", encontradas[0].Nome)
+	})
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// ReservatorioRepositorio implementa a interface repository.ReservatorioRepositorio
+type ReservatorioRepositorio struct {
+	db *gorm.DB
+}
+
+// NewReservatorioRepositorio cria uma nova instância do ReservatorioRepositorio
+func NewReservatorioRepositorio(db *gorm.DB) *ReservatorioRepositorio {
+	return &ReservatorioRepositorio{db: db}
+}
+
+func (r *ReservatorioRepositorio) Criar(reservatorio *entity.Reservatorio) error {
+	if reservatorio == nil {
+		return errors.New("reservatório não pode ser nulo")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		plantas := reservatorio.Plantas
+		if err := tx.Omit("Plantas").Create(reservatorio).Error; err != nil {
+			return err
+		}
+		if len(plantas) == 0 {
+			return nil
+		}
+		return tx.Model(reservatorio).Association("Plantas").Replace(plantas)
+	})
+}
+
+func (r *ReservatorioRepositorio) BuscarPorID(id uint) (*entity.Reservatorio, error) {
+	var reservatorio entity.Reservatorio
+	if err := r.db.Preload("Plantas", ordenarPorID).First(&reservatorio, id).Error; err != nil {
+		return nil, err
+	}
+	return &reservatorio, nil
+}
+
+func (r *ReservatorioRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.Reservatorio, error) {
+	var reservatorios []entity.Reservatorio
+	err := r.db.Preload("Plantas", ordenarPorID).
+		Where("usuario_id = ?", usuarioID).
+		Order("nome, id").
+		Find(&reservatorios).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar reservatórios do usuário %d: %w", usuarioID, err)
+	}
+	return reservatorios, nil
+}
+
+func (r *ReservatorioRepositorio) Atualizar(reservatorio *entity.Reservatorio) error {
+	if reservatorio == nil {
+		return errors.New("reservatório não pode ser nulo")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Plantas").Save(reservatorio).Error; err != nil {
+			return err
+		}
+		return tx.Model(reservatorio).Association("Plantas").Replace(reservatorio.Plantas)
+	})
+}
+
+func (r *ReservatorioRepositorio) Deletar(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entity.Reservatorio{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("reservatorio_id = ?", id).Delete(&entity.ManutencaoReservatorio{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover manutenções do reservatório %d: %w", id, err)
+		}
+		if err := tx.Where("reservatorio_id = ?", id).Delete(&entity.LeituraReservatorio{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover leituras do reservatório %d: %w", id, err)
+		}
+		return nil
+	})
+}
+
+func (r *ReservatorioRepositorio) ListarComTrocaAutomatica() ([]entity.Reservatorio, error) {
+	var reservatorios []entity.Reservatorio
+	if err := r.db.Where("intervalo_troca_dias > 0").Order("id").Find(&reservatorios).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar reservatórios com troca automática: %w", err)
+	}
+	return reservatorios, nil
+}
+
+func (r *ReservatorioRepositorio) CriarManutencao(manutencao *entity.ManutencaoReservatorio) error {
+	if manutencao == nil {
+		return errors.New("manutenção não pode ser nula")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(manutencao).Error; err != nil {
+			return err
+		}
+		if manutencao.Tipo != entity.ManutencaoReservatorioTroca {
+			return nil
+		}
+		// uma troca lançada com data retroativa não pode recuar a última troca
+		err := tx.Model(&entity.Reservatorio{}).
+			Where("id = ? AND (ultima_troca IS NULL OR ultima_troca < ?)", manutencao.ReservatorioID, manutencao.Data).
+			Update("ultima_troca", manutencao.Data).Error
+		if err != nil {
+			return fmt.Errorf("falha ao atualizar a última troca do reservatório %d: %w", manutencao.ReservatorioID, err)
+		}
+		return nil
+	})
+}
+
+func (r *ReservatorioRepositorio) ListarManutencoes(reservatorioID uint) ([]entity.ManutencaoReservatorio, error) {
+	var manutencoes []entity.ManutencaoReservatorio
+	err := r.db.Where("reservatorio_id = ?", reservatorioID).
+		Order("data DESC, id DESC").
+		Find(&manutencoes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar manutenções do reservatório %d: %w", reservatorioID, err)
+	}
+	return manutencoes, nil
+}
+
+func (r *ReservatorioRepositorio) CriarLeitura(leitura *entity.LeituraReservatorio) error {
+	if leitura == nil {
+		return errors.New("leitura não pode ser nula")
+	}
+	return r.db.Create(leitura).Error
+}
+
+func (r *ReservatorioRepositorio) ListarLeituras(reservatorioID uint, de, ate *time.Time) ([]entity.LeituraReservatorio, error) {
+	query := r.db.Where("reservatorio_id = ?", reservatorioID)
+	if de != nil {
+		query = query.Where("data >= ?", *de)
+	}
+	if ate != nil {
+		query = query.Where("data < ?", *ate)
+	}
+	var leituras []entity.LeituraReservatorio
+	if err := query.Order("data, id").Find(&leituras).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar leituras do reservatório %d: %w", reservatorioID, err)
+	}
+	return leituras, nil
+}
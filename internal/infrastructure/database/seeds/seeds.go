@@ -0,0 +1,10 @@
+// Package seeds guarda os dados iniciais que acompanham o binário
+package seeds
+
+import _ "embed"
+
+// GeneticasIniciais é o catálogo inicial de genéticas, no mesmo formato aceito por
+// POST /geneticas/importar/json
+//
+//go:embed geneticas.json
+var GeneticasIniciais []byte
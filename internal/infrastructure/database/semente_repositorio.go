@@ -0,0 +1,149 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SementeRepositorio implementa a interface repository.SementeRepositorio
+type SementeRepositorio struct {
+	db *gorm.DB
+}
+
+// NewSementeRepositorio cria uma nova instância do SementeRepositorio
+func NewSementeRepositorio(db *gorm.DB) *SementeRepositorio {
+	return &SementeRepositorio{db: db}
+}
+
+func (r *SementeRepositorio) CriarPacote(pacote *entity.PacoteSemente) error {
+	if pacote == nil {
+		return errors.New("pacote de sementes não pode ser nulo")
+	}
+	return r.db.Omit("Genetica").Create(pacote).Error
+}
+
+func (r *SementeRepositorio) BuscarPacote(id uint) (*entity.PacoteSemente, error) {
+	var pacote entity.PacoteSemente
+	if err := r.db.Preload("Genetica").First(&pacote, id).Error; err != nil {
+		return nil, err
+	}
+	return &pacote, nil
+}
+
+func (r *SementeRepositorio) ListarPacotes(filtro repository.FiltroPacotesSemente) ([]entity.PacoteSemente, error) {
+	query := r.db.Preload("Genetica").Where("usuario_id = ?", filtro.UsuarioID)
+	if filtro.GeneticaID != 0 {
+		query = query.Where("genetica_id = ?", filtro.GeneticaID)
+	}
+	if filtro.CruzamentoID != 0 {
+		query = query.Where("cruzamento_id = ?", filtro.CruzamentoID)
+	}
+	if filtro.Breeder != "" {
+		query = query.Where("LOWER(breeder) = ?", strings.ToLower(filtro.Breeder))
+	}
+	if filtro.ComSementes {
+		query = query.Where("quantidade_restante > 0")
+	}
+	var pacotes []entity.PacoteSemente
+	if err := query.Order("breeder, data_aquisicao, id").Find(&pacotes).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar pacotes de sementes do usuário %d: %w", filtro.UsuarioID, err)
+	}
+	return pacotes, nil
+}
+
+func (r *SementeRepositorio) AtualizarPacote(pacote *entity.PacoteSemente) error {
+	if pacote == nil {
+		return errors.New("pacote de sementes não pode ser nulo")
+	}
+	return r.db.Omit("Genetica").Save(pacote).Error
+}
+
+func (r *SementeRepositorio) DeletarPacote(id uint) error {
+	result := r.db.Delete(&entity.PacoteSemente{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *SementeRepositorio) RegistrarGerminacao(pacote *entity.PacoteSemente, germinacao *entity.GerminacaoSemente) error {
+	if pacote == nil || germinacao == nil {
+		return errors.New("pacote e germinação não podem ser nulos")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Pacote").Create(germinacao).Error; err != nil {
+			return err
+		}
+		return r.retirarSementes(tx, pacote, germinacao.Semeadas-germinacao.Germinadas)
+	})
+}
+
+func (r *SementeRepositorio) ListarGerminacoes(pacoteID uint) ([]entity.GerminacaoSemente, error) {
+	var germinacoes []entity.GerminacaoSemente
+	err := r.db.Where("pacote_id = ?", pacoteID).
+		Order("data DESC, id DESC").
+		Find(&germinacoes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar germinações do pacote de sementes %d: %w", pacoteID, err)
+	}
+	return germinacoes, nil
+}
+
+func (r *SementeRepositorio) ListarGerminacoesUsuario(usuarioID uint) ([]entity.GerminacaoSemente, error) {
+	var germinacoes []entity.GerminacaoSemente
+	err := r.db.
+		Preload("Pacote", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Pacote.Genetica", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Joins("JOIN pacotes_sementes ON pacotes_sementes.id = germinacoes_sementes.pacote_id").
+		Where("pacotes_sementes.usuario_id = ?", usuarioID).
+		Order("germinacoes_sementes.data, germinacoes_sementes.id").
+		Find(&germinacoes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar germinações do usuário %d: %w", usuarioID, err)
+	}
+	return germinacoes, nil
+}
+
+func (r *SementeRepositorio) CriarPlantas(pacote *entity.PacoteSemente, plantas []entity.Planta) error {
+	if pacote == nil || len(plantas) == 0 {
+		return errors.New("pacote e plantas não podem ser nulos")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&plantas).Error; err != nil {
+			return err
+		}
+		return r.retirarSementes(tx, pacote, len(plantas))
+	})
+}
+
+// retirarSementes debita o saldo no próprio UPDATE, que só passa se ainda houver sementes, para
+// que retiradas simultâneas do mesmo pacote não sobrescrevam uma à outra
+func (r *SementeRepositorio) retirarSementes(tx *gorm.DB, pacote *entity.PacoteSemente, quantidade int) error {
+	result := tx.Model(&entity.PacoteSemente{}).
+		Where("id = ? AND quantidade_restante >= ?", pacote.ID, quantidade).
+		Update("quantidade_restante", gorm.Expr("quantidade_restante - ?", quantidade))
+	if result.Error != nil {
+		return fmt.Errorf("falha ao atualizar saldo do pacote de sementes %d: %w", pacote.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrSementesInsuficientes
+	}
+	var restante int
+	err := tx.Model(&entity.PacoteSemente{}).
+		Where("id = ?", pacote.ID).
+		Pluck("quantidade_restante", &restante).Error
+	if err != nil {
+		return fmt.Errorf("falha ao ler saldo do pacote de sementes %d: %w", pacote.ID, err)
+	}
+	pacote.QuantidadeRestante = restante
+	return nil
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSementeRepositorio_DeletarPacote(t *testing.T) {
+	t.Run("Success - Germinações Continuam nas Taxas", func(t *testing.T) {
+		db := setupTestDB(t, &entity.Genetica{}, &entity.PacoteSemente{}, &entity.GerminacaoSemente{})
+		repo := NewSementeRepositorio(db)
+
+		genetica := entity.Genetica{Nome: "Skunk #1", TipoGenetica: "hibrido", TipoEspecie: "regular", TempoFloracao: 56, Origem: "Sensi Seeds"}
+		require.NoError(t, db.Create(&genetica).Error)
+		pacote := entity.PacoteSemente{UsuarioID: 7, GeneticaID: genetica.ID, Breeder: "Sensi Seeds", QuantidadeInicial: 10}
+		require.NoError(t, db.Omit("Genetica").Create(&pacote).Error)
+		germinacao := entity.GerminacaoSemente{PacoteID: pacote.ID, Data: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Semeadas: 5, Germinadas: 4}
+		require.NoError(t, db.Omit("Pacote").Create(&germinacao).Error)
+
+		require.NoError(t, repo.DeletarPacote(pacote.ID))
+
+		germinacoes, err := repo.ListarGerminacoesUsuario(7)
+		require.NoError(t, err)
+		require.Len(t, germinacoes, 1)
+		assert.Equal(t, 4, germinacoes[0].Germinadas)
+		require.NotNil(t, germinacoes[0].Pacote)
+		assert.Equal(t, "Sensi Seeds", germinacoes[0].Pacote.Breeder)
+		_, err = repo.BuscarPacote(pacote.ID)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// SubstratoRepositorio implementa a interface repository.SubstratoRepositorio
+type SubstratoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewSubstratoRepositorio cria uma nova instância do SubstratoRepositorio
+func NewSubstratoRepositorio(db *gorm.DB) *SubstratoRepositorio {
+	return &SubstratoRepositorio{db: db}
+}
+
+func (r *SubstratoRepositorio) Criar(substrato *entity.Substrato) error {
+	if substrato == nil {
+		return errors.New("substrato não pode ser nulo")
+	}
+	return r.db.Create(substrato).Error
+}
+
+func (r *SubstratoRepositorio) BuscarPorID(id uint) (*entity.Substrato, error) {
+	var substrato entity.Substrato
+	if err := r.db.First(&substrato, id).Error; err != nil {
+		return nil, err
+	}
+	return &substrato, nil
+}
+
+func (r *SubstratoRepositorio) Listar() ([]entity.Substrato, error) {
+	var substratos []entity.Substrato
+	if err := r.db.Order("nome, id").Find(&substratos).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar substratos: %w", err)
+	}
+	return substratos, nil
+}
+
+func (r *SubstratoRepositorio) Atualizar(substrato *entity.Substrato) error {
+	if substrato == nil {
+		return errors.New("substrato não pode ser nulo")
+	}
+	return r.db.Save(substrato).Error
+}
+
+func (r *SubstratoRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.Substrato{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
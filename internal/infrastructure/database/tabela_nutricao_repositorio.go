@@ -0,0 +1,84 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// TabelaNutricaoRepositorio implementa a interface repository.TabelaNutricaoRepositorio
+type TabelaNutricaoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewTabelaNutricaoRepositorio cria uma nova instância do TabelaNutricaoRepositorio
+func NewTabelaNutricaoRepositorio(db *gorm.DB) *TabelaNutricaoRepositorio {
+	return &TabelaNutricaoRepositorio{db: db}
+}
+
+func (r *TabelaNutricaoRepositorio) Criar(tabela *entity.TabelaNutricao) error {
+	if tabela == nil {
+		return errors.New("tabela de nutrição não pode ser nula")
+	}
+	return r.db.Create(tabela).Error
+}
+
+func (r *TabelaNutricaoRepositorio) BuscarPorID(id uint) (*entity.TabelaNutricao, error) {
+	var tabela entity.TabelaNutricao
+	if err := r.db.Preload("Itens", ordenarPorID).First(&tabela, id).Error; err != nil {
+		return nil, err
+	}
+	return &tabela, nil
+}
+
+func (r *TabelaNutricaoRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.TabelaNutricao, error) {
+	var tabelas []entity.TabelaNutricao
+	err := r.db.Preload("Itens", ordenarPorID).
+		Where("usuario_id = ?", usuarioID).
+		Order("nome, id").
+		Find(&tabelas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar tabelas de nutrição do usuário %d: %w", usuarioID, err)
+	}
+	return tabelas, nil
+}
+
+func (r *TabelaNutricaoRepositorio) Atualizar(tabela *entity.TabelaNutricao) error {
+	if tabela == nil {
+		return errors.New("tabela de nutrição não pode ser nula")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tabela_id = ?", tabela.ID).Delete(&entity.ItemTabelaNutricao{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover itens da tabela de nutrição %d: %w", tabela.ID, err)
+		}
+		for i := range tabela.Itens {
+			tabela.Itens[i].ID = 0
+			tabela.Itens[i].TabelaID = tabela.ID
+		}
+		if err := tx.Omit("Itens").Save(tabela).Error; err != nil {
+			return err
+		}
+		if len(tabela.Itens) == 0 {
+			return nil
+		}
+		return tx.Create(&tabela.Itens).Error
+	})
+}
+
+func (r *TabelaNutricaoRepositorio) Deletar(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entity.TabelaNutricao{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("tabela_id = ?", id).Delete(&entity.ItemTabelaNutricao{}).Error; err != nil {
+			return fmt.Errorf("falha ao remover itens da tabela de nutrição %d: %w", id, err)
+		}
+		return nil
+	})
+}
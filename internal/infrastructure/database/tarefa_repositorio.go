@@ -0,0 +1,218 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+)
+
+// TarefaRepositorio implementa a interface repository.TarefaRepositorio
+type TarefaRepositorio struct {
+	db *gorm.DB
+}
+
+// NewTarefaRepositorio cria uma nova instância do TarefaRepositorio
+func NewTarefaRepositorio(db *gorm.DB) *TarefaRepositorio {
+	return &TarefaRepositorio{db: db}
+}
+
+func (r *TarefaRepositorio) Criar(tarefa *entity.Tarefa) error {
+	if tarefa == nil {
+		return errors.New("tarefa não pode ser nula")
+	}
+	return r.db.Create(tarefa).Error
+}
+
+func (r *TarefaRepositorio) BuscarPorID(id uint) (*entity.Tarefa, error) {
+	var tarefa entity.Tarefa
+	if err := r.db.Preload("Fotos").First(&tarefa, id).Error; err != nil {
+		return nil, err
+	}
+	return &tarefa, nil
+}
+
+func (r *TarefaRepositorio) Listar(filtro repository.FiltroTarefas) ([]entity.Tarefa, int64, error) {
+	query := r.db.Model(&entity.Tarefa{})
+	if filtro.UsuarioID != 0 {
+		query = query.Where("usuario_id = ?", filtro.UsuarioID)
+	}
+	if filtro.PlantaID != 0 {
+		query = query.Where("planta_id = ?", filtro.PlantaID)
+	}
+	if filtro.AmbienteID != 0 {
+		query = query.Where("ambiente_id = ?", filtro.AmbienteID)
+	}
+	if filtro.DiarioCultivoID != 0 {
+		query = query.Where("diario_cultivo_id = ?", filtro.DiarioCultivoID)
+	}
+	if filtro.De != nil {
+		query = query.Where("data_agendada >= ?", *filtro.De)
+	}
+	if filtro.Ate != nil {
+		query = query.Where("data_agendada < ?", *filtro.Ate)
+	}
+	switch {
+	case filtro.Status == "":
+	case filtro.Agora.IsZero():
+		query = query.Where("status = ?", filtro.Status)
+	case filtro.Status == entity.StatusTarefaPendente:
+		query = query.Where("status = ? AND data_agendada >= ?", entity.StatusTarefaPendente, filtro.Agora)
+	case filtro.Status == entity.StatusTarefaAtrasada:
+		query = query.Where("(status = ? OR (status = ? AND data_agendada < ?))",
+			entity.StatusTarefaAtrasada, entity.StatusTarefaPendente, filtro.Agora)
+	default:
+		query = query.Where("status = ?", filtro.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("falha ao contar tarefas: %w", err)
+	}
+
+	var tarefas []entity.Tarefa
+	offset := (filtro.Page - 1) * filtro.Limit
+	if err := query.Preload("Fotos").Order("data_agendada, id").Offset(offset).Limit(filtro.Limit).Find(&tarefas).Error; err != nil {
+		return nil, 0, fmt.Errorf("falha ao listar tarefas: %w", err)
+	}
+	return tarefas, total, nil
+}
+
+func (r *TarefaRepositorio) Atualizar(tarefa *entity.Tarefa) error {
+	if tarefa == nil {
+		return errors.New("tarefa não pode ser nula")
+	}
+	return r.db.Save(tarefa).Error
+}
+
+func (r *TarefaRepositorio) Concluir(tarefa *entity.Tarefa, consumos *repository.MovimentacoesEstoque) error {
+	if tarefa == nil {
+		return errors.New("tarefa não pode ser nula")
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// a tarefa fica travada para que duas conclusões simultâneas não deem baixa duas vezes
+		var atual entity.Tarefa
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status").
+			First(&atual, tarefa.ID).Error
+		if err != nil {
+			return err
+		}
+		if atual.Status == entity.StatusTarefaConcluida {
+			return repository.ErrTarefaJaConcluida
+		}
+		if err := tx.Save(tarefa).Error; err != nil {
+			return err
+		}
+		return registrarMovimentacoes(tx, consumos)
+	})
+	if err != nil {
+		return err
+	}
+	consumos.Confirmar()
+	return nil
+}
+
+func (r *TarefaRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.Tarefa{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *TarefaRepositorio) MarcarAtrasadas(agora time.Time) (int64, error) {
+	result := r.db.Model(&entity.Tarefa{}).
+		Where("status = ? AND data_agendada < ?", entity.StatusTarefaPendente, agora).
+		Update("status", entity.StatusTarefaAtrasada)
+	if result.Error != nil {
+		return 0, fmt.Errorf("falha ao marcar tarefas atrasadas: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *TarefaRepositorio) BuscarSucessora(id uint) (*entity.Tarefa, error) {
+	var tarefa entity.Tarefa
+	if err := r.db.Unscoped().Where("tarefa_anterior_id = ?", id).First(&tarefa).Error; err != nil {
+		return nil, err
+	}
+	return &tarefa, nil
+}
+
+func (r *TarefaRepositorio) ListarUltimasRecorrentes(ate time.Time) ([]entity.Tarefa, error) {
+	var tarefas []entity.Tarefa
+	// a subconsulta considera também as sucessoras excluídas: excluir a última ocorrência encerra a série
+	err := r.db.
+		Where("recorrente = ? AND frequencia_dias > 0", true).
+		Where("data_agendada + make_interval(days => frequencia_dias) <= ?", ate).
+		Where("NOT EXISTS (SELECT 1 FROM tarefas sucessora WHERE sucessora.tarefa_anterior_id = tarefas.id)").
+		Order("data_agendada, id").
+		Find(&tarefas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar tarefas recorrentes: %w", err)
+	}
+	return tarefas, nil
+}
+
+func (r *TarefaRepositorio) ListarParaLembrete(ate time.Time) ([]entity.Tarefa, error) {
+	var tarefas []entity.Tarefa
+	err := r.db.
+		Where("status <> ? AND lembrete_enviado_em IS NULL AND data_agendada <= ?", entity.StatusTarefaConcluida, ate).
+		Order("data_agendada, id").
+		Find(&tarefas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar tarefas para lembrete: %w", err)
+	}
+	return tarefas, nil
+}
+
+func (r *TarefaRepositorio) MarcarLembreteEnviado(id uint, em time.Time) error {
+	result := r.db.Model(&entity.Tarefa{}).Where("id = ?", id).Update("lembrete_enviado_em", em)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *TarefaRepositorio) ListarPorAplicacao(aplicacaoID uint) ([]entity.Tarefa, error) {
+	var tarefas []entity.Tarefa
+	if err := r.db.Where("aplicacao_cronograma_id = ?", aplicacaoID).Order("data_agendada, id").Find(&tarefas).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar tarefas da aplicação %d: %w", aplicacaoID, err)
+	}
+	return tarefas, nil
+}
+
+func (r *TarefaRepositorio) ListarAbertasPorUsuario(usuarioID uint) ([]entity.Tarefa, error) {
+	var tarefas []entity.Tarefa
+	err := r.db.
+		Where("usuario_id = ? AND status <> ?", usuarioID, entity.StatusTarefaConcluida).
+		Order("data_agendada, id").
+		Find(&tarefas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar tarefas abertas do usuário %d: %w", usuarioID, err)
+	}
+	return tarefas, nil
+}
+
+func (r *TarefaRepositorio) ListarAbertasPorReservatorio(reservatorioID uint) ([]entity.Tarefa, error) {
+	var tarefas []entity.Tarefa
+	err := r.db.
+		Where("reservatorio_id = ? AND status <> ?", reservatorioID, entity.StatusTarefaConcluida).
+		Order("data_agendada, id").
+		Find(&tarefas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar tarefas abertas do reservatório %d: %w", reservatorioID, err)
+	}
+	return tarefas, nil
+}
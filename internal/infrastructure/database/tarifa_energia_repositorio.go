@@ -0,0 +1,81 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// TarifaEnergiaRepositorio implementa a interface repository.TarifaEnergiaRepositorio
+type TarifaEnergiaRepositorio struct {
+	db *gorm.DB
+}
+
+// NewTarifaEnergiaRepositorio cria uma nova instância do TarifaEnergiaRepositorio
+func NewTarifaEnergiaRepositorio(db *gorm.DB) *TarifaEnergiaRepositorio {
+	return &TarifaEnergiaRepositorio{db: db}
+}
+
+func (r *TarifaEnergiaRepositorio) Criar(tarifa *entity.TarifaEnergia) error {
+	if tarifa == nil {
+		return errors.New("tarifa de energia não pode ser nula")
+	}
+	return r.db.Create(tarifa).Error
+}
+
+func (r *TarifaEnergiaRepositorio) BuscarPorID(id uint) (*entity.TarifaEnergia, error) {
+	var tarifa entity.TarifaEnergia
+	if err := r.db.First(&tarifa, id).Error; err != nil {
+		return nil, err
+	}
+	return &tarifa, nil
+}
+
+// ListarPorUsuario retorna as tarifas do usuário em ordem de vigência
+func (r *TarifaEnergiaRepositorio) ListarPorUsuario(usuarioID uint) ([]entity.TarifaEnergia, error) {
+	var tarifas []entity.TarifaEnergia
+	err := r.db.Where("usuario_id = ?", usuarioID).
+		Order("vigente_desde, id").
+		Find(&tarifas).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar tarifas do usuário %d: %w", usuarioID, err)
+	}
+	return tarifas, nil
+}
+
+func (r *TarifaEnergiaRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.TarifaEnergia{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SalvarBandeira cria ou substitui a bandeira do usuário na competência
+func (r *TarifaEnergiaRepositorio) SalvarBandeira(bandeira *entity.BandeiraMensal) error {
+	if bandeira == nil {
+		return errors.New("bandeira tarifária não pode ser nula")
+	}
+	err := r.db.
+		Where(entity.BandeiraMensal{UsuarioID: bandeira.UsuarioID, Competencia: bandeira.Competencia}).
+		Assign(entity.BandeiraMensal{Bandeira: bandeira.Bandeira}).
+		FirstOrCreate(bandeira).Error
+	if err != nil {
+		return fmt.Errorf("falha ao salvar bandeira de %s: %w", bandeira.Competencia, err)
+	}
+	return nil
+}
+
+func (r *TarifaEnergiaRepositorio) ListarBandeiras(usuarioID uint) ([]entity.BandeiraMensal, error) {
+	var bandeiras []entity.BandeiraMensal
+	if err := r.db.Where("usuario_id = ?", usuarioID).Order("competencia").Find(&bandeiras).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar bandeiras do usuário %d: %w", usuarioID, err)
+	}
+	return bandeiras, nil
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TransplanteRepositorio implementa a interface repository.TransplanteRepositorio
+type TransplanteRepositorio struct {
+	db *gorm.DB
+}
+
+// NewTransplanteRepositorio cria uma nova instância do TransplanteRepositorio
+func NewTransplanteRepositorio(db *gorm.DB) *TransplanteRepositorio {
+	return &TransplanteRepositorio{db: db}
+}
+
+func (r *TransplanteRepositorio) Registrar(transplante *entity.Transplante, consumos *repository.MovimentacoesEstoque) error {
+	if transplante == nil {
+		return errors.New("transplante não pode ser nulo")
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(transplante).Error; err != nil {
+			return err
+		}
+		if err := definirRecipiente(tx, transplante.PlantaID, transplante.VasoNovoID, transplante.SubstratoNovoID); err != nil {
+			return err
+		}
+		if consumos != nil {
+			for _, movimentacao := range consumos.Itens {
+				origemID := transplante.ID
+				movimentacao.OrigemID = &origemID
+			}
+		}
+		return registrarMovimentacoes(tx, consumos)
+	})
+	if err != nil {
+		return err
+	}
+	consumos.Confirmar()
+	return nil
+}
+
+func (r *TransplanteRepositorio) ListarPorPlanta(plantaID uint) ([]entity.Transplante, error) {
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	var transplantes []entity.Transplante
+	err := r.db.
+		Preload("VasoAnterior", unscoped).
+		Preload("VasoNovo", unscoped).
+		Preload("SubstratoAnterior", unscoped).
+		Preload("SubstratoNovo", unscoped).
+		Where("planta_id = ?", plantaID).
+		Order("data, id").
+		Find(&transplantes).Error
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar transplantes da planta %d: %w", plantaID, err)
+	}
+	return transplantes, nil
+}
+
+func (r *TransplanteRepositorio) DefinirRecipiente(plantaID uint, vasoID, substratoID *uint) error {
+	return definirRecipiente(r.db, plantaID, vasoID, substratoID)
+}
+
+func definirRecipiente(db *gorm.DB, plantaID uint, vasoID, substratoID *uint) error {
+	result := db.Model(&entity.Planta{}).
+		Where("id = ?", plantaID).
+		Updates(map[string]any{"vaso_id": vasoID, "substrato_id": substratoID})
+	if result.Error != nil {
+		return fmt.Errorf("falha ao atualizar recipiente da planta %d: %w", plantaID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -58,3 +58,22 @@ func (r *UsuarioRepositorio) ExistePorEmail(email string) bool {
 	r.db.Model(&entity.Usuario{}).Where("email = ?", email).Count(&count)
 	return count > 0
 }
+
+func (r *UsuarioRepositorio) BuscarPorTokenCalendario(hash string) (*entity.Usuario, error) {
+	var usuario entity.Usuario
+	if err := r.db.Where("token_calendario_hash = ?", hash).First(&usuario).Error; err != nil {
+		return nil, err
+	}
+	return &usuario, nil
+}
+
+func (r *UsuarioRepositorio) DefinirTokenCalendario(usuarioID uint, hash *string) error {
+	result := r.db.Model(&entity.Usuario{}).Where("id = ?", usuarioID).Update("token_calendario_hash", hash)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
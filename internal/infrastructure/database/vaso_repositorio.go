@@ -0,0 +1,61 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+)
+
+// VasoRepositorio implementa a interface repository.VasoRepositorio
+type VasoRepositorio struct {
+	db *gorm.DB
+}
+
+// NewVasoRepositorio cria uma nova instância do VasoRepositorio
+func NewVasoRepositorio(db *gorm.DB) *VasoRepositorio {
+	return &VasoRepositorio{db: db}
+}
+
+func (r *VasoRepositorio) Criar(vaso *entity.Vaso) error {
+	if vaso == nil {
+		return errors.New("vaso não pode ser nulo")
+	}
+	return r.db.Create(vaso).Error
+}
+
+func (r *VasoRepositorio) BuscarPorID(id uint) (*entity.Vaso, error) {
+	var vaso entity.Vaso
+	if err := r.db.First(&vaso, id).Error; err != nil {
+		return nil, err
+	}
+	return &vaso, nil
+}
+
+func (r *VasoRepositorio) Listar() ([]entity.Vaso, error) {
+	var vasos []entity.Vaso
+	if err := r.db.Order("volume, nome, id").Find(&vasos).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar vasos: %w", err)
+	}
+	return vasos, nil
+}
+
+func (r *VasoRepositorio) Atualizar(vaso *entity.Vaso) error {
+	if vaso == nil {
+		return errors.New("vaso não pode ser nulo")
+	}
+	return r.db.Save(vaso).Error
+}
+
+func (r *VasoRepositorio) Deletar(id uint) error {
+	result := r.db.Delete(&entity.Vaso{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
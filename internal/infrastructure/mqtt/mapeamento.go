@@ -0,0 +1,92 @@
+package mqtt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ambienteDoTopico indica que o ID do ambiente vem do nível do tópico casado pelo primeiro "+"
+const ambienteDoTopico = "+"
+
+// Mapeamento associa um filtro de tópico a um ambiente e a um formato de payload.
+type Mapeamento struct {
+	Filtro     string
+	AmbienteID uint // zero quando o ambiente vem do próprio tópico
+	Formato    string
+	Metrica    string // obrigatória apenas no formato valor
+}
+
+// ParsearMapeamentos lê a configuração "filtro|ambiente|formato[|metrica]" separada por ";".
+// O ambiente pode ser um ID ou "+", que usa o nível do tópico casado pelo primeiro "+" do filtro.
+func ParsearMapeamentos(texto string) ([]Mapeamento, error) {
+	var mapeamentos []Mapeamento
+	for _, entrada := range strings.Split(texto, ";") {
+		entrada = strings.TrimSpace(entrada)
+		if entrada == "" {
+			continue
+		}
+		partes := strings.Split(entrada, "|")
+		if len(partes) < 3 || len(partes) > 4 {
+			return nil, fmt.Errorf("mapeamento MQTT inválido %q: use filtro|ambiente|formato[|metrica]", entrada)
+		}
+
+		m := Mapeamento{Filtro: strings.TrimSpace(partes[0]), Formato: strings.TrimSpace(partes[2])}
+		if len(partes) == 4 {
+			m.Metrica = strings.TrimSpace(partes[3])
+		}
+
+		ambiente := strings.TrimSpace(partes[1])
+		if ambiente == ambienteDoTopico {
+			if indiceCuringa(m.Filtro) < 0 {
+				return nil, fmt.Errorf("mapeamento MQTT %q usa o ambiente do tópico, mas o filtro não tem \"+\"", entrada)
+			}
+		} else {
+			id, err := strconv.ParseUint(ambiente, 10, 32)
+			if err != nil || id == 0 {
+				return nil, fmt.Errorf("mapeamento MQTT %q com ambiente inválido", entrada)
+			}
+			m.AmbienteID = uint(id)
+		}
+
+		switch m.Formato {
+		case FormatoJSON, FormatoTasmota:
+		case FormatoValor:
+			var teste medicoes
+			if !atribuir(&teste, m.Metrica, 0) {
+				return nil, fmt.Errorf("mapeamento MQTT %q no formato valor exige uma métrica conhecida", entrada)
+			}
+		default:
+			return nil, fmt.Errorf("mapeamento MQTT %q com formato desconhecido", entrada)
+		}
+		mapeamentos = append(mapeamentos, m)
+	}
+	return mapeamentos, nil
+}
+
+// ambiente resolve o ambiente de uma mensagem recebida no tópico informado
+func (m Mapeamento) ambiente(topico string) (uint, error) {
+	if m.AmbienteID != 0 {
+		return m.AmbienteID, nil
+	}
+	niveis := strings.Split(topico, "/")
+	indice := indiceCuringa(m.Filtro)
+	if indice < 0 || indice >= len(niveis) {
+		return 0, fmt.Errorf("tópico %q não corresponde ao filtro %q", topico, m.Filtro)
+	}
+	id, err := strconv.ParseUint(niveis[indice], 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("tópico %q sem ID de ambiente válido", topico)
+	}
+	return uint(id), nil
+}
+
+// indiceCuringa retorna a posição do primeiro nível "+" do filtro, ou -1
+func indiceCuringa(filtro string) int {
+	for i, nivel := range strings.Split(filtro, "/") {
+		if nivel == "+" {
+			return i
+		}
+	}
+	return -1
+}
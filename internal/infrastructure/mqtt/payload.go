@@ -0,0 +1,161 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatos de payload aceitos nos tópicos mapeados
+const (
+	// FormatoJSON é o JSON da API (dto.LeituraMicroclimaDTO): {"temperatura": 24.5, "umidade": 60, ...}
+	FormatoJSON = "json"
+	// FormatoTasmota é a telemetria tele/<dispositivo>/SENSOR do Tasmota: {"AM2301": {"Temperature": 24.5, "Humidity": 60}}
+	FormatoTasmota = "tasmota"
+	// FormatoValor é um número puro, como nos tópicos <no>/sensor/<nome>/state do ESPHome
+	FormatoValor = "valor"
+)
+
+// Métricas aceitas no formato valor
+const (
+	MetricaTemperatura  = "temperatura"
+	MetricaUmidade      = "umidade"
+	MetricaLuminosidade = "luminosidade"
+	MetricaCO2          = "co2"
+	MetricaUmidadeSolo  = "umidade_solo"
+	MetricaPPFD         = "ppfd"
+)
+
+// medicoes é uma leitura possivelmente parcial; campos nulos não vieram no payload
+type medicoes struct {
+	DataMedicao  *time.Time `json:"data_medicao"`
+	Temperatura  *float64   `json:"temperatura"`
+	Umidade      *float64   `json:"umidade"`
+	Luminosidade *float64   `json:"luminosidade"`
+	CO2          *float64   `json:"co2"`
+	UmidadeSolo  *float64   `json:"umidade_solo"`
+	PPFD         *float64   `json:"ppfd"`
+}
+
+// completa indica se já há temperatura e umidade, obrigatórias em um Microclima
+func (m *medicoes) completa() bool {
+	return m.Temperatura != nil && m.Umidade != nil
+}
+
+// mesclar sobrescreve os campos com os valores presentes em outra leitura
+func (m *medicoes) mesclar(outra medicoes) {
+	for _, par := range []struct{ destino, origem **float64 }{
+		{&m.Temperatura, &outra.Temperatura},
+		{&m.Umidade, &outra.Umidade},
+		{&m.Luminosidade, &outra.Luminosidade},
+		{&m.CO2, &outra.CO2},
+		{&m.UmidadeSolo, &outra.UmidadeSolo},
+		{&m.PPFD, &outra.PPFD},
+	} {
+		if *par.origem != nil {
+			*par.destino = *par.origem
+		}
+	}
+	if outra.DataMedicao != nil {
+		m.DataMedicao = outra.DataMedicao
+	}
+}
+
+// camposTasmota mapeia os nomes de campo da telemetria do Tasmota para as métricas
+var camposTasmota = map[string]string{
+	"Temperature":   MetricaTemperatura,
+	"Humidity":      MetricaUmidade,
+	"Illuminance":   MetricaLuminosidade,
+	"CarbonDioxide": MetricaCO2,
+	"eCO2":          MetricaCO2,
+	"Moisture":      MetricaUmidadeSolo,
+}
+
+// decodificar interpreta o payload conforme o formato do mapeamento
+func decodificar(formato, metrica string, payload []byte) (medicoes, error) {
+	switch formato {
+	case FormatoJSON:
+		var m medicoes
+		if err := json.Unmarshal(payload, &m); err != nil {
+			return medicoes{}, fmt.Errorf("payload JSON inválido: %w", err)
+		}
+		return m, nil
+	case FormatoTasmota:
+		return decodificarTasmota(payload)
+	case FormatoValor:
+		valor, err := strconv.ParseFloat(strings.TrimSpace(string(payload)), 64)
+		if err != nil {
+			return medicoes{}, fmt.Errorf("valor numérico inválido: %w", err)
+		}
+		var m medicoes
+		if !atribuir(&m, metrica, valor) {
+			return medicoes{}, fmt.Errorf("métrica %q desconhecida", metrica)
+		}
+		return m, nil
+	}
+	return medicoes{}, fmt.Errorf("formato %q desconhecido", formato)
+}
+
+// decodificarTasmota procura os campos conhecidos em cada sensor da telemetria.
+// Com vários sensores do mesmo tipo, prevalece o último em ordem alfabética.
+func decodificarTasmota(payload []byte) (medicoes, error) {
+	var telemetria map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &telemetria); err != nil {
+		return medicoes{}, fmt.Errorf("telemetria Tasmota inválida: %w", err)
+	}
+
+	nomes := make([]string, 0, len(telemetria))
+	for nome := range telemetria {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+
+	var m medicoes
+	encontrou := false
+	for _, nome := range nomes {
+		var sensor map[string]json.RawMessage
+		if json.Unmarshal(telemetria[nome], &sensor) != nil {
+			continue // campos como Time e TempUnit não são sensores
+		}
+		for campo, valorBruto := range sensor {
+			metrica, ok := camposTasmota[campo]
+			if !ok {
+				continue
+			}
+			var valor float64
+			if json.Unmarshal(valorBruto, &valor) != nil {
+				continue
+			}
+			atribuir(&m, metrica, valor)
+			encontrou = true
+		}
+	}
+	if !encontrou {
+		return medicoes{}, errors.New("telemetria Tasmota sem campos de sensor conhecidos")
+	}
+	return m, nil
+}
+
+func atribuir(m *medicoes, metrica string, valor float64) bool {
+	switch metrica {
+	case MetricaTemperatura:
+		m.Temperatura = &valor
+	case MetricaUmidade:
+		m.Umidade = &valor
+	case MetricaLuminosidade:
+		m.Luminosidade = &valor
+	case MetricaCO2:
+		m.CO2 = &valor
+	case MetricaUmidadeSolo:
+		m.UmidadeSolo = &valor
+	case MetricaPPFD:
+		m.PPFD = &valor
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,227 @@
+// Package mqtt liga sensores e atuadores que falam MQTT (Tasmota, ESPHome, firmwares próprios)
+// à API: leituras recebidas nos tópicos mapeados viram Microclima e comandos são publicados
+// nos tópicos dos atuadores.
+package mqtt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/sirupsen/logrus"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+)
+
+// timeoutPublicacao limita a espera pela confirmação do broker ao publicar comandos
+const timeoutPublicacao = 5 * time.Second
+
+// Config reúne os parâmetros de conexão e os mapeamentos de tópicos
+type Config struct {
+	Broker             string
+	ClientID           string
+	Usuario            string
+	Senha              string
+	Mapeamentos        []Mapeamento
+	PrefixosComando    []string
+	IntervaloReconexao time.Duration // padrão: 10s
+	// IdadeMaximaParcial é quanto uma leitura parcial espera pelas métricas que faltam (padrão: 5min).
+	// Métricas publicadas sozinhas (ex.: só CO2) são registradas com a próxima temperatura e umidade
+	// do ambiente; se elas não chegarem nesse prazo, a parcial é descartada.
+	IdadeMaximaParcial time.Duration
+}
+
+// Ponte mantém a conexão com o broker, converte mensagens dos sensores em leituras
+// de microclima e publica comandos para os atuadores.
+type Ponte struct {
+	config     Config
+	cliente    paho.Client
+	microclima service.MicroclimaService
+
+	mu          sync.Mutex
+	acumuladas  map[uint]*parcial // leituras parciais por ambiente, até reunir temperatura e umidade
+	conectado   chan struct{}
+	conectouUma sync.Once
+	agora       func() time.Time
+}
+
+// parcial é uma leitura ainda sem temperatura ou umidade, com o instante da primeira métrica
+type parcial struct {
+	medicoes
+	desde time.Time
+}
+
+// NewPonte cria a ponte sem conectar. As leituras são registradas pelo MicroclimaService,
+// então passam pelas mesmas validações e regras de alerta da ingestão HTTP.
+func NewPonte(config Config, microclima service.MicroclimaService) *Ponte {
+	if config.IntervaloReconexao <= 0 {
+		config.IntervaloReconexao = 10 * time.Second
+	}
+	if config.IdadeMaximaParcial <= 0 {
+		config.IdadeMaximaParcial = 5 * time.Minute
+	}
+	p := &Ponte{
+		config:     config,
+		microclima: microclima,
+		acumuladas: make(map[uint]*parcial),
+		conectado:  make(chan struct{}),
+		agora:      time.Now,
+	}
+
+	opcoes := paho.NewClientOptions().
+		AddBroker(config.Broker).
+		SetClientID(config.ClientID).
+		SetUsername(config.Usuario).
+		SetPassword(config.Senha).
+		SetAutoReconnect(true).
+		SetOrderMatters(false). // o registro pode disparar alertas; não bloqueia as demais mensagens
+		SetConnectRetry(true).
+		SetConnectRetryInterval(config.IntervaloReconexao).
+		SetOnConnectHandler(p.aoConectar).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			logrus.WithError(err).Warn("Conexão com o broker MQTT perdida")
+		})
+	p.cliente = paho.NewClient(opcoes)
+	return p
+}
+
+// Conectar inicia a conexão em segundo plano; se o broker estiver indisponível,
+// novas tentativas são feitas a cada IntervaloReconexao.
+func (p *Ponte) Conectar() {
+	p.cliente.Connect()
+}
+
+// AguardarConexao bloqueia até a primeira conexão (com inscrições feitas) ou o timeout
+func (p *Ponte) AguardarConexao(timeout time.Duration) bool {
+	select {
+	case <-p.conectado:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Desconectar encerra a conexão aguardando até 250ms pelo envio de mensagens pendentes
+func (p *Ponte) Desconectar() {
+	p.cliente.Disconnect(250)
+}
+
+// Publicar envia um comando para um atuador. Apenas tópicos sob os prefixos configurados são aceitos.
+func (p *Ponte) Publicar(topico string, payload []byte, retain bool) error {
+	if !p.topicoPermitido(topico) {
+		return fmt.Errorf("tópico %q fora dos prefixos de comando permitidos: %w", topico, utils.ErrInvalidInput)
+	}
+	if !p.cliente.IsConnectionOpen() {
+		return errors.New("broker MQTT indisponível")
+	}
+	token := p.cliente.Publish(topico, 1, retain, payload)
+	if !token.WaitTimeout(timeoutPublicacao) {
+		return fmt.Errorf("tempo esgotado ao publicar no tópico %q", topico)
+	}
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("falha ao publicar no tópico %q: %w", topico, err)
+	}
+	return nil
+}
+
+func (p *Ponte) topicoPermitido(topico string) bool {
+	if topico == "" || strings.ContainsAny(topico, "+#") {
+		return false
+	}
+	for _, prefixo := range p.config.PrefixosComando {
+		if prefixo != "" && strings.HasPrefix(topico, prefixo) {
+			return true
+		}
+	}
+	return false
+}
+
+// aoConectar refaz as inscrições a cada (re)conexão, já que a sessão não é persistente
+func (p *Ponte) aoConectar(cliente paho.Client) {
+	logrus.WithField("broker", p.config.Broker).Info("Conectado ao broker MQTT")
+	for _, mapeamento := range p.config.Mapeamentos {
+		mapeamento := mapeamento
+		token := cliente.Subscribe(mapeamento.Filtro, 1, func(_ paho.Client, msg paho.Message) {
+			p.processar(mapeamento, msg.Topic(), msg.Payload())
+		})
+		if token.WaitTimeout(timeoutPublicacao) && token.Error() != nil {
+			logrus.WithError(token.Error()).WithField("topico", mapeamento.Filtro).Error("Falha ao inscrever no tópico MQTT")
+		}
+	}
+	p.conectouUma.Do(func() { close(p.conectado) })
+}
+
+// processar converte a mensagem e registra a leitura quando houver temperatura e umidade.
+// Métricas publicadas em tópicos separados (ex.: ESPHome) são acumuladas por ambiente por até
+// IdadeMaximaParcial; parciais mais antigas são descartadas para não misturar medições distantes.
+func (p *Ponte) processar(mapeamento Mapeamento, topico string, payload []byte) {
+	log := logrus.WithField("topico", topico)
+
+	ambienteID, err := mapeamento.ambiente(topico)
+	if err != nil {
+		log.WithError(err).Warn("Mensagem MQTT ignorada")
+		return
+	}
+	recebidas, err := decodificar(mapeamento.Formato, mapeamento.Metrica, payload)
+	if err != nil {
+		log.WithError(err).Warn("Mensagem MQTT ignorada")
+		return
+	}
+
+	p.mu.Lock()
+	p.descartarExpiradas()
+	acumulada, ok := p.acumuladas[ambienteID]
+	if !ok {
+		acumulada = &parcial{desde: p.agora()}
+		p.acumuladas[ambienteID] = acumulada
+	}
+	acumulada.mesclar(recebidas)
+	if !acumulada.completa() {
+		p.mu.Unlock()
+		return
+	}
+	leitura := paraLeitura(acumulada.medicoes)
+	delete(p.acumuladas, ambienteID)
+	p.mu.Unlock()
+
+	if err := binding.Validator.ValidateStruct(&leitura); err != nil {
+		log.WithError(err).WithField("ambiente_id", ambienteID).Warn("Leitura MQTT fora dos limites aceitos")
+		return
+	}
+	if _, err := p.microclima.RegistrarLeitura(ambienteID, &leitura); err != nil {
+		log.WithError(err).WithField("ambiente_id", ambienteID).Error("Falha ao registrar leitura recebida por MQTT")
+	}
+}
+
+// descartarExpiradas remove as parciais que passaram de IdadeMaximaParcial; chamada com p.mu travado
+func (p *Ponte) descartarExpiradas() {
+	limite := p.agora().Add(-p.config.IdadeMaximaParcial)
+	for ambienteID, acumulada := range p.acumuladas {
+		if acumulada.desde.Before(limite) {
+			logrus.WithField("ambiente_id", ambienteID).
+				Warn("Leitura MQTT parcial descartada: temperatura e umidade não chegaram a tempo")
+			delete(p.acumuladas, ambienteID)
+		}
+	}
+}
+
+func paraLeitura(m medicoes) dto.LeituraMicroclimaDTO {
+	leitura := dto.LeituraMicroclimaDTO{
+		DataMedicao: m.DataMedicao,
+		Temperatura: m.Temperatura,
+		Umidade:     m.Umidade,
+		CO2:         m.CO2,
+		UmidadeSolo: m.UmidadeSolo,
+		PPFD:        m.PPFD,
+	}
+	if m.Luminosidade != nil {
+		leitura.Luminosidade = *m.Luminosidade
+	}
+	return leitura
+}
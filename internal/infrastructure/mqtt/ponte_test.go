@@ -0,0 +1,194 @@
+package mqtt
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/dto"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
+)
+
+// microclimaFake registra as leituras recebidas pela ponte
+type microclimaFake struct {
+	service.MicroclimaService
+	leituras chan leituraRecebida
+}
+
+type leituraRecebida struct {
+	ambienteID uint
+	leitura    dto.LeituraMicroclimaDTO
+}
+
+func (f *microclimaFake) RegistrarLeitura(ambienteID uint, leituraDto *dto.LeituraMicroclimaDTO) (*entity.Microclima, error) {
+	f.leituras <- leituraRecebida{ambienteID, *leituraDto}
+	return &entity.Microclima{}, nil
+}
+
+// iniciarBroker sobe um broker MQTT embarcado em uma porta livre
+func iniciarBroker(t *testing.T) (*mochi.Server, string) {
+	t.Helper()
+	broker := mochi.New(&mochi.Options{InlineClient: true})
+	require.NoError(t, broker.AddHook(new(auth.AllowHook), nil))
+	tcp := listeners.NewTCP(listeners.Config{ID: "teste", Address: "127.0.0.1:0"})
+	require.NoError(t, broker.AddListener(tcp))
+	go func() { _ = broker.Serve() }()
+	t.Cleanup(func() { _ = broker.Close() })
+	return broker, "tcp://" + tcp.Address()
+}
+
+func TestPonte_LeiturasEComandos(t *testing.T) {
+	broker, endereco := iniciarBroker(t)
+	microclima := &microclimaFake{leituras: make(chan leituraRecebida, 10)}
+
+	mapeamentos, err := ParsearMapeamentos(
+		"cultivo/ambientes/+/microclima|+|json;" +
+			"tele/tenda2/SENSOR|2|tasmota;" +
+			"tenda3/sensor/temperatura/state|3|valor|temperatura;" +
+			"tenda3/sensor/umidade/state|3|valor|umidade;" +
+			"tenda3/sensor/co2/state|3|valor|co2")
+	require.NoError(t, err)
+
+	ponte := NewPonte(Config{
+		Broker:          endereco,
+		ClientID:        "cultivo-api-teste",
+		Mapeamentos:     mapeamentos,
+		PrefixosComando: []string{"cmnd/"},
+	}, microclima)
+	ponte.Conectar()
+	defer ponte.Desconectar()
+	require.True(t, ponte.AguardarConexao(5*time.Second), "ponte não conectou ao broker embarcado")
+
+	receber := func() leituraRecebida {
+		t.Helper()
+		select {
+		case recebida := <-microclima.leituras:
+			return recebida
+		case <-time.After(5 * time.Second):
+			t.Fatal("leitura não recebida")
+			return leituraRecebida{}
+		}
+	}
+
+	t.Run("JSON com ambiente no tópico", func(t *testing.T) {
+		require.NoError(t, broker.Publish("cultivo/ambientes/7/microclima", []byte(`{"temperatura": 25.5, "umidade": 60, "co2": 900}`), false, 1))
+
+		recebida := receber()
+		assert.Equal(t, uint(7), recebida.ambienteID)
+		assert.Equal(t, 25.5, *recebida.leitura.Temperatura)
+		assert.Equal(t, 900.0, *recebida.leitura.CO2)
+	})
+
+	t.Run("Telemetria Tasmota", func(t *testing.T) {
+		payload := `{"Time":"2026-05-01T12:00:00","AM2301":{"Temperature":24.3,"Humidity":55.1,"DewPoint":14.7},"TempUnit":"C"}`
+		require.NoError(t, broker.Publish("tele/tenda2/SENSOR", []byte(payload), false, 1))
+
+		recebida := receber()
+		assert.Equal(t, uint(2), recebida.ambienteID)
+		assert.Equal(t, 24.3, *recebida.leitura.Temperatura)
+		assert.Equal(t, 55.1, *recebida.leitura.Umidade)
+	})
+
+	t.Run("Valores ESPHome acumulados por ambiente", func(t *testing.T) {
+		require.NoError(t, broker.Publish("tenda3/sensor/co2/state", []byte("1200"), false, 1))
+		require.NoError(t, broker.Publish("tenda3/sensor/temperatura/state", []byte("26.0"), false, 1))
+		require.Never(t, func() bool { return len(microclima.leituras) > 0 }, 200*time.Millisecond, 20*time.Millisecond,
+			"leitura registrada sem umidade")
+		require.NoError(t, broker.Publish("tenda3/sensor/umidade/state", []byte("58"), false, 1))
+
+		recebida := receber()
+		assert.Equal(t, uint(3), recebida.ambienteID)
+		assert.Equal(t, 26.0, *recebida.leitura.Temperatura)
+		assert.Equal(t, 58.0, *recebida.leitura.Umidade)
+		assert.Equal(t, 1200.0, *recebida.leitura.CO2)
+	})
+
+	t.Run("Leitura fora dos limites é descartada", func(t *testing.T) {
+		require.NoError(t, broker.Publish("cultivo/ambientes/7/microclima", []byte(`{"temperatura": 25, "umidade": 140}`), false, 1))
+		require.Never(t, func() bool { return len(microclima.leituras) > 0 }, 200*time.Millisecond, 20*time.Millisecond)
+	})
+
+	t.Run("Publica comando", func(t *testing.T) {
+		var mu sync.Mutex
+		var recebido []byte
+		require.NoError(t, broker.Subscribe("cmnd/#", 1, func(_ *mochi.Client, _ packets.Subscription, pk packets.Packet) {
+			mu.Lock()
+			defer mu.Unlock()
+			recebido = pk.Payload
+		}))
+
+		require.NoError(t, ponte.Publicar("cmnd/exaustor/POWER", []byte("ON"), false))
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return string(recebido) == "ON"
+		}, 5*time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("Comando fora dos prefixos permitidos", func(t *testing.T) {
+		err := ponte.Publicar("tele/tenda2/SENSOR", []byte("{}"), false)
+		assert.ErrorIs(t, err, utils.ErrInvalidInput)
+	})
+}
+
+func TestPonte_ParciaisExpiradas(t *testing.T) {
+	microclima := &microclimaFake{leituras: make(chan leituraRecebida, 10)}
+	mapeamentos, err := ParsearMapeamentos(
+		"tenda3/sensor/temperatura/state|3|valor|temperatura;" +
+			"tenda3/sensor/umidade/state|3|valor|umidade;" +
+			"tenda3/sensor/co2/state|3|valor|co2")
+	require.NoError(t, err)
+	ponte := NewPonte(Config{Broker: "tcp://127.0.0.1:0", Mapeamentos: mapeamentos, IdadeMaximaParcial: time.Minute}, microclima)
+	agora := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	ponte.agora = func() time.Time { return agora }
+	temperatura, umidade, co2 := mapeamentos[0], mapeamentos[1], mapeamentos[2]
+
+	// o CO2 publicado sozinho espera a temperatura e a umidade, mas não para sempre
+	ponte.processar(co2, "tenda3/sensor/co2/state", []byte("1200"))
+	ponte.processar(temperatura, "tenda3/sensor/temperatura/state", []byte("26"))
+	agora = agora.Add(2 * time.Minute)
+	ponte.processar(umidade, "tenda3/sensor/umidade/state", []byte("58"))
+	assert.Empty(t, microclima.leituras, "leitura registrada com métricas de uma parcial expirada")
+
+	// dentro do prazo, a métrica avulsa vai junto com a próxima leitura completa
+	ponte.processar(co2, "tenda3/sensor/co2/state", []byte("900"))
+	ponte.processar(temperatura, "tenda3/sensor/temperatura/state", []byte("25"))
+	require.Len(t, microclima.leituras, 1)
+	recebida := <-microclima.leituras
+	assert.Equal(t, 25.0, *recebida.leitura.Temperatura)
+	assert.Equal(t, 58.0, *recebida.leitura.Umidade)
+	assert.Equal(t, 900.0, *recebida.leitura.CO2)
+}
+
+func TestParsearMapeamentos(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mapeamentos, err := ParsearMapeamentos("a/+/b|+|json; c/d|4|valor|ppfd ;")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Mapeamento{
+			{Filtro: "a/+/b", Formato: FormatoJSON},
+			{Filtro: "c/d", AmbienteID: 4, Formato: FormatoValor, Metrica: MetricaPPFD},
+		}, mapeamentos)
+	})
+
+	for nome, texto := range map[string]string{
+		"Error - Ambiente do Tópico Sem Curinga": "a/b|+|json",
+		"Error - Valor Sem Métrica":              "a/b|1|valor",
+		"Error - Formato Desconhecido":           "a/b|1|xml",
+		"Error - Ambiente Inválido":              "a/b|zero|json",
+	} {
+		t.Run(nome, func(t *testing.T) {
+			_, err := ParsearMapeamentos(texto)
+			assert.Error(t, err)
+		})
+	}
+}
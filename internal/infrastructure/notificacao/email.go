@@ -0,0 +1,75 @@
+package notificacao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+)
+
+// ConfigSMTP reúne os dados de acesso ao servidor de e-mail
+type ConfigSMTP struct {
+	Host      string
+	Porta     string
+	Usuario   string
+	Senha     string
+	Remetente string
+}
+
+// CanalEmail entrega notificações por e-mail via SMTP.
+type CanalEmail struct {
+	config ConfigSMTP
+	enviar func(endereco string, auth smtp.Auth, de string, para []string, msg []byte) error
+}
+
+// NewCanalEmail cria o canal de e-mail com o servidor SMTP informado
+func NewCanalEmail(config ConfigSMTP) *CanalEmail {
+	return &CanalEmail{config: config, enviar: smtp.SendMail}
+}
+
+func (c *CanalEmail) Nome() string {
+	return service.CanalEmail
+}
+
+// Enviar envia o e-mail. smtp.SendMail não aceita contexto, então o prazo só é
+// verificado antes do envio.
+func (c *CanalEmail) Enviar(ctx context.Context, notificacao service.Notificacao) error {
+	if notificacao.Destino == "" {
+		return errors.New("e-mail de destino não informado")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if c.config.Usuario != "" {
+		auth = smtp.PlainAuth("", c.config.Usuario, c.config.Senha, c.config.Host)
+	}
+
+	if err := c.enviar(net.JoinHostPort(c.config.Host, c.config.Porta), auth, c.config.Remetente, []string{notificacao.Destino}, c.mensagem(notificacao)); err != nil {
+		return fmt.Errorf("falha ao enviar e-mail: %w", err)
+	}
+	return nil
+}
+
+func (c *CanalEmail) mensagem(notificacao service.Notificacao) []byte {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", c.config.Remetente)
+	fmt.Fprintf(&msg, "To: %s\r\n", notificacao.Destino)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", limparCabecalho(notificacao.Titulo)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(notificacao.Mensagem)
+	msg.WriteString("\r\n")
+	return []byte(msg.String())
+}
+
+// limparCabecalho evita quebras de linha que permitiriam injetar cabeçalhos
+func limparCabecalho(valor string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(valor)
+}
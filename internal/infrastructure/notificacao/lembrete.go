@@ -0,0 +1,29 @@
+package notificacao
+
+import (
+	"context"
+	"fmt"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+)
+
+// CanalLembrete entrega notificações na central de notificações do próprio usuário no sistema.
+type CanalLembrete struct {
+	central service.NotificacaoService
+}
+
+// NewCanalLembrete cria o canal que publica notificações na central do usuário
+func NewCanalLembrete(central service.NotificacaoService) *CanalLembrete {
+	return &CanalLembrete{central: central}
+}
+
+func (c *CanalLembrete) Nome() string {
+	return service.CanalLembrete
+}
+
+func (c *CanalLembrete) Enviar(_ context.Context, notificacao service.Notificacao) error {
+	if _, err := c.central.Publicar(notificacao); err != nil {
+		return fmt.Errorf("falha ao publicar notificação: %w", err)
+	}
+	return nil
+}
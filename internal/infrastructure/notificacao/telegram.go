@@ -0,0 +1,89 @@
+package notificacao
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+)
+
+// CanalTelegram entrega notificações pela API de bots do Telegram (sendMessage). A URL base
+// é configurável para apontar para servidores compatíveis ou para um servidor falso em testes.
+type CanalTelegram struct {
+	cliente *http.Client
+	urlBase string
+	token   string
+}
+
+// NewCanalTelegram cria o canal do bot com o token informado. Se cliente for nil, usa
+// http.DefaultClient; o prazo de cada envio vem do contexto.
+func NewCanalTelegram(urlBase, token string, cliente *http.Client) *CanalTelegram {
+	if cliente == nil {
+		cliente = http.DefaultClient
+	}
+	return &CanalTelegram{cliente: cliente, urlBase: strings.TrimSuffix(urlBase, "/"), token: token}
+}
+
+func (c *CanalTelegram) Nome() string {
+	return service.CanalTelegram
+}
+
+type mensagemTelegram struct {
+	ChatID string `json:"chat_id"`
+	Texto  string `json:"text"`
+}
+
+type respostaTelegram struct {
+	OK        bool   `json:"ok"`
+	Descricao string `json:"description"`
+}
+
+func (c *CanalTelegram) Enviar(ctx context.Context, notificacao service.Notificacao) error {
+	if notificacao.Destino == "" {
+		return errors.New("chat do Telegram não informado")
+	}
+	texto := notificacao.Mensagem
+	if notificacao.Titulo != "" {
+		texto = notificacao.Titulo + "\n" + notificacao.Mensagem
+	}
+	corpo, err := json.Marshal(mensagemTelegram{ChatID: notificacao.Destino, Texto: texto})
+	if err != nil {
+		return fmt.Errorf("falha ao serializar mensagem do Telegram: %w", err)
+	}
+
+	endereco := c.urlBase + "/bot" + c.token + "/sendMessage"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endereco, bytes.NewReader(corpo))
+	if err != nil {
+		// o erro traria a URL com o token do bot
+		return errors.New("falha ao montar requisição do Telegram")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.cliente.Do(req)
+	if err != nil {
+		// *url.Error traz a URL com o token do bot; só a causa é repassada
+		var errURL *url.Error
+		if errors.As(err, &errURL) {
+			err = errURL.Err
+		}
+		return fmt.Errorf("falha ao chamar a API do Telegram: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var resposta respostaTelegram
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&resposta)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || !resposta.OK {
+		if resposta.Descricao != "" {
+			return fmt.Errorf("API do Telegram respondeu com status %d: %s", resp.StatusCode, resposta.Descricao)
+		}
+		return fmt.Errorf("API do Telegram respondeu com status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package notificacao
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+)
+
+func TestCanalTelegram_Enviar(t *testing.T) {
+	var recebida mensagemTelegram
+	var caminho string
+	servidor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caminho = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&recebida))
+		_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer servidor.Close()
+
+	canal := NewCanalTelegram(servidor.URL+"/", "123:abc", servidor.Client())
+	err := canal.Enviar(context.Background(), service.Notificacao{Titulo: "Lembrete", Mensagem: "Medir pH", Destino: "42"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "/bot123:abc/sendMessage", caminho)
+	assert.Equal(t, "42", recebida.ChatID)
+	assert.Equal(t, "Lembrete\nMedir pH", recebida.Texto)
+}
+
+func TestCanalTelegram_EnviarErros(t *testing.T) {
+	t.Run("Error - Chat Não Informado", func(t *testing.T) {
+		canal := NewCanalTelegram("http://localhost", "token", nil)
+		assert.Error(t, canal.Enviar(context.Background(), service.Notificacao{Mensagem: "x"}))
+	})
+
+	t.Run("Error - API Recusa", func(t *testing.T) {
+		servidor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"ok":false,"description":"Bad Request: chat not found"}`))
+		}))
+		defer servidor.Close()
+
+		err := NewCanalTelegram(servidor.URL, "segredo", servidor.Client()).Enviar(context.Background(), service.Notificacao{Mensagem: "x", Destino: "1"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "chat not found")
+	})
+
+	t.Run("Error - Falha de Conexão Não Expõe o Token", func(t *testing.T) {
+		servidor := httptest.NewServer(http.NotFoundHandler())
+		servidor.Close()
+
+		err := NewCanalTelegram(servidor.URL, "segredo", nil).Enviar(context.Background(), service.Notificacao{Mensagem: "x", Destino: "1"})
+
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "segredo")
+	})
+}
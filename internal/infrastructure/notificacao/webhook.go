@@ -0,0 +1,59 @@
+package notificacao
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+)
+
+// CanalWebhook entrega notificações com um POST JSON para a URL de destino.
+type CanalWebhook struct {
+	cliente *http.Client
+}
+
+// NewCanalWebhook cria o canal de webhook. Se cliente for nil, usa http.DefaultClient;
+// o prazo de cada envio vem do contexto.
+func NewCanalWebhook(cliente *http.Client) *CanalWebhook {
+	if cliente == nil {
+		cliente = http.DefaultClient
+	}
+	return &CanalWebhook{cliente: cliente}
+}
+
+func (c *CanalWebhook) Nome() string {
+	return service.CanalWebhook
+}
+
+func (c *CanalWebhook) Enviar(ctx context.Context, notificacao service.Notificacao) error {
+	if notificacao.Destino == "" {
+		return errors.New("URL do webhook não informada")
+	}
+	corpo, err := json.Marshal(notificacao)
+	if err != nil {
+		return fmt.Errorf("falha ao serializar notificação: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notificacao.Destino, bytes.NewReader(corpo))
+	if err != nil {
+		return fmt.Errorf("falha ao montar requisição do webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.cliente.Do(req)
+	if err != nil {
+		return fmt.Errorf("falha ao chamar webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook respondeu com status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type diarioCultivoRepository struct {
@@ -17,9 +18,11 @@ func (r *diarioCultivoRepository) Create(diarioCultivo *entity.DiarioCultivo) er
 	return r.db.Create(diarioCultivo).Error
 }
 
+// GetByID carrega o diário com as plantas e os ambientes acompanhados, usados nos relatórios
+// por ciclo (energia, regas e custos)
 func (r *diarioCultivoRepository) GetByID(id uint) (*entity.DiarioCultivo, error) {
 	var diarioCultivo entity.DiarioCultivo
-	err := r.db.First(&diarioCultivo, id).Error
+	err := r.db.Preload("Plantas").Preload("Ambientes").First(&diarioCultivo, id).Error
 	return &diarioCultivo, err
 }
 
@@ -41,8 +44,10 @@ func (r *diarioCultivoRepository) GetAllByUserID(userID uint) ([]entity.DiarioCu
 	return diariosCultivo, err
 }
 
+// Update salva só os campos do diário; as plantas e os ambientes carregados por GetByID não são
+// regravados
 func (r *diarioCultivoRepository) Update(diarioCultivo *entity.DiarioCultivo) error {
-	return r.db.Save(diarioCultivo).Error
+	return r.db.Omit(clause.Associations).Save(diarioCultivo).Error
 }
 
 func (r *diarioCultivoRepository) Delete(id uint) error {
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestDB cria um banco SQLite em memória com as tabelas do diário e das associações dele
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.Usuario{}, &entity.Ambiente{}, &entity.Planta{}, &entity.DiarioCultivo{}))
+	return db
+}
+
+func TestDiarioCultivoRepository_GetByID(t *testing.T) {
+	t.Run("Success - Carrega Plantas e Ambientes do Diário", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewDiarioCultivoRepository(db)
+
+		plantio := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		ambiente := entity.Ambiente{Nome: "Tenda 80", Tipo: "interno", Comprimento: 80, Altura: 160, Largura: 80, TempoExposicao: 18, Orientacao: "norte"}
+		require.NoError(t, db.Create(&ambiente).Error)
+		plantas := []entity.Planta{
+			{Nome: "Gelato #1", ComecandoDe: "semente", Especie: "indica", DataPlantio: &plantio, Status: "ativa", AmbienteID: ambiente.ID, UsuarioID: 1},
+			{Nome: "Gelato #2", ComecandoDe: "semente", Especie: "indica", DataPlantio: &plantio, Status: "ativa", AmbienteID: ambiente.ID, UsuarioID: 1},
+		}
+		require.NoError(t, db.Omit("Genetica", "MeioCultivo", "Ambiente", "Usuario").Create(&plantas).Error)
+		diario := entity.DiarioCultivo{Nome: "Ciclo de outono", DataInicio: plantio, Privacidade: "privado", UsuarioID: 1}
+		require.NoError(t, db.Omit("Usuario", "Plantas", "Ambientes").Create(&diario).Error)
+		require.NoError(t, db.Exec("INSERT INTO diario_cultivo_plantas (diario_cultivo_id, planta_id) VALUES (?, ?), (?, ?)",
+			diario.ID, plantas[0].ID, diario.ID, plantas[1].ID).Error)
+		require.NoError(t, db.Exec("INSERT INTO diario_ambientes (diario_cultivo_id, ambiente_id) VALUES (?, ?)", diario.ID, ambiente.ID).Error)
+
+		encontrado, err := repo.GetByID(diario.ID)
+
+		require.NoError(t, err)
+		require.Len(t, encontrado.Plantas, 2)
+		assert.Equal(t, "Gelato #1", encontrado.Plantas[0].Nome)
+		require.Len(t, encontrado.Ambientes, 1)
+		assert.Equal(t, ambiente.ID, encontrado.Ambientes[0].ID)
+	})
+
+	t.Run("Success - Update Não Regrava as Associações", func(t *testing.T) {
+		db := setupTestDB(t)
+		repo := NewDiarioCultivoRepository(db)
+
+		diario := entity.DiarioCultivo{Nome: "Ciclo de inverno", DataInicio: time.Now(), Privacidade: "privado", UsuarioID: 1}
+		require.NoError(t, db.Omit("Usuario", "Plantas", "Ambientes").Create(&diario).Error)
+		diario.Nome = "Ciclo de inverno 2"
+		diario.Ambientes = []entity.Ambiente{{Nome: "Não salvo", Tipo: "interno", Comprimento: 1, Altura: 1, Largura: 1, TempoExposicao: 12, Orientacao: "sul"}}
+
+		require.NoError(t, repo.Update(&diario))
+
+		var ambientes int64
+		db.Model(&entity.Ambiente{}).Count(&ambientes)
+		assert.Zero(t, ambientes)
+		encontrado, err := repo.GetByID(diario.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Ciclo de inverno 2", encontrado.Nome)
+	})
+}
@@ -1,13 +1,24 @@
 package server
 
 import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/config"
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/controller"
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/domain/service"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/agendador"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/clima"
 	db_infra "gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/database"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/mqtt"
+	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/notificacao"
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/infrastructure/repository"
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/middleware"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -18,13 +29,21 @@ const (
 	rotasPlantas     = "/plantas"
 	rotaPlantaPorID  = "/:id"
 	rotaUsuarioPorID = "/:id"
+
+	// validadeTicketStream é o prazo para o cliente abrir o stream de notificações com o ticket emitido
+	validadeTicketStream = 30 * time.Second
 )
 
 type Server struct {
 	Router *gin.Engine
+	// Agendador executa as rotinas em segundo plano; é iniciado e parado pelo main
+	Agendador *agendador.Agendador
+	ponteMQTT *mqtt.Ponte
+	// notificacoes mantém os streams SSE, encerrados antes do Shutdown do HTTP
+	notificacoes *controller.NotificacaoController
 }
 
-func NewServer(db *db_infra.Database) *Server {
+func NewServer(db *db_infra.Database, cfg *config.Config) *Server {
 	router := gin.Default()
 
 	// Swagger docs
@@ -34,6 +53,12 @@ func NewServer(db *db_infra.Database) *Server {
 	router.Use(middleware.ErrorHandlerMiddleware())
 	router.Use(middleware.LoggingMiddleware())
 
+	fuso, err := time.LoadLocation(cfg.FusoHorario)
+	if err != nil {
+		logrus.WithError(err).Warnf("Fuso horário %q inválido, usando o fuso local", cfg.FusoHorario)
+		fuso = time.Local
+	}
+
 	// Repositories
 	usuarioRepo := db_infra.NewUsuarioRepositorio(db.DB)
 	plantaRepo := db_infra.NewPlantaRepositorio(db.DB)
@@ -42,6 +67,58 @@ func NewServer(db *db_infra.Database) *Server {
 	meioCultivoRepo := db_infra.NewMeioCultivoRepositorio(db.DB)
 	diarioCultivoRepo := repository.NewDiarioCultivoRepository(db.DB)
 	registroDiarioRepo := repository.NewRegistroDiarioRepositorio(db.DB)
+	microclimaRepo := db_infra.NewMicroclimaRepositorio(db.DB, fuso)
+	estagioRepo := db_infra.NewEstagioCrescimentoRepositorio(db.DB)
+	regraAlertaRepo := db_infra.NewRegraAlertaRepositorio(db.DB)
+	alertaRepo := db_infra.NewAlertaRepositorio(db.DB)
+	lembreteRepo := db_infra.NewLembreteRepositorio(db.DB)
+	preferenciaNotificacaoRepo := db_infra.NewPreferenciaNotificacaoRepositorio(db.DB)
+	notificacaoRepo := db_infra.NewNotificacaoRepositorio(db.DB)
+	fotoperiodoRepo := db_infra.NewFotoperiodoRepositorio(db.DB)
+	equipamentoRepo := db_infra.NewEquipamentoRepositorio(db.DB)
+	climaRegistroRepo := db_infra.NewClimaRegistroRepositorio(db.DB)
+	tarifaEnergiaRepo := db_infra.NewTarifaEnergiaRepositorio(db.DB)
+	posicaoLayoutRepo := db_infra.NewPosicaoLayoutRepositorio(db.DB)
+	vasoRepo := db_infra.NewVasoRepositorio(db.DB)
+	substratoRepo := db_infra.NewSubstratoRepositorio(db.DB)
+	transplanteRepo := db_infra.NewTransplanteRepositorio(db.DB)
+	cruzamentoRepo := db_infra.NewCruzamentoRepositorio(db.DB)
+	levantamentoPPFDRepo := db_infra.NewLevantamentoPPFDRepositorio(db.DB)
+	tarefaRepo := db_infra.NewTarefaRepositorio(db.DB)
+	cronogramaCultivoRepo := db_infra.NewCronogramaCultivoRepositorio(db.DB)
+	aplicacaoCronogramaRepo := db_infra.NewAplicacaoCronogramaRepositorio(db.DB)
+	regaRepo := db_infra.NewRegaRepositorio(db.DB)
+	receitaNutrienteRepo := db_infra.NewReceitaNutrienteRepositorio(db.DB)
+	tabelaNutricaoRepo := db_infra.NewTabelaNutricaoRepositorio(db.DB)
+	reservatorioRepo := db_infra.NewReservatorioRepositorio(db.DB)
+	estoqueRepo := db_infra.NewEstoqueRepositorio(db.DB)
+	sementeRepo := db_infra.NewSementeRepositorio(db.DB)
+
+	// Canais de notificação; o canal lembrete publica na central de notificações do usuário
+	notificacaoService := service.NewNotificacaoService(notificacaoRepo, preferenciaNotificacaoRepo)
+	canalLembrete := notificacao.NewCanalLembrete(notificacaoService)
+	canais := []service.CanalNotificacao{
+		canalLembrete,
+		notificacao.NewCanalWebhook(nil),
+	}
+	if cfg.SMTPHost != "" {
+		canais = append(canais, notificacao.NewCanalEmail(notificacao.ConfigSMTP{
+			Host:      cfg.SMTPHost,
+			Porta:     cfg.SMTPPort,
+			Usuario:   cfg.SMTPUsuario,
+			Senha:     cfg.SMTPSenha,
+			Remetente: cfg.SMTPRemetente,
+		}))
+	}
+	if cfg.TelegramToken != "" {
+		canais = append(canais, notificacao.NewCanalTelegram(cfg.TelegramURL, cfg.TelegramToken, nil))
+	}
+
+	// Provedor de clima dos ambientes externos
+	var provedorClima service.WeatherProvider = clima.NewOpenMeteo(cfg.ClimaURL, nil)
+	if cfg.ClimaArquivo != "" {
+		provedorClima = clima.NewArquivo(cfg.ClimaArquivo)
+	}
 
 	// Services
 	usuarioService := service.NewUsuarioService(usuarioRepo)
@@ -51,6 +128,115 @@ func NewServer(db *db_infra.Database) *Server {
 	meioCultivoService := service.NewMeioCultivoService(meioCultivoRepo)
 	diarioCultivoService := service.NewDiarioCultivoService(diarioCultivoRepo)
 	registroDiarioService := service.NewRegistroDiarioService(registroDiarioRepo, diarioCultivoRepo)
+	alertaService := service.NewAlertaService(regraAlertaRepo, alertaRepo, ambienteRepo, estagioRepo, fuso, canais...)
+	fotoperiodoService := service.NewFotoperiodoService(fotoperiodoRepo, ambienteRepo, estagioRepo, geneticaRepo, fuso, canalLembrete)
+	microclimaService := service.NewMicroclimaService(microclimaRepo, ambienteRepo, estagioRepo, fotoperiodoService, alertaService)
+	cronogramaCultivoService := service.NewCronogramaCultivoService(cronogramaCultivoRepo, aplicacaoCronogramaRepo, tarefaRepo, plantaRepo, estagioRepo, diarioCultivoRepo, fuso)
+	estagioService := service.NewEstagioCrescimentoService(estagioRepo, plantaRepo, fotoperiodoService, cronogramaCultivoService)
+	climaService := service.NewClimaService(climaRegistroRepo, ambienteRepo, provedorClima, fuso)
+	planejamentoSafraService := service.NewPlanejamentoSafraService(ambienteRepo, geneticaRepo, climaRegistroRepo, fuso)
+	energiaService := service.NewEnergiaService(equipamentoRepo, tarifaEnergiaRepo, ambienteRepo, diarioCultivoRepo, fotoperiodoService, fuso)
+	layoutService := service.NewLayoutService(posicaoLayoutRepo, ambienteRepo, plantaRepo, vasoRepo)
+	levantamentoPPFDService := service.NewLevantamentoPPFDService(levantamentoPPFDRepo, ambienteRepo, fotoperiodoService, fuso)
+	estoqueService := service.NewEstoqueService(estoqueRepo, plantaRepo, diarioCultivoRepo, canalLembrete)
+	tarefaService := service.NewTarefaService(tarefaRepo, plantaRepo, ambienteRepo, diarioCultivoRepo, estoqueService, fuso, canalLembrete)
+	// o alarme dos eventos no feed usa a mesma antecedência dos lembretes enviados pelo agendador
+	antecedenciaLembrete := duracaoConfig("TAREFAS_ANTECEDENCIA_LEMBRETE", cfg.TarefasAntecedenciaLembrete, time.Hour)
+	calendarioService := service.NewCalendarioService(usuarioRepo, tarefaRepo, lembreteRepo, plantaRepo, estagioRepo, tarefaService, fuso, antecedenciaLembrete)
+	lembreteService := service.NewLembreteService(lembreteRepo, preferenciaNotificacaoRepo, fuso, canais...)
+	regaService := service.NewRegaService(regaRepo, receitaNutrienteRepo, plantaRepo, diarioCultivoRepo, fuso, estoqueService)
+	tabelaNutricaoService := service.NewTabelaNutricaoService(tabelaNutricaoRepo, plantaRepo, estagioRepo, fuso)
+	reservatorioService := service.NewReservatorioService(reservatorioRepo, plantaRepo, ambienteRepo, tarefaRepo, fuso)
+	sementeService := service.NewSementeService(sementeRepo, geneticaRepo, plantaRepo, ambienteRepo, meioCultivoRepo)
+	vasoService := service.NewVasoService(vasoRepo)
+	substratoService := service.NewSubstratoService(substratoRepo)
+	transplanteService := service.NewTransplanteService(transplanteRepo, plantaRepo, vasoRepo, substratoRepo, regaRepo, estoqueService)
+	cruzamentoService := service.NewCruzamentoService(cruzamentoRepo, geneticaRepo, plantaRepo, sementeRepo)
+
+	// Ponte MQTT (opcional) para sensores e atuadores
+	var ponteMQTT *mqtt.Ponte
+	var publicadorComandos service.PublicadorComandos
+	if cfg.MQTTBroker != "" {
+		mapeamentos, err := mqtt.ParsearMapeamentos(cfg.MQTTTopicos)
+		if err != nil {
+			logrus.WithError(err).Fatal("Configuração MQTT_TOPICOS inválida")
+		}
+		ponteMQTT = mqtt.NewPonte(mqtt.Config{
+			Broker:          cfg.MQTTBroker,
+			ClientID:        cfg.MQTTClientID,
+			Usuario:         cfg.MQTTUsuario,
+			Senha:           cfg.MQTTSenha,
+			Mapeamentos:     mapeamentos,
+			PrefixosComando: strings.Split(cfg.MQTTPrefixosComando, ","),
+		}, microclimaService)
+		ponteMQTT.Conectar()
+		publicadorComandos = ponteMQTT
+	}
+
+	// Agendador das rotinas de tarefas, protegido por advisory locks entre réplicas
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		logrus.WithError(err).Fatal("Falha ao obter o pool de conexões para o agendador")
+	}
+	intervaloAgendador := duracaoConfig("AGENDADOR_INTERVALO", cfg.AgendadorIntervalo, time.Minute)
+	horizonteTarefas := time.Duration(diasConfig("TAREFAS_HORIZONTE_DIAS", cfg.TarefasHorizonteDias, 14)) * 24 * time.Hour
+	agendadorRotinas := agendador.NewAgendador(agendador.NewTravaPostgres(sqlDB),
+		agendador.Rotina{
+			Nome:      "tarefas-recorrentes",
+			Intervalo: intervaloAgendador,
+			Executar: func(context.Context) error {
+				criadas, err := tarefaService.MaterializarRecorrentes(horizonteTarefas)
+				if criadas > 0 {
+					logrus.Infof("Agendador: %d ocorrência(s) de tarefas recorrentes criadas", criadas)
+				}
+				return err
+			},
+		},
+		agendador.Rotina{
+			Nome:      "tarefas-atrasadas",
+			Intervalo: intervaloAgendador,
+			Executar: func(context.Context) error {
+				marcadas, err := tarefaService.AtualizarAtrasadas()
+				if marcadas > 0 {
+					logrus.Infof("Agendador: %d tarefa(s) marcadas como atrasadas", marcadas)
+				}
+				return err
+			},
+		},
+		agendador.Rotina{
+			Nome:      "tarefas-lembretes",
+			Intervalo: intervaloAgendador,
+			Executar: func(context.Context) error {
+				lembradas, err := tarefaService.DispararLembretes(antecedenciaLembrete)
+				if lembradas > 0 {
+					logrus.Infof("Agendador: %d lembrete(s) de tarefas enviados", lembradas)
+				}
+				return err
+			},
+		},
+		agendador.Rotina{
+			Nome:      "lembretes",
+			Intervalo: intervaloAgendador,
+			Executar: func(context.Context) error {
+				entregues, err := lembreteService.DispararPendentes()
+				if entregues > 0 {
+					logrus.Infof("Agendador: %d lembrete(s) entregues", entregues)
+				}
+				return err
+			},
+		},
+		agendador.Rotina{
+			Nome:      "reservatorios-trocas",
+			Intervalo: intervaloAgendador,
+			Executar: func(context.Context) error {
+				criadas, err := reservatorioService.GerarTarefasTroca()
+				if criadas > 0 {
+					logrus.Infof("Agendador: %d tarefa(s) de troca de reservatório criadas", criadas)
+				}
+				return err
+			},
+		},
+	)
 
 	// Controllers
 	controladorUsuario := controller.NewUsuarioController(usuarioService)
@@ -60,6 +246,31 @@ func NewServer(db *db_infra.Database) *Server {
 	controladorMeioCultivo := controller.NewMeioCultivoController(meioCultivoService)
 	controladorDiarioCultivo := controller.NewDiarioCultivoController(diarioCultivoService)
 	controladorRegistroDiario := controller.NewRegistroDiarioController(registroDiarioService)
+	controladorMicroclima := controller.NewMicroclimaController(microclimaService)
+	controladorEstagio := controller.NewEstagioCrescimentoController(estagioService)
+	controladorAlerta := controller.NewAlertaController(alertaService)
+	controladorFotoperiodo := controller.NewFotoperiodoController(fotoperiodoService)
+	controladorEnergia := controller.NewEnergiaController(energiaService)
+	controladorClima := controller.NewClimaController(climaService)
+	controladorPlanejamentoSafra := controller.NewPlanejamentoSafraController(planejamentoSafraService)
+	controladorLayout := controller.NewLayoutController(layoutService)
+	controladorLevantamentoPPFD := controller.NewLevantamentoPPFDController(levantamentoPPFDService)
+	controladorTarefa := controller.NewTarefaController(tarefaService)
+	controladorCronogramaCultivo := controller.NewCronogramaCultivoController(cronogramaCultivoService)
+	controladorCalendario := controller.NewCalendarioController(calendarioService)
+	controladorLembrete := controller.NewLembreteController(lembreteService)
+	ticketsStream := middleware.NewTicketsStream(validadeTicketStream)
+	controladorNotificacao := controller.NewNotificacaoController(notificacaoService, ticketsStream)
+	controladorRega := controller.NewRegaController(regaService)
+	controladorTabelaNutricao := controller.NewTabelaNutricaoController(tabelaNutricaoService)
+	controladorReservatorio := controller.NewReservatorioController(reservatorioService)
+	controladorEstoque := controller.NewEstoqueController(estoqueService)
+	controladorSemente := controller.NewSementeController(sementeService)
+	controladorVaso := controller.NewVasoController(vasoService)
+	controladorSubstrato := controller.NewSubstratoController(substratoService)
+	controladorTransplante := controller.NewTransplanteController(transplanteService)
+	controladorCruzamento := controller.NewCruzamentoController(cruzamentoService)
+	controladorComando := controller.NewComandoController(publicadorComandos)
 
 	// Health check routes
 	healthController := controller.NewHealthController(db.DB)
@@ -71,6 +282,12 @@ func NewServer(db *db_infra.Database) *Server {
 	router.POST(hostRoute+"/usuarios", controladorUsuario.Criar)
 	router.POST(hostRoute+"/login", controladorUsuario.Login)
 
+	// Feed iCalendar (autenticado pelo token secreto na URL, para os aplicativos de calendário)
+	router.GET(hostRoute+"/calendario/feed/:token", controladorCalendario.Feed)
+
+	// Stream de notificações (aceita também um ticket de uso único na URL, para o EventSource do navegador)
+	router.GET(hostRoute+"/notificacoes/stream", middleware.AuthStreamMiddleware(ticketsStream), controladorNotificacao.Stream)
+
 	// Rotas autenticadas
 	authRoutes := router.Group(hostRoute)
 	authRoutes.Use(middleware.AuthMiddleware())
@@ -82,6 +299,8 @@ func NewServer(db *db_infra.Database) *Server {
 		authRoutes.PUT(rotasPlantas+rotaPlantaPorID, controladorPlanta.Atualizar)
 		authRoutes.DELETE(rotasPlantas+rotaPlantaPorID, controladorPlanta.Deletar)
 		authRoutes.POST(rotasPlantas+rotaPlantaPorID+"/registrar-fato", controladorPlanta.RegistrarFato)
+		authRoutes.POST(rotasPlantas+rotaPlantaPorID+"/estagios", controladorEstagio.MudarEstagio)
+		authRoutes.GET(rotasPlantas+rotaPlantaPorID+"/estagios", controladorEstagio.ListarHistorico)
 
 		// Rotas de Ambiente
 		authRoutes.POST("/ambientes", controladorAmbiente.Criar)
@@ -90,12 +309,208 @@ func NewServer(db *db_infra.Database) *Server {
 		authRoutes.PUT("/ambientes/:id", controladorAmbiente.Atualizar)
 		authRoutes.DELETE("/ambientes/:id", controladorAmbiente.Deletar)
 
+		// Rotas de Layout (plantas e vasos na planta baixa do ambiente)
+		authRoutes.POST("/ambientes/:id/layout", controladorLayout.Posicionar)
+		authRoutes.GET("/ambientes/:id/layout", controladorLayout.Ocupacao)
+		authRoutes.GET("/ambientes/:id/layout/svg", controladorLayout.PlantaBaixa)
+		authRoutes.PUT("/layout/:id", controladorLayout.Atualizar)
+		authRoutes.DELETE("/layout/:id", controladorLayout.Remover)
+
+		// Rotas de Levantamentos de PPFD (mapas de luz)
+		authRoutes.POST("/ambientes/:id/levantamentos-ppfd", controladorLevantamentoPPFD.Registrar)
+		authRoutes.GET("/ambientes/:id/levantamentos-ppfd", controladorLevantamentoPPFD.Listar)
+		authRoutes.GET("/levantamentos-ppfd/:id", controladorLevantamentoPPFD.Buscar)
+		authRoutes.GET("/levantamentos-ppfd/:id/comparar", controladorLevantamentoPPFD.Comparar)
+		authRoutes.GET("/levantamentos-ppfd/:id/mapa", controladorLevantamentoPPFD.Mapa)
+		authRoutes.DELETE("/levantamentos-ppfd/:id", controladorLevantamentoPPFD.Deletar)
+
+		// Rotas de Microclima (leituras de sensores por ambiente)
+		authRoutes.POST("/ambientes/:id/microclima", controladorMicroclima.RegistrarLeitura)
+		authRoutes.POST("/ambientes/:id/microclima/lote", controladorMicroclima.RegistrarLote)
+		authRoutes.GET("/ambientes/:id/microclima", controladorMicroclima.ListarLeituras)
+		authRoutes.GET("/ambientes/:id/microclima/agregado", controladorMicroclima.Agregar)
+		authRoutes.POST("/ambientes/:id/microclima/importar/line-protocol", controladorMicroclima.ImportarLineProtocol)
+		authRoutes.POST("/ambientes/:id/microclima/importar/csv", controladorMicroclima.ImportarCSV)
+
+		// Rotas de Fotoperíodo (programações de luz por ambiente)
+		authRoutes.POST("/ambientes/:id/fotoperiodos", controladorFotoperiodo.Criar)
+		authRoutes.GET("/ambientes/:id/fotoperiodos", controladorFotoperiodo.ListarHistorico)
+		authRoutes.GET("/ambientes/:id/fotoperiodos/vigente", controladorFotoperiodo.BuscarVigente)
+		authRoutes.POST("/ambientes/:id/fotoperiodos/virada", controladorFotoperiodo.Virar)
+		authRoutes.GET("/ambientes/:id/fotoperiodos/horas-luz", controladorFotoperiodo.HorasLuz)
+		authRoutes.GET("/ambientes/:id/fotoperiodos/sugestao", controladorFotoperiodo.Sugestao)
+		authRoutes.DELETE("/fotoperiodos/:id", controladorFotoperiodo.Deletar)
+
+		// Rotas de Equipamentos e Energia
+		authRoutes.POST("/ambientes/:id/equipamentos", controladorEnergia.CriarEquipamento)
+		authRoutes.GET("/ambientes/:id/equipamentos", controladorEnergia.ListarEquipamentos)
+		authRoutes.PUT("/equipamentos/:id", controladorEnergia.AtualizarEquipamento)
+		authRoutes.DELETE("/equipamentos/:id", controladorEnergia.DeletarEquipamento)
+		authRoutes.GET("/ambientes/:id/energia", controladorEnergia.ConsumoAmbiente)
+		authRoutes.POST("/energia/tarifas", controladorEnergia.CriarTarifa)
+		authRoutes.GET("/energia/tarifas", controladorEnergia.ListarTarifas)
+		authRoutes.DELETE("/energia/tarifas/:id", controladorEnergia.DeletarTarifa)
+		authRoutes.PUT("/energia/bandeiras/:competencia", controladorEnergia.DefinirBandeira)
+		authRoutes.GET("/energia/bandeiras", controladorEnergia.ListarBandeiras)
+
+		// Rotas de Localização, Sol e Clima dos ambientes externos
+		authRoutes.PUT("/ambientes/:id/localizacao", controladorClima.DefinirLocalizacao)
+		authRoutes.DELETE("/ambientes/:id/localizacao", controladorClima.RemoverLocalizacao)
+		authRoutes.GET("/ambientes/:id/sol", controladorClima.Sol)
+		authRoutes.POST("/ambientes/:id/clima/sincronizar", controladorClima.Sincronizar)
+		authRoutes.GET("/ambientes/:id/clima", controladorClima.ListarClima)
+		authRoutes.GET("/ambientes/:id/planejamento-safra", controladorPlanejamentoSafra.Planejar)
+
+		// Rotas de Tarefas de cuidado
+		authRoutes.POST("/tarefas", controladorTarefa.Criar)
+		authRoutes.GET("/tarefas", controladorTarefa.Listar)
+		authRoutes.GET("/tarefas/:id", controladorTarefa.BuscarPorID)
+		authRoutes.PUT("/tarefas/:id", controladorTarefa.Atualizar)
+		authRoutes.POST("/tarefas/:id/concluir", controladorTarefa.Concluir)
+		authRoutes.DELETE("/tarefas/:id", controladorTarefa.Deletar)
+
+		// Rotas de Cronogramas de cultivo
+		authRoutes.POST("/cronogramas", controladorCronogramaCultivo.Criar)
+		authRoutes.GET("/cronogramas", controladorCronogramaCultivo.Listar)
+		authRoutes.GET("/cronogramas/:id", controladorCronogramaCultivo.BuscarPorID)
+		authRoutes.PUT("/cronogramas/:id", controladorCronogramaCultivo.Atualizar)
+		authRoutes.DELETE("/cronogramas/:id", controladorCronogramaCultivo.Deletar)
+		authRoutes.POST("/cronogramas/:id/aplicar", controladorCronogramaCultivo.Aplicar)
+		authRoutes.GET("/cronogramas/:id/aplicacoes", controladorCronogramaCultivo.ListarAplicacoes)
+		authRoutes.DELETE("/aplicacoes-cronograma/:id", controladorCronogramaCultivo.RemoverAplicacao)
+
+		// Rotas de Calendário
+		authRoutes.POST("/calendario/token", controladorCalendario.GerarToken)
+		authRoutes.DELETE("/calendario/token", controladorCalendario.RevogarToken)
+		authRoutes.POST("/calendario/importar", controladorCalendario.Importar)
+
+		// Rotas de Lembretes e preferências de notificação
+		authRoutes.POST("/lembretes", controladorLembrete.Criar)
+		authRoutes.GET("/lembretes", controladorLembrete.Listar)
+		authRoutes.GET("/lembretes/:id", controladorLembrete.BuscarPorID)
+		authRoutes.PUT("/lembretes/:id", controladorLembrete.Atualizar)
+		authRoutes.DELETE("/lembretes/:id", controladorLembrete.Deletar)
+		authRoutes.POST("/lembretes/:id/adiar", controladorLembrete.Adiar)
+		authRoutes.POST("/lembretes/:id/lido", controladorLembrete.MarcarLido)
+		authRoutes.GET("/preferencias-notificacao", controladorLembrete.BuscarPreferencias)
+		authRoutes.PUT("/preferencias-notificacao", controladorLembrete.AtualizarPreferencias)
+
+		// Rotas da Central de notificações
+		authRoutes.GET("/notificacoes", controladorNotificacao.Listar)
+		authRoutes.GET("/notificacoes/nao-lidas", controladorNotificacao.ContarNaoLidas)
+		authRoutes.POST("/notificacoes/stream/ticket", controladorNotificacao.EmitirTicketStream)
+		authRoutes.POST("/notificacoes/lidas", controladorNotificacao.MarcarLidas)
+		authRoutes.POST("/notificacoes/:id/lida", controladorNotificacao.MarcarLida)
+
+		// Rotas de Regas e receitas de nutrientes
+		authRoutes.POST("/regas", controladorRega.Criar)
+		authRoutes.GET("/regas", controladorRega.Listar)
+		authRoutes.GET("/regas/:id", controladorRega.BuscarPorID)
+		authRoutes.PUT("/regas/:id", controladorRega.Atualizar)
+		authRoutes.DELETE("/regas/:id", controladorRega.Deletar)
+		authRoutes.GET(rotasPlantas+rotaPlantaPorID+"/regas/grafico", controladorRega.GraficoPlanta)
+		authRoutes.POST("/receitas-nutriente", controladorRega.CriarReceita)
+		authRoutes.GET("/receitas-nutriente", controladorRega.ListarReceitas)
+		authRoutes.GET("/receitas-nutriente/:id", controladorRega.BuscarReceita)
+		authRoutes.PUT("/receitas-nutriente/:id", controladorRega.AtualizarReceita)
+		authRoutes.DELETE("/receitas-nutriente/:id", controladorRega.DeletarReceita)
+
+		// Rotas de Tabelas de nutrição e calculadora de mistura
+		authRoutes.POST("/tabelas-nutricao", controladorTabelaNutricao.Criar)
+		authRoutes.GET("/tabelas-nutricao", controladorTabelaNutricao.Listar)
+		authRoutes.POST("/tabelas-nutricao/importar/json", controladorTabelaNutricao.ImportarJSON)
+		authRoutes.POST("/tabelas-nutricao/importar/csv", controladorTabelaNutricao.ImportarCSV)
+		authRoutes.GET("/tabelas-nutricao/:id", controladorTabelaNutricao.BuscarPorID)
+		authRoutes.PUT("/tabelas-nutricao/:id", controladorTabelaNutricao.Atualizar)
+		authRoutes.DELETE("/tabelas-nutricao/:id", controladorTabelaNutricao.Deletar)
+		authRoutes.POST("/tabelas-nutricao/:id/mistura", controladorTabelaNutricao.CalcularMistura)
+
+		// Rotas de Reservatórios
+		authRoutes.POST("/reservatorios", controladorReservatorio.Criar)
+		authRoutes.GET("/reservatorios", controladorReservatorio.Listar)
+		authRoutes.GET("/reservatorios/:id", controladorReservatorio.BuscarPorID)
+		authRoutes.PUT("/reservatorios/:id", controladorReservatorio.Atualizar)
+		authRoutes.DELETE("/reservatorios/:id", controladorReservatorio.Deletar)
+		authRoutes.POST("/reservatorios/:id/manutencoes", controladorReservatorio.RegistrarManutencao)
+		authRoutes.GET("/reservatorios/:id/manutencoes", controladorReservatorio.ListarManutencoes)
+		authRoutes.POST("/reservatorios/:id/leituras", controladorReservatorio.RegistrarLeitura)
+		authRoutes.GET("/reservatorios/:id/leituras", controladorReservatorio.ListarLeituras)
+		authRoutes.GET("/reservatorios/:id/deriva", controladorReservatorio.AnalisarDeriva)
+
+		// Rotas de Estoque
+		authRoutes.POST("/estoque/itens", controladorEstoque.CriarItem)
+		authRoutes.GET("/estoque/itens", controladorEstoque.ListarItens)
+		authRoutes.GET("/estoque/itens/:id", controladorEstoque.BuscarItem)
+		authRoutes.PUT("/estoque/itens/:id", controladorEstoque.AtualizarItem)
+		authRoutes.DELETE("/estoque/itens/:id", controladorEstoque.DeletarItem)
+		authRoutes.POST("/estoque/itens/:id/compras", controladorEstoque.RegistrarCompra)
+		authRoutes.POST("/estoque/itens/:id/consumos", controladorEstoque.RegistrarConsumo)
+		authRoutes.POST("/estoque/itens/:id/ajustes", controladorEstoque.Ajustar)
+		authRoutes.GET("/estoque/itens/:id/movimentacoes", controladorEstoque.ListarMovimentacoes)
+
+		// Rotas de Banco de sementes
+		authRoutes.POST("/sementes/pacotes", controladorSemente.CriarPacote)
+		authRoutes.GET("/sementes/pacotes", controladorSemente.ListarPacotes)
+		authRoutes.GET("/sementes/pacotes/:id", controladorSemente.BuscarPacote)
+		authRoutes.PUT("/sementes/pacotes/:id", controladorSemente.AtualizarPacote)
+		authRoutes.DELETE("/sementes/pacotes/:id", controladorSemente.DeletarPacote)
+		authRoutes.POST("/sementes/pacotes/:id/plantas", controladorSemente.Plantar)
+		authRoutes.POST("/sementes/pacotes/:id/germinacoes", controladorSemente.RegistrarGerminacao)
+		authRoutes.GET("/sementes/pacotes/:id/germinacoes", controladorSemente.ListarGerminacoes)
+		authRoutes.GET("/sementes/taxas-germinacao", controladorSemente.TaxasGerminacao)
+
+		// Rotas de Cruzamentos
+		authRoutes.POST("/cruzamentos", controladorCruzamento.Criar)
+		authRoutes.GET("/cruzamentos", controladorCruzamento.Listar)
+		authRoutes.GET("/cruzamentos/:id", controladorCruzamento.BuscarPorID)
+		authRoutes.DELETE("/cruzamentos/:id", controladorCruzamento.Deletar)
+		authRoutes.POST("/cruzamentos/:id/polinizacoes", controladorCruzamento.RegistrarPolinizacao)
+		authRoutes.GET("/cruzamentos/:id/polinizacoes", controladorCruzamento.ListarPolinizacoes)
+		authRoutes.POST("/cruzamentos/:id/lotes", controladorCruzamento.RegistrarLote)
+		authRoutes.GET("/cruzamentos/:id/lotes", controladorCruzamento.ListarLotes)
+
+		// Rotas de Vasos, Substratos e Transplantes
+		authRoutes.POST("/vasos", controladorVaso.Criar)
+		authRoutes.GET("/vasos", controladorVaso.Listar)
+		authRoutes.GET("/vasos/:id", controladorVaso.BuscarPorID)
+		authRoutes.PUT("/vasos/:id", controladorVaso.Atualizar)
+		authRoutes.DELETE("/vasos/:id", controladorVaso.Deletar)
+		authRoutes.POST("/substratos", controladorSubstrato.Criar)
+		authRoutes.GET("/substratos", controladorSubstrato.Listar)
+		authRoutes.GET("/substratos/:id", controladorSubstrato.BuscarPorID)
+		authRoutes.PUT("/substratos/:id", controladorSubstrato.Atualizar)
+		authRoutes.DELETE("/substratos/:id", controladorSubstrato.Deletar)
+		authRoutes.GET(rotasPlantas+rotaPlantaPorID+"/recipiente", controladorTransplante.BuscarRecipiente)
+		authRoutes.PUT(rotasPlantas+rotaPlantaPorID+"/recipiente", controladorTransplante.DefinirRecipiente)
+		authRoutes.POST(rotasPlantas+rotaPlantaPorID+"/transplantes", controladorTransplante.Transplantar)
+		authRoutes.GET(rotasPlantas+rotaPlantaPorID+"/transplantes", controladorTransplante.ListarTransplantes)
+		authRoutes.GET(rotasPlantas+rotaPlantaPorID+"/sugestao-rega", controladorTransplante.SugerirRega)
+
+		// Rotas de Alertas de ambiente
+		authRoutes.POST("/ambientes/:id/regras-alerta", controladorAlerta.CriarRegra)
+		authRoutes.GET("/ambientes/:id/regras-alerta", controladorAlerta.ListarRegras)
+		authRoutes.GET("/regras-alerta/:id", controladorAlerta.BuscarRegra)
+		authRoutes.PUT("/regras-alerta/:id", controladorAlerta.AtualizarRegra)
+		authRoutes.DELETE("/regras-alerta/:id", controladorAlerta.DeletarRegra)
+		authRoutes.GET("/alertas", controladorAlerta.ListarAlertas)
+		authRoutes.POST("/alertas/:id/reconhecer", controladorAlerta.Reconhecer)
+		authRoutes.POST("/alertas/:id/resolver", controladorAlerta.Resolver)
+
+		// Rotas de Atuadores
+		authRoutes.POST("/atuadores/comandos", controladorComando.Publicar)
+
 		// Rotas de Genetica
 		authRoutes.POST("/geneticas", controladorGenetica.Criar)
 		authRoutes.GET("/geneticas", controladorGenetica.Listar)
+		authRoutes.POST("/geneticas/importar/json", controladorGenetica.ImportarJSON)
+		authRoutes.POST("/geneticas/importar/csv", controladorGenetica.ImportarCSV)
+		authRoutes.GET("/geneticas/exportar", controladorGenetica.Exportar)
+		authRoutes.GET("/geneticas/duplicadas", controladorGenetica.Duplicadas)
 		authRoutes.GET("/geneticas/:id", controladorGenetica.BuscarPorID)
 		authRoutes.PUT("/geneticas/:id", controladorGenetica.Atualizar)
 		authRoutes.DELETE("/geneticas/:id", controladorGenetica.Deletar)
+		authRoutes.POST("/geneticas/:id/mesclar", controladorGenetica.Mesclar)
+		authRoutes.GET("/geneticas/:id/pedigree", controladorGenetica.Pedigree)
 
 		// Rotas de MeioCultivo
 		authRoutes.POST("/meios-cultivos", controladorMeioCultivo.Criar)
@@ -118,6 +533,10 @@ func NewServer(db *db_infra.Database) *Server {
 			// Rotas de RegistroDiario aninhadas
 			diarioCultivoRoutes.POST("/registros", controladorRegistroDiario.Create)
 			diarioCultivoRoutes.GET("/registros", controladorRegistroDiario.List)
+
+			diarioCultivoRoutes.GET("/energia", controladorEnergia.ConsumoDiario)
+			diarioCultivoRoutes.GET("/regas/resumo", controladorRega.ResumoCiclo)
+			diarioCultivoRoutes.GET("/custos", controladorEstoque.CustoCiclo)
 		}
 
 		// Rotas de Usuario (autenticadas)
@@ -127,5 +546,38 @@ func NewServer(db *db_infra.Database) *Server {
 		authRoutes.DELETE(rotaUsuarioPorID, controladorUsuario.Deletar)
 	}
 
-	return &Server{Router: router}
+	return &Server{Router: router, Agendador: agendadorRotinas, ponteMQTT: ponteMQTT, notificacoes: controladorNotificacao}
+}
+
+// duracaoConfig interpreta uma duração da configuração, usando o padrão se estiver inválida
+func duracaoConfig(nome, valor string, padrao time.Duration) time.Duration {
+	duracao, err := time.ParseDuration(valor)
+	if err != nil || duracao <= 0 {
+		logrus.Warnf("%s inválido (%q), usando %s", nome, valor, padrao)
+		return padrao
+	}
+	return duracao
+}
+
+// diasConfig interpreta uma quantidade de dias da configuração, usando o padrão se estiver inválida
+func diasConfig(nome, valor string, padrao int) int {
+	dias, err := strconv.Atoi(valor)
+	if err != nil || dias <= 0 {
+		logrus.Warnf("%s inválido (%q), usando %d", nome, valor, padrao)
+		return padrao
+	}
+	return dias
+}
+
+// Encerrar libera as conexões mantidas pelo servidor além do HTTP (ex.: broker MQTT)
+func (s *Server) Encerrar() {
+	if s.ponteMQTT != nil {
+		s.ponteMQTT.Desconectar()
+	}
+}
+
+// EncerrarStreams encerra as conexões HTTP de longa duração (streams de notificações); deve ser
+// registrada com RegisterOnShutdown para o Shutdown não esperar por elas
+func (s *Server) EncerrarStreams() {
+	s.notificacoes.EncerrarStreams()
 }
@@ -72,7 +72,7 @@ func TestMain(m *testing.M) {
 
 	// Criar o servidor Gin para testes
 	gin.SetMode(gin.TestMode)
-	testServer = server.NewServer(testDB)
+	testServer = server.NewServer(testDB, cfg)
 
 	// Rodar os testes
 	code := m.Run()
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"gitea.paulojamil.dev.br/paulojamil.dev.br/cultivo-api-go/internal/utils"
@@ -58,3 +59,26 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AuthStreamMiddleware autentica como o AuthMiddleware, mas sem o cabeçalho Authorization aceita
+// um ticket emitido por TicketsStream no parâmetro ticket. O EventSource do navegador não envia
+// cabeçalhos, e o ticket de uso único evita expor o token JWT nos logs de acesso.
+func AuthStreamMiddleware(tickets *TicketsStream) gin.HandlerFunc {
+	autenticar := AuthMiddleware()
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			autenticar(c)
+			return
+		}
+
+		usuarioID, ok := tickets.Consumir(c.Query("ticket"))
+		if !ok {
+			logrus.WithField("path", c.Request.URL.Path).Warn("Ticket de stream ausente, expirado ou já usado")
+			utils.RespondWithError(c, http.StatusUnauthorized, "Ticket de stream inválido ou expirado", nil)
+			c.Abort()
+			return
+		}
+		c.Set("userID", strconv.FormatUint(uint64(usuarioID), 10))
+		c.Next()
+	}
+}
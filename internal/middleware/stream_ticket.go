@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TicketsStream emite tickets de uso único e curta duração para abrir streams SSE. O EventSource
+// do navegador não envia cabeçalhos, e um token JWT na URL acabaria nos logs de acesso.
+type TicketsStream struct {
+	validade time.Duration
+	mu       sync.Mutex
+	tickets  map[string]ticketStream
+}
+
+type ticketStream struct {
+	usuarioID uint
+	expiraEm  time.Time
+}
+
+func NewTicketsStream(validade time.Duration) *TicketsStream {
+	return &TicketsStream{validade: validade, tickets: make(map[string]ticketStream)}
+}
+
+// Emitir gera um ticket para o usuário, válido até a data retornada
+func (t *TicketsStream) Emitir(usuarioID uint) (string, time.Time, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", time.Time{}, fmt.Errorf("falha ao gerar ticket do stream: %w", err)
+	}
+	ticket := hex.EncodeToString(bytes)
+	agora := time.Now()
+	expiraEm := agora.Add(t.validade)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// descarta os tickets vencidos que nunca foram usados
+	for chave, emitido := range t.tickets {
+		if !agora.Before(emitido.expiraEm) {
+			delete(t.tickets, chave)
+		}
+	}
+	t.tickets[ticket] = ticketStream{usuarioID: usuarioID, expiraEm: expiraEm}
+	return ticket, expiraEm, nil
+}
+
+// Consumir retorna o usuário do ticket e o invalida; tickets vencidos ou já usados são recusados
+func (t *TicketsStream) Consumir(ticket string) (uint, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	emitido, ok := t.tickets[ticket]
+	if !ok {
+		return 0, false
+	}
+	delete(t.tickets, ticket)
+	if !time.Now().Before(emitido.expiraEm) {
+		return 0, false
+	}
+	return emitido.usuarioID, true
+}